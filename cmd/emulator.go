@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/robmorgan/infraspec/pkg/embedded"
+)
+
+var emulatorCmd = &cobra.Command{
+	Use:   "emulator",
+	Short: "Work with the embedded AWS emulator",
+	Long:  `Work with the embedded AWS emulator that runs features against the virtual cloud.`,
+}
+
+var emulatorSelftestCmd = &cobra.Command{
+	Use:   "selftest",
+	Short: "Check that every emulator service responds",
+	Long: `Start the embedded emulator and send a minimal read-only request, like ListBuckets or
+ListTables, to each of its services, checking for a 200 response that parses.
+
+Services without a probe are reported as skipped. The command fails if any service fails,
+so it can be used as a smoke check in CI.`,
+	Args: cobra.NoArgs,
+	RunE: runEmulatorSelftest,
+}
+
+func init() {
+	emulatorCmd.AddCommand(emulatorSelftestCmd)
+	RootCmd.AddCommand(emulatorCmd)
+}
+
+func runEmulatorSelftest(cmd *cobra.Command, args []string) error {
+	// The emulator logs every request, which would bury the results
+	if !verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	emu := embedded.New()
+	if err := emu.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start embedded emulator: %w", err)
+	}
+	defer emu.Stop(context.Background()) //nolint:errcheck
+
+	results := emu.SelfTest(ctx)
+
+	failed := 0
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0) //nolint:mnd
+	fmt.Fprintln(w, "SERVICE\tACTION\tRESULT")
+	for _, result := range results {
+		status := "ok"
+		switch {
+		case result.Skipped():
+			status = "skipped"
+		case result.Err != nil:
+			status = "fail: " + result.Err.Error()
+			failed++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", result.Service, result.Action, status)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d services failed the self-test", failed, len(results))
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -20,11 +21,27 @@ import (
 )
 
 var (
-	verbose  bool
-	format   string
-	liveMode bool // If true, run against real AWS instead of embedded emulator
-	parallel int  // Number of features to run in parallel (0 = sequential)
-	timeout  int  // Per-feature timeout in seconds (0 = no timeout)
+	verbose        bool
+	format         string
+	liveMode       bool // If true, run against real AWS instead of embedded emulator
+	parallel       int  // Number of features to run in parallel (0 = sequential)
+	featureTimeout int  // Per-feature timeout in seconds (0 = no timeout)
+	failFast       bool // If true, stop the run at the first failed scenario
+	strict         bool // If true, undefined and pending steps fail the run
+
+	runTimeout time.Duration // If set, how long the whole run can take before it's canceled
+
+	scenarioName string // If set, only the scenarios with this name are run
+
+	debugOnFailure bool // If true, failed assertions include a snapshot of the emulator state
+
+	validateResponses bool // If true, the embedded emulator checks its responses against generated SDK types
+
+	listenAddress string // If set, the address the embedded emulator listens on, overriding the config
+
+	logLevel string // If set, the emulator's per-service log levels, overriding the config
+
+	resultsFile string // If set, where a JSON report of every assertion's outcome is written
 
 	RootCmd = &cobra.Command{
 		Use:     "infraspec [features...]",
@@ -35,6 +52,14 @@ var (
 		Run: func(cmd *cobra.Command, args []string) {
 			startTime := time.Now()
 
+			// Exit once the deferred cleanup, like stopping the emulator, has run
+			exitCode := 0
+			defer func() {
+				if exitCode != 0 {
+					os.Exit(exitCode)
+				}
+			}()
+
 			// Default to embedded emulator (virtual cloud) unless --live is specified
 			useVirtualCloud := !liveMode
 
@@ -49,20 +74,74 @@ var (
 				cfg.ParallelMode = true
 			}
 
+			if failFast {
+				cfg.FailFast = true
+			}
+
+			if strict {
+				cfg.Strict = true
+			}
+
+			cfg.ScenarioName = scenarioName
+
+			if debugOnFailure {
+				cfg.DebugOnFailure = true
+			}
+
 			if verbose {
 				cfg.Verbose = true
 				config.Logging.Logger.Debug("Verbose mode enabled")
 			}
 
+			if listenAddress != "" {
+				cfg.Emulator.Listen = listenAddress
+			}
+
+			if logLevel != "" {
+				cfg.Emulator.LogLevel = logLevel
+			}
+
 			// Start embedded emulator if not in live mode
 			var emu *embedded.Emulator
 			if !liveMode {
 				emu = embedded.New()
+				if validateResponses {
+					emu.EnableResponseValidation()
+				}
+				if err := emu.SetLogLevels(cfg.Emulator.LogLevel); err != nil {
+					fmt.Printf("Invalid emulator log level: %v\n", err)
+					return
+				}
+				emu.SetListenAddress(cfg.Emulator.Listen)
+				emu.SetSQSMaxInFlightMessages(cfg.Emulator.SQSMaxInFlightMessages)
+				emu.SetS3LocationStyle(cfg.Emulator.S3LocationStyle)
+				emu.SetCORS(embedded.CORSConfig{
+					AllowedOrigins: cfg.Emulator.CORS.AllowedOrigins,
+					AllowedMethods: cfg.Emulator.CORS.AllowedMethods,
+					AllowedHeaders: cfg.Emulator.CORS.AllowedHeaders,
+				})
+				seedResources := make([]embedded.SeedResource, 0, len(cfg.Emulator.Resources))
+				for _, resource := range cfg.Emulator.Resources {
+					seedResources = append(seedResources, embedded.SeedResource{
+						Service: resource.Service,
+						Action:  resource.Action,
+						Params:  resource.Params,
+					})
+				}
+				emu.SetSeedResources(seedResources)
+				fixtures, err := loadFixtures(cfg.Emulator.Fixtures)
+				if err != nil {
+					fmt.Printf("Failed to load emulator fixtures: %v\n", err)
+					return
+				}
+				emu.SetFixtures(fixtures)
+				emu.SetInjectionRules(injectionRules(cfg.Emulator.Injections))
 				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 				defer cancel()
 
 				if err := emu.Start(ctx); err != nil {
 					fmt.Printf("Failed to start embedded emulator: %v\n", err)
+					exitCode = 1
 					return
 				}
 				defer func() {
@@ -96,9 +175,25 @@ var (
 			// Discover all feature files from provided paths
 			var featureFiles []string
 			for _, arg := range args {
+				// Feature bundles are run from a temporary copy of their contents
+				if runner.IsFeatureBundle(arg) {
+					dir, cleanup, err := runner.ExtractFeatureBundle(arg)
+					if err != nil {
+						fmt.Printf("Failed to discover features: %v\n", err)
+						exitCode = 1
+						return
+					}
+					defer cleanup()
+					arg = dir
+				}
+
+				// Returning, rather than exiting, lets the deferred cleanups remove extracted
+				// bundles and stop the emulator
 				files, err := runner.DiscoverFeatureFiles(arg)
 				if err != nil {
-					log.Fatalf("Failed to discover features: %v", err)
+					fmt.Printf("Failed to discover features: %v\n", err)
+					exitCode = 1
+					return
 				}
 				featureFiles = append(featureFiles, files...)
 			}
@@ -106,25 +201,72 @@ var (
 			// Remove duplicates
 			featureFiles = runner.UniqueStrings(featureFiles)
 
+			// Only run the features with the named scenario
+			if cfg.ScenarioName != "" {
+				featureFiles, err = runner.FilterFeaturesByScenario(featureFiles, cfg.ScenarioName)
+				if err != nil {
+					fmt.Printf("Failed to discover features: %v\n", err)
+					exitCode = 1
+					return
+				}
+			}
+
+			// Bound the whole run. When it times out, the running features are canceled like on
+			// an interrupt, destroying what they applied.
+			ctx, cancel := context.WithCancel(context.Background())
+			if runTimeout > 0 {
+				ctx, cancel = context.WithTimeout(context.Background(), runTimeout)
+			}
+			defer cancel()
+			stop := context.AfterFunc(ctx, func() {
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					fmt.Printf("\nRun timed out after %s, canceling tests...\n", runTimeout)
+				}
+			})
+			defer stop()
+
+			var results *runner.ResultsCollector
+			if resultsFile != "" {
+				results = runner.NewResultsCollector()
+			}
+
+			var failed bool
 			if parallel > 0 && len(featureFiles) > 1 {
 				// Parallel execution mode
-				runParallel(cfg, tel, featureFiles, startTime)
+				failed = runParallel(ctx, cfg, tel, featureFiles, startTime, results)
 			} else {
 				// Sequential execution mode
-				runSequential(cfg, tel, featureFiles, startTime)
+				failed = runSequential(ctx, cfg, tel, featureFiles, startTime, results)
+			}
+
+			if results != nil {
+				if err := results.WriteFile(resultsFile); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					failed = true
+				}
+			}
+
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				fmt.Printf("Error: run timed out after %s\n", runTimeout)
+				failed = true
+			}
+			if failed {
+				exitCode = 1
 			}
 		},
 	}
 )
 
-// runParallel executes feature files in parallel.
-func runParallel(cfg *config.Config, tel *telemetry.Client, featureFiles []string, startTime time.Time) {
+// runParallel executes feature files in parallel, returning whether any of them failed.
+func runParallel(ctx context.Context, cfg *config.Config, tel *telemetry.Client, featureFiles []string, startTime time.Time, assertions *runner.ResultsCollector) bool {
 	parallelCfg := runner.ParallelConfig{
 		MaxWorkers: parallel,
-		Timeout:    time.Duration(timeout) * time.Second,
+		Timeout:    time.Duration(featureTimeout) * time.Second,
+		FailFast:   failFast,
 	}
 
 	pr := runner.NewParallelRunner(cfg, parallelCfg)
+	pr.SetResultsCollector(assertions)
 
 	// Setup graceful shutdown
 	sigChan := make(chan os.Signal, 1)
@@ -142,10 +284,10 @@ func runParallel(cfg *config.Config, tel *telemetry.Client, featureFiles []strin
 		fmt.Printf("\nRunning %d feature(s) with %d worker(s)...\n\n", len(featureFiles), parallel)
 	}
 
-	ctx := context.Background()
 	results, err := pr.RunParallel(ctx, featureFiles, format)
 	if err != nil {
-		log.Fatalf("Parallel execution failed: %v", err)
+		fmt.Printf("Parallel execution failed: %v\n", err)
+		return true
 	}
 
 	// Print summary
@@ -164,41 +306,125 @@ func runParallel(cfg *config.Config, tel *telemetry.Client, featureFiles []strin
 		}
 	}
 
-	if results.FailedFeatures > 0 {
-		os.Exit(1)
-	}
+	return results.FailedFeatures > 0
 }
 
-// runSequential executes feature files sequentially (original behavior).
-func runSequential(cfg *config.Config, tel *telemetry.Client, featureFiles []string, startTime time.Time) {
+// runSequential executes feature files sequentially (original behavior), returning whether
+// any of them failed.
+func runSequential(ctx context.Context, cfg *config.Config, tel *telemetry.Client, featureFiles []string, startTime time.Time, assertions *runner.ResultsCollector) bool {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Setup graceful shutdown, interrupting the running feature's IaC commands
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		fmt.Println("\nReceived interrupt, canceling tests...")
+		cancel()
+	}()
+
 	var failed bool
+	var results []runner.FeatureResult
 	for _, featureFile := range featureFiles {
+		if ctx.Err() != nil {
+			failed = true
+			break
+		}
+
 		featureStart := time.Now()
 		tel.TrackTestRun(featureFile)
 
-		if err := runner.New(cfg).RunWithFormat(featureFile, format); err != nil {
+		result := runner.FeatureResult{FeaturePath: featureFile, Status: runner.StatusPassed}
+		featureRunner := runner.New(cfg)
+		featureRunner.SetResultsCollector(assertions)
+		if err := featureRunner.RunWithContext(ctx, featureFile, format); err != nil {
+			result.Status = runner.StatusFailed
+			result.Error = err
+			result.Duration = time.Since(featureStart)
+			results = append(results, result)
+
 			tel.TrackTestFailed(featureFile, time.Since(featureStart), err.Error())
 			log.Printf("Test execution failed for %s: %v", featureFile, err)
 			failed = true
+			if cfg.FailFast {
+				break
+			}
 			continue
 		}
+		result.Duration = time.Since(featureStart)
+		results = append(results, result)
 		tel.TrackTestComplete(featureFile, time.Since(featureStart), 0)
 	}
 
-	if failed {
-		os.Exit(1)
+	// The progress formatter summarizes each feature, so summarize the whole run after them
+	if format == runner.ProgressFormat && len(results) > 1 {
+		runner.PrintParallelResults(runner.AggregateResults(results, time.Since(startTime)))
+	}
+
+	return failed
+}
+
+// loadFixtures reads the response body of each configured fixture from its file
+func loadFixtures(configured []config.Fixture) ([]embedded.Fixture, error) {
+	fixtures := make([]embedded.Fixture, 0, len(configured))
+	for _, fixture := range configured {
+		var body []byte
+		if fixture.File != "" {
+			var err error
+			body, err = os.ReadFile(fixture.File)
+			if err != nil {
+				return nil, fmt.Errorf("fixture for %s: %w", fixture.Action, err)
+			}
+		}
+
+		fixtures = append(fixtures, embedded.Fixture{
+			Action:      fixture.Action,
+			BodyPattern: fixture.BodyMatches,
+			PathPattern: fixture.PathMatches,
+			StatusCode:  fixture.Status,
+			Headers:     fixture.Headers,
+			Body:        body,
+		})
+	}
+	return fixtures, nil
+}
+
+// injectionRules converts the configured injections to the emulator's injection rules
+func injectionRules(configured []config.Injection) []embedded.InjectionRule {
+	rules := make([]embedded.InjectionRule, 0, len(configured))
+	for _, injection := range configured {
+		rules = append(rules, embedded.InjectionRule{
+			Action:      injection.Action,
+			BodyPattern: injection.BodyMatches,
+			PathPattern: injection.PathMatches,
+			Latency:     injection.Latency,
+			StatusCode:  injection.Status,
+			ErrorCode:   injection.ErrorCode,
+			Message:     injection.Message,
+		})
 	}
+	return rules
 }
 
 func init() {
 	// Global flags
 	RootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
-	RootCmd.PersistentFlags().StringVarP(&format, "format", "f", "default", "output format (default, text, pretty, junit, cucumber)")
+	RootCmd.PersistentFlags().StringVarP(&format, "format", "f", "default", "output format (default, text, pretty, progress, junit, cucumber)")
 	RootCmd.PersistentFlags().BoolVar(&liveMode, "live", false, "run tests against real AWS (default: uses embedded virtual cloud)")
+	RootCmd.PersistentFlags().BoolVar(&failFast, "fail-fast", false, "stop the run at the first failed scenario")
+	RootCmd.PersistentFlags().BoolVar(&strict, "strict", false, "fail the run when a step is undefined or pending")
+	RootCmd.PersistentFlags().StringVar(&scenarioName, "scenario", "", "only run the scenarios with this name")
+	RootCmd.PersistentFlags().BoolVar(&debugOnFailure, "debug-on-failure", false, "include a snapshot of the emulator state for the resource in failed assertions")
+	RootCmd.PersistentFlags().BoolVar(&validateResponses, "validate-responses", false, "log a warning when an emulator response can't be unmarshaled into its generated response type")
+	RootCmd.PersistentFlags().StringVar(&listenAddress, "listen", "", "address the embedded emulator listens on, host:port or unix:///path/to/socket (default: a dynamic port on 127.0.0.1)")
+	RootCmd.PersistentFlags().StringVar(&resultsFile, "results-file", "", "write the outcome of every assertion to this file as JSON")
+	RootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "", "emulator log level per service, e.g. s3=debug,ec2=warn (default: info)")
 
 	// Parallel execution flags
 	RootCmd.PersistentFlags().IntVarP(&parallel, "parallel", "p", 0, "number of features to run in parallel (0 = sequential)")
-	RootCmd.PersistentFlags().IntVar(&timeout, "timeout", 0, "per-feature timeout in seconds (0 = no timeout)")
+	RootCmd.PersistentFlags().IntVar(&featureTimeout, "feature-timeout", 0, "per-feature timeout in seconds when running in parallel (0 = no timeout)")
+	RootCmd.PersistentFlags().DurationVar(&runTimeout, "timeout", 0, "cancel the whole run after this long, e.g. 30m (0 = no timeout)")
 
 	RootCmd.SetVersionTemplate(`{{printf "%s version %s\n" .Name .Version}}`)
 }
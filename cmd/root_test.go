@@ -2,11 +2,16 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/robmorgan/infraspec/internal/config"
+	"github.com/robmorgan/infraspec/internal/telemetry"
 )
 
 func TestRootCommand(t *testing.T) {
@@ -63,8 +68,26 @@ func TestParallelFlags(t *testing.T) {
 	assert.Equal(t, "0", parallelFlag.DefValue)
 	assert.Equal(t, "p", parallelFlag.Shorthand)
 
-	// Check timeout flag exists with correct default
+	// Check feature timeout flag exists with correct default
+	featureTimeoutFlag := RootCmd.PersistentFlags().Lookup("feature-timeout")
+	assert.NotNil(t, featureTimeoutFlag)
+	assert.Equal(t, "0", featureTimeoutFlag.DefValue)
+}
+
+func TestTimeoutFlag(t *testing.T) {
+	// The run timeout is a duration, off by default
 	timeoutFlag := RootCmd.PersistentFlags().Lookup("timeout")
 	assert.NotNil(t, timeoutFlag)
-	assert.Equal(t, "0", timeoutFlag.DefValue)
+	assert.Equal(t, "duration", timeoutFlag.Value.Type())
+	assert.Equal(t, "0s", timeoutFlag.DefValue)
+}
+
+func TestRunSequential_TimedOutRunFails(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	// The features left when the run times out aren't run, failing the run
+	tel := telemetry.New(telemetry.Config{Enabled: false})
+	assert.True(t, runSequential(ctx, &config.Config{}, tel, []string{"missing.feature"}, time.Now(), nil))
 }
@@ -0,0 +1,19 @@
+output "account_id" {
+  description = "The AWS account ID of the caller"
+  value       = data.aws_caller_identity.current.account_id
+}
+
+output "caller_arn" {
+  description = "The ARN of the caller"
+  value       = data.aws_caller_identity.current.arn
+}
+
+output "region" {
+  description = "The name of the current region"
+  value       = data.aws_region.current.name
+}
+
+output "first_availability_zone" {
+  description = "The first available Availability Zone in the region"
+  value       = data.aws_availability_zones.available.names[0]
+}
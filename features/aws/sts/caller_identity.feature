@@ -0,0 +1,13 @@
+Feature: Caller Identity and Region Data Sources
+  As a DevOps engineer
+  I want modules that read the caller identity and region to work in the virtual cloud
+  So that I can test them without making real AWS calls
+
+  Scenario: Resolve the caller identity, region, and availability zones
+    Given I have a Terraform configuration in "../../../examples/aws/sts/caller-identity"
+    And I set the variable "region" to "us-east-1"
+    When I run Terraform apply
+    Then the "account_id" output is "123456789012"
+    And the output "caller_arn" should contain "arn:aws:iam::123456789012:"
+    And the "region" output is "us-east-1"
+    And the "first_availability_zone" output is "us-east-1a"
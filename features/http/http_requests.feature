@@ -72,3 +72,18 @@ Feature: HTTP Requests
     Then the HTTP response status should be 200
     And the HTTP response should be valid JSON
     And the HTTP response should contain "slideshow"
+
+  Scenario: Test response time
+    Given I have a HTTP endpoint at "http://localhost:9000/get"
+    When I make a GET request
+    Then the HTTP response status should be 200
+    And the HTTP response should be received within 2000 ms
+
+  Scenario: Test cookies are sent on later requests
+    Given I enable HTTP cookies
+    And I have a HTTP endpoint at "http://localhost:9000/response-headers?Set-Cookie=session%3Dabc123"
+    When I make a GET request
+    Then the HTTP response should set the cookie "session" to "abc123"
+    Given I have a HTTP endpoint at "http://localhost:9000/cookies"
+    When I make a GET request
+    Then the HTTP response should contain "abc123"
@@ -3,9 +3,12 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/pelletier/go-toml/v2"
 	"github.com/spf13/viper"
 	"go.uber.org/zap/zapcore"
 	yaml "gopkg.in/yaml.v3"
@@ -25,7 +28,86 @@ type Config struct {
 	Debug           bool             `yaml:"debug"`   // Enable debug mode
 	Telemetry       TelemetryConfig  `yaml:"telemetry"`
 	VirtualCloud    bool             `yaml:"virtual_cloud"`
-	ParallelMode    bool             `yaml:"-"` // Runtime flag for parallel execution, not persisted
+	SoftAssertions  bool             `yaml:"soft_assertions"`  // Collect failed assertions in every scenario, as if tagged @soft-assertions
+	ParallelMode    bool             `yaml:"-"`                // Runtime flag for parallel execution, not persisted
+	FailFast        bool             `yaml:"-"`                // Runtime flag to stop the run at the first failed scenario
+	Strict          bool             `yaml:"-"`                // Runtime flag to fail the run on undefined or pending steps
+	ScenarioName    string           `yaml:"-"`                // Runtime flag to run only the scenarios with this name
+	DebugOnFailure  bool             `yaml:"debug_on_failure"` // Attach a snapshot of the emulator state to failed assertions
+	Emulator        EmulatorConfig   `yaml:"emulator"`
+}
+
+// EmulatorConfig configures the embedded emulator
+type EmulatorConfig struct {
+	// Listen is the address the emulator listens on, a TCP host:port or a Unix domain socket
+	// like "unix:///tmp/infraspec.sock". Defaults to a dynamic port on 127.0.0.1.
+	Listen string `yaml:"listen"`
+
+	// SQSMaxInFlightMessages is how many messages an SQS queue can have in flight before
+	// receives and sends fail with OverLimit. Defaults to the AWS quota of 120,000.
+	SQSMaxInFlightMessages int `yaml:"sqs_max_in_flight_messages"`
+
+	// S3LocationStyle is how the Location header of S3 CreateBucket responses addresses the
+	// new bucket, "path" (the default) or "virtual-hosted"
+	S3LocationStyle string `yaml:"s3_location_style"`
+
+	// LogLevel sets the level each service's messages are logged at, as a comma-separated list
+	// of service=level pairs like "s3=debug,ec2=warn". A level without a service sets the level of
+	// every other service, which defaults to info.
+	LogLevel string `yaml:"log_level"`
+
+	// CORS configures the CORS headers the emulator sends browser clients. By default any
+	// origin can call it with any headers.
+	CORS CORSConfig `yaml:"cors"`
+
+	// Resources are created when the emulator starts, before any scenario runs
+	Resources []SeedResource `yaml:"resources"`
+
+	// Fixtures are canned responses the emulator returns instead of handling the requests
+	// they match
+	Fixtures []Fixture `yaml:"fixtures"`
+
+	// Injections fail or delay the requests they match, e.g. to test how infrastructure
+	// code copes with throttling
+	Injections []Injection `yaml:"injections"`
+}
+
+// CORSConfig lists the origins, methods and headers browser clients can call the emulator
+// with. An empty list allows all of them.
+type CORSConfig struct {
+	AllowedOrigins []string `yaml:"allowed_origins"`
+	AllowedMethods []string `yaml:"allowed_methods"`
+	AllowedHeaders []string `yaml:"allowed_headers"`
+}
+
+// Fixture is a canned response, read from a file, for requests of an action whose body and
+// path match optional regular expressions
+type Fixture struct {
+	Action      string            `yaml:"action"`
+	BodyMatches string            `yaml:"body_matches"`
+	PathMatches string            `yaml:"path_matches"`
+	Status      int               `yaml:"status"`
+	File        string            `yaml:"file"`
+	Headers     map[string]string `yaml:"headers"`
+}
+
+// Injection fails or delays requests of an action whose body and path match optional
+// regular expressions
+type Injection struct {
+	Action      string        `yaml:"action"`
+	BodyMatches string        `yaml:"body_matches"`
+	PathMatches string        `yaml:"path_matches"`
+	Latency     time.Duration `yaml:"latency"`
+	Status      int           `yaml:"status"`
+	ErrorCode   string        `yaml:"error_code"`
+	Message     string        `yaml:"message"`
+}
+
+// SeedResource is a resource the emulator creates by calling an action of a service
+type SeedResource struct {
+	Service string                 `yaml:"service"`
+	Action  string                 `yaml:"action"`
+	Params  map[string]interface{} `yaml:"params"`
 }
 
 // StepDefinition defines a mapping between Gherkin steps and actions
@@ -64,28 +146,43 @@ type RetryConfig struct {
 	RetryableErrors []string      `yaml:"retryable_errors"`
 }
 
-const defaultConfigPath = "infraspec.yaml"
+// defaultConfigPaths are the config files LoadConfig looks for, in order, when no path is given
+var defaultConfigPaths = []string{"infraspec.yaml", "infraspec.yml", "infraspec.toml", "infraspec.json"}
 
 var currentConfig *Config
 
 // LoadConfig loads configuration from disk, applying default values and overrides from
-// environment variables and the virtual cloud CLI flag. If the config file is missing,
+// environment variables and the virtual cloud CLI flag. The file is parsed as YAML, TOML or
+// JSON by its extension. Without a path, the first of infraspec.yaml, infraspec.yml,
+// infraspec.toml and infraspec.json that exists is used. If the config file is missing,
 // only the defaults are used.
 func LoadConfig(path string, virtualCloudFlag bool) (*Config, error) {
 	if path == "" {
-		path = defaultConfigPath
+		path = findDefaultConfigPath()
 	}
 
 	v := viper.New()
 	v.SetConfigFile(path)
-	v.SetConfigType("yaml")
+	v.SetConfigType(configFormat(path))
 	applyDefaults(v)
 
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	v.AutomaticEnv()
 	_ = v.BindEnv("virtual_cloud", UseInfraspecVirtualCloudEnvVar)
 
+	var node *yaml.Node
 	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		node, err = parseConfigNode(path, data)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateSchema(node); err != nil {
+			return nil, fmt.Errorf("invalid config file %s:\n%w", path, err)
+		}
 		if err := v.ReadInConfig(); err != nil {
 			return nil, err
 		}
@@ -93,11 +190,20 @@ func LoadConfig(path string, virtualCloudFlag bool) (*Config, error) {
 		return nil, err
 	}
 
+	// Decode by the yaml tags, so keys like soft_assertions map onto their fields
 	var cfg Config
-	if err := v.Unmarshal(&cfg); err != nil {
+	if err := v.Unmarshal(&cfg, func(dc *mapstructure.DecoderConfig) { dc.TagName = "yaml" }); err != nil {
 		return nil, err
 	}
 
+	// Viper lowercases map keys, so read the seed resources from the file directly to keep the
+	// case of their params
+	if node != nil {
+		if err := loadSeedResources(node, &cfg); err != nil {
+			return nil, err
+		}
+	}
+
 	// Apply virtualCloudFlag after unmarshaling to ensure it overrides config file
 	if virtualCloudFlag {
 		cfg.VirtualCloud = true
@@ -114,6 +220,70 @@ func Current() *Config {
 	return currentConfig
 }
 
+// findDefaultConfigPath returns the first default config file that exists, or
+// infraspec.yaml if none do
+func findDefaultConfigPath() string {
+	for _, path := range defaultConfigPaths {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return defaultConfigPaths[0]
+}
+
+// configFormat returns the format of a config file by its extension, "toml", "json" or
+// "yaml" for any other extension
+func configFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		return "toml"
+	case ".json":
+		return "json"
+	default:
+		return "yaml"
+	}
+}
+
+// parseConfigNode parses a config file into a YAML node, so files of every format can be
+// validated and decoded the same way. It returns nil for an empty file. JSON is parsed as
+// YAML, which keeps the line numbers of its values; TOML is converted, so its nodes don't
+// have any.
+func parseConfigNode(path string, data []byte) (*yaml.Node, error) {
+	if configFormat(path) == "toml" {
+		var values map[string]interface{}
+		if err := toml.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("invalid TOML in %s: %w", path, err)
+		}
+		var node yaml.Node
+		if err := node.Encode(values); err != nil {
+			return nil, err
+		}
+		return &node, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid %s in %s: %w", strings.ToUpper(configFormat(path)), path, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	return doc.Content[0], nil
+}
+
+func loadSeedResources(node *yaml.Node, cfg *Config) error {
+	var file struct {
+		Emulator struct {
+			Resources []SeedResource `yaml:"resources"`
+		} `yaml:"emulator"`
+	}
+	if err := node.Decode(&file); err != nil {
+		return err
+	}
+	cfg.Emulator.Resources = file.Emulator.Resources
+	return nil
+}
+
 func applyDefaults(v *viper.Viper) {
 	telemetryDefaults := LoadTelemetryConfig()
 
@@ -130,6 +300,7 @@ func applyDefaults(v *viper.Viper) {
 	v.SetDefault("telemetry.enabled", telemetryDefaults.Enabled)
 	v.SetDefault("telemetry.user_id", telemetryDefaults.UserID)
 	v.SetDefault("virtual_cloud", false)
+	v.SetDefault("soft_assertions", false)
 }
 
 func normalizeTelemetry(cfg *Config) {
@@ -144,18 +315,25 @@ func normalizeTelemetry(cfg *Config) {
 	}
 }
 
-// Load reads and parses the configuration file
+// Load reads and parses the configuration file, as YAML, TOML or JSON by its extension
 func Load(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
-	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
+	node, err := parseConfigNode(path, data)
+	if err != nil {
 		return nil, err
 	}
 
+	var config Config
+	if node != nil {
+		if err := node.Decode(&config); err != nil {
+			return nil, err
+		}
+	}
+
 	return &config, nil
 }
 
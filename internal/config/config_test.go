@@ -0,0 +1,231 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig_DecodesSnakeCaseKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infraspec.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+soft_assertions: true
+virtual_cloud: true
+functions:
+  random_string:
+    length: 12
+retries:
+  max_attempts: 3
+  delay: 2s
+`), 0o644))
+
+	cfg, err := LoadConfig(path, false)
+	require.NoError(t, err)
+	assert.True(t, cfg.SoftAssertions)
+	assert.True(t, cfg.VirtualCloud)
+	assert.Equal(t, 12, cfg.Functions.RandomString.Length)
+	assert.Equal(t, 3, cfg.Retries.MaxAttempts)
+	assert.Equal(t, 2*time.Second, cfg.Retries.Delay)
+}
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	cfg, err := LoadConfig(filepath.Join(t.TempDir(), "missing.yaml"), false)
+	require.NoError(t, err)
+	assert.Equal(t, "aws", cfg.Provider)
+	assert.Equal(t, randomStringLength, cfg.Functions.RandomString.Length)
+	assert.False(t, cfg.SoftAssertions)
+}
+
+func TestLoadConfig_SeedResourcesKeepParamCase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infraspec.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+emulator:
+  sqs_max_in_flight_messages: 10
+  resources:
+    - service: s3
+      action: CreateBucket
+      params:
+        Bucket: config
+    - service: ec2
+      action: CreateTags
+      params:
+        ResourceId:
+          - vpc-default
+        Tag:
+          - Key: Name
+            Value: default
+`), 0o644))
+
+	cfg, err := LoadConfig(path, false)
+	require.NoError(t, err)
+	assert.Equal(t, 10, cfg.Emulator.SQSMaxInFlightMessages)
+	require.Len(t, cfg.Emulator.Resources, 2)
+	assert.Equal(t, SeedResource{Service: "s3", Action: "CreateBucket", Params: map[string]interface{}{"Bucket": "config"}}, cfg.Emulator.Resources[0])
+	assert.Equal(t, []interface{}{map[string]interface{}{"Key": "Name", "Value": "default"}}, cfg.Emulator.Resources[1].Params["Tag"])
+}
+
+func TestLoadConfig_Fixtures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infraspec.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+emulator:
+  fixtures:
+    - action: DescribeTable
+      body_matches: '"TableName":\s*"orders"'
+      path_matches: '^/$'
+      status: 400
+      file: fixtures/describe-table-error.json
+      headers:
+        Content-Type: application/x-amz-json-1.0
+`), 0o644))
+
+	cfg, err := LoadConfig(path, false)
+	require.NoError(t, err)
+	require.Len(t, cfg.Emulator.Fixtures, 1)
+	fixture := cfg.Emulator.Fixtures[0]
+	assert.Equal(t, "DescribeTable", fixture.Action)
+	assert.Equal(t, `"TableName":\s*"orders"`, fixture.BodyMatches)
+	assert.Equal(t, `^/$`, fixture.PathMatches)
+	assert.Equal(t, 400, fixture.Status)
+	assert.Equal(t, "fixtures/describe-table-error.json", fixture.File)
+	assert.Len(t, fixture.Headers, 1)
+}
+
+func TestLoadConfig_Injections(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infraspec.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+emulator:
+  injections:
+    - action: PutObject
+      path_matches: '^/my-bucket/'
+      status: 503
+      error_code: SlowDown
+      message: Please reduce your request rate.
+    - action: GetItem
+      body_matches: '"TableName":\s*"orders"'
+      latency: 2s
+`), 0o644))
+
+	cfg, err := LoadConfig(path, false)
+	require.NoError(t, err)
+	assert.Equal(t, []Injection{
+		{Action: "PutObject", PathMatches: `^/my-bucket/`, Status: 503, ErrorCode: "SlowDown", Message: "Please reduce your request rate."},
+		{Action: "GetItem", BodyMatches: `"TableName":\s*"orders"`, Latency: 2 * time.Second},
+	}, cfg.Emulator.Injections)
+}
+
+func TestLoadConfig_CORS(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infraspec.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+emulator:
+  cors:
+    allowed_origins: ["http://localhost:3000"]
+    allowed_headers: [authorization, x-amz-date]
+`), 0o644))
+
+	cfg, err := LoadConfig(path, false)
+	require.NoError(t, err)
+	assert.Equal(t, CORSConfig{
+		AllowedOrigins: []string{"http://localhost:3000"},
+		AllowedHeaders: []string{"authorization", "x-amz-date"},
+	}, cfg.Emulator.CORS)
+}
+
+func TestLoadConfig_ValidatesAgainstSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "infraspec.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+soft_asertions: true
+retries:
+  max_attempts: three
+  delay: 2s
+emulator:
+  s3_location_style: virtual
+  fixtures:
+    - action: DescribeTable
+      stauts: 400
+`), 0o644))
+
+	_, err := LoadConfig(path, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2: soft_asertions: unknown key, did you mean soft_assertions?")
+	assert.Contains(t, err.Error(), "line 4: retries.max_attempts: expected integer, got string")
+	assert.Contains(t, err.Error(), `line 7: emulator.s3_location_style: expected one of path, virtual-hosted, got "virtual"`)
+	assert.Contains(t, err.Error(), "line 10: emulator.fixtures[0].stauts: unknown key, did you mean status?")
+
+	var schemaErr *SchemaError
+	require.ErrorAs(t, err, &schemaErr)
+	assert.Equal(t, 2, schemaErr.Line)
+}
+
+func TestValidateSchema_AcceptsEmptyAndUnsetValues(t *testing.T) {
+	for _, data := range []string{"", `
+emulator:
+retries:
+  delay: 1000000000
+  backoff_factor: 2
+`} {
+		node, err := parseConfigNode("infraspec.yaml", []byte(data))
+		require.NoError(t, err)
+		assert.NoError(t, validateSchema(node))
+	}
+}
+
+func TestLoadConfig_TOMLAndJSON(t *testing.T) {
+	dir := t.TempDir()
+	tomlPath := filepath.Join(dir, "infraspec.toml")
+	require.NoError(t, os.WriteFile(tomlPath, []byte(`
+soft_assertions = true
+
+[retries]
+max_attempts = 3
+delay = "2s"
+
+[[emulator.resources]]
+service = "s3"
+action = "CreateBucket"
+params = { Bucket = "config" }
+`), 0o644))
+	jsonPath := filepath.Join(dir, "infraspec.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`{
+  "soft_assertions": true,
+  "retries": {"max_attempts": 3, "delay": "2s"},
+  "emulator": {"resources": [{"service": "s3", "action": "CreateBucket", "params": {"Bucket": "config"}}]}
+}`), 0o644))
+
+	for _, path := range []string{tomlPath, jsonPath} {
+		cfg, err := LoadConfig(path, false)
+		require.NoError(t, err, path)
+		assert.True(t, cfg.SoftAssertions, path)
+		assert.Equal(t, 3, cfg.Retries.MaxAttempts, path)
+		assert.Equal(t, 2*time.Second, cfg.Retries.Delay, path)
+		assert.Equal(t, []SeedResource{{Service: "s3", Action: "CreateBucket", Params: map[string]interface{}{"Bucket": "config"}}}, cfg.Emulator.Resources, path)
+	}
+}
+
+func TestLoadConfig_ValidatesTOMLAndJSON(t *testing.T) {
+	dir := t.TempDir()
+	tomlPath := filepath.Join(dir, "infraspec.toml")
+	require.NoError(t, os.WriteFile(tomlPath, []byte("[retries]\nmax_atempts = 3\n"), 0o644))
+	jsonPath := filepath.Join(dir, "infraspec.json")
+	require.NoError(t, os.WriteFile(jsonPath, []byte("{\n  \"retries\": {\n    \"max_attempts\": \"3\"\n  }\n}"), 0o644))
+
+	_, err := LoadConfig(tomlPath, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "retries.max_atempts: unknown key, did you mean max_attempts?")
+
+	_, err = LoadConfig(jsonPath, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 3: retries.max_attempts: expected integer, got string")
+}
+
+func TestLoadConfig_FindsDefaultConfigFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("infraspec.toml", []byte("soft_assertions = true\n"), 0o644))
+
+	cfg, err := LoadConfig("", false)
+	require.NoError(t, err)
+	assert.True(t, cfg.SoftAssertions)
+}
@@ -0,0 +1,243 @@
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// configSchema is the JSON schema of the config file. Only the keywords the validator
+// supports are used: type, properties, additionalProperties, items, enum, minimum and
+// $ref to $defs.
+//
+//go:embed schema.json
+var configSchema []byte
+
+// schema is a JSON schema, or the subset of one the validator supports
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 schemaTypes        `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+	Enum                 []string           `json:"enum"`
+	Minimum              *float64           `json:"minimum"`
+	Defs                 map[string]*schema `json:"$defs"`
+}
+
+// schemaTypes is the type keyword, a single type or a list of them
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+// SchemaError is a value in the config file that doesn't match the schema. Line is 0 when the
+// file's format doesn't keep line numbers, like TOML.
+type SchemaError struct {
+	Line    int
+	Path    string
+	Message string
+}
+
+func (e *SchemaError) Error() string {
+	var parts []string
+	if e.Line > 0 {
+		parts = append(parts, fmt.Sprintf("line %d", e.Line))
+	}
+	if e.Path != "" {
+		parts = append(parts, e.Path)
+	}
+	return strings.Join(append(parts, e.Message), ": ")
+}
+
+// validateSchema checks a parsed config file against the config schema, returning a
+// SchemaError for every unknown key and mismatched type, joined with errors.Join. A nil node,
+// an empty file, is valid.
+func validateSchema(node *yaml.Node) error {
+	if node == nil {
+		return nil
+	}
+
+	var root schema
+	if err := json.Unmarshal(configSchema, &root); err != nil {
+		return fmt.Errorf("invalid config schema: %w", err)
+	}
+
+	v := &schemaValidator{root: &root}
+	v.validate(node, &root, "")
+	return errors.Join(v.errs...)
+}
+
+// schemaValidator collects the errors found validating a document against a root schema
+type schemaValidator struct {
+	root *schema
+	errs []error
+}
+
+func (v *schemaValidator) fail(node *yaml.Node, path, format string, args ...interface{}) {
+	v.errs = append(v.errs, &SchemaError{Line: node.Line, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *schemaValidator) validate(node *yaml.Node, s *schema, path string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if s.Ref != "" {
+		name, ok := strings.CutPrefix(s.Ref, "#/$defs/")
+		if !ok || v.root.Defs[name] == nil {
+			v.fail(node, path, "unresolvable schema reference %s", s.Ref)
+			return
+		}
+		s = v.root.Defs[name]
+	}
+
+	// An empty value leaves the setting unset
+	actual := yamlNodeType(node)
+	if actual == "null" {
+		return
+	}
+
+	if len(s.Type) > 0 && !slices.ContainsFunc(s.Type, func(expected string) bool {
+		return expected == actual || (expected == "number" && actual == "integer")
+	}) {
+		v.fail(node, path, "expected %s, got %s", strings.Join(s.Type, " or "), actual)
+		return
+	}
+
+	if len(s.Enum) > 0 {
+		if node.Kind != yaml.ScalarNode || !slices.Contains(s.Enum, node.Value) {
+			v.fail(node, path, "expected one of %s, got %q", strings.Join(s.Enum, ", "), node.Value)
+			return
+		}
+	}
+
+	if s.Minimum != nil && (actual == "integer" || actual == "number") {
+		if n, err := strconv.ParseFloat(node.Value, 64); err == nil && n < *s.Minimum {
+			v.fail(node, path, "must be at least %v, got %s", *s.Minimum, node.Value)
+		}
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		v.validateMapping(node, s, path)
+	case yaml.SequenceNode:
+		if s.Items == nil {
+			return
+		}
+		for i, item := range node.Content {
+			v.validate(item, s.Items, fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
+
+func (v *schemaValidator) validateMapping(node *yaml.Node, s *schema, path string) {
+	var additional *schema
+	allowAdditional := true
+	if len(s.AdditionalProperties) > 0 {
+		if err := json.Unmarshal(s.AdditionalProperties, &allowAdditional); err != nil {
+			additional = &schema{}
+			if err := json.Unmarshal(s.AdditionalProperties, additional); err != nil {
+				v.fail(node, path, "invalid additionalProperties in schema: %v", err)
+				return
+			}
+		}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		keyPath := key.Value
+		if path != "" {
+			keyPath = path + "." + key.Value
+		}
+
+		if property, ok := s.Properties[key.Value]; ok {
+			v.validate(value, property, keyPath)
+		} else if additional != nil {
+			v.validate(value, additional, keyPath)
+		} else if !allowAdditional {
+			if suggestion := closestKey(key.Value, s.Properties); suggestion != "" {
+				v.fail(key, keyPath, "unknown key, did you mean %s?", suggestion)
+			} else {
+				v.fail(key, keyPath, "unknown key")
+			}
+		}
+	}
+}
+
+// yamlNodeType returns the JSON schema type of a YAML node
+func yamlNodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+
+	switch node.ShortTag() {
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!bool":
+		return "boolean"
+	case "!!null":
+		return "null"
+	}
+	return "string"
+}
+
+// closestKey returns the known key within two edits of key, if there is one
+func closestKey(key string, properties map[string]*schema) string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	best, bestDistance := "", 3
+	for _, name := range names {
+		if d := editDistance(key, name); d < bestDistance {
+			best, bestDistance = name, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
@@ -81,6 +81,9 @@ func (t *TestContext) GetAsserter(provider string) (assertions.Asserter, error)
 	if err != nil {
 		return nil, err
 	}
+	if debugger, ok := asserter.(assertions.FailureDebugger); ok && t.config != nil {
+		debugger.SetDebugOnFailure(t.config.DebugOnFailure)
+	}
 
 	t.assertions[provider] = asserter
 	return asserter, nil
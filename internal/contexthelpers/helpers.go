@@ -65,6 +65,9 @@ func GetAsserter(ctx context.Context, provider string) (assertions.Asserter, err
 	if err != nil {
 		return nil, err
 	}
+	if debugger, ok := asserter.(assertions.FailureDebugger); ok {
+		debugger.SetDebugOnFailure(cfg.DebugOnFailure)
+	}
 
 	return asserter, nil
 }
@@ -0,0 +1,44 @@
+package contexthelpers
+
+import (
+	"context"
+	"sync"
+)
+
+// ScenarioStoreCtxKey is the key used to store the scenario variables in context.Context.
+type ScenarioStoreCtxKey struct{}
+
+// ScenarioStore holds the variables captured by steps during a scenario.
+type ScenarioStore struct {
+	mu   sync.RWMutex
+	vars map[string]string
+}
+
+// NewScenarioStore creates an empty scenario store.
+func NewScenarioStore() *ScenarioStore {
+	return &ScenarioStore{vars: make(map[string]string)}
+}
+
+// Set stores the value of a variable, replacing any previous value.
+func (s *ScenarioStore) Set(name, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.vars[name] = value
+}
+
+// Get returns the value of a variable and whether it has been set.
+func (s *ScenarioStore) Get(name string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.vars[name]
+	return value, ok
+}
+
+// GetScenarioStore returns the scenario store from the context.
+func GetScenarioStore(ctx context.Context) *ScenarioStore {
+	store, exists := ctx.Value(ScenarioStoreCtxKey{}).(*ScenarioStore)
+	if !exists {
+		return nil
+	}
+	return store
+}
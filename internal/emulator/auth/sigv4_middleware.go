@@ -3,10 +3,11 @@ package auth
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 )
 
 const (
@@ -49,7 +50,7 @@ func (m *SigV4Middleware) Middleware(next http.Handler) http.Handler {
 		// Extract and validate authorization header
 		authHeader := r.Header.Get(authorizationHeader)
 		if authHeader == "" {
-			log.Printf("Authentication failed: missing Authorization header")
+			logging.Warnf("auth", "Authentication failed: missing Authorization header")
 			m.writeUnauthorizedResponse(w, r, "missing Authorization header")
 			return
 		}
@@ -57,14 +58,14 @@ func (m *SigV4Middleware) Middleware(next http.Handler) http.Handler {
 		// Parse authorization header to get access key and service name
 		authInfo, err := parseAuthorizationHeader(authHeader)
 		if err != nil {
-			log.Printf("Authentication failed: invalid Authorization header: %v", err)
+			logging.Warnf("auth", "Authentication failed: invalid Authorization header: %v", err)
 			m.writeUnauthorizedResponse(w, r, "invalid Authorization header")
 			return
 		}
 
 		// Validate access key exists in keystore
 		if !m.keyStore.ValidateAccessKey(authInfo.AccessKey) {
-			log.Printf("Authentication failed: invalid access key: %s", authInfo.AccessKey)
+			logging.Warnf("auth", "Authentication failed: invalid access key: %s", authInfo.AccessKey)
 			m.writeUnauthorizedResponse(w, r, "invalid access key")
 			return
 		}
@@ -73,7 +74,7 @@ func (m *SigV4Middleware) Middleware(next http.Handler) http.Handler {
 		// For a development/testing tool, this is sufficient. Real signature validation would
 		// require matching the client's signature computation exactly, which is complex due to
 		// differences in header normalization between SDK versions and proxy behaviors.
-		log.Printf("Authentication successful for access key: %s, service: %s", authInfo.AccessKey, authInfo.Service)
+		logging.Debugf("auth", "Authentication successful for access key: %s, service: %s", authInfo.AccessKey, authInfo.Service)
 
 		// Normalize service name to internal identifier
 		// AWS SigV4 uses short names (e.g., "dynamodb") but we use versioned identifiers internally
@@ -0,0 +1,35 @@
+package emulator
+
+import "time"
+
+// Clock supplies the current time to services. Services that record timestamps take a
+// Clock so tests can control the times they report.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is a Clock that reports the system time in UTC
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now().UTC()
+}
+
+// FixedClock is a Clock that always reports the same time, for use in tests
+type FixedClock struct {
+	Time time.Time
+}
+
+func (c *FixedClock) Now() time.Time {
+	return c.Time
+}
+
+// Advance moves the clock forward by d
+func (c *FixedClock) Advance(d time.Duration) {
+	c.Time = c.Time.Add(d)
+}
+
+var (
+	_ Clock = SystemClock{}
+	_ Clock = (*FixedClock)(nil)
+)
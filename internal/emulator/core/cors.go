@@ -0,0 +1,111 @@
+package emulator
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSConfig configures the CORS headers that let browser clients, like the AWS SDK for
+// JavaScript in a local web app, call the emulator. An empty list of origins, methods or
+// headers allows all of them.
+type CORSConfig struct {
+	// AllowedOrigins are the origins, like "http://localhost:3000", allowed to call the
+	// emulator. "*" allows any origin.
+	AllowedOrigins []string
+
+	// AllowedMethods are the HTTP methods preflight responses allow
+	AllowedMethods []string
+
+	// AllowedHeaders are the request headers preflight responses allow. "*" allows the
+	// headers the preflight request asks for.
+	AllowedHeaders []string
+
+	// ExposedHeaders are the response headers browser clients can read
+	ExposedHeaders []string
+
+	// MaxAge is how long browsers can cache a preflight response
+	MaxAge time.Duration
+}
+
+// DefaultCORSConfig allows any origin to call the emulator with any headers, and exposes
+// the response headers the AWS SDKs read
+func DefaultCORSConfig() CORSConfig {
+	return CORSConfig{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodPatch},
+		AllowedHeaders: []string{"*"},
+		ExposedHeaders: []string{
+			"ETag", "Content-Length", "Content-Range", "Accept-Ranges", "Last-Modified",
+			"x-amz-request-id", "x-amz-id-2", "x-amz-version-id", "x-amz-delete-marker",
+			"x-amzn-RequestId", "x-amzn-ErrorType", "x-amz-crc32",
+		},
+		MaxAge: 10 * time.Minute,
+	}
+}
+
+// allowsOrigin reports whether the origin is allowed to call the emulator
+func (c CORSConfig) allowsOrigin(origin string) bool {
+	return len(c.AllowedOrigins) == 0 || slices.Contains(c.AllowedOrigins, "*") || slices.Contains(c.AllowedOrigins, origin)
+}
+
+// allowedHeaders returns the Access-Control-Allow-Headers value for a preflight request
+// asking for the requested headers
+func (c CORSConfig) allowedHeaders(requested string) string {
+	if len(c.AllowedHeaders) == 0 || slices.Contains(c.AllowedHeaders, "*") {
+		return requested
+	}
+	return strings.Join(c.AllowedHeaders, ", ")
+}
+
+// CORSMiddleware answers CORS preflight requests, OPTIONS requests with an Origin and an
+// Access-Control-Request-Method header, itself, so they don't need to be signed or handled by
+// a service. Other requests from an allowed origin are passed on with the headers that let the
+// browser read the response.
+func CORSMiddleware(config CORSConfig, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Origin")
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+		if !config.allowsOrigin(origin) {
+			if preflight {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Echoing the origin, rather than "*", also works for requests sent with credentials
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		if !preflight {
+			if len(config.ExposedHeaders) > 0 {
+				w.Header().Set("Access-Control-Expose-Headers", strings.Join(config.ExposedHeaders, ", "))
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		methods := config.AllowedMethods
+		if len(methods) == 0 {
+			methods = DefaultCORSConfig().AllowedMethods
+		}
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+		if headers := config.allowedHeaders(r.Header.Get("Access-Control-Request-Headers")); headers != "" {
+			w.Header().Set("Access-Control-Allow-Headers", headers)
+		}
+		if config.MaxAge > 0 {
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(config.MaxAge.Seconds())))
+		}
+		w.Header().Add("Vary", "Access-Control-Request-Method")
+		w.Header().Add("Vary", "Access-Control-Request-Headers")
+		w.WriteHeader(http.StatusOK)
+	})
+}
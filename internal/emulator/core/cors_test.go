@@ -0,0 +1,126 @@
+package emulator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	tests := []struct {
+		name        string
+		config      CORSConfig
+		method      string
+		headers     map[string]string
+		wantStatus  int
+		wantHeaders map[string]string
+	}{
+		{
+			name:        "request without an origin",
+			config:      DefaultCORSConfig(),
+			method:      http.MethodGet,
+			wantStatus:  http.StatusTeapot,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:   "preflight request echoes the requested headers",
+			config: DefaultCORSConfig(),
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "http://localhost:3000",
+				"Access-Control-Request-Method":  "PUT",
+				"Access-Control-Request-Headers": "authorization, x-amz-date, x-amz-content-sha256",
+			},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "http://localhost:3000",
+				"Access-Control-Allow-Methods": "GET, HEAD, PUT, POST, DELETE, PATCH",
+				"Access-Control-Allow-Headers": "authorization, x-amz-date, x-amz-content-sha256",
+				"Access-Control-Max-Age":       "600",
+			},
+		},
+		{
+			name: "preflight request with configured methods and headers",
+			config: CORSConfig{
+				AllowedOrigins: []string{"http://localhost:3000"},
+				AllowedMethods: []string{"GET", "PUT"},
+				AllowedHeaders: []string{"authorization", "content-type"},
+			},
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "http://localhost:3000",
+				"Access-Control-Request-Method":  "PUT",
+				"Access-Control-Request-Headers": "x-custom",
+			},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "http://localhost:3000",
+				"Access-Control-Allow-Methods": "GET, PUT",
+				"Access-Control-Allow-Headers": "authorization, content-type",
+				"Access-Control-Max-Age":       "",
+			},
+		},
+		{
+			name:   "preflight request from another origin",
+			config: CORSConfig{AllowedOrigins: []string{"http://localhost:3000"}},
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "http://evil.example",
+				"Access-Control-Request-Method": "GET",
+			},
+			wantStatus:  http.StatusForbidden,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:       "request from an allowed origin",
+			config:     DefaultCORSConfig(),
+			method:     http.MethodGet,
+			headers:    map[string]string{"Origin": "http://localhost:3000"},
+			wantStatus: http.StatusTeapot,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":   "http://localhost:3000",
+				"Access-Control-Expose-Headers": "ETag, Content-Length, Content-Range, Accept-Ranges, Last-Modified, x-amz-request-id, x-amz-id-2, x-amz-version-id, x-amz-delete-marker, x-amzn-RequestId, x-amzn-ErrorType, x-amz-crc32",
+			},
+		},
+		{
+			name:        "request from another origin",
+			config:      CORSConfig{AllowedOrigins: []string{"http://localhost:3000"}},
+			method:      http.MethodGet,
+			headers:     map[string]string{"Origin": "http://evil.example"},
+			wantStatus:  http.StatusTeapot,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:        "OPTIONS request that isn't a preflight",
+			config:      DefaultCORSConfig(),
+			method:      http.MethodOptions,
+			headers:     map[string]string{"Origin": "http://localhost:3000"},
+			wantStatus:  http.StatusTeapot,
+			wantHeaders: map[string]string{"Access-Control-Allow-Methods": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/my-bucket/key", nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			rec := httptest.NewRecorder()
+			CORSMiddleware(tt.config, next).ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			for key, want := range tt.wantHeaders {
+				if got := rec.Header().Get(key); got != want {
+					t.Errorf("Expected %s %q, got %q", key, want, got)
+				}
+			}
+		})
+	}
+}
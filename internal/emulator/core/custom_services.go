@@ -0,0 +1,29 @@
+package emulator
+
+import "sync"
+
+// CustomService is a service compiled into a custom build, registered with the matcher that
+// routes requests to it. Match is nil for services routed by name.
+type CustomService struct {
+	Service Service
+	Match   Matcher
+}
+
+var customServices struct {
+	mu       sync.Mutex
+	services []CustomService
+}
+
+// RegisterCustomService adds a service for emulators started afterwards to register
+func RegisterCustomService(service Service, match Matcher) {
+	customServices.mu.Lock()
+	defer customServices.mu.Unlock()
+	customServices.services = append(customServices.services, CustomService{Service: service, Match: match})
+}
+
+// CustomServices returns the registered custom services, in registration order
+func CustomServices() []CustomService {
+	customServices.mu.Lock()
+	defer customServices.mu.Unlock()
+	return append([]CustomService(nil), customServices.services...)
+}
@@ -0,0 +1,68 @@
+package emulator
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ProtocolProvider is an optional interface that services can implement to declare the AWS
+// protocol they speak. MethodMiddleware uses it to reject requests with an HTTP method the
+// protocol never uses.
+type ProtocolProvider interface {
+	Service
+	Protocol() ProtocolType
+}
+
+// protocolMethods are the HTTP methods each protocol's requests are sent with
+var protocolMethods = map[ProtocolType][]string{
+	ProtocolQuery:    {http.MethodGet, http.MethodPost},
+	ProtocolJSON:     {http.MethodPost},
+	ProtocolRESTXML:  {http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodOptions},
+	ProtocolRESTJSON: {http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodPatch},
+}
+
+// AllowedMethods returns the HTTP methods the service accepts, or nil if it accepts any
+// method because it doesn't implement ProtocolProvider or speaks an unknown protocol
+func AllowedMethods(service Service) []string {
+	provider, ok := service.(ProtocolProvider)
+	if !ok {
+		return nil
+	}
+	return protocolMethods[provider.Protocol()]
+}
+
+// MethodMiddleware rejects requests whose HTTP method the resolved service's protocol doesn't
+// use, e.g. a PATCH to DynamoDB, with a 405 Method Not Allowed in the protocol's error format,
+// instead of letting the service fail to find an action. Requests that can't be routed are
+// passed on so the handler can report why.
+func MethodMiddleware(router RequestRouter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		service, err := router.Route(r)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		allowed := AllowedMethods(service)
+		if allowed == nil || slices.Contains(allowed, r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		resp := methodNotAllowedResponse(service.(ProtocolProvider).Protocol(), r.Method)
+		for key, value := range resp.Headers {
+			w.Header().Set(key, value)
+		}
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		w.WriteHeader(resp.StatusCode)
+		w.Write(resp.Body) //nolint:errcheck
+	})
+}
+
+// methodNotAllowedResponse builds the 405 error for a method in the protocol's error format
+func methodNotAllowedResponse(protocol ProtocolType, method string) *AWSResponse {
+	const code = "MethodNotAllowed"
+	message := "The specified method is not allowed against this resource: " + method
+	return BuildProtocolErrorResponse(protocol, http.StatusMethodNotAllowed, code, message)
+}
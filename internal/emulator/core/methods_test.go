@@ -0,0 +1,77 @@
+package emulator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// mockProtocolService implements Service and ProtocolProvider
+type mockProtocolService struct {
+	mockBasicService
+	protocol ProtocolType
+}
+
+func (s *mockProtocolService) Protocol() ProtocolType {
+	return s.protocol
+}
+
+func TestMethodMiddleware(t *testing.T) {
+	router := NewRouter()
+	for _, svc := range []Service{
+		&mockProtocolService{mockBasicService: mockBasicService{name: "dynamodb_20120810"}, protocol: ProtocolJSON},
+		&mockProtocolService{mockBasicService: mockBasicService{name: "s3"}, protocol: ProtocolRESTXML},
+		&mockBasicService{name: "inventory"},
+	} {
+		if err := router.RegisterService(svc); err != nil {
+			t.Fatalf("Failed to register service: %v", err)
+		}
+	}
+
+	handler := MethodMiddleware(router, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name       string
+		method     string
+		signedFor  string
+		wantStatus int
+	}{
+		{name: "JSON protocol POST", method: http.MethodPost, signedFor: "dynamodb", wantStatus: http.StatusOK},
+		{name: "JSON protocol PATCH", method: http.MethodPatch, signedFor: "dynamodb", wantStatus: http.StatusMethodNotAllowed},
+		{name: "REST-XML HEAD", method: http.MethodHead, signedFor: "s3", wantStatus: http.StatusOK},
+		{name: "REST-XML PATCH", method: http.MethodPatch, signedFor: "s3", wantStatus: http.StatusMethodNotAllowed},
+		{name: "service without a protocol", method: http.MethodPatch, signedFor: "inventory", wantStatus: http.StatusOK},
+		{name: "unroutable request", method: http.MethodPatch, signedFor: "", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/", nil)
+			req.Host = "localhost"
+			if tt.signedFor != "" {
+				req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential=test/20240101/us-east-1/"+tt.signedFor+"/aws4_request, SignedHeaders=host, Signature=test")
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+
+	// The error is in the protocol's format and lists the allowed methods
+	req := httptest.NewRequest(http.MethodPatch, "/", nil)
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential=test/20240101/us-east-1/dynamodb/aws4_request, SignedHeaders=host, Signature=test")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if got := rec.Header().Get("Allow"); got != "POST" {
+		t.Errorf("Expected Allow: POST, got %q", got)
+	}
+	if !strings.Contains(rec.Body.String(), `"__type":"MethodNotAllowed"`) {
+		t.Errorf("Expected a JSON MethodNotAllowed error, got %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,53 @@
+package emulator
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrInvalidPageToken is returned for a pagination token that is malformed, has expired or
+// was issued for another operation
+var ErrInvalidPageToken = errors.New("invalid pagination token")
+
+// PageTokenLifetime is how long a pagination token can be used for, like the 24 hours of
+// DynamoDB and S3 tokens
+var PageTokenLifetime = 24 * time.Hour
+
+// pageToken is the content of a pagination token
+type pageToken struct {
+	Operation string `json:"o"`
+	Position  string `json:"p"`
+	IssuedAt  int64  `json:"t"`
+}
+
+// EncodePageToken returns an opaque token for the position the next page of an operation's
+// results starts at, like the name of its first item. The operation, like "iam:ListUsers",
+// stops the token being used for another list.
+func EncodePageToken(operation, position string) string {
+	data, _ := json.Marshal(pageToken{Operation: operation, Position: position, IssuedAt: time.Now().Unix()})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePageToken returns the position of a token returned by EncodePageToken for the
+// operation, or ErrInvalidPageToken if it's malformed, expired or for another operation. An
+// empty token is the first page, whose position is "".
+func DecodePageToken(operation, token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", ErrInvalidPageToken
+	}
+	var decoded pageToken
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Operation != operation || decoded.Position == "" {
+		return "", ErrInvalidPageToken
+	}
+	if time.Since(time.Unix(decoded.IssuedAt, 0)) > PageTokenLifetime {
+		return "", ErrInvalidPageToken
+	}
+	return decoded.Position, nil
+}
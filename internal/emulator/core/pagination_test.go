@@ -0,0 +1,51 @@
+package emulator
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPageToken_RoundTrip(t *testing.T) {
+	token := EncodePageToken("iam:ListUsers", "bob")
+	if token == "bob" {
+		t.Fatal("Expected the token to be opaque")
+	}
+
+	position, err := DecodePageToken("iam:ListUsers", token)
+	if err != nil {
+		t.Fatalf("DecodePageToken failed: %v", err)
+	}
+	if position != "bob" {
+		t.Errorf("Expected position bob, got %q", position)
+	}
+
+	if position, err := DecodePageToken("iam:ListUsers", ""); err != nil || position != "" {
+		t.Errorf("Expected an empty token to be the first page, got %q, %v", position, err)
+	}
+}
+
+func TestDecodePageToken_Invalid(t *testing.T) {
+	expired, _ := json.Marshal(pageToken{
+		Operation: "iam:ListUsers",
+		Position:  "bob",
+		IssuedAt:  time.Now().Add(-PageTokenLifetime - time.Minute).Unix(),
+	})
+
+	tests := map[string]string{
+		"raw name":        "bob",
+		"not json":        base64.RawURLEncoding.EncodeToString([]byte("bob")),
+		"other operation": EncodePageToken("iam:ListRoles", "bob"),
+		"empty position":  EncodePageToken("iam:ListUsers", ""),
+		"expired":         base64.RawURLEncoding.EncodeToString(expired),
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := DecodePageToken("iam:ListUsers", token); !errors.Is(err, ErrInvalidPageToken) {
+				t.Errorf("Expected ErrInvalidPageToken, got %v", err)
+			}
+		})
+	}
+}
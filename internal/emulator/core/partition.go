@@ -0,0 +1,333 @@
+package emulator
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultAccountID is the account ID used when a request doesn't identify an account.
+	DefaultAccountID = "123456789012"
+	// DefaultRegion is the region used when a request doesn't carry a SigV4 credential scope,
+	// unless another is configured with ConfigureScope.
+	DefaultRegion = "us-east-1"
+)
+
+// ScopeConfig configures the region and partition of requests that don't determine them.
+type ScopeConfig struct {
+	// Region is used when a request doesn't carry a SigV4 credential scope. Defaults to DefaultRegion.
+	Region string
+	// Partition and DNSSuffix override the partition derived from each request's region, e.g.
+	// "aws-us-gov" and "amazonaws.com". Both are derived from the region when empty.
+	Partition string
+	DNSSuffix string
+}
+
+var (
+	scopeConfigMu sync.RWMutex
+	scopeConfig   = ScopeConfig{Region: DefaultRegion}
+)
+
+// ConfigureScope sets the default region and partition used to scope requests. It should be
+// called before the emulator starts serving requests.
+func ConfigureScope(cfg ScopeConfig) {
+	if cfg.Region == "" {
+		cfg.Region = DefaultRegion
+	}
+
+	scopeConfigMu.Lock()
+	defer scopeConfigMu.Unlock()
+	scopeConfig = cfg
+}
+
+func currentScopeConfig() ScopeConfig {
+	scopeConfigMu.RLock()
+	defer scopeConfigMu.RUnlock()
+	return scopeConfig
+}
+
+// regionPartitions maps region prefixes to the partition the regions belong to. Regions that
+// match none of them are in the standard "aws" partition.
+var regionPartitions = []struct {
+	prefix    string
+	partition string
+	dnsSuffix string
+}{
+	{"cn-", "aws-cn", "amazonaws.com.cn"},
+	{"us-gov-", "aws-us-gov", "amazonaws.com"},
+	{"us-iso-", "aws-iso", "c2s.ic.gov"},
+	{"us-isob-", "aws-iso-b", "sc2s.sgov.gov"},
+}
+
+// accountIDPattern matches access keys that are themselves 12-digit account IDs. Using the
+// account ID as the access key is how clients select a non-default account in the emulator.
+var accountIDPattern = regexp.MustCompile(`^\d{12}$`)
+
+// RequestScope identifies the account and region a request is made against.
+type RequestScope struct {
+	AccountID string
+	Region    string
+}
+
+// Partition returns the AWS partition the scope's region belongs to, e.g. "aws" or "aws-cn",
+// for use in ARNs.
+func (s RequestScope) Partition() string {
+	if cfg := currentScopeConfig(); cfg.Partition != "" {
+		return cfg.Partition
+	}
+	for _, p := range regionPartitions {
+		if strings.HasPrefix(s.Region, p.prefix) {
+			return p.partition
+		}
+	}
+	return "aws"
+}
+
+// DNSSuffix returns the DNS suffix of the scope's partition, e.g. "amazonaws.com", for use in
+// service URLs.
+func (s RequestScope) DNSSuffix() string {
+	if cfg := currentScopeConfig(); cfg.DNSSuffix != "" {
+		return cfg.DNSSuffix
+	}
+	for _, p := range regionPartitions {
+		if strings.HasPrefix(s.Region, p.prefix) {
+			return p.dnsSuffix
+		}
+	}
+	return "amazonaws.com"
+}
+
+type requestScopeContextKey struct{}
+
+// WithRequestScope returns a copy of ctx carrying the request scope.
+func WithRequestScope(ctx context.Context, scope RequestScope) context.Context {
+	return context.WithValue(ctx, requestScopeContextKey{}, scope)
+}
+
+// RequestScopeFromContext returns the request scope stored in ctx, or the default scope if none is set.
+func RequestScopeFromContext(ctx context.Context) RequestScope {
+	if scope, ok := ctx.Value(requestScopeContextKey{}).(RequestScope); ok {
+		return scope
+	}
+	return RequestScope{AccountID: DefaultAccountID, Region: currentScopeConfig().Region}
+}
+
+// ScopeFromRequest derives the account and region from the request's SigV4 credential scope,
+// read from either the Authorization header or the X-Amz-Credential query parameter of a
+// presigned URL. The account is taken from the access key when it is a 12-digit account ID.
+// Requests without a credential scope use the configured default region.
+func ScopeFromRequest(req *AWSRequest) RequestScope {
+	scope := RequestScope{AccountID: DefaultAccountID, Region: currentScopeConfig().Region}
+
+	credential := ""
+	if authHeader := req.Headers["Authorization"]; strings.HasPrefix(authHeader, "AWS4-HMAC-SHA256") {
+		if credIdx := strings.Index(authHeader, "Credential="); credIdx != -1 {
+			credential = authHeader[credIdx+len("Credential="):]
+			if commaIdx := strings.Index(credential, ","); commaIdx != -1 {
+				credential = credential[:commaIdx]
+			}
+		}
+	} else if queryIdx := strings.Index(req.Path, "?"); queryIdx != -1 {
+		if query, err := url.ParseQuery(req.Path[queryIdx+1:]); err == nil {
+			credential = query.Get("X-Amz-Credential")
+		}
+	}
+
+	// credential is now: ACCESS_KEY/DATE/REGION/SERVICE/aws4_request
+	components := strings.Split(strings.TrimSpace(credential), "/")
+	if len(components) >= 3 {
+		if accountIDPattern.MatchString(components[0]) {
+			scope.AccountID = components[0]
+		}
+		if components[2] != "" {
+			scope.Region = components[2]
+		}
+	}
+
+	return scope
+}
+
+// ScopedStateManager is a StateManager that stores all keys under a fixed prefix in
+// an underlying StateManager, isolating one account/region partition from another.
+type ScopedStateManager struct {
+	base   StateManager
+	prefix string
+}
+
+// NewScopedStateManager creates a StateManager that namespaces keys with prefix.
+func NewScopedStateManager(base StateManager, prefix string) *ScopedStateManager {
+	return &ScopedStateManager{
+		base:   base,
+		prefix: prefix,
+	}
+}
+
+func (s *ScopedStateManager) Get(key string, result interface{}) error {
+	return s.base.Get(s.prefix+key, result)
+}
+
+func (s *ScopedStateManager) Set(key string, value interface{}) error {
+	return s.base.Set(s.prefix+key, value)
+}
+
+func (s *ScopedStateManager) Delete(key string) error {
+	return s.base.Delete(s.prefix + key)
+}
+
+func (s *ScopedStateManager) List(prefix string) ([]string, error) {
+	keys, err := s.base.List(s.prefix + prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, key := range keys {
+		keys[i] = strings.TrimPrefix(key, s.prefix)
+	}
+	return keys, nil
+}
+
+func (s *ScopedStateManager) Exists(key string) bool {
+	return s.base.Exists(s.prefix + key)
+}
+
+func (s *ScopedStateManager) Update(key string, result interface{}, updateFn func() error) error {
+	return s.base.Update(s.prefix+key, result, updateFn)
+}
+
+// PartitionKind controls which parts of the request scope a service's state is partitioned by.
+type PartitionKind int
+
+const (
+	// PartitionByAccountAndRegion isolates state per account and region (e.g. EC2, SQS, DynamoDB).
+	PartitionByAccountAndRegion PartitionKind = iota
+	// PartitionByAccount isolates state per account only, for global services (e.g. IAM, S3).
+	PartitionByAccount
+)
+
+// ServiceFactory creates a service instance bound to the given state manager.
+type ServiceFactory func(state StateManager) Service
+
+// PartitionedService routes each request to a service instance whose state is
+// scoped to the request's account and region. Requests against the default account
+// and region are handled by the default service, which uses the unscoped state, so
+// single-account setups behave exactly as before. Instances for other partitions are
+// created on first use with a ScopedStateManager.
+type PartitionedService struct {
+	defaultService Service
+	state          StateManager
+	factory        ServiceFactory
+	kind           PartitionKind
+
+	mu         sync.Mutex
+	partitions map[string]Service
+}
+
+// NewPartitionedService wraps defaultService so that requests for other accounts or
+// regions are served by instances created with factory.
+func NewPartitionedService(defaultService Service, state StateManager, factory ServiceFactory, kind PartitionKind) *PartitionedService {
+	return &PartitionedService{
+		defaultService: defaultService,
+		state:          state,
+		factory:        factory,
+		kind:           kind,
+		partitions:     make(map[string]Service),
+	}
+}
+
+func (p *PartitionedService) ServiceName() string {
+	return p.defaultService.ServiceName()
+}
+
+// SupportedActions delegates to the default service so the router can register its actions.
+func (p *PartitionedService) SupportedActions() []string {
+	if provider, ok := p.defaultService.(ActionProvider); ok {
+		return provider.SupportedActions()
+	}
+	return nil
+}
+
+// ExtractAction delegates to the default service, leaving the action unchanged if it
+// doesn't implement ActionExtractor.
+func (p *PartitionedService) ExtractAction(req *AWSRequest) string {
+	if extractor, ok := p.defaultService.(ActionExtractor); ok {
+		return extractor.ExtractAction(req)
+	}
+	return req.Action
+}
+
+// Protocol delegates to the default service, returning an empty protocol, which allows every
+// method, if it doesn't implement ProtocolProvider.
+func (p *PartitionedService) Protocol() ProtocolType {
+	if provider, ok := p.defaultService.(ProtocolProvider); ok {
+		return provider.Protocol()
+	}
+	return ""
+}
+
+// ResponseTypes delegates to the default service so response validation works for partitioned services.
+func (p *PartitionedService) ResponseTypes() map[string]func() interface{} {
+	if provider, ok := p.defaultService.(ResponseTypeProvider); ok {
+		return provider.ResponseTypes()
+	}
+	return nil
+}
+
+// HandleRequest routes the request using the scope stored in ctx by the HTTP handler.
+func (p *PartitionedService) HandleRequest(ctx context.Context, req *AWSRequest) (*AWSResponse, error) {
+	return p.serviceFor(RequestScopeFromContext(ctx)).HandleRequest(ctx, req)
+}
+
+// Reset discards all non-default partition instances so they are recreated
+// (and re-initialize their defaults) on next use. The default service re-creates its
+// defaults if it implements DefaultsInitializer, so it should be called after the state
+// has been cleared.
+func (p *PartitionedService) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.partitions = make(map[string]Service)
+
+	if initializer, ok := p.defaultService.(DefaultsInitializer); ok {
+		initializer.InitializeDefaults()
+	}
+}
+
+func (p *PartitionedService) serviceFor(scope RequestScope) Service {
+	if p.kind == PartitionByAccount {
+		scope.Region = ""
+	}
+	if scope.AccountID == DefaultAccountID && (scope.Region == "" || scope.Region == currentScopeConfig().Region) {
+		return p.defaultService
+	}
+
+	prefix := PartitionPrefix(scope)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if svc, ok := p.partitions[prefix]; ok {
+		return svc
+	}
+	svc := p.factory(NewScopedStateManager(p.state, prefix))
+	p.partitions[prefix] = svc
+	return svc
+}
+
+// PartitionPrefix returns the state key prefix used for a non-default partition.
+// An empty region denotes an account-wide partition.
+func PartitionPrefix(scope RequestScope) string {
+	if scope.Region == "" {
+		return fmt.Sprintf("partition:%s:", scope.AccountID)
+	}
+	return fmt.Sprintf("partition:%s:%s:", scope.AccountID, scope.Region)
+}
+
+var (
+	_ Service              = (*PartitionedService)(nil)
+	_ ActionProvider       = (*PartitionedService)(nil)
+	_ ActionExtractor      = (*PartitionedService)(nil)
+	_ ResponseTypeProvider = (*PartitionedService)(nil)
+)
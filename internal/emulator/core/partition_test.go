@@ -0,0 +1,234 @@
+package emulator
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+// mockStatefulService stores a key named after the request body and reports how many
+// "item:" keys are visible through its state manager.
+type mockStatefulService struct {
+	state StateManager
+}
+
+func (s *mockStatefulService) ServiceName() string {
+	return "mock"
+}
+
+func (s *mockStatefulService) HandleRequest(ctx context.Context, req *AWSRequest) (*AWSResponse, error) {
+	if err := s.state.Set("item:"+string(req.Body), true); err != nil {
+		return nil, err
+	}
+	keys, err := s.state.List("item:")
+	if err != nil {
+		return nil, err
+	}
+	return &AWSResponse{StatusCode: 200, Headers: map[string]string{"Count": strconv.Itoa(len(keys))}}, nil
+}
+
+func signedRequest(accessKey, region, body string) *AWSRequest {
+	return &AWSRequest{
+		Method: "POST",
+		Path:   "/",
+		Headers: map[string]string{
+			"Authorization": "AWS4-HMAC-SHA256 Credential=" + accessKey + "/20240101/" + region + "/sqs/aws4_request, SignedHeaders=host, Signature=abc",
+		},
+		Body: []byte(body),
+	}
+}
+
+// scopedContext returns a context carrying the request's scope, as the HTTP handler does.
+func scopedContext(req *AWSRequest) context.Context {
+	return WithRequestScope(context.Background(), ScopeFromRequest(req))
+}
+
+func TestScopeFromRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *AWSRequest
+		account string
+		region  string
+	}{
+		{
+			name:    "unsigned request uses defaults",
+			req:     &AWSRequest{Path: "/", Headers: map[string]string{}},
+			account: DefaultAccountID,
+			region:  DefaultRegion,
+		},
+		{
+			name:    "region from credential scope",
+			req:     signedRequest("test", "eu-west-1", ""),
+			account: DefaultAccountID,
+			region:  "eu-west-1",
+		},
+		{
+			name:    "account id used as access key",
+			req:     signedRequest("111122223333", "us-west-2", ""),
+			account: "111122223333",
+			region:  "us-west-2",
+		},
+		{
+			name: "presigned url credential",
+			req: &AWSRequest{
+				Path:    "/bucket/key?X-Amz-Credential=444455556666%2F20240101%2Fap-south-1%2Fs3%2Faws4_request",
+				Headers: map[string]string{},
+			},
+			account: "444455556666",
+			region:  "ap-south-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scope := ScopeFromRequest(tt.req)
+			if scope.AccountID != tt.account {
+				t.Errorf("expected account %s, got %s", tt.account, scope.AccountID)
+			}
+			if scope.Region != tt.region {
+				t.Errorf("expected region %s, got %s", tt.region, scope.Region)
+			}
+		})
+	}
+}
+
+func TestRequestScope_Partition(t *testing.T) {
+	tests := []struct {
+		region    string
+		partition string
+		dnsSuffix string
+	}{
+		{"us-east-1", "aws", "amazonaws.com"},
+		{"eu-west-1", "aws", "amazonaws.com"},
+		{"us-gov-west-1", "aws-us-gov", "amazonaws.com"},
+		{"cn-north-1", "aws-cn", "amazonaws.com.cn"},
+		{"us-isob-east-1", "aws-iso-b", "sc2s.sgov.gov"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.region, func(t *testing.T) {
+			scope := RequestScope{AccountID: DefaultAccountID, Region: tt.region}
+			if scope.Partition() != tt.partition {
+				t.Errorf("expected partition %s, got %s", tt.partition, scope.Partition())
+			}
+			if scope.DNSSuffix() != tt.dnsSuffix {
+				t.Errorf("expected DNS suffix %s, got %s", tt.dnsSuffix, scope.DNSSuffix())
+			}
+		})
+	}
+}
+
+func TestConfigureScope(t *testing.T) {
+	ConfigureScope(ScopeConfig{Region: "eu-central-1", Partition: "aws-example", DNSSuffix: "example.com"})
+	defer ConfigureScope(ScopeConfig{})
+
+	scope := ScopeFromRequest(&AWSRequest{Path: "/", Headers: map[string]string{}})
+	if scope.Region != "eu-central-1" {
+		t.Errorf("expected configured region eu-central-1, got %s", scope.Region)
+	}
+	if scope.Partition() != "aws-example" || scope.DNSSuffix() != "example.com" {
+		t.Errorf("expected configured partition, got %s and %s", scope.Partition(), scope.DNSSuffix())
+	}
+
+	// The request's own region still takes precedence
+	if scope := ScopeFromRequest(signedRequest("test", "ap-south-1", "")); scope.Region != "ap-south-1" {
+		t.Errorf("expected region from credential scope, got %s", scope.Region)
+	}
+
+	ConfigureScope(ScopeConfig{})
+	if scope := RequestScopeFromContext(context.Background()); scope.Region != DefaultRegion || scope.Partition() != "aws" {
+		t.Errorf("expected defaults after reset, got %s in %s", scope.Region, scope.Partition())
+	}
+}
+
+func TestScopedStateManager(t *testing.T) {
+	base := NewMemoryStateManager()
+	base.Set("item:global", true)
+
+	scoped := NewScopedStateManager(base, "partition:111122223333:eu-west-1:")
+	if err := scoped.Set("item:a", "value"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var value string
+	if err := scoped.Get("item:a", &value); err != nil || value != "value" {
+		t.Fatalf("expected to read scoped key, got %q (err: %v)", value, err)
+	}
+	if scoped.Exists("item:global") {
+		t.Error("scoped state should not see unscoped keys")
+	}
+	if base.Exists("item:a") {
+		t.Error("scoped key should not be visible without the prefix")
+	}
+
+	keys, err := scoped.List("item:")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keys) != 1 || keys[0] != "item:a" {
+		t.Errorf("expected [item:a], got %v", keys)
+	}
+
+	baseKeys, _ := base.List("item:")
+	sort.Strings(baseKeys)
+	if len(baseKeys) != 1 || baseKeys[0] != "item:global" {
+		t.Errorf("expected default partition to only list its own keys, got %v", baseKeys)
+	}
+}
+
+func TestPartitionedService_IsolatesPartitions(t *testing.T) {
+	state := NewMemoryStateManager()
+	factory := func(s StateManager) Service { return &mockStatefulService{state: s} }
+
+	svc := NewPartitionedService(&mockStatefulService{state: state}, state, factory, PartitionByAccountAndRegion)
+
+	count := func(req *AWSRequest) string {
+		resp, err := svc.HandleRequest(scopedContext(req), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp.Headers["Count"]
+	}
+
+	if got := count(signedRequest("test", "us-east-1", "a")); got != "1" {
+		t.Errorf("default partition: expected 1 item, got %s", got)
+	}
+	if got := count(signedRequest("test", "eu-west-1", "b")); got != "1" {
+		t.Errorf("eu-west-1 partition: expected 1 item, got %s", got)
+	}
+	if got := count(signedRequest("111122223333", "us-east-1", "c")); got != "1" {
+		t.Errorf("account partition: expected 1 item, got %s", got)
+	}
+	if got := count(signedRequest("test", "eu-west-1", "d")); got != "2" {
+		t.Errorf("eu-west-1 partition: expected 2 items, got %s", got)
+	}
+
+	// Unscoped state belongs to the default account and region
+	if !state.Exists("item:a") {
+		t.Error("expected default partition to use unscoped keys")
+	}
+}
+
+func TestPartitionedService_AccountOnly(t *testing.T) {
+	state := NewMemoryStateManager()
+	factory := func(s StateManager) Service { return &mockStatefulService{state: s} }
+
+	svc := NewPartitionedService(&mockStatefulService{state: state}, state, factory, PartitionByAccount)
+
+	req := signedRequest("test", "eu-west-1", "global")
+	if _, err := svc.HandleRequest(scopedContext(req), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !state.Exists("item:global") {
+		t.Error("expected account-wide service to ignore the region for the default account")
+	}
+
+	req = signedRequest("111122223333", "eu-west-1", "other")
+	if _, err := svc.HandleRequest(scopedContext(req), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !state.Exists("partition:111122223333:item:other") {
+		t.Error("expected account partition key without a region")
+	}
+}
@@ -2,6 +2,7 @@ package emulator
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"reflect"
@@ -9,16 +10,65 @@ import (
 	"strings"
 )
 
+// Validatable is implemented by request types that check their own field constraints,
+// such as the input types CloudMirror generates with a Validate method.
+type Validatable interface {
+	Validate() []error
+}
+
+// RequestValidationError is returned by the Parse*Request functions when the parsed
+// input's Validate method reports constraint violations.
+type RequestValidationError struct {
+	Errors []error
+}
+
+func (e *RequestValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	noun := "errors"
+	if len(e.Errors) == 1 {
+		noun = "error"
+	}
+	return fmt.Sprintf("%d validation %s detected: %s", len(e.Errors), noun, strings.Join(messages, "; "))
+}
+
+// RequestErrorCode returns the AWS error code for an error from the Parse*Request functions:
+// ValidationException when the input failed validation, otherwise fallback.
+func RequestErrorCode(err error, fallback string) string {
+	var validationErr *RequestValidationError
+	if errors.As(err, &validationErr) {
+		return "ValidationException"
+	}
+	return fallback
+}
+
+// validateRequest runs input's Validate method, if it has one, and aggregates the errors
+func validateRequest(input interface{}) error {
+	v, ok := input.(Validatable)
+	if !ok {
+		return nil
+	}
+	if errs := v.Validate(); len(errs) > 0 {
+		return &RequestValidationError{Errors: errs}
+	}
+	return nil
+}
+
 // ParseJSONRequest parses a JSON request body into a typed struct.
 // Used for DynamoDB, CloudWatch, and other JSON protocol services.
+// If *T implements Validatable, the parsed input is validated before it's returned.
 func ParseJSONRequest[T any](body []byte) (*T, error) {
 	var input T
-	if len(body) == 0 {
-		return &input, nil
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &input); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON request: %w", err)
+		}
 	}
 
-	if err := json.Unmarshal(body, &input); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON request: %w", err)
+	if err := validateRequest(&input); err != nil {
+		return nil, err
 	}
 
 	return &input, nil
@@ -36,21 +86,25 @@ func ParseJSONRequest[T any](body []byte) (*T, error) {
 //	}
 //
 // Form data: "Action=CreateRole&RoleName=test&AssumeRolePolicyDocument=%7B%7D"
+//
+// If *T implements Validatable, the parsed input is validated before it's returned.
 func ParseQueryRequest[T any](body []byte) (*T, error) {
 	var input T
 
-	if len(body) == 0 {
-		return &input, nil
-	}
+	if len(body) > 0 {
+		// Parse form data
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse form data: %w", err)
+		}
 
-	// Parse form data
-	values, err := url.ParseQuery(string(body))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse form data: %w", err)
+		// Use reflection to populate struct fields
+		if err := populateStructFromForm(&input, values); err != nil {
+			return nil, err
+		}
 	}
 
-	// Use reflection to populate struct fields
-	if err := populateStructFromForm(&input, values); err != nil {
+	if err := validateRequest(&input); err != nil {
 		return nil, err
 	}
 
@@ -1,6 +1,8 @@
 package emulator
 
 import (
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -30,6 +32,55 @@ type JSONRequest struct {
 	KeySchema []string `json:"KeySchema"`
 }
 
+// ValidatedRequest mimics a Smithy-generated input type with a Validate method
+type ValidatedRequest struct {
+	Name *string `json:"Name" xml:"Name"`
+	Size *int32  `json:"Size" xml:"Size"`
+}
+
+func (r *ValidatedRequest) Validate() []error {
+	var errs []error
+	if r.Name != nil && len(*r.Name) < 3 {
+		errs = append(errs, errors.New("Name: length must be at least 3"))
+	}
+	if r.Size != nil && *r.Size > 10 {
+		errs = append(errs, errors.New("Size: value must be at most 10"))
+	}
+	return errs
+}
+
+func TestParseRequest_RunsValidate(t *testing.T) {
+	if _, err := ParseJSONRequest[ValidatedRequest]([]byte(`{"Name":"valid","Size":5}`)); err != nil {
+		t.Fatalf("valid input rejected: %v", err)
+	}
+
+	_, err := ParseJSONRequest[ValidatedRequest]([]byte(`{"Name":"ab","Size":11}`))
+	if err == nil {
+		t.Fatal("expected a validation error")
+	}
+	var validationErr *RequestValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Errors) != 2 {
+		t.Fatalf("expected 2 aggregated errors, got %v", err)
+	}
+	if !strings.HasPrefix(err.Error(), "2 validation errors detected: Name: length must be at least 3; Size:") {
+		t.Errorf("unexpected message: %s", err.Error())
+	}
+	if code := RequestErrorCode(err, "SerializationException"); code != "ValidationException" {
+		t.Errorf("expected ValidationException, got %s", code)
+	}
+
+	_, err = ParseQueryRequest[ValidatedRequest]([]byte("Name=ab"))
+	if err == nil || err.Error() != "1 validation error detected: Name: length must be at least 3" {
+		t.Errorf("expected query input to be validated, got %v", err)
+	}
+
+	// Malformed input is still a serialization error
+	_, err = ParseJSONRequest[ValidatedRequest]([]byte(`{`))
+	if code := RequestErrorCode(err, "SerializationException"); code != "SerializationException" {
+		t.Errorf("expected SerializationException, got %s", code)
+	}
+}
+
 func TestParseQueryRequest_BasicFields(t *testing.T) {
 	body := []byte("RoleName=test-role&AssumeRolePolicyDocument=%7B%22Version%22%3A%222012-10-17%22%7D&Description=Test%20role")
 
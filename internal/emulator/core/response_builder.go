@@ -410,8 +410,11 @@ func BuildResponse(serviceName, action string, data interface{}, config Response
 
 // BuildErrorResponse builds an error response using the appropriate protocol
 func BuildErrorResponse(serviceName string, statusCode int, code, message string) *AWSResponse {
-	protocol := GetProtocolForService(serviceName)
+	return BuildProtocolErrorResponse(GetProtocolForService(serviceName), statusCode, code, message)
+}
 
+// BuildProtocolErrorResponse builds an error response in the protocol's error format
+func BuildProtocolErrorResponse(protocol ProtocolType, statusCode int, code, message string) *AWSResponse {
 	switch protocol {
 	case ProtocolQuery:
 		return BuildQueryErrorResponse(statusCode, code, message)
@@ -1,9 +1,11 @@
 package emulator
 
 import (
+	"bytes"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"reflect"
 	"strings"
 )
@@ -265,3 +267,87 @@ func (v *ResponseValidator) ValidateResponseStatusCode(statusCode int) error {
 	return nil
 }
 
+// ResponseTypeProvider is an optional interface that services can implement to expose
+// constructors for their generated (CloudMirror) response types, keyed by action name.
+// It is used to check that responses can be unmarshaled by an SDK-shaped type.
+type ResponseTypeProvider interface {
+	Service
+	ResponseTypes() map[string]func() interface{}
+}
+
+// ValidateResponseUnmarshal attempts to unmarshal a successful response body into the
+// generated response type for the action. Error responses and empty bodies are skipped.
+//
+// JSON bodies are unmarshaled directly. For XML bodies, the {Action}Result element is
+// decoded when present (Query protocol), otherwise the document root is decoded (EC2 and
+// REST-XML protocols).
+func ValidateResponseUnmarshal(action string, resp *AWSResponse, target interface{}) error {
+	if resp == nil || resp.StatusCode >= 300 || len(bytes.TrimSpace(resp.Body)) == 0 {
+		return nil
+	}
+
+	contentType := resp.Headers["Content-Type"]
+	if strings.Contains(contentType, "json") {
+		if err := json.Unmarshal(resp.Body, target); err != nil {
+			return fmt.Errorf("failed to unmarshal %s JSON response into %T: %w", action, target, err)
+		}
+		return nil
+	}
+
+	if err := unmarshalXMLResult(action, resp.Body, target); err != nil {
+		return fmt.Errorf("failed to unmarshal %s XML response into %T: %w", action, target, err)
+	}
+	return nil
+}
+
+// unmarshalXMLResult decodes the {Action}Result element of an XML document into target,
+// falling back to decoding the whole document when no such element exists.
+func unmarshalXMLResult(action string, body []byte, target interface{}) error {
+	resultName := action + "Result"
+
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != resultName {
+			continue
+		}
+
+		// Generated types shared by several operations carry the XMLName of only one of
+		// them, so decode the result element under the name the type expects.
+		if name, ok := xmlNameOf(target); ok {
+			start.Name = xml.Name{Local: name}
+		}
+		return decoder.DecodeElement(target, &start)
+	}
+
+	return xml.Unmarshal(body, target)
+}
+
+// xmlNameOf returns the element name from the XMLName field tag of the struct pointed to by v.
+func xmlNameOf(v interface{}) (string, bool) {
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return "", false
+	}
+
+	field, ok := t.FieldByName("XMLName")
+	if !ok {
+		return "", false
+	}
+	name := strings.Split(field.Tag.Get("xml"), ",")[0]
+	if idx := strings.LastIndex(name, " "); idx != -1 {
+		name = name[idx+1:]
+	}
+	return name, name != ""
+}
@@ -0,0 +1,116 @@
+package emulator
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+type testQueryResult struct {
+	XMLName  xml.Name `xml:"CreateQueueResult"`
+	QueueUrl *string  `xml:"QueueUrl,omitempty"`
+}
+
+type testJSONResult struct {
+	TableNames []string `json:"TableNames,omitempty"`
+}
+
+type testEC2Result struct {
+	RequestId *string `xml:"requestId,omitempty"`
+	Return    *bool   `xml:"return,omitempty"`
+}
+
+func TestValidateResponseUnmarshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  string
+		resp    *AWSResponse
+		target  interface{}
+		wantErr bool
+	}{
+		{
+			name:   "json response",
+			action: "ListTables",
+			resp: &AWSResponse{
+				StatusCode: 200,
+				Headers:    map[string]string{"Content-Type": "application/x-amz-json-1.0"},
+				Body:       []byte(`{"TableNames":["a","b"]}`),
+			},
+			target: &testJSONResult{},
+		},
+		{
+			name:   "json type mismatch",
+			action: "ListTables",
+			resp: &AWSResponse{
+				StatusCode: 200,
+				Headers:    map[string]string{"Content-Type": "application/x-amz-json-1.0"},
+				Body:       []byte(`{"TableNames":"a"}`),
+			},
+			target:  &testJSONResult{},
+			wantErr: true,
+		},
+		{
+			name:   "query response decodes result element",
+			action: "CreateQueue",
+			resp: &AWSResponse{
+				StatusCode: 200,
+				Headers:    map[string]string{"Content-Type": "text/xml"},
+				Body:       []byte(`<CreateQueueResponse><CreateQueueResult><QueueUrl>http://q</QueueUrl></CreateQueueResult></CreateQueueResponse>`),
+			},
+			target: &testQueryResult{},
+		},
+		{
+			name:   "shared result type decodes under its own name",
+			action: "CreateQueueAlias",
+			resp: &AWSResponse{
+				StatusCode: 200,
+				Headers:    map[string]string{"Content-Type": "text/xml"},
+				Body:       []byte(`<CreateQueueAliasResponse><CreateQueueAliasResult><QueueUrl>http://q</QueueUrl></CreateQueueAliasResult></CreateQueueAliasResponse>`),
+			},
+			target: &testQueryResult{},
+		},
+		{
+			name:   "ec2 response decodes document root",
+			action: "DeleteVpc",
+			resp: &AWSResponse{
+				StatusCode: 200,
+				Headers:    map[string]string{"Content-Type": "text/xml"},
+				Body:       []byte(`<DeleteVpcResponse><requestId>abc</requestId><return>maybe</return></DeleteVpcResponse>`),
+			},
+			target:  &testEC2Result{},
+			wantErr: true,
+		},
+		{
+			name:   "malformed xml",
+			action: "CreateQueue",
+			resp: &AWSResponse{
+				StatusCode: 200,
+				Headers:    map[string]string{"Content-Type": "text/xml"},
+				Body:       []byte(`<CreateQueueResponse><CreateQueueResult>`),
+			},
+			target:  &testQueryResult{},
+			wantErr: true,
+		},
+		{
+			name:   "error responses are skipped",
+			action: "CreateQueue",
+			resp: &AWSResponse{
+				StatusCode: 400,
+				Headers:    map[string]string{"Content-Type": "text/xml"},
+				Body:       []byte(`<ErrorResponse>`),
+			},
+			target: &testQueryResult{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateResponseUnmarshal(tt.action, tt.resp, tt.target)
+			if tt.wantErr && err == nil {
+				t.Error("expected an error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
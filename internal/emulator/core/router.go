@@ -4,17 +4,27 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/robmorgan/infraspec/internal/emulator/auth"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 )
 
 type Router struct {
 	services    map[string]Service
 	actionToSvc map[string]string // maps action name to service name
+	matchers    []routeMatcher    // checked in registration order, before the built-in signals
+}
+
+// Matcher reports whether a request is for a particular service. A matcher that reads the
+// request body must restore it for the service.
+type Matcher func(req *http.Request) bool
+
+type routeMatcher struct {
+	service string
+	match   Matcher
 }
 
 func NewRouter() *Router {
@@ -44,13 +54,55 @@ func (r *Router) RegisterService(service Service) error {
 	return nil
 }
 
+// RegisterServiceWithMatcher registers service and routes every request match accepts to it,
+// ahead of the built-in routing signals.
+func (r *Router) RegisterServiceWithMatcher(service Service, match Matcher) error {
+	if match == nil {
+		return fmt.Errorf("service %s registered without a matcher", service.ServiceName())
+	}
+	if err := r.RegisterService(service); err != nil {
+		return err
+	}
+	r.matchers = append(r.matchers, routeMatcher{service: service.ServiceName(), match: match})
+	return nil
+}
+
+// Internal names of services whose requests resemble another service's. S3 Control signs
+// requests as "s3" and DynamoDB Streams as "dynamodb", so the signing name alone can't
+// tell them apart.
+const (
+	s3ControlServiceName       = "s3control"
+	dynamoDBStreamsServiceName = "dynamodbstreams"
+)
+
+// s3ControlPathPrefix prefixes every S3 Control API path (e.g. /v20180820/configuration/publicAccessBlock)
+const s3ControlPathPrefix = "/v20180820/"
+
+// Route implements RequestRouter; see Resolve.
 func (r *Router) Route(req *http.Request) (Service, error) {
+	return r.Resolve(req)
+}
+
+// Resolve determines which registered service handles req. Matchers registered with
+// RegisterServiceWithMatcher are checked first; then signals are checked in order:
+//
+//  1. Markers that distinguish services sharing a signing name or host: an X-Amz-Target of
+//     DynamoDBStreams_20120810, a streams.dynamodb host, or an s3-control host or
+//     /v20180820/ path
+//  2. The service name from the SigV4 signature (set on the context by the auth middleware)
+//  3. A service subdomain, e.g. dynamodb.infraspec.sh
+//  4. The X-Amz-Target prefix (JSON protocol services)
+//  5. The credential scope in the Authorization header (when auth is disabled)
+//  6. An S3 host, including virtual-hosted bucket addressing
+//  7. The Action form parameter (Query protocol), via actions registered by ActionProvider
+//  8. The first host label or path segment
+func (r *Router) Resolve(req *http.Request) (Service, error) {
 	serviceName := r.extractServiceFromRequest(req)
 	if serviceName == "" {
 		// Debug logging for failed routing
-		log.Printf("DEBUG: Failed to route request - Method: %s, Host: %s, Path: %s, ContentType: %s",
+		logging.Debugf("router", "Failed to route request - Method: %s, Host: %s, Path: %s, ContentType: %s",
 			req.Method, req.Host, req.URL.Path, req.Header.Get("Content-Type"))
-		log.Printf("DEBUG: Headers: %v", req.Header)
+		logging.Debugf("router", "Headers: %v", req.Header)
 		return nil, fmt.Errorf("unable to determine service from request")
 	}
 
@@ -63,19 +115,32 @@ func (r *Router) Route(req *http.Request) (Service, error) {
 }
 
 func (r *Router) extractServiceFromRequest(req *http.Request) string {
-	// FIRST: Check the request context for service name set by auth middleware
+	host := req.Host
+	// Use X-Forwarded-Host if present (for proxied requests like Railway)
+	if forwardedHost := req.Header.Get("X-Forwarded-Host"); forwardedHost != "" {
+		host = forwardedHost
+	}
+
+	// Matchers of custom services take priority over every built-in signal
+	for _, m := range r.matchers {
+		if m.match(req) {
+			return m.service
+		}
+	}
+
+	// FIRST: Disambiguate services that share a signing name with another service
+	if serviceName := sharedSigningNameService(req, host); serviceName != "" {
+		return serviceName
+	}
+
+	// SECOND: Check the request context for service name set by auth middleware
 	// This is the most reliable method as it comes from the AWS SigV4 signature
 	if serviceName, ok := req.Context().Value(auth.ServiceNameContextKey).(string); ok && serviceName != "" {
 		return serviceName
 	}
 
-	// SECOND: Check for service-specific subdomains (e.g., dynamodb.infraspec.sh)
+	// THIRD: Check for service-specific subdomains (e.g., dynamodb.infraspec.sh)
 	// This takes priority over other detection methods
-	host := req.Host
-	// Use X-Forwarded-Host if present (for proxied requests like Railway)
-	if forwardedHost := req.Header.Get("X-Forwarded-Host"); forwardedHost != "" {
-		host = forwardedHost
-	}
 
 	if host != "" {
 		// Remove port from host if present
@@ -89,16 +154,20 @@ func (r *Router) extractServiceFromRequest(req *http.Request) string {
 
 			// Map service names to internal service identifiers
 			serviceMap := map[string]string{
-				"dynamodb":    "dynamodb_20120810",
-				"autoscaling": "anyscalefrontendservice",
-				"sts":         "sts",
-				"rds":         "rds",
-				"s3":          "s3",
-				"ec2":         "ec2",
-				"ssm":         "ssm",
-				"sqs":         "sqs",
-				"iam":         "iam",
-				"lambda":      "lambda",
+				"dynamodb":             "dynamodb_20120810",
+				"autoscaling":          "anyscalefrontendservice",
+				"events":               "events",
+				"states":               "states",
+				"sts":                  "sts",
+				"rds":                  "rds",
+				"s3":                   "s3",
+				"ec2":                  "ec2",
+				"ssm":                  "ssm",
+				"sqs":                  "sqs",
+				"iam":                  "iam",
+				"lambda":               "lambda",
+				"monitoring":           "monitoring",
+				"elasticloadbalancing": "elasticloadbalancing",
 			}
 			if internalName, ok := serviceMap[subdomain]; ok {
 				return internalName
@@ -106,7 +175,7 @@ func (r *Router) extractServiceFromRequest(req *http.Request) string {
 		}
 	}
 
-	// THIRD: Check for X-Amz-Target header (JSON protocol services like DynamoDB)
+	// FOURTH: Check for X-Amz-Target header (JSON protocol services like DynamoDB)
 	target := req.Header.Get("X-Amz-Target")
 	if target != "" {
 		parts := strings.Split(target, ".")
@@ -119,6 +188,8 @@ func (r *Router) extractServiceFromRequest(req *http.Request) string {
 				"dynamodb_20120810":       "dynamodb_20120810",
 				"dynamodb":                "dynamodb_20120810",
 				"anyscalefrontendservice": "anyscalefrontendservice",
+				"awsevents":               "events",
+				"awsstepfunctions":        "states",
 			}
 			if internalName, ok := targetServiceMap[rawServiceName]; ok {
 				return internalName
@@ -127,7 +198,7 @@ func (r *Router) extractServiceFromRequest(req *http.Request) string {
 		}
 	}
 
-	// FOURTH: Extract service from Authorization header credential scope
+	// FIFTH: Extract service from Authorization header credential scope
 	// Format: AWS4-HMAC-SHA256 Credential=ACCESS_KEY/DATE/REGION/SERVICE/aws4_request, ...
 	// This is useful when auth is disabled but the client still sends SigV4 headers
 	if authHeader := req.Header.Get("Authorization"); authHeader != "" && strings.HasPrefix(authHeader, "AWS4-HMAC-SHA256") {
@@ -145,6 +216,8 @@ func (r *Router) extractServiceFromRequest(req *http.Request) string {
 					"dynamodb":                "dynamodb_20120810",
 					"application-autoscaling": "anyscalefrontendservice",
 					"autoscaling":             "anyscalefrontendservice",
+					"events":                  "events",
+					"states":                  "states",
 					"sts":                     "sts",
 					"rds":                     "rds",
 					"s3":                      "s3",
@@ -153,6 +226,8 @@ func (r *Router) extractServiceFromRequest(req *http.Request) string {
 					"sqs":                     "sqs",
 					"iam":                     "iam",
 					"lambda":                  "lambda",
+					"monitoring":              "monitoring",
+					"elasticloadbalancing":    "elasticloadbalancing",
 				}
 				if internalName, ok := serviceMap[serviceName]; ok {
 					return internalName
@@ -162,7 +237,7 @@ func (r *Router) extractServiceFromRequest(req *http.Request) string {
 		}
 	}
 
-	// FIFTH: Check for S3 service by looking for S3-specific indicators
+	// SIXTH: Check for S3 service by looking for S3-specific indicators
 	// S3 uses virtual-hosted-style bucket addressing:
 	// - bucket-name.s3.infraspec.sh or bucket-name.s3.localhost (virtual-hosted)
 	// - s3.infraspec.sh or s3.localhost (base S3 endpoint)
@@ -170,7 +245,7 @@ func (r *Router) extractServiceFromRequest(req *http.Request) string {
 		return "s3"
 	}
 
-	// Check for Query Protocol services by looking at form data for Action parameter
+	// SEVENTH: Check for Query Protocol services by looking at form data for Action parameter
 	if req.Method == "POST" && strings.Contains(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
 		body, err := io.ReadAll(req.Body)
 		if err == nil {
@@ -211,6 +286,30 @@ func (r *Router) extractServiceFromRequest(req *http.Request) string {
 	return ""
 }
 
+// sharedSigningNameService returns the service for requests that would otherwise be
+// routed by a signing name or host belonging to another service, or "" if req has no such
+// marker.
+func sharedSigningNameService(req *http.Request, host string) string {
+	if strings.HasPrefix(strings.ToLower(req.Header.Get("X-Amz-Target")), "dynamodbstreams_20120810.") {
+		return dynamoDBStreamsServiceName
+	}
+
+	labels := strings.Split(strings.ToLower(strings.Split(host, ":")[0]), ".")
+	if len(labels) >= 2 && labels[0] == "streams" && labels[1] == "dynamodb" {
+		return dynamoDBStreamsServiceName
+	}
+	for _, label := range labels {
+		if label == "s3-control" {
+			return s3ControlServiceName
+		}
+	}
+	if strings.HasPrefix(req.URL.Path, s3ControlPathPrefix) {
+		return s3ControlServiceName
+	}
+
+	return ""
+}
+
 func (r *Router) GetServices() []Service {
 	services := make([]Service, 0, len(r.services))
 	for _, service := range r.services {
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/robmorgan/infraspec/internal/emulator/auth"
 )
 
 // mockActionProviderService implements both Service and ActionProvider interfaces
@@ -231,6 +233,70 @@ func TestRouter_DuplicateServiceRejected(t *testing.T) {
 	}
 }
 
+func TestRouter_ResolveAmbiguousServices(t *testing.T) {
+	router := NewRouter()
+	for _, name := range []string{"s3", "s3control", "dynamodb_20120810", "dynamodbstreams"} {
+		if err := router.RegisterService(&mockBasicService{name: name}); err != nil {
+			t.Fatalf("Failed to register %s service: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		host        string
+		path        string
+		target      string
+		signingName string // normalized service name the auth middleware stores on the context
+		want        string
+	}{
+		{name: "s3 path style", host: "s3.localhost:3687", path: "/my-bucket/key", signingName: "s3", want: "s3"},
+		{name: "s3 virtual hosted", host: "my-bucket.s3.infraspec.sh", path: "/key", signingName: "s3", want: "s3"},
+		{name: "s3 virtual hosted without auth", host: "my-bucket.s3.infraspec.sh", path: "/key", want: "s3"},
+		{name: "s3 control host", host: "123456789012.s3-control.us-east-1.amazonaws.com", path: "/v20180820/configuration/publicAccessBlock", signingName: "s3", want: "s3control"},
+		{name: "s3 control path on s3 endpoint", host: "s3.localhost:3687", path: "/v20180820/configuration/publicAccessBlock", signingName: "s3", want: "s3control"},
+		{name: "dynamodb", host: "localhost:3687", path: "/", target: "DynamoDB_20120810.GetItem", signingName: "dynamodb_20120810", want: "dynamodb_20120810"},
+		{name: "dynamodb streams target", host: "localhost:3687", path: "/", target: "DynamoDBStreams_20120810.GetRecords", signingName: "dynamodb_20120810", want: "dynamodbstreams"},
+		{name: "dynamodb streams host", host: "streams.dynamodb.us-east-1.amazonaws.com", path: "/", signingName: "dynamodb_20120810", want: "dynamodbstreams"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", tt.path, nil)
+			req.Host = tt.host
+			if tt.target != "" {
+				req.Header.Set("X-Amz-Target", tt.target)
+			}
+			if tt.signingName != "" {
+				req = req.WithContext(context.WithValue(req.Context(), auth.ServiceNameContextKey, tt.signingName))
+			}
+
+			service, err := router.Resolve(req)
+			if err != nil {
+				t.Fatalf("Resolve failed: %v", err)
+			}
+			if service.ServiceName() != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, service.ServiceName())
+			}
+		})
+	}
+}
+
+func TestRouter_ResolveUnregisteredService(t *testing.T) {
+	router := NewRouter()
+	if err := router.RegisterService(&mockBasicService{name: "s3"}); err != nil {
+		t.Fatalf("Failed to register s3 service: %v", err)
+	}
+
+	// S3 Control requests must not fall through to S3 when S3 Control isn't emulated
+	req := httptest.NewRequest("GET", "/v20180820/configuration/publicAccessBlock", nil)
+	req.Host = "123456789012.s3-control.localhost"
+	req = req.WithContext(context.WithValue(req.Context(), auth.ServiceNameContextKey, "s3"))
+
+	if _, err := router.Resolve(req); err == nil {
+		t.Error("Expected an error for an unregistered service, got nil")
+	}
+}
+
 // Verify mock services implement the expected interfaces
 var _ Service = (*mockActionProviderService)(nil)
 var _ ActionProvider = (*mockActionProviderService)(nil)
@@ -254,3 +320,42 @@ func createTestRequest(method, host, body string) *http.Request {
 	}
 	return req
 }
+
+func TestRouter_MatcherTakesPrecedence(t *testing.T) {
+	router := NewRouter()
+	if err := router.RegisterService(&mockBasicService{name: "s3"}); err != nil {
+		t.Fatalf("Failed to register s3 service: %v", err)
+	}
+	custom := &mockBasicService{name: "inventory"}
+	err := router.RegisterServiceWithMatcher(custom, func(req *http.Request) bool {
+		return req.Header.Get("X-Inventory-Api") != ""
+	})
+	if err != nil {
+		t.Fatalf("Failed to register custom service: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/items", nil)
+	req.Host = "s3.infraspec.sh"
+	req.Header.Set("X-Inventory-Api", "1")
+	service, err := router.Route(req)
+	if err != nil {
+		t.Fatalf("Failed to route matched request: %v", err)
+	}
+	if service.ServiceName() != "inventory" {
+		t.Errorf("Expected the matcher to route to inventory, got %s", service.ServiceName())
+	}
+
+	// Requests the matcher rejects fall through to the built-in signals
+	req.Header.Del("X-Inventory-Api")
+	service, err = router.Route(req)
+	if err != nil {
+		t.Fatalf("Failed to route unmatched request: %v", err)
+	}
+	if service.ServiceName() != "s3" {
+		t.Errorf("Expected s3 for an unmatched request, got %s", service.ServiceName())
+	}
+
+	if err := router.RegisterServiceWithMatcher(&mockBasicService{name: "orders"}, nil); err == nil {
+		t.Error("Expected error when registering a nil matcher, got nil")
+	}
+}
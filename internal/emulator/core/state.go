@@ -77,6 +77,20 @@ func (m *MemoryStateManager) Exists(key string) bool {
 	return exists
 }
 
+// Snapshot returns a copy of the values of the keys that match, keyed by state key.
+func (m *MemoryStateManager) Snapshot(match func(key string) bool) map[string]json.RawMessage {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	snapshot := make(map[string]json.RawMessage)
+	for key, data := range m.data {
+		if match(key) {
+			snapshot[key] = append(json.RawMessage(nil), data...)
+		}
+	}
+	return snapshot
+}
+
 // Clear removes all data from the state manager.
 func (m *MemoryStateManager) Clear() {
 	m.mu.Lock()
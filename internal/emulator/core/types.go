@@ -5,6 +5,12 @@ import (
 	"net/http"
 )
 
+// Service is the contract every emulated AWS service implements. Optional capabilities
+// are separate interfaces embedding Service (ActionExtractor, ActionProvider and
+// ResponseTypeProvider), which callers detect with type assertions. Each service package
+// asserts the interfaces it implements at compile time, e.g.
+//
+//	var _ emulator.Service = (*S3Service)(nil)
 type Service interface {
 	HandleRequest(ctx context.Context, req *AWSRequest) (*AWSResponse, error)
 	ServiceName() string
@@ -19,6 +25,14 @@ type ActionExtractor interface {
 	ExtractAction(req *AWSRequest) string
 }
 
+// DefaultsInitializer is an optional interface for services that create resources of their
+// own when they're constructed, like EC2's default VPC. InitializeDefaults re-creates them
+// after the emulator's state has been reset.
+type DefaultsInitializer interface {
+	Service
+	InitializeDefaults()
+}
+
 // ActionProvider is an optional interface that Query Protocol services can implement
 // to register their supported actions for request routing. This eliminates the need
 // for hardcoded action lists in the router.
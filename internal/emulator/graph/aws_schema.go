@@ -126,6 +126,42 @@ func NewAWSSchema() *RelationshipSchema {
 		Description:    "Network interfaces reference security groups",
 	})
 
+	// VPC Endpoint -> VPC (endpoints belong to VPCs)
+	schema.AddRelationship("ec2", "vpc-endpoint", "ec2", "vpc", SchemaEntry{
+		Type:           RelContains,
+		Cardinality:    CardManyToOne,
+		DeleteBehavior: DeleteRestrict,
+		Required:       true,
+		Description:    "VPC endpoints are contained within VPCs",
+	})
+
+	// VPC Endpoint -> Route Table (gateway endpoint routes)
+	schema.AddRelationship("ec2", "vpc-endpoint", "ec2", "route-table", SchemaEntry{
+		Type:           RelAssociatedWith,
+		Cardinality:    CardManyToMany,
+		DeleteBehavior: DeleteSetNull,
+		Required:       false,
+		Description:    "Gateway endpoints add routes to route tables",
+	})
+
+	// VPC Endpoint -> Subnet (interface endpoint network interfaces)
+	schema.AddRelationship("ec2", "vpc-endpoint", "ec2", "subnet", SchemaEntry{
+		Type:           RelReferences,
+		Cardinality:    CardManyToMany,
+		DeleteBehavior: DeleteRestrict,
+		Required:       false,
+		Description:    "Interface endpoints have network interfaces in subnets",
+	})
+
+	// VPC Endpoint -> Security Group
+	schema.AddRelationship("ec2", "vpc-endpoint", "ec2", "security-group", SchemaEntry{
+		Type:           RelReferences,
+		Cardinality:    CardManyToMany,
+		DeleteBehavior: DeleteRestrict,
+		Required:       false,
+		Description:    "Interface endpoints reference security groups",
+	})
+
 	// ==========================================================================
 	// IAM Relationships
 	// ==========================================================================
@@ -326,6 +362,7 @@ var AWSResourceTypes = map[string]string{
 	"ec2:route-table":       "Route Table",
 	"ec2:network-acl":       "Network ACL",
 	"ec2:network-interface": "Network Interface",
+	"ec2:vpc-endpoint":      "VPC Endpoint",
 	"ec2:key-pair":          "Key Pair",
 
 	// IAM
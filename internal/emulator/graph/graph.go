@@ -1,9 +1,10 @@
 package graph
 
 import (
-	"log"
 	"sync"
 	"time"
+
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 )
 
 // RelationshipGraph manages AWS resource relationships using an adjacency list representation.
@@ -223,7 +224,7 @@ func (g *RelationshipGraph) addEdgeInternal(edge *Edge) error {
 				return err
 			}
 			// Log warning in lenient mode so validation issues are visible
-			log.Printf("[WARN] Relationship validation failed (lenient mode, allowing): %v", err)
+			logging.Warnf("graph", "[WARN] Relationship validation failed (lenient mode, allowing): %v", err)
 		}
 	}
 
@@ -426,6 +427,16 @@ func (g *RelationshipGraph) CanDelete(id ResourceID) (bool, []ResourceID, error)
 	return false, depList, nil
 }
 
+// Clear removes every node and edge, keeping the schema and configuration.
+func (g *RelationshipGraph) Clear() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.nodes = make(map[string]*Node)
+	g.outEdges = make(map[string][]*Edge)
+	g.inEdges = make(map[string][]*Edge)
+}
+
 // NodeCount returns the number of nodes in the graph.
 func (g *RelationshipGraph) NodeCount() int {
 	g.mu.RLock()
@@ -257,6 +257,12 @@ func (rm *ResourceManager) GetResource(id ResourceID) (*Node, error) {
 	return rm.graph.GetNode(id)
 }
 
+// Clear removes every resource and relationship from the graph without touching state data.
+// Use this after the state has been cleared.
+func (rm *ResourceManager) Clear() {
+	rm.graph.Clear()
+}
+
 // ResourceCount returns the number of resources in the graph.
 func (rm *ResourceManager) ResourceCount() int {
 	return rm.graph.NodeCount()
@@ -17,3 +17,10 @@ func Int64Ptr(v int64) *int64 { return &v }
 // TimePtr returns a pointer to the given time.Time value.
 func TimePtr(t time.Time) *time.Time { return &t }
 
+// StringValue returns the string the pointer points to, or "" if it's nil.
+func StringValue(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
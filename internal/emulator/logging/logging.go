@@ -0,0 +1,169 @@
+// Package logging writes the emulator's log messages at levels set per component, so the
+// messages of one service can be shown at debug level while the others are quieter.
+package logging
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Level is the severity of a log message
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return fmt.Sprintf("Level(%d)", int(l))
+	}
+}
+
+// ParseLevel parses a level name: debug, info, warn (or warning) or error
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q, expected debug, info, warn or error", name)
+	}
+}
+
+// Components are the names levels can be set for: a service, by its package name, or a part of
+// the emulator shared by every service
+var Components = []string{
+	"applicationautoscaling", "cloudwatch", "dynamodb", "dynamodbstreams", "ec2", "elbv2",
+	"eventbridge", "iam", "lambda", "rds", "s3", "s3control", "sqs", "stepfunctions", "sts",
+	"auth", "graph", "metadata", "router", "server",
+}
+
+// serviceComponents maps the service names that differ from their package name, mostly
+// protocol names like dynamodb_20120810, to the component they log as
+var serviceComponents = map[string]string{
+	"anyscalefrontendservice": "applicationautoscaling",
+	"dynamodb_20120810":       "dynamodb",
+	"elasticloadbalancing":    "elbv2",
+	"events":                  "eventbridge",
+	"monitoring":              "cloudwatch",
+	"states":                  "stepfunctions",
+}
+
+// ServiceComponent returns the component a service logs as, given its ServiceName
+func ServiceComponent(serviceName string) string {
+	if component, ok := serviceComponents[serviceName]; ok {
+		return component
+	}
+	return serviceName
+}
+
+// Levels are the minimum levels messages are logged at. Components without a level of their own
+// use Default.
+type Levels struct {
+	Default    Level
+	Components map[string]Level
+}
+
+// ParseLevels parses a comma-separated list of component=level pairs, like "s3=debug,ec2=warn".
+// An entry without a component, like "warn", sets the default level, which is otherwise info.
+func ParseLevels(spec string) (Levels, error) {
+	levels := Levels{Default: LevelInfo, Components: map[string]Level{}}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		component, name, ok := strings.Cut(entry, "=")
+		if !ok {
+			level, err := ParseLevel(entry)
+			if err != nil {
+				return Levels{}, err
+			}
+			levels.Default = level
+			continue
+		}
+
+		component = strings.ToLower(strings.TrimSpace(component))
+		if !slices.Contains(Components, component) {
+			return Levels{}, fmt.Errorf("unknown log component %q, expected one of %s", component, strings.Join(Components, ", "))
+		}
+		level, err := ParseLevel(name)
+		if err != nil {
+			return Levels{}, fmt.Errorf("%s: %w", component, err)
+		}
+		levels.Components[component] = level
+	}
+	return levels, nil
+}
+
+var (
+	mu      sync.RWMutex
+	current = Levels{Default: LevelInfo}
+)
+
+// SetLevels sets the levels messages are logged at
+func SetLevels(levels Levels) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = levels
+}
+
+// Enabled reports whether messages of a component are logged at level
+func Enabled(component string, level Level) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	minimum, ok := current.Components[component]
+	if !ok {
+		minimum = current.Default
+	}
+	return level >= minimum
+}
+
+// Logf logs a message of a component to the standard logger, if its level is enabled
+func Logf(component string, level Level, format string, args ...interface{}) {
+	if Enabled(component, level) {
+		log.Printf(format, args...)
+	}
+}
+
+// Debugf logs a debug message of a component
+func Debugf(component, format string, args ...interface{}) {
+	Logf(component, LevelDebug, format, args...)
+}
+
+// Infof logs an informational message of a component
+func Infof(component, format string, args ...interface{}) {
+	Logf(component, LevelInfo, format, args...)
+}
+
+// Warnf logs a warning of a component
+func Warnf(component, format string, args ...interface{}) {
+	Logf(component, LevelWarn, format, args...)
+}
+
+// Errorf logs an error of a component
+func Errorf(component, format string, args ...interface{}) {
+	Logf(component, LevelError, format, args...)
+}
@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevels(t *testing.T) {
+	levels, err := ParseLevels("s3=debug, EC2=warn")
+	require.NoError(t, err)
+	assert.Equal(t, LevelInfo, levels.Default)
+	assert.Equal(t, map[string]Level{"s3": LevelDebug, "ec2": LevelWarn}, levels.Components)
+
+	levels, err = ParseLevels("error,sqs=info")
+	require.NoError(t, err)
+	assert.Equal(t, LevelError, levels.Default)
+	assert.Equal(t, LevelInfo, levels.Components["sqs"])
+
+	_, err = ParseLevels("s4=debug")
+	assert.ErrorContains(t, err, `unknown log component "s4"`)
+
+	_, err = ParseLevels("s3=verbose")
+	assert.EqualError(t, err, `s3: unknown log level "verbose", expected debug, info, warn or error`)
+}
+
+func TestLogf_UsesComponentLevels(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	log.SetFlags(0)
+	t.Cleanup(func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+		SetLevels(Levels{Default: LevelInfo})
+	})
+
+	levels, err := ParseLevels("s3=debug,ec2=warn")
+	require.NoError(t, err)
+	SetLevels(levels)
+
+	Debugf("s3", "s3 debug")
+	Infof("ec2", "ec2 info")
+	Warnf("ec2", "ec2 warning")
+	Debugf(ServiceComponent("dynamodb_20120810"), "dynamodb debug")
+	Infof(ServiceComponent("dynamodb_20120810"), "dynamodb info")
+
+	assert.Equal(t, "s3 debug\nec2 warning\ndynamodb info\n", buf.String())
+}
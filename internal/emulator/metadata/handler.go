@@ -2,11 +2,11 @@ package metadata
 
 import (
 	"fmt"
-	"log"
 	"net/http"
 	"strings"
 
 	"github.com/robmorgan/infraspec/internal/emulator/core"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 )
 
 const (
@@ -32,7 +32,7 @@ func NewHandler(state emulator.StateManager) *Handler {
 
 // ServeHTTP handles HTTP requests for the metadata service
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	log.Printf("[Metadata] %s %s", r.Method, r.URL.Path)
+	logging.Infof("metadata", "[Metadata] %s %s", r.Method, r.URL.Path)
 
 	// Handle IMDSv2 token generation
 	if r.URL.Path == "/latest/api/token" {
@@ -45,12 +45,12 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if token != "" {
 		valid, err := ValidateToken(h.state, token)
 		if err != nil {
-			log.Printf("[Metadata] Error validating token: %v", err)
+			logging.Warnf("metadata", "[Metadata] Error validating token: %v", err)
 			http.Error(w, "Internal server error", http.StatusInternalServerError)
 			return
 		}
 		if !valid {
-			log.Printf("[Metadata] Invalid or expired token")
+			logging.Warnf("metadata", "[Metadata] Invalid or expired token")
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
@@ -72,7 +72,7 @@ func (h *Handler) handleTokenRequest(w http.ResponseWriter, r *http.Request) {
 	ttlHeader := r.Header.Get(HeaderIMDSv2TokenTTL)
 	ttl, err := ParseTTL(ttlHeader)
 	if err != nil {
-		log.Printf("[Metadata] Invalid TTL: %v", err)
+		logging.Warnf("metadata", "[Metadata] Invalid TTL: %v", err)
 		http.Error(w, fmt.Sprintf("Bad request: %v", err), http.StatusBadRequest)
 		return
 	}
@@ -80,12 +80,12 @@ func (h *Handler) handleTokenRequest(w http.ResponseWriter, r *http.Request) {
 	// Generate token
 	token, err := GenerateToken(h.state, ttl)
 	if err != nil {
-		log.Printf("[Metadata] Failed to generate token: %v", err)
+		logging.Warnf("metadata", "[Metadata] Failed to generate token: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
 
-	log.Printf("[Metadata] Generated IMDSv2 token with TTL %d seconds", ttl)
+	logging.Debugf("metadata", "[Metadata] Generated IMDSv2 token with TTL %d seconds", ttl)
 
 	// Return token as plain text
 	w.Header().Set("Content-Type", "text/plain")
@@ -104,7 +104,7 @@ func (h *Handler) handleMetadataRequest(w http.ResponseWriter, r *http.Request)
 	path := r.URL.Path
 	content, err := h.endpoint.GetMetadata(path)
 	if err != nil {
-		log.Printf("[Metadata] Not found: %s - %v", path, err)
+		logging.Warnf("metadata", "[Metadata] Not found: %s - %v", path, err)
 		http.Error(w, "Not Found", http.StatusNotFound)
 		return
 	}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"net/http"
+	"regexp"
+
+	emulator "github.com/robmorgan/infraspec/internal/emulator/core"
+)
+
+// Fixture is a canned response the emulator returns for requests of an action instead of
+// handling them, e.g. to reproduce a response captured from AWS
+type Fixture struct {
+	Action string
+
+	// BodyPattern, if set, must match the request body for the fixture to apply
+	BodyPattern *regexp.Regexp
+
+	// PathPattern, if set, must match the request path, including any query string. See
+	// matchPath.
+	PathPattern *regexp.Regexp
+
+	// StatusCode defaults to 200
+	StatusCode int
+	Headers    map[string]string
+	Body       []byte
+}
+
+// matches reports whether the fixture applies to the request, whose path to match is path
+func (f Fixture) matches(req *emulator.AWSRequest, path string) bool {
+	if f.Action != req.Action {
+		return false
+	}
+	if f.BodyPattern != nil && !f.BodyPattern.Match(req.Body) {
+		return false
+	}
+	return f.PathPattern == nil || f.PathPattern.MatchString(path)
+}
+
+// matchPath returns the path fixture and injection rule path patterns are matched against:
+// the request path, including any query string. The bucket of a virtual-hosted S3 request is
+// put in front of it, so "^/my-bucket/" matches requests to the bucket however they address it.
+func matchPath(service emulator.Service, req *emulator.AWSRequest) string {
+	if service.ServiceName() != "s3" {
+		return req.Path
+	}
+	if bucket := emulator.ExtractBucketNameFromHost(req.Headers["Host"]); bucket != "" {
+		return "/" + bucket + req.Path
+	}
+	return req.Path
+}
+
+// response returns the fixture's canned response
+func (f Fixture) response() *emulator.AWSResponse {
+	statusCode := f.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+
+	headers := make(map[string]string, len(f.Headers))
+	for key, value := range f.Headers {
+		headers[key] = value
+	}
+
+	return &emulator.AWSResponse{
+		StatusCode: statusCode,
+		Headers:    headers,
+		Body:       f.Body,
+	}
+}
@@ -4,38 +4,75 @@ import (
 	"context"
 	"encoding/json"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
 
 	"github.com/robmorgan/infraspec/internal/emulator/core"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 )
 
 type EmulatorHandler struct {
 	router emulator.RequestRouter
+
+	// validateResponses enables checking each response against the service's generated response types
+	validateResponses bool
+
+	// requests counts the handled requests per action
+	requests *RequestCounter
+
+	// fixtures are canned responses returned instead of handling matching requests
+	fixtures []Fixture
+
+	// injectionRules fail or delay matching requests
+	injectionRules []InjectionRule
 }
 
 func NewEmulatorHandler(router emulator.RequestRouter) *EmulatorHandler {
 	return &EmulatorHandler{
-		router: router,
+		router:   router,
+		requests: NewRequestCounter(),
 	}
 }
 
+// Requests returns the counter of the requests the handler has received
+func (h *EmulatorHandler) Requests() *RequestCounter {
+	return h.requests
+}
+
+// SetResponseValidation enables or disables the response self-check. When enabled, every
+// successful response is unmarshaled into the generated response type for its action and a
+// warning is logged if that fails.
+func (h *EmulatorHandler) SetResponseValidation(enabled bool) {
+	h.validateResponses = enabled
+}
+
+// SetFixtures sets the canned responses the handler returns instead of handling the requests
+// they match. The first matching fixture is used.
+func (h *EmulatorHandler) SetFixtures(fixtures []Fixture) {
+	h.fixtures = fixtures
+}
+
+// SetInjectionRules sets the rules that fail or delay the requests they match. The first
+// matching rule is applied, before any fixture.
+func (h *EmulatorHandler) SetInjectionRules(rules []InjectionRule) {
+	h.injectionRules = rules
+}
+
 func (h *EmulatorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := context.Background()
 
 	service, err := h.router.Route(r)
 	if err != nil {
-		log.Printf("Failed to route request: %v", err)
+		logging.Warnf("server", "Failed to route request: %v", err)
 		h.writeErrorResponseForRequest(w, r, 400, "InvalidService", err.Error())
 		return
 	}
 
 	awsReq, err := h.convertHTTPRequest(r)
 	if err != nil {
-		log.Printf("Failed to convert HTTP request: %v", err)
+		logging.Warnf("server", "Failed to convert HTTP request: %v", err)
 		h.writeErrorResponseForService(w, r, service, 400, "InvalidRequest", err.Error())
 		return
 	}
@@ -46,19 +83,59 @@ func (h *EmulatorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		awsReq.Action = actionExtractor.ExtractAction(awsReq)
 	}
 
-	// Log the service and action for each request
-	log.Printf("Service: %s, Action: %s", service.ServiceName(), awsReq.Action)
+	// Log the service and action for each request, at the level set for the service
+	component := logging.ServiceComponent(service.ServiceName())
+	logging.Infof(component, "Service: %s, Action: %s", service.ServiceName(), awsReq.Action)
+	h.requests.Record(awsReq.Action)
+
+	path := matchPath(service, awsReq)
+	if h.inject(w, r, service, awsReq, path) {
+		return
+	}
+	for _, fixture := range h.fixtures {
+		if fixture.matches(awsReq, path) {
+			h.writeAWSResponse(w, fixture.response())
+			return
+		}
+	}
+
+	// Make the account and region the request was signed for available to services
+	ctx = emulator.WithRequestScope(ctx, emulator.ScopeFromRequest(awsReq))
 
 	awsResp, err := service.HandleRequest(ctx, awsReq)
 	if err != nil {
-		log.Printf("Service error: %v", err)
+		logging.Errorf(component, "Service error: %v", err)
 		h.writeErrorResponseForService(w, r, service, 500, "InternalFailure", err.Error())
 		return
 	}
 
+	if h.validateResponses {
+		h.validateResponse(service, awsReq.Action, awsResp)
+	}
+
+	logging.Debugf(component, "Service: %s, Action: %s, Status: %d", service.ServiceName(), awsReq.Action, awsResp.StatusCode)
+
 	h.writeAWSResponse(w, awsResp)
 }
 
+// validateResponse logs a warning if the response can't be unmarshaled into the generated
+// response type for the action. Services without generated types are skipped.
+func (h *EmulatorHandler) validateResponse(service emulator.Service, action string, resp *emulator.AWSResponse) {
+	provider, ok := service.(emulator.ResponseTypeProvider)
+	if !ok {
+		return
+	}
+
+	newResponse, ok := provider.ResponseTypes()[action]
+	if !ok {
+		return
+	}
+
+	if err := emulator.ValidateResponseUnmarshal(action, resp, newResponse()); err != nil {
+		logging.Warnf(logging.ServiceComponent(service.ServiceName()), "Warning: response validation failed for %s %s: %v", service.ServiceName(), action, err)
+	}
+}
+
 func (h *EmulatorHandler) convertHTTPRequest(r *http.Request) (*emulator.AWSRequest, error) {
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -160,7 +237,7 @@ func (h *EmulatorHandler) writeErrorResponseForService(w http.ResponseWriter, r
 	serviceName := service.ServiceName()
 
 	// JSON protocol services
-	if serviceName == "dynamodb_20120810" {
+	if serviceName == "dynamodb_20120810" || serviceName == "dynamodbstreams" || serviceName == "events" || serviceName == "states" {
 		h.writeJSONErrorResponse(w, statusCode, code, message)
 		return
 	}
@@ -173,7 +250,7 @@ func (h *EmulatorHandler) writeErrorResponseForService(w http.ResponseWriter, r
 func (h *EmulatorHandler) isJSONProtocolService(r *http.Request) bool {
 	// Check for X-Amz-Target header (used by DynamoDB and other JSON protocol services)
 	if target := r.Header.Get("X-Amz-Target"); target != "" {
-		return strings.HasPrefix(target, "DynamoDB_")
+		return strings.HasPrefix(target, "DynamoDB_") || strings.HasPrefix(target, "DynamoDBStreams_") || strings.HasPrefix(target, "AWSEvents.") || strings.HasPrefix(target, "AWSStepFunctions.")
 	}
 	return false
 }
@@ -210,6 +287,24 @@ func (h *EmulatorHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// ReadinessCheck reports whether the emulator is ready to handle AWS requests, which it is once
+// its services are registered. It returns 503 Service Unavailable until then.
+func (h *EmulatorHandler) ReadinessCheck(w http.ResponseWriter, r *http.Request) {
+	services := h.router.GetServices()
+
+	status, statusCode := "ready", http.StatusOK
+	if len(services) == 0 {
+		status, statusCode = "starting", http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":   status,
+		"services": len(services),
+	})
+}
+
 func (h *EmulatorHandler) ListServices(w http.ResponseWriter, r *http.Request) {
 	services := h.router.GetServices()
 
@@ -244,6 +339,12 @@ func (h *EmulatorHandler) RootStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Signed requests are AWS API calls, like a path-style S3 ListBuckets
+	if strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256") {
+		h.ServeHTTP(w, r)
+		return
+	}
+
 	response := map[string]string{
 		"status": "ok",
 	}
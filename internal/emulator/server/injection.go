@@ -0,0 +1,93 @@
+package server
+
+import (
+	"net/http"
+	"regexp"
+	"time"
+
+	emulator "github.com/robmorgan/infraspec/internal/emulator/core"
+)
+
+// InjectionRule fails or delays requests of an action, e.g. to see how infrastructure code
+// copes with throttling or a slow service
+type InjectionRule struct {
+	Action string
+
+	// BodyPattern, if set, must match the request body for the rule to apply
+	BodyPattern *regexp.Regexp
+
+	// PathPattern, if set, must match the request path, including any query string. See
+	// matchPath.
+	PathPattern *regexp.Regexp
+
+	// Latency delays the response to matching requests
+	Latency time.Duration
+
+	// StatusCode, if set, fails matching requests with an error in the service's protocol
+	// instead of handling them
+	StatusCode int
+	ErrorCode  string
+	Message    string
+}
+
+// matches reports whether the rule applies to the request, whose path to match is path
+func (r InjectionRule) matches(req *emulator.AWSRequest, path string) bool {
+	if r.Action != req.Action {
+		return false
+	}
+	if r.BodyPattern != nil && !r.BodyPattern.Match(req.Body) {
+		return false
+	}
+	return r.PathPattern == nil || r.PathPattern.MatchString(path)
+}
+
+// fault returns the error the rule fails requests to the service with, or nil if it only
+// delays them
+func (r InjectionRule) fault(service emulator.Service) *emulator.AWSResponse {
+	if r.StatusCode == 0 {
+		return nil
+	}
+
+	code := r.ErrorCode
+	if code == "" {
+		code = "InjectedFault"
+	}
+	message := r.Message
+	if message == "" {
+		message = "The request failed because of an injected fault"
+	}
+
+	if provider, ok := service.(emulator.ProtocolProvider); ok {
+		return emulator.BuildProtocolErrorResponse(provider.Protocol(), r.StatusCode, code, message)
+	}
+	return emulator.BuildErrorResponse(service.ServiceName(), r.StatusCode, code, message)
+}
+
+// inject applies the first injection rule that matches the request: it waits out the rule's
+// latency and, if the rule injects a fault, writes the error and returns true. It returns
+// false if the request should be handled as usual.
+func (h *EmulatorHandler) inject(w http.ResponseWriter, r *http.Request, service emulator.Service, req *emulator.AWSRequest, path string) bool {
+	for _, rule := range h.injectionRules {
+		if !rule.matches(req, path) {
+			continue
+		}
+
+		if rule.Latency > 0 {
+			timer := time.NewTimer(rule.Latency)
+			select {
+			case <-timer.C:
+			case <-r.Context().Done():
+				// The client gave up waiting, so there's no one to respond to
+				timer.Stop()
+				return true
+			}
+		}
+
+		if fault := rule.fault(service); fault != nil {
+			h.writeAWSResponse(w, fault)
+			return true
+		}
+		return false
+	}
+	return false
+}
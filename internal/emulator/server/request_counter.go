@@ -0,0 +1,38 @@
+package server
+
+import "sync"
+
+// RequestCounter counts the requests the emulator has handled, per action. It lets tests
+// assert on how their code interacted with the emulator, not just on the resulting state.
+type RequestCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// NewRequestCounter creates an empty request counter
+func NewRequestCounter() *RequestCounter {
+	return &RequestCounter{
+		counts: make(map[string]int),
+	}
+}
+
+// Record counts one request for the action
+func (c *RequestCounter) Record(action string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[action]++
+}
+
+// Count returns the number of requests recorded for the action
+func (c *RequestCounter) Count(action string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[action]
+}
+
+// Reset discards all recorded requests
+func (c *RequestCounter) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts = make(map[string]int)
+}
@@ -3,7 +3,6 @@ package server
 import (
 	"context"
 	"fmt"
-	"log"
 	"net"
 	"net/http"
 	"time"
@@ -11,6 +10,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/robmorgan/infraspec/internal/emulator/auth"
 	emulator "github.com/robmorgan/infraspec/internal/emulator/core"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 	"github.com/robmorgan/infraspec/internal/emulator/metadata"
 )
 
@@ -30,18 +30,25 @@ func NewServer(port int, emulatorRouter emulator.RequestRouter, keyStore auth.Ke
 	var authMiddleware *auth.SigV4Middleware
 	var finalHandler http.Handler
 
+	// Reject methods the service's protocol doesn't use. It routes inside the auth middleware,
+	// which sets the signing name used to resolve the service.
+	methodHandler := emulator.MethodMiddleware(emulatorRouter, handler)
+
 	if keyStore != nil {
-		// Authentication enabled - exempt health, services, and metadata endpoints
-		authMiddleware = auth.NewSigV4Middleware(keyStore, []string{"/_health", "/_services", "/latest/"})
-		finalHandler = authMiddleware.Middleware(handler)
+		// Authentication enabled - exempt health, readiness, services, and metadata endpoints
+		authMiddleware = auth.NewSigV4Middleware(keyStore, []string{"/_health", "/_infraspec/ready", "/_services", "/latest/"})
+		finalHandler = authMiddleware.Middleware(methodHandler)
 	} else {
 		// Authentication disabled
-		finalHandler = handler
+		finalHandler = methodHandler
 	}
 
 	// Health check endpoint (exempt from authentication)
 	router.HandleFunc("/_health", handler.HealthCheck).Methods("GET")
 
+	// Readiness endpoint the runner polls before running scenarios (exempt from authentication)
+	router.HandleFunc("/_infraspec/ready", handler.ReadinessCheck).Methods("GET")
+
 	// Services list endpoint (exempt from authentication)
 	router.HandleFunc("/_services", handler.ListServices).Methods("GET")
 
@@ -60,7 +67,7 @@ func NewServer(port int, emulatorRouter emulator.RequestRouter, keyStore auth.Ke
 
 	httpServer := &http.Server{
 		Addr:         fmt.Sprintf("0.0.0.0:%d", port),
-		Handler:      router,
+		Handler:      emulator.CORSMiddleware(emulator.DefaultCORSConfig(), router),
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -74,19 +81,48 @@ func NewServer(port int, emulatorRouter emulator.RequestRouter, keyStore auth.Ke
 	}
 }
 
+// EnableResponseValidation turns on the response self-check for all services.
+// See EmulatorHandler.SetResponseValidation.
+func (s *Server) EnableResponseValidation() {
+	s.handler.SetResponseValidation(true)
+}
+
+// SetFixtures sets the canned responses the server returns instead of handling the requests
+// they match. See EmulatorHandler.SetFixtures.
+func (s *Server) SetFixtures(fixtures []Fixture) {
+	s.handler.SetFixtures(fixtures)
+}
+
+// SetInjectionRules sets the rules that fail or delay the requests they match. See
+// EmulatorHandler.SetInjectionRules.
+func (s *Server) SetInjectionRules(rules []InjectionRule) {
+	s.handler.SetInjectionRules(rules)
+}
+
+// SetCORSConfig sets the CORS headers the server sends to browser clients, which default to
+// emulator.DefaultCORSConfig. It must be called before the server starts.
+func (s *Server) SetCORSConfig(config emulator.CORSConfig) {
+	s.httpServer.Handler = emulator.CORSMiddleware(config, s.router)
+}
+
+// Requests returns the counter of the AWS requests the server has handled
+func (s *Server) Requests() *RequestCounter {
+	return s.handler.Requests()
+}
+
 func (s *Server) Start() error {
-	log.Printf("Starting AWS emulator server on %s", s.httpServer.Addr)
+	logging.Infof("server", "Starting AWS emulator server on %s", s.httpServer.Addr)
 	return s.httpServer.ListenAndServe()
 }
 
 // StartWithListener starts the server using the provided listener.
 // This is useful for embedded mode where we need to control the port.
 func (s *Server) StartWithListener(listener net.Listener) error {
-	log.Printf("Starting AWS emulator server on %s", listener.Addr().String())
+	logging.Infof("server", "Starting AWS emulator server on %s", listener.Addr().String())
 	return s.httpServer.Serve(listener)
 }
 
 func (s *Server) Stop(ctx context.Context) error {
-	log.Println("Shutting down AWS emulator server...")
+	logging.Infof("server", "Shutting down AWS emulator server...")
 	return s.httpServer.Shutdown(ctx)
 }
@@ -33,8 +33,9 @@ func (s *ApplicationAutoScalingService) putScheduledAction(ctx context.Context,
 
 	// Create the scheduled action
 	now := UnixTimestamp(time.Now())
-	scheduledActionARN := fmt.Sprintf("arn:aws:autoscaling:us-east-1:000000000000:scheduledAction:%s:resource/%s/%s:scheduledActionName/%s",
-		uuid.New().String(), input.ServiceNamespace, *input.ResourceId, *input.ScheduledActionName)
+	scope := emulator.RequestScopeFromContext(ctx)
+	scheduledActionARN := fmt.Sprintf("arn:aws:autoscaling:%s:%s:scheduledAction:%s:resource/%s/%s:scheduledActionName/%s",
+		scope.Region, scope.AccountID, uuid.New().String(), input.ServiceNamespace, *input.ResourceId, *input.ScheduledActionName)
 
 	// Convert input timestamps to UnixTimestamp
 	var startTime, endTime *UnixTimestamp
@@ -27,6 +27,16 @@ func (s *ApplicationAutoScalingService) ServiceName() string {
 	return "anyscalefrontendservice"
 }
 
+// Protocol returns the AWS protocol the service speaks
+func (s *ApplicationAutoScalingService) Protocol() emulator.ProtocolType {
+	return emulator.ProtocolJSON
+}
+
+// ResponseTypes returns constructors for the CloudMirror-generated response types, keyed by action.
+func (s *ApplicationAutoScalingService) ResponseTypes() map[string]func() interface{} {
+	return smithyResponseTypes
+}
+
 func (s *ApplicationAutoScalingService) HandleRequest(ctx context.Context, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
 	if err := s.validator.ValidateRequest(req); err != nil {
 		return s.errorResponse(400, "ValidationException", err.Error()), nil
@@ -41,85 +51,85 @@ func (s *ApplicationAutoScalingService) HandleRequest(ctx context.Context, req *
 	case "RegisterScalableTarget":
 		input, err := emulator.ParseJSONRequest[RegisterScalableTargetRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.registerScalableTarget(ctx, input)
 	case "DeregisterScalableTarget":
 		input, err := emulator.ParseJSONRequest[DeregisterScalableTargetRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.deregisterScalableTarget(ctx, input)
 	case "DescribeScalableTargets":
 		input, err := emulator.ParseJSONRequest[DescribeScalableTargetsRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.describeScalableTargets(ctx, input)
 	case "PutScalingPolicy":
 		input, err := emulator.ParseJSONRequest[PutScalingPolicyRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.putScalingPolicy(ctx, input)
 	case "DeleteScalingPolicy":
 		input, err := emulator.ParseJSONRequest[DeleteScalingPolicyRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.deleteScalingPolicy(ctx, input)
 	case "DescribeScalingPolicies":
 		input, err := emulator.ParseJSONRequest[DescribeScalingPoliciesRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.describeScalingPolicies(ctx, input)
 	case "DescribeScalingActivities":
 		input, err := emulator.ParseJSONRequest[DescribeScalingActivitiesRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.describeScalingActivities(ctx, input)
 	case "ListTagsForResource":
 		input, err := emulator.ParseJSONRequest[ListTagsForResourceRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.listTagsForResource(ctx, input)
 	case "TagResource":
 		input, err := emulator.ParseJSONRequest[TagResourceRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.tagResource(ctx, input)
 	case "UntagResource":
 		input, err := emulator.ParseJSONRequest[UntagResourceRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.untagResource(ctx, input)
 	case "DeleteScheduledAction":
 		input, err := emulator.ParseJSONRequest[DeleteScheduledActionRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.deleteScheduledAction(ctx, input)
 	case "DescribeScheduledActions":
 		input, err := emulator.ParseJSONRequest[DescribeScheduledActionsRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.describeScheduledActions(ctx, input)
 	case "GetPredictiveScalingForecast":
 		input, err := emulator.ParseJSONRequest[GetPredictiveScalingForecastRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.getPredictiveScalingForecast(ctx, input)
 	case "PutScheduledAction":
 		input, err := emulator.ParseJSONRequest[PutScheduledActionRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.putScheduledAction(ctx, input)
 	default:
@@ -165,7 +175,8 @@ func (s *ApplicationAutoScalingService) registerScalableTarget(ctx context.Conte
 	key := fmt.Sprintf("autoscaling:target:%s:%s:%s", serviceNamespace, resourceId, scalableDimension)
 
 	// Generate ARN
-	targetARN := fmt.Sprintf("arn:aws:application-autoscaling:us-east-1:000000000000:scalable-target/%s", uuid.New().String())
+	scope := emulator.RequestScopeFromContext(ctx)
+	targetARN := fmt.Sprintf("arn:aws:application-autoscaling:%s:%s:scalable-target/%s", scope.Region, scope.AccountID, uuid.New().String())
 
 	// Create suspended state with defaults
 	suspendedState := &SuspendedState{
@@ -186,7 +197,7 @@ func (s *ApplicationAutoScalingService) registerScalableTarget(ctx context.Conte
 	}
 
 	// Set RoleARN default
-	roleARN := "arn:aws:iam::000000000000:role/aws-service-role/dynamodb.application-autoscaling.amazonaws.com/AWSServiceRoleForApplicationAutoScaling_DynamoDBTable"
+	roleARN := fmt.Sprintf("arn:aws:iam::%s:role/aws-service-role/dynamodb.application-autoscaling.amazonaws.com/AWSServiceRoleForApplicationAutoScaling_DynamoDBTable", scope.AccountID)
 	if input.RoleARN != nil && *input.RoleARN != "" {
 		roleARN = *input.RoleARN
 	}
@@ -315,7 +326,8 @@ func (s *ApplicationAutoScalingService) putScalingPolicy(ctx context.Context, in
 
 	// Create scaling policy
 	now := UnixTimestamp(time.Now())
-	policyARN := fmt.Sprintf("arn:aws:autoscaling:us-east-1:000000000000:scalingPolicy:%s:resource/%s/%s:policyName/%s", uuid.New().String(), input.ServiceNamespace, *input.ResourceId, *input.PolicyName)
+	scope := emulator.RequestScopeFromContext(ctx)
+	policyARN := fmt.Sprintf("arn:aws:autoscaling:%s:%s:scalingPolicy:%s:resource/%s/%s:policyName/%s", scope.Region, scope.AccountID, uuid.New().String(), input.ServiceNamespace, *input.ResourceId, *input.PolicyName)
 	policy := &ScalingPolicy{
 		PolicyName:                               input.PolicyName,
 		ServiceNamespace:                         input.ServiceNamespace,
@@ -489,3 +501,8 @@ func (s *ApplicationAutoScalingService) untagResource(ctx context.Context, input
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+var (
+	_ emulator.Service              = (*ApplicationAutoScalingService)(nil)
+	_ emulator.ResponseTypeProvider = (*ApplicationAutoScalingService)(nil)
+)
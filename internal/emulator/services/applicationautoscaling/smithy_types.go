@@ -32,6 +32,24 @@ func (t *UnixTimestamp) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// smithyResponseTypes maps operation names to constructors for their generated response types.
+var smithyResponseTypes = map[string]func() interface{}{
+	"DeleteScalingPolicy":          func() interface{} { return &DeleteScalingPolicyResponse{} },
+	"DeleteScheduledAction":        func() interface{} { return &DeleteScheduledActionResponse{} },
+	"DeregisterScalableTarget":     func() interface{} { return &DeregisterScalableTargetResponse{} },
+	"DescribeScalableTargets":      func() interface{} { return &DescribeScalableTargetsResponse{} },
+	"DescribeScalingActivities":    func() interface{} { return &DescribeScalingActivitiesResponse{} },
+	"DescribeScalingPolicies":      func() interface{} { return &DescribeScalingPoliciesResponse{} },
+	"DescribeScheduledActions":     func() interface{} { return &DescribeScheduledActionsResponse{} },
+	"GetPredictiveScalingForecast": func() interface{} { return &GetPredictiveScalingForecastResponse{} },
+	"ListTagsForResource":          func() interface{} { return &ListTagsForResourceResponse{} },
+	"PutScalingPolicy":             func() interface{} { return &PutScalingPolicyResponse{} },
+	"PutScheduledAction":           func() interface{} { return &PutScheduledActionResponse{} },
+	"RegisterScalableTarget":       func() interface{} { return &RegisterScalableTargetResponse{} },
+	"TagResource":                  func() interface{} { return &TagResourceResponse{} },
+	"UntagResource":                func() interface{} { return &UntagResourceResponse{} },
+}
+
 // Enum type aliases
 
 type AdjustmentType string
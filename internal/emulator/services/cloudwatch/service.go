@@ -0,0 +1,583 @@
+package cloudwatch
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+)
+
+const (
+	metricKeyPrefix = "cloudwatch:metric:"
+
+	// maxMetricDatums is the most datums PutMetricData accepts in one request
+	maxMetricDatums = 1000
+)
+
+// CloudWatchService implements a minimal CloudWatch metrics emulator. Published datapoints are
+// kept in state and aggregated when they are read back; alarms, dashboards and metric math
+// expressions aren't supported.
+type CloudWatchService struct {
+	state     emulator.StateManager
+	validator emulator.Validator
+	clock     emulator.Clock
+}
+
+// NewCloudWatchService creates a new CloudWatch service instance
+func NewCloudWatchService(state emulator.StateManager, validator emulator.Validator) *CloudWatchService {
+	return &CloudWatchService{
+		state:     state,
+		validator: validator,
+		clock:     emulator.SystemClock{},
+	}
+}
+
+// SetClock sets the clock used to timestamp datapoints published without a timestamp
+func (s *CloudWatchService) SetClock(clock emulator.Clock) {
+	s.clock = clock
+}
+
+// ServiceName returns the service identifier
+func (s *CloudWatchService) ServiceName() string {
+	return "monitoring"
+}
+
+// Protocol returns the AWS protocol the service speaks
+func (s *CloudWatchService) Protocol() emulator.ProtocolType {
+	return emulator.ProtocolQuery
+}
+
+// SupportedActions returns the list of AWS API actions this service handles.
+// Used by the router to determine which service handles a given Query Protocol request.
+func (s *CloudWatchService) SupportedActions() []string {
+	return []string{
+		"GetMetricData",
+		"GetMetricStatistics",
+		"ListMetrics",
+		"PutMetricData",
+	}
+}
+
+// HandleRequest routes incoming requests to the appropriate handler
+func (s *CloudWatchService) HandleRequest(ctx context.Context, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	if err := s.validator.ValidateRequest(req); err != nil {
+		return s.errorResponse(400, "ValidationError", err.Error()), nil
+	}
+
+	params, err := s.parseParameters(req)
+	if err != nil {
+		return s.errorResponse(400, "InvalidParameterValue", err.Error()), nil
+	}
+
+	switch req.Action {
+	case "PutMetricData":
+		return s.putMetricData(ctx, params)
+	case "GetMetricStatistics":
+		return s.getMetricStatistics(ctx, params)
+	case "GetMetricData":
+		return s.getMetricData(ctx, params)
+	case "ListMetrics":
+		return s.listMetrics(ctx, params)
+	default:
+		return s.errorResponse(400, "InvalidAction", fmt.Sprintf("Unknown action: %s", req.Action)), nil
+	}
+}
+
+func (s *CloudWatchService) parseParameters(req *emulator.AWSRequest) (map[string]interface{}, error) {
+	if req.Parameters != nil {
+		return req.Parameters, nil
+	}
+
+	values, err := url.ParseQuery(string(req.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]interface{})
+	for key, vals := range values {
+		if len(vals) == 1 {
+			params[key] = vals[0]
+		} else {
+			params[key] = vals
+		}
+	}
+	return params, nil
+}
+
+func (s *CloudWatchService) putMetricData(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	namespace := emulator.GetStringParam(params, "Namespace", "")
+	if namespace == "" {
+		return s.errorResponse(400, "MissingParameter", "The parameter Namespace is required."), nil
+	}
+	if strings.HasPrefix(namespace, "AWS/") {
+		return s.errorResponse(400, "InvalidParameterValue", fmt.Sprintf("The value %s for parameter Namespace is invalid.", namespace)), nil
+	}
+
+	// Group the datums by metric so each metric is written once
+	metrics := make(map[string]*Metric)
+	var keys []string
+	for i := 1; ; i++ {
+		prefix := fmt.Sprintf("MetricData.member.%d", i)
+		metricName := emulator.GetStringParam(params, prefix+".MetricName", "")
+		if metricName == "" {
+			if i == 1 {
+				return s.errorResponse(400, "MissingParameter", "The parameter MetricData is required."), nil
+			}
+			break
+		}
+		if i > maxMetricDatums {
+			return s.errorResponse(400, "InvalidParameterValue", fmt.Sprintf("The collection MetricData must not have a size greater than %d.", maxMetricDatums)), nil
+		}
+
+		datapoints, err := s.parseDatapoints(params, prefix)
+		if err != nil {
+			return s.errorResponse(400, "InvalidParameterValue", err.Error()), nil
+		}
+
+		dimensions := sortedDimensions(parseDimensions(params, prefix+".Dimensions"))
+		key := metricKey(namespace, metricName, dimensions)
+		metric, ok := metrics[key]
+		if !ok {
+			if metric, err = s.loadMetric(namespace, metricName, dimensions); err != nil {
+				return s.errorResponse(500, "InternalFailure", err.Error()), nil
+			}
+			metrics[key] = metric
+			keys = append(keys, key)
+		}
+		metric.Datapoints = append(metric.Datapoints, datapoints...)
+	}
+
+	for _, key := range keys {
+		if err := s.state.Set(key, metrics[key]); err != nil {
+			return s.errorResponse(500, "InternalFailure", fmt.Sprintf("failed to store metric %s: %v", metrics[key].MetricName, err)), nil
+		}
+	}
+
+	return s.successResponse("PutMetricData", EmptyResult{})
+}
+
+// parseDatapoints reads the datapoints of the datum at prefix, which is published either as a
+// single value, a list of values with optional counts, or a statistic set
+func (s *CloudWatchService) parseDatapoints(params map[string]interface{}, prefix string) ([]Datapoint, error) {
+	timestamp := s.clock.Now()
+	if raw := emulator.GetStringParam(params, prefix+".Timestamp", ""); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, fmt.Errorf("the value %s for parameter Timestamp is invalid", raw)
+		}
+		timestamp = parsed.UTC()
+	}
+	unit := emulator.GetStringParam(params, prefix+".Unit", "")
+
+	if raw := emulator.GetStringParam(params, prefix+".Value", ""); raw != "" {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("the value %s for parameter Value is invalid", raw)
+		}
+		return []Datapoint{singleDatapoint(timestamp, value, 1, unit)}, nil
+	}
+
+	values, err := parseFloatList(params, prefix+".Values")
+	if err != nil {
+		return nil, err
+	}
+	if len(values) > 0 {
+		counts, err := parseFloatList(params, prefix+".Counts")
+		if err != nil {
+			return nil, err
+		}
+		if len(counts) > 0 && len(counts) != len(values) {
+			return nil, fmt.Errorf("the Values and Counts lists must have the same length")
+		}
+
+		datapoints := make([]Datapoint, 0, len(values))
+		for i, value := range values {
+			count := 1.0
+			if len(counts) > 0 {
+				count = counts[i]
+			}
+			datapoints = append(datapoints, singleDatapoint(timestamp, value, count, unit))
+		}
+		return datapoints, nil
+	}
+
+	if _, ok := params[prefix+".StatisticValues.SampleCount"]; ok {
+		stats := make(map[string]float64)
+		for _, name := range []string{"SampleCount", "Sum", "Minimum", "Maximum"} {
+			raw := emulator.GetStringParam(params, prefix+".StatisticValues."+name, "")
+			value, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return nil, fmt.Errorf("the value %q for parameter StatisticValues.%s is invalid", raw, name)
+			}
+			stats[name] = value
+		}
+		return []Datapoint{{
+			Timestamp:   timestamp,
+			SampleCount: stats["SampleCount"],
+			Sum:         stats["Sum"],
+			Minimum:     stats["Minimum"],
+			Maximum:     stats["Maximum"],
+			Unit:        unit,
+		}}, nil
+	}
+
+	return nil, fmt.Errorf("one of Value, Values or StatisticValues is required for metric %s", emulator.GetStringParam(params, prefix+".MetricName", ""))
+}
+
+func (s *CloudWatchService) getMetricStatistics(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	namespace := emulator.GetStringParam(params, "Namespace", "")
+	metricName := emulator.GetStringParam(params, "MetricName", "")
+	if namespace == "" || metricName == "" {
+		return s.errorResponse(400, "MissingParameter", "The parameters Namespace and MetricName are required."), nil
+	}
+
+	start, end, err := parseTimeRange(params, "StartTime", "EndTime")
+	if err != nil {
+		return s.errorResponse(400, "InvalidParameterValue", err.Error()), nil
+	}
+	period := emulator.GetInt32Param(params, "Period", 0)
+	if period <= 0 {
+		return s.errorResponse(400, "InvalidParameterValue", "The parameter Period must be greater than 0."), nil
+	}
+
+	statistics := parseStringList(params, "Statistics")
+	if len(statistics) == 0 {
+		return s.errorResponse(400, "MissingParameter", "The parameter Statistics is required."), nil
+	}
+	for _, stat := range statistics {
+		if !isSupportedStatistic(stat) {
+			return s.errorResponse(400, "InvalidParameterValue", fmt.Sprintf("The value %s for parameter Statistics is invalid.", stat)), nil
+		}
+	}
+
+	metric, err := s.loadMetric(namespace, metricName, parseDimensions(params, "Dimensions"))
+	if err != nil {
+		return s.errorResponse(500, "InternalFailure", err.Error()), nil
+	}
+
+	result := GetMetricStatisticsResult{Label: metricName}
+	unit := emulator.GetStringParam(params, "Unit", "")
+	for _, bucket := range aggregate(metric, start, end, period, unit) {
+		datapoint := XMLDatapoint{Timestamp: bucket.Timestamp, Unit: bucket.Unit}
+		for _, stat := range statistics {
+			value := statisticValue(bucket, stat)
+			switch stat {
+			case "SampleCount":
+				datapoint.SampleCount = &value
+			case "Average":
+				datapoint.Average = &value
+			case "Sum":
+				datapoint.Sum = &value
+			case "Minimum":
+				datapoint.Minimum = &value
+			case "Maximum":
+				datapoint.Maximum = &value
+			}
+		}
+		result.Datapoints = append(result.Datapoints, datapoint)
+	}
+
+	return s.successResponse("GetMetricStatistics", result)
+}
+
+func (s *CloudWatchService) getMetricData(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	start, end, err := parseTimeRange(params, "StartTime", "EndTime")
+	if err != nil {
+		return s.errorResponse(400, "InvalidParameterValue", err.Error()), nil
+	}
+	descending := emulator.GetStringParam(params, "ScanBy", "TimestampDescending") == "TimestampDescending"
+
+	var result GetMetricDataResult
+	for i := 1; ; i++ {
+		prefix := fmt.Sprintf("MetricDataQueries.member.%d", i)
+		id := emulator.GetStringParam(params, prefix+".Id", "")
+		if id == "" {
+			if i == 1 {
+				return s.errorResponse(400, "MissingParameter", "The parameter MetricDataQueries is required."), nil
+			}
+			break
+		}
+		if _, ok := params[prefix+".Expression"]; ok {
+			return s.errorResponse(400, "InvalidParameterValue", fmt.Sprintf("Metric math expressions are not supported (query %s).", id)), nil
+		}
+
+		namespace := emulator.GetStringParam(params, prefix+".MetricStat.Metric.Namespace", "")
+		metricName := emulator.GetStringParam(params, prefix+".MetricStat.Metric.MetricName", "")
+		stat := emulator.GetStringParam(params, prefix+".MetricStat.Stat", "")
+		period := emulator.GetInt32Param(params, prefix+".MetricStat.Period", 0)
+		if namespace == "" || metricName == "" || stat == "" || period <= 0 {
+			return s.errorResponse(400, "InvalidParameterValue", fmt.Sprintf("The query %s must have a MetricStat with a metric, period and stat.", id)), nil
+		}
+		if !isSupportedStatistic(stat) {
+			return s.errorResponse(400, "InvalidParameterValue", fmt.Sprintf("The value %s for parameter Stat is invalid.", stat)), nil
+		}
+
+		metric, err := s.loadMetric(namespace, metricName, parseDimensions(params, prefix+".MetricStat.Metric.Dimensions"))
+		if err != nil {
+			return s.errorResponse(500, "InternalFailure", err.Error()), nil
+		}
+		if !emulator.GetBoolParam(params, prefix+".ReturnData", true) {
+			continue
+		}
+
+		data := XMLMetricDataResult{
+			Id:         id,
+			Label:      emulator.GetStringParam(params, prefix+".Label", metricName),
+			StatusCode: "Complete",
+		}
+		buckets := aggregate(metric, start, end, period, emulator.GetStringParam(params, prefix+".MetricStat.Unit", ""))
+		if descending {
+			sort.Slice(buckets, func(a, b int) bool { return buckets[a].Timestamp.After(buckets[b].Timestamp) })
+		}
+		for _, bucket := range buckets {
+			data.Timestamps = append(data.Timestamps, bucket.Timestamp)
+			data.Values = append(data.Values, statisticValue(bucket, stat))
+		}
+		result.MetricDataResults = append(result.MetricDataResults, data)
+	}
+
+	return s.successResponse("GetMetricData", result)
+}
+
+func (s *CloudWatchService) listMetrics(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	namespace := emulator.GetStringParam(params, "Namespace", "")
+	metricName := emulator.GetStringParam(params, "MetricName", "")
+	filters := parseDimensions(params, "Dimensions")
+
+	keys, err := s.state.List(metricKeyPrefix)
+	if err != nil {
+		return s.errorResponse(500, "InternalFailure", fmt.Sprintf("failed to list metrics: %v", err)), nil
+	}
+	sort.Strings(keys)
+
+	var result ListMetricsResult
+	for _, key := range keys {
+		var metric Metric
+		if err := s.state.Get(key, &metric); err != nil {
+			continue
+		}
+		if namespace != "" && metric.Namespace != namespace {
+			continue
+		}
+		if metricName != "" && metric.MetricName != metricName {
+			continue
+		}
+		if !matchesDimensionFilters(metric.Dimensions, filters) {
+			continue
+		}
+		result.Metrics = append(result.Metrics, XMLMetric{
+			Namespace:  metric.Namespace,
+			MetricName: metric.MetricName,
+			Dimensions: metric.Dimensions,
+		})
+	}
+
+	return s.successResponse("ListMetrics", result)
+}
+
+// loadMetric returns the metric with exactly the given dimensions, or an empty metric if
+// nothing has been published to it
+func (s *CloudWatchService) loadMetric(namespace, metricName string, dimensions []Dimension) (*Metric, error) {
+	dimensions = sortedDimensions(dimensions)
+	metric := &Metric{Namespace: namespace, MetricName: metricName, Dimensions: dimensions}
+	key := metricKey(namespace, metricName, dimensions)
+	if !s.state.Exists(key) {
+		return metric, nil
+	}
+	if err := s.state.Get(key, metric); err != nil {
+		return nil, fmt.Errorf("failed to load metric %s: %w", metricName, err)
+	}
+	return metric, nil
+}
+
+func (s *CloudWatchService) successResponse(action string, data interface{}) (*emulator.AWSResponse, error) {
+	return emulator.BuildQueryResponse(action, data, emulator.ResponseBuilderConfig{
+		ServiceName: "monitoring",
+		Version:     "2010-08-01",
+	})
+}
+
+func (s *CloudWatchService) errorResponse(statusCode int, code, message string) *emulator.AWSResponse {
+	return emulator.BuildErrorResponse("monitoring", statusCode, code, message)
+}
+
+// aggregate combines the metric's datapoints in [start, end) into one datapoint per period,
+// aligned to multiples of the period since the Unix epoch and ordered by time
+func aggregate(metric *Metric, start, end time.Time, period int32, unit string) []Datapoint {
+	buckets := make(map[int64]*Datapoint)
+	for _, dp := range metric.Datapoints {
+		if dp.Timestamp.Before(start) || !dp.Timestamp.Before(end) {
+			continue
+		}
+		if unit != "" && dp.Unit != unit {
+			continue
+		}
+
+		slot := dp.Timestamp.Unix() / int64(period) * int64(period)
+		bucket, ok := buckets[slot]
+		if !ok {
+			buckets[slot] = &Datapoint{
+				Timestamp:   time.Unix(slot, 0).UTC(),
+				SampleCount: dp.SampleCount,
+				Sum:         dp.Sum,
+				Minimum:     dp.Minimum,
+				Maximum:     dp.Maximum,
+				Unit:        dp.Unit,
+			}
+			continue
+		}
+		bucket.SampleCount += dp.SampleCount
+		bucket.Sum += dp.Sum
+		bucket.Minimum = math.Min(bucket.Minimum, dp.Minimum)
+		bucket.Maximum = math.Max(bucket.Maximum, dp.Maximum)
+	}
+
+	result := make([]Datapoint, 0, len(buckets))
+	for _, bucket := range buckets {
+		result = append(result, *bucket)
+	}
+	sort.Slice(result, func(a, b int) bool { return result[a].Timestamp.Before(result[b].Timestamp) })
+	return result
+}
+
+func isSupportedStatistic(stat string) bool {
+	switch stat {
+	case "SampleCount", "Average", "Sum", "Minimum", "Maximum":
+		return true
+	}
+	return false
+}
+
+func statisticValue(dp Datapoint, stat string) float64 {
+	switch stat {
+	case "SampleCount":
+		return dp.SampleCount
+	case "Average":
+		if dp.SampleCount == 0 {
+			return 0
+		}
+		return dp.Sum / dp.SampleCount
+	case "Sum":
+		return dp.Sum
+	case "Minimum":
+		return dp.Minimum
+	case "Maximum":
+		return dp.Maximum
+	}
+	return 0
+}
+
+func singleDatapoint(timestamp time.Time, value, count float64, unit string) Datapoint {
+	return Datapoint{
+		Timestamp:   timestamp,
+		SampleCount: count,
+		Sum:         value * count,
+		Minimum:     value,
+		Maximum:     value,
+		Unit:        unit,
+	}
+}
+
+// metricKey returns the state key of a metric; dimensions must already be sorted
+func metricKey(namespace, metricName string, dimensions []Dimension) string {
+	parts := make([]string, 0, len(dimensions))
+	for _, d := range dimensions {
+		parts = append(parts, d.Name+"="+d.Value)
+	}
+	return fmt.Sprintf("%s%s|%s|%s", metricKeyPrefix, namespace, metricName, strings.Join(parts, ","))
+}
+
+func sortedDimensions(dimensions []Dimension) []Dimension {
+	sorted := append([]Dimension(nil), dimensions...)
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a].Name < sorted[b].Name })
+	return sorted
+}
+
+// matchesDimensionFilters reports whether the dimensions satisfy every filter. A filter
+// without a value matches any value of the named dimension.
+func matchesDimensionFilters(dimensions, filters []Dimension) bool {
+	for _, filter := range filters {
+		found := false
+		for _, d := range dimensions {
+			if d.Name == filter.Name && (filter.Value == "" || d.Value == filter.Value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDimensions parses AWS-style indexed dimensions: Key.member.1.Name, Key.member.1.Value, etc.
+func parseDimensions(params map[string]interface{}, key string) []Dimension {
+	var dimensions []Dimension
+	for i := 1; ; i++ {
+		name := emulator.GetStringParam(params, fmt.Sprintf("%s.member.%d.Name", key, i), "")
+		if name == "" {
+			break
+		}
+		dimensions = append(dimensions, Dimension{
+			Name:  name,
+			Value: emulator.GetStringParam(params, fmt.Sprintf("%s.member.%d.Value", key, i), ""),
+		})
+	}
+	return dimensions
+}
+
+// parseStringList parses AWS-style indexed parameters: Key.member.1, Key.member.2, etc.
+func parseStringList(params map[string]interface{}, key string) []string {
+	var result []string
+	for i := 1; ; i++ {
+		val, ok := params[fmt.Sprintf("%s.member.%d", key, i)].(string)
+		if !ok {
+			break
+		}
+		result = append(result, val)
+	}
+	return result
+}
+
+func parseFloatList(params map[string]interface{}, key string) ([]float64, error) {
+	var result []float64
+	for _, raw := range parseStringList(params, key) {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("the value %s for parameter %s is invalid", raw, key)
+		}
+		result = append(result, value)
+	}
+	return result, nil
+}
+
+func parseTimeRange(params map[string]interface{}, startKey, endKey string) (time.Time, time.Time, error) {
+	var times [2]time.Time
+	for i, key := range []string{startKey, endKey} {
+		raw := emulator.GetStringParam(params, key, "")
+		if raw == "" {
+			return time.Time{}, time.Time{}, fmt.Errorf("the parameter %s is required", key)
+		}
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("the value %s for parameter %s is invalid", raw, key)
+		}
+		times[i] = parsed.UTC()
+	}
+	if !times[0].Before(times[1]) {
+		return time.Time{}, time.Time{}, fmt.Errorf("the parameter %s must be before %s", startKey, endKey)
+	}
+	return times[0], times[1], nil
+}
+
+var (
+	_ emulator.Service        = (*CloudWatchService)(nil)
+	_ emulator.ActionProvider = (*CloudWatchService)(nil)
+)
@@ -0,0 +1,146 @@
+package cloudwatch
+
+import (
+	"context"
+	"encoding/xml"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+)
+
+var testStart = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestService() *CloudWatchService {
+	service := NewCloudWatchService(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	service.SetClock(&emulator.FixedClock{Time: testStart})
+	return service
+}
+
+// call sends a Query request for action and decodes the <ActionResult> element of the response into out
+func call(t *testing.T, service *CloudWatchService, action string, params url.Values, out interface{}) int {
+	t.Helper()
+	params.Set("Action", action)
+	resp, err := service.HandleRequest(context.Background(), &emulator.AWSRequest{
+		Method:  "POST",
+		Action:  action,
+		Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+		Body:    []byte(params.Encode()),
+	})
+	require.NoError(t, err)
+	if out != nil && resp.StatusCode == 200 {
+		envelope := struct {
+			Inner []byte `xml:",innerxml"`
+		}{}
+		require.NoError(t, xml.Unmarshal(resp.Body, &envelope))
+		require.NoError(t, xml.Unmarshal(envelope.Inner, out), string(resp.Body))
+	}
+	return resp.StatusCode
+}
+
+func putValues(t *testing.T, service *CloudWatchService, metricName, queue string, values ...string) {
+	t.Helper()
+	params := url.Values{"Namespace": {"Orders"}}
+	for i, value := range values {
+		prefix := "MetricData.member." + strconv.Itoa(i+1)
+		params.Set(prefix+".MetricName", metricName)
+		params.Set(prefix+".Value", value)
+		params.Set(prefix+".Dimensions.member.1.Name", "Queue")
+		params.Set(prefix+".Dimensions.member.1.Value", queue)
+	}
+	require.Equal(t, 200, call(t, service, "PutMetricData", params, nil))
+}
+
+func TestGetMetricStatistics_AggregatesDatapoints(t *testing.T) {
+	service := newTestService()
+	putValues(t, service, "Processed", "orders", "2", "4")
+	putValues(t, service, "Processed", "refunds", "100")
+
+	var result GetMetricStatisticsResult
+	status := call(t, service, "GetMetricStatistics", url.Values{
+		"Namespace":                 {"Orders"},
+		"MetricName":                {"Processed"},
+		"Dimensions.member.1.Name":  {"Queue"},
+		"Dimensions.member.1.Value": {"orders"},
+		"StartTime":                 {testStart.Add(-time.Hour).Format(time.RFC3339)},
+		"EndTime":                   {testStart.Add(time.Hour).Format(time.RFC3339)},
+		"Period":                    {"60"},
+		"Statistics.member.1":       {"SampleCount"},
+		"Statistics.member.2":       {"Average"},
+		"Statistics.member.3":       {"Maximum"},
+	}, &result)
+	require.Equal(t, 200, status)
+
+	require.Len(t, result.Datapoints, 1)
+	datapoint := result.Datapoints[0]
+	assert.Equal(t, testStart, datapoint.Timestamp)
+	assert.Equal(t, 2.0, *datapoint.SampleCount)
+	assert.Equal(t, 3.0, *datapoint.Average)
+	assert.Equal(t, 4.0, *datapoint.Maximum)
+	assert.Nil(t, datapoint.Sum)
+}
+
+func TestGetMetricData_ReturnsNewestFirst(t *testing.T) {
+	service := newTestService()
+	clock := &emulator.FixedClock{Time: testStart}
+	service.SetClock(clock)
+	putValues(t, service, "Latency", "orders", "10")
+	clock.Advance(time.Minute)
+	putValues(t, service, "Latency", "orders", "20", "30")
+
+	var result GetMetricDataResult
+	status := call(t, service, "GetMetricData", url.Values{
+		"StartTime":                     {testStart.Format(time.RFC3339)},
+		"EndTime":                       {testStart.Add(time.Hour).Format(time.RFC3339)},
+		"MetricDataQueries.member.1.Id": {"latency"},
+		"MetricDataQueries.member.1.MetricStat.Metric.Namespace":                 {"Orders"},
+		"MetricDataQueries.member.1.MetricStat.Metric.MetricName":                {"Latency"},
+		"MetricDataQueries.member.1.MetricStat.Metric.Dimensions.member.1.Name":  {"Queue"},
+		"MetricDataQueries.member.1.MetricStat.Metric.Dimensions.member.1.Value": {"orders"},
+		"MetricDataQueries.member.1.MetricStat.Period":                           {"60"},
+		"MetricDataQueries.member.1.MetricStat.Stat":                             {"Sum"},
+	}, &result)
+	require.Equal(t, 200, status)
+
+	require.Len(t, result.MetricDataResults, 1)
+	data := result.MetricDataResults[0]
+	assert.Equal(t, "latency", data.Id)
+	assert.Equal(t, "Complete", data.StatusCode)
+	assert.Equal(t, []time.Time{testStart.Add(time.Minute), testStart}, data.Timestamps)
+	assert.Equal(t, []float64{50, 10}, data.Values)
+}
+
+func TestListMetrics_FiltersByDimension(t *testing.T) {
+	service := newTestService()
+	putValues(t, service, "Processed", "orders", "1")
+	putValues(t, service, "Processed", "refunds", "1")
+	putValues(t, service, "Failed", "orders", "1")
+
+	var result ListMetricsResult
+	require.Equal(t, 200, call(t, service, "ListMetrics", url.Values{"Namespace": {"Orders"}}, &result))
+	assert.Len(t, result.Metrics, 3)
+
+	result = ListMetricsResult{}
+	require.Equal(t, 200, call(t, service, "ListMetrics", url.Values{
+		"MetricName":                {"Processed"},
+		"Dimensions.member.1.Name":  {"Queue"},
+		"Dimensions.member.1.Value": {"refunds"},
+	}, &result))
+	require.Len(t, result.Metrics, 1)
+	assert.Equal(t, []Dimension{{Name: "Queue", Value: "refunds"}}, result.Metrics[0].Dimensions)
+}
+
+func TestPutMetricData_RejectsReservedNamespace(t *testing.T) {
+	service := newTestService()
+	status := call(t, service, "PutMetricData", url.Values{
+		"Namespace":                      {"AWS/SQS"},
+		"MetricData.member.1.MetricName": {"Sent"},
+		"MetricData.member.1.Value":      {"1"},
+	}, nil)
+	assert.Equal(t, 400, status)
+}
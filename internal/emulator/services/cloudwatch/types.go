@@ -0,0 +1,87 @@
+package cloudwatch
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// ============================================================================
+// Internal Storage Types
+// ============================================================================
+
+// Metric is a CloudWatch metric stored in state: a namespace, name and set of dimensions,
+// with every datapoint published to it
+type Metric struct {
+	Namespace  string      `json:"namespace"`
+	MetricName string      `json:"metricName"`
+	Dimensions []Dimension `json:"dimensions,omitempty"`
+	Datapoints []Datapoint `json:"datapoints"`
+}
+
+// Datapoint is a single published value, or the statistic set of several values
+type Datapoint struct {
+	Timestamp   time.Time `json:"timestamp"`
+	SampleCount float64   `json:"sampleCount"`
+	Sum         float64   `json:"sum"`
+	Minimum     float64   `json:"minimum"`
+	Maximum     float64   `json:"maximum"`
+	Unit        string    `json:"unit,omitempty"`
+}
+
+// Dimension is a name/value pair that identifies a metric
+type Dimension struct {
+	Name  string `json:"name" xml:"Name"`
+	Value string `json:"value" xml:"Value"`
+}
+
+// ============================================================================
+// Response Types
+// ============================================================================
+
+type EmptyResult struct {
+	XMLName xml.Name `xml:""`
+}
+
+// XMLMetric is a metric in a ListMetrics response
+type XMLMetric struct {
+	Namespace  string      `xml:"Namespace"`
+	MetricName string      `xml:"MetricName"`
+	Dimensions []Dimension `xml:"Dimensions>member"`
+}
+
+type ListMetricsResult struct {
+	XMLName xml.Name    `xml:"ListMetricsResult"`
+	Metrics []XMLMetric `xml:"Metrics>member"`
+}
+
+// XMLDatapoint is an aggregated datapoint in a GetMetricStatistics response. Only the
+// requested statistics are set.
+type XMLDatapoint struct {
+	Timestamp   time.Time `xml:"Timestamp"`
+	SampleCount *float64  `xml:"SampleCount,omitempty"`
+	Average     *float64  `xml:"Average,omitempty"`
+	Sum         *float64  `xml:"Sum,omitempty"`
+	Minimum     *float64  `xml:"Minimum,omitempty"`
+	Maximum     *float64  `xml:"Maximum,omitempty"`
+	Unit        string    `xml:"Unit,omitempty"`
+}
+
+type GetMetricStatisticsResult struct {
+	XMLName    xml.Name       `xml:"GetMetricStatisticsResult"`
+	Label      string         `xml:"Label"`
+	Datapoints []XMLDatapoint `xml:"Datapoints>member"`
+}
+
+// XMLMetricDataResult is the result of one query in a GetMetricData response
+type XMLMetricDataResult struct {
+	Id         string      `xml:"Id"`
+	Label      string      `xml:"Label"`
+	StatusCode string      `xml:"StatusCode"`
+	Timestamps []time.Time `xml:"Timestamps>member"`
+	Values     []float64   `xml:"Values>member"`
+}
+
+type GetMetricDataResult struct {
+	XMLName           xml.Name              `xml:"GetMetricDataResult"`
+	MetricDataResults []XMLMetricDataResult `xml:"MetricDataResults>member"`
+}
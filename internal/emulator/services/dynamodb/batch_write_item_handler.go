@@ -0,0 +1,86 @@
+package dynamodb
+
+import (
+	"context"
+	"sort"
+
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+)
+
+// maxBatchWriteRequests is how many puts and deletes a BatchWriteItem request can make
+const maxBatchWriteRequests = 25
+
+func (s *DynamoDBService) batchWriteItem(ctx context.Context, input *BatchWriteItemRequest) (*emulator.AWSResponse, error) {
+	if len(input.RequestItems) == 0 {
+		return s.errorResponse(400, "ValidationException", "RequestItems is required"), nil
+	}
+
+	count := 0
+	for _, requests := range input.RequestItems {
+		for _, request := range requests {
+			if (request.PutRequest == nil) == (request.DeleteRequest == nil) {
+				return s.errorResponse(400, "ValidationException", "Each write request must have exactly one of PutRequest or DeleteRequest"), nil
+			}
+			count++
+		}
+	}
+	if count > maxBatchWriteRequests {
+		return s.errorResponse(400, "ValidationException", "Too many items requested for the BatchWriteItem call"), nil
+	}
+
+	tableNames := make([]string, 0, len(input.RequestItems))
+	for tableName := range input.RequestItems {
+		tableNames = append(tableNames, tableName)
+	}
+	sort.Strings(tableNames)
+
+	// The emulator processes every request, so nothing is left unprocessed
+	var capacities []*ConsumedCapacity
+	for _, tableName := range tableNames {
+		units := 0.0
+		for _, request := range input.RequestItems[tableName] {
+			name := tableName
+			var resp *emulator.AWSResponse
+			var err error
+			if request.PutRequest != nil {
+				units += writeCapacityUnits(itemSize(request.PutRequest.Item))
+				resp, err = s.putItem(ctx, &PutItemRequest{PutItemInput: PutItemInput{TableName: &name}, Item: request.PutRequest.Item})
+			} else {
+				units += writeCapacityUnits(s.storedItemSize(tableName, request.DeleteRequest.Key))
+				resp, err = s.deleteItem(ctx, &DeleteItemRequest{DeleteItemInput: DeleteItemInput{TableName: &name}, Key: request.DeleteRequest.Key})
+			}
+			if err != nil || resp.StatusCode != 200 {
+				return resp, err
+			}
+		}
+		if capacity := consumedCapacity(tableName, input.ReturnConsumedCapacity, units); capacity != nil {
+			capacities = append(capacities, capacity)
+		}
+	}
+
+	response := map[string]interface{}{
+		"UnprocessedItems": map[string]interface{}{},
+	}
+	if capacities != nil {
+		response["ConsumedCapacity"] = capacities
+	}
+	return s.jsonResponse(200, response)
+}
+
+// storedItemSize returns the size of the item with the key in the table, or 0 if there isn't one
+func (s *DynamoDBService) storedItemSize(tableName string, key AttributeMap) int {
+	tableDesc, errResp := s.loadTable(tableName)
+	if errResp != nil {
+		return 0
+	}
+	key, err := lookupKey(tableDesc, key)
+	if err != nil {
+		return 0
+	}
+	itemKey, err := itemStateKey(tableName, key)
+	if err != nil {
+		return 0
+	}
+	item, _ := s.loadItem(itemKey)
+	return itemSize(item)
+}
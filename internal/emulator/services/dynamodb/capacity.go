@@ -0,0 +1,87 @@
+package dynamodb
+
+import (
+	"encoding/base64"
+	"math"
+)
+
+// consumedCapacity returns the capacity an operation on a table consumed, for requests whose
+// ReturnConsumedCapacity is TOTAL or INDEXES, or nil otherwise. The emulator has no indexes
+// that consume capacity, so INDEXES only adds the table's share.
+func consumedCapacity(tableName string, mode ReturnConsumedCapacity, units float64) *ConsumedCapacity {
+	switch mode {
+	case "TOTAL":
+		return &ConsumedCapacity{TableName: &tableName, CapacityUnits: &units}
+	case "INDEXES":
+		return &ConsumedCapacity{TableName: &tableName, CapacityUnits: &units, Table: &Capacity{CapacityUnits: &units}}
+	default:
+		return nil
+	}
+}
+
+// readCapacityUnits returns the capacity units of reading size bytes: one per 4 KB, rounded up,
+// for a strongly consistent read and half that for an eventually consistent read, the default
+func readCapacityUnits(size int, consistentRead *bool) float64 {
+	units := math.Max(1, math.Ceil(float64(size)/4096))
+	if consistentRead != nil && *consistentRead {
+		return units
+	}
+	return units / 2
+}
+
+// writeCapacityUnits returns the capacity units of writing size bytes, one per 1 KB rounded up
+func writeCapacityUnits(size int) float64 {
+	return math.Max(1, math.Ceil(float64(size)/1024))
+}
+
+// itemSize approximates an item's size the way DynamoDB measures it, the lengths of its attribute
+// names plus the sizes of their values
+func itemSize(item AttributeMap) int {
+	size := 0
+	for name, value := range item {
+		size += len(name) + attributeValueSize(map[string]interface{}(value))
+	}
+	return size
+}
+
+// attributeValueSize approximates the size of an attribute value, like {"S": "text"}
+func attributeValueSize(value map[string]interface{}) int {
+	size := 0
+	for dataType, v := range value {
+		switch dataType {
+		case "S":
+			s, _ := v.(string)
+			size += len(s)
+		case "N":
+			// Numbers take about one byte per two significant digits
+			n, _ := v.(string)
+			size += len(n)/2 + 1
+		case "B":
+			b, _ := v.(string)
+			size += base64.StdEncoding.DecodedLen(len(b))
+		case "SS", "NS", "BS":
+			members, _ := v.([]interface{})
+			for _, member := range members {
+				size += attributeValueSize(map[string]interface{}{dataType[:1]: member})
+			}
+		case "L":
+			elements, _ := v.([]interface{})
+			size += 3
+			for _, element := range elements {
+				nested, _ := element.(map[string]interface{})
+				size += 1 + attributeValueSize(nested)
+			}
+		case "M":
+			attributes, _ := v.(map[string]interface{})
+			size += 3
+			for name, attribute := range attributes {
+				nested, _ := attribute.(map[string]interface{})
+				size += 1 + len(name) + attributeValueSize(nested)
+			}
+		default:
+			// BOOL and NULL
+			size++
+		}
+	}
+	return size
+}
@@ -0,0 +1,35 @@
+package dynamodb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItemSize(t *testing.T) {
+	item := AttributeMap{
+		"id":     {"S": "order-1"},
+		"total":  {"N": "1234"},
+		"paid":   {"BOOL": true},
+		"tags":   {"SS": []interface{}{"a", "bc"}},
+		"lines":  {"L": []interface{}{map[string]interface{}{"S": "sku"}}},
+		"extras": {"M": map[string]interface{}{"gift": map[string]interface{}{"BOOL": false}}},
+	}
+
+	// id 2+7, total 5+3, paid 4+1, tags 4+3, lines 5+3+1+3, extras 6+3+1+4+1
+	assert.Equal(t, 56, itemSize(item))
+	assert.Equal(t, 0, itemSize(nil))
+}
+
+func TestCapacityUnits(t *testing.T) {
+	consistent := true
+	assert.Equal(t, 0.5, readCapacityUnits(0, nil))
+	assert.Equal(t, 1.0, readCapacityUnits(4096, &consistent))
+	assert.Equal(t, 2.0, readCapacityUnits(4097, &consistent))
+	assert.Equal(t, 1.0, readCapacityUnits(4097, nil))
+
+	assert.Equal(t, 1.0, writeCapacityUnits(0))
+	assert.Equal(t, 1.0, writeCapacityUnits(1024))
+	assert.Equal(t, 3.0, writeCapacityUnits(len(strings.Repeat("x", 2049))))
+}
@@ -32,7 +32,8 @@ func (s *DynamoDBService) createBackup(ctx context.Context, input *CreateBackupI
 
 	// Create backup details
 	now := time.Now().Unix()
-	backupArn := fmt.Sprintf("arn:aws:dynamodb:us-east-1:000000000000:table/%s/backup/%s", tableName, uuid.New().String())
+	scope := emulator.RequestScopeFromContext(ctx)
+	backupArn := fmt.Sprintf("arn:aws:dynamodb:%s:%s:table/%s/backup/%s", scope.Region, scope.AccountID, tableName, uuid.New().String())
 
 	backupDetails := map[string]interface{}{
 		"BackupArn":              backupArn,
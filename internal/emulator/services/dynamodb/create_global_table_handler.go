@@ -29,6 +29,7 @@ func (s *DynamoDBService) createGlobalTable(ctx context.Context, input *CreateGl
 
 	// Build replicas from replication group
 	now := time.Now().Unix()
+	accountID := emulator.RequestScopeFromContext(ctx).AccountID
 	replicas := []map[string]interface{}{}
 
 	for _, replica := range input.ReplicationGroup {
@@ -37,7 +38,7 @@ func (s *DynamoDBService) createGlobalTable(ctx context.Context, input *CreateGl
 			replicaEntry := map[string]interface{}{
 				"RegionName":      regionName,
 				"ReplicaStatus":   "ACTIVE",
-				"ReplicaTableArn": fmt.Sprintf("arn:aws:dynamodb:%s:000000000000:table/%s", regionName, globalTableName),
+				"ReplicaTableArn": fmt.Sprintf("arn:aws:dynamodb:%s:%s:table/%s", regionName, accountID, globalTableName),
 			}
 			replicas = append(replicas, replicaEntry)
 		}
@@ -46,7 +47,7 @@ func (s *DynamoDBService) createGlobalTable(ctx context.Context, input *CreateGl
 	// Create global table description
 	globalTableDesc := map[string]interface{}{
 		"GlobalTableName":   globalTableName,
-		"GlobalTableArn":    fmt.Sprintf("arn:aws:dynamodb::000000000000:global-table/%s", globalTableName),
+		"GlobalTableArn":    fmt.Sprintf("arn:aws:dynamodb::%s:global-table/%s", accountID, globalTableName),
 		"GlobalTableStatus": "ACTIVE",
 		"CreationDateTime":  float64(now),
 		"ReplicationGroup":  replicas,
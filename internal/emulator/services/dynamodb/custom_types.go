@@ -11,3 +11,68 @@ type AttributeValue map[string]interface{}
 // AttributeMap represents a map of attribute names to attribute values.
 // This is used for items in DynamoDB (each item is a collection of attributes).
 type AttributeMap map[string]AttributeValue
+
+// The generated item operation inputs model attribute values as map[string]string, which can't
+// decode real requests. These embed the generated inputs and shadow the attribute value fields
+// with AttributeMap; encoding/json prefers the shallower field.
+
+// PutItemRequest is a PutItemInput with typed attribute values.
+type PutItemRequest struct {
+	PutItemInput
+	Item                      AttributeMap `json:"Item,omitempty"`
+	ExpressionAttributeValues AttributeMap `json:"ExpressionAttributeValues,omitempty"`
+}
+
+// GetItemRequest is a GetItemInput with typed attribute values.
+type GetItemRequest struct {
+	GetItemInput
+	Key AttributeMap `json:"Key,omitempty"`
+}
+
+// DeleteItemRequest is a DeleteItemInput with typed attribute values.
+type DeleteItemRequest struct {
+	DeleteItemInput
+	Key                       AttributeMap `json:"Key,omitempty"`
+	ExpressionAttributeValues AttributeMap `json:"ExpressionAttributeValues,omitempty"`
+}
+
+// UpdateItemRequest is an UpdateItemInput with typed attribute values.
+type UpdateItemRequest struct {
+	UpdateItemInput
+	Key                       AttributeMap `json:"Key,omitempty"`
+	ExpressionAttributeValues AttributeMap `json:"ExpressionAttributeValues,omitempty"`
+}
+
+// QueryRequest is a QueryInput with typed attribute values. The legacy conditions are decoded
+// loosely, as they're accepted but not evaluated.
+type QueryRequest struct {
+	QueryInput
+	ExclusiveStartKey         AttributeMap           `json:"ExclusiveStartKey,omitempty"`
+	ExpressionAttributeValues AttributeMap           `json:"ExpressionAttributeValues,omitempty"`
+	KeyConditions             map[string]interface{} `json:"KeyConditions,omitempty"`
+	QueryFilter               map[string]interface{} `json:"QueryFilter,omitempty"`
+}
+
+// ScanRequest is a ScanInput with typed attribute values.
+type ScanRequest struct {
+	ScanInput
+	ExclusiveStartKey         AttributeMap           `json:"ExclusiveStartKey,omitempty"`
+	ExpressionAttributeValues AttributeMap           `json:"ExpressionAttributeValues,omitempty"`
+	ScanFilter                map[string]interface{} `json:"ScanFilter,omitempty"`
+}
+
+// BatchWriteItemRequest is a BatchWriteItemInput with typed write requests.
+type BatchWriteItemRequest struct {
+	BatchWriteItemInput
+	RequestItems map[string][]WriteRequest `json:"RequestItems,omitempty"`
+}
+
+// WriteRequest is a put or delete of one item in a BatchWriteItem request.
+type WriteRequest struct {
+	PutRequest *struct {
+		Item AttributeMap `json:"Item"`
+	} `json:"PutRequest,omitempty"`
+	DeleteRequest *struct {
+		Key AttributeMap `json:"Key"`
+	} `json:"DeleteRequest,omitempty"`
+}
@@ -31,7 +31,8 @@ func (s *DynamoDBService) describeTableReplicaAutoScaling(ctx context.Context, i
 	var autoScalingDesc map[string]interface{}
 	if err := s.state.Get(autoScalingKey, &autoScalingDesc); err != nil {
 		// If no auto scaling settings exist, return default/disabled configuration
-		tableArn := fmt.Sprintf("arn:aws:dynamodb:us-east-1:000000000000:table/%s", tableName)
+		scope := emulator.RequestScopeFromContext(ctx)
+		tableArn := fmt.Sprintf("arn:aws:dynamodb:%s:%s:table/%s", scope.Region, scope.AccountID, tableName)
 		if arn, ok := tableDesc["TableArn"].(string); ok {
 			tableArn = arn
 		}
@@ -0,0 +1,144 @@
+package dynamodb
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+)
+
+// itemStateKey returns the state key of the item with the given primary key. The key is
+// encoded as JSON, which sorts attribute names, so equal keys always map to the same item.
+func itemStateKey(tableName string, key AttributeMap) (string, error) {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("dynamodb:item:%s:%s", tableName, data), nil
+}
+
+// keyAttributeNames returns the attribute names of a table's primary key, partition key first
+func keyAttributeNames(tableDesc map[string]interface{}) []string {
+	var names []string
+	schema, _ := tableDesc["KeySchema"].([]interface{})
+	for _, element := range schema {
+		ks, ok := element.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := ks["AttributeName"].(string)
+		if ks["KeyType"] == "HASH" {
+			names = append([]string{name}, names...)
+		} else {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// primaryKey extracts the primary key attributes of a table from an item or key
+func primaryKey(tableDesc map[string]interface{}, item AttributeMap) (AttributeMap, error) {
+	names := keyAttributeNames(tableDesc)
+	key := make(AttributeMap, len(names))
+	for _, name := range names {
+		value, ok := item[name]
+		if !ok || len(value) == 0 {
+			return nil, fmt.Errorf("One or more parameter values were invalid: Missing the key %s in the item", name)
+		}
+		key[name] = value
+	}
+	return key, nil
+}
+
+// lookupKey validates a Key parameter against the table's key schema
+func lookupKey(tableDesc map[string]interface{}, key AttributeMap) (AttributeMap, error) {
+	primary, err := primaryKey(tableDesc, key)
+	if err != nil || len(primary) != len(key) {
+		return nil, fmt.Errorf("The provided key element does not match the schema")
+	}
+	return primary, nil
+}
+
+// loadTable reads a table description, or returns a ResourceNotFoundException response
+func (s *DynamoDBService) loadTable(tableName string) (map[string]interface{}, *emulator.AWSResponse) {
+	var tableDesc map[string]interface{}
+	if err := s.state.Get(fmt.Sprintf("dynamodb:table:%s", tableName), &tableDesc); err != nil {
+		return nil, s.errorResponse(400, "ResourceNotFoundException", fmt.Sprintf("Requested resource not found: Table: %s not found", tableName))
+	}
+	return tableDesc, nil
+}
+
+// loadItem reads an item by state key. ok is false if the item doesn't exist.
+func (s *DynamoDBService) loadItem(itemKey string) (AttributeMap, bool) {
+	var item AttributeMap
+	if err := s.state.Get(itemKey, &item); err != nil {
+		return nil, false
+	}
+	return item, true
+}
+
+var (
+	updateClausePattern = regexp.MustCompile(`(?i)\b(SET|REMOVE)\s+`)
+	attributePathName   = regexp.MustCompile(`^(#[A-Za-z0-9_]+|[A-Za-z_][A-Za-z0-9_]*)$`)
+)
+
+// applyUpdateExpression applies the SET and REMOVE clauses of an UpdateExpression to item.
+// Only top-level attributes and plain assignments (SET a = :v) are supported.
+func applyUpdateExpression(item AttributeMap, expression string, names map[string]string, values AttributeMap) error {
+	locations := updateClausePattern.FindAllStringSubmatchIndex(expression, -1)
+	if len(locations) == 0 || strings.TrimSpace(expression[:locations[0][0]]) != "" {
+		return fmt.Errorf("Invalid UpdateExpression: %s", expression)
+	}
+
+	for i, loc := range locations {
+		end := len(expression)
+		if i+1 < len(locations) {
+			end = locations[i+1][0]
+		}
+		clause := strings.ToUpper(expression[loc[2]:loc[3]])
+		for _, action := range strings.Split(expression[loc[1]:end], ",") {
+			action = strings.TrimSpace(action)
+			switch clause {
+			case "SET":
+				path, operand, found := strings.Cut(action, "=")
+				if !found {
+					return fmt.Errorf("Invalid UpdateExpression: Syntax error in SET action: %s", action)
+				}
+				name, err := resolveAttributeName(strings.TrimSpace(path), names)
+				if err != nil {
+					return err
+				}
+				placeholder := strings.TrimSpace(operand)
+				value, ok := values[placeholder]
+				if !ok {
+					return fmt.Errorf("Invalid UpdateExpression: An expression attribute value used in expression is not defined; attribute value: %s", placeholder)
+				}
+				item[name] = value
+			case "REMOVE":
+				name, err := resolveAttributeName(action, names)
+				if err != nil {
+					return err
+				}
+				delete(item, name)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveAttributeName substitutes an ExpressionAttributeNames placeholder (#name)
+func resolveAttributeName(path string, names map[string]string) (string, error) {
+	if !attributePathName.MatchString(path) {
+		return "", fmt.Errorf("Invalid UpdateExpression: Unsupported attribute path: %s", path)
+	}
+	if !strings.HasPrefix(path, "#") {
+		return path, nil
+	}
+	name, ok := names[path]
+	if !ok {
+		return "", fmt.Errorf("Invalid UpdateExpression: An expression attribute name used in the document path is not defined; attribute name: %s", path)
+	}
+	return name, nil
+}
@@ -0,0 +1,250 @@
+package dynamodb
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// doItemRequest sends a DynamoDB JSON request and returns the response
+func doItemRequest(t *testing.T, service *DynamoDBService, action, body string) *emulator.AWSResponse {
+	t.Helper()
+	resp, err := service.HandleRequest(context.Background(), &emulator.AWSRequest{
+		Method: "POST",
+		Headers: map[string]string{
+			"Content-Type": "application/x-amz-json-1.0",
+			"X-Amz-Target": "DynamoDB_20120810." + action,
+		},
+		Body:   []byte(body),
+		Action: action,
+	})
+	require.NoError(t, err)
+	return resp
+}
+
+// createOrdersTable creates a table keyed on id, with a stream of the given view type if set
+func createOrdersTable(t *testing.T, service *DynamoDBService, streamViewType string) {
+	t.Helper()
+	stream := ""
+	if streamViewType != "" {
+		stream = `, "StreamSpecification": {"StreamEnabled": true, "StreamViewType": "` + streamViewType + `"}`
+	}
+	resp := doItemRequest(t, service, "CreateTable", `{
+		"TableName": "orders",
+		"KeySchema": [{"AttributeName": "id", "KeyType": "HASH"}],
+		"AttributeDefinitions": [{"AttributeName": "id", "AttributeType": "S"}],
+		"BillingMode": "PAY_PER_REQUEST"`+stream+`}`)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+}
+
+func streamRecords(t *testing.T, state emulator.StateManager) []StreamRecord {
+	t.Helper()
+	var log StreamLog
+	require.NoError(t, state.Get(StreamLogKey("orders"), &log))
+	return log.Records
+}
+
+func TestItemWrites_AppendStreamRecords(t *testing.T) {
+	state := emulator.NewMemoryStateManager()
+	service := NewDynamoDBService(state, emulator.NewSchemaValidator())
+	createOrdersTable(t, service, "NEW_AND_OLD_IMAGES")
+
+	resp := doItemRequest(t, service, "PutItem", `{"TableName": "orders", "Item": {"id": {"S": "1"}, "status": {"S": "new"}}}`)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+	resp = doItemRequest(t, service, "UpdateItem", `{
+		"TableName": "orders",
+		"Key": {"id": {"S": "1"}},
+		"UpdateExpression": "SET #s = :s",
+		"ExpressionAttributeNames": {"#s": "status"},
+		"ExpressionAttributeValues": {":s": {"S": "shipped"}}
+	}`)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+	resp = doItemRequest(t, service, "DeleteItem", `{"TableName": "orders", "Key": {"id": {"S": "1"}}}`)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+
+	records := streamRecords(t, state)
+	require.Len(t, records, 3)
+
+	key := AttributeMap{"id": {"S": "1"}}
+	assert.Equal(t, "INSERT", records[0].EventName)
+	assert.Equal(t, key, records[0].Keys)
+	assert.Nil(t, records[0].OldImage)
+	assert.Equal(t, AttributeMap{"id": {"S": "1"}, "status": {"S": "new"}}, records[0].NewImage)
+
+	assert.Equal(t, "MODIFY", records[1].EventName)
+	assert.Equal(t, AttributeMap{"id": {"S": "1"}, "status": {"S": "new"}}, records[1].OldImage)
+	assert.Equal(t, AttributeMap{"id": {"S": "1"}, "status": {"S": "shipped"}}, records[1].NewImage)
+
+	assert.Equal(t, "REMOVE", records[2].EventName)
+	assert.Equal(t, AttributeMap{"id": {"S": "1"}, "status": {"S": "shipped"}}, records[2].OldImage)
+	assert.Nil(t, records[2].NewImage)
+
+	assert.Less(t, records[0].SequenceNumber, records[1].SequenceNumber)
+	assert.Less(t, records[1].SequenceNumber, records[2].SequenceNumber)
+
+	// Deleting a missing item doesn't produce a record
+	resp = doItemRequest(t, service, "DeleteItem", `{"TableName": "orders", "Key": {"id": {"S": "1"}}}`)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+	assert.Len(t, streamRecords(t, state), 3)
+}
+
+func TestItemWrites_StreamViewType(t *testing.T) {
+	tests := []struct {
+		viewType     string
+		wantNewImage bool
+		wantOldImage bool
+	}{
+		{viewType: "KEYS_ONLY"},
+		{viewType: "NEW_IMAGE", wantNewImage: true},
+		{viewType: "OLD_IMAGE", wantOldImage: true},
+		{viewType: "NEW_AND_OLD_IMAGES", wantNewImage: true, wantOldImage: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.viewType, func(t *testing.T) {
+			state := emulator.NewMemoryStateManager()
+			service := NewDynamoDBService(state, emulator.NewSchemaValidator())
+			createOrdersTable(t, service, tt.viewType)
+
+			doItemRequest(t, service, "PutItem", `{"TableName": "orders", "Item": {"id": {"S": "1"}, "n": {"N": "1"}}}`)
+			doItemRequest(t, service, "PutItem", `{"TableName": "orders", "Item": {"id": {"S": "1"}, "n": {"N": "2"}}}`)
+
+			records := streamRecords(t, state)
+			require.Len(t, records, 2)
+			modify := records[1]
+			assert.Equal(t, "MODIFY", modify.EventName)
+			assert.Equal(t, tt.viewType, modify.StreamViewType)
+			assert.Equal(t, AttributeMap{"id": {"S": "1"}}, modify.Keys)
+			assert.Equal(t, tt.wantNewImage, modify.NewImage != nil)
+			assert.Equal(t, tt.wantOldImage, modify.OldImage != nil)
+		})
+	}
+}
+
+func TestItemWrites_WithoutStream(t *testing.T) {
+	state := emulator.NewMemoryStateManager()
+	service := NewDynamoDBService(state, emulator.NewSchemaValidator())
+	createOrdersTable(t, service, "")
+
+	resp := doItemRequest(t, service, "PutItem", `{"TableName": "orders", "Item": {"id": {"S": "1"}}}`)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+	assert.False(t, state.Exists(StreamLogKey("orders")))
+
+	resp = doItemRequest(t, service, "GetItem", `{"TableName": "orders", "Key": {"id": {"S": "1"}}}`)
+	require.Equal(t, 200, resp.StatusCode)
+	assert.JSONEq(t, `{"Item": {"id": {"S": "1"}}}`, string(resp.Body))
+}
+
+func TestPutItem_MissingKey(t *testing.T) {
+	service := NewDynamoDBService(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	createOrdersTable(t, service, "NEW_IMAGE")
+
+	resp := doItemRequest(t, service, "PutItem", `{"TableName": "orders", "Item": {"status": {"S": "new"}}}`)
+	assert.Equal(t, 400, resp.StatusCode)
+	assert.Contains(t, string(resp.Body), "Missing the key id")
+
+	resp = doItemRequest(t, service, "PutItem", `{"TableName": "missing", "Item": {"id": {"S": "1"}}}`)
+	assert.Equal(t, 400, resp.StatusCode)
+	assert.Contains(t, string(resp.Body), "ResourceNotFoundException")
+}
+
+func TestReads_ConsistentRead(t *testing.T) {
+	service := NewDynamoDBService(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	createOrdersTable(t, service, "")
+
+	resp := doItemRequest(t, service, "PutItem", `{"TableName": "orders", "Item": {"id": {"S": "1"}}}`)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+
+	// Strongly consistent reads are accepted, and consume twice the capacity of eventually consistent ones
+	resp = doItemRequest(t, service, "GetItem", `{"TableName": "orders", "Key": {"id": {"S": "1"}}, "ConsistentRead": true, "ReturnConsumedCapacity": "TOTAL"}`)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+	assert.JSONEq(t, `{"Item": {"id": {"S": "1"}}, "ConsumedCapacity": {"TableName": "orders", "CapacityUnits": 1}}`, string(resp.Body))
+
+	resp = doItemRequest(t, service, "GetItem", `{"TableName": "orders", "Key": {"id": {"S": "1"}}, "ReturnConsumedCapacity": "INDEXES"}`)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+	assert.JSONEq(t, `{"Item": {"id": {"S": "1"}}, "ConsumedCapacity": {"TableName": "orders", "CapacityUnits": 0.5, "Table": {"CapacityUnits": 0.5}}}`, string(resp.Body))
+
+	resp = doItemRequest(t, service, "GetItem", `{"TableName": "orders", "Key": {"id": {"S": "1"}}, "ConsistentRead": true}`)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+	assert.JSONEq(t, `{"Item": {"id": {"S": "1"}}}`, string(resp.Body))
+
+	resp = doItemRequest(t, service, "Query", `{
+		"TableName": "orders",
+		"KeyConditionExpression": "id = :id",
+		"ExpressionAttributeValues": {":id": {"S": "1"}},
+		"ConsistentRead": true,
+		"ReturnConsumedCapacity": "TOTAL"
+	}`)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+	assert.Contains(t, string(resp.Body), `"ConsumedCapacity":{"CapacityUnits":1,"TableName":"orders"}`)
+
+	resp = doItemRequest(t, service, "Scan", `{"TableName": "orders", "ConsistentRead": true, "ExclusiveStartKey": {"id": {"S": "1"}}}`)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+	assert.NotContains(t, string(resp.Body), "ConsumedCapacity")
+}
+
+func TestWrites_ReturnConsumedCapacity(t *testing.T) {
+	service := NewDynamoDBService(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	createOrdersTable(t, service, "")
+
+	// A 2 KB item takes three write units: "id", "1", "note" and 2,048 bytes of text
+	note := strings.Repeat("x", 2048)
+	resp := doItemRequest(t, service, "PutItem", `{"TableName": "orders", "Item": {"id": {"S": "1"}, "note": {"S": "`+note+`"}}, "ReturnConsumedCapacity": "TOTAL"}`)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+	assert.Contains(t, string(resp.Body), `"ConsumedCapacity":{"CapacityUnits":3,"TableName":"orders"}`)
+
+	resp = doItemRequest(t, service, "GetItem", `{"TableName": "orders", "Key": {"id": {"S": "1"}}, "ReturnConsumedCapacity": "TOTAL"}`)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+	assert.Contains(t, string(resp.Body), `"ConsumedCapacity":{"CapacityUnits":0.5,"TableName":"orders"}`)
+
+	// Shrinking the item still consumes capacity for its size before the update
+	resp = doItemRequest(t, service, "UpdateItem", `{
+		"TableName": "orders",
+		"Key": {"id": {"S": "1"}},
+		"UpdateExpression": "SET note = :n",
+		"ExpressionAttributeValues": {":n": {"S": "short"}},
+		"ReturnConsumedCapacity": "INDEXES"
+	}`)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+	assert.Contains(t, string(resp.Body), `"ConsumedCapacity":{"CapacityUnits":3,"Table":{"CapacityUnits":3},"TableName":"orders"}`)
+
+	resp = doItemRequest(t, service, "PutItem", `{"TableName": "orders", "Item": {"id": {"S": "2"}}}`)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+	assert.NotContains(t, string(resp.Body), "ConsumedCapacity")
+}
+
+func TestBatchWriteItem(t *testing.T) {
+	state := emulator.NewMemoryStateManager()
+	service := NewDynamoDBService(state, emulator.NewSchemaValidator())
+	createOrdersTable(t, service, "NEW_IMAGE")
+
+	resp := doItemRequest(t, service, "PutItem", `{"TableName": "orders", "Item": {"id": {"S": "old"}}}`)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+
+	resp = doItemRequest(t, service, "BatchWriteItem", `{
+		"RequestItems": {"orders": [
+			{"PutRequest": {"Item": {"id": {"S": "1"}}}},
+			{"PutRequest": {"Item": {"id": {"S": "2"}}}},
+			{"DeleteRequest": {"Key": {"id": {"S": "old"}}}}
+		]},
+		"ReturnConsumedCapacity": "TOTAL"
+	}`)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+	assert.JSONEq(t, `{"UnprocessedItems": {}, "ConsumedCapacity": [{"TableName": "orders", "CapacityUnits": 3}]}`, string(resp.Body))
+
+	resp = doItemRequest(t, service, "GetItem", `{"TableName": "orders", "Key": {"id": {"S": "2"}}}`)
+	assert.JSONEq(t, `{"Item": {"id": {"S": "2"}}}`, string(resp.Body))
+	resp = doItemRequest(t, service, "GetItem", `{"TableName": "orders", "Key": {"id": {"S": "old"}}}`)
+	assert.JSONEq(t, `{}`, string(resp.Body))
+
+	// The writes are recorded on the table's stream like single item writes
+	assert.Len(t, streamRecords(t, state), 4)
+
+	resp = doItemRequest(t, service, "BatchWriteItem", `{"RequestItems": {"orders": [{}]}}`)
+	assert.Equal(t, 400, resp.StatusCode)
+	assert.Contains(t, string(resp.Body), "ValidationException")
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/robmorgan/infraspec/internal/emulator/core"
+	"github.com/robmorgan/infraspec/internal/emulator/helpers"
 )
 
 type DynamoDBService struct {
@@ -27,6 +29,16 @@ func (s *DynamoDBService) ServiceName() string {
 	return "dynamodb_20120810"
 }
 
+// Protocol returns the AWS protocol the service speaks
+func (s *DynamoDBService) Protocol() emulator.ProtocolType {
+	return emulator.ProtocolJSON
+}
+
+// ResponseTypes returns constructors for the CloudMirror-generated response types, keyed by action.
+func (s *DynamoDBService) ResponseTypes() map[string]func() interface{} {
+	return smithyResponseTypes
+}
+
 func (s *DynamoDBService) HandleRequest(ctx context.Context, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
 	if err := s.validator.ValidateRequest(req); err != nil {
 		return s.errorResponse(400, "ValidationException", err.Error()), nil
@@ -50,139 +62,151 @@ func (s *DynamoDBService) HandleRequest(ctx context.Context, req *emulator.AWSRe
 	case "CreateTable":
 		input, err := emulator.ParseJSONRequest[CreateTableInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.createTable(ctx, input)
 	case "DescribeTable":
 		input, err := emulator.ParseJSONRequest[DescribeTableInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.describeTable(ctx, input)
 	case "DeleteTable":
 		input, err := emulator.ParseJSONRequest[DeleteTableInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.deleteTable(ctx, input)
 	case "ListTables":
 		input, err := emulator.ParseJSONRequest[ListTablesInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.listTables(ctx, input)
 	case "UpdateTable":
 		input, err := emulator.ParseJSONRequest[UpdateTableInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.updateTable(ctx, input)
 	case "DescribeContinuousBackups":
 		input, err := emulator.ParseJSONRequest[DescribeContinuousBackupsInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.describeContinuousBackups(ctx, input)
 	case "UpdateContinuousBackups":
 		input, err := emulator.ParseJSONRequest[UpdateContinuousBackupsInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.updateContinuousBackups(ctx, input)
 	case "DescribeTimeToLive":
 		input, err := emulator.ParseJSONRequest[DescribeTimeToLiveInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.describeTimeToLive(ctx, input)
 	case "UpdateTimeToLive":
 		input, err := emulator.ParseJSONRequest[UpdateTimeToLiveInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.updateTimeToLive(ctx, input)
 	case "ListTagsOfResource":
 		input, err := emulator.ParseJSONRequest[ListTagsOfResourceInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.listTagsOfResource(ctx, input)
 	case "TagResource":
 		input, err := emulator.ParseJSONRequest[TagResourceInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.tagResource(ctx, input)
 	case "UntagResource":
 		input, err := emulator.ParseJSONRequest[UntagResourceInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.untagResource(ctx, input)
 	case "PutItem":
-		input, err := emulator.ParseJSONRequest[PutItemInput](req.Body)
+		input, err := emulator.ParseJSONRequest[PutItemRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.putItem(ctx, input)
 	case "GetItem":
-		input, err := emulator.ParseJSONRequest[GetItemInput](req.Body)
+		input, err := emulator.ParseJSONRequest[GetItemRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.getItem(ctx, input)
 	case "DeleteItem":
-		input, err := emulator.ParseJSONRequest[DeleteItemInput](req.Body)
+		input, err := emulator.ParseJSONRequest[DeleteItemRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.deleteItem(ctx, input)
+	case "UpdateItem":
+		input, err := emulator.ParseJSONRequest[UpdateItemRequest](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.updateItem(ctx, input)
+	case "BatchWriteItem":
+		input, err := emulator.ParseJSONRequest[BatchWriteItemRequest](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.batchWriteItem(ctx, input)
 	case "Query":
-		input, err := emulator.ParseJSONRequest[QueryInput](req.Body)
+		input, err := emulator.ParseJSONRequest[QueryRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.query(ctx, input)
 	case "Scan":
-		input, err := emulator.ParseJSONRequest[ScanInput](req.Body)
+		input, err := emulator.ParseJSONRequest[ScanRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.scan(ctx, input)
 	case "CreateBackup":
 		input, err := emulator.ParseJSONRequest[CreateBackupInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.createBackup(ctx, input)
 	case "CreateGlobalTable":
 		input, err := emulator.ParseJSONRequest[CreateGlobalTableInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.createGlobalTable(ctx, input)
 	case "DeleteBackup":
 		input, err := emulator.ParseJSONRequest[DeleteBackupInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.deleteBackup(ctx, input)
 	case "DeleteResourcePolicy":
 		input, err := emulator.ParseJSONRequest[DeleteResourcePolicyInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.deleteResourcePolicy(ctx, input)
 	case "DescribeBackup":
 		input, err := emulator.ParseJSONRequest[DescribeBackupInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.describeBackup(ctx, input)
 	case "DescribeContributorInsights":
 		input, err := emulator.ParseJSONRequest[DescribeContributorInsightsInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.describeContributorInsights(ctx, input)
 	case "DescribeEndpoints":
@@ -190,31 +214,31 @@ func (s *DynamoDBService) HandleRequest(ctx context.Context, req *emulator.AWSRe
 	case "DescribeExport":
 		input, err := emulator.ParseJSONRequest[DescribeExportInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.describeExport(ctx, input)
 	case "DescribeGlobalTable":
 		input, err := emulator.ParseJSONRequest[DescribeGlobalTableInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.describeGlobalTable(ctx, input)
 	case "DescribeGlobalTableSettings":
 		input, err := emulator.ParseJSONRequest[DescribeGlobalTableSettingsInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.describeGlobalTableSettings(ctx, input)
 	case "DescribeImport":
 		input, err := emulator.ParseJSONRequest[DescribeImportInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.describeImport(ctx, input)
 	case "DescribeKinesisStreamingDestination":
 		input, err := emulator.ParseJSONRequest[DescribeKinesisStreamingDestinationInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.describeKinesisStreamingDestination(ctx, input)
 	case "DescribeLimits":
@@ -222,19 +246,19 @@ func (s *DynamoDBService) HandleRequest(ctx context.Context, req *emulator.AWSRe
 	case "DescribeTableReplicaAutoScaling":
 		input, err := emulator.ParseJSONRequest[DescribeTableReplicaAutoScalingInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.describeTableReplicaAutoScaling(ctx, input)
 	case "GetResourcePolicy":
 		input, err := emulator.ParseJSONRequest[GetResourcePolicyInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.getResourcePolicy(ctx, input)
 	case "ListBackups":
 		input, err := emulator.ParseJSONRequest[ListBackupsInput](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.listBackups(ctx, input)
 	default:
@@ -293,10 +317,11 @@ func (s *DynamoDBService) createTable(ctx context.Context, input *CreateTableInp
 
 	// Build table description
 	now := time.Now().Unix()
+	scope := emulator.RequestScopeFromContext(ctx)
 	tableDesc := map[string]interface{}{
 		"TableName":                 tableName,
 		"TableStatus":               "ACTIVE", // In emulator, table is immediately active
-		"TableArn":                  fmt.Sprintf("arn:aws:dynamodb:us-east-1:000000000000:table/%s", tableName),
+		"TableArn":                  fmt.Sprintf("arn:aws:dynamodb:%s:%s:table/%s", scope.Region, scope.AccountID, tableName),
 		"TableId":                   uuid.New().String(),
 		"CreationDateTime":          float64(now),
 		"TableSizeBytes":            0,
@@ -309,14 +334,7 @@ func (s *DynamoDBService) createTable(ctx context.Context, input *CreateTableInp
 
 	// Add key schema
 	if len(input.KeySchema) > 0 {
-		keySchema := make([]interface{}, len(input.KeySchema))
-		for i, ks := range input.KeySchema {
-			keySchema[i] = map[string]interface{}{
-				"AttributeName": ks.AttributeName,
-				"KeyType":       ks.KeyType,
-			}
-		}
-		tableDesc["KeySchema"] = keySchema
+		tableDesc["KeySchema"] = keySchemaDescription(input.KeySchema)
 	}
 
 	// Add attribute definitions
@@ -365,9 +383,27 @@ func (s *DynamoDBService) createTable(ctx context.Context, input *CreateTableInp
 	if len(input.GlobalSecondaryIndexes) > 0 {
 		gsi := make([]interface{}, len(input.GlobalSecondaryIndexes))
 		for i, idx := range input.GlobalSecondaryIndexes {
-			gsi[i] = map[string]interface{}{
-				"IndexName": idx.IndexName,
+			indexDesc := map[string]interface{}{
+				"IndexName":      idx.IndexName,
+				"IndexArn":       fmt.Sprintf("%s/index/%s", tableDesc["TableArn"], helpers.StringValue(idx.IndexName)),
+				"IndexStatus":    "ACTIVE",
+				"KeySchema":      keySchemaDescription(idx.KeySchema),
+				"Projection":     idx.Projection,
+				"IndexSizeBytes": 0,
+				"ItemCount":      0,
 			}
+			// Indexes of provisioned tables have their own throughput
+			if billingMode == "PROVISIONED" && idx.ProvisionedThroughput != nil {
+				indexDesc["ProvisionedThroughput"] = map[string]interface{}{
+					"ReadCapacityUnits":      idx.ProvisionedThroughput.ReadCapacityUnits,
+					"WriteCapacityUnits":     idx.ProvisionedThroughput.WriteCapacityUnits,
+					"NumberOfDecreasesToday": 0,
+				}
+			}
+			if idx.OnDemandThroughput != nil {
+				indexDesc["OnDemandThroughput"] = idx.OnDemandThroughput
+			}
+			gsi[i] = indexDesc
 		}
 		tableDesc["GlobalSecondaryIndexes"] = gsi
 	} else {
@@ -379,7 +415,12 @@ func (s *DynamoDBService) createTable(ctx context.Context, input *CreateTableInp
 		lsi := make([]interface{}, len(input.LocalSecondaryIndexes))
 		for i, idx := range input.LocalSecondaryIndexes {
 			lsi[i] = map[string]interface{}{
-				"IndexName": idx.IndexName,
+				"IndexName":      idx.IndexName,
+				"IndexArn":       fmt.Sprintf("%s/index/%s", tableDesc["TableArn"], helpers.StringValue(idx.IndexName)),
+				"KeySchema":      keySchemaDescription(idx.KeySchema),
+				"Projection":     idx.Projection,
+				"IndexSizeBytes": 0,
+				"ItemCount":      0,
 			}
 		}
 		tableDesc["LocalSecondaryIndexes"] = lsi
@@ -389,7 +430,7 @@ func (s *DynamoDBService) createTable(ctx context.Context, input *CreateTableInp
 
 	// Add stream ARN and label if streaming is enabled
 	if input.StreamSpecification != nil && input.StreamSpecification.StreamEnabled != nil && *input.StreamSpecification.StreamEnabled {
-		tableDesc["LatestStreamArn"] = fmt.Sprintf("arn:aws:dynamodb:us-east-1:000000000000:table/%s/stream/%s", tableName, uuid.New().String())
+		tableDesc["LatestStreamArn"] = fmt.Sprintf("arn:aws:dynamodb:%s:%s:table/%s/stream/%s", scope.Region, scope.AccountID, tableName, uuid.New().String())
 		tableDesc["LatestStreamLabel"] = fmt.Sprintf("%d", now)
 		tableDesc["StreamSpecification"] = map[string]interface{}{
 			"StreamEnabled":  *input.StreamSpecification.StreamEnabled,
@@ -399,7 +440,7 @@ func (s *DynamoDBService) createTable(ctx context.Context, input *CreateTableInp
 
 	// Add SSE description only if explicitly configured (not included when SSE not specified)
 	if input.SSESpecification != nil && input.SSESpecification.Enabled != nil && *input.SSESpecification.Enabled {
-		kmsKeyArn := "arn:aws:kms:us-east-1:000000000000:key/" + uuid.New().String()
+		kmsKeyArn := fmt.Sprintf("arn:aws:kms:%s:%s:key/%s", scope.Region, scope.AccountID, uuid.New().String())
 		if input.SSESpecification.KMSMasterKeyId != nil {
 			kmsKeyArn = *input.SSESpecification.KMSMasterKeyId
 		}
@@ -431,6 +472,13 @@ func (s *DynamoDBService) createTable(ctx context.Context, input *CreateTableInp
 		return s.errorResponse(500, "InternalServerError", "Failed to create table"), nil
 	}
 
+	// Start the change log read by the dynamodbstreams service
+	if streamArn, ok := tableDesc["LatestStreamArn"].(string); ok {
+		if err := createStreamLog(s.state, tableName, streamArn); err != nil {
+			return s.errorResponse(500, "InternalServerError", "Failed to create table stream"), nil
+		}
+	}
+
 	// Return response
 	response := map[string]interface{}{
 		"TableDescription": tableDesc,
@@ -520,6 +568,12 @@ func (s *DynamoDBService) deleteTable(ctx context.Context, input *DeleteTableInp
 		return s.errorResponse(500, "InternalServerError", "Failed to delete table"), nil
 	}
 
+	// Drop the table's items so a table recreated with the same name starts empty
+	itemKeys, _ := s.state.List(fmt.Sprintf("dynamodb:item:%s:", tableName))
+	for _, itemKey := range itemKeys {
+		_ = s.state.Delete(itemKey)
+	}
+
 	response := map[string]interface{}{
 		"TableDescription": tableDesc,
 	}
@@ -528,7 +582,14 @@ func (s *DynamoDBService) deleteTable(ctx context.Context, input *DeleteTableInp
 }
 
 func (s *DynamoDBService) listTables(ctx context.Context, input *ListTablesInput) (*emulator.AWSResponse, error) {
-	// List all tables (input may contain ExclusiveStartTableName and Limit for pagination)
+	limit := 100 // Default and maximum limit
+	if input.Limit != nil {
+		if *input.Limit < 1 || *input.Limit > 100 {
+			return s.errorResponse(400, "ValidationException", fmt.Sprintf("1 validation error detected: Value '%d' at 'limit' failed to satisfy constraint: Member must have value between 1 and 100", *input.Limit)), nil
+		}
+		limit = int(*input.Limit)
+	}
+
 	keys, err := s.state.List("dynamodb:table:")
 	if err != nil {
 		return s.errorResponse(500, "InternalServerError", "Failed to list tables"), nil
@@ -543,9 +604,22 @@ func (s *DynamoDBService) listTables(ctx context.Context, input *ListTablesInput
 		}
 	}
 
-	response := map[string]interface{}{
-		"TableNames": tableNames,
+	// Like DynamoDB, tables are listed in name order, resuming after ExclusiveStartTableName,
+	// which needn't be an existing table
+	sort.Strings(tableNames)
+	if input.ExclusiveStartTableName != nil && *input.ExclusiveStartTableName != "" {
+		start := sort.Search(len(tableNames), func(i int) bool {
+			return tableNames[i] > *input.ExclusiveStartTableName
+		})
+		tableNames = tableNames[start:]
+	}
+
+	response := map[string]interface{}{}
+	if len(tableNames) > limit {
+		tableNames = tableNames[:limit]
+		response["LastEvaluatedTableName"] = tableNames[limit-1]
 	}
+	response["TableNames"] = tableNames
 
 	return s.jsonResponse(200, response)
 }
@@ -582,7 +656,7 @@ func (s *DynamoDBService) updateTable(ctx context.Context, input *UpdateTableInp
 	return s.jsonResponse(200, response)
 }
 
-func (s *DynamoDBService) putItem(ctx context.Context, input *PutItemInput) (*emulator.AWSResponse, error) {
+func (s *DynamoDBService) putItem(ctx context.Context, input *PutItemRequest) (*emulator.AWSResponse, error) {
 	if input.TableName == nil || *input.TableName == "" {
 		return s.errorResponse(400, "ValidationException", "TableName is required"), nil
 	}
@@ -592,44 +666,205 @@ func (s *DynamoDBService) putItem(ctx context.Context, input *PutItemInput) (*em
 		return s.errorResponse(400, "ValidationException", "Item is required"), nil
 	}
 
+	tableDesc, errResp := s.loadTable(tableName)
+	if errResp != nil {
+		return errResp, nil
+	}
+	key, err := primaryKey(tableDesc, input.Item)
+	if err != nil {
+		return s.errorResponse(400, "ValidationException", err.Error()), nil
+	}
+	itemKey, err := itemStateKey(tableName, key)
+	if err != nil {
+		return s.errorResponse(500, "InternalServerError", "Failed to put item"), nil
+	}
+	oldItem, existed := s.loadItem(itemKey)
+
 	// Store item
-	itemKey := fmt.Sprintf("dynamodb:item:%s:%s", tableName, uuid.New().String())
 	if err := s.state.Set(itemKey, input.Item); err != nil {
 		return s.errorResponse(500, "InternalServerError", "Failed to put item"), nil
 	}
 
+	eventName := "INSERT"
+	if existed {
+		eventName = "MODIFY"
+	}
+	if err := appendStreamRecord(s.state, tableDesc, eventName, key, oldItem, input.Item); err != nil {
+		return s.errorResponse(500, "InternalServerError", "Failed to record stream change"), nil
+	}
+
+	response := map[string]interface{}{}
+	if input.ReturnValues == "ALL_OLD" && existed {
+		response["Attributes"] = oldItem
+	}
+	// A write consumes capacity for the larger of the item before and after it
+	if capacity := consumedCapacity(tableName, input.ReturnConsumedCapacity, writeCapacityUnits(max(itemSize(oldItem), itemSize(input.Item)))); capacity != nil {
+		response["ConsumedCapacity"] = capacity
+	}
+	return s.jsonResponse(200, response)
+}
+
+func (s *DynamoDBService) getItem(ctx context.Context, input *GetItemRequest) (*emulator.AWSResponse, error) {
+	if input.TableName == nil || *input.TableName == "" {
+		return s.errorResponse(400, "ValidationException", "TableName is required"), nil
+	}
+	tableName := *input.TableName
+
+	tableDesc, errResp := s.loadTable(tableName)
+	if errResp != nil {
+		return errResp, nil
+	}
+	key, err := lookupKey(tableDesc, input.Key)
+	if err != nil {
+		return s.errorResponse(400, "ValidationException", err.Error()), nil
+	}
+	itemKey, err := itemStateKey(tableName, key)
+	if err != nil {
+		return s.errorResponse(500, "InternalServerError", "Failed to get item"), nil
+	}
+
+	// Reads are always strongly consistent, so ConsistentRead only changes the capacity consumed
 	response := map[string]interface{}{}
+	item, ok := s.loadItem(itemKey)
+	if ok {
+		response["Item"] = item
+	}
+	if capacity := consumedCapacity(tableName, input.ReturnConsumedCapacity, readCapacityUnits(itemSize(item), input.ConsistentRead)); capacity != nil {
+		response["ConsumedCapacity"] = capacity
+	}
 	return s.jsonResponse(200, response)
 }
 
-func (s *DynamoDBService) getItem(ctx context.Context, input *GetItemInput) (*emulator.AWSResponse, error) {
+func (s *DynamoDBService) deleteItem(ctx context.Context, input *DeleteItemRequest) (*emulator.AWSResponse, error) {
 	if input.TableName == nil || *input.TableName == "" {
 		return s.errorResponse(400, "ValidationException", "TableName is required"), nil
 	}
+	tableName := *input.TableName
+
+	tableDesc, errResp := s.loadTable(tableName)
+	if errResp != nil {
+		return errResp, nil
+	}
+	key, err := lookupKey(tableDesc, input.Key)
+	if err != nil {
+		return s.errorResponse(400, "ValidationException", err.Error()), nil
+	}
+	itemKey, err := itemStateKey(tableName, key)
+	if err != nil {
+		return s.errorResponse(500, "InternalServerError", "Failed to delete item"), nil
+	}
+
+	// Deleting a missing item succeeds without producing a stream record
+	oldItem, existed := s.loadItem(itemKey)
+	if !existed {
+		response := map[string]interface{}{}
+		if capacity := consumedCapacity(tableName, input.ReturnConsumedCapacity, writeCapacityUnits(0)); capacity != nil {
+			response["ConsumedCapacity"] = capacity
+		}
+		return s.jsonResponse(200, response)
+	}
+	if err := s.state.Delete(itemKey); err != nil {
+		return s.errorResponse(500, "InternalServerError", "Failed to delete item"), nil
+	}
+	if err := appendStreamRecord(s.state, tableDesc, "REMOVE", key, oldItem, nil); err != nil {
+		return s.errorResponse(500, "InternalServerError", "Failed to record stream change"), nil
+	}
 
-	// For simplicity, just return empty for now
 	response := map[string]interface{}{}
+	if input.ReturnValues == "ALL_OLD" {
+		response["Attributes"] = oldItem
+	}
+	if capacity := consumedCapacity(tableName, input.ReturnConsumedCapacity, writeCapacityUnits(itemSize(oldItem))); capacity != nil {
+		response["ConsumedCapacity"] = capacity
+	}
 	return s.jsonResponse(200, response)
 }
 
-func (s *DynamoDBService) deleteItem(ctx context.Context, input *DeleteItemInput) (*emulator.AWSResponse, error) {
+func (s *DynamoDBService) updateItem(ctx context.Context, input *UpdateItemRequest) (*emulator.AWSResponse, error) {
+	if input.TableName == nil || *input.TableName == "" {
+		return s.errorResponse(400, "ValidationException", "TableName is required"), nil
+	}
+	tableName := *input.TableName
+
+	tableDesc, errResp := s.loadTable(tableName)
+	if errResp != nil {
+		return errResp, nil
+	}
+	key, err := lookupKey(tableDesc, input.Key)
+	if err != nil {
+		return s.errorResponse(400, "ValidationException", err.Error()), nil
+	}
+	itemKey, err := itemStateKey(tableName, key)
+	if err != nil {
+		return s.errorResponse(500, "InternalServerError", "Failed to update item"), nil
+	}
+
+	// UpdateItem creates the item if it doesn't exist yet
+	oldItem, existed := s.loadItem(itemKey)
+	newItem := make(AttributeMap, len(oldItem)+len(key))
+	for name, value := range oldItem {
+		newItem[name] = value
+	}
+	for name, value := range key {
+		newItem[name] = value
+	}
+	if input.UpdateExpression != nil && *input.UpdateExpression != "" {
+		if err := applyUpdateExpression(newItem, *input.UpdateExpression, input.ExpressionAttributeNames, input.ExpressionAttributeValues); err != nil {
+			return s.errorResponse(400, "ValidationException", err.Error()), nil
+		}
+		for name := range key {
+			if _, ok := newItem[name]; !ok {
+				return s.errorResponse(400, "ValidationException", fmt.Sprintf("Cannot update attribute %s. This attribute is part of the key", name)), nil
+			}
+		}
+	}
+
+	if err := s.state.Set(itemKey, newItem); err != nil {
+		return s.errorResponse(500, "InternalServerError", "Failed to update item"), nil
+	}
+
+	eventName := "INSERT"
+	if existed {
+		eventName = "MODIFY"
+	}
+	if err := appendStreamRecord(s.state, tableDesc, eventName, key, oldItem, newItem); err != nil {
+		return s.errorResponse(500, "InternalServerError", "Failed to record stream change"), nil
+	}
+
 	response := map[string]interface{}{}
+	switch input.ReturnValues {
+	case "ALL_OLD":
+		if existed {
+			response["Attributes"] = oldItem
+		}
+	case "ALL_NEW":
+		response["Attributes"] = newItem
+	}
+	if capacity := consumedCapacity(tableName, input.ReturnConsumedCapacity, writeCapacityUnits(max(itemSize(oldItem), itemSize(newItem)))); capacity != nil {
+		response["ConsumedCapacity"] = capacity
+	}
 	return s.jsonResponse(200, response)
 }
 
-func (s *DynamoDBService) query(ctx context.Context, input *QueryInput) (*emulator.AWSResponse, error) {
+func (s *DynamoDBService) query(ctx context.Context, input *QueryRequest) (*emulator.AWSResponse, error) {
 	response := map[string]interface{}{
 		"Items": []interface{}{},
 		"Count": 0,
 	}
+	if capacity := consumedCapacity(helpers.StringValue(input.TableName), input.ReturnConsumedCapacity, readCapacityUnits(0, input.ConsistentRead)); capacity != nil {
+		response["ConsumedCapacity"] = capacity
+	}
 	return s.jsonResponse(200, response)
 }
 
-func (s *DynamoDBService) scan(ctx context.Context, input *ScanInput) (*emulator.AWSResponse, error) {
+func (s *DynamoDBService) scan(ctx context.Context, input *ScanRequest) (*emulator.AWSResponse, error) {
 	response := map[string]interface{}{
 		"Items": []interface{}{},
 		"Count": 0,
 	}
+	if capacity := consumedCapacity(helpers.StringValue(input.TableName), input.ReturnConsumedCapacity, readCapacityUnits(0, input.ConsistentRead)); capacity != nil {
+		response["ConsumedCapacity"] = capacity
+	}
 	return s.jsonResponse(200, response)
 }
 
@@ -949,3 +1184,21 @@ func getStringOrDefault(params map[string]interface{}, key, defaultValue string)
 	}
 	return defaultValue
 }
+
+// keySchemaDescription returns the key schema of a table or index as it's stored in the
+// table description
+func keySchemaDescription(keySchema []KeySchemaElement) []interface{} {
+	description := make([]interface{}, len(keySchema))
+	for i, ks := range keySchema {
+		description[i] = map[string]interface{}{
+			"AttributeName": ks.AttributeName,
+			"KeyType":       ks.KeyType,
+		}
+	}
+	return description
+}
+
+var (
+	_ emulator.Service              = (*DynamoDBService)(nil)
+	_ emulator.ResponseTypeProvider = (*DynamoDBService)(nil)
+)
@@ -0,0 +1,63 @@
+package dynamodb
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTables_Pagination(t *testing.T) {
+	service := NewDynamoDBService(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+
+	// Created out of order, since tables are listed by name
+	for _, name := range []string{"events", "accounts", "orders", "carts", "invoices"} {
+		resp := doItemRequest(t, service, "CreateTable", fmt.Sprintf(`{
+			"TableName": %q,
+			"KeySchema": [{"AttributeName": "id", "KeyType": "HASH"}],
+			"AttributeDefinitions": [{"AttributeName": "id", "AttributeType": "S"}],
+			"BillingMode": "PAY_PER_REQUEST"}`, name))
+		require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+	}
+
+	type page struct {
+		TableNames             []string
+		LastEvaluatedTableName string
+	}
+	listTables := func(body string) page {
+		t.Helper()
+		resp := doItemRequest(t, service, "ListTables", body)
+		require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+		var result page
+		require.NoError(t, json.Unmarshal(resp.Body, &result))
+		return result
+	}
+
+	var pages [][]string
+	body := `{"Limit": 2}`
+	for {
+		result := listTables(body)
+		pages = append(pages, result.TableNames)
+		if result.LastEvaluatedTableName == "" {
+			break
+		}
+		require.Less(t, len(pages), 5, "paging didn't end")
+		body = fmt.Sprintf(`{"Limit": 2, "ExclusiveStartTableName": %q}`, result.LastEvaluatedTableName)
+	}
+	assert.Equal(t, [][]string{{"accounts", "carts"}, {"events", "invoices"}, {"orders"}}, pages)
+
+	// Without a limit every table fits in one page
+	result := listTables(`{}`)
+	assert.Equal(t, []string{"accounts", "carts", "events", "invoices", "orders"}, result.TableNames)
+	assert.Empty(t, result.LastEvaluatedTableName)
+
+	// The start table needn't exist
+	assert.Equal(t, []string{"invoices", "orders"}, listTables(`{"ExclusiveStartTableName": "f"}`).TableNames)
+
+	resp := doItemRequest(t, service, "ListTables", `{"Limit": 101}`)
+	assert.Equal(t, 400, resp.StatusCode)
+	assert.Contains(t, string(resp.Body), "ValidationException")
+}
@@ -10,6 +10,65 @@ import (
 	"time"
 )
 
+// smithyResponseTypes maps operation names to constructors for their generated response types.
+var smithyResponseTypes = map[string]func() interface{}{
+	"BatchExecuteStatement":               func() interface{} { return &BatchExecuteStatementOutput{} },
+	"BatchGetItem":                        func() interface{} { return &BatchGetItemOutput{} },
+	"BatchWriteItem":                      func() interface{} { return &BatchWriteItemOutput{} },
+	"CreateBackup":                        func() interface{} { return &CreateBackupOutput{} },
+	"CreateGlobalTable":                   func() interface{} { return &CreateGlobalTableOutput{} },
+	"CreateTable":                         func() interface{} { return &CreateTableOutput{} },
+	"DeleteBackup":                        func() interface{} { return &DeleteBackupOutput{} },
+	"DeleteItem":                          func() interface{} { return &DeleteItemOutput{} },
+	"DeleteResourcePolicy":                func() interface{} { return &DeleteResourcePolicyOutput{} },
+	"DeleteTable":                         func() interface{} { return &DeleteTableOutput{} },
+	"DescribeBackup":                      func() interface{} { return &DescribeBackupOutput{} },
+	"DescribeContinuousBackups":           func() interface{} { return &DescribeContinuousBackupsOutput{} },
+	"DescribeContributorInsights":         func() interface{} { return &DescribeContributorInsightsOutput{} },
+	"DescribeEndpoints":                   func() interface{} { return &DescribeEndpointsResponse{} },
+	"DescribeExport":                      func() interface{} { return &DescribeExportOutput{} },
+	"DescribeGlobalTable":                 func() interface{} { return &DescribeGlobalTableOutput{} },
+	"DescribeGlobalTableSettings":         func() interface{} { return &DescribeGlobalTableSettingsOutput{} },
+	"DescribeImport":                      func() interface{} { return &DescribeImportOutput{} },
+	"DescribeKinesisStreamingDestination": func() interface{} { return &DescribeKinesisStreamingDestinationOutput{} },
+	"DescribeLimits":                      func() interface{} { return &DescribeLimitsOutput{} },
+	"DescribeTable":                       func() interface{} { return &DescribeTableOutput{} },
+	"DescribeTableReplicaAutoScaling":     func() interface{} { return &DescribeTableReplicaAutoScalingOutput{} },
+	"DescribeTimeToLive":                  func() interface{} { return &DescribeTimeToLiveOutput{} },
+	"DisableKinesisStreamingDestination":  func() interface{} { return &KinesisStreamingDestinationOutput{} },
+	"EnableKinesisStreamingDestination":   func() interface{} { return &KinesisStreamingDestinationOutput{} },
+	"ExecuteStatement":                    func() interface{} { return &ExecuteStatementOutput{} },
+	"ExecuteTransaction":                  func() interface{} { return &ExecuteTransactionOutput{} },
+	"ExportTableToPointInTime":            func() interface{} { return &ExportTableToPointInTimeOutput{} },
+	"GetItem":                             func() interface{} { return &GetItemOutput{} },
+	"GetResourcePolicy":                   func() interface{} { return &GetResourcePolicyOutput{} },
+	"ImportTable":                         func() interface{} { return &ImportTableOutput{} },
+	"ListBackups":                         func() interface{} { return &ListBackupsOutput{} },
+	"ListContributorInsights":             func() interface{} { return &ListContributorInsightsOutput{} },
+	"ListExports":                         func() interface{} { return &ListExportsOutput{} },
+	"ListGlobalTables":                    func() interface{} { return &ListGlobalTablesOutput{} },
+	"ListImports":                         func() interface{} { return &ListImportsOutput{} },
+	"ListTables":                          func() interface{} { return &ListTablesOutput{} },
+	"ListTagsOfResource":                  func() interface{} { return &ListTagsOfResourceOutput{} },
+	"PutItem":                             func() interface{} { return &PutItemOutput{} },
+	"PutResourcePolicy":                   func() interface{} { return &PutResourcePolicyOutput{} },
+	"Query":                               func() interface{} { return &QueryOutput{} },
+	"RestoreTableFromBackup":              func() interface{} { return &RestoreTableFromBackupOutput{} },
+	"RestoreTableToPointInTime":           func() interface{} { return &RestoreTableToPointInTimeOutput{} },
+	"Scan":                                func() interface{} { return &ScanOutput{} },
+	"TransactGetItems":                    func() interface{} { return &TransactGetItemsOutput{} },
+	"TransactWriteItems":                  func() interface{} { return &TransactWriteItemsOutput{} },
+	"UpdateContinuousBackups":             func() interface{} { return &UpdateContinuousBackupsOutput{} },
+	"UpdateContributorInsights":           func() interface{} { return &UpdateContributorInsightsOutput{} },
+	"UpdateGlobalTable":                   func() interface{} { return &UpdateGlobalTableOutput{} },
+	"UpdateGlobalTableSettings":           func() interface{} { return &UpdateGlobalTableSettingsOutput{} },
+	"UpdateItem":                          func() interface{} { return &UpdateItemOutput{} },
+	"UpdateKinesisStreamingDestination":   func() interface{} { return &UpdateKinesisStreamingDestinationOutput{} },
+	"UpdateTable":                         func() interface{} { return &UpdateTableOutput{} },
+	"UpdateTableReplicaAutoScaling":       func() interface{} { return &UpdateTableReplicaAutoScalingOutput{} },
+	"UpdateTimeToLive":                    func() interface{} { return &UpdateTimeToLiveOutput{} },
+}
+
 // Enum type aliases
 
 type ApproximateCreationDateTimePrecision string
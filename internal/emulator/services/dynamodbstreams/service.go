@@ -0,0 +1,356 @@
+package dynamodbstreams
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+	"github.com/robmorgan/infraspec/internal/emulator/services/dynamodb"
+)
+
+// maxGetRecordsLimit is the largest number of records GetRecords returns in one call
+const maxGetRecordsLimit = 1000
+
+// DynamoDBStreamsService serves the change logs that the dynamodb service records for tables
+// with streaming enabled. It must share the dynamodb service's state (and partition).
+type DynamoDBStreamsService struct {
+	state     emulator.StateManager
+	validator emulator.Validator
+}
+
+func NewDynamoDBStreamsService(state emulator.StateManager, validator emulator.Validator) *DynamoDBStreamsService {
+	return &DynamoDBStreamsService{
+		state:     state,
+		validator: validator,
+	}
+}
+
+func (s *DynamoDBStreamsService) ServiceName() string {
+	return "dynamodbstreams"
+}
+
+// Protocol returns the AWS protocol the service speaks
+func (s *DynamoDBStreamsService) Protocol() emulator.ProtocolType {
+	return emulator.ProtocolJSON
+}
+
+func (s *DynamoDBStreamsService) HandleRequest(ctx context.Context, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	if err := s.validator.ValidateRequest(req); err != nil {
+		return s.errorResponse(400, "ValidationException", err.Error()), nil
+	}
+
+	action := s.extractAction(req)
+	if action == "" {
+		return s.errorResponse(400, "InvalidAction", "Missing or invalid action"), nil
+	}
+
+	switch action {
+	case "DescribeStream":
+		input, err := emulator.ParseJSONRequest[DescribeStreamInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.describeStream(ctx, input)
+	case "GetShardIterator":
+		input, err := emulator.ParseJSONRequest[GetShardIteratorInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.getShardIterator(ctx, input)
+	case "GetRecords":
+		input, err := emulator.ParseJSONRequest[GetRecordsInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.getRecords(ctx, input)
+	default:
+		return s.errorResponse(400, "InvalidAction", fmt.Sprintf("Unknown action: %s", action)), nil
+	}
+}
+
+func (s *DynamoDBStreamsService) extractAction(req *emulator.AWSRequest) string {
+	if req.Action != "" {
+		return req.Action
+	}
+
+	// DynamoDB Streams uses X-Amz-Target header: "DynamoDBStreams_20120810.GetRecords"
+	target := req.Headers["X-Amz-Target"]
+	if target != "" {
+		parts := strings.Split(target, ".")
+		if len(parts) >= 2 {
+			return parts[len(parts)-1]
+		}
+	}
+
+	return ""
+}
+
+func (s *DynamoDBStreamsService) describeStream(ctx context.Context, input *DescribeStreamInput) (*emulator.AWSResponse, error) {
+	if input.StreamArn == nil || *input.StreamArn == "" {
+		return s.errorResponse(400, "ValidationException", "StreamArn is required"), nil
+	}
+
+	stream, errResp := s.loadStream(*input.StreamArn)
+	if errResp != nil {
+		return errResp, nil
+	}
+
+	description := map[string]interface{}{
+		"StreamArn":               stream.arn,
+		"StreamLabel":             stream.label,
+		"StreamStatus":            "ENABLED",
+		"StreamViewType":          stream.viewType,
+		"CreationRequestDateTime": stream.table["CreationDateTime"],
+		"TableName":               stream.tableName,
+		"KeySchema":               stream.table["KeySchema"],
+		"Shards": []interface{}{
+			map[string]interface{}{
+				"ShardId": stream.shardID,
+				"SequenceNumberRange": map[string]interface{}{
+					"StartingSequenceNumber": startingSequenceNumber(stream.log),
+				},
+			},
+		},
+	}
+
+	return s.jsonResponse(200, map[string]interface{}{
+		"StreamDescription": description,
+	})
+}
+
+func (s *DynamoDBStreamsService) getShardIterator(ctx context.Context, input *GetShardIteratorInput) (*emulator.AWSResponse, error) {
+	if input.StreamArn == nil || *input.StreamArn == "" {
+		return s.errorResponse(400, "ValidationException", "StreamArn is required"), nil
+	}
+	if input.ShardId == nil || *input.ShardId == "" {
+		return s.errorResponse(400, "ValidationException", "ShardId is required"), nil
+	}
+
+	stream, errResp := s.loadStream(*input.StreamArn)
+	if errResp != nil {
+		return errResp, nil
+	}
+	if *input.ShardId != stream.shardID {
+		return s.errorResponse(400, "ResourceNotFoundException", fmt.Sprintf("Requested resource not found: Shard: %s in Stream: %s not found", *input.ShardId, stream.arn)), nil
+	}
+
+	var position int
+	switch input.ShardIteratorType {
+	case "TRIM_HORIZON":
+		position = 0
+	case "LATEST":
+		position = len(stream.log.Records)
+	case "AT_SEQUENCE_NUMBER", "AFTER_SEQUENCE_NUMBER":
+		if input.SequenceNumber == nil || *input.SequenceNumber == "" {
+			return s.errorResponse(400, "ValidationException", fmt.Sprintf("SequenceNumber is required for ShardIteratorType %s", input.ShardIteratorType)), nil
+		}
+		index := sequenceNumberIndex(stream.log, *input.SequenceNumber)
+		if index < 0 {
+			return s.errorResponse(400, "ValidationException", fmt.Sprintf("Invalid SequenceNumber: %s", *input.SequenceNumber)), nil
+		}
+		position = index
+		if input.ShardIteratorType == "AFTER_SEQUENCE_NUMBER" {
+			position++
+		}
+	default:
+		return s.errorResponse(400, "ValidationException", fmt.Sprintf("Invalid ShardIteratorType: %s", input.ShardIteratorType)), nil
+	}
+
+	return s.jsonResponse(200, map[string]interface{}{
+		"ShardIterator": encodeShardIterator(shardIterator{StreamArn: stream.arn, ShardId: stream.shardID, Position: position}),
+	})
+}
+
+func (s *DynamoDBStreamsService) getRecords(ctx context.Context, input *GetRecordsInput) (*emulator.AWSResponse, error) {
+	if input.ShardIterator == nil || *input.ShardIterator == "" {
+		return s.errorResponse(400, "ValidationException", "ShardIterator is required"), nil
+	}
+
+	iterator, err := decodeShardIterator(*input.ShardIterator)
+	if err != nil {
+		return s.errorResponse(400, "ValidationException", "Invalid ShardIterator"), nil
+	}
+
+	limit := maxGetRecordsLimit
+	if input.Limit != nil {
+		if *input.Limit < 1 || *input.Limit > maxGetRecordsLimit {
+			return s.errorResponse(400, "ValidationException", fmt.Sprintf("Limit must be between 1 and %d", maxGetRecordsLimit)), nil
+		}
+		limit = int(*input.Limit)
+	}
+
+	stream, errResp := s.loadStream(iterator.StreamArn)
+	if errResp != nil {
+		return errResp, nil
+	}
+
+	start := iterator.Position
+	if start > len(stream.log.Records) {
+		start = len(stream.log.Records)
+	}
+	end := start + limit
+	if end > len(stream.log.Records) {
+		end = len(stream.log.Records)
+	}
+
+	region := emulator.RequestScopeFromContext(ctx).Region
+	records := make([]interface{}, 0, end-start)
+	for _, record := range stream.log.Records[start:end] {
+		records = append(records, buildRecord(record, region))
+	}
+
+	iterator.Position = end
+	return s.jsonResponse(200, map[string]interface{}{
+		"Records":           records,
+		"NextShardIterator": encodeShardIterator(iterator),
+	})
+}
+
+// tableStream is a table's current stream and its change log
+type tableStream struct {
+	arn       string
+	label     string
+	shardID   string
+	viewType  interface{}
+	tableName string
+	table     map[string]interface{}
+	log       dynamodb.StreamLog
+}
+
+// loadStream resolves a stream ARN to the table's current stream, or returns an error response
+func (s *DynamoDBStreamsService) loadStream(streamArn string) (*tableStream, *emulator.AWSResponse) {
+	notFound := s.errorResponse(400, "ResourceNotFoundException", fmt.Sprintf("Requested resource not found: Stream: %s not found", streamArn))
+
+	// arn:aws:dynamodb:{region}:{account}:table/{table}/stream/{label}
+	parts := strings.Split(streamArn, "/")
+	if len(parts) != 4 || parts[2] != "stream" || !strings.HasSuffix(parts[0], ":table") {
+		return nil, s.errorResponse(400, "ValidationException", fmt.Sprintf("Invalid StreamArn: %s", streamArn))
+	}
+	tableName, label := parts[1], parts[3]
+
+	var table map[string]interface{}
+	if err := s.state.Get(fmt.Sprintf("dynamodb:table:%s", tableName), &table); err != nil {
+		return nil, notFound
+	}
+	if table["LatestStreamArn"] != streamArn {
+		return nil, notFound
+	}
+
+	var log dynamodb.StreamLog
+	if err := s.state.Get(dynamodb.StreamLogKey(tableName), &log); err != nil {
+		return nil, notFound
+	}
+
+	var viewType interface{}
+	if spec, ok := table["StreamSpecification"].(map[string]interface{}); ok {
+		viewType = spec["StreamViewType"]
+	}
+
+	return &tableStream{
+		arn:       streamArn,
+		label:     label,
+		shardID:   shardID(label),
+		viewType:  viewType,
+		tableName: tableName,
+		table:     table,
+		log:       log,
+	}, nil
+}
+
+// shardID returns the ID of the single shard the emulator keeps per stream
+func shardID(streamLabel string) string {
+	suffix := strings.ReplaceAll(streamLabel, "-", "")
+	if len(suffix) > 8 {
+		suffix = suffix[:8]
+	}
+	return fmt.Sprintf("shardId-00000000000000000001-%s", suffix)
+}
+
+func startingSequenceNumber(log dynamodb.StreamLog) interface{} {
+	if len(log.Records) == 0 {
+		return nil
+	}
+	return log.Records[0].SequenceNumber
+}
+
+func sequenceNumberIndex(log dynamodb.StreamLog, sequenceNumber string) int {
+	for i, record := range log.Records {
+		if record.SequenceNumber == sequenceNumber {
+			return i
+		}
+	}
+	return -1
+}
+
+// buildRecord renders a change log entry as a GetRecords record
+func buildRecord(record dynamodb.StreamRecord, region string) map[string]interface{} {
+	data := map[string]interface{}{
+		"ApproximateCreationDateTime": record.ApproximateCreationDateTime,
+		"Keys":                        record.Keys,
+		"SequenceNumber":              record.SequenceNumber,
+		"SizeBytes":                   record.SizeBytes,
+		"StreamViewType":              record.StreamViewType,
+	}
+	if record.NewImage != nil {
+		data["NewImage"] = record.NewImage
+	}
+	if record.OldImage != nil {
+		data["OldImage"] = record.OldImage
+	}
+
+	return map[string]interface{}{
+		"eventID":      record.EventID,
+		"eventName":    record.EventName,
+		"eventVersion": "1.1",
+		"eventSource":  "aws:dynamodb",
+		"awsRegion":    region,
+		"dynamodb":     data,
+	}
+}
+
+// shardIterator is the position of a reader in a stream's shard. It's handed to clients as an
+// opaque token.
+type shardIterator struct {
+	StreamArn string
+	ShardId   string
+	Position  int
+}
+
+func encodeShardIterator(iterator shardIterator) string {
+	data, _ := json.Marshal(iterator)
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+func decodeShardIterator(token string) (shardIterator, error) {
+	var iterator shardIterator
+	data, err := base64.StdEncoding.DecodeString(token)
+	if err != nil {
+		return iterator, err
+	}
+	if err := json.Unmarshal(data, &iterator); err != nil {
+		return iterator, err
+	}
+	if iterator.StreamArn == "" || iterator.Position < 0 {
+		return iterator, fmt.Errorf("invalid shard iterator")
+	}
+	return iterator, nil
+}
+
+func (s *DynamoDBStreamsService) jsonResponse(statusCode int, data interface{}) (*emulator.AWSResponse, error) {
+	resp, err := emulator.BuildJSONResponse(statusCode, data)
+	if err != nil {
+		return s.errorResponse(500, "InternalServerError", "Failed to marshal response"), nil
+	}
+	return resp, nil
+}
+
+func (s *DynamoDBStreamsService) errorResponse(statusCode int, code, message string) *emulator.AWSResponse {
+	return emulator.BuildJSONErrorResponse(statusCode, code, message)
+}
+
+var (
+	_ emulator.Service = (*DynamoDBStreamsService)(nil)
+)
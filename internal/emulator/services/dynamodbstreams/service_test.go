@@ -0,0 +1,198 @@
+package dynamodbstreams
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+	"github.com/robmorgan/infraspec/internal/emulator/services/dynamodb"
+)
+
+// streamsRequest builds a DynamoDB Streams JSON request for action
+func streamsRequest(t *testing.T, action string, input interface{}) *emulator.AWSRequest {
+	t.Helper()
+	body, err := json.Marshal(input)
+	require.NoError(t, err)
+	return &emulator.AWSRequest{
+		Method: "POST",
+		Action: action,
+		Headers: map[string]string{
+			"Content-Type": "application/x-amz-json-1.0",
+			"X-Amz-Target": "DynamoDBStreams_20120810." + action,
+		},
+		Body: body,
+	}
+}
+
+// call sends a request and decodes the JSON response body
+func call(t *testing.T, service emulator.Service, req *emulator.AWSRequest) (int, map[string]interface{}) {
+	t.Helper()
+	resp, err := service.HandleRequest(context.Background(), req)
+	require.NoError(t, err)
+	var out map[string]interface{}
+	require.NoError(t, json.Unmarshal(resp.Body, &out))
+	return resp.StatusCode, out
+}
+
+// setupStream creates an orders table with a stream and seeds its change log with n records
+func setupStream(t *testing.T, n int) (*DynamoDBStreamsService, string) {
+	t.Helper()
+	state := emulator.NewMemoryStateManager()
+	validator := emulator.NewSchemaValidator()
+	tables := dynamodb.NewDynamoDBService(state, validator)
+
+	createBody := `{
+		"TableName": "orders",
+		"KeySchema": [{"AttributeName": "id", "KeyType": "HASH"}],
+		"AttributeDefinitions": [{"AttributeName": "id", "AttributeType": "S"}],
+		"BillingMode": "PAY_PER_REQUEST",
+		"StreamSpecification": {"StreamEnabled": true, "StreamViewType": "NEW_AND_OLD_IMAGES"}
+	}`
+	resp, err := tables.HandleRequest(context.Background(), &emulator.AWSRequest{
+		Method:  "POST",
+		Action:  "CreateTable",
+		Headers: map[string]string{"X-Amz-Target": "DynamoDB_20120810.CreateTable"},
+		Body:    []byte(createBody),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode, string(resp.Body))
+
+	var created struct {
+		TableDescription struct {
+			LatestStreamArn string
+		}
+	}
+	require.NoError(t, json.Unmarshal(resp.Body, &created))
+	require.NotEmpty(t, created.TableDescription.LatestStreamArn)
+
+	var log dynamodb.StreamLog
+	require.NoError(t, state.Get(dynamodb.StreamLogKey("orders"), &log))
+	for i := 1; i <= n; i++ {
+		log.Records = append(log.Records, dynamodb.StreamRecord{
+			EventID:        "event",
+			EventName:      "INSERT",
+			SequenceNumber: formatTestSequenceNumber(i),
+			Keys:           dynamodb.AttributeMap{"id": {"S": "order"}},
+			NewImage:       dynamodb.AttributeMap{"id": {"S": "order"}},
+			StreamViewType: "NEW_AND_OLD_IMAGES",
+		})
+	}
+	require.NoError(t, state.Set(dynamodb.StreamLogKey("orders"), &log))
+
+	return NewDynamoDBStreamsService(state, validator), created.TableDescription.LatestStreamArn
+}
+
+func formatTestSequenceNumber(i int) string {
+	return fmt.Sprintf("%021d", i)
+}
+
+func TestDescribeStream(t *testing.T) {
+	service, streamArn := setupStream(t, 1)
+
+	status, out := call(t, service, streamsRequest(t, "DescribeStream", map[string]string{"StreamArn": streamArn}))
+	require.Equal(t, 200, status, out)
+
+	description := out["StreamDescription"].(map[string]interface{})
+	assert.Equal(t, streamArn, description["StreamArn"])
+	assert.Equal(t, "orders", description["TableName"])
+	assert.Equal(t, "ENABLED", description["StreamStatus"])
+	assert.Equal(t, "NEW_AND_OLD_IMAGES", description["StreamViewType"])
+
+	shards := description["Shards"].([]interface{})
+	require.Len(t, shards, 1)
+	assert.NotEmpty(t, shards[0].(map[string]interface{})["ShardId"])
+}
+
+func TestDescribeStream_NotFound(t *testing.T) {
+	service, _ := setupStream(t, 0)
+
+	status, out := call(t, service, streamsRequest(t, "DescribeStream", map[string]string{
+		"StreamArn": "arn:aws:dynamodb:us-east-1:123456789012:table/missing/stream/label",
+	}))
+	assert.Equal(t, 400, status)
+	assert.Equal(t, "ResourceNotFoundException", out["__type"])
+}
+
+func TestGetRecords_ReadsChangeLog(t *testing.T) {
+	service, streamArn := setupStream(t, 3)
+
+	_, out := call(t, service, streamsRequest(t, "DescribeStream", map[string]string{"StreamArn": streamArn}))
+	shardID := out["StreamDescription"].(map[string]interface{})["Shards"].([]interface{})[0].(map[string]interface{})["ShardId"].(string)
+
+	status, out := call(t, service, streamsRequest(t, "GetShardIterator", map[string]string{
+		"StreamArn":         streamArn,
+		"ShardId":           shardID,
+		"ShardIteratorType": "TRIM_HORIZON",
+	}))
+	require.Equal(t, 200, status, out)
+	iterator := out["ShardIterator"].(string)
+
+	// Page through the log two records at a time
+	status, out = call(t, service, streamsRequest(t, "GetRecords", map[string]interface{}{"ShardIterator": iterator, "Limit": 2}))
+	require.Equal(t, 200, status, out)
+	records := out["Records"].([]interface{})
+	require.Len(t, records, 2)
+
+	first := records[0].(map[string]interface{})
+	assert.Equal(t, "INSERT", first["eventName"])
+	assert.Equal(t, "aws:dynamodb", first["eventSource"])
+	assert.Equal(t, "us-east-1", first["awsRegion"])
+	data := first["dynamodb"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"id": map[string]interface{}{"S": "order"}}, data["Keys"])
+	assert.NotNil(t, data["NewImage"])
+
+	status, out = call(t, service, streamsRequest(t, "GetRecords", map[string]interface{}{"ShardIterator": out["NextShardIterator"]}))
+	require.Equal(t, 200, status, out)
+	assert.Len(t, out["Records"].([]interface{}), 1)
+
+	// Caught up: no records, but the iterator stays usable
+	status, out = call(t, service, streamsRequest(t, "GetRecords", map[string]interface{}{"ShardIterator": out["NextShardIterator"]}))
+	require.Equal(t, 200, status, out)
+	assert.Empty(t, out["Records"])
+	assert.NotEmpty(t, out["NextShardIterator"])
+}
+
+func TestGetShardIterator_Types(t *testing.T) {
+	service, streamArn := setupStream(t, 3)
+	_, out := call(t, service, streamsRequest(t, "DescribeStream", map[string]string{"StreamArn": streamArn}))
+	shardID := out["StreamDescription"].(map[string]interface{})["Shards"].([]interface{})[0].(map[string]interface{})["ShardId"].(string)
+
+	tests := []struct {
+		iteratorType   string
+		sequenceNumber string
+		wantRecords    int
+	}{
+		{iteratorType: "TRIM_HORIZON", wantRecords: 3},
+		{iteratorType: "LATEST", wantRecords: 0},
+		{iteratorType: "AT_SEQUENCE_NUMBER", sequenceNumber: formatTestSequenceNumber(2), wantRecords: 2},
+		{iteratorType: "AFTER_SEQUENCE_NUMBER", sequenceNumber: formatTestSequenceNumber(2), wantRecords: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.iteratorType, func(t *testing.T) {
+			input := map[string]string{"StreamArn": streamArn, "ShardId": shardID, "ShardIteratorType": tt.iteratorType}
+			if tt.sequenceNumber != "" {
+				input["SequenceNumber"] = tt.sequenceNumber
+			}
+			status, out := call(t, service, streamsRequest(t, "GetShardIterator", input))
+			require.Equal(t, 200, status, out)
+
+			status, out = call(t, service, streamsRequest(t, "GetRecords", map[string]interface{}{"ShardIterator": out["ShardIterator"]}))
+			require.Equal(t, 200, status, out)
+			assert.Len(t, out["Records"], tt.wantRecords)
+		})
+	}
+}
+
+func TestGetRecords_InvalidIterator(t *testing.T) {
+	service, _ := setupStream(t, 0)
+
+	status, out := call(t, service, streamsRequest(t, "GetRecords", map[string]string{"ShardIterator": "not-an-iterator"}))
+	assert.Equal(t, 400, status)
+	assert.Equal(t, "ValidationException", out["__type"])
+}
@@ -0,0 +1,22 @@
+package dynamodbstreams
+
+// DescribeStreamInput is the request for DescribeStream
+type DescribeStreamInput struct {
+	StreamArn             *string `json:"StreamArn,omitempty"`
+	Limit                 *int32  `json:"Limit,omitempty"`
+	ExclusiveStartShardId *string `json:"ExclusiveStartShardId,omitempty"`
+}
+
+// GetShardIteratorInput is the request for GetShardIterator
+type GetShardIteratorInput struct {
+	StreamArn         *string `json:"StreamArn,omitempty"`
+	ShardId           *string `json:"ShardId,omitempty"`
+	ShardIteratorType string  `json:"ShardIteratorType,omitempty"`
+	SequenceNumber    *string `json:"SequenceNumber,omitempty"`
+}
+
+// GetRecordsInput is the request for GetRecords
+type GetRecordsInput struct {
+	ShardIterator *string `json:"ShardIterator,omitempty"`
+	Limit         *int32  `json:"Limit,omitempty"`
+}
@@ -25,5 +25,10 @@ func (s *EC2Service) authorizeSecurityGroupEgress(ctx context.Context, params ma
 		return s.errorResponse(500, "InternalFailure", "Failed to update security group"), nil
 	}
 
-	return s.authorizeSecurityGroupEgressResponse()
+	// Return the rules the new permissions added
+	var rules []SecurityGroupRule
+	for _, permission := range permissions {
+		rules = append(rules, permissionRules(sg, permission, true)...)
+	}
+	return s.authorizeSecurityGroupEgressResponse(rules)
 }
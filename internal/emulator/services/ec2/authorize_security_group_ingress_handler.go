@@ -26,5 +26,10 @@ func (s *EC2Service) authorizeSecurityGroupIngress(ctx context.Context, params m
 		return s.errorResponse(500, "InternalFailure", "Failed to update security group"), nil
 	}
 
-	return s.authorizeSecurityGroupIngressResponse()
+	// Return the rules the new permissions added
+	var rules []SecurityGroupRule
+	for _, permission := range permissions {
+		rules = append(rules, permissionRules(sg, permission, false)...)
+	}
+	return s.authorizeSecurityGroupIngressResponse(rules)
 }
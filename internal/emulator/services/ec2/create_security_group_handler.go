@@ -3,12 +3,12 @@ package ec2
 import (
 	"context"
 	"fmt"
-	"log"
 
 	"github.com/google/uuid"
 	"github.com/robmorgan/infraspec/internal/emulator/core"
 	"github.com/robmorgan/infraspec/internal/emulator/graph"
 	"github.com/robmorgan/infraspec/internal/emulator/helpers"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 )
 
 func (s *EC2Service) createSecurityGroup(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
@@ -31,7 +31,7 @@ func (s *EC2Service) createSecurityGroup(ctx context.Context, params map[string]
 		GroupName:     &groupName,
 		Description:   &description,
 		VpcId:         &vpcId,
-		OwnerId:       helpers.StringPtr("123456789012"),
+		OwnerId:       helpers.StringPtr(emulator.RequestScopeFromContext(ctx).AccountID),
 		IpPermissions: []IpPermission{},
 		IpPermissionsEgress: []IpPermission{
 			{
@@ -60,7 +60,7 @@ func (s *EC2Service) createSecurityGroup(ctx context.Context, params map[string]
 			s.unregisterResource("security-group", groupId)
 			return s.errorResponse(500, "InternalFailure", fmt.Sprintf("Failed to create security-group-vpc relationship: %v", err)), nil
 		}
-		log.Printf("Warning: failed to add security-group-vpc relationship in graph: %v", err)
+		logging.Warnf("ec2", "Warning: failed to add security-group-vpc relationship in graph: %v", err)
 	}
 
 	return s.createSecurityGroupResponse(groupId)
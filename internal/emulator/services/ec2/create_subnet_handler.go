@@ -3,13 +3,13 @@ package ec2
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/robmorgan/infraspec/internal/emulator/core"
 	"github.com/robmorgan/infraspec/internal/emulator/graph"
 	"github.com/robmorgan/infraspec/internal/emulator/helpers"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 )
 
 func (s *EC2Service) createSubnet(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
@@ -48,7 +48,7 @@ func (s *EC2Service) createSubnet(ctx context.Context, params map[string]interfa
 		DefaultForAz:            helpers.BoolPtr(false),
 		MapPublicIpOnLaunch:     helpers.BoolPtr(false),
 		AvailableIpAddressCount: helpers.Int32Ptr(251),
-		OwnerId:                 helpers.StringPtr("123456789012"),
+		OwnerId:                 helpers.StringPtr(emulator.RequestScopeFromContext(ctx).AccountID),
 	}
 
 	stateKey := fmt.Sprintf("ec2:subnets:%s", subnetId)
@@ -70,7 +70,7 @@ func (s *EC2Service) createSubnet(ctx context.Context, params map[string]interfa
 			s.unregisterResource("subnet", subnetId)
 			return s.errorResponse(500, "InternalFailure", fmt.Sprintf("Failed to create subnet-vpc relationship: %v", err)), nil
 		}
-		log.Printf("Warning: failed to add subnet-vpc relationship in graph: %v", err)
+		logging.Warnf("ec2", "Warning: failed to add subnet-vpc relationship in graph: %v", err)
 	}
 
 	// Schedule transition to available
@@ -3,7 +3,6 @@ package ec2
 import (
 	"context"
 	"fmt"
-	"log"
 	"strings"
 	"time"
 
@@ -11,6 +10,7 @@ import (
 	"github.com/robmorgan/infraspec/internal/emulator/core"
 	"github.com/robmorgan/infraspec/internal/emulator/graph"
 	"github.com/robmorgan/infraspec/internal/emulator/helpers"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 )
 
 func (s *EC2Service) createVpc(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
@@ -35,7 +35,7 @@ func (s *EC2Service) createVpc(ctx context.Context, params map[string]interface{
 		CidrBlock:       &cidrBlock,
 		State:           VpcState("pending"),
 		IsDefault:       helpers.BoolPtr(false),
-		OwnerId:         helpers.StringPtr("123456789012"),
+		OwnerId:         helpers.StringPtr(emulator.RequestScopeFromContext(ctx).AccountID),
 		InstanceTenancy: Tenancy("default"),
 		Tags:            tags,
 	}
@@ -63,7 +63,7 @@ func (s *EC2Service) createVpc(ctx context.Context, params map[string]interface{
 	rtb := RouteTable{
 		RouteTableId: helpers.StringPtr(rtbId),
 		VpcId:        &vpcId,
-		OwnerId:      helpers.StringPtr("123456789012"),
+		OwnerId:      helpers.StringPtr(emulator.RequestScopeFromContext(ctx).AccountID),
 		Routes: []Route{
 			{
 				DestinationCidrBlock: &cidrBlock,
@@ -92,7 +92,7 @@ func (s *EC2Service) createVpc(ctx context.Context, params map[string]interface{
 		"main":  "true",
 	})
 	if err := s.addRelationship("route-table", rtbId, "ec2", "vpc", vpcId, graph.RelContains); err != nil {
-		log.Printf("Warning: failed to add route-table-vpc relationship in graph: %v", err)
+		logging.Warnf("ec2", "Warning: failed to add route-table-vpc relationship in graph: %v", err)
 	}
 
 	// Create the default security group for this VPC (AWS creates one automatically)
@@ -104,7 +104,7 @@ func (s *EC2Service) createVpc(ctx context.Context, params map[string]interface{
 		GroupName:   &defaultSgName,
 		Description: &defaultSgDesc,
 		VpcId:       &vpcId,
-		OwnerId:     helpers.StringPtr("123456789012"),
+		OwnerId:     helpers.StringPtr(emulator.RequestScopeFromContext(ctx).AccountID),
 		// Default inbound rule: allow all traffic from resources in this security group
 		IpPermissions: []IpPermission{
 			{
@@ -112,7 +112,7 @@ func (s *EC2Service) createVpc(ctx context.Context, params map[string]interface{
 				UserIdGroupPairs: []UserIdGroupPair{
 					{
 						GroupId: helpers.StringPtr(sgId),
-						UserId:  helpers.StringPtr("123456789012"),
+						UserId:  helpers.StringPtr(emulator.RequestScopeFromContext(ctx).AccountID),
 					},
 				},
 			},
@@ -134,7 +134,7 @@ func (s *EC2Service) createVpc(ctx context.Context, params map[string]interface{
 		"vpcId": vpcId,
 	})
 	if err := s.addRelationship("security-group", sgId, "ec2", "vpc", vpcId, graph.RelContains); err != nil {
-		log.Printf("Warning: failed to add security-group-vpc relationship in graph: %v", err)
+		logging.Warnf("ec2", "Warning: failed to add security-group-vpc relationship in graph: %v", err)
 	}
 
 	// Schedule transition to available
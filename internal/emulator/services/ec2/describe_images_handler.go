@@ -3,12 +3,33 @@ package ec2
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/robmorgan/infraspec/internal/emulator/core"
+	"github.com/robmorgan/infraspec/internal/emulator/helpers"
+)
+
+const (
+	// amazonOwnerId is the account that owns the Amazon Linux AMIs
+	amazonOwnerId = "137112412989"
+	// canonicalOwnerId is the account that owns the Ubuntu AMIs
+	canonicalOwnerId = "099720109477"
 )
 
 func (s *EC2Service) describeImages(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
 	imageIds := s.parseImageIds(params)
+	owners := s.parseIndexedParams(params, "Owner")
+	filters := map[string][]string{
+		"image-id":            s.parseFilterValues(params, "image-id"),
+		"name":                s.parseFilterValues(params, "name"),
+		"owner-id":            s.parseFilterValues(params, "owner-id"),
+		"owner-alias":         s.parseFilterValues(params, "owner-alias"),
+		"architecture":        s.parseFilterValues(params, "architecture"),
+		"virtualization-type": s.parseFilterValues(params, "virtualization-type"),
+		"root-device-type":    s.parseFilterValues(params, "root-device-type"),
+		"image-type":          s.parseFilterValues(params, "image-type"),
+		"state":               s.parseFilterValues(params, "state"),
+	}
 
 	var images []Image
 
@@ -34,5 +55,53 @@ func (s *EC2Service) describeImages(ctx context.Context, params map[string]inter
 		}
 	}
 
-	return s.describeImagesResponse(images)
+	accountId := emulator.RequestScopeFromContext(ctx).AccountID
+	matched := make([]Image, 0, len(images))
+	for _, image := range images {
+		if len(owners) > 0 && !imageOwnedBy(image, owners, accountId) {
+			continue
+		}
+		if !matchesAllFilters(filters, imageFilterValues(image)) {
+			continue
+		}
+		matched = append(matched, image)
+	}
+
+	// Order the images by creation date, oldest first. The timestamps share one format, so
+	// they sort as strings.
+	sort.SliceStable(matched, func(i, j int) bool {
+		return helpers.StringValue(matched[i].CreationDate) < helpers.StringValue(matched[j].CreationDate)
+	})
+
+	return s.describeImagesResponse(matched)
+}
+
+// imageOwnedBy reports whether one of the owners, given as account IDs, aliases like
+// "amazon", or "self" for the caller's account, owns the image
+func imageOwnedBy(image Image, owners []string, accountId string) bool {
+	for _, owner := range owners {
+		switch owner {
+		case helpers.StringValue(image.OwnerId), helpers.StringValue(image.ImageOwnerAlias):
+			return true
+		case "self":
+			if helpers.StringValue(image.OwnerId) == accountId {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func imageFilterValues(image Image) map[string]string {
+	return map[string]string{
+		"image-id":            helpers.StringValue(image.ImageId),
+		"name":                helpers.StringValue(image.Name),
+		"owner-id":            helpers.StringValue(image.OwnerId),
+		"owner-alias":         helpers.StringValue(image.ImageOwnerAlias),
+		"architecture":        string(image.Architecture),
+		"virtualization-type": string(image.VirtualizationType),
+		"root-device-type":    string(image.RootDeviceType),
+		"image-type":          string(image.ImageType),
+		"state":               string(image.State),
+	}
 }
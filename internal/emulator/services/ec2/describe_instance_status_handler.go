@@ -0,0 +1,76 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+)
+
+// describeInstanceStatus reports the status checks of instances. Like AWS, only running
+// instances are described unless IncludeAllInstances is true. The emulator has no impaired
+// instances, so the checks of a running instance always pass.
+func (s *EC2Service) describeInstanceStatus(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	instanceIds := s.parseInstanceIds(params)
+	includeAll, _ := params["IncludeAllInstances"].(string)
+
+	var instances []Instance
+	if len(instanceIds) > 0 {
+		for _, instanceId := range instanceIds {
+			var instance Instance
+			if err := s.state.Get(fmt.Sprintf("ec2:instances:%s", instanceId), &instance); err != nil {
+				return s.errorResponse(400, "InvalidInstanceID.NotFound", fmt.Sprintf("The instance ID '%s' does not exist", instanceId)), nil
+			}
+			instances = append(instances, instance)
+		}
+	} else {
+		keys, err := s.state.List("ec2:instances:")
+		if err != nil {
+			return s.errorResponse(500, "InternalFailure", "Failed to list instances"), nil
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			var instance Instance
+			if err := s.state.Get(key, &instance); err == nil {
+				instances = append(instances, instance)
+			}
+		}
+	}
+
+	statuses := make([]InstanceStatus, 0, len(instances))
+	for _, instance := range instances {
+		running := instance.State != nil && instance.State.Name == InstanceStateName("running")
+		if !running && includeAll != "true" {
+			continue
+		}
+
+		status := InstanceStatus{
+			InstanceId:     instance.InstanceId,
+			InstanceState:  instance.State,
+			InstanceStatus: instanceStatusSummary(running),
+			SystemStatus:   instanceStatusSummary(running),
+		}
+		if instance.Placement != nil {
+			status.AvailabilityZone = instance.Placement.AvailabilityZone
+		}
+		statuses = append(statuses, status)
+	}
+
+	return s.describeInstanceStatusResponse(statuses)
+}
+
+// instanceStatusSummary returns a passing status check, or "not-applicable" for an instance
+// that isn't running
+func instanceStatusSummary(running bool) *InstanceStatusSummary {
+	if !running {
+		return &InstanceStatusSummary{Status: SummaryStatus("not-applicable")}
+	}
+	return &InstanceStatusSummary{
+		Status: SummaryStatus("ok"),
+		Details: []InstanceStatusDetails{
+			{Name: StatusName("reachability"), Status: StatusType("passed")},
+		},
+	}
+}
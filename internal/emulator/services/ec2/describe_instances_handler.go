@@ -3,6 +3,7 @@ package ec2
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/robmorgan/infraspec/internal/emulator/core"
 	"github.com/robmorgan/infraspec/internal/emulator/helpers"
@@ -10,6 +11,8 @@ import (
 
 func (s *EC2Service) describeInstances(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
 	instanceIds := s.parseInstanceIds(params)
+	stateFilter := s.parseFilterValues(params, "instance-state-name")
+	tagFilters := s.extractTagFilters(params)
 
 	var reservations []Reservation
 
@@ -23,12 +26,15 @@ func (s *EC2Service) describeInstances(ctx context.Context, params map[string]in
 			}
 			// Merge tags from separate tag storage
 			s.mergeResourceTags(&instance.Tags, instanceId)
+			if !instanceMatchesFilters(instance, stateFilter, tagFilters) {
+				continue
+			}
 			instances = append(instances, instance)
 		}
 		if len(instances) > 0 {
 			reservations = append(reservations, Reservation{
 				ReservationId: helpers.StringPtr("r-synthetic"),
-				OwnerId:       helpers.StringPtr("123456789012"),
+				OwnerId:       helpers.StringPtr(emulator.RequestScopeFromContext(ctx).AccountID),
 				Instances:     instances,
 			})
 		}
@@ -47,13 +53,16 @@ func (s *EC2Service) describeInstances(ctx context.Context, params map[string]in
 				if instance.InstanceId != nil {
 					s.mergeResourceTags(&instance.Tags, *instance.InstanceId)
 				}
+				if !instanceMatchesFilters(instance, stateFilter, tagFilters) {
+					continue
+				}
 				instances = append(instances, instance)
 			}
 		}
 		if len(instances) > 0 {
 			reservations = append(reservations, Reservation{
 				ReservationId: helpers.StringPtr("r-synthetic"),
-				OwnerId:       helpers.StringPtr("123456789012"),
+				OwnerId:       helpers.StringPtr(emulator.RequestScopeFromContext(ctx).AccountID),
 				Instances:     instances,
 			})
 		}
@@ -61,3 +70,14 @@ func (s *EC2Service) describeInstances(ctx context.Context, params map[string]in
 
 	return s.describeInstancesResponse(reservations)
 }
+
+// instanceMatchesFilters reports whether an instance is in one of the states of the
+// instance-state-name filter, if any, and has the value of every tag filter
+func instanceMatchesFilters(instance Instance, states []string, tagFilters map[string]string) bool {
+	if len(states) > 0 {
+		if instance.State == nil || !slices.Contains(states, string(instance.State.Name)) {
+			return false
+		}
+	}
+	return matchesTagFilters(instance.Tags, tagFilters)
+}
@@ -0,0 +1,116 @@
+package ec2
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+	"github.com/robmorgan/infraspec/internal/emulator/helpers"
+)
+
+func (s *EC2Service) describeSecurityGroupRules(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	ruleIds := s.parseIndexedParams(params, "SecurityGroupRuleId")
+	ruleIds = append(ruleIds, s.parseFilterValues(params, "security-group-rule-id")...)
+	groupIds := s.parseFilterValues(params, "group-id")
+
+	keys, err := s.state.List("ec2:security-groups:")
+	if err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to list security groups"), nil
+	}
+	sort.Strings(keys)
+
+	var rules []SecurityGroupRule
+	for _, key := range keys {
+		var sg SecurityGroup
+		if err := s.state.Get(key, &sg); err != nil || sg.GroupId == nil {
+			continue
+		}
+		if len(groupIds) > 0 && !slices.Contains(groupIds, *sg.GroupId) {
+			continue
+		}
+
+		for _, rule := range securityGroupRules(sg) {
+			if len(ruleIds) > 0 && !slices.Contains(ruleIds, *rule.SecurityGroupRuleId) {
+				continue
+			}
+			rules = append(rules, rule)
+		}
+	}
+
+	return s.describeSecurityGroupRulesResponse(rules)
+}
+
+// securityGroupRules returns the rules of a security group's permissions, one for each of the
+// CIDR ranges, prefix lists and security groups a permission allows, ingress rules first
+func securityGroupRules(sg SecurityGroup) []SecurityGroupRule {
+	var rules []SecurityGroupRule
+	for _, permission := range sg.IpPermissions {
+		rules = append(rules, permissionRules(sg, permission, false)...)
+	}
+	for _, permission := range sg.IpPermissionsEgress {
+		rules = append(rules, permissionRules(sg, permission, true)...)
+	}
+	return rules
+}
+
+// permissionRules splits a permission into a rule for each of the sources, or destinations for
+// egress, that it allows
+func permissionRules(sg SecurityGroup, permission IpPermission, egress bool) []SecurityGroupRule {
+	newRule := func(target string) SecurityGroupRule {
+		return SecurityGroupRule{
+			SecurityGroupRuleId: helpers.StringPtr(securityGroupRuleId(*sg.GroupId, permission, egress, target)),
+			GroupId:             sg.GroupId,
+			GroupOwnerId:        sg.OwnerId,
+			IsEgress:            &egress,
+			IpProtocol:          permission.IpProtocol,
+			FromPort:            permission.FromPort,
+			ToPort:              permission.ToPort,
+		}
+	}
+
+	var rules []SecurityGroupRule
+	for _, ipRange := range permission.IpRanges {
+		rule := newRule(helpers.StringValue(ipRange.CidrIp))
+		rule.CidrIpv4 = ipRange.CidrIp
+		rule.Description = ipRange.Description
+		rules = append(rules, rule)
+	}
+	for _, ipv6Range := range permission.Ipv6Ranges {
+		rule := newRule(helpers.StringValue(ipv6Range.CidrIpv6))
+		rule.CidrIpv6 = ipv6Range.CidrIpv6
+		rule.Description = ipv6Range.Description
+		rules = append(rules, rule)
+	}
+	for _, prefixList := range permission.PrefixListIds {
+		rule := newRule(helpers.StringValue(prefixList.PrefixListId))
+		rule.PrefixListId = prefixList.PrefixListId
+		rule.Description = prefixList.Description
+		rules = append(rules, rule)
+	}
+	for _, pair := range permission.UserIdGroupPairs {
+		rule := newRule(helpers.StringValue(pair.GroupId))
+		rule.ReferencedGroupInfo = &ReferencedSecurityGroup{GroupId: pair.GroupId, UserId: pair.UserId, VpcId: pair.VpcId}
+		rule.Description = pair.Description
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// securityGroupRuleId derives a rule's ID from the rule, so it stays the same while the rule
+// exists without being stored, and identical rules in a group share it
+func securityGroupRuleId(groupId string, permission IpPermission, egress bool, target string) string {
+	fromPort, toPort := int32(-1), int32(-1)
+	if permission.FromPort != nil {
+		fromPort = *permission.FromPort
+	}
+	if permission.ToPort != nil {
+		toPort = *permission.ToPort
+	}
+	key := fmt.Sprintf("%s|%t|%s|%d|%d|%s", groupId, egress, helpers.StringValue(permission.IpProtocol), fromPort, toPort, target)
+	sum := sha256.Sum256([]byte(key))
+	return "sgr-" + hex.EncodeToString(sum[:])[:17]
+}
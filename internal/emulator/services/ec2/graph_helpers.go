@@ -1,9 +1,8 @@
 package ec2
 
 import (
-	"log"
-
 	"github.com/robmorgan/infraspec/internal/emulator/graph"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 )
 
 // ==================== Resource Graph Helper Functions ====================
@@ -20,7 +19,7 @@ func (s *EC2Service) registerResource(resourceType, resourceID string, metadata
 		ID:      resourceID,
 	}
 	if err := s.resourceManager.RegisterResource(id, metadata); err != nil {
-		log.Printf("Warning: failed to register %s/%s in graph: %v", resourceType, resourceID, err)
+		logging.Warnf("ec2", "Warning: failed to register %s/%s in graph: %v", resourceType, resourceID, err)
 	}
 }
 
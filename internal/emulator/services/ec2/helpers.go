@@ -82,6 +82,8 @@ func getResourceType(resourceId string) ResourceType {
 		return ResourceType("security-group")
 	case strings.HasPrefix(resourceId, "igw-"):
 		return ResourceType("internet-gateway")
+	case strings.HasPrefix(resourceId, "vpce-"):
+		return ResourceType("vpc-endpoint")
 	case strings.HasPrefix(resourceId, "lt-"):
 		return ResourceType("launch-template")
 	default:
@@ -298,6 +300,36 @@ func (s *EC2Service) extractFilterValue(params map[string]interface{}, filterNam
 	return ""
 }
 
+// extractTagFilters returns the values of the tag:<key> filters, keyed by tag key
+func (s *EC2Service) extractTagFilters(params map[string]interface{}) map[string]string {
+	filters := make(map[string]string)
+	for i := 1; i <= 10; i++ {
+		name, _ := params[fmt.Sprintf("Filter.%d.Name", i)].(string)
+		if key, ok := strings.CutPrefix(name, "tag:"); ok {
+			value, _ := params[fmt.Sprintf("Filter.%d.Value.1", i)].(string)
+			filters[key] = value
+		}
+	}
+	return filters
+}
+
+// matchesTagFilters reports whether the tags have the value of every tag filter
+func matchesTagFilters(tags []Tag, filters map[string]string) bool {
+	for key, value := range filters {
+		matched := false
+		for _, tag := range tags {
+			if tag.Key != nil && *tag.Key == key && tag.Value != nil && *tag.Value == value {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
 // ==================== Security Group Rule Helpers ====================
 
 // removeMatchingRules removes rules from existingRules that match any of the rulesToRevoke.
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -374,3 +375,604 @@ func TestIntegration_CreateAndDescribeVolume(t *testing.T) {
 	// This is a known limitation of using generic XML marshaling with AWS SDK types
 	t.Skip("Skipping: XML response structure requires custom marshaling for AWS SDK compatibility")
 }
+
+func TestIntegration_DescribeRegions(t *testing.T) {
+	client, _, cleanup := setupIntegrationTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	result, err := client.DescribeRegions(ctx, &ec2.DescribeRegionsInput{})
+	if err != nil {
+		t.Fatalf("DescribeRegions failed: %v", err)
+	}
+
+	if len(result.Regions) != len(emulatedRegions) {
+		t.Fatalf("Expected %d regions, got %d", len(emulatedRegions), len(result.Regions))
+	}
+
+	// Filtering by name should return just that region
+	result, err = client.DescribeRegions(ctx, &ec2.DescribeRegionsInput{
+		RegionNames: []string{"eu-west-1"},
+	})
+	if err != nil {
+		t.Fatalf("DescribeRegions with RegionNames failed: %v", err)
+	}
+
+	if len(result.Regions) != 1 {
+		t.Fatalf("Expected 1 region, got %d", len(result.Regions))
+	}
+	if aws.ToString(result.Regions[0].RegionName) != "eu-west-1" {
+		t.Errorf("Expected region eu-west-1, got %s", aws.ToString(result.Regions[0].RegionName))
+	}
+	if aws.ToString(result.Regions[0].Endpoint) != "ec2.eu-west-1.amazonaws.com" {
+		t.Errorf("Unexpected endpoint: %s", aws.ToString(result.Regions[0].Endpoint))
+	}
+}
+
+func TestIntegration_DescribeAvailabilityZones(t *testing.T) {
+	client, _, cleanup := setupIntegrationTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Terraform's aws_availability_zones data source filters on state by default
+	result, err := client.DescribeAvailabilityZones(ctx, &ec2.DescribeAvailabilityZonesInput{
+		Filters: []types.Filter{
+			{Name: aws.String("state"), Values: []string{"available"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("DescribeAvailabilityZones failed: %v", err)
+	}
+
+	if len(result.AvailabilityZones) != 3 {
+		t.Fatalf("Expected 3 availability zones, got %d", len(result.AvailabilityZones))
+	}
+
+	zone := result.AvailabilityZones[0]
+	if aws.ToString(zone.ZoneName) != "us-east-1a" {
+		t.Errorf("Expected zone us-east-1a, got %s", aws.ToString(zone.ZoneName))
+	}
+	if aws.ToString(zone.ZoneId) != "use1-az1" {
+		t.Errorf("Expected zone ID use1-az1, got %s", aws.ToString(zone.ZoneId))
+	}
+	if zone.State != types.AvailabilityZoneStateAvailable {
+		t.Errorf("Expected zone state available, got %s", zone.State)
+	}
+
+	// A filter that matches nothing returns an empty list
+	result, err = client.DescribeAvailabilityZones(ctx, &ec2.DescribeAvailabilityZonesInput{
+		ZoneNames: []string{"us-east-1z"},
+	})
+	if err != nil {
+		t.Fatalf("DescribeAvailabilityZones with ZoneNames failed: %v", err)
+	}
+	if len(result.AvailabilityZones) != 0 {
+		t.Errorf("Expected no availability zones, got %d", len(result.AvailabilityZones))
+	}
+}
+
+func TestIntegration_DescribeImages_MostRecent(t *testing.T) {
+	client, _, cleanup := setupIntegrationTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// The lookup Terraform's aws_ami data source makes for owners and a name filter
+	result, err := client.DescribeImages(ctx, &ec2.DescribeImagesInput{
+		Owners: []string{"099720109477"},
+		Filters: []types.Filter{
+			{Name: aws.String("name"), Values: []string{"ubuntu/images/hvm-ssd/ubuntu-jammy-22.04-amd64-server-*"}},
+			{Name: aws.String("virtualization-type"), Values: []string{"hvm"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("DescribeImages failed: %v", err)
+	}
+
+	if len(result.Images) != 2 {
+		t.Fatalf("Expected 2 images, got %d", len(result.Images))
+	}
+	newest := result.Images[len(result.Images)-1]
+	if aws.ToString(newest.ImageId) != "ami-04a81a99f5ec58529" {
+		t.Errorf("Expected the newest image to be ami-04a81a99f5ec58529, got %s", aws.ToString(newest.ImageId))
+	}
+	if aws.ToString(newest.CreationDate) <= aws.ToString(result.Images[0].CreationDate) {
+		t.Errorf("Expected images ordered by creation date, got %s then %s", aws.ToString(result.Images[0].CreationDate), aws.ToString(newest.CreationDate))
+	}
+
+	// Owner aliases and the architecture filter
+	result, err = client.DescribeImages(ctx, &ec2.DescribeImagesInput{
+		Owners: []string{"amazon"},
+		Filters: []types.Filter{
+			{Name: aws.String("name"), Values: []string{"al2023-ami-2023.*"}},
+			{Name: aws.String("architecture"), Values: []string{"arm64"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("DescribeImages with an owner alias failed: %v", err)
+	}
+	if len(result.Images) != 1 || aws.ToString(result.Images[0].ImageId) != "ami-0c9e5f1d1e5c8a0b2" {
+		t.Fatalf("Expected only the arm64 Amazon Linux 2023 image, got %d images", len(result.Images))
+	}
+
+	// Images owned by another account are excluded
+	result, err = client.DescribeImages(ctx, &ec2.DescribeImagesInput{
+		Filters: []types.Filter{
+			{Name: aws.String("owner-id"), Values: []string{"137112412989"}},
+			{Name: aws.String("name"), Values: []string{"ubuntu*"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("DescribeImages with an owner-id filter failed: %v", err)
+	}
+	if len(result.Images) != 0 {
+		t.Errorf("Expected no images, got %d", len(result.Images))
+	}
+}
+
+func TestIntegration_NetworkInterfaceLifecycle(t *testing.T) {
+	client, _, cleanup := setupIntegrationTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Create a network interface in the default subnet, which uses the default security group
+	createResult, err := client.CreateNetworkInterface(ctx, &ec2.CreateNetworkInterfaceInput{
+		SubnetId:    aws.String("subnet-default"),
+		Description: aws.String("lambda eni"),
+	})
+	if err != nil {
+		t.Fatalf("CreateNetworkInterface failed: %v", err)
+	}
+
+	eni := createResult.NetworkInterface
+	eniId := aws.ToString(eni.NetworkInterfaceId)
+	if !strings.HasPrefix(eniId, "eni-") {
+		t.Errorf("Expected a network interface ID starting with eni-, got %q", eniId)
+	}
+	if aws.ToString(eni.VpcId) != "vpc-default" {
+		t.Errorf("Expected VpcId vpc-default, got %s", aws.ToString(eni.VpcId))
+	}
+	if eni.Status != types.NetworkInterfaceStatusAvailable {
+		t.Errorf("Expected status available, got %s", eni.Status)
+	}
+	if len(eni.Groups) != 1 || aws.ToString(eni.Groups[0].GroupId) != "sg-default" {
+		t.Errorf("Expected the default security group, got %v", eni.Groups)
+	}
+	if aws.ToString(eni.PrivateIpAddress) == "" {
+		t.Error("Expected PrivateIpAddress to be set")
+	}
+
+	// Describe it by subnet and security group
+	descResult, err := client.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{
+		Filters: []types.Filter{
+			{Name: aws.String("subnet-id"), Values: []string{"subnet-default"}},
+			{Name: aws.String("group-id"), Values: []string{"sg-default"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("DescribeNetworkInterfaces failed: %v", err)
+	}
+	if len(descResult.NetworkInterfaces) != 1 || aws.ToString(descResult.NetworkInterfaces[0].NetworkInterfaceId) != eniId {
+		t.Fatalf("Expected network interface %s, got %v", eniId, descResult.NetworkInterfaces)
+	}
+
+	// Attach it to an instance
+	runResult, err := client.RunInstances(ctx, &ec2.RunInstancesInput{
+		ImageId:      aws.String("ami-12345678"),
+		InstanceType: types.InstanceTypeT2Micro,
+		MinCount:     aws.Int32(1),
+		MaxCount:     aws.Int32(1),
+	})
+	if err != nil {
+		t.Fatalf("RunInstances failed: %v", err)
+	}
+	instanceId := runResult.Instances[0].InstanceId
+
+	attachResult, err := client.AttachNetworkInterface(ctx, &ec2.AttachNetworkInterfaceInput{
+		NetworkInterfaceId: aws.String(eniId),
+		InstanceId:         instanceId,
+		DeviceIndex:        aws.Int32(1),
+	})
+	if err != nil {
+		t.Fatalf("AttachNetworkInterface failed: %v", err)
+	}
+	if !strings.HasPrefix(aws.ToString(attachResult.AttachmentId), "eni-attach-") {
+		t.Errorf("Expected an attachment ID starting with eni-attach-, got %q", aws.ToString(attachResult.AttachmentId))
+	}
+
+	descResult, err = client.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{
+		NetworkInterfaceIds: []string{eniId},
+	})
+	if err != nil {
+		t.Fatalf("DescribeNetworkInterfaces failed: %v", err)
+	}
+	attached := descResult.NetworkInterfaces[0]
+	if attached.Status != types.NetworkInterfaceStatusInUse {
+		t.Errorf("Expected status in-use, got %s", attached.Status)
+	}
+	if attached.Attachment == nil || aws.ToString(attached.Attachment.InstanceId) != aws.ToString(instanceId) {
+		t.Errorf("Expected the network interface to be attached to %s", aws.ToString(instanceId))
+	}
+
+	// An attached network interface can't be deleted
+	if _, err := client.DeleteNetworkInterface(ctx, &ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: aws.String(eniId)}); err == nil {
+		t.Error("Expected an error when deleting an attached network interface")
+	}
+
+	if _, err := client.DetachNetworkInterface(ctx, &ec2.DetachNetworkInterfaceInput{AttachmentId: attachResult.AttachmentId}); err != nil {
+		t.Fatalf("DetachNetworkInterface failed: %v", err)
+	}
+	if _, err := client.DeleteNetworkInterface(ctx, &ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: aws.String(eniId)}); err != nil {
+		t.Fatalf("DeleteNetworkInterface failed: %v", err)
+	}
+
+	// Verify it's deleted (should return error)
+	_, err = client.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{
+		NetworkInterfaceIds: []string{eniId},
+	})
+	if err == nil {
+		t.Error("Expected error when describing deleted network interface")
+	}
+}
+
+func TestIntegration_VpcEndpointLifecycle(t *testing.T) {
+	client, _, cleanup := setupIntegrationTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Create a gateway endpoint for S3 on the default route table
+	createResult, err := client.CreateVpcEndpoint(ctx, &ec2.CreateVpcEndpointInput{
+		VpcId:         aws.String("vpc-default"),
+		ServiceName:   aws.String("com.amazonaws.us-east-1.s3"),
+		RouteTableIds: []string{"rtb-default"},
+	})
+	if err != nil {
+		t.Fatalf("CreateVpcEndpoint failed: %v", err)
+	}
+
+	vpce := createResult.VpcEndpoint
+	vpceId := aws.ToString(vpce.VpcEndpointId)
+	if !strings.HasPrefix(vpceId, "vpce-") {
+		t.Errorf("Expected a VPC endpoint ID starting with vpce-, got %q", vpceId)
+	}
+	if vpce.VpcEndpointType != types.VpcEndpointTypeGateway {
+		t.Errorf("Expected type Gateway, got %s", vpce.VpcEndpointType)
+	}
+	// Unlike the SDK's State constants, AWS reports endpoint states in lower case
+	if vpce.State != "available" {
+		t.Errorf("Expected state available, got %s", vpce.State)
+	}
+	if len(vpce.RouteTableIds) != 1 || vpce.RouteTableIds[0] != "rtb-default" {
+		t.Errorf("Expected route table rtb-default, got %v", vpce.RouteTableIds)
+	}
+	if aws.ToString(vpce.PolicyDocument) == "" {
+		t.Error("Expected the default policy document")
+	}
+
+	// An interface endpoint in the default subnet uses the default security group
+	interfaceResult, err := client.CreateVpcEndpoint(ctx, &ec2.CreateVpcEndpointInput{
+		VpcId:           aws.String("vpc-default"),
+		ServiceName:     aws.String("com.amazonaws.us-east-1.sqs"),
+		VpcEndpointType: types.VpcEndpointTypeInterface,
+		SubnetIds:       []string{"subnet-default"},
+	})
+	if err != nil {
+		t.Fatalf("CreateVpcEndpoint failed: %v", err)
+	}
+	groups := interfaceResult.VpcEndpoint.Groups
+	if len(groups) != 1 || aws.ToString(groups[0].GroupId) != "sg-default" {
+		t.Errorf("Expected the default security group, got %v", groups)
+	}
+
+	// Gateway endpoints can't be placed in subnets
+	if _, err := client.CreateVpcEndpoint(ctx, &ec2.CreateVpcEndpointInput{
+		VpcId:       aws.String("vpc-default"),
+		ServiceName: aws.String("com.amazonaws.us-east-1.dynamodb"),
+		SubnetIds:   []string{"subnet-default"},
+	}); err == nil {
+		t.Error("Expected an error when creating a gateway endpoint in a subnet")
+	}
+
+	// Describe it by VPC and service name
+	descResult, err := client.DescribeVpcEndpoints(ctx, &ec2.DescribeVpcEndpointsInput{
+		Filters: []types.Filter{
+			{Name: aws.String("vpc-id"), Values: []string{"vpc-default"}},
+			{Name: aws.String("service-name"), Values: []string{"com.amazonaws.us-east-1.s3"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("DescribeVpcEndpoints failed: %v", err)
+	}
+	if len(descResult.VpcEndpoints) != 1 || aws.ToString(descResult.VpcEndpoints[0].VpcEndpointId) != vpceId {
+		t.Fatalf("Expected VPC endpoint %s, got %v", vpceId, descResult.VpcEndpoints)
+	}
+
+	// Remove the route table and replace the policy
+	policy := `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*"}]}`
+	if _, err := client.ModifyVpcEndpoint(ctx, &ec2.ModifyVpcEndpointInput{
+		VpcEndpointId:       aws.String(vpceId),
+		RemoveRouteTableIds: []string{"rtb-default"},
+		PolicyDocument:      aws.String(policy),
+	}); err != nil {
+		t.Fatalf("ModifyVpcEndpoint failed: %v", err)
+	}
+
+	descResult, err = client.DescribeVpcEndpoints(ctx, &ec2.DescribeVpcEndpointsInput{
+		VpcEndpointIds: []string{vpceId},
+	})
+	if err != nil {
+		t.Fatalf("DescribeVpcEndpoints failed: %v", err)
+	}
+	modified := descResult.VpcEndpoints[0]
+	if len(modified.RouteTableIds) != 0 {
+		t.Errorf("Expected no route tables, got %v", modified.RouteTableIds)
+	}
+	if aws.ToString(modified.PolicyDocument) != policy {
+		t.Errorf("Expected policy %s, got %s", policy, aws.ToString(modified.PolicyDocument))
+	}
+
+	// Endpoints that don't exist are reported as unsuccessful
+	deleteResult, err := client.DeleteVpcEndpoints(ctx, &ec2.DeleteVpcEndpointsInput{
+		VpcEndpointIds: []string{vpceId, "vpce-missing"},
+	})
+	if err != nil {
+		t.Fatalf("DeleteVpcEndpoints failed: %v", err)
+	}
+	if len(deleteResult.Unsuccessful) != 1 || aws.ToString(deleteResult.Unsuccessful[0].ResourceId) != "vpce-missing" {
+		t.Errorf("Expected vpce-missing to be unsuccessful, got %v", deleteResult.Unsuccessful)
+	}
+
+	// Verify it's deleted (should return error)
+	_, err = client.DescribeVpcEndpoints(ctx, &ec2.DescribeVpcEndpointsInput{
+		VpcEndpointIds: []string{vpceId},
+	})
+	if err == nil {
+		t.Error("Expected error when describing deleted VPC endpoint")
+	}
+}
+
+// TestIntegration_DescribeInternetGatewaysFilters tests resolving an internet gateway from its VPC and tags
+func TestIntegration_DescribeInternetGatewaysFilters(t *testing.T) {
+	client, _, cleanup := setupIntegrationTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	createGateway := func(name string) string {
+		t.Helper()
+		result, err := client.CreateInternetGateway(ctx, &ec2.CreateInternetGatewayInput{
+			TagSpecifications: []types.TagSpecification{{
+				ResourceType: types.ResourceTypeInternetGateway,
+				Tags:         []types.Tag{{Key: aws.String("Name"), Value: aws.String(name)}},
+			}},
+		})
+		if err != nil {
+			t.Fatalf("CreateInternetGateway failed: %v", err)
+		}
+		return aws.ToString(result.InternetGateway.InternetGatewayId)
+	}
+	mainId := createGateway("main")
+	otherId := createGateway("other")
+
+	if _, err := client.AttachInternetGateway(ctx, &ec2.AttachInternetGatewayInput{
+		InternetGatewayId: aws.String(mainId),
+		VpcId:             aws.String("vpc-default"),
+	}); err != nil {
+		t.Fatalf("AttachInternetGateway failed: %v", err)
+	}
+
+	describe := func(name, value string) []types.InternetGateway {
+		t.Helper()
+		result, err := client.DescribeInternetGateways(ctx, &ec2.DescribeInternetGatewaysInput{
+			Filters: []types.Filter{{Name: aws.String(name), Values: []string{value}}},
+		})
+		if err != nil {
+			t.Fatalf("DescribeInternetGateways failed: %v", err)
+		}
+		return result.InternetGateways
+	}
+
+	gateways := describe("attachment.vpc-id", "vpc-default")
+	if len(gateways) != 1 || aws.ToString(gateways[0].InternetGatewayId) != mainId {
+		t.Fatalf("Expected only %s to be attached to vpc-default, got %v", mainId, gateways)
+	}
+	if len(gateways[0].Attachments) != 1 || gateways[0].Attachments[0].State != "available" {
+		t.Errorf("Expected an available attachment, got %v", gateways[0].Attachments)
+	}
+
+	gateways = describe("tag:Name", "other")
+	if len(gateways) != 1 || aws.ToString(gateways[0].InternetGatewayId) != otherId {
+		t.Fatalf("Expected only %s to be tagged other, got %v", otherId, gateways)
+	}
+	if len(gateways[0].Tags) != 1 || aws.ToString(gateways[0].Tags[0].Value) != "other" {
+		t.Errorf("Expected the Name tag, got %v", gateways[0].Tags)
+	}
+
+	if gateways := describe("internet-gateway-id", mainId); len(gateways) != 1 {
+		t.Errorf("Expected 1 internet gateway with ID %s, got %d", mainId, len(gateways))
+	}
+	if gateways := describe("attachment.vpc-id", "vpc-missing"); len(gateways) != 0 {
+		t.Errorf("Expected no internet gateways attached to vpc-missing, got %d", len(gateways))
+	}
+}
+
+func TestIntegration_RunInstances_MaxCount(t *testing.T) {
+	client, _, cleanup := setupIntegrationTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	runResult, err := client.RunInstances(ctx, &ec2.RunInstancesInput{
+		ImageId:      aws.String("ami-12345678"),
+		InstanceType: types.InstanceTypeT2Micro,
+		MinCount:     aws.Int32(1),
+		MaxCount:     aws.Int32(3),
+	})
+	if err != nil {
+		t.Fatalf("RunInstances failed: %v", err)
+	}
+	if len(runResult.Instances) != 3 {
+		t.Fatalf("Expected 3 instances, got %d", len(runResult.Instances))
+	}
+
+	instanceIds := make(map[string]bool)
+	for _, instance := range runResult.Instances {
+		instanceIds[*instance.InstanceId] = true
+	}
+	if len(instanceIds) != 3 {
+		t.Errorf("Expected 3 unique instance IDs, got %v", instanceIds)
+	}
+
+	descResult, err := client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{})
+	if err != nil {
+		t.Fatalf("DescribeInstances failed: %v", err)
+	}
+	described := 0
+	for _, reservation := range descResult.Reservations {
+		for _, instance := range reservation.Instances {
+			if instanceIds[*instance.InstanceId] {
+				described++
+			}
+		}
+	}
+	if described != 3 {
+		t.Errorf("Expected DescribeInstances to return 3 instances, got %d", described)
+	}
+}
+
+func TestIntegration_DescribeInstancesFilters(t *testing.T) {
+	client, _, cleanup := setupIntegrationTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	launch := func(app string, count int32) {
+		t.Helper()
+		_, err := client.RunInstances(ctx, &ec2.RunInstancesInput{
+			ImageId:  aws.String("ami-12345678"),
+			MinCount: aws.Int32(count),
+			MaxCount: aws.Int32(count),
+			TagSpecifications: []types.TagSpecification{{
+				ResourceType: types.ResourceTypeInstance,
+				Tags:         []types.Tag{{Key: aws.String("app"), Value: aws.String(app)}},
+			}},
+		})
+		if err != nil {
+			t.Fatalf("RunInstances failed: %v", err)
+		}
+	}
+	launch("web", 3)
+	launch("api", 1)
+
+	count := func(filters ...types.Filter) int {
+		t.Helper()
+		result, err := client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{Filters: filters})
+		if err != nil {
+			t.Fatalf("DescribeInstances failed: %v", err)
+		}
+		instances := 0
+		for _, reservation := range result.Reservations {
+			instances += len(reservation.Instances)
+		}
+		return instances
+	}
+
+	webFilter := types.Filter{Name: aws.String("tag:app"), Values: []string{"web"}}
+	if got := count(webFilter); got != 3 {
+		t.Errorf("Expected 3 instances tagged app=web, got %d", got)
+	}
+	if got := count(types.Filter{Name: aws.String("tag:app"), Values: []string{"worker"}}); got != 0 {
+		t.Errorf("Expected no instances tagged app=worker, got %d", got)
+	}
+
+	// Instances are pending until their scheduled transition to running
+	if got := count(webFilter, types.Filter{Name: aws.String("instance-state-name"), Values: []string{"pending"}}); got != 3 {
+		t.Errorf("Expected 3 pending instances tagged app=web, got %d", got)
+	}
+	if got := count(webFilter, types.Filter{Name: aws.String("instance-state-name"), Values: []string{"running"}}); got != 0 {
+		t.Errorf("Expected no running instances tagged app=web, got %d", got)
+	}
+}
+
+func TestIntegration_DescribeSecurityGroupRules(t *testing.T) {
+	client, _, cleanup := setupIntegrationTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	createResult, err := client.CreateSecurityGroup(ctx, &ec2.CreateSecurityGroupInput{
+		GroupName:   aws.String("web"),
+		Description: aws.String("Web servers"),
+	})
+	if err != nil {
+		t.Fatalf("CreateSecurityGroup failed: %v", err)
+	}
+	groupId := createResult.GroupId
+
+	authorizeResult, err := client.AuthorizeSecurityGroupIngress(ctx, &ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId: groupId,
+		IpPermissions: []types.IpPermission{{
+			IpProtocol: aws.String("tcp"),
+			FromPort:   aws.Int32(443),
+			ToPort:     aws.Int32(443),
+			IpRanges:   []types.IpRange{{CidrIp: aws.String("0.0.0.0/0")}, {CidrIp: aws.String("10.0.0.0/8")}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("AuthorizeSecurityGroupIngress failed: %v", err)
+	}
+	if len(authorizeResult.SecurityGroupRules) != 2 {
+		t.Fatalf("Expected AuthorizeSecurityGroupIngress to return 2 rules, got %d", len(authorizeResult.SecurityGroupRules))
+	}
+
+	describe := func(input *ec2.DescribeSecurityGroupRulesInput) []types.SecurityGroupRule {
+		t.Helper()
+		result, err := client.DescribeSecurityGroupRules(ctx, input)
+		if err != nil {
+			t.Fatalf("DescribeSecurityGroupRules failed: %v", err)
+		}
+		return result.SecurityGroupRules
+	}
+
+	groupFilter := []types.Filter{{Name: aws.String("group-id"), Values: []string{*groupId}}}
+	rules := describe(&ec2.DescribeSecurityGroupRulesInput{Filters: groupFilter})
+
+	// The two ingress rules and the default egress rule
+	if len(rules) != 3 {
+		t.Fatalf("Expected 3 rules, got %d", len(rules))
+	}
+	ingress := rules[0]
+	if aws.ToBool(ingress.IsEgress) || aws.ToString(ingress.CidrIpv4) != "0.0.0.0/0" || aws.ToInt32(ingress.FromPort) != 443 {
+		t.Errorf("Unexpected first rule: egress %v, CIDR %s, port %d", aws.ToBool(ingress.IsEgress), aws.ToString(ingress.CidrIpv4), aws.ToInt32(ingress.FromPort))
+	}
+	if !aws.ToBool(rules[2].IsEgress) {
+		t.Error("Expected the last rule to be the egress rule")
+	}
+
+	// Rule IDs are stable, so a rule can be looked up by the ID Authorize returned
+	ruleId := aws.ToString(authorizeResult.SecurityGroupRules[1].SecurityGroupRuleId)
+	byId := describe(&ec2.DescribeSecurityGroupRulesInput{SecurityGroupRuleIds: []string{ruleId}})
+	if len(byId) != 1 || aws.ToString(byId[0].CidrIpv4) != "10.0.0.0/8" {
+		t.Fatalf("Expected rule %s to be the 10.0.0.0/8 rule, got %+v", ruleId, byId)
+	}
+
+	_, err = client.RevokeSecurityGroupIngress(ctx, &ec2.RevokeSecurityGroupIngressInput{
+		GroupId: groupId,
+		IpPermissions: []types.IpPermission{{
+			IpProtocol: aws.String("tcp"),
+			FromPort:   aws.Int32(443),
+			ToPort:     aws.Int32(443),
+			IpRanges:   []types.IpRange{{CidrIp: aws.String("0.0.0.0/0")}, {CidrIp: aws.String("10.0.0.0/8")}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("RevokeSecurityGroupIngress failed: %v", err)
+	}
+	if rules := describe(&ec2.DescribeSecurityGroupRulesInput{Filters: groupFilter}); len(rules) != 1 {
+		t.Errorf("Expected only the egress rule after revoking, got %d rules", len(rules))
+	}
+}
@@ -6,28 +6,48 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/robmorgan/infraspec/internal/emulator/core"
+	"github.com/robmorgan/infraspec/internal/emulator/graph"
 	"github.com/robmorgan/infraspec/internal/emulator/helpers"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 )
 
 func (s *EC2Service) createInternetGateway(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
 	igwId := fmt.Sprintf("igw-%s", uuid.New().String()[:8])
 
+	// Parse tags from TagSpecification parameters
+	tags := s.parseTagSpecifications(params, "internet-gateway")
+
 	igw := InternetGateway{
 		InternetGatewayId: &igwId,
-		OwnerId:           helpers.StringPtr("123456789012"),
+		OwnerId:           helpers.StringPtr(emulator.RequestScopeFromContext(ctx).AccountID),
 		Attachments:       []InternetGatewayAttachment{},
+		Tags:              tags,
 	}
 
 	if err := s.state.Set(fmt.Sprintf("ec2:internet-gateways:%s", igwId), &igw); err != nil {
 		return s.errorResponse(500, "InternalFailure", "Failed to store internet gateway"), nil
 	}
 
+	// Also store tags in the separate tag storage for consistency with CreateTags
+	if len(tags) > 0 {
+		s.state.Set(fmt.Sprintf("ec2:tags:%s", igwId), tags)
+	}
+
+	// Register the internet gateway in the relationship graph, so attaching it to a VPC can
+	// block the VPC's deletion
+	s.registerResource("internet-gateway", igwId, map[string]string{})
+
 	return s.createInternetGatewayResponse(igw)
 }
 
 func (s *EC2Service) describeInternetGateways(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
 	igwIds := s.parseInternetGatewayIds(params)
 
+	// Extract filters
+	attachedVpcFilter := s.extractFilterValue(params, "attachment.vpc-id")
+	igwIdFilter := s.extractFilterValue(params, "internet-gateway-id")
+	tagFilters := s.extractTagFilters(params)
+
 	var gateways []InternetGateway
 
 	if len(igwIds) > 0 {
@@ -52,7 +72,34 @@ func (s *EC2Service) describeInternetGateways(ctx context.Context, params map[st
 		}
 	}
 
-	return s.describeInternetGatewaysResponse(gateways)
+	filtered := make([]InternetGateway, 0, len(gateways))
+	for _, igw := range gateways {
+		if igwIdFilter != "" && helpers.StringValue(igw.InternetGatewayId) != igwIdFilter {
+			continue
+		}
+		if attachedVpcFilter != "" && !internetGatewayAttachedTo(igw, attachedVpcFilter) {
+			continue
+		}
+
+		// Merge in tags from separate tag storage
+		s.mergeResourceTags(&igw.Tags, helpers.StringValue(igw.InternetGatewayId))
+		if !matchesTagFilters(igw.Tags, tagFilters) {
+			continue
+		}
+		filtered = append(filtered, igw)
+	}
+
+	return s.describeInternetGatewaysResponse(filtered)
+}
+
+// internetGatewayAttachedTo reports whether the internet gateway has an attachment to the VPC
+func internetGatewayAttachedTo(igw InternetGateway, vpcId string) bool {
+	for _, attachment := range igw.Attachments {
+		if helpers.StringValue(attachment.VpcId) == vpcId {
+			return true
+		}
+	}
+	return false
 }
 
 func (s *EC2Service) attachInternetGateway(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
@@ -90,6 +137,7 @@ func (s *EC2Service) attachInternetGateway(ctx context.Context, params map[strin
 		}
 	}
 
+	detached := igw.Attachments
 	igw.Attachments = append(igw.Attachments, InternetGatewayAttachment{
 		VpcId: &vpcId,
 		State: AttachmentStatus("available"),
@@ -99,6 +147,16 @@ func (s *EC2Service) attachInternetGateway(ctx context.Context, params map[strin
 		return s.errorResponse(500, "InternalFailure", "Failed to update internet gateway"), nil
 	}
 
+	// An attached internet gateway must be detached before its VPC can be deleted
+	if err := s.addRelationship("internet-gateway", igwId, "ec2", "vpc", vpcId, graph.RelAttachedTo); err != nil {
+		if s.isStrictMode() {
+			igw.Attachments = detached
+			s.state.Set(fmt.Sprintf("ec2:internet-gateways:%s", igwId), &igw)
+			return s.errorResponse(500, "InternalFailure", fmt.Sprintf("Failed to create internet-gateway-vpc relationship: %v", err)), nil
+		}
+		logging.Warnf("ec2", "Warning: failed to add internet-gateway-vpc relationship in graph: %v", err)
+	}
+
 	return s.attachInternetGatewayResponse()
 }
 
@@ -150,6 +208,10 @@ func (s *EC2Service) detachInternetGateway(ctx context.Context, params map[strin
 		return s.errorResponse(500, "InternalFailure", "Failed to update internet gateway"), nil
 	}
 
+	if err := s.removeRelationship("internet-gateway", igwId, "ec2", "vpc", vpcId, graph.RelAttachedTo); err != nil {
+		logging.Warnf("ec2", "Warning: failed to remove internet-gateway-vpc relationship from graph: %v", err)
+	}
+
 	return s.detachInternetGatewayResponse()
 }
 
@@ -174,6 +236,10 @@ func (s *EC2Service) deleteInternetGateway(ctx context.Context, params map[strin
 		return s.errorResponse(400, "DependencyViolation", "The internet gateway is still attached to a VPC"), nil
 	}
 
+	if err := s.unregisterResource("internet-gateway", igwId); err != nil {
+		logging.Warnf("ec2", "Warning: failed to unregister internet gateway %s from graph: %v", igwId, err)
+	}
+
 	s.state.Delete(fmt.Sprintf("ec2:internet-gateways:%s", igwId))
 
 	// Clean up state machine entry
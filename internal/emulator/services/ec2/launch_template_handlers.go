@@ -23,7 +23,7 @@ func (s *EC2Service) createLaunchTemplate(ctx context.Context, params map[string
 		LaunchTemplateId:     &templateId,
 		LaunchTemplateName:   &templateName,
 		CreateTime:           helpers.TimePtr(time.Now()),
-		CreatedBy:            helpers.StringPtr("arn:aws:iam::123456789012:root"),
+		CreatedBy:            helpers.StringPtr(fmt.Sprintf("arn:aws:iam::%s:root", emulator.RequestScopeFromContext(ctx).AccountID)),
 		DefaultVersionNumber: &versionNumber,
 		LatestVersionNumber:  &versionNumber,
 	}
@@ -9,16 +9,6 @@ import (
 	"github.com/robmorgan/infraspec/internal/emulator/helpers"
 )
 
-// describeNetworkInterfaces returns network interfaces matching the given filters.
-// For the emulator, we return an empty list since we don't fully simulate ENIs.
-func (s *EC2Service) describeNetworkInterfaces(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
-	// Return an empty list - we don't simulate network interfaces in detail
-	// This is sufficient for Terraform cleanup operations that check for ENIs
-	return s.successResponse("DescribeNetworkInterfaces", NetworkInterfaceSetResponse{
-		NetworkInterfaces: []NetworkInterface{},
-	})
-}
-
 func (s *EC2Service) describeNetworkAcls(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
 	// Extract VPC filter if present (Terraform uses this to find default NACL)
 	vpcFilter := s.extractFilterValue(params, "vpc-id")
@@ -93,7 +83,7 @@ func (s *EC2Service) describeNetworkAcls(ctx context.Context, params map[string]
 			NetworkAclId: helpers.StringPtr(naclId),
 			VpcId:        vpc.VpcId,
 			IsDefault:    helpers.BoolPtr(isDefault),
-			OwnerId:      helpers.StringPtr("123456789012"),
+			OwnerId:      helpers.StringPtr(emulator.RequestScopeFromContext(ctx).AccountID),
 			Entries:      entries,
 			Associations: []NetworkAclAssociation{},
 			Tags:         []Tag{},
@@ -0,0 +1,398 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+	"github.com/robmorgan/infraspec/internal/emulator/graph"
+	"github.com/robmorgan/infraspec/internal/emulator/helpers"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
+)
+
+func (s *EC2Service) createNetworkInterface(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	subnetId, ok := params["SubnetId"].(string)
+	if !ok || subnetId == "" {
+		return s.errorResponse(400, "MissingParameter", "SubnetId is required"), nil
+	}
+
+	var subnet Subnet
+	if err := s.state.Get(fmt.Sprintf("ec2:subnets:%s", subnetId), &subnet); err != nil {
+		return s.errorResponse(400, "InvalidSubnetID.NotFound", fmt.Sprintf("The subnet ID '%s' does not exist", subnetId)), nil
+	}
+	vpcId := helpers.StringValue(subnet.VpcId)
+
+	// Without security groups, the network interface uses the default security group of its VPC
+	groupIds := s.parseSecurityGroupIds(params)
+	if len(groupIds) == 0 {
+		if defaultGroupId := s.defaultSecurityGroupId(vpcId); defaultGroupId != "" {
+			groupIds = []string{defaultGroupId}
+		}
+	}
+	groups := make([]GroupIdentifier, 0, len(groupIds))
+	for _, groupId := range groupIds {
+		var sg SecurityGroup
+		if err := s.state.Get(fmt.Sprintf("ec2:security-groups:%s", groupId), &sg); err != nil {
+			return s.errorResponse(400, "InvalidGroup.NotFound", fmt.Sprintf("The security group '%s' does not exist", groupId)), nil
+		}
+		if helpers.StringValue(sg.VpcId) != vpcId {
+			return s.errorResponse(400, "InvalidParameterValue", fmt.Sprintf("Security group %s and subnet %s belong to different networks.", groupId, subnetId)), nil
+		}
+		groups = append(groups, GroupIdentifier{GroupId: sg.GroupId, GroupName: sg.GroupName})
+	}
+
+	privateIp := getStringParamValue(params, "PrivateIpAddress", "")
+	if privateIp != "" {
+		if !subnetContainsIP(helpers.StringValue(subnet.CidrBlock), privateIp) {
+			return s.errorResponse(400, "InvalidParameterValue", fmt.Sprintf("Address %s does not fall within the subnet's address range", privateIp)), nil
+		}
+	} else {
+		var err error
+		if privateIp, err = s.nextNetworkInterfaceIP(subnet); err != nil {
+			return s.errorResponse(400, "InsufficientFreeAddressesInSubnet", err.Error()), nil
+		}
+	}
+
+	eniId := fmt.Sprintf("eni-%s", uuid.New().String()[:8])
+	tags := s.parseTagSpecifications(params, "network-interface")
+
+	eni := NetworkInterface{
+		NetworkInterfaceId: &eniId,
+		SubnetId:           &subnetId,
+		VpcId:              &vpcId,
+		AvailabilityZone:   subnet.AvailabilityZone,
+		AvailabilityZoneId: subnet.AvailabilityZoneId,
+		Description:        helpers.StringPtr(getStringParamValue(params, "Description", "")),
+		Groups:             groups,
+		InterfaceType:      NetworkInterfaceType(getStringParamValue(params, "InterfaceType", "interface")),
+		MacAddress:         helpers.StringPtr(randomMacAddress()),
+		OwnerId:            helpers.StringPtr(emulator.RequestScopeFromContext(ctx).AccountID),
+		PrivateIpAddress:   &privateIp,
+		PrivateDnsName:     helpers.StringPtr(fmt.Sprintf("ip-%s.ec2.internal", strings.ReplaceAll(privateIp, ".", "-"))),
+		PrivateIpAddresses: []NetworkInterfacePrivateIpAddress{
+			{
+				Primary:          helpers.BoolPtr(true),
+				PrivateIpAddress: &privateIp,
+				PrivateDnsName:   helpers.StringPtr(fmt.Sprintf("ip-%s.ec2.internal", strings.ReplaceAll(privateIp, ".", "-"))),
+			},
+		},
+		RequesterManaged: helpers.BoolPtr(false),
+		SourceDestCheck:  helpers.BoolPtr(true),
+		Status:           NetworkInterfaceStatus("available"),
+		TagSet:           tags,
+	}
+
+	stateKey := fmt.Sprintf("ec2:network-interfaces:%s", eniId)
+	if err := s.state.Set(stateKey, &eni); err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to store network interface"), nil
+	}
+
+	// Also store tags in the separate tag storage for consistency with CreateTags
+	if len(tags) > 0 {
+		s.state.Set(fmt.Sprintf("ec2:tags:%s", eniId), tags)
+	}
+
+	// Register the network interface in the relationship graph, in its subnet and referencing
+	// its security groups
+	s.registerResource("network-interface", eniId, map[string]string{
+		"subnetId": subnetId,
+		"vpcId":    vpcId,
+	})
+	if err := s.addRelationship("network-interface", eniId, "ec2", "subnet", subnetId, graph.RelContains); err != nil {
+		if s.isStrictMode() {
+			s.state.Delete(stateKey)
+			s.unregisterResource("network-interface", eniId)
+			return s.errorResponse(500, "InternalFailure", fmt.Sprintf("Failed to create network-interface-subnet relationship: %v", err)), nil
+		}
+		logging.Warnf("ec2", "Warning: failed to add network-interface-subnet relationship in graph: %v", err)
+	}
+	for _, groupId := range groupIds {
+		if err := s.addRelationship("network-interface", eniId, "ec2", "security-group", groupId, graph.RelReferences); err != nil {
+			logging.Warnf("ec2", "Warning: failed to add network-interface-security-group relationship in graph: %v", err)
+		}
+	}
+
+	return s.createNetworkInterfaceResponse(eni)
+}
+
+func (s *EC2Service) describeNetworkInterfaces(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	eniIds := s.parseIndexedParams(params, "NetworkInterfaceId")
+	groupIds := s.parseFilterValues(params, "group-id")
+	filters := map[string][]string{
+		"network-interface-id":   s.parseFilterValues(params, "network-interface-id"),
+		"subnet-id":              s.parseFilterValues(params, "subnet-id"),
+		"vpc-id":                 s.parseFilterValues(params, "vpc-id"),
+		"status":                 s.parseFilterValues(params, "status"),
+		"description":            s.parseFilterValues(params, "description"),
+		"private-ip-address":     s.parseFilterValues(params, "private-ip-address"),
+		"attachment.instance-id": s.parseFilterValues(params, "attachment.instance-id"),
+		"interface-type":         s.parseFilterValues(params, "interface-type"),
+	}
+
+	var enis []NetworkInterface
+
+	if len(eniIds) > 0 {
+		for _, eniId := range eniIds {
+			var eni NetworkInterface
+			if err := s.state.Get(fmt.Sprintf("ec2:network-interfaces:%s", eniId), &eni); err != nil {
+				return s.errorResponse(400, "InvalidNetworkInterfaceID.NotFound", fmt.Sprintf("The networkInterface ID '%s' does not exist", eniId)), nil
+			}
+			enis = append(enis, eni)
+		}
+	} else {
+		keys, err := s.state.List("ec2:network-interfaces:")
+		if err != nil {
+			return s.errorResponse(500, "InternalFailure", "Failed to list network interfaces"), nil
+		}
+
+		for _, key := range keys {
+			var eni NetworkInterface
+			if err := s.state.Get(key, &eni); err == nil {
+				enis = append(enis, eni)
+			}
+		}
+	}
+
+	matched := make([]NetworkInterface, 0, len(enis))
+	for _, eni := range enis {
+		values := map[string]string{
+			"network-interface-id": helpers.StringValue(eni.NetworkInterfaceId),
+			"subnet-id":            helpers.StringValue(eni.SubnetId),
+			"vpc-id":               helpers.StringValue(eni.VpcId),
+			"status":               string(eni.Status),
+			"description":          helpers.StringValue(eni.Description),
+			"private-ip-address":   helpers.StringValue(eni.PrivateIpAddress),
+			"interface-type":       string(eni.InterfaceType),
+		}
+		if eni.Attachment != nil {
+			values["attachment.instance-id"] = helpers.StringValue(eni.Attachment.InstanceId)
+		}
+		if !matchesAllFilters(filters, values) {
+			continue
+		}
+		if len(groupIds) > 0 && !networkInterfaceInAnyGroup(eni, groupIds) {
+			continue
+		}
+		matched = append(matched, eni)
+	}
+
+	return s.describeNetworkInterfacesResponse(matched)
+}
+
+func (s *EC2Service) attachNetworkInterface(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	eniId, ok := params["NetworkInterfaceId"].(string)
+	if !ok || eniId == "" {
+		return s.errorResponse(400, "MissingParameter", "NetworkInterfaceId is required"), nil
+	}
+
+	instanceId, ok := params["InstanceId"].(string)
+	if !ok || instanceId == "" {
+		return s.errorResponse(400, "MissingParameter", "InstanceId is required"), nil
+	}
+
+	if _, ok := params["DeviceIndex"].(string); !ok {
+		return s.errorResponse(400, "MissingParameter", "DeviceIndex is required"), nil
+	}
+	deviceIndex := int32(getIntParam(params, "DeviceIndex", 0))
+
+	// Acquire per-resource lock for atomic operation
+	resourceKey := "network-interfaces:" + eniId
+	rs := s.stateMachine.GetOrCreateResourceState(resourceKey)
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	var eni NetworkInterface
+	if err := s.state.Get(fmt.Sprintf("ec2:network-interfaces:%s", eniId), &eni); err != nil {
+		return s.errorResponse(400, "InvalidNetworkInterfaceID.NotFound", fmt.Sprintf("The networkInterface ID '%s' does not exist", eniId)), nil
+	}
+
+	if eni.Attachment != nil {
+		return s.errorResponse(400, "InvalidParameterValue", fmt.Sprintf("Interface: [%s] in use.", eniId)), nil
+	}
+
+	var instance Instance
+	if err := s.state.Get(fmt.Sprintf("ec2:instances:%s", instanceId), &instance); err != nil {
+		return s.errorResponse(400, "InvalidInstanceID.NotFound", fmt.Sprintf("The instance ID '%s' does not exist", instanceId)), nil
+	}
+
+	// Network interfaces can't be attached to instances that are terminating
+	if instance.State != nil && (instance.State.Name == InstanceStateName("shutting-down") || instance.State.Name == InstanceStateName("terminated")) {
+		return s.errorResponse(400, "IncorrectInstanceState", fmt.Sprintf("The instance '%s' is not in a valid state for this operation. Current state: %s", instanceId, instance.State.Name)), nil
+	}
+
+	if instance.VpcId != nil && *instance.VpcId != helpers.StringValue(eni.VpcId) {
+		return s.errorResponse(400, "InvalidParameterValue", fmt.Sprintf("Instance '%s' and network interface '%s' are in different VPCs", instanceId, eniId)), nil
+	}
+
+	// Only one network interface can use each device index of an instance
+	keys, err := s.state.List("ec2:network-interfaces:")
+	if err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to list network interfaces"), nil
+	}
+	for _, key := range keys {
+		var other NetworkInterface
+		if err := s.state.Get(key, &other); err != nil || other.Attachment == nil {
+			continue
+		}
+		if helpers.StringValue(other.Attachment.InstanceId) == instanceId && other.Attachment.DeviceIndex != nil && *other.Attachment.DeviceIndex == deviceIndex {
+			return s.errorResponse(400, "InvalidParameterValue", fmt.Sprintf("Instance '%s' already has an interface attached at device index '%d'.", instanceId, deviceIndex)), nil
+		}
+	}
+
+	attachmentId := fmt.Sprintf("eni-attach-%s", uuid.New().String()[:8])
+	eni.Attachment = &NetworkInterfaceAttachment{
+		AttachmentId:        &attachmentId,
+		AttachTime:          helpers.TimePtr(time.Now()),
+		DeleteOnTermination: helpers.BoolPtr(false),
+		DeviceIndex:         &deviceIndex,
+		InstanceId:          &instanceId,
+		InstanceOwnerId:     eni.OwnerId,
+		NetworkCardIndex:    helpers.Int32Ptr(0),
+		Status:              AttachmentStatus("attached"),
+	}
+	eni.Status = NetworkInterfaceStatus("in-use")
+
+	if err := s.state.Set(fmt.Sprintf("ec2:network-interfaces:%s", eniId), &eni); err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to update network interface"), nil
+	}
+
+	return s.attachNetworkInterfaceResponse(attachmentId)
+}
+
+func (s *EC2Service) detachNetworkInterface(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	attachmentId, ok := params["AttachmentId"].(string)
+	if !ok || attachmentId == "" {
+		return s.errorResponse(400, "MissingParameter", "AttachmentId is required"), nil
+	}
+
+	keys, err := s.state.List("ec2:network-interfaces:")
+	if err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to list network interfaces"), nil
+	}
+	for _, key := range keys {
+		var eni NetworkInterface
+		if err := s.state.Get(key, &eni); err != nil || eni.Attachment == nil || helpers.StringValue(eni.Attachment.AttachmentId) != attachmentId {
+			continue
+		}
+
+		eniId := helpers.StringValue(eni.NetworkInterfaceId)
+		resourceKey := "network-interfaces:" + eniId
+		rs := s.stateMachine.GetOrCreateResourceState(resourceKey)
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+
+		eni.Attachment = nil
+		eni.Status = NetworkInterfaceStatus("available")
+		if err := s.state.Set(key, &eni); err != nil {
+			return s.errorResponse(500, "InternalFailure", "Failed to update network interface"), nil
+		}
+		return s.detachNetworkInterfaceResponse()
+	}
+
+	return s.errorResponse(400, "InvalidAttachmentID.NotFound", fmt.Sprintf("The attachment ID '%s' does not exist", attachmentId)), nil
+}
+
+func (s *EC2Service) deleteNetworkInterface(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	eniId, ok := params["NetworkInterfaceId"].(string)
+	if !ok || eniId == "" {
+		return s.errorResponse(400, "MissingParameter", "NetworkInterfaceId is required"), nil
+	}
+
+	// Acquire per-resource lock for atomic operation
+	resourceKey := "network-interfaces:" + eniId
+	rs := s.stateMachine.GetOrCreateResourceState(resourceKey)
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	var eni NetworkInterface
+	if err := s.state.Get(fmt.Sprintf("ec2:network-interfaces:%s", eniId), &eni); err != nil {
+		return s.errorResponse(400, "InvalidNetworkInterfaceID.NotFound", fmt.Sprintf("The networkInterface ID '%s' does not exist", eniId)), nil
+	}
+
+	if eni.Attachment != nil {
+		return s.errorResponse(400, "InvalidNetworkInterface.InUse", fmt.Sprintf("The network interface '%s' is currently in use.", eniId)), nil
+	}
+
+	// Unregister from graph, which removes its subnet and security group relationships
+	if err := s.unregisterResource("network-interface", eniId); err != nil {
+		return s.errorResponse(400, "DependencyViolation", fmt.Sprintf("Cannot delete network interface: %v", err)), nil
+	}
+
+	s.state.Delete(fmt.Sprintf("ec2:network-interfaces:%s", eniId))
+	s.state.Delete(fmt.Sprintf("ec2:tags:%s", eniId))
+	s.stateMachine.RemoveResourceState(resourceKey)
+
+	return s.deleteNetworkInterfaceResponse()
+}
+
+// defaultSecurityGroupId returns the ID of the VPC's default security group, or "" if it has none
+func (s *EC2Service) defaultSecurityGroupId(vpcId string) string {
+	keys, err := s.state.List("ec2:security-groups:")
+	if err != nil {
+		return ""
+	}
+	for _, key := range keys {
+		var sg SecurityGroup
+		if err := s.state.Get(key, &sg); err == nil && helpers.StringValue(sg.GroupName) == "default" && helpers.StringValue(sg.VpcId) == vpcId {
+			return helpers.StringValue(sg.GroupId)
+		}
+	}
+	return ""
+}
+
+// nextNetworkInterfaceIP returns the next private IP address of the subnet for a network
+// interface, skipping the first four addresses AWS reserves and those already in use
+func (s *EC2Service) nextNetworkInterfaceIP(subnet Subnet) (string, error) {
+	prefix, err := netip.ParsePrefix(helpers.StringValue(subnet.CidrBlock))
+	if err != nil {
+		return "", fmt.Errorf("invalid subnet CIDR block %q", helpers.StringValue(subnet.CidrBlock))
+	}
+
+	used := make(map[string]bool)
+	keys, _ := s.state.List("ec2:network-interfaces:")
+	for _, key := range keys {
+		var eni NetworkInterface
+		if err := s.state.Get(key, &eni); err == nil && helpers.StringValue(eni.SubnetId) == helpers.StringValue(subnet.SubnetId) {
+			used[helpers.StringValue(eni.PrivateIpAddress)] = true
+		}
+	}
+
+	addr := prefix.Masked().Addr()
+	for i := 0; i < 4; i++ {
+		addr = addr.Next()
+	}
+	for ; prefix.Contains(addr); addr = addr.Next() {
+		if !used[addr.String()] {
+			return addr.String(), nil
+		}
+	}
+	return "", fmt.Errorf("There are not enough free addresses in subnet '%s' to satisfy the requested number of instances.", helpers.StringValue(subnet.SubnetId))
+}
+
+func subnetContainsIP(cidrBlock, ip string) bool {
+	prefix, err := netip.ParsePrefix(cidrBlock)
+	if err != nil {
+		return false
+	}
+	addr, err := netip.ParseAddr(ip)
+	return err == nil && prefix.Contains(addr)
+}
+
+func networkInterfaceInAnyGroup(eni NetworkInterface, groupIds []string) bool {
+	for _, group := range eni.Groups {
+		if containsString(groupIds, helpers.StringValue(group.GroupId)) {
+			return true
+		}
+	}
+	return false
+}
+
+// randomMacAddress returns a locally administered MAC address, like those of AWS network interfaces
+func randomMacAddress() string {
+	b := uuid.New()
+	return fmt.Sprintf("02:%02x:%02x:%02x:%02x:%02x", b[0], b[1], b[2], b[3], b[4])
+}
@@ -0,0 +1,183 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+	"github.com/robmorgan/infraspec/internal/emulator/helpers"
+)
+
+// emulatedRegions lists the commercial regions reported by DescribeRegions.
+// These are all reported as opt-in-not-required so Terraform's aws_region and
+// aws_regions data sources can resolve them without real AWS calls.
+var emulatedRegions = []string{
+	"us-east-1",
+	"us-east-2",
+	"us-west-1",
+	"us-west-2",
+	"ca-central-1",
+	"eu-west-1",
+	"eu-west-2",
+	"eu-west-3",
+	"eu-central-1",
+	"eu-north-1",
+	"ap-south-1",
+	"ap-northeast-1",
+	"ap-northeast-2",
+	"ap-northeast-3",
+	"ap-southeast-1",
+	"ap-southeast-2",
+	"sa-east-1",
+}
+
+// emulatedZoneSuffixes are the Availability Zone suffixes reported for each region.
+var emulatedZoneSuffixes = []string{"a", "b", "c"}
+
+// regionZoneIds maps region names to the prefix used for Availability Zone IDs (e.g. "use1").
+var regionZoneIds = map[string]string{
+	"us-east-1":      "use1",
+	"us-east-2":      "use2",
+	"us-west-1":      "usw1",
+	"us-west-2":      "usw2",
+	"ca-central-1":   "cac1",
+	"eu-west-1":      "euw1",
+	"eu-west-2":      "euw2",
+	"eu-west-3":      "euw3",
+	"eu-central-1":   "euc1",
+	"eu-north-1":     "eun1",
+	"ap-south-1":     "aps1",
+	"ap-northeast-1": "apne1",
+	"ap-northeast-2": "apne2",
+	"ap-northeast-3": "apne3",
+	"ap-southeast-1": "apse1",
+	"ap-southeast-2": "apse2",
+	"sa-east-1":      "sae1",
+}
+
+func (s *EC2Service) describeRegions(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	regionNames := s.parseIndexedParams(params, "RegionName")
+	nameFilter := s.parseFilterValues(params, "region-name")
+	optInFilter := s.parseFilterValues(params, "opt-in-status")
+
+	for _, name := range regionNames {
+		if _, ok := regionZoneIds[name]; !ok {
+			return s.errorResponse(400, "InvalidParameterValue", fmt.Sprintf("Invalid region: %s", name)), nil
+		}
+	}
+
+	regions := make([]Region, 0, len(emulatedRegions))
+	for _, name := range emulatedRegions {
+		if len(regionNames) > 0 && !containsString(regionNames, name) {
+			continue
+		}
+		if len(nameFilter) > 0 && !containsString(nameFilter, name) {
+			continue
+		}
+		if len(optInFilter) > 0 && !containsString(optInFilter, "opt-in-not-required") {
+			continue
+		}
+
+		regions = append(regions, Region{
+			RegionName:  helpers.StringPtr(name),
+			Endpoint:    helpers.StringPtr(fmt.Sprintf("ec2.%s.amazonaws.com", name)),
+			OptInStatus: helpers.StringPtr("opt-in-not-required"),
+		})
+	}
+
+	return s.describeRegionsResponse(regions)
+}
+
+func (s *EC2Service) describeAvailabilityZones(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	zoneNames := s.parseIndexedParams(params, "ZoneName")
+	zoneIds := s.parseIndexedParams(params, "ZoneId")
+	filters := map[string][]string{
+		"zone-name":     s.parseFilterValues(params, "zone-name"),
+		"zone-id":       s.parseFilterValues(params, "zone-id"),
+		"zone-type":     s.parseFilterValues(params, "zone-type"),
+		"state":         s.parseFilterValues(params, "state"),
+		"region-name":   s.parseFilterValues(params, "region-name"),
+		"opt-in-status": s.parseFilterValues(params, "opt-in-status"),
+	}
+
+	// Availability Zones belong to the region the request was signed for
+	region := emulator.RequestScopeFromContext(ctx).Region
+	zoneIdPrefix, ok := regionZoneIds[region]
+	if !ok {
+		return s.errorResponse(400, "InvalidParameterValue", fmt.Sprintf("Invalid region: %s", region)), nil
+	}
+
+	zones := make([]AvailabilityZone, 0, len(emulatedZoneSuffixes))
+	for i, suffix := range emulatedZoneSuffixes {
+		zoneName := region + suffix
+		zoneId := fmt.Sprintf("%s-az%d", zoneIdPrefix, i+1)
+
+		values := map[string]string{
+			"zone-name":     zoneName,
+			"zone-id":       zoneId,
+			"zone-type":     "availability-zone",
+			"state":         "available",
+			"region-name":   region,
+			"opt-in-status": "opt-in-not-required",
+		}
+
+		if len(zoneNames) > 0 && !containsString(zoneNames, zoneName) {
+			continue
+		}
+		if len(zoneIds) > 0 && !containsString(zoneIds, zoneId) {
+			continue
+		}
+		if !matchesAllFilters(filters, values) {
+			continue
+		}
+
+		zones = append(zones, AvailabilityZone{
+			ZoneName:           helpers.StringPtr(zoneName),
+			ZoneId:             helpers.StringPtr(zoneId),
+			ZoneType:           helpers.StringPtr("availability-zone"),
+			State:              AvailabilityZoneState("available"),
+			RegionName:         helpers.StringPtr(region),
+			GroupName:          helpers.StringPtr(region),
+			NetworkBorderGroup: helpers.StringPtr(region),
+			OptInStatus:        AvailabilityZoneOptInStatus("opt-in-not-required"),
+			Messages:           []AvailabilityZoneMessage{},
+		})
+	}
+
+	return s.describeAvailabilityZonesResponse(zones)
+}
+
+// matchesAllFilters returns true if every non-empty filter has a value matching the
+// corresponding value. Filter values can contain the * and ? wildcards, as in AWS.
+func matchesAllFilters(filters map[string][]string, values map[string]string) bool {
+	for name, accepted := range filters {
+		if len(accepted) > 0 && !matchesAnyFilterValue(accepted, values[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesAnyFilterValue(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if filterWildcardMatch(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterWildcardMatch matches value against a filter value, where * matches any sequence of
+// characters and ? matches any single character
+func filterWildcardMatch(pattern, value string) bool {
+	if !strings.ContainsAny(pattern, "*?") {
+		return pattern == value
+	}
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	matched, _ := regexp.MatchString("^"+expr+"$", value)
+	return matched
+}
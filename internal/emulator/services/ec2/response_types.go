@@ -151,13 +151,15 @@ type DeleteSecurityGroupResponse struct {
 }
 
 type AuthorizeSecurityGroupIngressResponse struct {
-	XMLName xml.Name `xml:"AuthorizeSecurityGroupIngressResponse"`
-	Return  bool     `xml:"return"`
+	XMLName            xml.Name            `xml:"AuthorizeSecurityGroupIngressResponse"`
+	Return             bool                `xml:"return"`
+	SecurityGroupRules []SecurityGroupRule `xml:"securityGroupRuleSet>item,omitempty"`
 }
 
 type AuthorizeSecurityGroupEgressResponse struct {
-	XMLName xml.Name `xml:"AuthorizeSecurityGroupEgressResponse"`
-	Return  bool     `xml:"return"`
+	XMLName            xml.Name            `xml:"AuthorizeSecurityGroupEgressResponse"`
+	Return             bool                `xml:"return"`
+	SecurityGroupRules []SecurityGroupRule `xml:"securityGroupRuleSet>item,omitempty"`
 }
 
 type RevokeSecurityGroupIngressResponse struct {
@@ -170,6 +172,12 @@ type RevokeSecurityGroupEgressResponse struct {
 	Return  bool     `xml:"return"`
 }
 
+// DescribeSecurityGroupRulesResponse wraps security group rules for DescribeSecurityGroupRules response
+type DescribeSecurityGroupRulesResponse struct {
+	XMLName            xml.Name            `xml:"DescribeSecurityGroupRulesResponse"`
+	SecurityGroupRules []SecurityGroupRule `xml:"securityGroupRuleSet>item"`
+}
+
 type CreateTagsResponse struct {
 	XMLName xml.Name `xml:"CreateTagsResponse"`
 	Return  bool     `xml:"return"`
@@ -237,9 +245,56 @@ type DescribeVpcAttributeResponse struct {
 
 // NetworkInterfaceSetResponse wraps network interfaces for DescribeNetworkInterfaces response
 type NetworkInterfaceSetResponse struct {
+	XMLName           xml.Name           `xml:"DescribeNetworkInterfacesResponse"`
 	NetworkInterfaces []NetworkInterface `xml:"networkInterfaceSet>item"`
 }
 
+// CreateNetworkInterfaceResponse wraps the network interface for CreateNetworkInterface response
+type CreateNetworkInterfaceResponse struct {
+	XMLName          xml.Name         `xml:"CreateNetworkInterfaceResponse"`
+	NetworkInterface NetworkInterface `xml:"networkInterface"`
+}
+
+// AttachNetworkInterfaceResponse is the response for AttachNetworkInterface
+type AttachNetworkInterfaceResponse struct {
+	XMLName          xml.Name `xml:"AttachNetworkInterfaceResponse"`
+	AttachmentId     string   `xml:"attachmentId"`
+	NetworkCardIndex int32    `xml:"networkCardIndex"`
+}
+
+type DetachNetworkInterfaceResponse struct {
+	XMLName xml.Name `xml:"DetachNetworkInterfaceResponse"`
+	Return  bool     `xml:"return"`
+}
+
+type DeleteNetworkInterfaceResponse struct {
+	XMLName xml.Name `xml:"DeleteNetworkInterfaceResponse"`
+	Return  bool     `xml:"return"`
+}
+
+// VpcEndpointSetResponse wraps VPC endpoints for DescribeVpcEndpoints response
+type VpcEndpointSetResponse struct {
+	XMLName        xml.Name      `xml:"DescribeVpcEndpointsResponse"`
+	VpcEndpointSet []VpcEndpoint `xml:"vpcEndpointSet>item"`
+}
+
+// CreateVpcEndpointResponse wraps the VPC endpoint for CreateVpcEndpoint response
+type CreateVpcEndpointResponse struct {
+	XMLName     xml.Name    `xml:"CreateVpcEndpointResponse"`
+	VpcEndpoint VpcEndpoint `xml:"vpcEndpoint"`
+}
+
+type ModifyVpcEndpointResponse struct {
+	XMLName xml.Name `xml:"ModifyVpcEndpointResponse"`
+	Return  bool     `xml:"return"`
+}
+
+// DeleteVpcEndpointsResponse lists the VPC endpoints DeleteVpcEndpoints couldn't delete
+type DeleteVpcEndpointsResponse struct {
+	XMLName      xml.Name           `xml:"DeleteVpcEndpointsResponse"`
+	Unsuccessful []UnsuccessfulItem `xml:"unsuccessful>item"`
+}
+
 // NetworkAclSetResponse wraps network ACLs for DescribeNetworkAcls response
 type NetworkAclSetResponse struct {
 	NetworkAcls []NetworkAcl `xml:"networkAclSet>item"`
@@ -255,3 +310,15 @@ type InstanceTypeSetResponse struct {
 	XMLName       xml.Name           `xml:"DescribeInstanceTypesResponse"`
 	InstanceTypes []InstanceTypeInfo `xml:"instanceTypeSet>item"`
 }
+
+// DescribeRegionsResponse wraps regions for DescribeRegions response
+type DescribeRegionsResponse struct {
+	XMLName    xml.Name `xml:"DescribeRegionsResponse"`
+	RegionInfo []Region `xml:"regionInfo>item"`
+}
+
+// DescribeAvailabilityZonesResponse wraps zones for DescribeAvailabilityZones response
+type DescribeAvailabilityZonesResponse struct {
+	XMLName              xml.Name           `xml:"DescribeAvailabilityZonesResponse"`
+	AvailabilityZoneInfo []AvailabilityZone `xml:"availabilityZoneInfo>item"`
+}
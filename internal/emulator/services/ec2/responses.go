@@ -184,12 +184,12 @@ func (s *EC2Service) deleteSecurityGroupResponse() (*emulator.AWSResponse, error
 	return s.successResponse("DeleteSecurityGroup", DeleteSecurityGroupResponse{Return: true})
 }
 
-func (s *EC2Service) authorizeSecurityGroupIngressResponse() (*emulator.AWSResponse, error) {
-	return s.successResponse("AuthorizeSecurityGroupIngress", AuthorizeSecurityGroupIngressResponse{Return: true})
+func (s *EC2Service) authorizeSecurityGroupIngressResponse(rules []SecurityGroupRule) (*emulator.AWSResponse, error) {
+	return s.successResponse("AuthorizeSecurityGroupIngress", AuthorizeSecurityGroupIngressResponse{Return: true, SecurityGroupRules: rules})
 }
 
-func (s *EC2Service) authorizeSecurityGroupEgressResponse() (*emulator.AWSResponse, error) {
-	return s.successResponse("AuthorizeSecurityGroupEgress", AuthorizeSecurityGroupEgressResponse{Return: true})
+func (s *EC2Service) authorizeSecurityGroupEgressResponse(rules []SecurityGroupRule) (*emulator.AWSResponse, error) {
+	return s.successResponse("AuthorizeSecurityGroupEgress", AuthorizeSecurityGroupEgressResponse{Return: true, SecurityGroupRules: rules})
 }
 
 func (s *EC2Service) revokeSecurityGroupIngressResponse() (*emulator.AWSResponse, error) {
@@ -200,6 +200,10 @@ func (s *EC2Service) revokeSecurityGroupEgressResponse() (*emulator.AWSResponse,
 	return s.successResponse("RevokeSecurityGroupEgress", RevokeSecurityGroupEgressResponse{Return: true})
 }
 
+func (s *EC2Service) describeSecurityGroupRulesResponse(rules []SecurityGroupRule) (*emulator.AWSResponse, error) {
+	return s.successResponse("DescribeSecurityGroupRules", DescribeSecurityGroupRulesResponse{SecurityGroupRules: rules})
+}
+
 // ==================== Internet Gateway Responses ====================
 
 func (s *EC2Service) createInternetGatewayResponse(igw InternetGateway) (*emulator.AWSResponse, error) {
@@ -224,6 +228,24 @@ func (s *EC2Service) deleteInternetGatewayResponse() (*emulator.AWSResponse, err
 	return s.successResponse("DeleteInternetGateway", DeleteInternetGatewayResponse{Return: true})
 }
 
+// ==================== VPC Endpoint Responses ====================
+
+func (s *EC2Service) createVpcEndpointResponse(vpce VpcEndpoint) (*emulator.AWSResponse, error) {
+	return s.successResponse("CreateVpcEndpoint", CreateVpcEndpointResponse{VpcEndpoint: vpce})
+}
+
+func (s *EC2Service) describeVpcEndpointsResponse(endpoints []VpcEndpoint) (*emulator.AWSResponse, error) {
+	return s.successResponse("DescribeVpcEndpoints", VpcEndpointSetResponse{VpcEndpointSet: endpoints})
+}
+
+func (s *EC2Service) modifyVpcEndpointResponse() (*emulator.AWSResponse, error) {
+	return s.successResponse("ModifyVpcEndpoint", ModifyVpcEndpointResponse{Return: true})
+}
+
+func (s *EC2Service) deleteVpcEndpointsResponse(unsuccessful []UnsuccessfulItem) (*emulator.AWSResponse, error) {
+	return s.successResponse("DeleteVpcEndpoints", DeleteVpcEndpointsResponse{Unsuccessful: unsuccessful})
+}
+
 // ==================== AMI Responses ====================
 
 func (s *EC2Service) describeImagesResponse(images []Image) (*emulator.AWSResponse, error) {
@@ -255,6 +277,28 @@ func (s *EC2Service) deleteVolumeResponse() (*emulator.AWSResponse, error) {
 	return s.successResponse("DeleteVolume", DeleteVolumeResponse{Return: true})
 }
 
+// ==================== Network Interface Responses ====================
+
+func (s *EC2Service) createNetworkInterfaceResponse(eni NetworkInterface) (*emulator.AWSResponse, error) {
+	return s.successResponse("CreateNetworkInterface", CreateNetworkInterfaceResponse{NetworkInterface: eni})
+}
+
+func (s *EC2Service) describeNetworkInterfacesResponse(enis []NetworkInterface) (*emulator.AWSResponse, error) {
+	return s.successResponse("DescribeNetworkInterfaces", NetworkInterfaceSetResponse{NetworkInterfaces: enis})
+}
+
+func (s *EC2Service) attachNetworkInterfaceResponse(attachmentId string) (*emulator.AWSResponse, error) {
+	return s.successResponse("AttachNetworkInterface", AttachNetworkInterfaceResponse{AttachmentId: attachmentId})
+}
+
+func (s *EC2Service) detachNetworkInterfaceResponse() (*emulator.AWSResponse, error) {
+	return s.successResponse("DetachNetworkInterface", DetachNetworkInterfaceResponse{Return: true})
+}
+
+func (s *EC2Service) deleteNetworkInterfaceResponse() (*emulator.AWSResponse, error) {
+	return s.successResponse("DeleteNetworkInterface", DeleteNetworkInterfaceResponse{Return: true})
+}
+
 // ==================== Key Pair Responses ====================
 
 func (s *EC2Service) createKeyPairResponse(keyPairId, keyName, fingerprint, privateKey string) (*emulator.AWSResponse, error) {
@@ -329,3 +373,19 @@ func (s *EC2Service) describeInstanceCreditSpecificationsResponse(creditSpecs []
 		InstanceCreditSpecifications: creditSpecs,
 	})
 }
+
+func (s *EC2Service) describeInstanceStatusResponse(statuses []InstanceStatus) (*emulator.AWSResponse, error) {
+	return s.successResponse("DescribeInstanceStatus", DescribeInstanceStatusResult{
+		InstanceStatuses: statuses,
+	})
+}
+
+// ==================== Region Responses ====================
+
+func (s *EC2Service) describeRegionsResponse(regions []Region) (*emulator.AWSResponse, error) {
+	return s.successResponse("DescribeRegions", DescribeRegionsResponse{RegionInfo: regions})
+}
+
+func (s *EC2Service) describeAvailabilityZonesResponse(zones []AvailabilityZone) (*emulator.AWSResponse, error) {
+	return s.successResponse("DescribeAvailabilityZones", DescribeAvailabilityZonesResponse{AvailabilityZoneInfo: zones})
+}
@@ -8,7 +8,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/robmorgan/infraspec/internal/emulator/core"
+	"github.com/robmorgan/infraspec/internal/emulator/graph"
 	"github.com/robmorgan/infraspec/internal/emulator/helpers"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 )
 
 func (s *EC2Service) runInstances(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
@@ -98,8 +100,22 @@ func (s *EC2Service) runInstances(ctx context.Context, params map[string]interfa
 			}
 		}
 
-		// Store instance
+		instances = append(instances, instance)
+	}
+
+	// Register every instance before storing any, so a launch that fails in strict mode
+	// leaves nothing behind
+	for i, instance := range instances {
+		if resp := s.registerInstance(*instance.InstanceId, subnetId, vpcId, securityGroupIds); resp != nil {
+			s.rollbackInstances(instances[:i], 0)
+			return resp, nil
+		}
+	}
+
+	for i, instance := range instances {
+		instanceId := *instance.InstanceId
 		if err := s.state.Set(fmt.Sprintf("ec2:instances:%s", instanceId), &instance); err != nil {
+			s.rollbackInstances(instances, i)
 			return s.errorResponse(500, "InternalFailure", "Failed to store instance"), nil
 		}
 
@@ -107,20 +123,58 @@ func (s *EC2Service) runInstances(ctx context.Context, params map[string]interfa
 		if len(instanceTags) > 0 {
 			s.state.Set(fmt.Sprintf("ec2:tags:%s", instanceId), instanceTags)
 		}
+	}
 
-		instances = append(instances, instance)
-
-		// Schedule transition to running after delay
-		s.scheduleInstanceTransition(instanceId, InstanceStateName("running"), 5*time.Second)
+	// Schedule transition to running after delay
+	for _, instance := range instances {
+		s.scheduleInstanceTransition(*instance.InstanceId, InstanceStateName("running"), 5*time.Second)
 	}
 
 	// Store reservation
 	reservation := Reservation{
 		ReservationId: &reservationId,
-		OwnerId:       helpers.StringPtr("123456789012"),
+		OwnerId:       helpers.StringPtr(emulator.RequestScopeFromContext(ctx).AccountID),
 		Instances:     instances,
 	}
 	s.state.Set(fmt.Sprintf("ec2:reservations:%s", reservationId), &reservation)
 
 	return s.runInstancesResponse(reservation)
 }
+
+// rollbackInstances undoes a failed launch: it unregisters the instances from the graph and
+// deletes the state of the first stored of them
+func (s *EC2Service) rollbackInstances(instances []Instance, stored int) {
+	for i, instance := range instances {
+		instanceId := *instance.InstanceId
+		if err := s.unregisterResource("instance", instanceId); err != nil {
+			logging.Warnf("ec2", "Warning: failed to unregister instance %s from graph: %v", instanceId, err)
+		}
+		if i < stored {
+			s.state.Delete(fmt.Sprintf("ec2:instances:%s", instanceId))
+			s.state.Delete(fmt.Sprintf("ec2:tags:%s", instanceId))
+		}
+	}
+}
+
+// registerInstance registers an instance in the relationship graph, in its subnet and
+// referencing its security groups. It returns an error response if the subnet relationship
+// can't be created in strict mode.
+func (s *EC2Service) registerInstance(instanceId, subnetId, vpcId string, securityGroupIds []string) *emulator.AWSResponse {
+	s.registerResource("instance", instanceId, map[string]string{
+		"subnetId": subnetId,
+		"vpcId":    vpcId,
+	})
+	if err := s.addRelationship("instance", instanceId, "ec2", "subnet", subnetId, graph.RelReferences); err != nil {
+		if s.isStrictMode() {
+			s.unregisterResource("instance", instanceId)
+			return s.errorResponse(500, "InternalFailure", fmt.Sprintf("Failed to create instance-subnet relationship: %v", err))
+		}
+		logging.Warnf("ec2", "Warning: failed to add instance-subnet relationship in graph: %v", err)
+	}
+	for _, groupId := range securityGroupIds {
+		if err := s.addRelationship("instance", instanceId, "ec2", "security-group", groupId, graph.RelReferences); err != nil {
+			logging.Warnf("ec2", "Warning: failed to add instance-security-group relationship in graph: %v", err)
+		}
+	}
+	return nil
+}
@@ -4,13 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/url"
 	"strings"
 
 	"github.com/robmorgan/infraspec/internal/emulator/core"
 	"github.com/robmorgan/infraspec/internal/emulator/graph"
 	"github.com/robmorgan/infraspec/internal/emulator/helpers"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 )
 
 // EC2Service implements the EC2 service emulator
@@ -75,6 +75,16 @@ func (s *EC2Service) ServiceName() string {
 	return "ec2"
 }
 
+// Protocol returns the AWS protocol the service speaks
+func (s *EC2Service) Protocol() emulator.ProtocolType {
+	return emulator.ProtocolQuery
+}
+
+// ResponseTypes returns constructors for the CloudMirror-generated response types, keyed by action.
+func (s *EC2Service) ResponseTypes() map[string]func() interface{} {
+	return smithyResponseTypes
+}
+
 // SupportedActions returns the list of AWS API actions this service handles.
 // Used by the router to determine which service handles a given Query Protocol request.
 func (s *EC2Service) SupportedActions() []string {
@@ -85,6 +95,7 @@ func (s *EC2Service) SupportedActions() []string {
 		"DescribeInstanceTypes",
 		"DescribeInstanceAttribute",
 		"DescribeInstanceCreditSpecifications",
+		"DescribeInstanceStatus",
 		"TerminateInstances",
 		"StartInstances",
 		"StopInstances",
@@ -106,12 +117,18 @@ func (s *EC2Service) SupportedActions() []string {
 		"AuthorizeSecurityGroupEgress",
 		"RevokeSecurityGroupIngress",
 		"RevokeSecurityGroupEgress",
+		"DescribeSecurityGroupRules",
 		// Internet Gateway operations
 		"CreateInternetGateway",
 		"DescribeInternetGateways",
 		"AttachInternetGateway",
 		"DetachInternetGateway",
 		"DeleteInternetGateway",
+		// VPC Endpoint operations
+		"CreateVpcEndpoint",
+		"DescribeVpcEndpoints",
+		"ModifyVpcEndpoint",
+		"DeleteVpcEndpoints",
 		// AMI operations
 		"DescribeImages",
 		// Volume operations
@@ -134,14 +151,27 @@ func (s *EC2Service) SupportedActions() []string {
 		"DescribeTags",
 		"DeleteTags",
 		// Network Interface operations
+		"CreateNetworkInterface",
 		"DescribeNetworkInterfaces",
+		"AttachNetworkInterface",
+		"DetachNetworkInterface",
+		"DeleteNetworkInterface",
 		// Network ACL operations
 		"DescribeNetworkAcls",
 		// Route Table operations
 		"DescribeRouteTables",
+		// Region operations
+		"DescribeRegions",
+		"DescribeAvailabilityZones",
 	}
 }
 
+// InitializeDefaults implements emulator.DefaultsInitializer, re-creating the default VPC,
+// subnet and security group after the state has been reset
+func (s *EC2Service) InitializeDefaults() {
+	s.initializeDefaults()
+}
+
 // initializeDefaults sets up default VPC, subnet, security group, and AMIs
 func (s *EC2Service) initializeDefaults() {
 	// Create default VPC
@@ -185,7 +215,7 @@ func (s *EC2Service) initializeDefaults() {
 		"default": "true",
 	})
 	if err := s.addRelationship("subnet", defaultSubnetId, "ec2", "vpc", defaultVpcId, graph.RelContains); err != nil {
-		log.Printf("Warning: failed to add default subnet-vpc relationship in graph: %v", err)
+		logging.Warnf("ec2", "Warning: failed to add default subnet-vpc relationship in graph: %v", err)
 	}
 
 	// Create default security group
@@ -221,7 +251,7 @@ func (s *EC2Service) initializeDefaults() {
 		"default": "true",
 	})
 	if err := s.addRelationship("security-group", defaultSgId, "ec2", "vpc", defaultVpcId, graph.RelContains); err != nil {
-		log.Printf("Warning: failed to add default security-group-vpc relationship in graph: %v", err)
+		logging.Warnf("ec2", "Warning: failed to add default security-group-vpc relationship in graph: %v", err)
 	}
 
 	// Create default network ACL for default VPC
@@ -272,7 +302,7 @@ func (s *EC2Service) initializeDefaults() {
 		"default": "true",
 	})
 	if err := s.addRelationship("network-acl", defaultNaclId, "ec2", "vpc", defaultVpcId, graph.RelContains); err != nil {
-		log.Printf("Warning: failed to add default network-acl-vpc relationship in graph: %v", err)
+		logging.Warnf("ec2", "Warning: failed to add default network-acl-vpc relationship in graph: %v", err)
 	}
 
 	// Create default route table for default VPC
@@ -310,20 +340,28 @@ func (s *EC2Service) initializeDefaults() {
 		"default": "true",
 	})
 	if err := s.addRelationship("route-table", defaultRtbId, "ec2", "vpc", defaultVpcId, graph.RelContains); err != nil {
-		log.Printf("Warning: failed to add default route-table-vpc relationship in graph: %v", err)
+		logging.Warnf("ec2", "Warning: failed to add default route-table-vpc relationship in graph: %v", err)
 	}
 
-	// Pre-populate common AMIs
+	// Pre-populate common AMIs, owned by Amazon and Canonical, with distinct creation dates so
+	// lookups like Terraform's aws_ami data source with most_recent can pick the newest
 	amis := []struct {
-		id          string
-		name        string
-		description string
-		arch        ArchitectureValues
+		id           string
+		name         string
+		description  string
+		arch         ArchitectureValues
+		ownerId      string
+		ownerAlias   string
+		creationDate string
 	}{
-		{"ami-0c55b159cbfafe1f0", "amzn2-ami-hvm-2.0.20210721.2-x86_64-gp2", "Amazon Linux 2 AMI", ArchitectureValues("x86_64")},
-		{"ami-0c94855ba95c71c99", "amzn2-ami-hvm-2.0.20210701.0-x86_64-gp2", "Amazon Linux 2 AMI", ArchitectureValues("x86_64")},
-		{"ami-0885b1f6bd170450c", "ubuntu/images/hvm-ssd/ubuntu-focal-20.04-amd64-server", "Ubuntu 20.04 LTS", ArchitectureValues("x86_64")},
-		{"ami-0dba2cb6798deb6d8", "ubuntu/images/hvm-ssd/ubuntu-bionic-18.04-amd64-server", "Ubuntu 18.04 LTS", ArchitectureValues("x86_64")},
+		{"ami-0c55b159cbfafe1f0", "amzn2-ami-hvm-2.0.20210721.2-x86_64-gp2", "Amazon Linux 2 AMI", ArchitectureValues("x86_64"), amazonOwnerId, "amazon", "2021-07-22T21:37:44.000Z"},
+		{"ami-0c94855ba95c71c99", "amzn2-ami-hvm-2.0.20210701.0-x86_64-gp2", "Amazon Linux 2 AMI", ArchitectureValues("x86_64"), amazonOwnerId, "amazon", "2021-07-02T18:51:17.000Z"},
+		{"ami-0b72821e2f351e396", "al2023-ami-2023.5.20240722.0-kernel-6.1-x86_64", "Amazon Linux 2023 AMI", ArchitectureValues("x86_64"), amazonOwnerId, "amazon", "2024-07-19T20:13:25.000Z"},
+		{"ami-0c9e5f1d1e5c8a0b2", "al2023-ami-2023.5.20240722.0-kernel-6.1-arm64", "Amazon Linux 2023 AMI", ArchitectureValues("arm64"), amazonOwnerId, "amazon", "2024-07-19T20:13:29.000Z"},
+		{"ami-0885b1f6bd170450c", "ubuntu/images/hvm-ssd/ubuntu-focal-20.04-amd64-server", "Ubuntu 20.04 LTS", ArchitectureValues("x86_64"), canonicalOwnerId, "", "2021-07-21T14:24:02.000Z"},
+		{"ami-0dba2cb6798deb6d8", "ubuntu/images/hvm-ssd/ubuntu-bionic-18.04-amd64-server", "Ubuntu 18.04 LTS", ArchitectureValues("x86_64"), canonicalOwnerId, "", "2021-07-14T09:52:18.000Z"},
+		{"ami-04a81a99f5ec58529", "ubuntu/images/hvm-ssd/ubuntu-jammy-22.04-amd64-server-20240701", "Canonical, Ubuntu, 22.04 LTS, amd64 jammy image build on 2024-07-01", ArchitectureValues("x86_64"), canonicalOwnerId, "", "2024-07-01T22:23:29.000Z"},
+		{"ami-0a7a4e87939439934", "ubuntu/images/hvm-ssd/ubuntu-jammy-22.04-amd64-server-20240207.1", "Canonical, Ubuntu, 22.04 LTS, amd64 jammy image build on 2024-02-07", ArchitectureValues("x86_64"), canonicalOwnerId, "", "2024-02-07T20:48:04.000Z"},
 	}
 
 	for _, ami := range amis {
@@ -336,9 +374,13 @@ func (s *EC2Service) initializeDefaults() {
 			State:              ImageState("available"),
 			RootDeviceType:     DeviceType("ebs"),
 			RootDeviceName:     helpers.StringPtr("/dev/xvda"),
-			OwnerId:            helpers.StringPtr("amazon"),
+			OwnerId:            helpers.StringPtr(ami.ownerId),
 			Public:             helpers.BoolPtr(true),
 			VirtualizationType: VirtualizationType("hvm"),
+			CreationDate:       helpers.StringPtr(ami.creationDate),
+		}
+		if ami.ownerAlias != "" {
+			image.ImageOwnerAlias = helpers.StringPtr(ami.ownerAlias)
 		}
 		s.state.Set(fmt.Sprintf("ec2:images:%s", ami.id), &image)
 	}
@@ -376,6 +418,8 @@ func (s *EC2Service) HandleRequest(ctx context.Context, req *emulator.AWSRequest
 		return s.describeInstanceAttribute(ctx, params)
 	case "DescribeInstanceCreditSpecifications":
 		return s.describeInstanceCreditSpecifications(ctx, params)
+	case "DescribeInstanceStatus":
+		return s.describeInstanceStatus(ctx, params)
 	case "TerminateInstances":
 		return s.terminateInstances(ctx, params)
 	case "StartInstances":
@@ -416,6 +460,8 @@ func (s *EC2Service) HandleRequest(ctx context.Context, req *emulator.AWSRequest
 		return s.authorizeSecurityGroupEgress(ctx, params)
 	case "RevokeSecurityGroupIngress":
 		return s.revokeSecurityGroupIngress(ctx, params)
+	case "DescribeSecurityGroupRules":
+		return s.describeSecurityGroupRules(ctx, params)
 	case "RevokeSecurityGroupEgress":
 		return s.revokeSecurityGroupEgress(ctx, params)
 
@@ -431,6 +477,16 @@ func (s *EC2Service) HandleRequest(ctx context.Context, req *emulator.AWSRequest
 	case "DeleteInternetGateway":
 		return s.deleteInternetGateway(ctx, params)
 
+	// VPC Endpoint operations
+	case "CreateVpcEndpoint":
+		return s.createVpcEndpoint(ctx, params)
+	case "DescribeVpcEndpoints":
+		return s.describeVpcEndpoints(ctx, params)
+	case "ModifyVpcEndpoint":
+		return s.modifyVpcEndpoint(ctx, params)
+	case "DeleteVpcEndpoints":
+		return s.deleteVpcEndpoints(ctx, params)
+
 	// AMI operations
 	case "DescribeImages":
 		return s.describeImages(ctx, params)
@@ -474,8 +530,16 @@ func (s *EC2Service) HandleRequest(ctx context.Context, req *emulator.AWSRequest
 		return s.deleteTags(ctx, params)
 
 	// Network Interface operations
+	case "CreateNetworkInterface":
+		return s.createNetworkInterface(ctx, params)
 	case "DescribeNetworkInterfaces":
 		return s.describeNetworkInterfaces(ctx, params)
+	case "AttachNetworkInterface":
+		return s.attachNetworkInterface(ctx, params)
+	case "DetachNetworkInterface":
+		return s.detachNetworkInterface(ctx, params)
+	case "DeleteNetworkInterface":
+		return s.deleteNetworkInterface(ctx, params)
 
 	// Network ACL operations
 	case "DescribeNetworkAcls":
@@ -485,6 +549,12 @@ func (s *EC2Service) HandleRequest(ctx context.Context, req *emulator.AWSRequest
 	case "DescribeRouteTables":
 		return s.describeRouteTables(ctx, params)
 
+	// Region operations
+	case "DescribeRegions":
+		return s.describeRegions(ctx, params)
+	case "DescribeAvailabilityZones":
+		return s.describeAvailabilityZones(ctx, params)
+
 	default:
 		return s.errorResponse(400, "InvalidAction", fmt.Sprintf("Unknown action: %s", action)), nil
 	}
@@ -544,3 +614,9 @@ func (s *EC2Service) parseFormData(body string) (map[string]interface{}, error)
 
 	return params, nil
 }
+
+var (
+	_ emulator.Service              = (*EC2Service)(nil)
+	_ emulator.ActionProvider       = (*EC2Service)(nil)
+	_ emulator.ResponseTypeProvider = (*EC2Service)(nil)
+)
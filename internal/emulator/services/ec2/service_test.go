@@ -2,11 +2,13 @@ package ec2
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/robmorgan/infraspec/internal/emulator/core"
 	"github.com/robmorgan/infraspec/internal/emulator/graph"
+	"github.com/robmorgan/infraspec/internal/emulator/helpers"
 	testhelpers "github.com/robmorgan/infraspec/internal/emulator/testing"
 )
 
@@ -143,6 +145,53 @@ func TestTerminateInstances_ResponseFormat(t *testing.T) {
 	testhelpers.AssertContentType(t, resp, "text/xml")
 }
 
+func TestDescribeInstanceStatus_OnlyRunningUnlessIncludeAll(t *testing.T) {
+	state := emulator.NewMemoryStateManager()
+	validator := emulator.NewSchemaValidator()
+	service := NewEC2Service(state, validator)
+
+	for id, stateName := range map[string]string{"i-running": "running", "i-stopped": "stopped"} {
+		instanceId := id
+		state.Set("ec2:instances:"+instanceId, &Instance{
+			InstanceId: &instanceId,
+			State:      &InstanceState{Name: InstanceStateName(stateName)},
+		})
+	}
+
+	describe := func(body string) string {
+		t.Helper()
+		resp, err := service.HandleRequest(context.Background(), &emulator.AWSRequest{
+			Method: "POST",
+			Headers: map[string]string{
+				"Content-Type": "application/x-www-form-urlencoded",
+			},
+			Body:   []byte(body),
+			Action: "DescribeInstanceStatus",
+		})
+		if err != nil {
+			t.Fatalf("HandleRequest failed: %v", err)
+		}
+		testhelpers.AssertResponseStatus(t, resp, 200)
+		return string(resp.Body)
+	}
+
+	body := describe("Action=DescribeInstanceStatus")
+	if !strings.Contains(body, "<instanceId>i-running</instanceId>") || !strings.Contains(body, "<status>ok</status>") {
+		t.Errorf("Expected a passing status for the running instance, got: %s", body)
+	}
+	if strings.Contains(body, "i-stopped") {
+		t.Errorf("Expected the stopped instance to be omitted, got: %s", body)
+	}
+
+	body = describe("Action=DescribeInstanceStatus&InstanceId.1=i-stopped&IncludeAllInstances=true")
+	if !strings.Contains(body, "<instanceId>i-stopped</instanceId>") || !strings.Contains(body, "<name>stopped</name>") {
+		t.Errorf("Expected the stopped instance with its state, got: %s", body)
+	}
+	if strings.Contains(body, "i-running") {
+		t.Errorf("Expected only the requested instance, got: %s", body)
+	}
+}
+
 func TestCreateVpc_ResponseFormat(t *testing.T) {
 	state := emulator.NewMemoryStateManager()
 	validator := emulator.NewSchemaValidator()
@@ -1009,3 +1058,427 @@ func TestStrictMode_SubnetCreation_RollbackOnRelationshipFailure(t *testing.T) {
 		t.Errorf("VPC dependents should include subnet %s, got: %v", subnetId, dependents)
 	}
 }
+
+func TestDescribeAvailabilityZones_UsesRequestRegion(t *testing.T) {
+	state := emulator.NewMemoryStateManager()
+	validator := emulator.NewSchemaValidator()
+	service := NewEC2Service(state, validator)
+
+	req := &emulator.AWSRequest{
+		Method: "POST",
+		Headers: map[string]string{
+			"Content-Type": "application/x-www-form-urlencoded",
+		},
+		Body:   []byte("Action=DescribeAvailabilityZones"),
+		Action: "DescribeAvailabilityZones",
+	}
+
+	ctx := emulator.WithRequestScope(context.Background(), emulator.RequestScope{AccountID: emulator.DefaultAccountID, Region: "eu-west-1"})
+	resp, err := service.HandleRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("HandleRequest failed: %v", err)
+	}
+	testhelpers.AssertResponseStatus(t, resp, 200)
+
+	body := string(resp.Body)
+	for _, want := range []string{
+		"<zoneName>eu-west-1a</zoneName>",
+		"<zoneId>euw1-az3</zoneId>",
+		"<regionName>eu-west-1</regionName>",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Response should contain %s", want)
+		}
+	}
+	if strings.Contains(body, "us-east-1") {
+		t.Error("Response should not contain us-east-1 zones")
+	}
+
+	// Regions the emulator doesn't know about are rejected
+	ctx = emulator.WithRequestScope(context.Background(), emulator.RequestScope{AccountID: emulator.DefaultAccountID, Region: "mars-north-1"})
+	resp, err = service.HandleRequest(ctx, req)
+	if err != nil {
+		t.Fatalf("HandleRequest failed: %v", err)
+	}
+	testhelpers.AssertResponseStatus(t, resp, 400)
+	if !strings.Contains(string(resp.Body), "InvalidParameterValue") {
+		t.Error("Response should contain InvalidParameterValue")
+	}
+}
+
+func TestDeleteSubnet_WithNetworkInterface_BlockedByGraph(t *testing.T) {
+	state := emulator.NewMemoryStateManager()
+	validator := emulator.NewSchemaValidator()
+
+	// Create service WITH graph support
+	rm := createTestResourceManager(state)
+	service := NewEC2ServiceWithGraph(state, validator, rm)
+
+	// Create a subnet in the default VPC
+	createSubnetReq := &emulator.AWSRequest{
+		Method: "POST",
+		Headers: map[string]string{
+			"Content-Type": "application/x-www-form-urlencoded",
+		},
+		Body:   []byte("Action=CreateSubnet&VpcId=vpc-default&CidrBlock=172.31.96.0/20"),
+		Action: "CreateSubnet",
+	}
+	createSubnetResp, err := service.HandleRequest(context.Background(), createSubnetReq)
+	if err != nil {
+		t.Fatalf("CreateSubnet failed: %v", err)
+	}
+	testhelpers.AssertResponseStatus(t, createSubnetResp, 200)
+
+	bodyStr := string(createSubnetResp.Body)
+	start := strings.Index(bodyStr, "<subnetId>") + len("<subnetId>")
+	end := strings.Index(bodyStr[start:], "</subnetId>")
+	if start < len("<subnetId>") || end < 0 {
+		t.Fatalf("Could not extract subnet ID from response: %s", bodyStr)
+	}
+	subnetId := bodyStr[start : start+end]
+
+	// Create a network interface in the subnet
+	createEniReq := &emulator.AWSRequest{
+		Method: "POST",
+		Headers: map[string]string{
+			"Content-Type": "application/x-www-form-urlencoded",
+		},
+		Body:   []byte("Action=CreateNetworkInterface&SubnetId=" + subnetId + "&SecurityGroupId.1=sg-default"),
+		Action: "CreateNetworkInterface",
+	}
+	createEniResp, err := service.HandleRequest(context.Background(), createEniReq)
+	if err != nil {
+		t.Fatalf("CreateNetworkInterface failed: %v", err)
+	}
+	testhelpers.AssertResponseStatus(t, createEniResp, 200)
+
+	// The first usable address of the subnet, after the four AWS reserves
+	if !strings.Contains(string(createEniResp.Body), "<privateIpAddress>172.31.96.4</privateIpAddress>") {
+		t.Errorf("Expected private IP 172.31.96.4, got: %s", string(createEniResp.Body))
+	}
+
+	// Try to delete the subnet - should be blocked because the network interface exists
+	deleteSubnetReq := &emulator.AWSRequest{
+		Method: "POST",
+		Headers: map[string]string{
+			"Content-Type": "application/x-www-form-urlencoded",
+		},
+		Body:   []byte("Action=DeleteSubnet&SubnetId=" + subnetId),
+		Action: "DeleteSubnet",
+	}
+	deleteSubnetResp, err := service.HandleRequest(context.Background(), deleteSubnetReq)
+	if err != nil {
+		t.Fatalf("DeleteSubnet failed: %v", err)
+	}
+
+	testhelpers.AssertResponseStatus(t, deleteSubnetResp, 400)
+	testhelpers.AssertErrorResponse(t, deleteSubnetResp, "DependencyViolation", emulator.ProtocolQuery)
+}
+
+func TestDeleteVpc_WithVpcEndpoint_BlockedByGraph(t *testing.T) {
+	state := emulator.NewMemoryStateManager()
+	validator := emulator.NewSchemaValidator()
+
+	// Create service WITH graph support
+	rm := createTestResourceManager(state)
+	service := NewEC2ServiceWithGraph(state, validator, rm)
+
+	// Create a VPC
+	createVpcReq := &emulator.AWSRequest{
+		Method: "POST",
+		Headers: map[string]string{
+			"Content-Type": "application/x-www-form-urlencoded",
+		},
+		Body:   []byte("Action=CreateVpc&CidrBlock=10.0.0.0/16"),
+		Action: "CreateVpc",
+	}
+	createVpcResp, err := service.HandleRequest(context.Background(), createVpcReq)
+	if err != nil {
+		t.Fatalf("CreateVpc failed: %v", err)
+	}
+	testhelpers.AssertResponseStatus(t, createVpcResp, 200)
+
+	bodyStr := string(createVpcResp.Body)
+	start := strings.Index(bodyStr, "<vpcId>") + len("<vpcId>")
+	end := strings.Index(bodyStr[start:], "</vpcId>")
+	if start < len("<vpcId>") || end < 0 {
+		t.Fatalf("Could not extract VPC ID from response: %s", bodyStr)
+	}
+	vpcId := bodyStr[start : start+end]
+
+	// Create a gateway endpoint in the VPC
+	createVpceReq := &emulator.AWSRequest{
+		Method: "POST",
+		Headers: map[string]string{
+			"Content-Type": "application/x-www-form-urlencoded",
+		},
+		Body:   []byte("Action=CreateVpcEndpoint&VpcId=" + vpcId + "&ServiceName=com.amazonaws.us-east-1.dynamodb"),
+		Action: "CreateVpcEndpoint",
+	}
+	createVpceResp, err := service.HandleRequest(context.Background(), createVpceReq)
+	if err != nil {
+		t.Fatalf("CreateVpcEndpoint failed: %v", err)
+	}
+	testhelpers.AssertResponseStatus(t, createVpceResp, 200)
+
+	bodyStr = string(createVpceResp.Body)
+	start = strings.Index(bodyStr, "<vpcEndpointId>") + len("<vpcEndpointId>")
+	end = strings.Index(bodyStr[start:], "</vpcEndpointId>")
+	if start < len("<vpcEndpointId>") || end < 0 {
+		t.Fatalf("Could not extract VPC endpoint ID from response: %s", bodyStr)
+	}
+	vpceId := bodyStr[start : start+end]
+
+	// Try to delete the VPC - should be blocked because the endpoint exists
+	deleteVpcReq := &emulator.AWSRequest{
+		Method: "POST",
+		Headers: map[string]string{
+			"Content-Type": "application/x-www-form-urlencoded",
+		},
+		Body:   []byte("Action=DeleteVpc&VpcId=" + vpcId),
+		Action: "DeleteVpc",
+	}
+	deleteVpcResp, err := service.HandleRequest(context.Background(), deleteVpcReq)
+	if err != nil {
+		t.Fatalf("DeleteVpc failed: %v", err)
+	}
+	testhelpers.AssertResponseStatus(t, deleteVpcResp, 400)
+	testhelpers.AssertErrorResponse(t, deleteVpcResp, "DependencyViolation", emulator.ProtocolQuery)
+
+	// Once the endpoint is deleted, so can the VPC be
+	deleteVpceReq := &emulator.AWSRequest{
+		Method: "POST",
+		Headers: map[string]string{
+			"Content-Type": "application/x-www-form-urlencoded",
+		},
+		Body:   []byte("Action=DeleteVpcEndpoints&VpcEndpointId.1=" + vpceId),
+		Action: "DeleteVpcEndpoints",
+	}
+	deleteVpceResp, err := service.HandleRequest(context.Background(), deleteVpceReq)
+	if err != nil {
+		t.Fatalf("DeleteVpcEndpoints failed: %v", err)
+	}
+	testhelpers.AssertResponseStatus(t, deleteVpceResp, 200)
+
+	deleteVpcResp, err = service.HandleRequest(context.Background(), deleteVpcReq)
+	if err != nil {
+		t.Fatalf("DeleteVpc failed: %v", err)
+	}
+	testhelpers.AssertResponseStatus(t, deleteVpcResp, 200)
+}
+
+func TestDeleteVpc_WithAttachedInternetGateway_BlockedByGraph(t *testing.T) {
+	state := emulator.NewMemoryStateManager()
+	validator := emulator.NewSchemaValidator()
+
+	// Create service WITH graph support
+	rm := createTestResourceManager(state)
+	service := NewEC2ServiceWithGraph(state, validator, rm)
+
+	request := func(action, params string) *emulator.AWSResponse {
+		t.Helper()
+		resp, err := service.HandleRequest(context.Background(), &emulator.AWSRequest{
+			Method: "POST",
+			Headers: map[string]string{
+				"Content-Type": "application/x-www-form-urlencoded",
+			},
+			Body:   []byte("Action=" + action + params),
+			Action: action,
+		})
+		if err != nil {
+			t.Fatalf("%s failed: %v", action, err)
+		}
+		return resp
+	}
+	extract := func(resp *emulator.AWSResponse, element string) string {
+		t.Helper()
+		bodyStr := string(resp.Body)
+		start := strings.Index(bodyStr, "<"+element+">") + len(element) + 2
+		end := strings.Index(bodyStr[start:], "</"+element+">")
+		if start < len(element)+2 || end < 0 {
+			t.Fatalf("Could not extract %s from response: %s", element, bodyStr)
+		}
+		return bodyStr[start : start+end]
+	}
+
+	vpcId := extract(request("CreateVpc", "&CidrBlock=10.0.0.0/16"), "vpcId")
+	igwId := extract(request("CreateInternetGateway", ""), "internetGatewayId")
+
+	// Created and attached separately, like aws_internet_gateway_attachment
+	testhelpers.AssertResponseStatus(t, request("AttachInternetGateway", "&InternetGatewayId="+igwId+"&VpcId="+vpcId), 200)
+
+	resp := request("DeleteVpc", "&VpcId="+vpcId)
+	testhelpers.AssertResponseStatus(t, resp, 400)
+	testhelpers.AssertErrorResponse(t, resp, "DependencyViolation", emulator.ProtocolQuery)
+
+	// The attached gateway can't be deleted either
+	resp = request("DeleteInternetGateway", "&InternetGatewayId="+igwId)
+	testhelpers.AssertErrorResponse(t, resp, "DependencyViolation", emulator.ProtocolQuery)
+
+	// Once the gateway is detached, the VPC and the gateway can be deleted
+	testhelpers.AssertResponseStatus(t, request("DetachInternetGateway", "&InternetGatewayId="+igwId+"&VpcId="+vpcId), 200)
+	testhelpers.AssertResponseStatus(t, request("DeleteVpc", "&VpcId="+vpcId), 200)
+	testhelpers.AssertResponseStatus(t, request("DeleteInternetGateway", "&InternetGatewayId="+igwId), 200)
+
+	if rm.HasResource(graph.ResourceID{Service: "ec2", Type: "internet-gateway", ID: igwId}) {
+		t.Errorf("Expected internet gateway %s to be removed from the graph", igwId)
+	}
+}
+
+func TestDeleteSubnet_WithInstances_BlockedByGraph(t *testing.T) {
+	state := emulator.NewMemoryStateManager()
+	validator := emulator.NewSchemaValidator()
+
+	// Create service WITH graph support
+	rm := createTestResourceManager(state)
+	service := NewEC2ServiceWithGraph(state, validator, rm)
+
+	request := func(action, params string) *emulator.AWSResponse {
+		t.Helper()
+		resp, err := service.HandleRequest(context.Background(), &emulator.AWSRequest{
+			Method: "POST",
+			Headers: map[string]string{
+				"Content-Type": "application/x-www-form-urlencoded",
+			},
+			Body:   []byte("Action=" + action + params),
+			Action: action,
+		})
+		if err != nil {
+			t.Fatalf("%s failed: %v", action, err)
+		}
+		return resp
+	}
+
+	createSubnetResp := request("CreateSubnet", "&VpcId=vpc-default&CidrBlock=172.31.96.0/20")
+	testhelpers.AssertResponseStatus(t, createSubnetResp, 200)
+	bodyStr := string(createSubnetResp.Body)
+	start := strings.Index(bodyStr, "<subnetId>") + len("<subnetId>")
+	end := strings.Index(bodyStr[start:], "</subnetId>")
+	if start < len("<subnetId>") || end < 0 {
+		t.Fatalf("Could not extract subnet ID from response: %s", bodyStr)
+	}
+	subnetId := bodyStr[start : start+end]
+
+	runResp := request("RunInstances", "&ImageId=ami-12345678&MinCount=1&MaxCount=3&SubnetId="+subnetId)
+	testhelpers.AssertResponseStatus(t, runResp, 200)
+
+	// Every instance is registered in the graph
+	var instanceIds []string
+	for _, part := range strings.Split(string(runResp.Body), "<instanceId>")[1:] {
+		instanceIds = append(instanceIds, part[:strings.Index(part, "</instanceId>")])
+	}
+	if len(instanceIds) != 3 {
+		t.Fatalf("Expected 3 instances, got %d", len(instanceIds))
+	}
+	for _, instanceId := range instanceIds {
+		if !rm.Graph().HasNode(graph.ResourceID{Service: "ec2", Type: "instance", ID: instanceId}) {
+			t.Errorf("Expected instance %s to be registered in the graph", instanceId)
+		}
+	}
+
+	// The subnet can't be deleted while it holds instances
+	resp := request("DeleteSubnet", "&SubnetId="+subnetId)
+	testhelpers.AssertResponseStatus(t, resp, 400)
+	testhelpers.AssertErrorResponse(t, resp, "DependencyViolation", emulator.ProtocolQuery)
+
+	params := ""
+	for i, instanceId := range instanceIds {
+		params += fmt.Sprintf("&InstanceId.%d=%s", i+1, instanceId)
+	}
+	testhelpers.AssertResponseStatus(t, request("TerminateInstances", params), 200)
+	testhelpers.AssertResponseStatus(t, request("DeleteSubnet", "&SubnetId="+subnetId), 200)
+}
+
+func TestStrictMode_RunInstances_RollsBackEveryInstance(t *testing.T) {
+	state := emulator.NewMemoryStateManager()
+	validator := emulator.NewSchemaValidator()
+	rm := createTestResourceManager(state)
+	service := NewEC2ServiceWithGraph(state, validator, rm)
+
+	// A subnet in the state but not the graph, so the instances can't reference it
+	subnetId := "subnet-0123456789abcdef0"
+	if err := state.Set("ec2:subnets:"+subnetId, &Subnet{SubnetId: &subnetId, VpcId: helpers.StringPtr("vpc-default")}); err != nil {
+		t.Fatalf("Failed to store subnet: %v", err)
+	}
+	nodes, edges := rm.Graph().NodeCount(), rm.Graph().EdgeCount()
+
+	resp, err := service.HandleRequest(context.Background(), &emulator.AWSRequest{
+		Method: "POST",
+		Headers: map[string]string{
+			"Content-Type": "application/x-www-form-urlencoded",
+		},
+		Body:   []byte("Action=RunInstances&ImageId=ami-12345678&MinCount=3&MaxCount=3&SubnetId=" + subnetId + "&TagSpecification.1.ResourceType=instance&TagSpecification.1.Tag.1.Key=Name&TagSpecification.1.Tag.1.Value=web"),
+		Action: "RunInstances",
+	})
+	if err != nil {
+		t.Fatalf("RunInstances failed: %v", err)
+	}
+	testhelpers.AssertResponseStatus(t, resp, 500)
+
+	// None of the instances are left behind
+	for _, prefix := range []string{"ec2:instances:", "ec2:tags:i-", "ec2:reservations:"} {
+		keys, err := state.List(prefix)
+		if err != nil {
+			t.Fatalf("Failed to list %s: %v", prefix, err)
+		}
+		if len(keys) != 0 {
+			t.Errorf("Expected no %s keys after the failed launch, got %v", prefix, keys)
+		}
+	}
+	if rm.Graph().NodeCount() != nodes || rm.Graph().EdgeCount() != edges {
+		t.Errorf("Expected the graph to be unchanged, got %d nodes and %d edges, want %d and %d",
+			rm.Graph().NodeCount(), rm.Graph().EdgeCount(), nodes, edges)
+	}
+}
+
+// failingInstanceState fails to store the instance after the first ok ones
+type failingInstanceState struct {
+	emulator.StateManager
+	ok int
+}
+
+func (s *failingInstanceState) Set(key string, value interface{}) error {
+	if strings.HasPrefix(key, "ec2:instances:") {
+		if s.ok == 0 {
+			return fmt.Errorf("state unavailable")
+		}
+		s.ok--
+	}
+	return s.StateManager.Set(key, value)
+}
+
+func TestRunInstances_StorageFailure_RollsBackEveryInstance(t *testing.T) {
+	memory := emulator.NewMemoryStateManager()
+	state := &failingInstanceState{StateManager: memory, ok: 1}
+	rm := createTestResourceManager(memory)
+	service := NewEC2ServiceWithGraph(state, emulator.NewSchemaValidator(), rm)
+	nodes, edges := rm.Graph().NodeCount(), rm.Graph().EdgeCount()
+
+	resp, err := service.HandleRequest(context.Background(), &emulator.AWSRequest{
+		Method: "POST",
+		Headers: map[string]string{
+			"Content-Type": "application/x-www-form-urlencoded",
+		},
+		Body:   []byte("Action=RunInstances&ImageId=ami-12345678&MinCount=3&MaxCount=3&TagSpecification.1.ResourceType=instance&TagSpecification.1.Tag.1.Key=Name&TagSpecification.1.Tag.1.Value=web"),
+		Action: "RunInstances",
+	})
+	if err != nil {
+		t.Fatalf("RunInstances failed: %v", err)
+	}
+	testhelpers.AssertResponseStatus(t, resp, 500)
+
+	// The instance stored before the failure is removed too
+	for _, prefix := range []string{"ec2:instances:", "ec2:tags:i-", "ec2:reservations:"} {
+		keys, err := memory.List(prefix)
+		if err != nil {
+			t.Fatalf("Failed to list %s: %v", prefix, err)
+		}
+		if len(keys) != 0 {
+			t.Errorf("Expected no %s keys after the failed launch, got %v", prefix, keys)
+		}
+	}
+	if rm.Graph().NodeCount() != nodes || rm.Graph().EdgeCount() != edges {
+		t.Errorf("Expected the graph to be unchanged, got %d nodes and %d edges, want %d and %d",
+			rm.Graph().NodeCount(), rm.Graph().EdgeCount(), nodes, edges)
+	}
+}
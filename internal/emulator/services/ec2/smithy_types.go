@@ -10,6 +10,706 @@ import (
 	"time"
 )
 
+// smithyResponseTypes maps operation names to constructors for their generated response types.
+var smithyResponseTypes = map[string]func() interface{}{
+	"AcceptAddressTransfer":                                           func() interface{} { return &AcceptAddressTransferResult{} },
+	"AcceptCapacityReservationBillingOwnership":                       func() interface{} { return &AcceptCapacityReservationBillingOwnershipResult{} },
+	"AcceptReservedInstancesExchangeQuote":                            func() interface{} { return &AcceptReservedInstancesExchangeQuoteResult{} },
+	"AcceptTransitGatewayMulticastDomainAssociations":                 func() interface{} { return &AcceptTransitGatewayMulticastDomainAssociationsResult{} },
+	"AcceptTransitGatewayPeeringAttachment":                           func() interface{} { return &AcceptTransitGatewayPeeringAttachmentResult{} },
+	"AcceptTransitGatewayVpcAttachment":                               func() interface{} { return &AcceptTransitGatewayVpcAttachmentResult{} },
+	"AcceptVpcEndpointConnections":                                    func() interface{} { return &AcceptVpcEndpointConnectionsResult{} },
+	"AcceptVpcPeeringConnection":                                      func() interface{} { return &AcceptVpcPeeringConnectionResult{} },
+	"AdvertiseByoipCidr":                                              func() interface{} { return &AdvertiseByoipCidrResult{} },
+	"AllocateAddress":                                                 func() interface{} { return &AllocateAddressResult{} },
+	"AllocateHosts":                                                   func() interface{} { return &AllocateHostsResult{} },
+	"AllocateIpamPoolCidr":                                            func() interface{} { return &AllocateIpamPoolCidrResult{} },
+	"ApplySecurityGroupsToClientVpnTargetNetwork":                     func() interface{} { return &ApplySecurityGroupsToClientVpnTargetNetworkResult{} },
+	"AssignIpv6Addresses":                                             func() interface{} { return &AssignIpv6AddressesResult{} },
+	"AssignPrivateIpAddresses":                                        func() interface{} { return &AssignPrivateIpAddressesResult{} },
+	"AssignPrivateNatGatewayAddress":                                  func() interface{} { return &AssignPrivateNatGatewayAddressResult{} },
+	"AssociateAddress":                                                func() interface{} { return &AssociateAddressResult{} },
+	"AssociateCapacityReservationBillingOwner":                        func() interface{} { return &AssociateCapacityReservationBillingOwnerResult{} },
+	"AssociateClientVpnTargetNetwork":                                 func() interface{} { return &AssociateClientVpnTargetNetworkResult{} },
+	"AssociateEnclaveCertificateIamRole":                              func() interface{} { return &AssociateEnclaveCertificateIamRoleResult{} },
+	"AssociateIamInstanceProfile":                                     func() interface{} { return &AssociateIamInstanceProfileResult{} },
+	"AssociateInstanceEventWindow":                                    func() interface{} { return &AssociateInstanceEventWindowResult{} },
+	"AssociateIpamByoasn":                                             func() interface{} { return &AssociateIpamByoasnResult{} },
+	"AssociateIpamResourceDiscovery":                                  func() interface{} { return &AssociateIpamResourceDiscoveryResult{} },
+	"AssociateNatGatewayAddress":                                      func() interface{} { return &AssociateNatGatewayAddressResult{} },
+	"AssociateRouteServer":                                            func() interface{} { return &AssociateRouteServerResult{} },
+	"AssociateRouteTable":                                             func() interface{} { return &AssociateRouteTableResult{} },
+	"AssociateSecurityGroupVpc":                                       func() interface{} { return &AssociateSecurityGroupVpcResult{} },
+	"AssociateSubnetCidrBlock":                                        func() interface{} { return &AssociateSubnetCidrBlockResult{} },
+	"AssociateTransitGatewayMulticastDomain":                          func() interface{} { return &AssociateTransitGatewayMulticastDomainResult{} },
+	"AssociateTransitGatewayPolicyTable":                              func() interface{} { return &AssociateTransitGatewayPolicyTableResult{} },
+	"AssociateTransitGatewayRouteTable":                               func() interface{} { return &AssociateTransitGatewayRouteTableResult{} },
+	"AssociateTrunkInterface":                                         func() interface{} { return &AssociateTrunkInterfaceResult{} },
+	"AssociateVpcCidrBlock":                                           func() interface{} { return &AssociateVpcCidrBlockResult{} },
+	"AttachClassicLinkVpc":                                            func() interface{} { return &AttachClassicLinkVpcResult{} },
+	"AttachNetworkInterface":                                          func() interface{} { return &AttachNetworkInterfaceResult{} },
+	"AttachVerifiedAccessTrustProvider":                               func() interface{} { return &AttachVerifiedAccessTrustProviderResult{} },
+	"AttachVolume":                                                    func() interface{} { return &VolumeAttachment{} },
+	"AttachVpnGateway":                                                func() interface{} { return &AttachVpnGatewayResult{} },
+	"AuthorizeClientVpnIngress":                                       func() interface{} { return &AuthorizeClientVpnIngressResult{} },
+	"AuthorizeSecurityGroupEgress":                                    func() interface{} { return &AuthorizeSecurityGroupEgressResult{} },
+	"AuthorizeSecurityGroupIngress":                                   func() interface{} { return &AuthorizeSecurityGroupIngressResult{} },
+	"BundleInstance":                                                  func() interface{} { return &BundleInstanceResult{} },
+	"CancelBundleTask":                                                func() interface{} { return &CancelBundleTaskResult{} },
+	"CancelCapacityReservation":                                       func() interface{} { return &CancelCapacityReservationResult{} },
+	"CancelCapacityReservationFleets":                                 func() interface{} { return &CancelCapacityReservationFleetsResult{} },
+	"CancelDeclarativePoliciesReport":                                 func() interface{} { return &CancelDeclarativePoliciesReportResult{} },
+	"CancelImageLaunchPermission":                                     func() interface{} { return &CancelImageLaunchPermissionResult{} },
+	"CancelImportTask":                                                func() interface{} { return &CancelImportTaskResult{} },
+	"CancelReservedInstancesListing":                                  func() interface{} { return &CancelReservedInstancesListingResult{} },
+	"CancelSpotFleetRequests":                                         func() interface{} { return &CancelSpotFleetRequestsResponse{} },
+	"CancelSpotInstanceRequests":                                      func() interface{} { return &CancelSpotInstanceRequestsResult{} },
+	"ConfirmProductInstance":                                          func() interface{} { return &ConfirmProductInstanceResult{} },
+	"CopyFpgaImage":                                                   func() interface{} { return &CopyFpgaImageResult{} },
+	"CopyImage":                                                       func() interface{} { return &CopyImageResult{} },
+	"CopySnapshot":                                                    func() interface{} { return &CopySnapshotResult{} },
+	"CopyVolumes":                                                     func() interface{} { return &CopyVolumesResult{} },
+	"CreateCapacityManagerDataExport":                                 func() interface{} { return &CreateCapacityManagerDataExportResult{} },
+	"CreateCapacityReservation":                                       func() interface{} { return &CreateCapacityReservationResult{} },
+	"CreateCapacityReservationBySplitting":                            func() interface{} { return &CreateCapacityReservationBySplittingResult{} },
+	"CreateCapacityReservationFleet":                                  func() interface{} { return &CreateCapacityReservationFleetResult{} },
+	"CreateCarrierGateway":                                            func() interface{} { return &CreateCarrierGatewayResult{} },
+	"CreateClientVpnEndpoint":                                         func() interface{} { return &CreateClientVpnEndpointResult{} },
+	"CreateClientVpnRoute":                                            func() interface{} { return &CreateClientVpnRouteResult{} },
+	"CreateCoipCidr":                                                  func() interface{} { return &CreateCoipCidrResult{} },
+	"CreateCoipPool":                                                  func() interface{} { return &CreateCoipPoolResult{} },
+	"CreateCustomerGateway":                                           func() interface{} { return &CreateCustomerGatewayResult{} },
+	"CreateDefaultSubnet":                                             func() interface{} { return &CreateDefaultSubnetResult{} },
+	"CreateDefaultVpc":                                                func() interface{} { return &CreateDefaultVpcResult{} },
+	"CreateDelegateMacVolumeOwnershipTask":                            func() interface{} { return &CreateDelegateMacVolumeOwnershipTaskResult{} },
+	"CreateDhcpOptions":                                               func() interface{} { return &CreateDhcpOptionsResult{} },
+	"CreateEgressOnlyInternetGateway":                                 func() interface{} { return &CreateEgressOnlyInternetGatewayResult{} },
+	"CreateFleet":                                                     func() interface{} { return &CreateFleetResult{} },
+	"CreateFlowLogs":                                                  func() interface{} { return &CreateFlowLogsResult{} },
+	"CreateFpgaImage":                                                 func() interface{} { return &CreateFpgaImageResult{} },
+	"CreateImage":                                                     func() interface{} { return &CreateImageResult{} },
+	"CreateImageUsageReport":                                          func() interface{} { return &CreateImageUsageReportResult{} },
+	"CreateInstanceConnectEndpoint":                                   func() interface{} { return &CreateInstanceConnectEndpointResult{} },
+	"CreateInstanceEventWindow":                                       func() interface{} { return &CreateInstanceEventWindowResult{} },
+	"CreateInstanceExportTask":                                        func() interface{} { return &CreateInstanceExportTaskResult{} },
+	"CreateInternetGateway":                                           func() interface{} { return &CreateInternetGatewayResult{} },
+	"CreateInterruptibleCapacityReservationAllocation":                func() interface{} { return &CreateInterruptibleCapacityReservationAllocationResult{} },
+	"CreateIpam":                                                      func() interface{} { return &CreateIpamResult{} },
+	"CreateIpamExternalResourceVerificationToken":                     func() interface{} { return &CreateIpamExternalResourceVerificationTokenResult{} },
+	"CreateIpamPolicy":                                                func() interface{} { return &CreateIpamPolicyResult{} },
+	"CreateIpamPool":                                                  func() interface{} { return &CreateIpamPoolResult{} },
+	"CreateIpamPrefixListResolver":                                    func() interface{} { return &CreateIpamPrefixListResolverResult{} },
+	"CreateIpamPrefixListResolverTarget":                              func() interface{} { return &CreateIpamPrefixListResolverTargetResult{} },
+	"CreateIpamResourceDiscovery":                                     func() interface{} { return &CreateIpamResourceDiscoveryResult{} },
+	"CreateIpamScope":                                                 func() interface{} { return &CreateIpamScopeResult{} },
+	"CreateKeyPair":                                                   func() interface{} { return &KeyPair{} },
+	"CreateLaunchTemplate":                                            func() interface{} { return &CreateLaunchTemplateResult{} },
+	"CreateLaunchTemplateVersion":                                     func() interface{} { return &CreateLaunchTemplateVersionResult{} },
+	"CreateLocalGatewayRoute":                                         func() interface{} { return &CreateLocalGatewayRouteResult{} },
+	"CreateLocalGatewayRouteTable":                                    func() interface{} { return &CreateLocalGatewayRouteTableResult{} },
+	"CreateLocalGatewayRouteTableVirtualInterfaceGroupAssociation":    func() interface{} { return &CreateLocalGatewayRouteTableVirtualInterfaceGroupAssociationResult{} },
+	"CreateLocalGatewayRouteTableVpcAssociation":                      func() interface{} { return &CreateLocalGatewayRouteTableVpcAssociationResult{} },
+	"CreateLocalGatewayVirtualInterface":                              func() interface{} { return &CreateLocalGatewayVirtualInterfaceResult{} },
+	"CreateLocalGatewayVirtualInterfaceGroup":                         func() interface{} { return &CreateLocalGatewayVirtualInterfaceGroupResult{} },
+	"CreateMacSystemIntegrityProtectionModificationTask":              func() interface{} { return &CreateMacSystemIntegrityProtectionModificationTaskResult{} },
+	"CreateManagedPrefixList":                                         func() interface{} { return &CreateManagedPrefixListResult{} },
+	"CreateNatGateway":                                                func() interface{} { return &CreateNatGatewayResult{} },
+	"CreateNetworkAcl":                                                func() interface{} { return &CreateNetworkAclResult{} },
+	"CreateNetworkInsightsAccessScope":                                func() interface{} { return &CreateNetworkInsightsAccessScopeResult{} },
+	"CreateNetworkInsightsPath":                                       func() interface{} { return &CreateNetworkInsightsPathResult{} },
+	"CreateNetworkInterface":                                          func() interface{} { return &CreateNetworkInterfaceResult{} },
+	"CreateNetworkInterfacePermission":                                func() interface{} { return &CreateNetworkInterfacePermissionResult{} },
+	"CreatePlacementGroup":                                            func() interface{} { return &CreatePlacementGroupResult{} },
+	"CreatePublicIpv4Pool":                                            func() interface{} { return &CreatePublicIpv4PoolResult{} },
+	"CreateReplaceRootVolumeTask":                                     func() interface{} { return &CreateReplaceRootVolumeTaskResult{} },
+	"CreateReservedInstancesListing":                                  func() interface{} { return &CreateReservedInstancesListingResult{} },
+	"CreateRestoreImageTask":                                          func() interface{} { return &CreateRestoreImageTaskResult{} },
+	"CreateRoute":                                                     func() interface{} { return &CreateRouteResult{} },
+	"CreateRouteServer":                                               func() interface{} { return &CreateRouteServerResult{} },
+	"CreateRouteServerEndpoint":                                       func() interface{} { return &CreateRouteServerEndpointResult{} },
+	"CreateRouteServerPeer":                                           func() interface{} { return &CreateRouteServerPeerResult{} },
+	"CreateRouteTable":                                                func() interface{} { return &CreateRouteTableResult{} },
+	"CreateSecurityGroup":                                             func() interface{} { return &CreateSecurityGroupResult{} },
+	"CreateSnapshot":                                                  func() interface{} { return &Snapshot{} },
+	"CreateSnapshots":                                                 func() interface{} { return &CreateSnapshotsResult{} },
+	"CreateSpotDatafeedSubscription":                                  func() interface{} { return &CreateSpotDatafeedSubscriptionResult{} },
+	"CreateStoreImageTask":                                            func() interface{} { return &CreateStoreImageTaskResult{} },
+	"CreateSubnet":                                                    func() interface{} { return &CreateSubnetResult{} },
+	"CreateSubnetCidrReservation":                                     func() interface{} { return &CreateSubnetCidrReservationResult{} },
+	"CreateTrafficMirrorFilter":                                       func() interface{} { return &CreateTrafficMirrorFilterResult{} },
+	"CreateTrafficMirrorFilterRule":                                   func() interface{} { return &CreateTrafficMirrorFilterRuleResult{} },
+	"CreateTrafficMirrorSession":                                      func() interface{} { return &CreateTrafficMirrorSessionResult{} },
+	"CreateTrafficMirrorTarget":                                       func() interface{} { return &CreateTrafficMirrorTargetResult{} },
+	"CreateTransitGateway":                                            func() interface{} { return &CreateTransitGatewayResult{} },
+	"CreateTransitGatewayConnect":                                     func() interface{} { return &CreateTransitGatewayConnectResult{} },
+	"CreateTransitGatewayConnectPeer":                                 func() interface{} { return &CreateTransitGatewayConnectPeerResult{} },
+	"CreateTransitGatewayMeteringPolicy":                              func() interface{} { return &CreateTransitGatewayMeteringPolicyResult{} },
+	"CreateTransitGatewayMeteringPolicyEntry":                         func() interface{} { return &CreateTransitGatewayMeteringPolicyEntryResult{} },
+	"CreateTransitGatewayMulticastDomain":                             func() interface{} { return &CreateTransitGatewayMulticastDomainResult{} },
+	"CreateTransitGatewayPeeringAttachment":                           func() interface{} { return &CreateTransitGatewayPeeringAttachmentResult{} },
+	"CreateTransitGatewayPolicyTable":                                 func() interface{} { return &CreateTransitGatewayPolicyTableResult{} },
+	"CreateTransitGatewayPrefixListReference":                         func() interface{} { return &CreateTransitGatewayPrefixListReferenceResult{} },
+	"CreateTransitGatewayRoute":                                       func() interface{} { return &CreateTransitGatewayRouteResult{} },
+	"CreateTransitGatewayRouteTable":                                  func() interface{} { return &CreateTransitGatewayRouteTableResult{} },
+	"CreateTransitGatewayRouteTableAnnouncement":                      func() interface{} { return &CreateTransitGatewayRouteTableAnnouncementResult{} },
+	"CreateTransitGatewayVpcAttachment":                               func() interface{} { return &CreateTransitGatewayVpcAttachmentResult{} },
+	"CreateVerifiedAccessEndpoint":                                    func() interface{} { return &CreateVerifiedAccessEndpointResult{} },
+	"CreateVerifiedAccessGroup":                                       func() interface{} { return &CreateVerifiedAccessGroupResult{} },
+	"CreateVerifiedAccessInstance":                                    func() interface{} { return &CreateVerifiedAccessInstanceResult{} },
+	"CreateVerifiedAccessTrustProvider":                               func() interface{} { return &CreateVerifiedAccessTrustProviderResult{} },
+	"CreateVolume":                                                    func() interface{} { return &Volume{} },
+	"CreateVpc":                                                       func() interface{} { return &CreateVpcResult{} },
+	"CreateVpcBlockPublicAccessExclusion":                             func() interface{} { return &CreateVpcBlockPublicAccessExclusionResult{} },
+	"CreateVpcEncryptionControl":                                      func() interface{} { return &CreateVpcEncryptionControlResult{} },
+	"CreateVpcEndpoint":                                               func() interface{} { return &CreateVpcEndpointResult{} },
+	"CreateVpcEndpointConnectionNotification":                         func() interface{} { return &CreateVpcEndpointConnectionNotificationResult{} },
+	"CreateVpcEndpointServiceConfiguration":                           func() interface{} { return &CreateVpcEndpointServiceConfigurationResult{} },
+	"CreateVpcPeeringConnection":                                      func() interface{} { return &CreateVpcPeeringConnectionResult{} },
+	"CreateVpnConcentrator":                                           func() interface{} { return &CreateVpnConcentratorResult{} },
+	"CreateVpnConnection":                                             func() interface{} { return &CreateVpnConnectionResult{} },
+	"CreateVpnGateway":                                                func() interface{} { return &CreateVpnGatewayResult{} },
+	"DeleteCapacityManagerDataExport":                                 func() interface{} { return &DeleteCapacityManagerDataExportResult{} },
+	"DeleteCarrierGateway":                                            func() interface{} { return &DeleteCarrierGatewayResult{} },
+	"DeleteClientVpnEndpoint":                                         func() interface{} { return &DeleteClientVpnEndpointResult{} },
+	"DeleteClientVpnRoute":                                            func() interface{} { return &DeleteClientVpnRouteResult{} },
+	"DeleteCoipCidr":                                                  func() interface{} { return &DeleteCoipCidrResult{} },
+	"DeleteCoipPool":                                                  func() interface{} { return &DeleteCoipPoolResult{} },
+	"DeleteEgressOnlyInternetGateway":                                 func() interface{} { return &DeleteEgressOnlyInternetGatewayResult{} },
+	"DeleteFleets":                                                    func() interface{} { return &DeleteFleetsResult{} },
+	"DeleteFlowLogs":                                                  func() interface{} { return &DeleteFlowLogsResult{} },
+	"DeleteFpgaImage":                                                 func() interface{} { return &DeleteFpgaImageResult{} },
+	"DeleteImageUsageReport":                                          func() interface{} { return &DeleteImageUsageReportResult{} },
+	"DeleteInstanceConnectEndpoint":                                   func() interface{} { return &DeleteInstanceConnectEndpointResult{} },
+	"DeleteInstanceEventWindow":                                       func() interface{} { return &DeleteInstanceEventWindowResult{} },
+	"DeleteIpam":                                                      func() interface{} { return &DeleteIpamResult{} },
+	"DeleteIpamExternalResourceVerificationToken":                     func() interface{} { return &DeleteIpamExternalResourceVerificationTokenResult{} },
+	"DeleteIpamPolicy":                                                func() interface{} { return &DeleteIpamPolicyResult{} },
+	"DeleteIpamPool":                                                  func() interface{} { return &DeleteIpamPoolResult{} },
+	"DeleteIpamPrefixListResolver":                                    func() interface{} { return &DeleteIpamPrefixListResolverResult{} },
+	"DeleteIpamPrefixListResolverTarget":                              func() interface{} { return &DeleteIpamPrefixListResolverTargetResult{} },
+	"DeleteIpamResourceDiscovery":                                     func() interface{} { return &DeleteIpamResourceDiscoveryResult{} },
+	"DeleteIpamScope":                                                 func() interface{} { return &DeleteIpamScopeResult{} },
+	"DeleteKeyPair":                                                   func() interface{} { return &DeleteKeyPairResult{} },
+	"DeleteLaunchTemplate":                                            func() interface{} { return &DeleteLaunchTemplateResult{} },
+	"DeleteLaunchTemplateVersions":                                    func() interface{} { return &DeleteLaunchTemplateVersionsResult{} },
+	"DeleteLocalGatewayRoute":                                         func() interface{} { return &DeleteLocalGatewayRouteResult{} },
+	"DeleteLocalGatewayRouteTable":                                    func() interface{} { return &DeleteLocalGatewayRouteTableResult{} },
+	"DeleteLocalGatewayRouteTableVirtualInterfaceGroupAssociation":    func() interface{} { return &DeleteLocalGatewayRouteTableVirtualInterfaceGroupAssociationResult{} },
+	"DeleteLocalGatewayRouteTableVpcAssociation":                      func() interface{} { return &DeleteLocalGatewayRouteTableVpcAssociationResult{} },
+	"DeleteLocalGatewayVirtualInterface":                              func() interface{} { return &DeleteLocalGatewayVirtualInterfaceResult{} },
+	"DeleteLocalGatewayVirtualInterfaceGroup":                         func() interface{} { return &DeleteLocalGatewayVirtualInterfaceGroupResult{} },
+	"DeleteManagedPrefixList":                                         func() interface{} { return &DeleteManagedPrefixListResult{} },
+	"DeleteNatGateway":                                                func() interface{} { return &DeleteNatGatewayResult{} },
+	"DeleteNetworkInsightsAccessScope":                                func() interface{} { return &DeleteNetworkInsightsAccessScopeResult{} },
+	"DeleteNetworkInsightsAccessScopeAnalysis":                        func() interface{} { return &DeleteNetworkInsightsAccessScopeAnalysisResult{} },
+	"DeleteNetworkInsightsAnalysis":                                   func() interface{} { return &DeleteNetworkInsightsAnalysisResult{} },
+	"DeleteNetworkInsightsPath":                                       func() interface{} { return &DeleteNetworkInsightsPathResult{} },
+	"DeleteNetworkInterfacePermission":                                func() interface{} { return &DeleteNetworkInterfacePermissionResult{} },
+	"DeletePublicIpv4Pool":                                            func() interface{} { return &DeletePublicIpv4PoolResult{} },
+	"DeleteQueuedReservedInstances":                                   func() interface{} { return &DeleteQueuedReservedInstancesResult{} },
+	"DeleteRouteServer":                                               func() interface{} { return &DeleteRouteServerResult{} },
+	"DeleteRouteServerEndpoint":                                       func() interface{} { return &DeleteRouteServerEndpointResult{} },
+	"DeleteRouteServerPeer":                                           func() interface{} { return &DeleteRouteServerPeerResult{} },
+	"DeleteSecurityGroup":                                             func() interface{} { return &DeleteSecurityGroupResult{} },
+	"DeleteSubnetCidrReservation":                                     func() interface{} { return &DeleteSubnetCidrReservationResult{} },
+	"DeleteTrafficMirrorFilter":                                       func() interface{} { return &DeleteTrafficMirrorFilterResult{} },
+	"DeleteTrafficMirrorFilterRule":                                   func() interface{} { return &DeleteTrafficMirrorFilterRuleResult{} },
+	"DeleteTrafficMirrorSession":                                      func() interface{} { return &DeleteTrafficMirrorSessionResult{} },
+	"DeleteTrafficMirrorTarget":                                       func() interface{} { return &DeleteTrafficMirrorTargetResult{} },
+	"DeleteTransitGateway":                                            func() interface{} { return &DeleteTransitGatewayResult{} },
+	"DeleteTransitGatewayConnect":                                     func() interface{} { return &DeleteTransitGatewayConnectResult{} },
+	"DeleteTransitGatewayConnectPeer":                                 func() interface{} { return &DeleteTransitGatewayConnectPeerResult{} },
+	"DeleteTransitGatewayMeteringPolicy":                              func() interface{} { return &DeleteTransitGatewayMeteringPolicyResult{} },
+	"DeleteTransitGatewayMeteringPolicyEntry":                         func() interface{} { return &DeleteTransitGatewayMeteringPolicyEntryResult{} },
+	"DeleteTransitGatewayMulticastDomain":                             func() interface{} { return &DeleteTransitGatewayMulticastDomainResult{} },
+	"DeleteTransitGatewayPeeringAttachment":                           func() interface{} { return &DeleteTransitGatewayPeeringAttachmentResult{} },
+	"DeleteTransitGatewayPolicyTable":                                 func() interface{} { return &DeleteTransitGatewayPolicyTableResult{} },
+	"DeleteTransitGatewayPrefixListReference":                         func() interface{} { return &DeleteTransitGatewayPrefixListReferenceResult{} },
+	"DeleteTransitGatewayRoute":                                       func() interface{} { return &DeleteTransitGatewayRouteResult{} },
+	"DeleteTransitGatewayRouteTable":                                  func() interface{} { return &DeleteTransitGatewayRouteTableResult{} },
+	"DeleteTransitGatewayRouteTableAnnouncement":                      func() interface{} { return &DeleteTransitGatewayRouteTableAnnouncementResult{} },
+	"DeleteTransitGatewayVpcAttachment":                               func() interface{} { return &DeleteTransitGatewayVpcAttachmentResult{} },
+	"DeleteVerifiedAccessEndpoint":                                    func() interface{} { return &DeleteVerifiedAccessEndpointResult{} },
+	"DeleteVerifiedAccessGroup":                                       func() interface{} { return &DeleteVerifiedAccessGroupResult{} },
+	"DeleteVerifiedAccessInstance":                                    func() interface{} { return &DeleteVerifiedAccessInstanceResult{} },
+	"DeleteVerifiedAccessTrustProvider":                               func() interface{} { return &DeleteVerifiedAccessTrustProviderResult{} },
+	"DeleteVpcBlockPublicAccessExclusion":                             func() interface{} { return &DeleteVpcBlockPublicAccessExclusionResult{} },
+	"DeleteVpcEncryptionControl":                                      func() interface{} { return &DeleteVpcEncryptionControlResult{} },
+	"DeleteVpcEndpointConnectionNotifications":                        func() interface{} { return &DeleteVpcEndpointConnectionNotificationsResult{} },
+	"DeleteVpcEndpointServiceConfigurations":                          func() interface{} { return &DeleteVpcEndpointServiceConfigurationsResult{} },
+	"DeleteVpcEndpoints":                                              func() interface{} { return &DeleteVpcEndpointsResult{} },
+	"DeleteVpcPeeringConnection":                                      func() interface{} { return &DeleteVpcPeeringConnectionResult{} },
+	"DeleteVpnConcentrator":                                           func() interface{} { return &DeleteVpnConcentratorResult{} },
+	"DeprovisionByoipCidr":                                            func() interface{} { return &DeprovisionByoipCidrResult{} },
+	"DeprovisionIpamByoasn":                                           func() interface{} { return &DeprovisionIpamByoasnResult{} },
+	"DeprovisionIpamPoolCidr":                                         func() interface{} { return &DeprovisionIpamPoolCidrResult{} },
+	"DeprovisionPublicIpv4PoolCidr":                                   func() interface{} { return &DeprovisionPublicIpv4PoolCidrResult{} },
+	"DeregisterImage":                                                 func() interface{} { return &DeregisterImageResult{} },
+	"DeregisterInstanceEventNotificationAttributes":                   func() interface{} { return &DeregisterInstanceEventNotificationAttributesResult{} },
+	"DeregisterTransitGatewayMulticastGroupMembers":                   func() interface{} { return &DeregisterTransitGatewayMulticastGroupMembersResult{} },
+	"DeregisterTransitGatewayMulticastGroupSources":                   func() interface{} { return &DeregisterTransitGatewayMulticastGroupSourcesResult{} },
+	"DescribeAccountAttributes":                                       func() interface{} { return &DescribeAccountAttributesResult{} },
+	"DescribeAddressTransfers":                                        func() interface{} { return &DescribeAddressTransfersResult{} },
+	"DescribeAddresses":                                               func() interface{} { return &DescribeAddressesResult{} },
+	"DescribeAddressesAttribute":                                      func() interface{} { return &DescribeAddressesAttributeResult{} },
+	"DescribeAggregateIdFormat":                                       func() interface{} { return &DescribeAggregateIdFormatResult{} },
+	"DescribeAvailabilityZones":                                       func() interface{} { return &DescribeAvailabilityZonesResult{} },
+	"DescribeAwsNetworkPerformanceMetricSubscriptions":                func() interface{} { return &DescribeAwsNetworkPerformanceMetricSubscriptionsResult{} },
+	"DescribeBundleTasks":                                             func() interface{} { return &DescribeBundleTasksResult{} },
+	"DescribeByoipCidrs":                                              func() interface{} { return &DescribeByoipCidrsResult{} },
+	"DescribeCapacityBlockExtensionHistory":                           func() interface{} { return &DescribeCapacityBlockExtensionHistoryResult{} },
+	"DescribeCapacityBlockExtensionOfferings":                         func() interface{} { return &DescribeCapacityBlockExtensionOfferingsResult{} },
+	"DescribeCapacityBlockOfferings":                                  func() interface{} { return &DescribeCapacityBlockOfferingsResult{} },
+	"DescribeCapacityBlockStatus":                                     func() interface{} { return &DescribeCapacityBlockStatusResult{} },
+	"DescribeCapacityBlocks":                                          func() interface{} { return &DescribeCapacityBlocksResult{} },
+	"DescribeCapacityManagerDataExports":                              func() interface{} { return &DescribeCapacityManagerDataExportsResult{} },
+	"DescribeCapacityReservationBillingRequests":                      func() interface{} { return &DescribeCapacityReservationBillingRequestsResult{} },
+	"DescribeCapacityReservationFleets":                               func() interface{} { return &DescribeCapacityReservationFleetsResult{} },
+	"DescribeCapacityReservationTopology":                             func() interface{} { return &DescribeCapacityReservationTopologyResult{} },
+	"DescribeCapacityReservations":                                    func() interface{} { return &DescribeCapacityReservationsResult{} },
+	"DescribeCarrierGateways":                                         func() interface{} { return &DescribeCarrierGatewaysResult{} },
+	"DescribeClassicLinkInstances":                                    func() interface{} { return &DescribeClassicLinkInstancesResult{} },
+	"DescribeClientVpnAuthorizationRules":                             func() interface{} { return &DescribeClientVpnAuthorizationRulesResult{} },
+	"DescribeClientVpnConnections":                                    func() interface{} { return &DescribeClientVpnConnectionsResult{} },
+	"DescribeClientVpnEndpoints":                                      func() interface{} { return &DescribeClientVpnEndpointsResult{} },
+	"DescribeClientVpnRoutes":                                         func() interface{} { return &DescribeClientVpnRoutesResult{} },
+	"DescribeClientVpnTargetNetworks":                                 func() interface{} { return &DescribeClientVpnTargetNetworksResult{} },
+	"DescribeCoipPools":                                               func() interface{} { return &DescribeCoipPoolsResult{} },
+	"DescribeConversionTasks":                                         func() interface{} { return &DescribeConversionTasksResult{} },
+	"DescribeCustomerGateways":                                        func() interface{} { return &DescribeCustomerGatewaysResult{} },
+	"DescribeDeclarativePoliciesReports":                              func() interface{} { return &DescribeDeclarativePoliciesReportsResult{} },
+	"DescribeDhcpOptions":                                             func() interface{} { return &DescribeDhcpOptionsResult{} },
+	"DescribeEgressOnlyInternetGateways":                              func() interface{} { return &DescribeEgressOnlyInternetGatewaysResult{} },
+	"DescribeElasticGpus":                                             func() interface{} { return &DescribeElasticGpusResult{} },
+	"DescribeExportImageTasks":                                        func() interface{} { return &DescribeExportImageTasksResult{} },
+	"DescribeExportTasks":                                             func() interface{} { return &DescribeExportTasksResult{} },
+	"DescribeFastLaunchImages":                                        func() interface{} { return &DescribeFastLaunchImagesResult{} },
+	"DescribeFastSnapshotRestores":                                    func() interface{} { return &DescribeFastSnapshotRestoresResult{} },
+	"DescribeFleetHistory":                                            func() interface{} { return &DescribeFleetHistoryResult{} },
+	"DescribeFleetInstances":                                          func() interface{} { return &DescribeFleetInstancesResult{} },
+	"DescribeFleets":                                                  func() interface{} { return &DescribeFleetsResult{} },
+	"DescribeFlowLogs":                                                func() interface{} { return &DescribeFlowLogsResult{} },
+	"DescribeFpgaImageAttribute":                                      func() interface{} { return &DescribeFpgaImageAttributeResult{} },
+	"DescribeFpgaImages":                                              func() interface{} { return &DescribeFpgaImagesResult{} },
+	"DescribeHostReservationOfferings":                                func() interface{} { return &DescribeHostReservationOfferingsResult{} },
+	"DescribeHostReservations":                                        func() interface{} { return &DescribeHostReservationsResult{} },
+	"DescribeHosts":                                                   func() interface{} { return &DescribeHostsResult{} },
+	"DescribeIamInstanceProfileAssociations":                          func() interface{} { return &DescribeIamInstanceProfileAssociationsResult{} },
+	"DescribeIdFormat":                                                func() interface{} { return &DescribeIdFormatResult{} },
+	"DescribeIdentityIdFormat":                                        func() interface{} { return &DescribeIdentityIdFormatResult{} },
+	"DescribeImageAttribute":                                          func() interface{} { return &ImageAttribute{} },
+	"DescribeImageReferences":                                         func() interface{} { return &DescribeImageReferencesResult{} },
+	"DescribeImageUsageReportEntries":                                 func() interface{} { return &DescribeImageUsageReportEntriesResult{} },
+	"DescribeImageUsageReports":                                       func() interface{} { return &DescribeImageUsageReportsResult{} },
+	"DescribeImages":                                                  func() interface{} { return &DescribeImagesResult{} },
+	"DescribeImportImageTasks":                                        func() interface{} { return &DescribeImportImageTasksResult{} },
+	"DescribeImportSnapshotTasks":                                     func() interface{} { return &DescribeImportSnapshotTasksResult{} },
+	"DescribeInstanceAttribute":                                       func() interface{} { return &InstanceAttribute{} },
+	"DescribeInstanceConnectEndpoints":                                func() interface{} { return &DescribeInstanceConnectEndpointsResult{} },
+	"DescribeInstanceCreditSpecifications":                            func() interface{} { return &DescribeInstanceCreditSpecificationsResult{} },
+	"DescribeInstanceEventNotificationAttributes":                     func() interface{} { return &DescribeInstanceEventNotificationAttributesResult{} },
+	"DescribeInstanceEventWindows":                                    func() interface{} { return &DescribeInstanceEventWindowsResult{} },
+	"DescribeInstanceImageMetadata":                                   func() interface{} { return &DescribeInstanceImageMetadataResult{} },
+	"DescribeInstanceSqlHaHistoryStates":                              func() interface{} { return &DescribeInstanceSqlHaHistoryStatesResult{} },
+	"DescribeInstanceSqlHaStates":                                     func() interface{} { return &DescribeInstanceSqlHaStatesResult{} },
+	"DescribeInstanceStatus":                                          func() interface{} { return &DescribeInstanceStatusResult{} },
+	"DescribeInstanceTopology":                                        func() interface{} { return &DescribeInstanceTopologyResult{} },
+	"DescribeInstanceTypeOfferings":                                   func() interface{} { return &DescribeInstanceTypeOfferingsResult{} },
+	"DescribeInstanceTypes":                                           func() interface{} { return &DescribeInstanceTypesResult{} },
+	"DescribeInstances":                                               func() interface{} { return &DescribeInstancesResult{} },
+	"DescribeInternetGateways":                                        func() interface{} { return &DescribeInternetGatewaysResult{} },
+	"DescribeIpamByoasn":                                              func() interface{} { return &DescribeIpamByoasnResult{} },
+	"DescribeIpamExternalResourceVerificationTokens":                  func() interface{} { return &DescribeIpamExternalResourceVerificationTokensResult{} },
+	"DescribeIpamPolicies":                                            func() interface{} { return &DescribeIpamPoliciesResult{} },
+	"DescribeIpamPools":                                               func() interface{} { return &DescribeIpamPoolsResult{} },
+	"DescribeIpamPrefixListResolverTargets":                           func() interface{} { return &DescribeIpamPrefixListResolverTargetsResult{} },
+	"DescribeIpamPrefixListResolvers":                                 func() interface{} { return &DescribeIpamPrefixListResolversResult{} },
+	"DescribeIpamResourceDiscoveries":                                 func() interface{} { return &DescribeIpamResourceDiscoveriesResult{} },
+	"DescribeIpamResourceDiscoveryAssociations":                       func() interface{} { return &DescribeIpamResourceDiscoveryAssociationsResult{} },
+	"DescribeIpamScopes":                                              func() interface{} { return &DescribeIpamScopesResult{} },
+	"DescribeIpams":                                                   func() interface{} { return &DescribeIpamsResult{} },
+	"DescribeIpv6Pools":                                               func() interface{} { return &DescribeIpv6PoolsResult{} },
+	"DescribeKeyPairs":                                                func() interface{} { return &DescribeKeyPairsResult{} },
+	"DescribeLaunchTemplateVersions":                                  func() interface{} { return &DescribeLaunchTemplateVersionsResult{} },
+	"DescribeLaunchTemplates":                                         func() interface{} { return &DescribeLaunchTemplatesResult{} },
+	"DescribeLocalGatewayRouteTableVirtualInterfaceGroupAssociations": func() interface{} { return &DescribeLocalGatewayRouteTableVirtualInterfaceGroupAssociationsResult{} },
+	"DescribeLocalGatewayRouteTableVpcAssociations":                   func() interface{} { return &DescribeLocalGatewayRouteTableVpcAssociationsResult{} },
+	"DescribeLocalGatewayRouteTables":                                 func() interface{} { return &DescribeLocalGatewayRouteTablesResult{} },
+	"DescribeLocalGatewayVirtualInterfaceGroups":                      func() interface{} { return &DescribeLocalGatewayVirtualInterfaceGroupsResult{} },
+	"DescribeLocalGatewayVirtualInterfaces":                           func() interface{} { return &DescribeLocalGatewayVirtualInterfacesResult{} },
+	"DescribeLocalGateways":                                           func() interface{} { return &DescribeLocalGatewaysResult{} },
+	"DescribeLockedSnapshots":                                         func() interface{} { return &DescribeLockedSnapshotsResult{} },
+	"DescribeMacHosts":                                                func() interface{} { return &DescribeMacHostsResult{} },
+	"DescribeMacModificationTasks":                                    func() interface{} { return &DescribeMacModificationTasksResult{} },
+	"DescribeManagedPrefixLists":                                      func() interface{} { return &DescribeManagedPrefixListsResult{} },
+	"DescribeMovingAddresses":                                         func() interface{} { return &DescribeMovingAddressesResult{} },
+	"DescribeNatGateways":                                             func() interface{} { return &DescribeNatGatewaysResult{} },
+	"DescribeNetworkAcls":                                             func() interface{} { return &DescribeNetworkAclsResult{} },
+	"DescribeNetworkInsightsAccessScopeAnalyses":                      func() interface{} { return &DescribeNetworkInsightsAccessScopeAnalysesResult{} },
+	"DescribeNetworkInsightsAccessScopes":                             func() interface{} { return &DescribeNetworkInsightsAccessScopesResult{} },
+	"DescribeNetworkInsightsAnalyses":                                 func() interface{} { return &DescribeNetworkInsightsAnalysesResult{} },
+	"DescribeNetworkInsightsPaths":                                    func() interface{} { return &DescribeNetworkInsightsPathsResult{} },
+	"DescribeNetworkInterfaceAttribute":                               func() interface{} { return &DescribeNetworkInterfaceAttributeResult{} },
+	"DescribeNetworkInterfacePermissions":                             func() interface{} { return &DescribeNetworkInterfacePermissionsResult{} },
+	"DescribeNetworkInterfaces":                                       func() interface{} { return &DescribeNetworkInterfacesResult{} },
+	"DescribeOutpostLags":                                             func() interface{} { return &DescribeOutpostLagsResult{} },
+	"DescribePlacementGroups":                                         func() interface{} { return &DescribePlacementGroupsResult{} },
+	"DescribePrefixLists":                                             func() interface{} { return &DescribePrefixListsResult{} },
+	"DescribePrincipalIdFormat":                                       func() interface{} { return &DescribePrincipalIdFormatResult{} },
+	"DescribePublicIpv4Pools":                                         func() interface{} { return &DescribePublicIpv4PoolsResult{} },
+	"DescribeRegions":                                                 func() interface{} { return &DescribeRegionsResult{} },
+	"DescribeReplaceRootVolumeTasks":                                  func() interface{} { return &DescribeReplaceRootVolumeTasksResult{} },
+	"DescribeReservedInstances":                                       func() interface{} { return &DescribeReservedInstancesResult{} },
+	"DescribeReservedInstancesListings":                               func() interface{} { return &DescribeReservedInstancesListingsResult{} },
+	"DescribeReservedInstancesModifications":                          func() interface{} { return &DescribeReservedInstancesModificationsResult{} },
+	"DescribeReservedInstancesOfferings":                              func() interface{} { return &DescribeReservedInstancesOfferingsResult{} },
+	"DescribeRouteServerEndpoints":                                    func() interface{} { return &DescribeRouteServerEndpointsResult{} },
+	"DescribeRouteServerPeers":                                        func() interface{} { return &DescribeRouteServerPeersResult{} },
+	"DescribeRouteServers":                                            func() interface{} { return &DescribeRouteServersResult{} },
+	"DescribeRouteTables":                                             func() interface{} { return &DescribeRouteTablesResult{} },
+	"DescribeScheduledInstanceAvailability":                           func() interface{} { return &DescribeScheduledInstanceAvailabilityResult{} },
+	"DescribeScheduledInstances":                                      func() interface{} { return &DescribeScheduledInstancesResult{} },
+	"DescribeSecurityGroupReferences":                                 func() interface{} { return &DescribeSecurityGroupReferencesResult{} },
+	"DescribeSecurityGroupRules":                                      func() interface{} { return &DescribeSecurityGroupRulesResult{} },
+	"DescribeSecurityGroupVpcAssociations":                            func() interface{} { return &DescribeSecurityGroupVpcAssociationsResult{} },
+	"DescribeSecurityGroups":                                          func() interface{} { return &DescribeSecurityGroupsResult{} },
+	"DescribeServiceLinkVirtualInterfaces":                            func() interface{} { return &DescribeServiceLinkVirtualInterfacesResult{} },
+	"DescribeSnapshotAttribute":                                       func() interface{} { return &DescribeSnapshotAttributeResult{} },
+	"DescribeSnapshotTierStatus":                                      func() interface{} { return &DescribeSnapshotTierStatusResult{} },
+	"DescribeSnapshots":                                               func() interface{} { return &DescribeSnapshotsResult{} },
+	"DescribeSpotDatafeedSubscription":                                func() interface{} { return &DescribeSpotDatafeedSubscriptionResult{} },
+	"DescribeSpotFleetInstances":                                      func() interface{} { return &DescribeSpotFleetInstancesResponse{} },
+	"DescribeSpotFleetRequestHistory":                                 func() interface{} { return &DescribeSpotFleetRequestHistoryResponse{} },
+	"DescribeSpotFleetRequests":                                       func() interface{} { return &DescribeSpotFleetRequestsResponse{} },
+	"DescribeSpotInstanceRequests":                                    func() interface{} { return &DescribeSpotInstanceRequestsResult{} },
+	"DescribeSpotPriceHistory":                                        func() interface{} { return &DescribeSpotPriceHistoryResult{} },
+	"DescribeStaleSecurityGroups":                                     func() interface{} { return &DescribeStaleSecurityGroupsResult{} },
+	"DescribeStoreImageTasks":                                         func() interface{} { return &DescribeStoreImageTasksResult{} },
+	"DescribeSubnets":                                                 func() interface{} { return &DescribeSubnetsResult{} },
+	"DescribeTags":                                                    func() interface{} { return &DescribeTagsResult{} },
+	"DescribeTrafficMirrorFilterRules":                                func() interface{} { return &DescribeTrafficMirrorFilterRulesResult{} },
+	"DescribeTrafficMirrorFilters":                                    func() interface{} { return &DescribeTrafficMirrorFiltersResult{} },
+	"DescribeTrafficMirrorSessions":                                   func() interface{} { return &DescribeTrafficMirrorSessionsResult{} },
+	"DescribeTrafficMirrorTargets":                                    func() interface{} { return &DescribeTrafficMirrorTargetsResult{} },
+	"DescribeTransitGatewayAttachments":                               func() interface{} { return &DescribeTransitGatewayAttachmentsResult{} },
+	"DescribeTransitGatewayConnectPeers":                              func() interface{} { return &DescribeTransitGatewayConnectPeersResult{} },
+	"DescribeTransitGatewayConnects":                                  func() interface{} { return &DescribeTransitGatewayConnectsResult{} },
+	"DescribeTransitGatewayMeteringPolicies":                          func() interface{} { return &DescribeTransitGatewayMeteringPoliciesResult{} },
+	"DescribeTransitGatewayMulticastDomains":                          func() interface{} { return &DescribeTransitGatewayMulticastDomainsResult{} },
+	"DescribeTransitGatewayPeeringAttachments":                        func() interface{} { return &DescribeTransitGatewayPeeringAttachmentsResult{} },
+	"DescribeTransitGatewayPolicyTables":                              func() interface{} { return &DescribeTransitGatewayPolicyTablesResult{} },
+	"DescribeTransitGatewayRouteTableAnnouncements":                   func() interface{} { return &DescribeTransitGatewayRouteTableAnnouncementsResult{} },
+	"DescribeTransitGatewayRouteTables":                               func() interface{} { return &DescribeTransitGatewayRouteTablesResult{} },
+	"DescribeTransitGatewayVpcAttachments":                            func() interface{} { return &DescribeTransitGatewayVpcAttachmentsResult{} },
+	"DescribeTransitGateways":                                         func() interface{} { return &DescribeTransitGatewaysResult{} },
+	"DescribeTrunkInterfaceAssociations":                              func() interface{} { return &DescribeTrunkInterfaceAssociationsResult{} },
+	"DescribeVerifiedAccessEndpoints":                                 func() interface{} { return &DescribeVerifiedAccessEndpointsResult{} },
+	"DescribeVerifiedAccessGroups":                                    func() interface{} { return &DescribeVerifiedAccessGroupsResult{} },
+	"DescribeVerifiedAccessInstanceLoggingConfigurations":             func() interface{} { return &DescribeVerifiedAccessInstanceLoggingConfigurationsResult{} },
+	"DescribeVerifiedAccessInstances":                                 func() interface{} { return &DescribeVerifiedAccessInstancesResult{} },
+	"DescribeVerifiedAccessTrustProviders":                            func() interface{} { return &DescribeVerifiedAccessTrustProvidersResult{} },
+	"DescribeVolumeAttribute":                                         func() interface{} { return &DescribeVolumeAttributeResult{} },
+	"DescribeVolumeStatus":                                            func() interface{} { return &DescribeVolumeStatusResult{} },
+	"DescribeVolumes":                                                 func() interface{} { return &DescribeVolumesResult{} },
+	"DescribeVolumesModifications":                                    func() interface{} { return &DescribeVolumesModificationsResult{} },
+	"DescribeVpcAttribute":                                            func() interface{} { return &DescribeVpcAttributeResult{} },
+	"DescribeVpcBlockPublicAccessExclusions":                          func() interface{} { return &DescribeVpcBlockPublicAccessExclusionsResult{} },
+	"DescribeVpcBlockPublicAccessOptions":                             func() interface{} { return &DescribeVpcBlockPublicAccessOptionsResult{} },
+	"DescribeVpcClassicLink":                                          func() interface{} { return &DescribeVpcClassicLinkResult{} },
+	"DescribeVpcClassicLinkDnsSupport":                                func() interface{} { return &DescribeVpcClassicLinkDnsSupportResult{} },
+	"DescribeVpcEncryptionControls":                                   func() interface{} { return &DescribeVpcEncryptionControlsResult{} },
+	"DescribeVpcEndpointAssociations":                                 func() interface{} { return &DescribeVpcEndpointAssociationsResult{} },
+	"DescribeVpcEndpointConnectionNotifications":                      func() interface{} { return &DescribeVpcEndpointConnectionNotificationsResult{} },
+	"DescribeVpcEndpointConnections":                                  func() interface{} { return &DescribeVpcEndpointConnectionsResult{} },
+	"DescribeVpcEndpointServiceConfigurations":                        func() interface{} { return &DescribeVpcEndpointServiceConfigurationsResult{} },
+	"DescribeVpcEndpointServicePermissions":                           func() interface{} { return &DescribeVpcEndpointServicePermissionsResult{} },
+	"DescribeVpcEndpointServices":                                     func() interface{} { return &DescribeVpcEndpointServicesResult{} },
+	"DescribeVpcEndpoints":                                            func() interface{} { return &DescribeVpcEndpointsResult{} },
+	"DescribeVpcPeeringConnections":                                   func() interface{} { return &DescribeVpcPeeringConnectionsResult{} },
+	"DescribeVpcs":                                                    func() interface{} { return &DescribeVpcsResult{} },
+	"DescribeVpnConcentrators":                                        func() interface{} { return &DescribeVpnConcentratorsResult{} },
+	"DescribeVpnConnections":                                          func() interface{} { return &DescribeVpnConnectionsResult{} },
+	"DescribeVpnGateways":                                             func() interface{} { return &DescribeVpnGatewaysResult{} },
+	"DetachClassicLinkVpc":                                            func() interface{} { return &DetachClassicLinkVpcResult{} },
+	"DetachVerifiedAccessTrustProvider":                               func() interface{} { return &DetachVerifiedAccessTrustProviderResult{} },
+	"DetachVolume":                                                    func() interface{} { return &VolumeAttachment{} },
+	"DisableAddressTransfer":                                          func() interface{} { return &DisableAddressTransferResult{} },
+	"DisableAllowedImagesSettings":                                    func() interface{} { return &DisableAllowedImagesSettingsResult{} },
+	"DisableAwsNetworkPerformanceMetricSubscription":                  func() interface{} { return &DisableAwsNetworkPerformanceMetricSubscriptionResult{} },
+	"DisableCapacityManager":                                          func() interface{} { return &DisableCapacityManagerResult{} },
+	"DisableEbsEncryptionByDefault":                                   func() interface{} { return &DisableEbsEncryptionByDefaultResult{} },
+	"DisableFastLaunch":                                               func() interface{} { return &DisableFastLaunchResult{} },
+	"DisableFastSnapshotRestores":                                     func() interface{} { return &DisableFastSnapshotRestoresResult{} },
+	"DisableImage":                                                    func() interface{} { return &DisableImageResult{} },
+	"DisableImageBlockPublicAccess":                                   func() interface{} { return &DisableImageBlockPublicAccessResult{} },
+	"DisableImageDeprecation":                                         func() interface{} { return &DisableImageDeprecationResult{} },
+	"DisableImageDeregistrationProtection":                            func() interface{} { return &DisableImageDeregistrationProtectionResult{} },
+	"DisableInstanceSqlHaStandbyDetections":                           func() interface{} { return &DisableInstanceSqlHaStandbyDetectionsResult{} },
+	"DisableIpamOrganizationAdminAccount":                             func() interface{} { return &DisableIpamOrganizationAdminAccountResult{} },
+	"DisableIpamPolicy":                                               func() interface{} { return &DisableIpamPolicyResult{} },
+	"DisableRouteServerPropagation":                                   func() interface{} { return &DisableRouteServerPropagationResult{} },
+	"DisableSerialConsoleAccess":                                      func() interface{} { return &DisableSerialConsoleAccessResult{} },
+	"DisableSnapshotBlockPublicAccess":                                func() interface{} { return &DisableSnapshotBlockPublicAccessResult{} },
+	"DisableTransitGatewayRouteTablePropagation":                      func() interface{} { return &DisableTransitGatewayRouteTablePropagationResult{} },
+	"DisableVpcClassicLink":                                           func() interface{} { return &DisableVpcClassicLinkResult{} },
+	"DisableVpcClassicLinkDnsSupport":                                 func() interface{} { return &DisableVpcClassicLinkDnsSupportResult{} },
+	"DisassociateCapacityReservationBillingOwner":                     func() interface{} { return &DisassociateCapacityReservationBillingOwnerResult{} },
+	"DisassociateClientVpnTargetNetwork":                              func() interface{} { return &DisassociateClientVpnTargetNetworkResult{} },
+	"DisassociateEnclaveCertificateIamRole":                           func() interface{} { return &DisassociateEnclaveCertificateIamRoleResult{} },
+	"DisassociateIamInstanceProfile":                                  func() interface{} { return &DisassociateIamInstanceProfileResult{} },
+	"DisassociateInstanceEventWindow":                                 func() interface{} { return &DisassociateInstanceEventWindowResult{} },
+	"DisassociateIpamByoasn":                                          func() interface{} { return &DisassociateIpamByoasnResult{} },
+	"DisassociateIpamResourceDiscovery":                               func() interface{} { return &DisassociateIpamResourceDiscoveryResult{} },
+	"DisassociateNatGatewayAddress":                                   func() interface{} { return &DisassociateNatGatewayAddressResult{} },
+	"DisassociateRouteServer":                                         func() interface{} { return &DisassociateRouteServerResult{} },
+	"DisassociateSecurityGroupVpc":                                    func() interface{} { return &DisassociateSecurityGroupVpcResult{} },
+	"DisassociateSubnetCidrBlock":                                     func() interface{} { return &DisassociateSubnetCidrBlockResult{} },
+	"DisassociateTransitGatewayMulticastDomain":                       func() interface{} { return &DisassociateTransitGatewayMulticastDomainResult{} },
+	"DisassociateTransitGatewayPolicyTable":                           func() interface{} { return &DisassociateTransitGatewayPolicyTableResult{} },
+	"DisassociateTransitGatewayRouteTable":                            func() interface{} { return &DisassociateTransitGatewayRouteTableResult{} },
+	"DisassociateTrunkInterface":                                      func() interface{} { return &DisassociateTrunkInterfaceResult{} },
+	"DisassociateVpcCidrBlock":                                        func() interface{} { return &DisassociateVpcCidrBlockResult{} },
+	"EnableAddressTransfer":                                           func() interface{} { return &EnableAddressTransferResult{} },
+	"EnableAllowedImagesSettings":                                     func() interface{} { return &EnableAllowedImagesSettingsResult{} },
+	"EnableAwsNetworkPerformanceMetricSubscription":                   func() interface{} { return &EnableAwsNetworkPerformanceMetricSubscriptionResult{} },
+	"EnableCapacityManager":                                           func() interface{} { return &EnableCapacityManagerResult{} },
+	"EnableEbsEncryptionByDefault":                                    func() interface{} { return &EnableEbsEncryptionByDefaultResult{} },
+	"EnableFastLaunch":                                                func() interface{} { return &EnableFastLaunchResult{} },
+	"EnableFastSnapshotRestores":                                      func() interface{} { return &EnableFastSnapshotRestoresResult{} },
+	"EnableImage":                                                     func() interface{} { return &EnableImageResult{} },
+	"EnableImageBlockPublicAccess":                                    func() interface{} { return &EnableImageBlockPublicAccessResult{} },
+	"EnableImageDeprecation":                                          func() interface{} { return &EnableImageDeprecationResult{} },
+	"EnableImageDeregistrationProtection":                             func() interface{} { return &EnableImageDeregistrationProtectionResult{} },
+	"EnableInstanceSqlHaStandbyDetections":                            func() interface{} { return &EnableInstanceSqlHaStandbyDetectionsResult{} },
+	"EnableIpamOrganizationAdminAccount":                              func() interface{} { return &EnableIpamOrganizationAdminAccountResult{} },
+	"EnableIpamPolicy":                                                func() interface{} { return &EnableIpamPolicyResult{} },
+	"EnableReachabilityAnalyzerOrganizationSharing":                   func() interface{} { return &EnableReachabilityAnalyzerOrganizationSharingResult{} },
+	"EnableRouteServerPropagation":                                    func() interface{} { return &EnableRouteServerPropagationResult{} },
+	"EnableSerialConsoleAccess":                                       func() interface{} { return &EnableSerialConsoleAccessResult{} },
+	"EnableSnapshotBlockPublicAccess":                                 func() interface{} { return &EnableSnapshotBlockPublicAccessResult{} },
+	"EnableTransitGatewayRouteTablePropagation":                       func() interface{} { return &EnableTransitGatewayRouteTablePropagationResult{} },
+	"EnableVpcClassicLink":                                            func() interface{} { return &EnableVpcClassicLinkResult{} },
+	"EnableVpcClassicLinkDnsSupport":                                  func() interface{} { return &EnableVpcClassicLinkDnsSupportResult{} },
+	"ExportClientVpnClientCertificateRevocationList":                  func() interface{} { return &ExportClientVpnClientCertificateRevocationListResult{} },
+	"ExportClientVpnClientConfiguration":                              func() interface{} { return &ExportClientVpnClientConfigurationResult{} },
+	"ExportImage":                                                     func() interface{} { return &ExportImageResult{} },
+	"ExportTransitGatewayRoutes":                                      func() interface{} { return &ExportTransitGatewayRoutesResult{} },
+	"ExportVerifiedAccessInstanceClientConfiguration":                 func() interface{} { return &ExportVerifiedAccessInstanceClientConfigurationResult{} },
+	"GetActiveVpnTunnelStatus":                                        func() interface{} { return &GetActiveVpnTunnelStatusResult{} },
+	"GetAllowedImagesSettings":                                        func() interface{} { return &GetAllowedImagesSettingsResult{} },
+	"GetAssociatedEnclaveCertificateIamRoles":                         func() interface{} { return &GetAssociatedEnclaveCertificateIamRolesResult{} },
+	"GetAssociatedIpv6PoolCidrs":                                      func() interface{} { return &GetAssociatedIpv6PoolCidrsResult{} },
+	"GetAwsNetworkPerformanceData":                                    func() interface{} { return &GetAwsNetworkPerformanceDataResult{} },
+	"GetCapacityManagerAttributes":                                    func() interface{} { return &GetCapacityManagerAttributesResult{} },
+	"GetCapacityManagerMetricData":                                    func() interface{} { return &GetCapacityManagerMetricDataResult{} },
+	"GetCapacityManagerMetricDimensions":                              func() interface{} { return &GetCapacityManagerMetricDimensionsResult{} },
+	"GetCapacityReservationUsage":                                     func() interface{} { return &GetCapacityReservationUsageResult{} },
+	"GetCoipPoolUsage":                                                func() interface{} { return &GetCoipPoolUsageResult{} },
+	"GetConsoleOutput":                                                func() interface{} { return &GetConsoleOutputResult{} },
+	"GetConsoleScreenshot":                                            func() interface{} { return &GetConsoleScreenshotResult{} },
+	"GetDeclarativePoliciesReportSummary":                             func() interface{} { return &GetDeclarativePoliciesReportSummaryResult{} },
+	"GetDefaultCreditSpecification":                                   func() interface{} { return &GetDefaultCreditSpecificationResult{} },
+	"GetEbsDefaultKmsKeyId":                                           func() interface{} { return &GetEbsDefaultKmsKeyIdResult{} },
+	"GetEbsEncryptionByDefault":                                       func() interface{} { return &GetEbsEncryptionByDefaultResult{} },
+	"GetEnabledIpamPolicy":                                            func() interface{} { return &GetEnabledIpamPolicyResult{} },
+	"GetFlowLogsIntegrationTemplate":                                  func() interface{} { return &GetFlowLogsIntegrationTemplateResult{} },
+	"GetGroupsForCapacityReservation":                                 func() interface{} { return &GetGroupsForCapacityReservationResult{} },
+	"GetHostReservationPurchasePreview":                               func() interface{} { return &GetHostReservationPurchasePreviewResult{} },
+	"GetImageAncestry":                                                func() interface{} { return &GetImageAncestryResult{} },
+	"GetImageBlockPublicAccessState":                                  func() interface{} { return &GetImageBlockPublicAccessStateResult{} },
+	"GetInstanceMetadataDefaults":                                     func() interface{} { return &GetInstanceMetadataDefaultsResult{} },
+	"GetInstanceTpmEkPub":                                             func() interface{} { return &GetInstanceTpmEkPubResult{} },
+	"GetInstanceTypesFromInstanceRequirements":                        func() interface{} { return &GetInstanceTypesFromInstanceRequirementsResult{} },
+	"GetInstanceUefiData":                                             func() interface{} { return &GetInstanceUefiDataResult{} },
+	"GetIpamAddressHistory":                                           func() interface{} { return &GetIpamAddressHistoryResult{} },
+	"GetIpamDiscoveredAccounts":                                       func() interface{} { return &GetIpamDiscoveredAccountsResult{} },
+	"GetIpamDiscoveredPublicAddresses":                                func() interface{} { return &GetIpamDiscoveredPublicAddressesResult{} },
+	"GetIpamDiscoveredResourceCidrs":                                  func() interface{} { return &GetIpamDiscoveredResourceCidrsResult{} },
+	"GetIpamPolicyAllocationRules":                                    func() interface{} { return &GetIpamPolicyAllocationRulesResult{} },
+	"GetIpamPolicyOrganizationTargets":                                func() interface{} { return &GetIpamPolicyOrganizationTargetsResult{} },
+	"GetIpamPoolAllocations":                                          func() interface{} { return &GetIpamPoolAllocationsResult{} },
+	"GetIpamPoolCidrs":                                                func() interface{} { return &GetIpamPoolCidrsResult{} },
+	"GetIpamPrefixListResolverRules":                                  func() interface{} { return &GetIpamPrefixListResolverRulesResult{} },
+	"GetIpamPrefixListResolverVersionEntries":                         func() interface{} { return &GetIpamPrefixListResolverVersionEntriesResult{} },
+	"GetIpamPrefixListResolverVersions":                               func() interface{} { return &GetIpamPrefixListResolverVersionsResult{} },
+	"GetIpamResourceCidrs":                                            func() interface{} { return &GetIpamResourceCidrsResult{} },
+	"GetLaunchTemplateData":                                           func() interface{} { return &GetLaunchTemplateDataResult{} },
+	"GetManagedPrefixListAssociations":                                func() interface{} { return &GetManagedPrefixListAssociationsResult{} },
+	"GetManagedPrefixListEntries":                                     func() interface{} { return &GetManagedPrefixListEntriesResult{} },
+	"GetNetworkInsightsAccessScopeAnalysisFindings":                   func() interface{} { return &GetNetworkInsightsAccessScopeAnalysisFindingsResult{} },
+	"GetNetworkInsightsAccessScopeContent":                            func() interface{} { return &GetNetworkInsightsAccessScopeContentResult{} },
+	"GetPasswordData":                                                 func() interface{} { return &GetPasswordDataResult{} },
+	"GetReservedInstancesExchangeQuote":                               func() interface{} { return &GetReservedInstancesExchangeQuoteResult{} },
+	"GetRouteServerAssociations":                                      func() interface{} { return &GetRouteServerAssociationsResult{} },
+	"GetRouteServerPropagations":                                      func() interface{} { return &GetRouteServerPropagationsResult{} },
+	"GetRouteServerRoutingDatabase":                                   func() interface{} { return &GetRouteServerRoutingDatabaseResult{} },
+	"GetSecurityGroupsForVpc":                                         func() interface{} { return &GetSecurityGroupsForVpcResult{} },
+	"GetSerialConsoleAccessStatus":                                    func() interface{} { return &GetSerialConsoleAccessStatusResult{} },
+	"GetSnapshotBlockPublicAccessState":                               func() interface{} { return &GetSnapshotBlockPublicAccessStateResult{} },
+	"GetSpotPlacementScores":                                          func() interface{} { return &GetSpotPlacementScoresResult{} },
+	"GetSubnetCidrReservations":                                       func() interface{} { return &GetSubnetCidrReservationsResult{} },
+	"GetTransitGatewayAttachmentPropagations":                         func() interface{} { return &GetTransitGatewayAttachmentPropagationsResult{} },
+	"GetTransitGatewayMeteringPolicyEntries":                          func() interface{} { return &GetTransitGatewayMeteringPolicyEntriesResult{} },
+	"GetTransitGatewayMulticastDomainAssociations":                    func() interface{} { return &GetTransitGatewayMulticastDomainAssociationsResult{} },
+	"GetTransitGatewayPolicyTableAssociations":                        func() interface{} { return &GetTransitGatewayPolicyTableAssociationsResult{} },
+	"GetTransitGatewayPolicyTableEntries":                             func() interface{} { return &GetTransitGatewayPolicyTableEntriesResult{} },
+	"GetTransitGatewayPrefixListReferences":                           func() interface{} { return &GetTransitGatewayPrefixListReferencesResult{} },
+	"GetTransitGatewayRouteTableAssociations":                         func() interface{} { return &GetTransitGatewayRouteTableAssociationsResult{} },
+	"GetTransitGatewayRouteTablePropagations":                         func() interface{} { return &GetTransitGatewayRouteTablePropagationsResult{} },
+	"GetVerifiedAccessEndpointPolicy":                                 func() interface{} { return &GetVerifiedAccessEndpointPolicyResult{} },
+	"GetVerifiedAccessEndpointTargets":                                func() interface{} { return &GetVerifiedAccessEndpointTargetsResult{} },
+	"GetVerifiedAccessGroupPolicy":                                    func() interface{} { return &GetVerifiedAccessGroupPolicyResult{} },
+	"GetVpcResourcesBlockingEncryptionEnforcement":                    func() interface{} { return &GetVpcResourcesBlockingEncryptionEnforcementResult{} },
+	"GetVpnConnectionDeviceSampleConfiguration":                       func() interface{} { return &GetVpnConnectionDeviceSampleConfigurationResult{} },
+	"GetVpnConnectionDeviceTypes":                                     func() interface{} { return &GetVpnConnectionDeviceTypesResult{} },
+	"GetVpnTunnelReplacementStatus":                                   func() interface{} { return &GetVpnTunnelReplacementStatusResult{} },
+	"ImportClientVpnClientCertificateRevocationList":                  func() interface{} { return &ImportClientVpnClientCertificateRevocationListResult{} },
+	"ImportImage":                                                     func() interface{} { return &ImportImageResult{} },
+	"ImportInstance":                                                  func() interface{} { return &ImportInstanceResult{} },
+	"ImportKeyPair":                                                   func() interface{} { return &ImportKeyPairResult{} },
+	"ImportSnapshot":                                                  func() interface{} { return &ImportSnapshotResult{} },
+	"ImportVolume":                                                    func() interface{} { return &ImportVolumeResult{} },
+	"ListImagesInRecycleBin":                                          func() interface{} { return &ListImagesInRecycleBinResult{} },
+	"ListSnapshotsInRecycleBin":                                       func() interface{} { return &ListSnapshotsInRecycleBinResult{} },
+	"ListVolumesInRecycleBin":                                         func() interface{} { return &ListVolumesInRecycleBinResult{} },
+	"LockSnapshot":                                                    func() interface{} { return &LockSnapshotResult{} },
+	"ModifyAddressAttribute":                                          func() interface{} { return &ModifyAddressAttributeResult{} },
+	"ModifyAvailabilityZoneGroup":                                     func() interface{} { return &ModifyAvailabilityZoneGroupResult{} },
+	"ModifyCapacityReservation":                                       func() interface{} { return &ModifyCapacityReservationResult{} },
+	"ModifyCapacityReservationFleet":                                  func() interface{} { return &ModifyCapacityReservationFleetResult{} },
+	"ModifyClientVpnEndpoint":                                         func() interface{} { return &ModifyClientVpnEndpointResult{} },
+	"ModifyDefaultCreditSpecification":                                func() interface{} { return &ModifyDefaultCreditSpecificationResult{} },
+	"ModifyEbsDefaultKmsKeyId":                                        func() interface{} { return &ModifyEbsDefaultKmsKeyIdResult{} },
+	"ModifyFleet":                                                     func() interface{} { return &ModifyFleetResult{} },
+	"ModifyFpgaImageAttribute":                                        func() interface{} { return &ModifyFpgaImageAttributeResult{} },
+	"ModifyHosts":                                                     func() interface{} { return &ModifyHostsResult{} },
+	"ModifyInstanceCapacityReservationAttributes":                     func() interface{} { return &ModifyInstanceCapacityReservationAttributesResult{} },
+	"ModifyInstanceConnectEndpoint":                                   func() interface{} { return &ModifyInstanceConnectEndpointResult{} },
+	"ModifyInstanceCpuOptions":                                        func() interface{} { return &ModifyInstanceCpuOptionsResult{} },
+	"ModifyInstanceCreditSpecification":                               func() interface{} { return &ModifyInstanceCreditSpecificationResult{} },
+	"ModifyInstanceEventStartTime":                                    func() interface{} { return &ModifyInstanceEventStartTimeResult{} },
+	"ModifyInstanceEventWindow":                                       func() interface{} { return &ModifyInstanceEventWindowResult{} },
+	"ModifyInstanceMaintenanceOptions":                                func() interface{} { return &ModifyInstanceMaintenanceOptionsResult{} },
+	"ModifyInstanceMetadataDefaults":                                  func() interface{} { return &ModifyInstanceMetadataDefaultsResult{} },
+	"ModifyInstanceMetadataOptions":                                   func() interface{} { return &ModifyInstanceMetadataOptionsResult{} },
+	"ModifyInstanceNetworkPerformanceOptions":                         func() interface{} { return &ModifyInstanceNetworkPerformanceResult{} },
+	"ModifyInstancePlacement":                                         func() interface{} { return &ModifyInstancePlacementResult{} },
+	"ModifyIpam":                                                      func() interface{} { return &ModifyIpamResult{} },
+	"ModifyIpamPolicyAllocationRules":                                 func() interface{} { return &ModifyIpamPolicyAllocationRulesResult{} },
+	"ModifyIpamPool":                                                  func() interface{} { return &ModifyIpamPoolResult{} },
+	"ModifyIpamPrefixListResolver":                                    func() interface{} { return &ModifyIpamPrefixListResolverResult{} },
+	"ModifyIpamPrefixListResolverTarget":                              func() interface{} { return &ModifyIpamPrefixListResolverTargetResult{} },
+	"ModifyIpamResourceCidr":                                          func() interface{} { return &ModifyIpamResourceCidrResult{} },
+	"ModifyIpamResourceDiscovery":                                     func() interface{} { return &ModifyIpamResourceDiscoveryResult{} },
+	"ModifyIpamScope":                                                 func() interface{} { return &ModifyIpamScopeResult{} },
+	"ModifyLaunchTemplate":                                            func() interface{} { return &ModifyLaunchTemplateResult{} },
+	"ModifyLocalGatewayRoute":                                         func() interface{} { return &ModifyLocalGatewayRouteResult{} },
+	"ModifyManagedPrefixList":                                         func() interface{} { return &ModifyManagedPrefixListResult{} },
+	"ModifyPrivateDnsNameOptions":                                     func() interface{} { return &ModifyPrivateDnsNameOptionsResult{} },
+	"ModifyPublicIpDnsNameOptions":                                    func() interface{} { return &ModifyPublicIpDnsNameOptionsResult{} },
+	"ModifyReservedInstances":                                         func() interface{} { return &ModifyReservedInstancesResult{} },
+	"ModifyRouteServer":                                               func() interface{} { return &ModifyRouteServerResult{} },
+	"ModifySecurityGroupRules":                                        func() interface{} { return &ModifySecurityGroupRulesResult{} },
+	"ModifySnapshotTier":                                              func() interface{} { return &ModifySnapshotTierResult{} },
+	"ModifySpotFleetRequest":                                          func() interface{} { return &ModifySpotFleetRequestResponse{} },
+	"ModifyTrafficMirrorFilterNetworkServices":                        func() interface{} { return &ModifyTrafficMirrorFilterNetworkServicesResult{} },
+	"ModifyTrafficMirrorFilterRule":                                   func() interface{} { return &ModifyTrafficMirrorFilterRuleResult{} },
+	"ModifyTrafficMirrorSession":                                      func() interface{} { return &ModifyTrafficMirrorSessionResult{} },
+	"ModifyTransitGateway":                                            func() interface{} { return &ModifyTransitGatewayResult{} },
+	"ModifyTransitGatewayMeteringPolicy":                              func() interface{} { return &ModifyTransitGatewayMeteringPolicyResult{} },
+	"ModifyTransitGatewayPrefixListReference":                         func() interface{} { return &ModifyTransitGatewayPrefixListReferenceResult{} },
+	"ModifyTransitGatewayVpcAttachment":                               func() interface{} { return &ModifyTransitGatewayVpcAttachmentResult{} },
+	"ModifyVerifiedAccessEndpoint":                                    func() interface{} { return &ModifyVerifiedAccessEndpointResult{} },
+	"ModifyVerifiedAccessEndpointPolicy":                              func() interface{} { return &ModifyVerifiedAccessEndpointPolicyResult{} },
+	"ModifyVerifiedAccessGroup":                                       func() interface{} { return &ModifyVerifiedAccessGroupResult{} },
+	"ModifyVerifiedAccessGroupPolicy":                                 func() interface{} { return &ModifyVerifiedAccessGroupPolicyResult{} },
+	"ModifyVerifiedAccessInstance":                                    func() interface{} { return &ModifyVerifiedAccessInstanceResult{} },
+	"ModifyVerifiedAccessInstanceLoggingConfiguration":                func() interface{} { return &ModifyVerifiedAccessInstanceLoggingConfigurationResult{} },
+	"ModifyVerifiedAccessTrustProvider":                               func() interface{} { return &ModifyVerifiedAccessTrustProviderResult{} },
+	"ModifyVolume":                                                    func() interface{} { return &ModifyVolumeResult{} },
+	"ModifyVpcBlockPublicAccessExclusion":                             func() interface{} { return &ModifyVpcBlockPublicAccessExclusionResult{} },
+	"ModifyVpcBlockPublicAccessOptions":                               func() interface{} { return &ModifyVpcBlockPublicAccessOptionsResult{} },
+	"ModifyVpcEncryptionControl":                                      func() interface{} { return &ModifyVpcEncryptionControlResult{} },
+	"ModifyVpcEndpoint":                                               func() interface{} { return &ModifyVpcEndpointResult{} },
+	"ModifyVpcEndpointConnectionNotification":                         func() interface{} { return &ModifyVpcEndpointConnectionNotificationResult{} },
+	"ModifyVpcEndpointServiceConfiguration":                           func() interface{} { return &ModifyVpcEndpointServiceConfigurationResult{} },
+	"ModifyVpcEndpointServicePayerResponsibility":                     func() interface{} { return &ModifyVpcEndpointServicePayerResponsibilityResult{} },
+	"ModifyVpcEndpointServicePermissions":                             func() interface{} { return &ModifyVpcEndpointServicePermissionsResult{} },
+	"ModifyVpcPeeringConnectionOptions":                               func() interface{} { return &ModifyVpcPeeringConnectionOptionsResult{} },
+	"ModifyVpcTenancy":                                                func() interface{} { return &ModifyVpcTenancyResult{} },
+	"ModifyVpnConnection":                                             func() interface{} { return &ModifyVpnConnectionResult{} },
+	"ModifyVpnConnectionOptions":                                      func() interface{} { return &ModifyVpnConnectionOptionsResult{} },
+	"ModifyVpnTunnelCertificate":                                      func() interface{} { return &ModifyVpnTunnelCertificateResult{} },
+	"ModifyVpnTunnelOptions":                                          func() interface{} { return &ModifyVpnTunnelOptionsResult{} },
+	"MonitorInstances":                                                func() interface{} { return &MonitorInstancesResult{} },
+	"MoveAddressToVpc":                                                func() interface{} { return &MoveAddressToVpcResult{} },
+	"MoveByoipCidrToIpam":                                             func() interface{} { return &MoveByoipCidrToIpamResult{} },
+	"MoveCapacityReservationInstances":                                func() interface{} { return &MoveCapacityReservationInstancesResult{} },
+	"ProvisionByoipCidr":                                              func() interface{} { return &ProvisionByoipCidrResult{} },
+	"ProvisionIpamByoasn":                                             func() interface{} { return &ProvisionIpamByoasnResult{} },
+	"ProvisionIpamPoolCidr":                                           func() interface{} { return &ProvisionIpamPoolCidrResult{} },
+	"ProvisionPublicIpv4PoolCidr":                                     func() interface{} { return &ProvisionPublicIpv4PoolCidrResult{} },
+	"PurchaseCapacityBlock":                                           func() interface{} { return &PurchaseCapacityBlockResult{} },
+	"PurchaseCapacityBlockExtension":                                  func() interface{} { return &PurchaseCapacityBlockExtensionResult{} },
+	"PurchaseHostReservation":                                         func() interface{} { return &PurchaseHostReservationResult{} },
+	"PurchaseReservedInstancesOffering":                               func() interface{} { return &PurchaseReservedInstancesOfferingResult{} },
+	"PurchaseScheduledInstances":                                      func() interface{} { return &PurchaseScheduledInstancesResult{} },
+	"RegisterImage":                                                   func() interface{} { return &RegisterImageResult{} },
+	"RegisterInstanceEventNotificationAttributes":                     func() interface{} { return &RegisterInstanceEventNotificationAttributesResult{} },
+	"RegisterTransitGatewayMulticastGroupMembers":                     func() interface{} { return &RegisterTransitGatewayMulticastGroupMembersResult{} },
+	"RegisterTransitGatewayMulticastGroupSources":                     func() interface{} { return &RegisterTransitGatewayMulticastGroupSourcesResult{} },
+	"RejectCapacityReservationBillingOwnership":                       func() interface{} { return &RejectCapacityReservationBillingOwnershipResult{} },
+	"RejectTransitGatewayMulticastDomainAssociations":                 func() interface{} { return &RejectTransitGatewayMulticastDomainAssociationsResult{} },
+	"RejectTransitGatewayPeeringAttachment":                           func() interface{} { return &RejectTransitGatewayPeeringAttachmentResult{} },
+	"RejectTransitGatewayVpcAttachment":                               func() interface{} { return &RejectTransitGatewayVpcAttachmentResult{} },
+	"RejectVpcEndpointConnections":                                    func() interface{} { return &RejectVpcEndpointConnectionsResult{} },
+	"RejectVpcPeeringConnection":                                      func() interface{} { return &RejectVpcPeeringConnectionResult{} },
+	"ReleaseHosts":                                                    func() interface{} { return &ReleaseHostsResult{} },
+	"ReleaseIpamPoolAllocation":                                       func() interface{} { return &ReleaseIpamPoolAllocationResult{} },
+	"ReplaceIamInstanceProfileAssociation":                            func() interface{} { return &ReplaceIamInstanceProfileAssociationResult{} },
+	"ReplaceImageCriteriaInAllowedImagesSettings":                     func() interface{} { return &ReplaceImageCriteriaInAllowedImagesSettingsResult{} },
+	"ReplaceNetworkAclAssociation":                                    func() interface{} { return &ReplaceNetworkAclAssociationResult{} },
+	"ReplaceRouteTableAssociation":                                    func() interface{} { return &ReplaceRouteTableAssociationResult{} },
+	"ReplaceTransitGatewayRoute":                                      func() interface{} { return &ReplaceTransitGatewayRouteResult{} },
+	"ReplaceVpnTunnel":                                                func() interface{} { return &ReplaceVpnTunnelResult{} },
+	"RequestSpotFleet":                                                func() interface{} { return &RequestSpotFleetResponse{} },
+	"RequestSpotInstances":                                            func() interface{} { return &RequestSpotInstancesResult{} },
+	"ResetAddressAttribute":                                           func() interface{} { return &ResetAddressAttributeResult{} },
+	"ResetEbsDefaultKmsKeyId":                                         func() interface{} { return &ResetEbsDefaultKmsKeyIdResult{} },
+	"ResetFpgaImageAttribute":                                         func() interface{} { return &ResetFpgaImageAttributeResult{} },
+	"RestoreAddressToClassic":                                         func() interface{} { return &RestoreAddressToClassicResult{} },
+	"RestoreImageFromRecycleBin":                                      func() interface{} { return &RestoreImageFromRecycleBinResult{} },
+	"RestoreManagedPrefixListVersion":                                 func() interface{} { return &RestoreManagedPrefixListVersionResult{} },
+	"RestoreSnapshotFromRecycleBin":                                   func() interface{} { return &RestoreSnapshotFromRecycleBinResult{} },
+	"RestoreSnapshotTier":                                             func() interface{} { return &RestoreSnapshotTierResult{} },
+	"RestoreVolumeFromRecycleBin":                                     func() interface{} { return &RestoreVolumeFromRecycleBinResult{} },
+	"RevokeClientVpnIngress":                                          func() interface{} { return &RevokeClientVpnIngressResult{} },
+	"RevokeSecurityGroupEgress":                                       func() interface{} { return &RevokeSecurityGroupEgressResult{} },
+	"RevokeSecurityGroupIngress":                                      func() interface{} { return &RevokeSecurityGroupIngressResult{} },
+	"RunInstances":                                                    func() interface{} { return &Reservation{} },
+	"RunScheduledInstances":                                           func() interface{} { return &RunScheduledInstancesResult{} },
+	"SearchLocalGatewayRoutes":                                        func() interface{} { return &SearchLocalGatewayRoutesResult{} },
+	"SearchTransitGatewayMulticastGroups":                             func() interface{} { return &SearchTransitGatewayMulticastGroupsResult{} },
+	"SearchTransitGatewayRoutes":                                      func() interface{} { return &SearchTransitGatewayRoutesResult{} },
+	"StartDeclarativePoliciesReport":                                  func() interface{} { return &StartDeclarativePoliciesReportResult{} },
+	"StartInstances":                                                  func() interface{} { return &StartInstancesResult{} },
+	"StartNetworkInsightsAccessScopeAnalysis":                         func() interface{} { return &StartNetworkInsightsAccessScopeAnalysisResult{} },
+	"StartNetworkInsightsAnalysis":                                    func() interface{} { return &StartNetworkInsightsAnalysisResult{} },
+	"StartVpcEndpointServicePrivateDnsVerification":                   func() interface{} { return &StartVpcEndpointServicePrivateDnsVerificationResult{} },
+	"StopInstances":                                                   func() interface{} { return &StopInstancesResult{} },
+	"TerminateClientVpnConnections":                                   func() interface{} { return &TerminateClientVpnConnectionsResult{} },
+	"TerminateInstances":                                              func() interface{} { return &TerminateInstancesResult{} },
+	"UnassignIpv6Addresses":                                           func() interface{} { return &UnassignIpv6AddressesResult{} },
+	"UnassignPrivateNatGatewayAddress":                                func() interface{} { return &UnassignPrivateNatGatewayAddressResult{} },
+	"UnlockSnapshot":                                                  func() interface{} { return &UnlockSnapshotResult{} },
+	"UnmonitorInstances":                                              func() interface{} { return &UnmonitorInstancesResult{} },
+	"UpdateCapacityManagerOrganizationsAccess":                        func() interface{} { return &UpdateCapacityManagerOrganizationsAccessResult{} },
+	"UpdateInterruptibleCapacityReservationAllocation":                func() interface{} { return &UpdateInterruptibleCapacityReservationAllocationResult{} },
+	"UpdateSecurityGroupRuleDescriptionsEgress":                       func() interface{} { return &UpdateSecurityGroupRuleDescriptionsEgressResult{} },
+	"UpdateSecurityGroupRuleDescriptionsIngress":                      func() interface{} { return &UpdateSecurityGroupRuleDescriptionsIngressResult{} },
+	"WithdrawByoipCidr":                                               func() interface{} { return &WithdrawByoipCidrResult{} },
+}
+
 // Enum type aliases
 
 type AcceleratorManufacturer string
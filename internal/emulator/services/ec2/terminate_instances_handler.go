@@ -7,6 +7,7 @@ import (
 
 	"github.com/robmorgan/infraspec/internal/emulator/core"
 	"github.com/robmorgan/infraspec/internal/emulator/helpers"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 )
 
 func (s *EC2Service) terminateInstances(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
@@ -47,6 +48,11 @@ func (s *EC2Service) terminateInstances(ctx context.Context, params map[string]i
 		s.state.Set(fmt.Sprintf("ec2:instances:%s", instanceId), &instance)
 		rs.mu.Unlock()
 
+		// A terminating instance no longer holds its subnet and security groups
+		if err := s.unregisterResource("instance", instanceId); err != nil {
+			logging.Warnf("ec2", "Warning: failed to unregister instance %s from graph: %v", instanceId, err)
+		}
+
 		stateChanges = append(stateChanges, InstanceStateChange{
 			InstanceId:    &instanceId,
 			CurrentState:  instance.State,
@@ -0,0 +1,398 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+	"github.com/robmorgan/infraspec/internal/emulator/graph"
+	"github.com/robmorgan/infraspec/internal/emulator/helpers"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
+)
+
+const (
+	vpcEndpointTypeGateway             = "Gateway"
+	vpcEndpointTypeInterface           = "Interface"
+	vpcEndpointTypeGatewayLoadBalancer = "GatewayLoadBalancer"
+
+	// defaultVpcEndpointPolicy is the full access policy AWS gives Gateway and Interface
+	// endpoints created without a policy
+	defaultVpcEndpointPolicy = `{"Version":"2008-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}]}`
+)
+
+func (s *EC2Service) createVpcEndpoint(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	vpcId, ok := params["VpcId"].(string)
+	if !ok || vpcId == "" {
+		return s.errorResponse(400, "MissingParameter", "VpcId is required"), nil
+	}
+
+	serviceName, ok := params["ServiceName"].(string)
+	if !ok || serviceName == "" {
+		return s.errorResponse(400, "MissingParameter", "ServiceName is required"), nil
+	}
+
+	endpointType := getStringParamValue(params, "VpcEndpointType", vpcEndpointTypeGateway)
+	switch endpointType {
+	case vpcEndpointTypeGateway, vpcEndpointTypeInterface, vpcEndpointTypeGatewayLoadBalancer:
+	default:
+		return s.errorResponse(400, "InvalidParameterValue", fmt.Sprintf("Value (%s) for parameter VpcEndpointType is invalid", endpointType)), nil
+	}
+
+	var vpc Vpc
+	if err := s.state.Get(fmt.Sprintf("ec2:vpcs:%s", vpcId), &vpc); err != nil {
+		return s.errorResponse(400, "InvalidVpcID.NotFound", fmt.Sprintf("The vpc ID '%s' does not exist", vpcId)), nil
+	}
+
+	// Gateway endpoints are reached through route tables, the other types through network
+	// interfaces in subnets
+	routeTableIds := s.parseIndexedParams(params, "RouteTableId")
+	subnetIds := s.parseSubnetIds(params)
+	groupIds := s.parseSecurityGroupIds(params)
+	if endpointType == vpcEndpointTypeGateway {
+		if len(subnetIds) > 0 || len(groupIds) > 0 {
+			return s.errorResponse(400, "InvalidParameter", "Subnets and security groups are not supported for Gateway endpoints"), nil
+		}
+	} else if len(routeTableIds) > 0 {
+		return s.errorResponse(400, "InvalidParameter", fmt.Sprintf("Route tables are not supported for %s endpoints", endpointType)), nil
+	}
+
+	if errResp := s.validateVpcEndpointAssociations(vpcId, routeTableIds, subnetIds); errResp != nil {
+		return errResp, nil
+	}
+
+	// Without security groups, an Interface endpoint uses the default security group of its VPC
+	if endpointType == vpcEndpointTypeInterface && len(groupIds) == 0 {
+		if defaultGroupId := s.defaultSecurityGroupId(vpcId); defaultGroupId != "" {
+			groupIds = []string{defaultGroupId}
+		}
+	}
+	groups, errResp := s.vpcEndpointGroups(vpcId, groupIds)
+	if errResp != nil {
+		return errResp, nil
+	}
+
+	vpceId := fmt.Sprintf("vpce-%s", uuid.New().String()[:8])
+	tags := s.parseTagSpecifications(params, "vpc-endpoint")
+
+	vpce := VpcEndpoint{
+		VpcEndpointId:     &vpceId,
+		VpcEndpointType:   VpcEndpointType(endpointType),
+		VpcId:             &vpcId,
+		ServiceName:       &serviceName,
+		State:             State("available"),
+		RouteTableIds:     routeTableIds,
+		SubnetIds:         subnetIds,
+		Groups:            groups,
+		PrivateDnsEnabled: helpers.BoolPtr(getStringParamValue(params, "PrivateDnsEnabled", "false") == "true"),
+		RequesterManaged:  helpers.BoolPtr(false),
+		OwnerId:           helpers.StringPtr(emulator.RequestScopeFromContext(ctx).AccountID),
+		CreationTimestamp: helpers.TimePtr(time.Now().UTC()),
+		Tags:              tags,
+	}
+	if endpointType != vpcEndpointTypeGatewayLoadBalancer {
+		vpce.PolicyDocument = helpers.StringPtr(getStringParamValue(params, "PolicyDocument", defaultVpcEndpointPolicy))
+	}
+
+	stateKey := fmt.Sprintf("ec2:vpc-endpoints:%s", vpceId)
+	if err := s.state.Set(stateKey, &vpce); err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to store VPC endpoint"), nil
+	}
+
+	// Also store tags in the separate tag storage for consistency with CreateTags
+	if len(tags) > 0 {
+		s.state.Set(fmt.Sprintf("ec2:tags:%s", vpceId), tags)
+	}
+
+	// Register the endpoint in the relationship graph, in its VPC and associated with its
+	// route tables, subnets and security groups
+	s.registerResource("vpc-endpoint", vpceId, map[string]string{
+		"vpcId":       vpcId,
+		"serviceName": serviceName,
+		"type":        endpointType,
+	})
+	if err := s.addRelationship("vpc-endpoint", vpceId, "ec2", "vpc", vpcId, graph.RelContains); err != nil {
+		if s.isStrictMode() {
+			s.state.Delete(stateKey)
+			s.state.Delete(fmt.Sprintf("ec2:tags:%s", vpceId))
+			s.unregisterResource("vpc-endpoint", vpceId)
+			return s.errorResponse(500, "InternalFailure", fmt.Sprintf("Failed to create vpc-endpoint-vpc relationship: %v", err)), nil
+		}
+		logging.Warnf("ec2", "Warning: failed to add vpc-endpoint-vpc relationship in graph: %v", err)
+	}
+	s.addVpcEndpointRelationships(vpceId, routeTableIds, subnetIds, groupIds)
+
+	return s.createVpcEndpointResponse(vpce)
+}
+
+func (s *EC2Service) describeVpcEndpoints(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	vpceIds := s.parseIndexedParams(params, "VpcEndpointId")
+
+	// Extract filters
+	vpcFilter := s.extractFilterValue(params, "vpc-id")
+	serviceNameFilter := s.extractFilterValue(params, "service-name")
+	typeFilter := s.extractFilterValue(params, "vpc-endpoint-type")
+	stateFilter := s.extractFilterValue(params, "vpc-endpoint-state")
+
+	var endpoints []VpcEndpoint
+
+	if len(vpceIds) > 0 {
+		for _, vpceId := range vpceIds {
+			var vpce VpcEndpoint
+			if err := s.state.Get(fmt.Sprintf("ec2:vpc-endpoints:%s", vpceId), &vpce); err != nil {
+				return s.errorResponse(400, "InvalidVpcEndpointId.NotFound", fmt.Sprintf("The Vpc Endpoint Id '%s' does not exist", vpceId)), nil
+			}
+			endpoints = append(endpoints, vpce)
+		}
+	} else {
+		keys, err := s.state.List("ec2:vpc-endpoints:")
+		if err != nil {
+			return s.errorResponse(500, "InternalFailure", "Failed to list VPC endpoints"), nil
+		}
+
+		for _, key := range keys {
+			var vpce VpcEndpoint
+			if err := s.state.Get(key, &vpce); err == nil {
+				endpoints = append(endpoints, vpce)
+			}
+		}
+	}
+
+	filtered := make([]VpcEndpoint, 0, len(endpoints))
+	for _, vpce := range endpoints {
+		if vpcFilter != "" && helpers.StringValue(vpce.VpcId) != vpcFilter {
+			continue
+		}
+		if serviceNameFilter != "" && helpers.StringValue(vpce.ServiceName) != serviceNameFilter {
+			continue
+		}
+		if typeFilter != "" && string(vpce.VpcEndpointType) != typeFilter {
+			continue
+		}
+		if stateFilter != "" && string(vpce.State) != stateFilter {
+			continue
+		}
+
+		// Merge in tags from separate tag storage
+		s.mergeResourceTags(&vpce.Tags, helpers.StringValue(vpce.VpcEndpointId))
+		filtered = append(filtered, vpce)
+	}
+
+	return s.describeVpcEndpointsResponse(filtered)
+}
+
+func (s *EC2Service) modifyVpcEndpoint(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	vpceId, ok := params["VpcEndpointId"].(string)
+	if !ok || vpceId == "" {
+		return s.errorResponse(400, "MissingParameter", "VpcEndpointId is required"), nil
+	}
+
+	// Acquire per-resource lock for atomic operation
+	resourceKey := "vpc-endpoints:" + vpceId
+	rs := s.stateMachine.GetOrCreateResourceState(resourceKey)
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	stateKey := fmt.Sprintf("ec2:vpc-endpoints:%s", vpceId)
+	var vpce VpcEndpoint
+	if err := s.state.Get(stateKey, &vpce); err != nil {
+		return s.errorResponse(400, "InvalidVpcEndpointId.NotFound", fmt.Sprintf("The Vpc Endpoint Id '%s' does not exist", vpceId)), nil
+	}
+	vpcId := helpers.StringValue(vpce.VpcId)
+
+	addRouteTableIds := s.parseIndexedParams(params, "AddRouteTableId")
+	removeRouteTableIds := s.parseIndexedParams(params, "RemoveRouteTableId")
+	addSubnetIds := s.parseIndexedParams(params, "AddSubnetId")
+	removeSubnetIds := s.parseIndexedParams(params, "RemoveSubnetId")
+	addGroupIds := s.parseIndexedParams(params, "AddSecurityGroupId")
+	removeGroupIds := s.parseIndexedParams(params, "RemoveSecurityGroupId")
+
+	if vpce.VpcEndpointType == VpcEndpointType(vpcEndpointTypeGateway) {
+		if len(addSubnetIds) > 0 || len(addGroupIds) > 0 {
+			return s.errorResponse(400, "InvalidParameter", "Subnets and security groups are not supported for Gateway endpoints"), nil
+		}
+	} else if len(addRouteTableIds) > 0 {
+		return s.errorResponse(400, "InvalidParameter", fmt.Sprintf("Route tables are not supported for %s endpoints", vpce.VpcEndpointType)), nil
+	}
+
+	if errResp := s.validateVpcEndpointAssociations(vpcId, addRouteTableIds, addSubnetIds); errResp != nil {
+		return errResp, nil
+	}
+	addGroups, errResp := s.vpcEndpointGroups(vpcId, addGroupIds)
+	if errResp != nil {
+		return errResp, nil
+	}
+
+	vpce.RouteTableIds = updateIDs(vpce.RouteTableIds, addRouteTableIds, removeRouteTableIds)
+	vpce.SubnetIds = updateIDs(vpce.SubnetIds, addSubnetIds, removeSubnetIds)
+
+	groups := slices.DeleteFunc(vpce.Groups, func(group SecurityGroupIdentifier) bool {
+		return slices.Contains(removeGroupIds, helpers.StringValue(group.GroupId))
+	})
+	for _, group := range addGroups {
+		if !slices.ContainsFunc(groups, func(existing SecurityGroupIdentifier) bool {
+			return helpers.StringValue(existing.GroupId) == helpers.StringValue(group.GroupId)
+		}) {
+			groups = append(groups, group)
+		}
+	}
+	vpce.Groups = groups
+
+	if policy, ok := params["PolicyDocument"].(string); ok && policy != "" {
+		vpce.PolicyDocument = &policy
+	} else if getStringParamValue(params, "ResetPolicy", "false") == "true" {
+		vpce.PolicyDocument = helpers.StringPtr(defaultVpcEndpointPolicy)
+	}
+
+	if privateDns, ok := params["PrivateDnsEnabled"].(string); ok && privateDns != "" {
+		vpce.PrivateDnsEnabled = helpers.BoolPtr(privateDns == "true")
+	}
+
+	if err := s.state.Set(stateKey, &vpce); err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to update VPC endpoint"), nil
+	}
+
+	s.removeVpcEndpointRelationships(vpceId, removeRouteTableIds, removeSubnetIds, removeGroupIds)
+	s.addVpcEndpointRelationships(vpceId, addRouteTableIds, addSubnetIds, addGroupIds)
+
+	return s.modifyVpcEndpointResponse()
+}
+
+func (s *EC2Service) deleteVpcEndpoints(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	vpceIds := s.parseIndexedParams(params, "VpcEndpointId")
+	if len(vpceIds) == 0 {
+		return s.errorResponse(400, "MissingParameter", "VpcEndpointId is required"), nil
+	}
+
+	// Endpoints that can't be deleted are reported in the response rather than failing the request
+	unsuccessful := []UnsuccessfulItem{}
+	for _, vpceId := range vpceIds {
+		resourceKey := "vpc-endpoints:" + vpceId
+		rs := s.stateMachine.GetOrCreateResourceState(resourceKey)
+		rs.mu.Lock()
+
+		stateKey := fmt.Sprintf("ec2:vpc-endpoints:%s", vpceId)
+		if !s.state.Exists(stateKey) {
+			rs.mu.Unlock()
+			s.stateMachine.RemoveResourceState(resourceKey)
+			unsuccessful = append(unsuccessful, UnsuccessfulItem{
+				ResourceId: helpers.StringPtr(vpceId),
+				Error: &UnsuccessfulItemError{
+					Code:    helpers.StringPtr("InvalidVpcEndpoint.NotFound"),
+					Message: helpers.StringPtr(fmt.Sprintf("The Vpc Endpoint Id '%s' does not exist", vpceId)),
+				},
+			})
+			continue
+		}
+
+		// Unregister from graph, which removes its VPC, route table, subnet and security group relationships
+		if err := s.unregisterResource("vpc-endpoint", vpceId); err != nil {
+			rs.mu.Unlock()
+			unsuccessful = append(unsuccessful, UnsuccessfulItem{
+				ResourceId: helpers.StringPtr(vpceId),
+				Error: &UnsuccessfulItemError{
+					Code:    helpers.StringPtr("DependencyViolation"),
+					Message: helpers.StringPtr(fmt.Sprintf("Cannot delete VPC endpoint: %v", err)),
+				},
+			})
+			continue
+		}
+
+		s.state.Delete(stateKey)
+		s.state.Delete(fmt.Sprintf("ec2:tags:%s", vpceId))
+		rs.mu.Unlock()
+		s.stateMachine.RemoveResourceState(resourceKey)
+	}
+
+	return s.deleteVpcEndpointsResponse(unsuccessful)
+}
+
+// validateVpcEndpointAssociations checks that the route tables and subnets exist and are in
+// the endpoint's VPC, returning the error response if they aren't
+func (s *EC2Service) validateVpcEndpointAssociations(vpcId string, routeTableIds, subnetIds []string) *emulator.AWSResponse {
+	for _, rtbId := range routeTableIds {
+		var rtb RouteTable
+		if err := s.state.Get(fmt.Sprintf("ec2:route-tables:%s", rtbId), &rtb); err != nil {
+			return s.errorResponse(400, "InvalidRouteTableID.NotFound", fmt.Sprintf("The routeTable ID '%s' does not exist", rtbId))
+		}
+		if helpers.StringValue(rtb.VpcId) != vpcId {
+			return s.errorResponse(400, "InvalidParameter", fmt.Sprintf("Route table %s does not belong to VPC %s", rtbId, vpcId))
+		}
+	}
+
+	for _, subnetId := range subnetIds {
+		var subnet Subnet
+		if err := s.state.Get(fmt.Sprintf("ec2:subnets:%s", subnetId), &subnet); err != nil {
+			return s.errorResponse(400, "InvalidSubnetID.NotFound", fmt.Sprintf("The subnet ID '%s' does not exist", subnetId))
+		}
+		if helpers.StringValue(subnet.VpcId) != vpcId {
+			return s.errorResponse(400, "InvalidParameter", fmt.Sprintf("Subnet %s does not belong to VPC %s", subnetId, vpcId))
+		}
+	}
+
+	return nil
+}
+
+// vpcEndpointGroups looks up the security groups for an endpoint in the VPC, returning the
+// error response if one doesn't exist or is in another VPC
+func (s *EC2Service) vpcEndpointGroups(vpcId string, groupIds []string) ([]SecurityGroupIdentifier, *emulator.AWSResponse) {
+	groups := make([]SecurityGroupIdentifier, 0, len(groupIds))
+	for _, groupId := range groupIds {
+		var sg SecurityGroup
+		if err := s.state.Get(fmt.Sprintf("ec2:security-groups:%s", groupId), &sg); err != nil {
+			return nil, s.errorResponse(400, "InvalidGroup.NotFound", fmt.Sprintf("The security group '%s' does not exist", groupId))
+		}
+		if helpers.StringValue(sg.VpcId) != vpcId {
+			return nil, s.errorResponse(400, "InvalidParameter", fmt.Sprintf("Security group %s does not belong to VPC %s", groupId, vpcId))
+		}
+		groups = append(groups, SecurityGroupIdentifier{GroupId: sg.GroupId, GroupName: sg.GroupName})
+	}
+	return groups, nil
+}
+
+// addVpcEndpointRelationships associates an endpoint with route tables, subnets and security groups in the graph
+func (s *EC2Service) addVpcEndpointRelationships(vpceId string, routeTableIds, subnetIds, groupIds []string) {
+	for _, rtbId := range routeTableIds {
+		if err := s.addRelationship("vpc-endpoint", vpceId, "ec2", "route-table", rtbId, graph.RelAssociatedWith); err != nil {
+			logging.Warnf("ec2", "Warning: failed to add vpc-endpoint-route-table relationship in graph: %v", err)
+		}
+	}
+	for _, subnetId := range subnetIds {
+		if err := s.addRelationship("vpc-endpoint", vpceId, "ec2", "subnet", subnetId, graph.RelReferences); err != nil {
+			logging.Warnf("ec2", "Warning: failed to add vpc-endpoint-subnet relationship in graph: %v", err)
+		}
+	}
+	for _, groupId := range groupIds {
+		if err := s.addRelationship("vpc-endpoint", vpceId, "ec2", "security-group", groupId, graph.RelReferences); err != nil {
+			logging.Warnf("ec2", "Warning: failed to add vpc-endpoint-security-group relationship in graph: %v", err)
+		}
+	}
+}
+
+// removeVpcEndpointRelationships removes an endpoint's associations with route tables, subnets and security groups from the graph
+func (s *EC2Service) removeVpcEndpointRelationships(vpceId string, routeTableIds, subnetIds, groupIds []string) {
+	for _, rtbId := range routeTableIds {
+		s.removeRelationship("vpc-endpoint", vpceId, "ec2", "route-table", rtbId, graph.RelAssociatedWith)
+	}
+	for _, subnetId := range subnetIds {
+		s.removeRelationship("vpc-endpoint", vpceId, "ec2", "subnet", subnetId, graph.RelReferences)
+	}
+	for _, groupId := range groupIds {
+		s.removeRelationship("vpc-endpoint", vpceId, "ec2", "security-group", groupId, graph.RelReferences)
+	}
+}
+
+// updateIDs returns ids without the removed IDs and with the added IDs it doesn't already have
+func updateIDs(ids, add, remove []string) []string {
+	updated := slices.DeleteFunc(slices.Clone(ids), func(id string) bool {
+		return slices.Contains(remove, id)
+	})
+	for _, id := range add {
+		if !slices.Contains(updated, id) {
+			updated = append(updated, id)
+		}
+	}
+	return updated
+}
@@ -0,0 +1,1238 @@
+package elbv2
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+)
+
+const (
+	loadBalancerKeyPrefix = "elbv2:loadbalancer:"
+	targetGroupKeyPrefix  = "elbv2:targetgroup:"
+	listenerKeyPrefix     = "elbv2:listener:"
+	tagsKeyPrefix         = "elbv2:tags:"
+	attributesKeyPrefix   = "elbv2:attributes:"
+)
+
+// resourceNamePattern matches valid load balancer and target group names: up to 32
+// alphanumeric characters or hyphens, not beginning or ending with a hyphen
+var resourceNamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,30}[a-zA-Z0-9])?$`)
+
+// hostedZoneIDs are the Route 53 hosted zones of load balancers in some regions, keyed by
+// region and then load balancer type. Other regions use those of us-east-1.
+var hostedZoneIDs = map[string]map[string]string{
+	"us-east-1": {"application": "Z35SXDOTRQ7X7K", "network": "Z26RNL4JYFTOTI"},
+	"us-west-2": {"application": "Z1H1FL5HABSF5", "network": "Z18D5FSROUN65G"},
+	"eu-west-1": {"application": "Z32O12XQLNTSW2", "network": "Z2IFOLAFXWLO4F"},
+}
+
+// ELBv2Service implements the Elastic Load Balancing v2 API for Application, Network and
+// Gateway Load Balancers. Load balancers don't serve traffic; targets are reported healthy
+// once their target group receives traffic from a load balancer.
+type ELBv2Service struct {
+	state     emulator.StateManager
+	validator emulator.Validator
+	clock     emulator.Clock
+}
+
+// NewELBv2Service creates a new ELBv2 service instance
+func NewELBv2Service(state emulator.StateManager, validator emulator.Validator) *ELBv2Service {
+	return &ELBv2Service{
+		state:     state,
+		validator: validator,
+		clock:     emulator.SystemClock{},
+	}
+}
+
+// SetClock sets the clock used to timestamp load balancers and target groups
+func (s *ELBv2Service) SetClock(clock emulator.Clock) {
+	s.clock = clock
+}
+
+// ServiceName returns the service identifier
+func (s *ELBv2Service) ServiceName() string {
+	return "elasticloadbalancing"
+}
+
+// Protocol returns the AWS protocol the service speaks
+func (s *ELBv2Service) Protocol() emulator.ProtocolType {
+	return emulator.ProtocolQuery
+}
+
+// SupportedActions returns the list of AWS API actions this service handles.
+// Used by the router to determine which service handles a given Query Protocol request.
+// DescribeTags is omitted because EC2 registers it; ELBv2 requests for it are routed by
+// their signing name instead.
+func (s *ELBv2Service) SupportedActions() []string {
+	return []string{
+		"AddTags",
+		"CreateListener",
+		"CreateLoadBalancer",
+		"CreateTargetGroup",
+		"DeleteListener",
+		"DeleteLoadBalancer",
+		"DeleteTargetGroup",
+		"DeregisterTargets",
+		"DescribeListeners",
+		"DescribeLoadBalancerAttributes",
+		"DescribeLoadBalancers",
+		"DescribeTargetGroupAttributes",
+		"DescribeTargetGroups",
+		"DescribeTargetHealth",
+		"ModifyLoadBalancerAttributes",
+		"ModifyTargetGroupAttributes",
+		"RegisterTargets",
+		"RemoveTags",
+	}
+}
+
+// HandleRequest routes incoming requests to the appropriate handler
+func (s *ELBv2Service) HandleRequest(ctx context.Context, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	if err := s.validator.ValidateRequest(req); err != nil {
+		return s.errorResponse(400, "ValidationError", err.Error()), nil
+	}
+
+	params, err := s.parseParameters(req)
+	if err != nil {
+		return s.errorResponse(400, "InvalidParameterValue", err.Error()), nil
+	}
+
+	switch req.Action {
+	case "CreateLoadBalancer":
+		return s.createLoadBalancer(ctx, params)
+	case "DescribeLoadBalancers":
+		return s.describeLoadBalancers(ctx, params)
+	case "DeleteLoadBalancer":
+		return s.deleteLoadBalancer(ctx, params)
+	case "DescribeLoadBalancerAttributes":
+		return s.describeAttributes(ctx, params, "LoadBalancerArn", "DescribeLoadBalancerAttributes")
+	case "ModifyLoadBalancerAttributes":
+		return s.modifyAttributes(ctx, params, "LoadBalancerArn", "ModifyLoadBalancerAttributes")
+	case "CreateTargetGroup":
+		return s.createTargetGroup(ctx, params)
+	case "DescribeTargetGroups":
+		return s.describeTargetGroups(ctx, params)
+	case "DeleteTargetGroup":
+		return s.deleteTargetGroup(ctx, params)
+	case "DescribeTargetGroupAttributes":
+		return s.describeAttributes(ctx, params, "TargetGroupArn", "DescribeTargetGroupAttributes")
+	case "ModifyTargetGroupAttributes":
+		return s.modifyAttributes(ctx, params, "TargetGroupArn", "ModifyTargetGroupAttributes")
+	case "CreateListener":
+		return s.createListener(ctx, params)
+	case "DescribeListeners":
+		return s.describeListeners(ctx, params)
+	case "DeleteListener":
+		return s.deleteListener(ctx, params)
+	case "RegisterTargets":
+		return s.registerTargets(ctx, params)
+	case "DeregisterTargets":
+		return s.deregisterTargets(ctx, params)
+	case "DescribeTargetHealth":
+		return s.describeTargetHealth(ctx, params)
+	case "AddTags":
+		return s.addTags(ctx, params)
+	case "RemoveTags":
+		return s.removeTags(ctx, params)
+	case "DescribeTags":
+		return s.describeTags(ctx, params)
+	default:
+		return s.errorResponse(400, "InvalidAction", fmt.Sprintf("Unknown action: %s", req.Action)), nil
+	}
+}
+
+func (s *ELBv2Service) parseParameters(req *emulator.AWSRequest) (map[string]interface{}, error) {
+	if req.Parameters != nil {
+		return req.Parameters, nil
+	}
+
+	values, err := url.ParseQuery(string(req.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]interface{})
+	for key, vals := range values {
+		if len(vals) == 1 {
+			params[key] = vals[0]
+		} else {
+			params[key] = vals
+		}
+	}
+	return params, nil
+}
+
+// ============================================================================
+// Load Balancers
+// ============================================================================
+
+func (s *ELBv2Service) createLoadBalancer(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	name := emulator.GetStringParam(params, "Name", "")
+	if name == "" {
+		return s.errorResponse(400, "ValidationError", "A load balancer name is required"), nil
+	}
+	if !resourceNamePattern.MatchString(name) || strings.HasPrefix(name, "internal-") {
+		return s.errorResponse(400, "ValidationError", fmt.Sprintf("The load balancer name '%s' is not valid", name)), nil
+	}
+	if _, err := s.findLoadBalancerByName(name); err == nil {
+		return s.errorResponse(400, "DuplicateLoadBalancerName", "A load balancer with the same name already exists"), nil
+	}
+
+	lbType := emulator.GetStringParam(params, "Type", "application")
+	if lbType != "application" && lbType != "network" && lbType != "gateway" {
+		return s.errorResponse(400, "ValidationError", fmt.Sprintf("The load balancer type '%s' is not valid", lbType)), nil
+	}
+	scheme := emulator.GetStringParam(params, "Scheme", "internet-facing")
+
+	subnetIds := parseMemberList(params, "Subnets")
+	for i := 1; ; i++ {
+		subnetId := emulator.GetStringParam(params, fmt.Sprintf("SubnetMappings.member.%d.SubnetId", i), "")
+		if subnetId == "" {
+			break
+		}
+		subnetIds = append(subnetIds, subnetId)
+	}
+
+	var vpcId string
+	zones := make([]AvailabilityZone, 0, len(subnetIds))
+	for _, subnetId := range subnetIds {
+		subnet, err := s.loadSubnet(subnetId)
+		if err != nil {
+			return s.errorResponse(400, "SubnetNotFound", fmt.Sprintf("The subnet ID '%s' is not valid", subnetId)), nil
+		}
+		if vpcId == "" {
+			vpcId = subnet.VpcId
+		} else if subnet.VpcId != vpcId {
+			return s.errorResponse(400, "InvalidConfigurationRequest", "The subnets must all be in the same VPC"), nil
+		}
+		zones = append(zones, AvailabilityZone{ZoneName: subnet.AvailabilityZone, SubnetId: subnetId})
+	}
+
+	scope := emulator.RequestScopeFromContext(ctx)
+	id := randomID()
+	lb := LoadBalancer{
+		LoadBalancerArn:       fmt.Sprintf("arn:%s:elasticloadbalancing:%s:%s:loadbalancer/%s/%s/%s", scope.Partition(), scope.Region, scope.AccountID, typeSegment(lbType), name, id),
+		LoadBalancerName:      name,
+		DNSName:               dnsName(name, lbType, scheme, id, scope),
+		CanonicalHostedZoneId: hostedZoneID(scope.Region, lbType),
+		CreatedTime:           s.clock.Now().UTC(),
+		Scheme:                scheme,
+		Type:                  lbType,
+		IpAddressType:         emulator.GetStringParam(params, "IpAddressType", "ipv4"),
+		VpcId:                 vpcId,
+		AvailabilityZones:     zones,
+	}
+	if lbType == "application" {
+		lb.SecurityGroups = parseMemberList(params, "SecurityGroups")
+	}
+
+	if err := s.state.Set(loadBalancerKeyPrefix+lb.LoadBalancerArn, &lb); err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to store load balancer"), nil
+	}
+	if tags := parseTags(params); len(tags) > 0 {
+		if err := s.saveTags(lb.LoadBalancerArn, tags); err != nil {
+			return s.errorResponse(500, "InternalFailure", "Failed to store tags"), nil
+		}
+	}
+
+	return s.successResponse("CreateLoadBalancer", CreateLoadBalancerResult{
+		LoadBalancers: []XMLLoadBalancer{toXMLLoadBalancer(lb)},
+	})
+}
+
+func (s *ELBv2Service) describeLoadBalancers(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	arns := parseMemberList(params, "LoadBalancerArns")
+	names := parseMemberList(params, "Names")
+
+	var lbs []LoadBalancer
+	switch {
+	case len(arns) > 0:
+		for _, lbArn := range arns {
+			lb, err := s.loadLoadBalancer(lbArn)
+			if err != nil {
+				return s.errorResponse(400, "LoadBalancerNotFound", fmt.Sprintf("Load balancer '%s' not found", lbArn)), nil
+			}
+			lbs = append(lbs, *lb)
+		}
+	case len(names) > 0:
+		for _, name := range names {
+			lb, err := s.findLoadBalancerByName(name)
+			if err != nil {
+				return s.errorResponse(400, "LoadBalancerNotFound", fmt.Sprintf("Load balancers '[%s]' not found", name)), nil
+			}
+			lbs = append(lbs, *lb)
+		}
+	default:
+		all, err := s.listLoadBalancers()
+		if err != nil {
+			return s.errorResponse(500, "InternalFailure", "Failed to list load balancers"), nil
+		}
+		lbs = all
+	}
+
+	result := DescribeLoadBalancersResult{LoadBalancers: make([]XMLLoadBalancer, 0, len(lbs))}
+	for _, lb := range lbs {
+		result.LoadBalancers = append(result.LoadBalancers, toXMLLoadBalancer(lb))
+	}
+	return s.successResponse("DescribeLoadBalancers", result)
+}
+
+// deleteLoadBalancer deletes the load balancer and its listeners. Like AWS, deleting a load
+// balancer that doesn't exist succeeds.
+func (s *ELBv2Service) deleteLoadBalancer(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	lbArn := emulator.GetStringParam(params, "LoadBalancerArn", "")
+	if lbArn == "" {
+		return s.errorResponse(400, "ValidationError", "A load balancer ARN is required"), nil
+	}
+
+	listeners, err := s.listListeners(lbArn)
+	if err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to list listeners"), nil
+	}
+	for _, listener := range listeners {
+		s.deleteResource(listenerKeyPrefix, listener.ListenerArn)
+	}
+	s.deleteResource(loadBalancerKeyPrefix, lbArn)
+
+	return s.successResponse("DeleteLoadBalancer", EmptyResult{XMLName: xmlName("DeleteLoadBalancerResult")})
+}
+
+// ============================================================================
+// Target Groups
+// ============================================================================
+
+func (s *ELBv2Service) createTargetGroup(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	name := emulator.GetStringParam(params, "Name", "")
+	if name == "" {
+		return s.errorResponse(400, "ValidationError", "A target group name is required"), nil
+	}
+	if !resourceNamePattern.MatchString(name) {
+		return s.errorResponse(400, "ValidationError", fmt.Sprintf("The target group name '%s' is not valid", name)), nil
+	}
+	if _, err := s.findTargetGroupByName(name); err == nil {
+		return s.errorResponse(400, "DuplicateTargetGroupName", "A target group with the same name already exists"), nil
+	}
+
+	targetType := emulator.GetStringParam(params, "TargetType", "instance")
+	protocol := emulator.GetStringParam(params, "Protocol", "")
+	port := emulator.GetInt32Param(params, "Port", 0)
+	vpcId := emulator.GetStringParam(params, "VpcId", "")
+	if targetType != "lambda" && (protocol == "" || port == 0 || vpcId == "") {
+		return s.errorResponse(400, "ValidationError", "A protocol, port and VPC ID are required for target groups with a target type of "+targetType), nil
+	}
+
+	scope := emulator.RequestScopeFromContext(ctx)
+	tg := TargetGroup{
+		TargetGroupArn:             fmt.Sprintf("arn:%s:elasticloadbalancing:%s:%s:targetgroup/%s/%s", scope.Partition(), scope.Region, scope.AccountID, name, randomID()),
+		TargetGroupName:            name,
+		Protocol:                   protocol,
+		Port:                       port,
+		VpcId:                      vpcId,
+		TargetType:                 targetType,
+		HealthCheckEnabled:         emulator.GetBoolParam(params, "HealthCheckEnabled", true),
+		HealthCheckIntervalSeconds: emulator.GetInt32Param(params, "HealthCheckIntervalSeconds", 30),
+		HealthCheckTimeoutSeconds:  emulator.GetInt32Param(params, "HealthCheckTimeoutSeconds", 5),
+		HealthyThresholdCount:      emulator.GetInt32Param(params, "HealthyThresholdCount", 5),
+		UnhealthyThresholdCount:    emulator.GetInt32Param(params, "UnhealthyThresholdCount", 2),
+		CreatedTime:                s.clock.Now().UTC(),
+	}
+	if targetType != "lambda" {
+		tg.IpAddressType = emulator.GetStringParam(params, "IpAddressType", "ipv4")
+		tg.HealthCheckProtocol = emulator.GetStringParam(params, "HealthCheckProtocol", healthCheckProtocol(protocol))
+		tg.HealthCheckPort = emulator.GetStringParam(params, "HealthCheckPort", "traffic-port")
+	}
+	if tg.HealthCheckProtocol == "HTTP" || tg.HealthCheckProtocol == "HTTPS" || targetType == "lambda" {
+		tg.HealthCheckPath = emulator.GetStringParam(params, "HealthCheckPath", "/")
+		tg.MatcherHttpCode = emulator.GetStringParam(params, "Matcher.HttpCode", "200")
+	}
+
+	if err := s.state.Set(targetGroupKeyPrefix+tg.TargetGroupArn, &tg); err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to store target group"), nil
+	}
+	if tags := parseTags(params); len(tags) > 0 {
+		if err := s.saveTags(tg.TargetGroupArn, tags); err != nil {
+			return s.errorResponse(500, "InternalFailure", "Failed to store tags"), nil
+		}
+	}
+
+	return s.successResponse("CreateTargetGroup", CreateTargetGroupResult{
+		TargetGroups: []XMLTargetGroup{s.toXMLTargetGroup(tg)},
+	})
+}
+
+func (s *ELBv2Service) describeTargetGroups(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	arns := parseMemberList(params, "TargetGroupArns")
+	names := parseMemberList(params, "Names")
+	lbArn := emulator.GetStringParam(params, "LoadBalancerArn", "")
+
+	var tgs []TargetGroup
+	switch {
+	case len(arns) > 0:
+		for _, tgArn := range arns {
+			tg, err := s.loadTargetGroup(tgArn)
+			if err != nil {
+				return s.errorResponse(400, "TargetGroupNotFound", fmt.Sprintf("Target groups '[%s]' not found", tgArn)), nil
+			}
+			tgs = append(tgs, *tg)
+		}
+	case len(names) > 0:
+		for _, name := range names {
+			tg, err := s.findTargetGroupByName(name)
+			if err != nil {
+				return s.errorResponse(400, "TargetGroupNotFound", fmt.Sprintf("Target groups '[%s]' not found", name)), nil
+			}
+			tgs = append(tgs, *tg)
+		}
+	default:
+		if lbArn != "" {
+			if _, err := s.loadLoadBalancer(lbArn); err != nil {
+				return s.errorResponse(400, "LoadBalancerNotFound", fmt.Sprintf("Load balancer '%s' not found", lbArn)), nil
+			}
+		}
+		all, err := s.listTargetGroups()
+		if err != nil {
+			return s.errorResponse(500, "InternalFailure", "Failed to list target groups"), nil
+		}
+		tgs = all
+	}
+
+	result := DescribeTargetGroupsResult{TargetGroups: make([]XMLTargetGroup, 0, len(tgs))}
+	for _, tg := range tgs {
+		xmlTg := s.toXMLTargetGroup(tg)
+		if lbArn != "" && !contains(xmlTg.LoadBalancerArns, lbArn) {
+			continue
+		}
+		result.TargetGroups = append(result.TargetGroups, xmlTg)
+	}
+	return s.successResponse("DescribeTargetGroups", result)
+}
+
+func (s *ELBv2Service) deleteTargetGroup(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	tgArn := emulator.GetStringParam(params, "TargetGroupArn", "")
+	if tgArn == "" {
+		return s.errorResponse(400, "ValidationError", "A target group ARN is required"), nil
+	}
+
+	if lbArns := s.targetGroupLoadBalancers(tgArn); len(lbArns) > 0 {
+		return s.errorResponse(400, "ResourceInUse", fmt.Sprintf("Target group '%s' is currently in use by a listener or a rule", tgArn)), nil
+	}
+	s.deleteResource(targetGroupKeyPrefix, tgArn)
+
+	return s.successResponse("DeleteTargetGroup", EmptyResult{XMLName: xmlName("DeleteTargetGroupResult")})
+}
+
+// ============================================================================
+// Listeners
+// ============================================================================
+
+func (s *ELBv2Service) createListener(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	lbArn := emulator.GetStringParam(params, "LoadBalancerArn", "")
+	lb, err := s.loadLoadBalancer(lbArn)
+	if err != nil {
+		return s.errorResponse(400, "LoadBalancerNotFound", fmt.Sprintf("Load balancer '%s' not found", lbArn)), nil
+	}
+
+	port := emulator.GetInt32Param(params, "Port", 0)
+	if lb.Type != "gateway" && port == 0 {
+		return s.errorResponse(400, "ValidationError", "A listener port is required"), nil
+	}
+	protocol := emulator.GetStringParam(params, "Protocol", "")
+	if protocol == "" && lb.Type == "application" {
+		protocol = "HTTP"
+	}
+
+	existing, err := s.listListeners(lbArn)
+	if err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to list listeners"), nil
+	}
+	for _, listener := range existing {
+		if listener.Port == port {
+			return s.errorResponse(400, "DuplicateListener", "A listener already exists on this port for this load balancer"), nil
+		}
+	}
+
+	actions, errResp := s.parseActions(params)
+	if errResp != nil {
+		return errResp, nil
+	}
+
+	parts := strings.SplitN(lbArn, ":loadbalancer/", 2)
+	listener := Listener{
+		ListenerArn:     fmt.Sprintf("%s:listener/%s/%s", parts[0], parts[len(parts)-1], randomID()),
+		LoadBalancerArn: lbArn,
+		Protocol:        protocol,
+		Port:            port,
+		SslPolicy:       emulator.GetStringParam(params, "SslPolicy", ""),
+		CertificateArns: parseMemberFieldList(params, "Certificates", "CertificateArn"),
+		DefaultActions:  actions,
+	}
+	if listener.SslPolicy == "" && (protocol == "HTTPS" || protocol == "TLS") {
+		listener.SslPolicy = "ELBSecurityPolicy-2016-08"
+	}
+
+	if err := s.state.Set(listenerKeyPrefix+listener.ListenerArn, &listener); err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to store listener"), nil
+	}
+	if tags := parseTags(params); len(tags) > 0 {
+		if err := s.saveTags(listener.ListenerArn, tags); err != nil {
+			return s.errorResponse(500, "InternalFailure", "Failed to store tags"), nil
+		}
+	}
+
+	return s.successResponse("CreateListener", CreateListenerResult{
+		Listeners: []XMLListener{toXMLListener(listener)},
+	})
+}
+
+// parseActions parses the listener's DefaultActions, checking that the target groups they
+// forward to exist
+func (s *ELBv2Service) parseActions(params map[string]interface{}) ([]Action, *emulator.AWSResponse) {
+	var actions []Action
+	for i := 1; ; i++ {
+		prefix := fmt.Sprintf("DefaultActions.member.%d.", i)
+		actionType := emulator.GetStringParam(params, prefix+"Type", "")
+		if actionType == "" {
+			break
+		}
+
+		action := Action{
+			Type:           actionType,
+			Order:          emulator.GetInt32Param(params, prefix+"Order", 0),
+			TargetGroupArn: emulator.GetStringParam(params, prefix+"TargetGroupArn", ""),
+		}
+		// A forward action may name its target group in a ForwardConfig instead
+		if action.TargetGroupArn == "" {
+			action.TargetGroupArn = emulator.GetStringParam(params, prefix+"ForwardConfig.TargetGroups.member.1.TargetGroupArn", "")
+		}
+
+		switch actionType {
+		case "forward":
+			if action.TargetGroupArn == "" {
+				return nil, s.errorResponse(400, "ValidationError", "A target group ARN must be specified for a forward action")
+			}
+			if _, err := s.loadTargetGroup(action.TargetGroupArn); err != nil {
+				return nil, s.errorResponse(400, "TargetGroupNotFound", fmt.Sprintf("Target groups '[%s]' not found", action.TargetGroupArn))
+			}
+		case "fixed-response":
+			action.FixedResponseConfig = &FixedResponseConfig{
+				StatusCode:  emulator.GetStringParam(params, prefix+"FixedResponseConfig.StatusCode", ""),
+				ContentType: emulator.GetStringParam(params, prefix+"FixedResponseConfig.ContentType", ""),
+				MessageBody: emulator.GetStringParam(params, prefix+"FixedResponseConfig.MessageBody", ""),
+			}
+		case "redirect":
+			action.RedirectConfig = &RedirectConfig{
+				StatusCode: emulator.GetStringParam(params, prefix+"RedirectConfig.StatusCode", ""),
+				Protocol:   emulator.GetStringParam(params, prefix+"RedirectConfig.Protocol", "#{protocol}"),
+				Port:       emulator.GetStringParam(params, prefix+"RedirectConfig.Port", "#{port}"),
+				Host:       emulator.GetStringParam(params, prefix+"RedirectConfig.Host", "#{host}"),
+				Path:       emulator.GetStringParam(params, prefix+"RedirectConfig.Path", "/#{path}"),
+				Query:      emulator.GetStringParam(params, prefix+"RedirectConfig.Query", "#{query}"),
+			}
+		}
+		actions = append(actions, action)
+	}
+
+	if len(actions) == 0 {
+		return nil, s.errorResponse(400, "ValidationError", "At least one default action is required")
+	}
+	return actions, nil
+}
+
+func (s *ELBv2Service) describeListeners(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	arns := parseMemberList(params, "ListenerArns")
+	lbArn := emulator.GetStringParam(params, "LoadBalancerArn", "")
+
+	var listeners []Listener
+	switch {
+	case len(arns) > 0:
+		for _, listenerArn := range arns {
+			var listener Listener
+			if err := s.state.Get(listenerKeyPrefix+listenerArn, &listener); err != nil {
+				return s.errorResponse(400, "ListenerNotFound", fmt.Sprintf("Listener '%s' not found", listenerArn)), nil
+			}
+			listeners = append(listeners, listener)
+		}
+	case lbArn != "":
+		if _, err := s.loadLoadBalancer(lbArn); err != nil {
+			return s.errorResponse(400, "LoadBalancerNotFound", fmt.Sprintf("Load balancer '%s' not found", lbArn)), nil
+		}
+		all, err := s.listListeners(lbArn)
+		if err != nil {
+			return s.errorResponse(500, "InternalFailure", "Failed to list listeners"), nil
+		}
+		listeners = all
+	default:
+		return s.errorResponse(400, "ValidationError", "A load balancer ARN or listener ARNs must be specified"), nil
+	}
+
+	result := DescribeListenersResult{Listeners: make([]XMLListener, 0, len(listeners))}
+	for _, listener := range listeners {
+		result.Listeners = append(result.Listeners, toXMLListener(listener))
+	}
+	return s.successResponse("DescribeListeners", result)
+}
+
+func (s *ELBv2Service) deleteListener(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	listenerArn := emulator.GetStringParam(params, "ListenerArn", "")
+	if !s.state.Exists(listenerKeyPrefix + listenerArn) {
+		return s.errorResponse(400, "ListenerNotFound", fmt.Sprintf("Listener '%s' not found", listenerArn)), nil
+	}
+	s.deleteResource(listenerKeyPrefix, listenerArn)
+
+	return s.successResponse("DeleteListener", EmptyResult{XMLName: xmlName("DeleteListenerResult")})
+}
+
+// ============================================================================
+// Targets
+// ============================================================================
+
+func (s *ELBv2Service) registerTargets(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	tgArn := emulator.GetStringParam(params, "TargetGroupArn", "")
+	tg, err := s.loadTargetGroup(tgArn)
+	if err != nil {
+		return s.errorResponse(400, "TargetGroupNotFound", fmt.Sprintf("Target groups '[%s]' not found", tgArn)), nil
+	}
+
+	targets := parseTargets(params)
+	if len(targets) == 0 {
+		return s.errorResponse(400, "ValidationError", "At least one target must be specified"), nil
+	}
+	for _, target := range targets {
+		if tg.TargetType == "instance" && !s.state.Exists("ec2:instances:"+target.Id) {
+			return s.errorResponse(400, "InvalidTarget", fmt.Sprintf("The following targets are not valid instances: '%s'", target.Id)), nil
+		}
+		if i := findTarget(tg.Targets, target, tg.Port); i >= 0 {
+			tg.Targets[i] = target
+		} else {
+			tg.Targets = append(tg.Targets, target)
+		}
+	}
+
+	if err := s.state.Set(targetGroupKeyPrefix+tgArn, tg); err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to store target group"), nil
+	}
+	return s.successResponse("RegisterTargets", EmptyResult{XMLName: xmlName("RegisterTargetsResult")})
+}
+
+func (s *ELBv2Service) deregisterTargets(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	tgArn := emulator.GetStringParam(params, "TargetGroupArn", "")
+	tg, err := s.loadTargetGroup(tgArn)
+	if err != nil {
+		return s.errorResponse(400, "TargetGroupNotFound", fmt.Sprintf("Target groups '[%s]' not found", tgArn)), nil
+	}
+
+	for _, target := range parseTargets(params) {
+		i := findTarget(tg.Targets, target, tg.Port)
+		if i < 0 {
+			return s.errorResponse(400, "InvalidTarget", fmt.Sprintf("The target '%s' is not registered with the target group", target.Id)), nil
+		}
+		tg.Targets = append(tg.Targets[:i], tg.Targets[i+1:]...)
+	}
+
+	if err := s.state.Set(targetGroupKeyPrefix+tgArn, tg); err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to store target group"), nil
+	}
+	return s.successResponse("DeregisterTargets", EmptyResult{XMLName: xmlName("DeregisterTargetsResult")})
+}
+
+// describeTargetHealth reports registered targets as healthy once a load balancer forwards to
+// their target group, and as unused before then or while their instance isn't running
+func (s *ELBv2Service) describeTargetHealth(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	tgArn := emulator.GetStringParam(params, "TargetGroupArn", "")
+	tg, err := s.loadTargetGroup(tgArn)
+	if err != nil {
+		return s.errorResponse(400, "TargetGroupNotFound", fmt.Sprintf("Target groups '[%s]' not found", tgArn)), nil
+	}
+
+	targets := tg.Targets
+	if requested := parseTargets(params); len(requested) > 0 {
+		targets = requested
+	}
+	inUse := len(s.targetGroupLoadBalancers(tgArn)) > 0
+
+	result := DescribeTargetHealthResult{TargetHealthDescriptions: make([]XMLTargetHealthDescription, 0, len(targets))}
+	for _, target := range targets {
+		if target.Port == 0 {
+			target.Port = tg.Port
+		}
+		description := XMLTargetHealthDescription{Target: target}
+		if tg.TargetType != "lambda" {
+			description.HealthCheckPort = strconv.Itoa(int(target.Port))
+			if tg.HealthCheckPort != "" && tg.HealthCheckPort != "traffic-port" {
+				description.HealthCheckPort = tg.HealthCheckPort
+			}
+		}
+
+		switch {
+		case findTarget(tg.Targets, target, tg.Port) < 0:
+			description.TargetHealth = XMLTargetHealth{State: "unused", Reason: "Target.NotRegistered", Description: "Target is not registered to the target group"}
+		case !inUse:
+			description.TargetHealth = XMLTargetHealth{State: "unused", Reason: "Target.NotInUse", Description: "Target group is not configured to receive traffic from the load balancer"}
+		case tg.TargetType == "instance" && s.instanceState(target.Id) != "running":
+			description.TargetHealth = XMLTargetHealth{State: "unused", Reason: "Target.InvalidState", Description: fmt.Sprintf("Target is in the %s state", s.instanceState(target.Id))}
+		default:
+			description.TargetHealth = XMLTargetHealth{State: "healthy"}
+		}
+		result.TargetHealthDescriptions = append(result.TargetHealthDescriptions, description)
+	}
+
+	return s.successResponse("DescribeTargetHealth", result)
+}
+
+// ============================================================================
+// Tags and Attributes
+// ============================================================================
+
+func (s *ELBv2Service) addTags(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	arns := parseMemberList(params, "ResourceArns")
+	tags := parseTags(params)
+	for _, resourceArn := range arns {
+		if !s.resourceExists(resourceArn) {
+			return s.resourceNotFound(resourceArn), nil
+		}
+		existing := s.loadTags(resourceArn)
+		for _, tag := range tags {
+			existing = setTag(existing, tag)
+		}
+		if err := s.saveTags(resourceArn, existing); err != nil {
+			return s.errorResponse(500, "InternalFailure", "Failed to store tags"), nil
+		}
+	}
+	return s.successResponse("AddTags", EmptyResult{XMLName: xmlName("AddTagsResult")})
+}
+
+func (s *ELBv2Service) removeTags(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	arns := parseMemberList(params, "ResourceArns")
+	keys := parseMemberList(params, "TagKeys")
+	for _, resourceArn := range arns {
+		if !s.resourceExists(resourceArn) {
+			return s.resourceNotFound(resourceArn), nil
+		}
+		var remaining []Tag
+		for _, tag := range s.loadTags(resourceArn) {
+			if !contains(keys, tag.Key) {
+				remaining = append(remaining, tag)
+			}
+		}
+		if err := s.saveTags(resourceArn, remaining); err != nil {
+			return s.errorResponse(500, "InternalFailure", "Failed to store tags"), nil
+		}
+	}
+	return s.successResponse("RemoveTags", EmptyResult{XMLName: xmlName("RemoveTagsResult")})
+}
+
+func (s *ELBv2Service) describeTags(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
+	result := DescribeTagsResult{}
+	for _, resourceArn := range parseMemberList(params, "ResourceArns") {
+		if !s.resourceExists(resourceArn) {
+			return s.resourceNotFound(resourceArn), nil
+		}
+		result.TagDescriptions = append(result.TagDescriptions, XMLTagDescription{
+			ResourceArn: resourceArn,
+			Tags:        s.loadTags(resourceArn),
+		})
+	}
+	return s.successResponse("DescribeTags", result)
+}
+
+// describeAttributes returns the attributes of the load balancer or target group named by the
+// arnParam parameter, with the defaults of any that haven't been modified
+func (s *ELBv2Service) describeAttributes(ctx context.Context, params map[string]interface{}, arnParam, action string) (*emulator.AWSResponse, error) {
+	resourceArn := emulator.GetStringParam(params, arnParam, "")
+	attributes, errResp := s.loadAttributes(resourceArn)
+	if errResp != nil {
+		return errResp, nil
+	}
+	return s.successResponse(action, AttributesResult{XMLName: xmlName(action + "Result"), Attributes: attributes})
+}
+
+func (s *ELBv2Service) modifyAttributes(ctx context.Context, params map[string]interface{}, arnParam, action string) (*emulator.AWSResponse, error) {
+	resourceArn := emulator.GetStringParam(params, arnParam, "")
+	attributes, errResp := s.loadAttributes(resourceArn)
+	if errResp != nil {
+		return errResp, nil
+	}
+
+	for i := 1; ; i++ {
+		prefix := fmt.Sprintf("Attributes.member.%d.", i)
+		key := emulator.GetStringParam(params, prefix+"Key", "")
+		if key == "" {
+			break
+		}
+		value := emulator.GetStringParam(params, prefix+"Value", "")
+		found := false
+		for j := range attributes {
+			if attributes[j].Key == key {
+				attributes[j].Value = value
+				found = true
+			}
+		}
+		if !found {
+			attributes = append(attributes, Attribute{Key: key, Value: value})
+		}
+	}
+
+	if err := s.state.Set(attributesKeyPrefix+resourceArn, attributes); err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to store attributes"), nil
+	}
+	return s.successResponse(action, AttributesResult{XMLName: xmlName(action + "Result"), Attributes: attributes})
+}
+
+func (s *ELBv2Service) loadAttributes(resourceArn string) ([]Attribute, *emulator.AWSResponse) {
+	var defaults []Attribute
+	if lb, err := s.loadLoadBalancer(resourceArn); err == nil {
+		defaults = defaultLoadBalancerAttributes(lb.Type)
+	} else if tg, err := s.loadTargetGroup(resourceArn); err == nil {
+		defaults = defaultTargetGroupAttributes(tg.TargetType)
+	} else {
+		return nil, s.resourceNotFound(resourceArn)
+	}
+
+	var attributes []Attribute
+	if err := s.state.Get(attributesKeyPrefix+resourceArn, &attributes); err != nil {
+		return defaults, nil
+	}
+	return attributes, nil
+}
+
+func defaultLoadBalancerAttributes(lbType string) []Attribute {
+	attributes := []Attribute{
+		{Key: "deletion_protection.enabled", Value: "false"},
+		{Key: "load_balancing.cross_zone.enabled", Value: strconv.FormatBool(lbType == "application")},
+	}
+	if lbType == "application" {
+		attributes = append(attributes,
+			Attribute{Key: "idle_timeout.timeout_seconds", Value: "60"},
+			Attribute{Key: "routing.http2.enabled", Value: "true"},
+			Attribute{Key: "routing.http.drop_invalid_header_fields.enabled", Value: "false"},
+			Attribute{Key: "access_logs.s3.enabled", Value: "false"},
+		)
+	}
+	return attributes
+}
+
+func defaultTargetGroupAttributes(targetType string) []Attribute {
+	if targetType == "lambda" {
+		return []Attribute{{Key: "lambda.multi_value_headers.enabled", Value: "false"}}
+	}
+	return []Attribute{
+		{Key: "deregistration_delay.timeout_seconds", Value: "300"},
+		{Key: "stickiness.enabled", Value: "false"},
+		{Key: "stickiness.type", Value: "lb_cookie"},
+	}
+}
+
+// ============================================================================
+// State Helpers
+// ============================================================================
+
+func (s *ELBv2Service) loadLoadBalancer(lbArn string) (*LoadBalancer, error) {
+	var lb LoadBalancer
+	if err := s.state.Get(loadBalancerKeyPrefix+lbArn, &lb); err != nil {
+		return nil, err
+	}
+	return &lb, nil
+}
+
+func (s *ELBv2Service) listLoadBalancers() ([]LoadBalancer, error) {
+	keys, err := s.state.List(loadBalancerKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	lbs := make([]LoadBalancer, 0, len(keys))
+	for _, key := range keys {
+		var lb LoadBalancer
+		if err := s.state.Get(key, &lb); err == nil {
+			lbs = append(lbs, lb)
+		}
+	}
+	sort.Slice(lbs, func(i, j int) bool { return lbs[i].LoadBalancerName < lbs[j].LoadBalancerName })
+	return lbs, nil
+}
+
+func (s *ELBv2Service) findLoadBalancerByName(name string) (*LoadBalancer, error) {
+	lbs, err := s.listLoadBalancers()
+	if err != nil {
+		return nil, err
+	}
+	for _, lb := range lbs {
+		if lb.LoadBalancerName == name {
+			return &lb, nil
+		}
+	}
+	return nil, fmt.Errorf("load balancer %s not found", name)
+}
+
+func (s *ELBv2Service) loadTargetGroup(tgArn string) (*TargetGroup, error) {
+	var tg TargetGroup
+	if err := s.state.Get(targetGroupKeyPrefix+tgArn, &tg); err != nil {
+		return nil, err
+	}
+	return &tg, nil
+}
+
+func (s *ELBv2Service) listTargetGroups() ([]TargetGroup, error) {
+	keys, err := s.state.List(targetGroupKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	tgs := make([]TargetGroup, 0, len(keys))
+	for _, key := range keys {
+		var tg TargetGroup
+		if err := s.state.Get(key, &tg); err == nil {
+			tgs = append(tgs, tg)
+		}
+	}
+	sort.Slice(tgs, func(i, j int) bool { return tgs[i].TargetGroupName < tgs[j].TargetGroupName })
+	return tgs, nil
+}
+
+func (s *ELBv2Service) findTargetGroupByName(name string) (*TargetGroup, error) {
+	tgs, err := s.listTargetGroups()
+	if err != nil {
+		return nil, err
+	}
+	for _, tg := range tgs {
+		if tg.TargetGroupName == name {
+			return &tg, nil
+		}
+	}
+	return nil, fmt.Errorf("target group %s not found", name)
+}
+
+// listListeners returns the listeners of the load balancer, or of every load balancer when
+// lbArn is empty, ordered by port
+func (s *ELBv2Service) listListeners(lbArn string) ([]Listener, error) {
+	keys, err := s.state.List(listenerKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var listeners []Listener
+	for _, key := range keys {
+		var listener Listener
+		if err := s.state.Get(key, &listener); err == nil && (lbArn == "" || listener.LoadBalancerArn == lbArn) {
+			listeners = append(listeners, listener)
+		}
+	}
+	sort.Slice(listeners, func(i, j int) bool { return listeners[i].Port < listeners[j].Port })
+	return listeners, nil
+}
+
+// targetGroupLoadBalancers returns the load balancers whose listeners forward to the target group
+func (s *ELBv2Service) targetGroupLoadBalancers(tgArn string) []string {
+	listeners, err := s.listListeners("")
+	if err != nil {
+		return nil
+	}
+
+	var lbArns []string
+	for _, listener := range listeners {
+		for _, action := range listener.DefaultActions {
+			if action.TargetGroupArn == tgArn && !contains(lbArns, listener.LoadBalancerArn) {
+				lbArns = append(lbArns, listener.LoadBalancerArn)
+			}
+		}
+	}
+	sort.Strings(lbArns)
+	return lbArns
+}
+
+func (s *ELBv2Service) resourceExists(resourceArn string) bool {
+	return s.state.Exists(loadBalancerKeyPrefix+resourceArn) ||
+		s.state.Exists(targetGroupKeyPrefix+resourceArn) ||
+		s.state.Exists(listenerKeyPrefix+resourceArn)
+}
+
+func (s *ELBv2Service) resourceNotFound(resourceArn string) *emulator.AWSResponse {
+	switch {
+	case strings.Contains(resourceArn, ":targetgroup/"):
+		return s.errorResponse(400, "TargetGroupNotFound", fmt.Sprintf("Target groups '[%s]' not found", resourceArn))
+	case strings.Contains(resourceArn, ":listener/"):
+		return s.errorResponse(400, "ListenerNotFound", fmt.Sprintf("Listener '%s' not found", resourceArn))
+	default:
+		return s.errorResponse(400, "LoadBalancerNotFound", fmt.Sprintf("Load balancer '%s' not found", resourceArn))
+	}
+}
+
+// deleteResource removes a resource along with its tags and attributes
+func (s *ELBv2Service) deleteResource(prefix, resourceArn string) {
+	_ = s.state.Delete(prefix + resourceArn)
+	_ = s.state.Delete(tagsKeyPrefix + resourceArn)
+	_ = s.state.Delete(attributesKeyPrefix + resourceArn)
+}
+
+func (s *ELBv2Service) loadTags(resourceArn string) []Tag {
+	var tags []Tag
+	if err := s.state.Get(tagsKeyPrefix+resourceArn, &tags); err != nil {
+		return []Tag{}
+	}
+	return tags
+}
+
+func (s *ELBv2Service) saveTags(resourceArn string, tags []Tag) error {
+	return s.state.Set(tagsKeyPrefix+resourceArn, tags)
+}
+
+// subnet holds the fields of an EC2 subnet that a load balancer needs, read from the EC2
+// service's state
+type subnet struct {
+	VpcId            string
+	AvailabilityZone string
+}
+
+func (s *ELBv2Service) loadSubnet(subnetId string) (*subnet, error) {
+	var sn subnet
+	if err := s.state.Get("ec2:subnets:"+subnetId, &sn); err != nil {
+		return nil, err
+	}
+	return &sn, nil
+}
+
+// instanceState returns the state of the EC2 instance, or "unknown" if it doesn't exist
+func (s *ELBv2Service) instanceState(instanceId string) string {
+	var instance struct {
+		State *struct{ Name string }
+	}
+	if err := s.state.Get("ec2:instances:"+instanceId, &instance); err != nil || instance.State == nil {
+		return "unknown"
+	}
+	return instance.State.Name
+}
+
+// ============================================================================
+// Response Helpers
+// ============================================================================
+
+func toXMLLoadBalancer(lb LoadBalancer) XMLLoadBalancer {
+	zones := lb.AvailabilityZones
+	if zones == nil {
+		zones = []AvailabilityZone{}
+	}
+	return XMLLoadBalancer{
+		LoadBalancerArn:       lb.LoadBalancerArn,
+		LoadBalancerName:      lb.LoadBalancerName,
+		DNSName:               lb.DNSName,
+		CanonicalHostedZoneId: lb.CanonicalHostedZoneId,
+		CreatedTime:           lb.CreatedTime,
+		Scheme:                lb.Scheme,
+		Type:                  lb.Type,
+		IpAddressType:         lb.IpAddressType,
+		VpcId:                 lb.VpcId,
+		State:                 XMLLoadBalancerState{Code: "active"},
+		AvailabilityZones:     zones,
+		SecurityGroups:        lb.SecurityGroups,
+	}
+}
+
+func (s *ELBv2Service) toXMLTargetGroup(tg TargetGroup) XMLTargetGroup {
+	xmlTg := XMLTargetGroup{
+		TargetGroupArn:             tg.TargetGroupArn,
+		TargetGroupName:            tg.TargetGroupName,
+		Protocol:                   tg.Protocol,
+		Port:                       tg.Port,
+		VpcId:                      tg.VpcId,
+		TargetType:                 tg.TargetType,
+		IpAddressType:              tg.IpAddressType,
+		HealthCheckEnabled:         tg.HealthCheckEnabled,
+		HealthCheckProtocol:        tg.HealthCheckProtocol,
+		HealthCheckPort:            tg.HealthCheckPort,
+		HealthCheckPath:            tg.HealthCheckPath,
+		HealthCheckIntervalSeconds: tg.HealthCheckIntervalSeconds,
+		HealthCheckTimeoutSeconds:  tg.HealthCheckTimeoutSeconds,
+		HealthyThresholdCount:      tg.HealthyThresholdCount,
+		UnhealthyThresholdCount:    tg.UnhealthyThresholdCount,
+		LoadBalancerArns:           s.targetGroupLoadBalancers(tg.TargetGroupArn),
+	}
+	if tg.MatcherHttpCode != "" {
+		xmlTg.Matcher = &XMLMatcher{HttpCode: tg.MatcherHttpCode}
+	}
+	return xmlTg
+}
+
+func toXMLListener(listener Listener) XMLListener {
+	xmlListener := XMLListener{
+		ListenerArn:     listener.ListenerArn,
+		LoadBalancerArn: listener.LoadBalancerArn,
+		Protocol:        listener.Protocol,
+		Port:            listener.Port,
+		SslPolicy:       listener.SslPolicy,
+		DefaultActions:  listener.DefaultActions,
+	}
+	for _, certificateArn := range listener.CertificateArns {
+		xmlListener.Certificates = append(xmlListener.Certificates, XMLCertificate{CertificateArn: certificateArn})
+	}
+	return xmlListener
+}
+
+func (s *ELBv2Service) successResponse(action string, data interface{}) (*emulator.AWSResponse, error) {
+	return emulator.BuildQueryResponse(action, data, emulator.ResponseBuilderConfig{
+		ServiceName: "elasticloadbalancing",
+		Version:     "2015-12-01",
+	})
+}
+
+func (s *ELBv2Service) errorResponse(statusCode int, code, message string) *emulator.AWSResponse {
+	return emulator.BuildErrorResponse("elasticloadbalancing", statusCode, code, message)
+}
+
+// ============================================================================
+// Parameter Helpers
+// ============================================================================
+
+// parseMemberList parses a list parameter, e.g. Subnets.member.1, Subnets.member.2
+func parseMemberList(params map[string]interface{}, name string) []string {
+	var values []string
+	for i := 1; ; i++ {
+		value := emulator.GetStringParam(params, fmt.Sprintf("%s.member.%d", name, i), "")
+		if value == "" {
+			return values
+		}
+		values = append(values, value)
+	}
+}
+
+// parseMemberFieldList parses one field of a list of structures, e.g. Certificates.member.1.CertificateArn
+func parseMemberFieldList(params map[string]interface{}, name, field string) []string {
+	var values []string
+	for i := 1; ; i++ {
+		value := emulator.GetStringParam(params, fmt.Sprintf("%s.member.%d.%s", name, i, field), "")
+		if value == "" {
+			return values
+		}
+		values = append(values, value)
+	}
+}
+
+func parseTags(params map[string]interface{}) []Tag {
+	var tags []Tag
+	for i := 1; ; i++ {
+		prefix := fmt.Sprintf("Tags.member.%d.", i)
+		key := emulator.GetStringParam(params, prefix+"Key", "")
+		if key == "" {
+			return tags
+		}
+		tags = setTag(tags, Tag{Key: key, Value: emulator.GetStringParam(params, prefix+"Value", "")})
+	}
+}
+
+func parseTargets(params map[string]interface{}) []Target {
+	var targets []Target
+	for i := 1; ; i++ {
+		prefix := fmt.Sprintf("Targets.member.%d.", i)
+		id := emulator.GetStringParam(params, prefix+"Id", "")
+		if id == "" {
+			return targets
+		}
+		targets = append(targets, Target{
+			Id:               id,
+			Port:             emulator.GetInt32Param(params, prefix+"Port", 0),
+			AvailabilityZone: emulator.GetStringParam(params, prefix+"AvailabilityZone", ""),
+		})
+	}
+}
+
+// findTarget returns the index of the registered target with the same ID and port, or -1. A
+// target without a port uses the target group's.
+func findTarget(targets []Target, target Target, defaultPort int32) int {
+	port := target.Port
+	if port == 0 {
+		port = defaultPort
+	}
+	for i, registered := range targets {
+		registeredPort := registered.Port
+		if registeredPort == 0 {
+			registeredPort = defaultPort
+		}
+		if registered.Id == target.Id && registeredPort == port {
+			return i
+		}
+	}
+	return -1
+}
+
+func setTag(tags []Tag, tag Tag) []Tag {
+	for i := range tags {
+		if tags[i].Key == tag.Key {
+			tags[i].Value = tag.Value
+			return tags
+		}
+	}
+	return append(tags, tag)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// randomID returns the 16 hex digit suffix of a load balancer, target group or listener ARN
+func randomID() string {
+	return strings.ReplaceAll(uuid.New().String(), "-", "")[:16]
+}
+
+// typeSegment returns the ARN and DNS name component for a load balancer type
+func typeSegment(lbType string) string {
+	switch lbType {
+	case "network":
+		return "net"
+	case "gateway":
+		return "gwy"
+	default:
+		return "app"
+	}
+}
+
+// dnsName builds a load balancer's DNS name in the format AWS uses for its type and scheme,
+// e.g. my-alb-1234567890.us-east-1.elb.amazonaws.com or my-nlb-0123456789abcdef.elb.us-east-1.amazonaws.com
+func dnsName(name, lbType, scheme, id string, scope emulator.RequestScope) string {
+	if scheme == "internal" {
+		name = "internal-" + name
+	}
+	if lbType == "application" {
+		return fmt.Sprintf("%s-%d.%s.elb.%s", name, uuid.New().ID(), scope.Region, scope.DNSSuffix())
+	}
+	return fmt.Sprintf("%s-%s.elb.%s.%s", name, id, scope.Region, scope.DNSSuffix())
+}
+
+func hostedZoneID(region, lbType string) string {
+	zones, ok := hostedZoneIDs[region]
+	if !ok {
+		zones = hostedZoneIDs["us-east-1"]
+	}
+	if zone, ok := zones[lbType]; ok {
+		return zone
+	}
+	return zones["network"]
+}
+
+// healthCheckProtocol returns the default health check protocol for a target group protocol
+func healthCheckProtocol(protocol string) string {
+	switch protocol {
+	case "HTTP", "HTTPS":
+		return protocol
+	case "TCP", "TLS", "UDP", "TCP_UDP":
+		return "TCP"
+	default:
+		return "HTTP"
+	}
+}
+
+func xmlName(local string) xml.Name {
+	return xml.Name{Local: local}
+}
@@ -0,0 +1,234 @@
+package elbv2
+
+import (
+	"context"
+	"encoding/xml"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+)
+
+func newTestService(t *testing.T) (*ELBv2Service, emulator.StateManager) {
+	t.Helper()
+	state := emulator.NewMemoryStateManager()
+	require.NoError(t, state.Set("ec2:subnets:subnet-a", map[string]string{"VpcId": "vpc-1", "AvailabilityZone": "us-east-1a"}))
+	require.NoError(t, state.Set("ec2:subnets:subnet-b", map[string]string{"VpcId": "vpc-1", "AvailabilityZone": "us-east-1b"}))
+
+	service := NewELBv2Service(state, emulator.NewSchemaValidator())
+	service.SetClock(&emulator.FixedClock{Time: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)})
+	return service, state
+}
+
+// call sends a Query request for action and decodes the <ActionResult> element of the response into out
+func call(t *testing.T, service *ELBv2Service, action string, params url.Values, out interface{}) (int, string) {
+	t.Helper()
+	params.Set("Action", action)
+	resp, err := service.HandleRequest(context.Background(), &emulator.AWSRequest{
+		Method:  "POST",
+		Action:  action,
+		Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+		Body:    []byte(params.Encode()),
+	})
+	require.NoError(t, err)
+	if out != nil && resp.StatusCode == 200 {
+		envelope := struct {
+			Inner []byte `xml:",innerxml"`
+		}{}
+		require.NoError(t, xml.Unmarshal(resp.Body, &envelope))
+		require.NoError(t, xml.Unmarshal(envelope.Inner, out), string(resp.Body))
+	}
+	return resp.StatusCode, string(resp.Body)
+}
+
+func createLoadBalancer(t *testing.T, service *ELBv2Service, name string) XMLLoadBalancer {
+	t.Helper()
+	var result CreateLoadBalancerResult
+	status, body := call(t, service, "CreateLoadBalancer", url.Values{
+		"Name":             {name},
+		"Subnets.member.1": {"subnet-a"},
+		"Subnets.member.2": {"subnet-b"},
+	}, &result)
+	require.Equal(t, 200, status, body)
+	require.Len(t, result.LoadBalancers, 1)
+	return result.LoadBalancers[0]
+}
+
+func createTargetGroup(t *testing.T, service *ELBv2Service, name string) XMLTargetGroup {
+	t.Helper()
+	var result CreateTargetGroupResult
+	status, body := call(t, service, "CreateTargetGroup", url.Values{
+		"Name":       {name},
+		"Protocol":   {"HTTP"},
+		"Port":       {"80"},
+		"VpcId":      {"vpc-1"},
+		"TargetType": {"ip"},
+	}, &result)
+	require.Equal(t, 200, status, body)
+	require.Len(t, result.TargetGroups, 1)
+	return result.TargetGroups[0]
+}
+
+func TestCreateLoadBalancer_GeneratesArnAndDNSName(t *testing.T) {
+	service, _ := newTestService(t)
+
+	lb := createLoadBalancer(t, service, "web")
+	assert.Regexp(t, `^arn:aws:elasticloadbalancing:us-east-1:\d{12}:loadbalancer/app/web/[0-9a-f]{16}$`, lb.LoadBalancerArn)
+	assert.Regexp(t, `^web-\d+\.us-east-1\.elb\.amazonaws\.com$`, lb.DNSName)
+	assert.Equal(t, "Z35SXDOTRQ7X7K", lb.CanonicalHostedZoneId)
+	assert.Equal(t, "vpc-1", lb.VpcId)
+	assert.Equal(t, "active", lb.State.Code)
+	assert.Len(t, lb.AvailabilityZones, 2)
+
+	var described DescribeLoadBalancersResult
+	status, _ := call(t, service, "DescribeLoadBalancers", url.Values{"Names.member.1": {"web"}}, &described)
+	require.Equal(t, 200, status)
+	require.Len(t, described.LoadBalancers, 1)
+	assert.Equal(t, lb.LoadBalancerArn, described.LoadBalancers[0].LoadBalancerArn)
+
+	status, body := call(t, service, "CreateLoadBalancer", url.Values{"Name": {"web"}}, nil)
+	assert.Equal(t, 400, status)
+	assert.Contains(t, body, "DuplicateLoadBalancerName")
+
+	status, body = call(t, service, "CreateLoadBalancer", url.Values{"Name": {"api"}, "Subnets.member.1": {"subnet-missing"}}, nil)
+	assert.Equal(t, 400, status)
+	assert.Contains(t, body, "SubnetNotFound")
+}
+
+func TestCreateLoadBalancer_NetworkLoadBalancerDNSName(t *testing.T) {
+	service, _ := newTestService(t)
+
+	var result CreateLoadBalancerResult
+	status, body := call(t, service, "CreateLoadBalancer", url.Values{"Name": {"tcp"}, "Type": {"network"}, "Scheme": {"internal"}}, &result)
+	require.Equal(t, 200, status, body)
+
+	lb := result.LoadBalancers[0]
+	assert.Contains(t, lb.LoadBalancerArn, ":loadbalancer/net/tcp/")
+	assert.Regexp(t, `^internal-tcp-[0-9a-f]{16}\.elb\.us-east-1\.amazonaws\.com$`, lb.DNSName)
+	assert.Equal(t, "Z26RNL4JYFTOTI", lb.CanonicalHostedZoneId)
+}
+
+func TestCreateListener_ForwardsToTargetGroup(t *testing.T) {
+	service, _ := newTestService(t)
+	lb := createLoadBalancer(t, service, "web")
+	tg := createTargetGroup(t, service, "web-tg")
+
+	listenerParams := url.Values{
+		"LoadBalancerArn":                        {lb.LoadBalancerArn},
+		"Protocol":                               {"HTTP"},
+		"Port":                                   {"80"},
+		"DefaultActions.member.1.Type":           {"forward"},
+		"DefaultActions.member.1.TargetGroupArn": {tg.TargetGroupArn},
+	}
+	var created CreateListenerResult
+	status, body := call(t, service, "CreateListener", listenerParams, &created)
+	require.Equal(t, 200, status, body)
+	assert.True(t, strings.HasPrefix(created.Listeners[0].ListenerArn, strings.Replace(lb.LoadBalancerArn, ":loadbalancer/", ":listener/", 1)+"/"))
+
+	status, body = call(t, service, "CreateListener", listenerParams, nil)
+	assert.Equal(t, 400, status)
+	assert.Contains(t, body, "DuplicateListener")
+
+	var listeners DescribeListenersResult
+	status, _ = call(t, service, "DescribeListeners", url.Values{"LoadBalancerArn": {lb.LoadBalancerArn}}, &listeners)
+	require.Equal(t, 200, status)
+	require.Len(t, listeners.Listeners, 1)
+	assert.Equal(t, int32(80), listeners.Listeners[0].Port)
+	assert.Equal(t, tg.TargetGroupArn, listeners.Listeners[0].DefaultActions[0].TargetGroupArn)
+
+	var groups DescribeTargetGroupsResult
+	status, _ = call(t, service, "DescribeTargetGroups", url.Values{"LoadBalancerArn": {lb.LoadBalancerArn}}, &groups)
+	require.Equal(t, 200, status)
+	require.Len(t, groups.TargetGroups, 1)
+	assert.Equal(t, []string{lb.LoadBalancerArn}, groups.TargetGroups[0].LoadBalancerArns)
+
+	status, body = call(t, service, "DeleteTargetGroup", url.Values{"TargetGroupArn": {tg.TargetGroupArn}}, nil)
+	assert.Equal(t, 400, status)
+	assert.Contains(t, body, "ResourceInUse")
+}
+
+func TestDescribeTargetHealth(t *testing.T) {
+	service, _ := newTestService(t)
+	lb := createLoadBalancer(t, service, "web")
+	tg := createTargetGroup(t, service, "web-tg")
+
+	status, _ := call(t, service, "RegisterTargets", url.Values{
+		"TargetGroupArn":      {tg.TargetGroupArn},
+		"Targets.member.1.Id": {"10.0.0.10"},
+		"Targets.member.2.Id": {"10.0.0.11"},
+	}, nil)
+	require.Equal(t, 200, status)
+
+	describe := func() []XMLTargetHealthDescription {
+		var result DescribeTargetHealthResult
+		status, body := call(t, service, "DescribeTargetHealth", url.Values{"TargetGroupArn": {tg.TargetGroupArn}}, &result)
+		require.Equal(t, 200, status, body)
+		return result.TargetHealthDescriptions
+	}
+
+	// Targets aren't in use until a listener forwards to their target group
+	health := describe()
+	require.Len(t, health, 2)
+	assert.Equal(t, "unused", health[0].TargetHealth.State)
+	assert.Equal(t, "Target.NotInUse", health[0].TargetHealth.Reason)
+
+	status, _ = call(t, service, "CreateListener", url.Values{
+		"LoadBalancerArn":                        {lb.LoadBalancerArn},
+		"Port":                                   {"80"},
+		"DefaultActions.member.1.Type":           {"forward"},
+		"DefaultActions.member.1.TargetGroupArn": {tg.TargetGroupArn},
+	}, nil)
+	require.Equal(t, 200, status)
+
+	health = describe()
+	require.Len(t, health, 2)
+	for _, description := range health {
+		assert.Equal(t, "healthy", description.TargetHealth.State)
+		assert.Equal(t, int32(80), description.Target.Port)
+		assert.Equal(t, "80", description.HealthCheckPort)
+	}
+
+	status, _ = call(t, service, "DeregisterTargets", url.Values{
+		"TargetGroupArn":      {tg.TargetGroupArn},
+		"Targets.member.1.Id": {"10.0.0.10"},
+	}, nil)
+	require.Equal(t, 200, status)
+	assert.Len(t, describe(), 1)
+}
+
+func TestDescribeTargetHealth_StoppedInstance(t *testing.T) {
+	service, state := newTestService(t)
+	require.NoError(t, state.Set("ec2:instances:i-1", map[string]interface{}{"InstanceId": "i-1", "State": map[string]string{"Name": "stopped"}}))
+	lb := createLoadBalancer(t, service, "web")
+
+	var created CreateTargetGroupResult
+	status, _ := call(t, service, "CreateTargetGroup", url.Values{"Name": {"instances"}, "Protocol": {"HTTP"}, "Port": {"8080"}, "VpcId": {"vpc-1"}}, &created)
+	require.Equal(t, 200, status)
+	tgArn := created.TargetGroups[0].TargetGroupArn
+
+	status, body := call(t, service, "RegisterTargets", url.Values{"TargetGroupArn": {tgArn}, "Targets.member.1.Id": {"i-missing"}}, nil)
+	assert.Equal(t, 400, status)
+	assert.Contains(t, body, "InvalidTarget")
+
+	status, _ = call(t, service, "RegisterTargets", url.Values{"TargetGroupArn": {tgArn}, "Targets.member.1.Id": {"i-1"}}, nil)
+	require.Equal(t, 200, status)
+	status, _ = call(t, service, "CreateListener", url.Values{
+		"LoadBalancerArn":                        {lb.LoadBalancerArn},
+		"Port":                                   {"80"},
+		"DefaultActions.member.1.Type":           {"forward"},
+		"DefaultActions.member.1.TargetGroupArn": {tgArn},
+	}, nil)
+	require.Equal(t, 200, status)
+
+	var result DescribeTargetHealthResult
+	status, _ = call(t, service, "DescribeTargetHealth", url.Values{"TargetGroupArn": {tgArn}}, &result)
+	require.Equal(t, 200, status)
+	require.Len(t, result.TargetHealthDescriptions, 1)
+	assert.Equal(t, "unused", result.TargetHealthDescriptions[0].TargetHealth.State)
+	assert.Equal(t, "Target.InvalidState", result.TargetHealthDescriptions[0].TargetHealth.Reason)
+}
@@ -0,0 +1,243 @@
+package elbv2
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// ============================================================================
+// Internal Storage Types
+// ============================================================================
+
+// LoadBalancer is an Application, Network or Gateway Load Balancer stored in state
+type LoadBalancer struct {
+	LoadBalancerArn       string             `json:"loadBalancerArn"`
+	LoadBalancerName      string             `json:"loadBalancerName"`
+	DNSName               string             `json:"dnsName"`
+	CanonicalHostedZoneId string             `json:"canonicalHostedZoneId"`
+	CreatedTime           time.Time          `json:"createdTime"`
+	Scheme                string             `json:"scheme"`
+	Type                  string             `json:"type"`
+	IpAddressType         string             `json:"ipAddressType"`
+	VpcId                 string             `json:"vpcId,omitempty"`
+	AvailabilityZones     []AvailabilityZone `json:"availabilityZones,omitempty"`
+	SecurityGroups        []string           `json:"securityGroups,omitempty"`
+}
+
+// AvailabilityZone is a subnet a load balancer is placed in
+type AvailabilityZone struct {
+	ZoneName string `json:"zoneName" xml:"ZoneName"`
+	SubnetId string `json:"subnetId" xml:"SubnetId"`
+}
+
+// TargetGroup is a target group stored in state, along with its registered targets
+type TargetGroup struct {
+	TargetGroupArn             string    `json:"targetGroupArn"`
+	TargetGroupName            string    `json:"targetGroupName"`
+	Protocol                   string    `json:"protocol,omitempty"`
+	Port                       int32     `json:"port,omitempty"`
+	VpcId                      string    `json:"vpcId,omitempty"`
+	TargetType                 string    `json:"targetType"`
+	IpAddressType              string    `json:"ipAddressType,omitempty"`
+	HealthCheckEnabled         bool      `json:"healthCheckEnabled"`
+	HealthCheckProtocol        string    `json:"healthCheckProtocol,omitempty"`
+	HealthCheckPort            string    `json:"healthCheckPort,omitempty"`
+	HealthCheckPath            string    `json:"healthCheckPath,omitempty"`
+	HealthCheckIntervalSeconds int32     `json:"healthCheckIntervalSeconds"`
+	HealthCheckTimeoutSeconds  int32     `json:"healthCheckTimeoutSeconds"`
+	HealthyThresholdCount      int32     `json:"healthyThresholdCount"`
+	UnhealthyThresholdCount    int32     `json:"unhealthyThresholdCount"`
+	MatcherHttpCode            string    `json:"matcherHttpCode,omitempty"`
+	Targets                    []Target  `json:"targets,omitempty"`
+	CreatedTime                time.Time `json:"createdTime"`
+}
+
+// Target is a target registered with a target group
+type Target struct {
+	Id               string `json:"id" xml:"Id"`
+	Port             int32  `json:"port,omitempty" xml:"Port,omitempty"`
+	AvailabilityZone string `json:"availabilityZone,omitempty" xml:"AvailabilityZone,omitempty"`
+}
+
+// Listener is a listener stored in state
+type Listener struct {
+	ListenerArn     string   `json:"listenerArn"`
+	LoadBalancerArn string   `json:"loadBalancerArn"`
+	Protocol        string   `json:"protocol,omitempty"`
+	Port            int32    `json:"port,omitempty"`
+	SslPolicy       string   `json:"sslPolicy,omitempty"`
+	CertificateArns []string `json:"certificateArns,omitempty"`
+	DefaultActions  []Action `json:"defaultActions"`
+}
+
+// Action is a listener's default action
+type Action struct {
+	Type                string               `json:"type" xml:"Type"`
+	Order               int32                `json:"order,omitempty" xml:"Order,omitempty"`
+	TargetGroupArn      string               `json:"targetGroupArn,omitempty" xml:"TargetGroupArn,omitempty"`
+	FixedResponseConfig *FixedResponseConfig `json:"fixedResponseConfig,omitempty" xml:"FixedResponseConfig,omitempty"`
+	RedirectConfig      *RedirectConfig      `json:"redirectConfig,omitempty" xml:"RedirectConfig,omitempty"`
+}
+
+// FixedResponseConfig is the response returned by a fixed-response action
+type FixedResponseConfig struct {
+	StatusCode  string `json:"statusCode" xml:"StatusCode"`
+	ContentType string `json:"contentType,omitempty" xml:"ContentType,omitempty"`
+	MessageBody string `json:"messageBody,omitempty" xml:"MessageBody,omitempty"`
+}
+
+// RedirectConfig is where a redirect action sends requests
+type RedirectConfig struct {
+	StatusCode string `json:"statusCode" xml:"StatusCode"`
+	Protocol   string `json:"protocol,omitempty" xml:"Protocol,omitempty"`
+	Port       string `json:"port,omitempty" xml:"Port,omitempty"`
+	Host       string `json:"host,omitempty" xml:"Host,omitempty"`
+	Path       string `json:"path,omitempty" xml:"Path,omitempty"`
+	Query      string `json:"query,omitempty" xml:"Query,omitempty"`
+}
+
+// Attribute is a load balancer or target group attribute
+type Attribute struct {
+	Key   string `json:"key" xml:"Key"`
+	Value string `json:"value" xml:"Value"`
+}
+
+// Tag is a resource tag
+type Tag struct {
+	Key   string `json:"key" xml:"Key"`
+	Value string `json:"value" xml:"Value"`
+}
+
+// ============================================================================
+// Response Types
+// ============================================================================
+
+type EmptyResult struct {
+	XMLName xml.Name `xml:""`
+}
+
+// XMLLoadBalancer is a load balancer in a CreateLoadBalancer or DescribeLoadBalancers response
+type XMLLoadBalancer struct {
+	LoadBalancerArn       string               `xml:"LoadBalancerArn"`
+	LoadBalancerName      string               `xml:"LoadBalancerName"`
+	DNSName               string               `xml:"DNSName"`
+	CanonicalHostedZoneId string               `xml:"CanonicalHostedZoneId"`
+	CreatedTime           time.Time            `xml:"CreatedTime"`
+	Scheme                string               `xml:"Scheme"`
+	Type                  string               `xml:"Type"`
+	IpAddressType         string               `xml:"IpAddressType"`
+	VpcId                 string               `xml:"VpcId,omitempty"`
+	State                 XMLLoadBalancerState `xml:"State"`
+	AvailabilityZones     []AvailabilityZone   `xml:"AvailabilityZones>member"`
+	SecurityGroups        []string             `xml:"SecurityGroups>member,omitempty"`
+}
+
+type XMLLoadBalancerState struct {
+	Code string `xml:"Code"`
+}
+
+type CreateLoadBalancerResult struct {
+	XMLName       xml.Name          `xml:"CreateLoadBalancerResult"`
+	LoadBalancers []XMLLoadBalancer `xml:"LoadBalancers>member"`
+}
+
+type DescribeLoadBalancersResult struct {
+	XMLName       xml.Name          `xml:"DescribeLoadBalancersResult"`
+	LoadBalancers []XMLLoadBalancer `xml:"LoadBalancers>member"`
+}
+
+// XMLTargetGroup is a target group in a CreateTargetGroup or DescribeTargetGroups response
+type XMLTargetGroup struct {
+	TargetGroupArn             string      `xml:"TargetGroupArn"`
+	TargetGroupName            string      `xml:"TargetGroupName"`
+	Protocol                   string      `xml:"Protocol,omitempty"`
+	Port                       int32       `xml:"Port,omitempty"`
+	VpcId                      string      `xml:"VpcId,omitempty"`
+	TargetType                 string      `xml:"TargetType"`
+	IpAddressType              string      `xml:"IpAddressType,omitempty"`
+	HealthCheckEnabled         bool        `xml:"HealthCheckEnabled"`
+	HealthCheckProtocol        string      `xml:"HealthCheckProtocol,omitempty"`
+	HealthCheckPort            string      `xml:"HealthCheckPort,omitempty"`
+	HealthCheckPath            string      `xml:"HealthCheckPath,omitempty"`
+	HealthCheckIntervalSeconds int32       `xml:"HealthCheckIntervalSeconds"`
+	HealthCheckTimeoutSeconds  int32       `xml:"HealthCheckTimeoutSeconds"`
+	HealthyThresholdCount      int32       `xml:"HealthyThresholdCount"`
+	UnhealthyThresholdCount    int32       `xml:"UnhealthyThresholdCount"`
+	Matcher                    *XMLMatcher `xml:"Matcher,omitempty"`
+	LoadBalancerArns           []string    `xml:"LoadBalancerArns>member"`
+}
+
+type XMLMatcher struct {
+	HttpCode string `xml:"HttpCode"`
+}
+
+type CreateTargetGroupResult struct {
+	XMLName      xml.Name         `xml:"CreateTargetGroupResult"`
+	TargetGroups []XMLTargetGroup `xml:"TargetGroups>member"`
+}
+
+type DescribeTargetGroupsResult struct {
+	XMLName      xml.Name         `xml:"DescribeTargetGroupsResult"`
+	TargetGroups []XMLTargetGroup `xml:"TargetGroups>member"`
+}
+
+// XMLListener is a listener in a CreateListener or DescribeListeners response
+type XMLListener struct {
+	ListenerArn     string           `xml:"ListenerArn"`
+	LoadBalancerArn string           `xml:"LoadBalancerArn"`
+	Protocol        string           `xml:"Protocol,omitempty"`
+	Port            int32            `xml:"Port,omitempty"`
+	SslPolicy       string           `xml:"SslPolicy,omitempty"`
+	Certificates    []XMLCertificate `xml:"Certificates>member,omitempty"`
+	DefaultActions  []Action         `xml:"DefaultActions>member"`
+}
+
+type XMLCertificate struct {
+	CertificateArn string `xml:"CertificateArn"`
+}
+
+type CreateListenerResult struct {
+	XMLName   xml.Name      `xml:"CreateListenerResult"`
+	Listeners []XMLListener `xml:"Listeners>member"`
+}
+
+type DescribeListenersResult struct {
+	XMLName   xml.Name      `xml:"DescribeListenersResult"`
+	Listeners []XMLListener `xml:"Listeners>member"`
+}
+
+// XMLTargetHealthDescription is the health of one target in a DescribeTargetHealth response
+type XMLTargetHealthDescription struct {
+	Target          Target          `xml:"Target"`
+	HealthCheckPort string          `xml:"HealthCheckPort,omitempty"`
+	TargetHealth    XMLTargetHealth `xml:"TargetHealth"`
+}
+
+type XMLTargetHealth struct {
+	State       string `xml:"State"`
+	Reason      string `xml:"Reason,omitempty"`
+	Description string `xml:"Description,omitempty"`
+}
+
+type DescribeTargetHealthResult struct {
+	XMLName                  xml.Name                     `xml:"DescribeTargetHealthResult"`
+	TargetHealthDescriptions []XMLTargetHealthDescription `xml:"TargetHealthDescriptions>member"`
+}
+
+// XMLTagDescription is the tags of one resource in a DescribeTags response
+type XMLTagDescription struct {
+	ResourceArn string `xml:"ResourceArn"`
+	Tags        []Tag  `xml:"Tags>member"`
+}
+
+type DescribeTagsResult struct {
+	XMLName         xml.Name            `xml:"DescribeTagsResult"`
+	TagDescriptions []XMLTagDescription `xml:"TagDescriptions>member"`
+}
+
+// AttributesResult is the result of the Describe and Modify attribute actions, whose responses
+// differ only in their element name
+type AttributesResult struct {
+	XMLName    xml.Name
+	Attributes []Attribute `xml:"Attributes>member"`
+}
@@ -0,0 +1,97 @@
+package eventbridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+	"github.com/robmorgan/infraspec/internal/emulator/services/lambda"
+	"github.com/robmorgan/infraspec/internal/emulator/services/sqs"
+)
+
+// deliver sends an event to a rule target through the target service's emulator. Targets
+// are reached through the same (partitioned) state as EventBridge, so only targets in the
+// caller's account and region receive events. SNS targets are accepted by PutTargets but not
+// delivered, as the emulator has no SNS service.
+func (s *EventBridgeService) deliver(ctx context.Context, target Target, event map[string]interface{}) error {
+	payload, err := targetPayload(target, event)
+	if err != nil {
+		return err
+	}
+
+	parts := strings.SplitN(target.Arn, ":", 6)
+	if len(parts) < 6 {
+		return fmt.Errorf("invalid target ARN %s", target.Arn)
+	}
+	service, region, account, resource := parts[2], parts[3], parts[4], parts[5]
+
+	var resp *emulator.AWSResponse
+	switch service {
+	case "sqs":
+		body, _ := json.Marshal(map[string]string{
+			"QueueUrl":    fmt.Sprintf("https://sqs.%s.amazonaws.com/%s/%s", region, account, resource),
+			"MessageBody": payload,
+		})
+		resp, err = sqs.NewSQSService(s.state, s.validator).HandleRequest(ctx, &emulator.AWSRequest{
+			Method: "POST",
+			Action: "SendMessage",
+			Body:   body,
+		})
+	case "lambda":
+		// resource is function:<name>[:<qualifier>]
+		functionName := strings.TrimPrefix(resource, "function:")
+		path := fmt.Sprintf("/2015-03-31/functions/%s/invocations", url.PathEscape(functionName))
+		if name, qualifier, ok := strings.Cut(functionName, ":"); ok {
+			path = fmt.Sprintf("/2015-03-31/functions/%s/invocations?Qualifier=%s", url.PathEscape(name), url.QueryEscape(qualifier))
+		}
+		resp, err = lambda.NewLambdaService(s.state, s.validator).HandleRequest(ctx, &emulator.AWSRequest{
+			Method: "POST",
+			Path:   path,
+			Action: "Invoke",
+			Headers: map[string]string{
+				"Content-Type":          "application/json",
+				"X-Amz-Invocation-Type": "Event",
+			},
+			Body: []byte(payload),
+		})
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("delivery to %s failed with status %d: %s", target.Arn, resp.StatusCode, resp.Body)
+	}
+	return nil
+}
+
+// targetPayload renders the event as the target receives it: the target's constant Input,
+// the part of the event selected by InputPath, or the whole event
+func targetPayload(target Target, event map[string]interface{}) (string, error) {
+	if target.Input != nil {
+		return *target.Input, nil
+	}
+
+	var selected interface{} = event
+	if target.InputPath != nil && *target.InputPath != "" && *target.InputPath != "$" {
+		path := strings.TrimPrefix(*target.InputPath, "$.")
+		for _, field := range strings.Split(path, ".") {
+			object, ok := selected.(map[string]interface{})
+			if !ok {
+				selected = nil
+				break
+			}
+			selected = object[field]
+		}
+	}
+
+	data, err := json.Marshal(selected)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
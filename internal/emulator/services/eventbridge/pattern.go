@@ -0,0 +1,219 @@
+package eventbridge
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// matchesPattern reports whether an event matches an EventBridge event pattern. Each field in
+// the pattern lists the values it accepts (an array) or nests a pattern for an object field.
+// Besides exact values, the prefix, anything-but, exists and numeric content filters are
+// supported.
+func matchesPattern(pattern string, event map[string]interface{}) (bool, error) {
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(pattern), &parsed); err != nil {
+		return false, fmt.Errorf("Event pattern is not valid JSON: %w", err)
+	}
+	return matchObject(parsed, event)
+}
+
+// validatePattern checks that an event pattern is well formed, as PutRule does
+func validatePattern(pattern string) error {
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(pattern), &parsed); err != nil {
+		return fmt.Errorf("Event pattern is not valid JSON: %w", err)
+	}
+	return validateObject(parsed)
+}
+
+func validateObject(pattern map[string]interface{}) error {
+	for field, fieldPattern := range pattern {
+		switch p := fieldPattern.(type) {
+		case map[string]interface{}:
+			if err := validateObject(p); err != nil {
+				return err
+			}
+		case []interface{}:
+			for _, candidate := range p {
+				filter, ok := candidate.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				// Probe each filter so malformed arguments are reported now
+				if exists, ok := filter["exists"]; ok {
+					if _, ok := exists.(bool); !ok {
+						return fmt.Errorf("exists filter must be a boolean")
+					}
+					continue
+				}
+				if _, err := matchFilter(filter, nil); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("Event pattern field %q must be an array or an object", field)
+		}
+	}
+	return nil
+}
+
+func matchObject(pattern, value map[string]interface{}) (bool, error) {
+	for field, fieldPattern := range pattern {
+		fieldValue, present := value[field]
+		switch p := fieldPattern.(type) {
+		case map[string]interface{}:
+			nested, ok := fieldValue.(map[string]interface{})
+			if !ok {
+				nested = map[string]interface{}{}
+			}
+			matched, err := matchObject(p, nested)
+			if err != nil || !matched {
+				return false, err
+			}
+		case []interface{}:
+			matched, err := matchField(p, fieldValue, present)
+			if err != nil || !matched {
+				return false, err
+			}
+		default:
+			return false, fmt.Errorf("Event pattern field %q must be an array or an object", field)
+		}
+	}
+	return true, nil
+}
+
+// matchField reports whether a field value matches any of the accepted values. Array values
+// in the event match if any element does.
+func matchField(accepted []interface{}, value interface{}, present bool) (bool, error) {
+	values := []interface{}{value}
+	if list, ok := value.([]interface{}); ok {
+		values = list
+	}
+
+	for _, candidate := range accepted {
+		filter, isFilter := candidate.(map[string]interface{})
+		if isFilter {
+			if exists, ok := filter["exists"]; ok {
+				want, ok := exists.(bool)
+				if !ok {
+					return false, fmt.Errorf("exists filter must be a boolean")
+				}
+				if want == present {
+					return true, nil
+				}
+				continue
+			}
+		}
+		if !present {
+			continue
+		}
+		for _, v := range values {
+			var matched bool
+			var err error
+			if isFilter {
+				matched, err = matchFilter(filter, v)
+			} else {
+				matched = equalValues(candidate, v)
+			}
+			if err != nil {
+				return false, err
+			}
+			if matched {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func matchFilter(filter map[string]interface{}, value interface{}) (bool, error) {
+	for name, arg := range filter {
+		switch name {
+		case "prefix":
+			prefix, ok := arg.(string)
+			if !ok {
+				return false, fmt.Errorf("prefix filter must be a string")
+			}
+			s, ok := value.(string)
+			return ok && strings.HasPrefix(s, prefix), nil
+		case "anything-but":
+			excluded := []interface{}{arg}
+			if list, ok := arg.([]interface{}); ok {
+				excluded = list
+			}
+			for _, e := range excluded {
+				if equalValues(e, value) {
+					return false, nil
+				}
+			}
+			return true, nil
+		case "numeric":
+			return matchNumeric(arg, value)
+		default:
+			return false, fmt.Errorf("Unsupported event pattern filter %q", name)
+		}
+	}
+	return false, nil
+}
+
+// matchNumeric applies a numeric filter such as [">", 0, "<=", 5]
+func matchNumeric(arg, value interface{}) (bool, error) {
+	conditions, ok := arg.([]interface{})
+	if !ok || len(conditions) == 0 || len(conditions)%2 != 0 {
+		return false, fmt.Errorf("numeric filter must be a list of operator and value pairs")
+	}
+	for i := 0; i < len(conditions); i += 2 {
+		op, _ := conditions[i].(string)
+		if _, ok := conditions[i+1].(float64); !ok {
+			return false, fmt.Errorf("numeric filter bounds must be numbers")
+		}
+		switch op {
+		case "=", "<", "<=", ">", ">=":
+		default:
+			return false, fmt.Errorf("Unsupported numeric operator %q", op)
+		}
+	}
+
+	n, ok := value.(float64)
+	if !ok {
+		return false, nil
+	}
+	for i := 0; i < len(conditions); i += 2 {
+		bound := conditions[i+1].(float64)
+		var matched bool
+		switch conditions[i].(string) {
+		case "=":
+			matched = n == bound
+		case "<":
+			matched = n < bound
+		case "<=":
+			matched = n <= bound
+		case ">":
+			matched = n > bound
+		case ">=":
+			matched = n >= bound
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func equalValues(a, b interface{}) bool {
+	switch av := a.(type) {
+	case string:
+		bv, ok := b.(string)
+		return ok && av == bv
+	case float64:
+		bv, ok := b.(float64)
+		return ok && av == bv
+	case bool:
+		bv, ok := b.(bool)
+		return ok && av == bv
+	case nil:
+		return b == nil
+	}
+	return false
+}
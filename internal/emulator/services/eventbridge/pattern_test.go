@@ -0,0 +1,57 @@
+package eventbridge
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchesPattern(t *testing.T) {
+	var event map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"source": "com.example.orders",
+		"detail-type": "OrderPlaced",
+		"resources": ["arn:aws:s3:::a", "arn:aws:s3:::b"],
+		"detail": {"status": "new", "total": 42, "region": "eu-west-1"}
+	}`), &event))
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    bool
+	}{
+		{"exact value", `{"source": ["com.example.orders"]}`, true},
+		{"one of several values", `{"source": ["other", "com.example.orders"]}`, true},
+		{"different value", `{"source": ["other"]}`, false},
+		{"all fields must match", `{"source": ["com.example.orders"], "detail-type": ["OrderShipped"]}`, false},
+		{"nested field", `{"detail": {"status": ["new"]}}`, true},
+		{"event array matches any element", `{"resources": ["arn:aws:s3:::b"]}`, true},
+		{"prefix", `{"detail": {"region": [{"prefix": "eu-"}]}}`, true},
+		{"anything-but", `{"detail": {"status": [{"anything-but": ["cancelled", "failed"]}]}}`, true},
+		{"anything-but excludes", `{"detail": {"status": [{"anything-but": "new"}]}}`, false},
+		{"exists", `{"detail": {"total": [{"exists": true}]}}`, true},
+		{"does not exist", `{"detail": {"coupon": [{"exists": false}]}}`, true},
+		{"missing field", `{"detail": {"coupon": ["SAVE10"]}}`, false},
+		{"numeric range", `{"detail": {"total": [{"numeric": [">", 10, "<=", 42]}]}}`, true},
+		{"numeric out of range", `{"detail": {"total": [{"numeric": ["<", 10]}]}}`, false},
+		{"number equality", `{"detail": {"total": [42]}}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchesPattern(tt.pattern, event)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidatePattern(t *testing.T) {
+	assert.NoError(t, validatePattern(`{"source": ["a"], "detail": {"n": [{"numeric": [">=", 1]}]}}`))
+	assert.Error(t, validatePattern(`not json`))
+	assert.Error(t, validatePattern(`{"source": "a"}`))
+	assert.Error(t, validatePattern(`{"source": [{"suffix": "a"}]}`))
+	assert.Error(t, validatePattern(`{"detail": {"n": [{"numeric": [">", "one"]}]}}`))
+}
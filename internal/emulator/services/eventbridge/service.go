@@ -0,0 +1,637 @@
+package eventbridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+)
+
+const (
+	defaultEventBusName = "default"
+	maxPutEventsEntries = 10
+	defaultListLimit    = 100
+)
+
+// EventBridgeService implements the Amazon EventBridge (CloudWatch Events) emulator. PutEvents
+// matches events against the rules of the event bus and delivers them to SQS and Lambda
+// targets through those services' emulators.
+type EventBridgeService struct {
+	state     emulator.StateManager
+	validator emulator.Validator
+}
+
+// NewEventBridgeService creates a new EventBridge service instance
+func NewEventBridgeService(state emulator.StateManager, validator emulator.Validator) *EventBridgeService {
+	return &EventBridgeService{
+		state:     state,
+		validator: validator,
+	}
+}
+
+// ServiceName returns the service identifier
+func (s *EventBridgeService) ServiceName() string {
+	return "events"
+}
+
+// Protocol returns the AWS protocol the service speaks
+func (s *EventBridgeService) Protocol() emulator.ProtocolType {
+	return emulator.ProtocolJSON
+}
+
+// HandleRequest routes incoming requests to the appropriate handler
+func (s *EventBridgeService) HandleRequest(ctx context.Context, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	if err := s.validator.ValidateRequest(req); err != nil {
+		return s.errorResponse(400, "ValidationException", err.Error()), nil
+	}
+
+	action := s.extractAction(req)
+	if action == "" {
+		return s.errorResponse(400, "InvalidAction", "Missing or invalid action"), nil
+	}
+
+	switch action {
+	case "PutRule":
+		input, err := emulator.ParseJSONRequest[PutRuleInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.putRule(ctx, input)
+	case "DescribeRule":
+		input, err := emulator.ParseJSONRequest[DescribeRuleInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.describeRule(ctx, input)
+	case "ListRules":
+		input, err := emulator.ParseJSONRequest[ListRulesInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.listRules(ctx, input)
+	case "DeleteRule":
+		input, err := emulator.ParseJSONRequest[DeleteRuleInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.deleteRule(ctx, input)
+	case "PutTargets":
+		input, err := emulator.ParseJSONRequest[PutTargetsInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.putTargets(ctx, input)
+	case "ListTargetsByRule":
+		input, err := emulator.ParseJSONRequest[ListTargetsByRuleInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.listTargetsByRule(ctx, input)
+	case "RemoveTargets":
+		input, err := emulator.ParseJSONRequest[RemoveTargetsInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.removeTargets(ctx, input)
+	case "PutEvents":
+		input, err := emulator.ParseJSONRequest[PutEventsInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.putEvents(ctx, input)
+	case "ListTagsForResource":
+		input, err := emulator.ParseJSONRequest[ListTagsForResourceInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.listTagsForResource(ctx, input)
+	default:
+		return s.errorResponse(400, "InvalidAction", fmt.Sprintf("Unknown action: %s", action)), nil
+	}
+}
+
+func (s *EventBridgeService) extractAction(req *emulator.AWSRequest) string {
+	if req.Action != "" {
+		return req.Action
+	}
+
+	// EventBridge uses X-Amz-Target header: "AWSEvents.PutRule"
+	target := req.Headers["X-Amz-Target"]
+	if target != "" {
+		parts := strings.Split(target, ".")
+		if len(parts) >= 2 {
+			return parts[len(parts)-1]
+		}
+	}
+
+	return ""
+}
+
+func (s *EventBridgeService) putRule(ctx context.Context, input *PutRuleInput) (*emulator.AWSResponse, error) {
+	if input.Name == nil || *input.Name == "" {
+		return s.errorResponse(400, "ValidationException", "Name is required"), nil
+	}
+	hasPattern := input.EventPattern != nil && *input.EventPattern != ""
+	hasSchedule := input.ScheduleExpression != nil && *input.ScheduleExpression != ""
+	if !hasPattern && !hasSchedule {
+		return s.errorResponse(400, "ValidationException", "Parameter(s) EventPattern or ScheduleExpression must be specified."), nil
+	}
+	if hasPattern {
+		if err := validatePattern(*input.EventPattern); err != nil {
+			return s.errorResponse(400, "InvalidEventPatternException", err.Error()), nil
+		}
+	}
+
+	busName := eventBusName(input.EventBusName)
+	stateKey := ruleStateKey(busName, *input.Name)
+
+	// PutRule creates the rule or replaces its settings, keeping its tags
+	var rule Rule
+	if err := s.state.Get(stateKey, &rule); err != nil {
+		rule = Rule{
+			Name:         *input.Name,
+			Arn:          ruleArn(ctx, busName, *input.Name),
+			EventBusName: busName,
+			Tags:         map[string]string{},
+		}
+	}
+	rule.EventPattern = stringValue(input.EventPattern)
+	rule.ScheduleExpression = stringValue(input.ScheduleExpression)
+	rule.Description = stringValue(input.Description)
+	rule.RoleArn = stringValue(input.RoleArn)
+	rule.State = "ENABLED"
+	if input.State != nil && *input.State != "" {
+		rule.State = *input.State
+	}
+	for _, tag := range input.Tags {
+		if tag.Key != nil {
+			rule.Tags[*tag.Key] = stringValue(tag.Value)
+		}
+	}
+
+	if err := s.state.Set(stateKey, &rule); err != nil {
+		return s.errorResponse(500, "InternalException", "Failed to store rule"), nil
+	}
+
+	return s.jsonResponse(200, map[string]interface{}{
+		"RuleArn": rule.Arn,
+	})
+}
+
+func (s *EventBridgeService) describeRule(ctx context.Context, input *DescribeRuleInput) (*emulator.AWSResponse, error) {
+	if input.Name == nil || *input.Name == "" {
+		return s.errorResponse(400, "ValidationException", "Name is required"), nil
+	}
+
+	rule, errResp := s.loadRule(eventBusName(input.EventBusName), *input.Name)
+	if errResp != nil {
+		return errResp, nil
+	}
+
+	return s.jsonResponse(200, ruleResponse(rule))
+}
+
+func (s *EventBridgeService) listRules(ctx context.Context, input *ListRulesInput) (*emulator.AWSResponse, error) {
+	busName := eventBusName(input.EventBusName)
+	rules, err := s.rulesForBus(busName)
+	if err != nil {
+		return s.errorResponse(500, "InternalException", "Failed to list rules"), nil
+	}
+
+	var matching []Rule
+	for _, rule := range rules {
+		if input.NamePrefix == nil || strings.HasPrefix(rule.Name, *input.NamePrefix) {
+			matching = append(matching, rule)
+		}
+	}
+
+	// Rules are sorted by name, and the token holds the name of the next rule
+	page, nextToken, err := paginate("events:ListRules", len(matching), func(i int) string { return matching[i].Name }, input.Limit, input.NextToken)
+	if err != nil {
+		return s.errorResponse(400, "ValidationException", "The NextToken is invalid or has expired"), nil
+	}
+	result := make([]interface{}, 0, len(page))
+	for _, i := range page {
+		result = append(result, ruleResponse(&matching[i]))
+	}
+
+	response := map[string]interface{}{
+		"Rules": result,
+	}
+	if nextToken != "" {
+		response["NextToken"] = nextToken
+	}
+	return s.jsonResponse(200, response)
+}
+
+func (s *EventBridgeService) deleteRule(ctx context.Context, input *DeleteRuleInput) (*emulator.AWSResponse, error) {
+	if input.Name == nil || *input.Name == "" {
+		return s.errorResponse(400, "ValidationException", "Name is required"), nil
+	}
+	busName := eventBusName(input.EventBusName)
+
+	// Deleting a rule that doesn't exist succeeds
+	if !s.state.Exists(ruleStateKey(busName, *input.Name)) {
+		return s.jsonResponse(200, map[string]interface{}{})
+	}
+
+	targets := s.loadTargets(busName, *input.Name)
+	if len(targets.Targets) > 0 && !input.Force {
+		return s.errorResponse(400, "ValidationException", "Rule can't be deleted since it has targets."), nil
+	}
+
+	_ = s.state.Delete(targetsStateKey(busName, *input.Name))
+	if err := s.state.Delete(ruleStateKey(busName, *input.Name)); err != nil {
+		return s.errorResponse(500, "InternalException", "Failed to delete rule"), nil
+	}
+
+	return s.jsonResponse(200, map[string]interface{}{})
+}
+
+func (s *EventBridgeService) putTargets(ctx context.Context, input *PutTargetsInput) (*emulator.AWSResponse, error) {
+	if input.Rule == nil || *input.Rule == "" {
+		return s.errorResponse(400, "ValidationException", "Rule is required"), nil
+	}
+	if len(input.Targets) == 0 {
+		return s.errorResponse(400, "ValidationException", "Targets is required"), nil
+	}
+	busName := eventBusName(input.EventBusName)
+
+	if _, errResp := s.loadRule(busName, *input.Rule); errResp != nil {
+		return errResp, nil
+	}
+
+	targets := s.loadTargets(busName, *input.Rule)
+	failed := []interface{}{}
+	for _, target := range input.Targets {
+		if target.Id == "" || target.Arn == "" {
+			failed = append(failed, map[string]interface{}{
+				"TargetId":     target.Id,
+				"ErrorCode":    "ValidationException",
+				"ErrorMessage": "Target Id and Arn are required",
+			})
+			continue
+		}
+
+		// A target with an existing Id replaces it
+		replaced := false
+		for i := range targets.Targets {
+			if targets.Targets[i].Id == target.Id {
+				targets.Targets[i] = target
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			targets.Targets = append(targets.Targets, target)
+		}
+	}
+
+	if err := s.state.Set(targetsStateKey(busName, *input.Rule), &targets); err != nil {
+		return s.errorResponse(500, "InternalException", "Failed to store targets"), nil
+	}
+
+	return s.jsonResponse(200, map[string]interface{}{
+		"FailedEntryCount": len(failed),
+		"FailedEntries":    failed,
+	})
+}
+
+func (s *EventBridgeService) listTargetsByRule(ctx context.Context, input *ListTargetsByRuleInput) (*emulator.AWSResponse, error) {
+	if input.Rule == nil || *input.Rule == "" {
+		return s.errorResponse(400, "ValidationException", "Rule is required"), nil
+	}
+	busName := eventBusName(input.EventBusName)
+
+	if _, errResp := s.loadRule(busName, *input.Rule); errResp != nil {
+		return errResp, nil
+	}
+
+	targets := s.loadTargets(busName, *input.Rule).Targets
+	sort.Slice(targets, func(i, j int) bool { return targets[i].Id < targets[j].Id })
+
+	page, nextToken, err := paginate("events:ListTargetsByRule", len(targets), func(i int) string { return targets[i].Id }, input.Limit, input.NextToken)
+	if err != nil {
+		return s.errorResponse(400, "ValidationException", "The NextToken is invalid or has expired"), nil
+	}
+	result := make([]Target, 0, len(page))
+	for _, i := range page {
+		result = append(result, targets[i])
+	}
+
+	response := map[string]interface{}{
+		"Targets": result,
+	}
+	if nextToken != "" {
+		response["NextToken"] = nextToken
+	}
+	return s.jsonResponse(200, response)
+}
+
+func (s *EventBridgeService) removeTargets(ctx context.Context, input *RemoveTargetsInput) (*emulator.AWSResponse, error) {
+	if input.Rule == nil || *input.Rule == "" {
+		return s.errorResponse(400, "ValidationException", "Rule is required"), nil
+	}
+	if len(input.Ids) == 0 {
+		return s.errorResponse(400, "ValidationException", "Ids is required"), nil
+	}
+	busName := eventBusName(input.EventBusName)
+
+	if _, errResp := s.loadRule(busName, *input.Rule); errResp != nil {
+		return errResp, nil
+	}
+
+	remove := make(map[string]bool, len(input.Ids))
+	for _, id := range input.Ids {
+		remove[id] = true
+	}
+
+	targets := s.loadTargets(busName, *input.Rule)
+	kept := make([]Target, 0, len(targets.Targets))
+	for _, target := range targets.Targets {
+		if !remove[target.Id] {
+			kept = append(kept, target)
+		}
+	}
+	targets.Targets = kept
+
+	if err := s.state.Set(targetsStateKey(busName, *input.Rule), &targets); err != nil {
+		return s.errorResponse(500, "InternalException", "Failed to store targets"), nil
+	}
+
+	return s.jsonResponse(200, map[string]interface{}{
+		"FailedEntryCount": 0,
+		"FailedEntries":    []interface{}{},
+	})
+}
+
+func (s *EventBridgeService) putEvents(ctx context.Context, input *PutEventsInput) (*emulator.AWSResponse, error) {
+	if len(input.Entries) == 0 {
+		return s.errorResponse(400, "ValidationException", "Entries is required"), nil
+	}
+	if len(input.Entries) > maxPutEventsEntries {
+		return s.errorResponse(400, "ValidationException", fmt.Sprintf("1 validation error detected: Value at 'entries' failed to satisfy constraint: Member must have length less than or equal to %d", maxPutEventsEntries)), nil
+	}
+
+	scope := emulator.RequestScopeFromContext(ctx)
+	entries := make([]interface{}, 0, len(input.Entries))
+	failedCount := 0
+	for _, entry := range input.Entries {
+		event, err := buildEvent(entry, scope)
+		if err != nil {
+			failedCount++
+			entries = append(entries, map[string]interface{}{
+				"ErrorCode":    "InvalidArgument",
+				"ErrorMessage": err.Error(),
+			})
+			continue
+		}
+
+		if err := s.routeEvent(ctx, eventBusName(entry.EventBusName), event); err != nil {
+			return s.errorResponse(500, "InternalException", err.Error()), nil
+		}
+		entries = append(entries, map[string]interface{}{
+			"EventId": event["id"],
+		})
+	}
+
+	return s.jsonResponse(200, map[string]interface{}{
+		"FailedEntryCount": failedCount,
+		"Entries":          entries,
+	})
+}
+
+// routeEvent delivers an event to the targets of every enabled rule on the bus whose pattern
+// matches it. Delivery failures are dropped, as they are asynchronous in EventBridge.
+func (s *EventBridgeService) routeEvent(ctx context.Context, busName string, event map[string]interface{}) error {
+	rules, err := s.rulesForBus(busName)
+	if err != nil {
+		return fmt.Errorf("failed to load rules: %w", err)
+	}
+
+	for _, rule := range rules {
+		if rule.State != "ENABLED" || rule.EventPattern == "" {
+			continue
+		}
+		matched, err := matchesPattern(rule.EventPattern, event)
+		if err != nil || !matched {
+			continue
+		}
+		for _, target := range s.loadTargets(busName, rule.Name).Targets {
+			_ = s.deliver(ctx, target, event)
+		}
+	}
+	return nil
+}
+
+func (s *EventBridgeService) listTagsForResource(ctx context.Context, input *ListTagsForResourceInput) (*emulator.AWSResponse, error) {
+	if input.ResourceARN == nil || *input.ResourceARN == "" {
+		return s.errorResponse(400, "ValidationException", "ResourceARN is required"), nil
+	}
+
+	// arn:aws:events:{region}:{account}:rule/[{bus}/]{name}
+	_, resource, _ := strings.Cut(*input.ResourceARN, ":rule/")
+	busName, ruleName := defaultEventBusName, resource
+	if bus, name, ok := strings.Cut(resource, "/"); ok {
+		busName, ruleName = bus, name
+	}
+
+	rule, errResp := s.loadRule(busName, ruleName)
+	if errResp != nil {
+		return errResp, nil
+	}
+
+	tags := make([]interface{}, 0, len(rule.Tags))
+	keys := make([]string, 0, len(rule.Tags))
+	for key := range rule.Tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		tags = append(tags, map[string]string{"Key": key, "Value": rule.Tags[key]})
+	}
+
+	return s.jsonResponse(200, map[string]interface{}{
+		"Tags": tags,
+	})
+}
+
+// buildEvent converts a PutEvents entry into the event that rules match and targets receive
+func buildEvent(entry PutEventsRequestEntry, scope emulator.RequestScope) (map[string]interface{}, error) {
+	if entry.Source == nil || *entry.Source == "" {
+		return nil, fmt.Errorf("Parameter Source is not valid. Reason: Source is a required argument.")
+	}
+	if entry.DetailType == nil || *entry.DetailType == "" {
+		return nil, fmt.Errorf("Parameter DetailType is not valid. Reason: DetailType is a required argument.")
+	}
+	if entry.Detail == nil || *entry.Detail == "" {
+		return nil, fmt.Errorf("Parameter Detail is not valid. Reason: Detail is a required argument.")
+	}
+	var detail map[string]interface{}
+	if err := json.Unmarshal([]byte(*entry.Detail), &detail); err != nil {
+		return nil, fmt.Errorf("Parameter Detail is not valid. Reason: Detail must be a JSON object.")
+	}
+
+	eventTime := time.Now().UTC()
+	if entry.Time != nil {
+		eventTime = time.Unix(int64(*entry.Time), 0).UTC()
+	}
+	resources := make([]interface{}, 0, len(entry.Resources))
+	for _, resource := range entry.Resources {
+		resources = append(resources, resource)
+	}
+
+	return map[string]interface{}{
+		"version":     "0",
+		"id":          uuid.New().String(),
+		"detail-type": *entry.DetailType,
+		"source":      *entry.Source,
+		"account":     scope.AccountID,
+		"time":        eventTime.Format(time.RFC3339),
+		"region":      scope.Region,
+		"resources":   resources,
+		"detail":      detail,
+	}, nil
+}
+
+// loadRule reads a rule, or returns a ResourceNotFoundException response
+func (s *EventBridgeService) loadRule(busName, name string) (*Rule, *emulator.AWSResponse) {
+	var rule Rule
+	if err := s.state.Get(ruleStateKey(busName, name), &rule); err != nil {
+		return nil, s.errorResponse(400, "ResourceNotFoundException", fmt.Sprintf("Rule %s does not exist on EventBus %s.", name, busName))
+	}
+	return &rule, nil
+}
+
+func (s *EventBridgeService) loadTargets(busName, ruleName string) RuleTargets {
+	var targets RuleTargets
+	if err := s.state.Get(targetsStateKey(busName, ruleName), &targets); err != nil {
+		return RuleTargets{Targets: []Target{}}
+	}
+	return targets
+}
+
+// rulesForBus returns the rules of an event bus sorted by name
+func (s *EventBridgeService) rulesForBus(busName string) ([]Rule, error) {
+	keys, err := s.state.List(fmt.Sprintf("events:rule:%s:", busName))
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]Rule, 0, len(keys))
+	for _, key := range keys {
+		var rule Rule
+		if err := s.state.Get(key, &rule); err == nil {
+			rules = append(rules, rule)
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+	return rules, nil
+}
+
+func ruleResponse(rule *Rule) map[string]interface{} {
+	response := map[string]interface{}{
+		"Name":         rule.Name,
+		"Arn":          rule.Arn,
+		"EventBusName": rule.EventBusName,
+		"State":        rule.State,
+	}
+	if rule.EventPattern != "" {
+		response["EventPattern"] = rule.EventPattern
+	}
+	if rule.ScheduleExpression != "" {
+		response["ScheduleExpression"] = rule.ScheduleExpression
+	}
+	if rule.Description != "" {
+		response["Description"] = rule.Description
+	}
+	if rule.RoleArn != "" {
+		response["RoleArn"] = rule.RoleArn
+	}
+	return response
+}
+
+// paginate returns the indexes of one page of n sorted items, and the token of the next page.
+// The token is the key of the first item on the next page.
+func paginate(operation string, n int, key func(int) string, limit *int32, nextToken *string) ([]int, string, error) {
+	start := 0
+	if nextToken != nil && *nextToken != "" {
+		position, err := emulator.DecodePageToken(operation, *nextToken)
+		if err != nil {
+			return nil, "", err
+		}
+		start = sort.Search(n, func(i int) bool { return key(i) >= position })
+	}
+	pageSize := defaultListLimit
+	if limit != nil && *limit > 0 {
+		pageSize = int(*limit)
+	}
+	end := start + pageSize
+	if end > n {
+		end = n
+	}
+
+	page := make([]int, 0, end-start)
+	for i := start; i < end; i++ {
+		page = append(page, i)
+	}
+	if end < n {
+		return page, emulator.EncodePageToken(operation, key(end)), nil
+	}
+	return page, "", nil
+}
+
+// eventBusName resolves an EventBusName parameter, which may be a name or an ARN
+func eventBusName(name *string) string {
+	if name == nil || *name == "" {
+		return defaultEventBusName
+	}
+	if _, bus, ok := strings.Cut(*name, ":event-bus/"); ok {
+		return bus
+	}
+	return *name
+}
+
+func ruleStateKey(busName, name string) string {
+	return fmt.Sprintf("events:rule:%s:%s", busName, name)
+}
+
+func targetsStateKey(busName, ruleName string) string {
+	return fmt.Sprintf("events:targets:%s:%s", busName, ruleName)
+}
+
+func ruleArn(ctx context.Context, busName, name string) string {
+	scope := emulator.RequestScopeFromContext(ctx)
+	if busName == defaultEventBusName {
+		return fmt.Sprintf("arn:aws:events:%s:%s:rule/%s", scope.Region, scope.AccountID, name)
+	}
+	return fmt.Sprintf("arn:aws:events:%s:%s:rule/%s/%s", scope.Region, scope.AccountID, busName, name)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func (s *EventBridgeService) jsonResponse(statusCode int, data interface{}) (*emulator.AWSResponse, error) {
+	resp, err := emulator.BuildJSONResponse(statusCode, data)
+	if err != nil {
+		return s.errorResponse(500, "InternalException", "Failed to marshal response"), nil
+	}
+	return resp, nil
+}
+
+func (s *EventBridgeService) errorResponse(statusCode int, code, message string) *emulator.AWSResponse {
+	return emulator.BuildJSONErrorResponse(statusCode, code, message)
+}
+
+var (
+	_ emulator.Service = (*EventBridgeService)(nil)
+)
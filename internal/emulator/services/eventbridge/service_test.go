@@ -0,0 +1,161 @@
+package eventbridge
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+	"github.com/robmorgan/infraspec/internal/emulator/services/sqs"
+)
+
+// call sends a JSON request for action and decodes the response body
+func call(t *testing.T, service emulator.Service, action string, input interface{}) (int, map[string]interface{}) {
+	t.Helper()
+	body, err := json.Marshal(input)
+	require.NoError(t, err)
+	resp, err := service.HandleRequest(context.Background(), &emulator.AWSRequest{
+		Method: "POST",
+		Action: action,
+		Headers: map[string]string{
+			"Content-Type": "application/x-amz-json-1.1",
+			"X-Amz-Target": "AWSEvents." + action,
+		},
+		Body: body,
+	})
+	require.NoError(t, err)
+	var out map[string]interface{}
+	require.NoError(t, json.Unmarshal(resp.Body, &out))
+	return resp.StatusCode, out
+}
+
+func TestRuleLifecycle(t *testing.T) {
+	service := NewEventBridgeService(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+
+	status, out := call(t, service, "PutRule", map[string]interface{}{
+		"Name":         "orders",
+		"EventPattern": `{"source": ["com.example.orders"]}`,
+		"Tags":         []map[string]string{{"Key": "team", "Value": "payments"}},
+	})
+	require.Equal(t, 200, status, out)
+	assert.Equal(t, "arn:aws:events:us-east-1:123456789012:rule/orders", out["RuleArn"])
+
+	status, out = call(t, service, "DescribeRule", map[string]string{"Name": "orders"})
+	require.Equal(t, 200, status, out)
+	assert.Equal(t, "ENABLED", out["State"])
+	assert.Equal(t, "default", out["EventBusName"])
+
+	status, out = call(t, service, "ListTagsForResource", map[string]string{"ResourceARN": "arn:aws:events:us-east-1:123456789012:rule/orders"})
+	require.Equal(t, 200, status, out)
+	assert.Equal(t, []interface{}{map[string]interface{}{"Key": "team", "Value": "payments"}}, out["Tags"])
+
+	status, out = call(t, service, "PutTargets", map[string]interface{}{
+		"Rule":    "orders",
+		"Targets": []map[string]string{{"Id": "queue", "Arn": "arn:aws:sqs:us-east-1:123456789012:orders"}},
+	})
+	require.Equal(t, 200, status, out)
+	assert.Equal(t, float64(0), out["FailedEntryCount"])
+
+	status, out = call(t, service, "ListTargetsByRule", map[string]string{"Rule": "orders"})
+	require.Equal(t, 200, status, out)
+	assert.Len(t, out["Targets"], 1)
+
+	// A rule with targets can't be deleted
+	status, _ = call(t, service, "DeleteRule", map[string]string{"Name": "orders"})
+	assert.Equal(t, 400, status)
+
+	status, out = call(t, service, "RemoveTargets", map[string]interface{}{"Rule": "orders", "Ids": []string{"queue"}})
+	require.Equal(t, 200, status, out)
+	status, out = call(t, service, "DeleteRule", map[string]string{"Name": "orders"})
+	require.Equal(t, 200, status, out)
+
+	status, out = call(t, service, "ListRules", map[string]string{})
+	require.Equal(t, 200, status, out)
+	assert.Empty(t, out["Rules"])
+}
+
+func TestPutRule_InvalidPattern(t *testing.T) {
+	service := NewEventBridgeService(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+
+	status, out := call(t, service, "PutRule", map[string]string{"Name": "bad", "EventPattern": `{"source": "not-a-list"}`})
+	assert.Equal(t, 400, status)
+	assert.Equal(t, "InvalidEventPatternException", out["__type"])
+
+	status, _ = call(t, service, "PutRule", map[string]string{"Name": "empty"})
+	assert.Equal(t, 400, status)
+}
+
+func TestListRules_Pagination(t *testing.T) {
+	service := NewEventBridgeService(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	for _, name := range []string{"c", "a", "b", "other"} {
+		status, out := call(t, service, "PutRule", map[string]string{"Name": name, "ScheduleExpression": "rate(5 minutes)"})
+		require.Equal(t, 200, status, out)
+	}
+
+	var names []string
+	input := map[string]interface{}{"NamePrefix": "", "Limit": 2}
+	for {
+		status, out := call(t, service, "ListRules", input)
+		require.Equal(t, 200, status, out)
+		for _, rule := range out["Rules"].([]interface{}) {
+			names = append(names, rule.(map[string]interface{})["Name"].(string))
+		}
+		if out["NextToken"] == nil {
+			break
+		}
+		input["NextToken"] = out["NextToken"]
+	}
+	assert.Equal(t, []string{"a", "b", "c", "other"}, names)
+}
+
+func TestPutEvents_DeliversToMatchingQueue(t *testing.T) {
+	state := emulator.NewMemoryStateManager()
+	validator := emulator.NewSchemaValidator()
+	service := NewEventBridgeService(state, validator)
+	queues := sqs.NewSQSService(state, validator)
+
+	for _, name := range []string{"orders", "audit"} {
+		status, out := call(t, queues, "CreateQueue", map[string]string{"QueueName": name})
+		require.Equal(t, 200, status, out)
+	}
+
+	call(t, service, "PutRule", map[string]string{"Name": "orders", "EventPattern": `{"source": ["com.example.orders"], "detail": {"status": ["new"]}}`})
+	call(t, service, "PutTargets", map[string]interface{}{
+		"Rule": "orders",
+		"Targets": []map[string]string{
+			{"Id": "all", "Arn": "arn:aws:sqs:us-east-1:123456789012:orders"},
+			{"Id": "detail", "Arn": "arn:aws:sqs:us-east-1:123456789012:audit", "InputPath": "$.detail"},
+		},
+	})
+
+	status, out := call(t, service, "PutEvents", map[string]interface{}{
+		"Entries": []map[string]string{
+			{"Source": "com.example.orders", "DetailType": "OrderPlaced", "Detail": `{"status": "new"}`},
+			{"Source": "com.example.orders", "DetailType": "OrderPlaced", "Detail": `{"status": "cancelled"}`},
+			{"Source": "com.example.orders", "DetailType": "OrderPlaced"},
+		},
+	})
+	require.Equal(t, 200, status, out)
+	assert.Equal(t, float64(1), out["FailedEntryCount"])
+	entries := out["Entries"].([]interface{})
+	require.Len(t, entries, 3)
+	assert.NotEmpty(t, entries[0].(map[string]interface{})["EventId"])
+	assert.Equal(t, "InvalidArgument", entries[2].(map[string]interface{})["ErrorCode"])
+
+	// Only the matching event is delivered, once to each target
+	var orders sqs.QueueMessages
+	require.NoError(t, state.Get("sqs:messages:orders", &orders))
+	require.Len(t, orders.Messages, 1)
+	var delivered map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(orders.Messages[0].Body), &delivered))
+	assert.Equal(t, "OrderPlaced", delivered["detail-type"])
+	assert.Equal(t, "123456789012", delivered["account"])
+
+	var audit sqs.QueueMessages
+	require.NoError(t, state.Get("sqs:messages:audit", &audit))
+	require.Len(t, audit.Messages, 1)
+	assert.JSONEq(t, `{"status": "new"}`, audit.Messages[0].Body)
+}
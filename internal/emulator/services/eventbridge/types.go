@@ -0,0 +1,107 @@
+package eventbridge
+
+// ============================================================================
+// Internal Storage Types
+// ============================================================================
+
+// Rule is an EventBridge rule stored in state
+type Rule struct {
+	Name               string            `json:"name"`
+	Arn                string            `json:"arn"`
+	EventBusName       string            `json:"eventBusName"`
+	EventPattern       string            `json:"eventPattern,omitempty"`
+	ScheduleExpression string            `json:"scheduleExpression,omitempty"`
+	State              string            `json:"state"`
+	Description        string            `json:"description,omitempty"`
+	RoleArn            string            `json:"roleArn,omitempty"`
+	Tags               map[string]string `json:"tags,omitempty"`
+}
+
+// RuleTargets stores the targets of a rule
+type RuleTargets struct {
+	Targets []Target `json:"targets"`
+}
+
+// Target is where a rule sends matching events. Its JSON form matches the API's.
+type Target struct {
+	Id        string  `json:"Id"`
+	Arn       string  `json:"Arn"`
+	RoleArn   *string `json:"RoleArn,omitempty"`
+	Input     *string `json:"Input,omitempty"`
+	InputPath *string `json:"InputPath,omitempty"`
+}
+
+// ============================================================================
+// Request Types
+// ============================================================================
+
+type Tag struct {
+	Key   *string `json:"Key,omitempty"`
+	Value *string `json:"Value,omitempty"`
+}
+
+type PutRuleInput struct {
+	Name               *string `json:"Name,omitempty"`
+	EventBusName       *string `json:"EventBusName,omitempty"`
+	EventPattern       *string `json:"EventPattern,omitempty"`
+	ScheduleExpression *string `json:"ScheduleExpression,omitempty"`
+	State              *string `json:"State,omitempty"`
+	Description        *string `json:"Description,omitempty"`
+	RoleArn            *string `json:"RoleArn,omitempty"`
+	Tags               []Tag   `json:"Tags,omitempty"`
+}
+
+type DescribeRuleInput struct {
+	Name         *string `json:"Name,omitempty"`
+	EventBusName *string `json:"EventBusName,omitempty"`
+}
+
+type DeleteRuleInput struct {
+	Name         *string `json:"Name,omitempty"`
+	EventBusName *string `json:"EventBusName,omitempty"`
+	Force        bool    `json:"Force,omitempty"`
+}
+
+type ListRulesInput struct {
+	NamePrefix   *string `json:"NamePrefix,omitempty"`
+	EventBusName *string `json:"EventBusName,omitempty"`
+	Limit        *int32  `json:"Limit,omitempty"`
+	NextToken    *string `json:"NextToken,omitempty"`
+}
+
+type PutTargetsInput struct {
+	Rule         *string  `json:"Rule,omitempty"`
+	EventBusName *string  `json:"EventBusName,omitempty"`
+	Targets      []Target `json:"Targets,omitempty"`
+}
+
+type ListTargetsByRuleInput struct {
+	Rule         *string `json:"Rule,omitempty"`
+	EventBusName *string `json:"EventBusName,omitempty"`
+	Limit        *int32  `json:"Limit,omitempty"`
+	NextToken    *string `json:"NextToken,omitempty"`
+}
+
+type RemoveTargetsInput struct {
+	Rule         *string  `json:"Rule,omitempty"`
+	EventBusName *string  `json:"EventBusName,omitempty"`
+	Ids          []string `json:"Ids,omitempty"`
+	Force        bool     `json:"Force,omitempty"`
+}
+
+type PutEventsRequestEntry struct {
+	Source       *string  `json:"Source,omitempty"`
+	DetailType   *string  `json:"DetailType,omitempty"`
+	Detail       *string  `json:"Detail,omitempty"`
+	EventBusName *string  `json:"EventBusName,omitempty"`
+	Resources    []string `json:"Resources,omitempty"`
+	Time         *float64 `json:"Time,omitempty"`
+}
+
+type PutEventsInput struct {
+	Entries []PutEventsRequestEntry `json:"Entries,omitempty"`
+}
+
+type ListTagsForResourceInput struct {
+	ResourceARN *string `json:"ResourceARN,omitempty"`
+}
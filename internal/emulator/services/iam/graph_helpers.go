@@ -1,9 +1,8 @@
 package iam
 
 import (
-	"log"
-
 	"github.com/robmorgan/infraspec/internal/emulator/graph"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 )
 
 // registerResource registers a resource in the relationship graph if ResourceManager is available.
@@ -18,7 +17,7 @@ func (s *IAMService) registerResource(resourceType, resourceID string, metadata
 		ID:      resourceID,
 	}
 	if err := s.resourceManager.RegisterResource(id, metadata); err != nil {
-		log.Printf("Warning: failed to register %s/%s in graph: %v", resourceType, resourceID, err)
+		logging.Warnf("iam", "Warning: failed to register %s/%s in graph: %v", resourceType, resourceID, err)
 	}
 }
 
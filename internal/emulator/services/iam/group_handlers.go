@@ -33,7 +33,7 @@ func (s *IAMService) createGroup(ctx context.Context, params map[string]interfac
 	group := XMLGroup{
 		GroupName:  groupName,
 		GroupId:    generateIAMId("AGPA"),
-		Arn:        fmt.Sprintf("arn:aws:iam::%s:group%s%s", defaultAccountID, path, groupName),
+		Arn:        iamArn(ctx, "group"+path+groupName),
 		Path:       path,
 		CreateDate: time.Now().UTC(),
 	}
@@ -176,7 +176,7 @@ func (s *IAMService) updateGroup(ctx context.Context, params map[string]interfac
 		if newPath != "" {
 			group.Path = newPath
 		}
-		group.Arn = fmt.Sprintf("arn:aws:iam::%s:group%s%s", defaultAccountID, group.Path, newGroupName)
+		group.Arn = iamArn(ctx, "group"+group.Path+newGroupName)
 
 		// Store with new key first (safer order - new key exists before old is deleted)
 		if err := s.state.Set(newStateKey, &group); err != nil {
@@ -198,7 +198,7 @@ func (s *IAMService) updateGroup(ctx context.Context, params map[string]interfac
 	} else if newPath != "" {
 		// Just updating path
 		group.Path = newPath
-		group.Arn = fmt.Sprintf("arn:aws:iam::%s:group%s%s", defaultAccountID, group.Path, groupName)
+		group.Arn = iamArn(ctx, "group"+group.Path+groupName)
 		if err := s.state.Set(stateKey, &group); err != nil {
 			return s.errorResponse(500, "InternalFailure", "Failed to update group"), nil
 		}
@@ -3,10 +3,10 @@ package iam
 import (
 	"context"
 	"fmt"
-	"log"
 
 	"github.com/robmorgan/infraspec/internal/emulator/core"
 	"github.com/robmorgan/infraspec/internal/emulator/graph"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 )
 
 // ============================================================================
@@ -65,7 +65,7 @@ func (s *IAMService) attachGroupPolicy(ctx context.Context, params map[string]in
 			s.state.Set(attachKey, &attachments)
 			return s.errorResponse(500, "InternalFailure", fmt.Sprintf("Failed to create group-policy relationship: %v", err)), nil
 		}
-		log.Printf("Warning: failed to add group-policy relationship in graph: %v", err)
+		logging.Warnf("iam", "Warning: failed to add group-policy relationship in graph: %v", err)
 	}
 
 	// Increment attachment count on policy
@@ -127,7 +127,7 @@ func (s *IAMService) detachGroupPolicy(ctx context.Context, params map[string]in
 	// Remove relationship in graph
 	policyName := extractPolicyNameFromArn(policyArn)
 	if err := s.removeRelationship("policy", policyName, "group", groupName, graph.RelAssociatedWith); err != nil {
-		log.Printf("Warning: failed to remove group-policy relationship in graph: %v", err)
+		logging.Warnf("iam", "Warning: failed to remove group-policy relationship in graph: %v", err)
 	}
 
 	// Decrement attachment count on policy
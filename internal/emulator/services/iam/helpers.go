@@ -1,10 +1,14 @@
 package iam
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"fmt"
+	"sort"
 	"strings"
+
+	"github.com/robmorgan/infraspec/internal/emulator/core"
 )
 
 // generateIAMId generates an AWS-style IAM resource ID with the given prefix
@@ -66,6 +70,46 @@ func getInt32Value(params map[string]interface{}, key string, defaultValue int32
 	return defaultValue
 }
 
+// validatePathPrefix checks a PathPrefix parameter, which like a path must begin with a slash
+func validatePathPrefix(pathPrefix string) error {
+	if pathPrefix != "" && !strings.HasPrefix(pathPrefix, "/") {
+		return fmt.Errorf("1 validation error detected: Value '%s' at 'pathPrefix' failed to satisfy constraint: Member must satisfy regular expression pattern: \\u002F[\\u0021-\\u007F]*", pathPrefix)
+	}
+	return nil
+}
+
+// paginateByName sorts items by name and returns the page of at most MaxItems (100 by default)
+// that starts at the params' Marker, along with the marker of the next page, or "" if it's the
+// last page. The marker is an opaque token, for the operation, holding the name of the first
+// item of the page.
+func paginateByName[T any](operation string, items []T, name func(T) string, params map[string]interface{}) ([]T, string, error) {
+	maxItems := getInt32Value(params, "MaxItems", 100)
+	if maxItems < 1 || maxItems > 1000 {
+		return nil, "", fmt.Errorf("1 validation error detected: Value '%d' at 'maxItems' failed to satisfy constraint: Member must have value between 1 and 1000", maxItems)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return name(items[i]) < name(items[j])
+	})
+
+	start := 0
+	if marker := getStringValue(params, "Marker"); marker != "" {
+		position, err := emulator.DecodePageToken(operation, marker)
+		if err != nil {
+			return nil, "", fmt.Errorf("Invalid Marker: the marker is malformed or has expired")
+		}
+		start = sort.Search(len(items), func(i int) bool {
+			return name(items[i]) >= position
+		})
+	}
+
+	end := start + int(maxItems)
+	if end >= len(items) {
+		return items[start:], "", nil
+	}
+	return items[start:end], emulator.EncodePageToken(operation, name(items[end])), nil
+}
+
 // roleToListItem converts an XMLRole to XMLRoleListItem for list responses
 func roleToListItem(r XMLRole) XMLRoleListItem {
 	return XMLRoleListItem{
@@ -117,3 +161,10 @@ func groupToListItem(g XMLGroup) XMLGroupListItem {
 		CreateDate: g.CreateDate,
 	}
 }
+
+// iamArn returns the ARN of an IAM resource, such as "role/path/name". The resource is owned by
+// the account the request was signed for, in the partition of the request's region.
+func iamArn(ctx context.Context, resource string) string {
+	scope := emulator.RequestScopeFromContext(ctx)
+	return fmt.Sprintf("arn:%s:iam::%s:%s", scope.Partition(), scope.AccountID, resource)
+}
@@ -3,12 +3,12 @@ package iam
 import (
 	"context"
 	"fmt"
-	"log"
 	"strings"
 	"time"
 
 	"github.com/robmorgan/infraspec/internal/emulator/core"
 	"github.com/robmorgan/infraspec/internal/emulator/graph"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 )
 
 func (s *IAMService) createInstanceProfile(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
@@ -30,7 +30,7 @@ func (s *IAMService) createInstanceProfile(ctx context.Context, params map[strin
 	profile := XMLInstanceProfile{
 		InstanceProfileName: profileName,
 		InstanceProfileId:   generateIAMId("AIPA"),
-		Arn:                 fmt.Sprintf("arn:aws:iam::%s:instance-profile%s%s", defaultAccountID, path, profileName),
+		Arn:                 iamArn(ctx, "instance-profile"+path+profileName),
 		Path:                path,
 		CreateDate:          time.Now().UTC(),
 		Roles:               []XMLRoleListItem{},
@@ -144,7 +144,7 @@ func (s *IAMService) addRoleToInstanceProfile(ctx context.Context, params map[st
 			s.state.Set(profileKey, &profile)
 			return s.errorResponse(500, "InternalFailure", fmt.Sprintf("Failed to create instance-profile-role relationship: %v", err)), nil
 		}
-		log.Printf("Warning: failed to add instance-profile-role relationship in graph: %v", err)
+		logging.Warnf("iam", "Warning: failed to add instance-profile-role relationship in graph: %v", err)
 	}
 
 	return s.successResponse("AddRoleToInstanceProfile", EmptyResult{})
@@ -190,7 +190,7 @@ func (s *IAMService) removeRoleFromInstanceProfile(ctx context.Context, params m
 
 	// Remove relationship in graph: instance-profile -> role
 	if err := s.removeRelationship("instance-profile", profileName, "role", roleName, graph.RelContains); err != nil {
-		log.Printf("Warning: failed to remove instance-profile-role relationship in graph: %v", err)
+		logging.Warnf("iam", "Warning: failed to remove instance-profile-role relationship in graph: %v", err)
 	}
 
 	return s.successResponse("RemoveRoleFromInstanceProfile", EmptyResult{})
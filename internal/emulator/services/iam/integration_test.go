@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -214,6 +215,96 @@ func TestIntegration_ListRoles(t *testing.T) {
 	}
 }
 
+func TestIntegration_ListRoles_PathPrefixAndPagination(t *testing.T) {
+	client, _, cleanup := setupIntegrationTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	trustPolicy := `{"Version": "2012-10-17", "Statement": []}`
+
+	roles := map[string]string{
+		"lambda-exec": "/service-role/",
+		"ecs-task":    "/service-role/",
+		"states-exec": "/service-role/",
+		"admin":       "/",
+		"ci-deployer": "/ci/",
+	}
+	for name, path := range roles {
+		_, err := client.CreateRole(ctx, &iam.CreateRoleInput{
+			RoleName:                 aws.String(name),
+			Path:                     aws.String(path),
+			AssumeRolePolicyDocument: aws.String(trustPolicy),
+		})
+		if err != nil {
+			t.Fatalf("CreateRole %s failed: %v", name, err)
+		}
+	}
+
+	// Page through the service roles two at a time
+	var names []string
+	var marker *string
+	pages := 0
+	for {
+		result, err := client.ListRoles(ctx, &iam.ListRolesInput{
+			PathPrefix: aws.String("/service-role/"),
+			MaxItems:   aws.Int32(2),
+			Marker:     marker,
+		})
+		if err != nil {
+			t.Fatalf("ListRoles failed: %v", err)
+		}
+		pages++
+		for _, role := range result.Roles {
+			names = append(names, aws.ToString(role.RoleName))
+		}
+		if !result.IsTruncated {
+			break
+		}
+		marker = result.Marker
+	}
+
+	if pages != 2 {
+		t.Errorf("Expected 2 pages, got %d", pages)
+	}
+	expected := []string{"ecs-task", "lambda-exec", "states-exec"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected roles %v, got %v", expected, names)
+	}
+
+	if _, err := client.ListRoles(ctx, &iam.ListRolesInput{PathPrefix: aws.String("service-role")}); err == nil {
+		t.Error("Expected an error for a path prefix without a leading slash")
+	}
+
+	if _, err := client.ListRoles(ctx, &iam.ListRolesInput{Marker: aws.String("lambda-exec")}); err == nil {
+		t.Error("Expected an error for a marker that wasn't returned by ListRoles")
+	}
+}
+
+func TestIntegration_ListUsers_PathPrefix(t *testing.T) {
+	client, _, cleanup := setupIntegrationTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	for name, path := range map[string]string{"alice": "/engineering/", "bob": "/engineering/platform/", "carol": "/sales/"} {
+		if _, err := client.CreateUser(ctx, &iam.CreateUserInput{UserName: aws.String(name), Path: aws.String(path)}); err != nil {
+			t.Fatalf("CreateUser %s failed: %v", name, err)
+		}
+	}
+
+	result, err := client.ListUsers(ctx, &iam.ListUsersInput{PathPrefix: aws.String("/engineering/")})
+	if err != nil {
+		t.Fatalf("ListUsers failed: %v", err)
+	}
+	if len(result.Users) != 2 || aws.ToString(result.Users[0].UserName) != "alice" || aws.ToString(result.Users[1].UserName) != "bob" {
+		t.Errorf("Expected users alice and bob, got %v", result.Users)
+	}
+	if result.IsTruncated {
+		t.Error("Expected the list not to be truncated")
+	}
+}
+
 func TestIntegration_CreateAndGetPolicy(t *testing.T) {
 	client, _, cleanup := setupIntegrationTest(t)
 	defer cleanup()
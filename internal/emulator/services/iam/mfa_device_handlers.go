@@ -34,7 +34,7 @@ func (s *IAMService) createVirtualMFADevice(ctx context.Context, params map[stri
 	}
 
 	// Generate serial number
-	serialNumber := fmt.Sprintf("arn:aws:iam::%s:mfa/%s%s", defaultAccountID, path[1:], virtualMFADeviceName)
+	serialNumber := iamArn(ctx, "mfa/"+path[1:]+virtualMFADeviceName)
 
 	// Check if device already exists
 	stateKey := fmt.Sprintf("iam:mfa-device:%s", serialNumber)
@@ -57,7 +57,7 @@ func (s *IAMService) createOpenIDConnectProvider(ctx context.Context, params map
 	// Parse tags if provided
 	tags := s.parseTags(params)
 
-	arn := generateOIDCProviderArn(url)
+	arn := generateOIDCProviderArn(ctx, url)
 	now := time.Now().UTC()
 
 	provider := OIDCProviderData{
@@ -3,10 +3,10 @@ package iam
 import (
 	"context"
 	"fmt"
-	"log"
 
 	"github.com/robmorgan/infraspec/internal/emulator/core"
 	"github.com/robmorgan/infraspec/internal/emulator/graph"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 )
 
 func (s *IAMService) attachRolePolicy(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
@@ -69,7 +69,7 @@ func (s *IAMService) attachRolePolicy(ctx context.Context, params map[string]int
 				s.state.Set(attachKey, &attachments)
 				return s.errorResponse(500, "InternalFailure", fmt.Sprintf("Failed to create role-policy relationship: %v", err)), nil
 			}
-			log.Printf("Warning: failed to add role-policy relationship in graph: %v", err)
+			logging.Warnf("iam", "Warning: failed to add role-policy relationship in graph: %v", err)
 		}
 
 		// Increment attachment count on policy atomically (only for customer-managed policies)
@@ -137,7 +137,7 @@ func (s *IAMService) detachRolePolicy(ctx context.Context, params map[string]int
 	if !isAWSManaged {
 		// Remove relationship in graph: policy -> role
 		if err := s.removeRelationship("policy", policyName, "role", roleName, graph.RelAssociatedWith); err != nil {
-			log.Printf("Warning: failed to remove role-policy relationship in graph: %v", err)
+			logging.Warnf("iam", "Warning: failed to remove role-policy relationship in graph: %v", err)
 		}
 
 		// Decrement attachment count on policy atomically
@@ -30,7 +30,7 @@ func (s *IAMService) createPolicy(ctx context.Context, params map[string]interfa
 		path = "/"
 	}
 
-	policyArn := fmt.Sprintf("arn:aws:iam::%s:policy%s%s", defaultAccountID, path, policyName)
+	policyArn := iamArn(ctx, "policy"+path+policyName)
 
 	// Check if policy already exists
 	stateKey := fmt.Sprintf("iam:policy:%s:%s", defaultAccountID, policyName)
@@ -403,3 +403,21 @@ func TestGetServiceLinkedRoleDeletionStatus_NotFound(t *testing.T) {
 	require.Equal(t, 404, resp.StatusCode)
 	require.Contains(t, string(resp.Body), "NoSuchEntity")
 }
+
+func TestCreateRole_ArnUsesRequestAccount(t *testing.T) {
+	state := emulator.NewMemoryStateManager()
+	validator := emulator.NewSchemaValidator()
+	service := NewIAMService(state, validator)
+
+	ctx := emulator.WithRequestScope(context.Background(), emulator.RequestScope{AccountID: "111122223333", Region: "eu-west-1"})
+	req := &emulator.AWSRequest{
+		Method:  "POST",
+		Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+		Body:    []byte(`Action=CreateRole&RoleName=ScopedRole&AssumeRolePolicyDocument={"Version":"2012-10-17","Statement":[]}`),
+		Action:  "CreateRole",
+	}
+	resp, err := service.HandleRequest(ctx, req)
+	require.NoError(t, err)
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	require.Contains(t, string(resp.Body), "<Arn>arn:aws:iam::111122223333:role/ScopedRole</Arn>")
+}
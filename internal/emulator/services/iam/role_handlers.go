@@ -43,7 +43,7 @@ func (s *IAMService) createRole(ctx context.Context, params map[string]interface
 	role := XMLRole{
 		RoleName:                 roleName,
 		RoleId:                   generateIAMId("AROA"),
-		Arn:                      fmt.Sprintf("arn:aws:iam::%s:role%s%s", defaultAccountID, path, roleName),
+		Arn:                      iamArn(ctx, "role"+path+roleName),
 		Path:                     path,
 		AssumeRolePolicyDocument: assumeRolePolicyDocument,
 		Description:              description,
@@ -148,6 +148,9 @@ func (s *IAMService) updateAssumeRolePolicy(ctx context.Context, params map[stri
 
 func (s *IAMService) listRoles(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
 	pathPrefix := getStringValue(params, "PathPrefix")
+	if err := validatePathPrefix(pathPrefix); err != nil {
+		return s.errorResponse(400, "ValidationError", err.Error()), nil
+	}
 
 	keys, err := s.state.List("iam:role:")
 	if err != nil {
@@ -164,9 +167,15 @@ func (s *IAMService) listRoles(ctx context.Context, params map[string]interface{
 		}
 	}
 
+	roles, marker, err := paginateByName("iam:ListRoles", roles, func(r XMLRoleListItem) string { return r.RoleName }, params)
+	if err != nil {
+		return s.errorResponse(400, "ValidationError", err.Error()), nil
+	}
+
 	result := ListRolesResult{
 		Roles:       roles,
-		IsTruncated: false,
+		IsTruncated: marker != "",
+		Marker:      marker,
 	}
 	return s.successResponse("ListRoles", result)
 }
@@ -403,7 +412,7 @@ func (s *IAMService) createServiceLinkedRole(ctx context.Context, params map[str
 	role := XMLRole{
 		RoleName:                 roleName,
 		RoleId:                   generateIAMId("AROA"),
-		Arn:                      fmt.Sprintf("arn:aws:iam::%s:role%s%s", defaultAccountID, path, roleName),
+		Arn:                      iamArn(ctx, "role"+path+roleName),
 		Path:                     path,
 		AssumeRolePolicyDocument: assumeRolePolicyDocument,
 		Description:              description,
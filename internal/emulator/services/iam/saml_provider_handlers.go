@@ -43,7 +43,7 @@ func (s *IAMService) createSAMLProvider(ctx context.Context, params map[string]i
 	// Parse tags if provided
 	tags := s.parseTags(params)
 
-	arn := fmt.Sprintf("arn:aws:iam::%s:saml-provider/%s", defaultAccountID, name)
+	arn := iamArn(ctx, "saml-provider/"+name)
 	now := time.Now().UTC()
 
 	// Calculate ValidUntil from metadata (simplified - in real AWS this parses the XML)
@@ -221,11 +221,11 @@ func extractSAMLProviderNameFromArn(arn string) string {
 }
 
 // generateOIDCProviderArn generates an ARN for an OIDC provider based on URL
-func generateOIDCProviderArn(url string) string {
+func generateOIDCProviderArn(ctx context.Context, url string) string {
 	// Remove protocol prefix
 	url = strings.TrimPrefix(url, "https://")
 	url = strings.TrimPrefix(url, "http://")
-	return fmt.Sprintf("arn:aws:iam::%s:oidc-provider/%s", defaultAccountID, url)
+	return iamArn(ctx, "oidc-provider/"+url)
 }
 
 // generateOIDCProviderStateKey generates a state key from the URL (hashed for safety)
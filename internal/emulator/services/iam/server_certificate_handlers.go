@@ -50,7 +50,7 @@ func (s *IAMService) uploadServerCertificate(ctx context.Context, params map[str
 
 	// Generate certificate ID
 	certId := generateServerCertificateId()
-	arn := fmt.Sprintf("arn:aws:iam::%s:server-certificate%s%s", defaultAccountID, path, serverCertificateName)
+	arn := iamArn(ctx, "server-certificate"+path+serverCertificateName)
 	now := time.Now().UTC()
 
 	// Parse expiration from certificate (simplified - in real AWS this parses the X.509 cert)
@@ -220,7 +220,7 @@ func (s *IAMService) updateServerCertificate(ctx context.Context, params map[str
 	}
 
 	// Update ARN
-	cert.Arn = fmt.Sprintf("arn:aws:iam::%s:server-certificate%s%s", defaultAccountID, cert.Path, cert.ServerCertificateName)
+	cert.Arn = iamArn(ctx, "server-certificate"+cert.Path+cert.ServerCertificateName)
 
 	// Handle rename: create new key first, then delete old (safer order)
 	if newName != "" && newName != serverCertificateName {
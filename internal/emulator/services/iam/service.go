@@ -45,6 +45,16 @@ func (s *IAMService) ServiceName() string {
 	return "iam"
 }
 
+// Protocol returns the AWS protocol the service speaks
+func (s *IAMService) Protocol() emulator.ProtocolType {
+	return emulator.ProtocolQuery
+}
+
+// ResponseTypes returns constructors for the CloudMirror-generated response types, keyed by action.
+func (s *IAMService) ResponseTypes() map[string]func() interface{} {
+	return smithyResponseTypes
+}
+
 // SupportedActions returns the list of AWS API actions this service handles.
 // Used by the router to determine which service handles a given Query Protocol request.
 func (s *IAMService) SupportedActions() []string {
@@ -519,3 +529,9 @@ func (s *IAMService) parseFormData(body string) (map[string]interface{}, error)
 
 	return params, nil
 }
+
+var (
+	_ emulator.Service              = (*IAMService)(nil)
+	_ emulator.ActionProvider       = (*IAMService)(nil)
+	_ emulator.ResponseTypeProvider = (*IAMService)(nil)
+)
@@ -11,6 +11,107 @@ import (
 	"time"
 )
 
+// smithyResponseTypes maps operation names to constructors for their generated response types.
+var smithyResponseTypes = map[string]func() interface{}{
+	"CreateAccessKey":                               func() interface{} { return &CreateAccessKeyResponse{} },
+	"CreateDelegationRequest":                       func() interface{} { return &CreateDelegationRequestResponse{} },
+	"CreateGroup":                                   func() interface{} { return &CreateGroupResponse{} },
+	"CreateInstanceProfile":                         func() interface{} { return &CreateInstanceProfileResponse{} },
+	"CreateLoginProfile":                            func() interface{} { return &CreateLoginProfileResponse{} },
+	"CreateOpenIDConnectProvider":                   func() interface{} { return &CreateOpenIDConnectProviderResponse{} },
+	"CreatePolicy":                                  func() interface{} { return &CreatePolicyResponse{} },
+	"CreatePolicyVersion":                           func() interface{} { return &CreatePolicyVersionResponse{} },
+	"CreateRole":                                    func() interface{} { return &CreateRoleResponse{} },
+	"CreateSAMLProvider":                            func() interface{} { return &CreateSAMLProviderResponse{} },
+	"CreateServiceLinkedRole":                       func() interface{} { return &CreateServiceLinkedRoleResponse{} },
+	"CreateServiceSpecificCredential":               func() interface{} { return &CreateServiceSpecificCredentialResponse{} },
+	"CreateUser":                                    func() interface{} { return &CreateUserResponse{} },
+	"CreateVirtualMFADevice":                        func() interface{} { return &CreateVirtualMFADeviceResponse{} },
+	"DeleteServiceLinkedRole":                       func() interface{} { return &DeleteServiceLinkedRoleResponse{} },
+	"DisableOrganizationsRootCredentialsManagement": func() interface{} { return &DisableOrganizationsRootCredentialsManagementResponse{} },
+	"DisableOrganizationsRootSessions":              func() interface{} { return &DisableOrganizationsRootSessionsResponse{} },
+	"EnableOrganizationsRootCredentialsManagement":  func() interface{} { return &EnableOrganizationsRootCredentialsManagementResponse{} },
+	"EnableOrganizationsRootSessions":               func() interface{} { return &EnableOrganizationsRootSessionsResponse{} },
+	"EnableOutboundWebIdentityFederation":           func() interface{} { return &EnableOutboundWebIdentityFederationResponse{} },
+	"GenerateCredentialReport":                      func() interface{} { return &GenerateCredentialReportResponse{} },
+	"GenerateOrganizationsAccessReport":             func() interface{} { return &GenerateOrganizationsAccessReportResponse{} },
+	"GenerateServiceLastAccessedDetails":            func() interface{} { return &GenerateServiceLastAccessedDetailsResponse{} },
+	"GetAccessKeyLastUsed":                          func() interface{} { return &GetAccessKeyLastUsedResponse{} },
+	"GetAccountAuthorizationDetails":                func() interface{} { return &GetAccountAuthorizationDetailsResponse{} },
+	"GetAccountPasswordPolicy":                      func() interface{} { return &GetAccountPasswordPolicyResponse{} },
+	"GetAccountSummary":                             func() interface{} { return &GetAccountSummaryResponse{} },
+	"GetContextKeysForCustomPolicy":                 func() interface{} { return &GetContextKeysForPolicyResponse{} },
+	"GetContextKeysForPrincipalPolicy":              func() interface{} { return &GetContextKeysForPolicyResponse{} },
+	"GetCredentialReport":                           func() interface{} { return &GetCredentialReportResponse{} },
+	"GetDelegationRequest":                          func() interface{} { return &GetDelegationRequestResponse{} },
+	"GetGroup":                                      func() interface{} { return &GetGroupResponse{} },
+	"GetGroupPolicy":                                func() interface{} { return &GetGroupPolicyResponse{} },
+	"GetHumanReadableSummary":                       func() interface{} { return &GetHumanReadableSummaryResponse{} },
+	"GetInstanceProfile":                            func() interface{} { return &GetInstanceProfileResponse{} },
+	"GetLoginProfile":                               func() interface{} { return &GetLoginProfileResponse{} },
+	"GetMFADevice":                                  func() interface{} { return &GetMFADeviceResponse{} },
+	"GetOpenIDConnectProvider":                      func() interface{} { return &GetOpenIDConnectProviderResponse{} },
+	"GetOrganizationsAccessReport":                  func() interface{} { return &GetOrganizationsAccessReportResponse{} },
+	"GetOutboundWebIdentityFederationInfo":          func() interface{} { return &GetOutboundWebIdentityFederationInfoResponse{} },
+	"GetPolicy":                                     func() interface{} { return &GetPolicyResponse{} },
+	"GetPolicyVersion":                              func() interface{} { return &GetPolicyVersionResponse{} },
+	"GetRole":                                       func() interface{} { return &GetRoleResponse{} },
+	"GetRolePolicy":                                 func() interface{} { return &GetRolePolicyResponse{} },
+	"GetSAMLProvider":                               func() interface{} { return &GetSAMLProviderResponse{} },
+	"GetSSHPublicKey":                               func() interface{} { return &GetSSHPublicKeyResponse{} },
+	"GetServerCertificate":                          func() interface{} { return &GetServerCertificateResponse{} },
+	"GetServiceLastAccessedDetails":                 func() interface{} { return &GetServiceLastAccessedDetailsResponse{} },
+	"GetServiceLastAccessedDetailsWithEntities":     func() interface{} { return &GetServiceLastAccessedDetailsWithEntitiesResponse{} },
+	"GetServiceLinkedRoleDeletionStatus":            func() interface{} { return &GetServiceLinkedRoleDeletionStatusResponse{} },
+	"GetUser":                                       func() interface{} { return &GetUserResponse{} },
+	"GetUserPolicy":                                 func() interface{} { return &GetUserPolicyResponse{} },
+	"ListAccessKeys":                                func() interface{} { return &ListAccessKeysResponse{} },
+	"ListAccountAliases":                            func() interface{} { return &ListAccountAliasesResponse{} },
+	"ListAttachedGroupPolicies":                     func() interface{} { return &ListAttachedGroupPoliciesResponse{} },
+	"ListAttachedRolePolicies":                      func() interface{} { return &ListAttachedRolePoliciesResponse{} },
+	"ListAttachedUserPolicies":                      func() interface{} { return &ListAttachedUserPoliciesResponse{} },
+	"ListDelegationRequests":                        func() interface{} { return &ListDelegationRequestsResponse{} },
+	"ListEntitiesForPolicy":                         func() interface{} { return &ListEntitiesForPolicyResponse{} },
+	"ListGroupPolicies":                             func() interface{} { return &ListGroupPoliciesResponse{} },
+	"ListGroups":                                    func() interface{} { return &ListGroupsResponse{} },
+	"ListGroupsForUser":                             func() interface{} { return &ListGroupsForUserResponse{} },
+	"ListInstanceProfileTags":                       func() interface{} { return &ListInstanceProfileTagsResponse{} },
+	"ListInstanceProfiles":                          func() interface{} { return &ListInstanceProfilesResponse{} },
+	"ListInstanceProfilesForRole":                   func() interface{} { return &ListInstanceProfilesForRoleResponse{} },
+	"ListMFADeviceTags":                             func() interface{} { return &ListMFADeviceTagsResponse{} },
+	"ListMFADevices":                                func() interface{} { return &ListMFADevicesResponse{} },
+	"ListOpenIDConnectProviderTags":                 func() interface{} { return &ListOpenIDConnectProviderTagsResponse{} },
+	"ListOpenIDConnectProviders":                    func() interface{} { return &ListOpenIDConnectProvidersResponse{} },
+	"ListOrganizationsFeatures":                     func() interface{} { return &ListOrganizationsFeaturesResponse{} },
+	"ListPolicies":                                  func() interface{} { return &ListPoliciesResponse{} },
+	"ListPoliciesGrantingServiceAccess":             func() interface{} { return &ListPoliciesGrantingServiceAccessResponse{} },
+	"ListPolicyTags":                                func() interface{} { return &ListPolicyTagsResponse{} },
+	"ListPolicyVersions":                            func() interface{} { return &ListPolicyVersionsResponse{} },
+	"ListRolePolicies":                              func() interface{} { return &ListRolePoliciesResponse{} },
+	"ListRoleTags":                                  func() interface{} { return &ListRoleTagsResponse{} },
+	"ListRoles":                                     func() interface{} { return &ListRolesResponse{} },
+	"ListSAMLProviderTags":                          func() interface{} { return &ListSAMLProviderTagsResponse{} },
+	"ListSAMLProviders":                             func() interface{} { return &ListSAMLProvidersResponse{} },
+	"ListSSHPublicKeys":                             func() interface{} { return &ListSSHPublicKeysResponse{} },
+	"ListServerCertificateTags":                     func() interface{} { return &ListServerCertificateTagsResponse{} },
+	"ListServerCertificates":                        func() interface{} { return &ListServerCertificatesResponse{} },
+	"ListServiceSpecificCredentials":                func() interface{} { return &ListServiceSpecificCredentialsResponse{} },
+	"ListSigningCertificates":                       func() interface{} { return &ListSigningCertificatesResponse{} },
+	"ListUserPolicies":                              func() interface{} { return &ListUserPoliciesResponse{} },
+	"ListUserTags":                                  func() interface{} { return &ListUserTagsResponse{} },
+	"ListUsers":                                     func() interface{} { return &ListUsersResponse{} },
+	"ListVirtualMFADevices":                         func() interface{} { return &ListVirtualMFADevicesResponse{} },
+	"ResetServiceSpecificCredential":                func() interface{} { return &ResetServiceSpecificCredentialResponse{} },
+	"SimulateCustomPolicy":                          func() interface{} { return &SimulatePolicyResponse{} },
+	"SimulatePrincipalPolicy":                       func() interface{} { return &SimulatePolicyResponse{} },
+	"UpdateRole":                                    func() interface{} { return &UpdateRoleResponse{} },
+	"UpdateRoleDescription":                         func() interface{} { return &UpdateRoleDescriptionResponse{} },
+	"UpdateSAMLProvider":                            func() interface{} { return &UpdateSAMLProviderResponse{} },
+	"UploadSSHPublicKey":                            func() interface{} { return &UploadSSHPublicKeyResponse{} },
+	"UploadServerCertificate":                       func() interface{} { return &UploadServerCertificateResponse{} },
+	"UploadSigningCertificate":                      func() interface{} { return &UploadSigningCertificateResponse{} },
+}
+
 // Enum type aliases
 
 type AccessAdvisorUsageGranularityType string
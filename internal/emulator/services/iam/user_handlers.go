@@ -33,7 +33,7 @@ func (s *IAMService) createUser(ctx context.Context, params map[string]interface
 	user := XMLUser{
 		UserName:   userName,
 		UserId:     generateIAMId("AIDA"),
-		Arn:        fmt.Sprintf("arn:aws:iam::%s:user%s%s", defaultAccountID, path, userName),
+		Arn:        iamArn(ctx, "user"+path+userName),
 		Path:       path,
 		CreateDate: time.Now().UTC(),
 		Tags:       s.parseTags(params),
@@ -191,7 +191,7 @@ func (s *IAMService) updateUser(ctx context.Context, params map[string]interface
 		if newPath != "" {
 			user.Path = newPath
 		}
-		user.Arn = fmt.Sprintf("arn:aws:iam::%s:user%s%s", defaultAccountID, user.Path, newUserName)
+		user.Arn = iamArn(ctx, "user"+user.Path+newUserName)
 
 		// Store with new key first (safer order - new key exists before old is deleted)
 		if err := s.state.Set(newStateKey, &user); err != nil {
@@ -213,7 +213,7 @@ func (s *IAMService) updateUser(ctx context.Context, params map[string]interface
 	} else if newPath != "" {
 		// Just updating path
 		user.Path = newPath
-		user.Arn = fmt.Sprintf("arn:aws:iam::%s:user%s%s", defaultAccountID, user.Path, userName)
+		user.Arn = iamArn(ctx, "user"+user.Path+userName)
 		if err := s.state.Set(stateKey, &user); err != nil {
 			return s.errorResponse(500, "InternalFailure", "Failed to update user"), nil
 		}
@@ -224,6 +224,9 @@ func (s *IAMService) updateUser(ctx context.Context, params map[string]interface
 
 func (s *IAMService) listUsers(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
 	pathPrefix := getStringValue(params, "PathPrefix")
+	if err := validatePathPrefix(pathPrefix); err != nil {
+		return s.errorResponse(400, "ValidationError", err.Error()), nil
+	}
 
 	keys, err := s.state.List("iam:user:")
 	if err != nil {
@@ -244,9 +247,15 @@ func (s *IAMService) listUsers(ctx context.Context, params map[string]interface{
 		}
 	}
 
+	users, marker, err := paginateByName("iam:ListUsers", users, func(u XMLUserListItem) string { return u.UserName }, params)
+	if err != nil {
+		return s.errorResponse(400, "ValidationError", err.Error()), nil
+	}
+
 	result := ListUsersResult{
 		Users:       users,
-		IsTruncated: false,
+		IsTruncated: marker != "",
+		Marker:      marker,
 	}
 	return s.successResponse("ListUsers", result)
 }
@@ -3,10 +3,10 @@ package iam
 import (
 	"context"
 	"fmt"
-	"log"
 
 	"github.com/robmorgan/infraspec/internal/emulator/core"
 	"github.com/robmorgan/infraspec/internal/emulator/graph"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 )
 
 // ============================================================================
@@ -65,7 +65,7 @@ func (s *IAMService) attachUserPolicy(ctx context.Context, params map[string]int
 			s.state.Set(attachKey, &attachments)
 			return s.errorResponse(500, "InternalFailure", fmt.Sprintf("Failed to create user-policy relationship: %v", err)), nil
 		}
-		log.Printf("Warning: failed to add user-policy relationship in graph: %v", err)
+		logging.Warnf("iam", "Warning: failed to add user-policy relationship in graph: %v", err)
 	}
 
 	// Increment attachment count on policy
@@ -127,7 +127,7 @@ func (s *IAMService) detachUserPolicy(ctx context.Context, params map[string]int
 	// Remove relationship in graph
 	policyName := extractPolicyNameFromArn(policyArn)
 	if err := s.removeRelationship("policy", policyName, "user", userName, graph.RelAssociatedWith); err != nil {
-		log.Printf("Warning: failed to remove user-policy relationship in graph: %v", err)
+		logging.Warnf("iam", "Warning: failed to remove user-policy relationship in graph: %v", err)
 	}
 
 	// Decrement attachment count on policy
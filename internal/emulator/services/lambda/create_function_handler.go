@@ -89,7 +89,7 @@ func (s *LambdaService) handleCreateFunction(ctx context.Context, req *emulator.
 	// Build the stored function
 	function := &StoredFunction{
 		FunctionName:      input.FunctionName,
-		FunctionArn:       generateFunctionArn(input.FunctionName),
+		FunctionArn:       generateFunctionArn(emulator.RequestScopeFromContext(ctx), input.FunctionName),
 		Runtime:           input.Runtime,
 		Role:              input.Role,
 		Handler:           input.Handler,
@@ -163,7 +163,7 @@ func (s *LambdaService) handleCreateFunction(ctx context.Context, req *emulator.
 			CodeSha256:   codeSha256,
 			CodeSize:     codeSize,
 			RevisionId:   generateRevisionId(),
-			FunctionArn:  generateVersionArn(input.FunctionName, version),
+			FunctionArn:  generateVersionArn(emulator.RequestScopeFromContext(ctx), input.FunctionName, version),
 			LastModified: now(),
 		}
 		function.PublishedVersions[version] = storedVersion
@@ -269,6 +269,16 @@ func (s *LambdaService) handleListEventSourceMappings(ctx context.Context, req *
 	marker := queryParams.Get("Marker")
 	maxItemsStr := queryParams.Get("MaxItems")
 
+	// The marker is an opaque token holding the last item of the previous page
+	if marker != "" {
+		position, err := emulator.DecodePageToken("lambda:ListEventSourceMappings", marker)
+		if err != nil {
+			return s.errorResponse(http.StatusBadRequest, "InvalidParameterValueException",
+				"Invalid Marker: the marker is malformed or has expired"), nil
+		}
+		marker = position
+	}
+
 	// Parse MaxItems
 	maxItems := 100
 	if maxItemsStr != "" {
@@ -344,7 +354,7 @@ func (s *LambdaService) handleListEventSourceMappings(ctx context.Context, req *
 		"EventSourceMappings": mappingResponses,
 	}
 	if nextMarker != "" {
-		response["NextMarker"] = nextMarker
+		response["NextMarker"] = emulator.EncodePageToken("lambda:ListEventSourceMappings", nextMarker)
 	}
 
 	return s.successResponse(http.StatusOK, response)
@@ -54,7 +54,7 @@ func (s *LambdaService) handleGetFunction(ctx context.Context, functionName stri
 	// Build the GetFunction response which includes Code and Configuration
 	response := map[string]interface{}{
 		"Configuration": configuration,
-		"Code":          s.buildCodeResponse(&function),
+		"Code":          s.buildCodeResponse(emulator.RequestScopeFromContext(ctx), &function),
 	}
 
 	// Add tags if present
@@ -112,7 +112,7 @@ func (s *LambdaService) handleGetFunctionConfiguration(ctx context.Context, func
 }
 
 // buildCodeResponse builds the Code section of GetFunction response
-func (s *LambdaService) buildCodeResponse(fn *StoredFunction) map[string]interface{} {
+func (s *LambdaService) buildCodeResponse(scope emulator.RequestScope, fn *StoredFunction) map[string]interface{} {
 	code := map[string]interface{}{
 		"RepositoryType": "S3",
 	}
@@ -121,7 +121,7 @@ func (s *LambdaService) buildCodeResponse(fn *StoredFunction) map[string]interfa
 	if fn.Code != nil {
 		if fn.Code.S3Bucket != "" {
 			code["Location"] = fmt.Sprintf("https://awslambda-%s-tasks.s3.%s.amazonaws.com/snapshots/%s/%s",
-				DefaultRegion, DefaultRegion, DefaultAccountID, fn.FunctionName)
+				scope.Region, scope.Region, scope.AccountID, fn.FunctionName)
 		} else if fn.Code.ImageUri != "" {
 			code["RepositoryType"] = "ECR"
 			code["ImageUri"] = fn.Code.ImageUri
@@ -129,7 +129,7 @@ func (s *LambdaService) buildCodeResponse(fn *StoredFunction) map[string]interfa
 		} else {
 			// ZipFile case - provide mock S3 location
 			code["Location"] = fmt.Sprintf("https://awslambda-%s-tasks.s3.%s.amazonaws.com/snapshots/%s/%s",
-				DefaultRegion, DefaultRegion, DefaultAccountID, fn.FunctionName)
+				scope.Region, scope.Region, scope.AccountID, fn.FunctionName)
 		}
 	}
 
@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+
+	"github.com/robmorgan/infraspec/internal/emulator/core"
 )
 
 const (
@@ -40,10 +42,6 @@ const (
 	// Invoke modes
 	InvokeModeBuffered    = "BUFFERED"
 	InvokeModeResponseStream = "RESPONSE_STREAM"
-
-	// Default account ID and region for mock
-	DefaultAccountID = "000000000000"
-	DefaultRegion    = "us-east-1"
 )
 
 // Valid runtimes (subset - not exhaustive)
@@ -78,35 +76,35 @@ var validArchitectures = map[string]bool{
 var functionNamePattern = regexp.MustCompile(`^[a-zA-Z0-9-_]+$`)
 
 // generateFunctionArn generates a Lambda function ARN
-func generateFunctionArn(functionName string) string {
+func generateFunctionArn(scope emulator.RequestScope, functionName string) string {
 	return fmt.Sprintf("arn:aws:lambda:%s:%s:function:%s",
-		DefaultRegion, DefaultAccountID, functionName)
+		scope.Region, scope.AccountID, functionName)
 }
 
 // generateVersionArn generates an ARN for a specific function version
-func generateVersionArn(functionName, version string) string {
+func generateVersionArn(scope emulator.RequestScope, functionName, version string) string {
 	return fmt.Sprintf("arn:aws:lambda:%s:%s:function:%s:%s",
-		DefaultRegion, DefaultAccountID, functionName, version)
+		scope.Region, scope.AccountID, functionName, version)
 }
 
 // generateAliasArn generates an ARN for a function alias
-func generateAliasArn(functionName, aliasName string) string {
+func generateAliasArn(scope emulator.RequestScope, functionName, aliasName string) string {
 	return fmt.Sprintf("arn:aws:lambda:%s:%s:function:%s:%s",
-		DefaultRegion, DefaultAccountID, functionName, aliasName)
+		scope.Region, scope.AccountID, functionName, aliasName)
 }
 
 // generateLayerArn generates a Lambda layer ARN
-func generateLayerArn(layerName string, version int64) string {
+func generateLayerArn(scope emulator.RequestScope, layerName string, version int64) string {
 	return fmt.Sprintf("arn:aws:lambda:%s:%s:layer:%s:%d",
-		DefaultRegion, DefaultAccountID, layerName, version)
+		scope.Region, scope.AccountID, layerName, version)
 }
 
 // generateFunctionUrl generates a function URL
-func generateFunctionUrl(functionName string) string {
+func generateFunctionUrl(scope emulator.RequestScope, functionName string) string {
 	// Real AWS format: https://<url-id>.lambda-url.<region>.on.aws/
 	// For mock, we use a simpler format
 	urlId := strings.ToLower(uuid.New().String()[:12])
-	return fmt.Sprintf("https://%s.lambda-url.%s.on.aws/", urlId, DefaultRegion)
+	return fmt.Sprintf("https://%s.lambda-url.%s.on.aws/", urlId, scope.Region)
 }
 
 // generateRevisionId generates a new revision ID
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/robmorgan/infraspec/internal/emulator/core"
 )
@@ -42,7 +43,7 @@ func (s *LambdaService) handlePublishLayerVersion(ctx context.Context, layerName
 		// Create new layer
 		layer = StoredLayer{
 			LayerName:           layerName,
-			LayerArn:            generateLayerBaseArn(layerName),
+			LayerArn:            generateLayerBaseArn(emulator.RequestScopeFromContext(ctx), layerName),
 			LatestVersionNumber: 0,
 			Versions:            make(map[int64]*StoredLayerVersion),
 		}
@@ -54,7 +55,7 @@ func (s *LambdaService) handlePublishLayerVersion(ctx context.Context, layerName
 
 	// Create layer version
 	layerVersion := &StoredLayerVersion{
-		LayerVersionArn:         generateLayerArn(layerName, version),
+		LayerVersionArn:         generateLayerArn(emulator.RequestScopeFromContext(ctx), layerName, version),
 		Version:                 version,
 		Description:             input.Description,
 		CreatedDate:             now(),
@@ -95,7 +96,7 @@ func (s *LambdaService) handleGetLayerVersion(ctx context.Context, layerName str
 			fmt.Sprintf("Layer version not found: %s:%d", layerName, versionNumber)), nil
 	}
 
-	response := s.buildLayerVersionDetailResponse(version, layerName)
+	response := s.buildLayerVersionDetailResponse(emulator.RequestScopeFromContext(ctx), version, layerName)
 	return s.successResponse(http.StatusOK, response)
 }
 
@@ -160,6 +161,16 @@ func (s *LambdaService) handleListLayers(ctx context.Context, req *emulator.AWSR
 	marker := queryParams.Get("Marker")
 	maxItemsStr := queryParams.Get("MaxItems")
 
+	// The marker is an opaque token holding the last item of the previous page
+	if marker != "" {
+		position, err := emulator.DecodePageToken("lambda:ListLayers", marker)
+		if err != nil {
+			return s.errorResponse(http.StatusBadRequest, "InvalidParameterValueException",
+				"Invalid Marker: the marker is malformed or has expired"), nil
+		}
+		marker = position
+	}
+
 	maxItems := 50
 	if maxItemsStr != "" {
 		if parsed, err := strconv.Atoi(maxItemsStr); err == nil && parsed > 0 {
@@ -256,7 +267,7 @@ func (s *LambdaService) handleListLayers(ctx context.Context, req *emulator.AWSR
 		"Layers": layerResponses,
 	}
 	if nextMarker != "" {
-		response["NextMarker"] = nextMarker
+		response["NextMarker"] = emulator.EncodePageToken("lambda:ListLayers", nextMarker)
 	}
 
 	return s.successResponse(http.StatusOK, response)
@@ -271,6 +282,16 @@ func (s *LambdaService) handleListLayerVersions(ctx context.Context, layerName s
 	marker := queryParams.Get("Marker")
 	maxItemsStr := queryParams.Get("MaxItems")
 
+	// The marker is an opaque token holding the last item of the previous page
+	if marker != "" {
+		position, err := emulator.DecodePageToken("lambda:ListLayerVersions", marker)
+		if err != nil {
+			return s.errorResponse(http.StatusBadRequest, "InvalidParameterValueException",
+				"Invalid Marker: the marker is malformed or has expired"), nil
+		}
+		marker = position
+	}
+
 	maxItems := 50
 	if maxItemsStr != "" {
 		if parsed, err := strconv.Atoi(maxItemsStr); err == nil && parsed > 0 {
@@ -341,7 +362,7 @@ func (s *LambdaService) handleListLayerVersions(ctx context.Context, layerName s
 		"LayerVersions": versionResponses,
 	}
 	if nextMarker != "" {
-		response["NextMarker"] = nextMarker
+		response["NextMarker"] = emulator.EncodePageToken("lambda:ListLayerVersions", nextMarker)
 	}
 
 	return s.successResponse(http.StatusOK, response)
@@ -524,7 +545,7 @@ func (s *LambdaService) handleGetLayerVersionPolicy(ctx context.Context, layerNa
 
 func (s *LambdaService) buildLayerVersionResponse(v *StoredLayerVersion, layerName string) map[string]interface{} {
 	response := map[string]interface{}{
-		"LayerArn":        generateLayerBaseArn(layerName),
+		"LayerArn":        strings.TrimSuffix(v.LayerVersionArn, fmt.Sprintf(":%d", v.Version)),
 		"LayerVersionArn": v.LayerVersionArn,
 		"Version":         v.Version,
 		"CreatedDate":     v.CreatedDate,
@@ -548,11 +569,11 @@ func (s *LambdaService) buildLayerVersionResponse(v *StoredLayerVersion, layerNa
 	return response
 }
 
-func (s *LambdaService) buildLayerVersionDetailResponse(v *StoredLayerVersion, layerName string) map[string]interface{} {
+func (s *LambdaService) buildLayerVersionDetailResponse(scope emulator.RequestScope, v *StoredLayerVersion, layerName string) map[string]interface{} {
 	response := s.buildLayerVersionResponse(v, layerName)
 	// Add location info for GetLayerVersion
 	content := response["Content"].(map[string]interface{})
-	content["Location"] = fmt.Sprintf("https://awslambda-us-east-1-layers.s3.amazonaws.com/snapshots/%s/%d", layerName, v.Version)
+	content["Location"] = fmt.Sprintf("https://awslambda-%s-layers.s3.amazonaws.com/snapshots/%s/%d", scope.Region, layerName, v.Version)
 	return response
 }
 
@@ -579,9 +600,9 @@ func (s *LambdaService) buildLayerVersionSummary(v *StoredLayerVersion) map[stri
 
 // Helper functions
 
-func generateLayerBaseArn(layerName string) string {
+func generateLayerBaseArn(scope emulator.RequestScope, layerName string) string {
 	return fmt.Sprintf("arn:aws:lambda:%s:%s:layer:%s",
-		DefaultRegion, DefaultAccountID, layerName)
+		scope.Region, scope.AccountID, layerName)
 }
 
 func validateLayerName(name string) error {
@@ -19,6 +19,16 @@ func (s *LambdaService) handleListFunctions(ctx context.Context, req *emulator.A
 	marker := queryParams.Get("Marker")
 	maxItemsStr := queryParams.Get("MaxItems")
 
+	// The marker is an opaque token holding the last item of the previous page
+	if marker != "" {
+		position, err := emulator.DecodePageToken("lambda:ListFunctions", marker)
+		if err != nil {
+			return s.errorResponse(http.StatusBadRequest, "InvalidParameterValueException",
+				"Invalid Marker: the marker is malformed or has expired"), nil
+		}
+		marker = position
+	}
+
 	// Parse MaxItems (default 50, max 10000)
 	maxItems := 50
 	if maxItemsStr != "" {
@@ -103,7 +113,7 @@ func (s *LambdaService) handleListFunctions(ctx context.Context, req *emulator.A
 	}
 
 	if nextMarker != "" {
-		response["NextMarker"] = nextMarker
+		response["NextMarker"] = emulator.EncodePageToken("lambda:ListFunctions", nextMarker)
 	}
 
 	return s.successResponse(http.StatusOK, response)
@@ -117,6 +127,16 @@ func (s *LambdaService) handleListVersionsByFunction(ctx context.Context, functi
 	marker := queryParams.Get("Marker")
 	maxItemsStr := queryParams.Get("MaxItems")
 
+	// The marker is an opaque token holding the last item of the previous page
+	if marker != "" {
+		position, err := emulator.DecodePageToken("lambda:ListVersionsByFunction", marker)
+		if err != nil {
+			return s.errorResponse(http.StatusBadRequest, "InvalidParameterValueException",
+				"Invalid Marker: the marker is malformed or has expired"), nil
+		}
+		marker = position
+	}
+
 	// Parse MaxItems
 	maxItems := 50
 	if maxItemsStr != "" {
@@ -211,7 +231,7 @@ func (s *LambdaService) handleListVersionsByFunction(ctx context.Context, functi
 	}
 
 	if nextMarker != "" {
-		response["NextMarker"] = nextMarker
+		response["NextMarker"] = emulator.EncodePageToken("lambda:ListVersionsByFunction", nextMarker)
 	}
 
 	return s.successResponse(http.StatusOK, response)
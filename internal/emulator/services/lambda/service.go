@@ -43,6 +43,16 @@ func (s *LambdaService) ServiceName() string {
 	return "lambda"
 }
 
+// Protocol returns the AWS protocol the service speaks
+func (s *LambdaService) Protocol() emulator.ProtocolType {
+	return emulator.ProtocolRESTJSON
+}
+
+// ResponseTypes returns constructors for the CloudMirror-generated response types, keyed by action.
+func (s *LambdaService) ResponseTypes() map[string]func() interface{} {
+	return smithyResponseTypes
+}
+
 // HandleRequest routes Lambda API requests based on HTTP method and path.
 // Lambda uses REST-JSON protocol with path-based routing:
 //   - POST   /2015-03-31/functions                              -> CreateFunction
@@ -550,3 +560,8 @@ func (s *LambdaService) successResponse(statusCode int, data interface{}) (*emul
 func (s *LambdaService) errorResponse(statusCode int, code, message string) *emulator.AWSResponse {
 	return emulator.BuildRESTJSONErrorResponse(statusCode, code, message)
 }
+
+var (
+	_ emulator.Service              = (*LambdaService)(nil)
+	_ emulator.ResponseTypeProvider = (*LambdaService)(nil)
+)
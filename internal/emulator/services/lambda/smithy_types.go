@@ -32,6 +32,82 @@ func (t *UnixTimestamp) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// smithyResponseTypes maps operation names to constructors for their generated response types.
+var smithyResponseTypes = map[string]func() interface{}{
+	"AddLayerVersionPermission":              func() interface{} { return &AddLayerVersionPermissionResponse{} },
+	"AddPermission":                          func() interface{} { return &AddPermissionResponse{} },
+	"CheckpointDurableExecution":             func() interface{} { return &CheckpointDurableExecutionResponse{} },
+	"CreateAlias":                            func() interface{} { return &AliasConfiguration{} },
+	"CreateCapacityProvider":                 func() interface{} { return &CreateCapacityProviderResponse{} },
+	"CreateCodeSigningConfig":                func() interface{} { return &CreateCodeSigningConfigResponse{} },
+	"CreateEventSourceMapping":               func() interface{} { return &EventSourceMappingConfiguration{} },
+	"CreateFunction":                         func() interface{} { return &FunctionConfiguration{} },
+	"CreateFunctionUrlConfig":                func() interface{} { return &CreateFunctionUrlConfigResponse{} },
+	"DeleteCapacityProvider":                 func() interface{} { return &DeleteCapacityProviderResponse{} },
+	"DeleteCodeSigningConfig":                func() interface{} { return &DeleteCodeSigningConfigResponse{} },
+	"DeleteEventSourceMapping":               func() interface{} { return &EventSourceMappingConfiguration{} },
+	"DeleteFunction":                         func() interface{} { return &DeleteFunctionResponse{} },
+	"GetAccountSettings":                     func() interface{} { return &GetAccountSettingsResponse{} },
+	"GetAlias":                               func() interface{} { return &AliasConfiguration{} },
+	"GetCapacityProvider":                    func() interface{} { return &GetCapacityProviderResponse{} },
+	"GetCodeSigningConfig":                   func() interface{} { return &GetCodeSigningConfigResponse{} },
+	"GetDurableExecution":                    func() interface{} { return &GetDurableExecutionResponse{} },
+	"GetDurableExecutionHistory":             func() interface{} { return &GetDurableExecutionHistoryResponse{} },
+	"GetDurableExecutionState":               func() interface{} { return &GetDurableExecutionStateResponse{} },
+	"GetEventSourceMapping":                  func() interface{} { return &EventSourceMappingConfiguration{} },
+	"GetFunction":                            func() interface{} { return &GetFunctionResponse{} },
+	"GetFunctionCodeSigningConfig":           func() interface{} { return &GetFunctionCodeSigningConfigResponse{} },
+	"GetFunctionConcurrency":                 func() interface{} { return &GetFunctionConcurrencyResponse{} },
+	"GetFunctionConfiguration":               func() interface{} { return &FunctionConfiguration{} },
+	"GetFunctionEventInvokeConfig":           func() interface{} { return &FunctionEventInvokeConfig{} },
+	"GetFunctionRecursionConfig":             func() interface{} { return &GetFunctionRecursionConfigResponse{} },
+	"GetFunctionScalingConfig":               func() interface{} { return &GetFunctionScalingConfigResponse{} },
+	"GetFunctionUrlConfig":                   func() interface{} { return &GetFunctionUrlConfigResponse{} },
+	"GetLayerVersion":                        func() interface{} { return &GetLayerVersionResponse{} },
+	"GetLayerVersionByArn":                   func() interface{} { return &GetLayerVersionResponse{} },
+	"GetLayerVersionPolicy":                  func() interface{} { return &GetLayerVersionPolicyResponse{} },
+	"GetPolicy":                              func() interface{} { return &GetPolicyResponse{} },
+	"GetProvisionedConcurrencyConfig":        func() interface{} { return &GetProvisionedConcurrencyConfigResponse{} },
+	"GetRuntimeManagementConfig":             func() interface{} { return &GetRuntimeManagementConfigResponse{} },
+	"InvokeAsync":                            func() interface{} { return &InvokeAsyncResponse{} },
+	"ListAliases":                            func() interface{} { return &ListAliasesResponse{} },
+	"ListCapacityProviders":                  func() interface{} { return &ListCapacityProvidersResponse{} },
+	"ListCodeSigningConfigs":                 func() interface{} { return &ListCodeSigningConfigsResponse{} },
+	"ListDurableExecutionsByFunction":        func() interface{} { return &ListDurableExecutionsByFunctionResponse{} },
+	"ListEventSourceMappings":                func() interface{} { return &ListEventSourceMappingsResponse{} },
+	"ListFunctionEventInvokeConfigs":         func() interface{} { return &ListFunctionEventInvokeConfigsResponse{} },
+	"ListFunctionUrlConfigs":                 func() interface{} { return &ListFunctionUrlConfigsResponse{} },
+	"ListFunctionVersionsByCapacityProvider": func() interface{} { return &ListFunctionVersionsByCapacityProviderResponse{} },
+	"ListFunctions":                          func() interface{} { return &ListFunctionsResponse{} },
+	"ListFunctionsByCodeSigningConfig":       func() interface{} { return &ListFunctionsByCodeSigningConfigResponse{} },
+	"ListLayerVersions":                      func() interface{} { return &ListLayerVersionsResponse{} },
+	"ListLayers":                             func() interface{} { return &ListLayersResponse{} },
+	"ListProvisionedConcurrencyConfigs":      func() interface{} { return &ListProvisionedConcurrencyConfigsResponse{} },
+	"ListTags":                               func() interface{} { return &ListTagsResponse{} },
+	"ListVersionsByFunction":                 func() interface{} { return &ListVersionsByFunctionResponse{} },
+	"PublishLayerVersion":                    func() interface{} { return &PublishLayerVersionResponse{} },
+	"PublishVersion":                         func() interface{} { return &FunctionConfiguration{} },
+	"PutFunctionCodeSigningConfig":           func() interface{} { return &PutFunctionCodeSigningConfigResponse{} },
+	"PutFunctionConcurrency":                 func() interface{} { return &Concurrency{} },
+	"PutFunctionEventInvokeConfig":           func() interface{} { return &FunctionEventInvokeConfig{} },
+	"PutFunctionRecursionConfig":             func() interface{} { return &PutFunctionRecursionConfigResponse{} },
+	"PutFunctionScalingConfig":               func() interface{} { return &PutFunctionScalingConfigResponse{} },
+	"PutProvisionedConcurrencyConfig":        func() interface{} { return &PutProvisionedConcurrencyConfigResponse{} },
+	"PutRuntimeManagementConfig":             func() interface{} { return &PutRuntimeManagementConfigResponse{} },
+	"SendDurableExecutionCallbackFailure":    func() interface{} { return &SendDurableExecutionCallbackFailureResponse{} },
+	"SendDurableExecutionCallbackHeartbeat":  func() interface{} { return &SendDurableExecutionCallbackHeartbeatResponse{} },
+	"SendDurableExecutionCallbackSuccess":    func() interface{} { return &SendDurableExecutionCallbackSuccessResponse{} },
+	"StopDurableExecution":                   func() interface{} { return &StopDurableExecutionResponse{} },
+	"UpdateAlias":                            func() interface{} { return &AliasConfiguration{} },
+	"UpdateCapacityProvider":                 func() interface{} { return &UpdateCapacityProviderResponse{} },
+	"UpdateCodeSigningConfig":                func() interface{} { return &UpdateCodeSigningConfigResponse{} },
+	"UpdateEventSourceMapping":               func() interface{} { return &EventSourceMappingConfiguration{} },
+	"UpdateFunctionCode":                     func() interface{} { return &FunctionConfiguration{} },
+	"UpdateFunctionConfiguration":            func() interface{} { return &FunctionConfiguration{} },
+	"UpdateFunctionEventInvokeConfig":        func() interface{} { return &FunctionEventInvokeConfig{} },
+	"UpdateFunctionUrlConfig":                func() interface{} { return &UpdateFunctionUrlConfigResponse{} },
+}
+
 // Enum type aliases
 
 type ApplicationLogLevel string
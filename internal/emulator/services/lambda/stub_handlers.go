@@ -53,7 +53,7 @@ func (s *LambdaService) handlePublishVersion(ctx context.Context, functionName s
 		CodeSha256:   function.CodeSha256,
 		CodeSize:     function.CodeSize,
 		RevisionId:   generateRevisionId(),
-		FunctionArn:  generateVersionArn(functionName, version),
+		FunctionArn:  generateVersionArn(emulator.RequestScopeFromContext(ctx), functionName, version),
 		LastModified: now(),
 	}
 
@@ -121,7 +121,7 @@ func (s *LambdaService) handleCreateAlias(ctx context.Context, functionName stri
 		FunctionName:    functionName,
 		FunctionVersion: input.FunctionVersion,
 		Description:     input.Description,
-		AliasArn:        generateAliasArn(functionName, input.Name),
+		AliasArn:        generateAliasArn(emulator.RequestScopeFromContext(ctx), functionName, input.Name),
 		RevisionId:      generateRevisionId(),
 		RoutingConfig:   input.RoutingConfig,
 	}
@@ -276,7 +276,7 @@ func (s *LambdaService) handleCreateFunctionUrlConfig(ctx context.Context, funct
 	urlConfig := &StoredFunctionUrl{
 		FunctionName:     functionName,
 		FunctionArn:      function.FunctionArn,
-		FunctionUrl:      generateFunctionUrl(functionName),
+		FunctionUrl:      generateFunctionUrl(emulator.RequestScopeFromContext(ctx), functionName),
 		AuthType:         input.AuthType,
 		Cors:             input.Cors,
 		InvokeMode:       coalesce(input.InvokeMode, InvokeModeBuffered),
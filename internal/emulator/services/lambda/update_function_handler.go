@@ -101,7 +101,7 @@ func (s *LambdaService) handleUpdateFunctionCode(ctx context.Context, functionNa
 			CodeSha256:   function.CodeSha256,
 			CodeSize:     function.CodeSize,
 			RevisionId:   generateRevisionId(),
-			FunctionArn:  generateVersionArn(functionName, version),
+			FunctionArn:  generateVersionArn(emulator.RequestScopeFromContext(ctx), functionName, version),
 			LastModified: now(),
 		}
 		function.PublishedVersions[version] = storedVersion
@@ -41,6 +41,16 @@ func (s *RDSService) ServiceName() string {
 	return "rds"
 }
 
+// Protocol returns the AWS protocol the service speaks
+func (s *RDSService) Protocol() emulator.ProtocolType {
+	return emulator.ProtocolQuery
+}
+
+// ResponseTypes returns constructors for the CloudMirror-generated response types, keyed by action.
+func (s *RDSService) ResponseTypes() map[string]func() interface{} {
+	return smithyResponseTypes
+}
+
 // SupportedActions returns the list of AWS API actions this service handles.
 // Used by the router to determine which service handles a given Query Protocol request.
 func (s *RDSService) SupportedActions() []string {
@@ -161,10 +171,11 @@ func (s *RDSService) createDBInstance(ctx context.Context, params map[string]int
 		return s.errorResponse(400, "InvalidParameterValue", "DBInstanceIdentifier is required"), nil
 	}
 
-	if s.state.(*emulator.MemoryStateManager).Exists(fmt.Sprintf("rds:db-instance:%s", identifier)) {
+	if s.state.Exists(fmt.Sprintf("rds:db-instance:%s", identifier)) {
 		return s.errorResponse(409, "DBInstanceAlreadyExistsFault", fmt.Sprintf("DB instance %s already exists", identifier)), nil
 	}
 
+	scope := emulator.RequestScopeFromContext(ctx)
 	dbInstance := &DBInstance{
 		DBInstanceIdentifier:             &identifier,
 		DBInstanceClass:                  getStringParam(params, "DBInstanceClass", "db.t3.micro"),
@@ -182,14 +193,14 @@ func (s *RDSService) createDBInstance(ctx context.Context, params map[string]int
 		DeletionProtection:               getBoolParam(params, "DeletionProtection", false),
 		IAMDatabaseAuthenticationEnabled: getBoolParam(params, "IAMDatabaseAuthenticationEnabled", false),
 		PerformanceInsightsEnabled:       getBoolParam(params, "PerformanceInsightsEnabled", false),
-		DBInstanceArn:                    helpers.StringPtr(fmt.Sprintf("arn:aws:rds:us-east-1:123456789012:db:%s", identifier)),
+		DBInstanceArn:                    helpers.StringPtr(fmt.Sprintf("arn:aws:rds:%s:%s:db:%s", scope.Region, scope.AccountID, identifier)),
 		DbiResourceId:                    helpers.StringPtr(fmt.Sprintf("db-%s", uuid.New().String()[:8])),
 		InstanceCreateTime:               &time.Time{},
 	}
 
 	if port := getInt32Param(params, "Port", 0); port != nil && *port > 0 {
 		dbInstance.Endpoint = &Endpoint{
-			Address: helpers.StringPtr(fmt.Sprintf("%s.cluster-xyz.us-east-1.rds.amazonaws.com", identifier)),
+			Address: helpers.StringPtr(fmt.Sprintf("%s.cluster-xyz.%s.rds.amazonaws.com", identifier, scope.Region)),
 			Port:    port,
 		}
 		dbInstance.DbInstancePort = port
@@ -606,3 +617,9 @@ func getBoolParam(params map[string]interface{}, key string, defaultValue bool)
 	}
 	return &defaultValue
 }
+
+var (
+	_ emulator.Service              = (*RDSService)(nil)
+	_ emulator.ActionProvider       = (*RDSService)(nil)
+	_ emulator.ResponseTypeProvider = (*RDSService)(nil)
+)
@@ -10,6 +10,162 @@ import (
 	"time"
 )
 
+// smithyResponseTypes maps operation names to constructors for their generated response types.
+var smithyResponseTypes = map[string]func() interface{}{
+	"AddSourceIdentifierToSubscription":          func() interface{} { return &AddSourceIdentifierToSubscriptionResult{} },
+	"ApplyPendingMaintenanceAction":              func() interface{} { return &ApplyPendingMaintenanceActionResult{} },
+	"AuthorizeDBSecurityGroupIngress":            func() interface{} { return &AuthorizeDBSecurityGroupIngressResult{} },
+	"BacktrackDBCluster":                         func() interface{} { return &DBClusterBacktrack{} },
+	"CancelExportTask":                           func() interface{} { return &ExportTask{} },
+	"CopyDBClusterParameterGroup":                func() interface{} { return &CopyDBClusterParameterGroupResult{} },
+	"CopyDBClusterSnapshot":                      func() interface{} { return &CopyDBClusterSnapshotResult{} },
+	"CopyDBParameterGroup":                       func() interface{} { return &CopyDBParameterGroupResult{} },
+	"CopyDBSnapshot":                             func() interface{} { return &CopyDBSnapshotResult{} },
+	"CopyOptionGroup":                            func() interface{} { return &CopyOptionGroupResult{} },
+	"CreateBlueGreenDeployment":                  func() interface{} { return &CreateBlueGreenDeploymentResponse{} },
+	"CreateCustomDBEngineVersion":                func() interface{} { return &DBEngineVersion{} },
+	"CreateDBCluster":                            func() interface{} { return &CreateDBClusterResult{} },
+	"CreateDBClusterEndpoint":                    func() interface{} { return &DBClusterEndpoint{} },
+	"CreateDBClusterParameterGroup":              func() interface{} { return &CreateDBClusterParameterGroupResult{} },
+	"CreateDBClusterSnapshot":                    func() interface{} { return &CreateDBClusterSnapshotResult{} },
+	"CreateDBInstance":                           func() interface{} { return &CreateDBInstanceResult{} },
+	"CreateDBInstanceReadReplica":                func() interface{} { return &CreateDBInstanceReadReplicaResult{} },
+	"CreateDBParameterGroup":                     func() interface{} { return &CreateDBParameterGroupResult{} },
+	"CreateDBProxy":                              func() interface{} { return &CreateDBProxyResponse{} },
+	"CreateDBProxyEndpoint":                      func() interface{} { return &CreateDBProxyEndpointResponse{} },
+	"CreateDBSecurityGroup":                      func() interface{} { return &CreateDBSecurityGroupResult{} },
+	"CreateDBShardGroup":                         func() interface{} { return &DBShardGroup{} },
+	"CreateDBSnapshot":                           func() interface{} { return &CreateDBSnapshotResult{} },
+	"CreateDBSubnetGroup":                        func() interface{} { return &CreateDBSubnetGroupResult{} },
+	"CreateEventSubscription":                    func() interface{} { return &CreateEventSubscriptionResult{} },
+	"CreateGlobalCluster":                        func() interface{} { return &CreateGlobalClusterResult{} },
+	"CreateIntegration":                          func() interface{} { return &Integration{} },
+	"CreateOptionGroup":                          func() interface{} { return &CreateOptionGroupResult{} },
+	"CreateTenantDatabase":                       func() interface{} { return &CreateTenantDatabaseResult{} },
+	"DeleteBlueGreenDeployment":                  func() interface{} { return &DeleteBlueGreenDeploymentResponse{} },
+	"DeleteCustomDBEngineVersion":                func() interface{} { return &DBEngineVersion{} },
+	"DeleteDBCluster":                            func() interface{} { return &DeleteDBClusterResult{} },
+	"DeleteDBClusterAutomatedBackup":             func() interface{} { return &DeleteDBClusterAutomatedBackupResult{} },
+	"DeleteDBClusterEndpoint":                    func() interface{} { return &DBClusterEndpoint{} },
+	"DeleteDBClusterSnapshot":                    func() interface{} { return &DeleteDBClusterSnapshotResult{} },
+	"DeleteDBInstance":                           func() interface{} { return &DeleteDBInstanceResult{} },
+	"DeleteDBInstanceAutomatedBackup":            func() interface{} { return &DeleteDBInstanceAutomatedBackupResult{} },
+	"DeleteDBProxy":                              func() interface{} { return &DeleteDBProxyResponse{} },
+	"DeleteDBProxyEndpoint":                      func() interface{} { return &DeleteDBProxyEndpointResponse{} },
+	"DeleteDBShardGroup":                         func() interface{} { return &DBShardGroup{} },
+	"DeleteDBSnapshot":                           func() interface{} { return &DeleteDBSnapshotResult{} },
+	"DeleteEventSubscription":                    func() interface{} { return &DeleteEventSubscriptionResult{} },
+	"DeleteGlobalCluster":                        func() interface{} { return &DeleteGlobalClusterResult{} },
+	"DeleteIntegration":                          func() interface{} { return &Integration{} },
+	"DeleteTenantDatabase":                       func() interface{} { return &DeleteTenantDatabaseResult{} },
+	"DeregisterDBProxyTargets":                   func() interface{} { return &DeregisterDBProxyTargetsResponse{} },
+	"DescribeAccountAttributes":                  func() interface{} { return &AccountAttributesMessage{} },
+	"DescribeBlueGreenDeployments":               func() interface{} { return &DescribeBlueGreenDeploymentsResponse{} },
+	"DescribeCertificates":                       func() interface{} { return &CertificateMessage{} },
+	"DescribeDBClusterAutomatedBackups":          func() interface{} { return &DBClusterAutomatedBackupMessage{} },
+	"DescribeDBClusterBacktracks":                func() interface{} { return &DBClusterBacktrackMessage{} },
+	"DescribeDBClusterEndpoints":                 func() interface{} { return &DBClusterEndpointMessage{} },
+	"DescribeDBClusterParameterGroups":           func() interface{} { return &DBClusterParameterGroupsMessage{} },
+	"DescribeDBClusterParameters":                func() interface{} { return &DBClusterParameterGroupDetails{} },
+	"DescribeDBClusterSnapshotAttributes":        func() interface{} { return &DescribeDBClusterSnapshotAttributesResult{} },
+	"DescribeDBClusterSnapshots":                 func() interface{} { return &DBClusterSnapshotMessage{} },
+	"DescribeDBClusters":                         func() interface{} { return &DBClusterMessage{} },
+	"DescribeDBEngineVersions":                   func() interface{} { return &DBEngineVersionMessage{} },
+	"DescribeDBInstanceAutomatedBackups":         func() interface{} { return &DBInstanceAutomatedBackupMessage{} },
+	"DescribeDBInstances":                        func() interface{} { return &DBInstanceMessage{} },
+	"DescribeDBLogFiles":                         func() interface{} { return &DescribeDBLogFilesResponse{} },
+	"DescribeDBMajorEngineVersions":              func() interface{} { return &DescribeDBMajorEngineVersionsResponse{} },
+	"DescribeDBParameterGroups":                  func() interface{} { return &DBParameterGroupsMessage{} },
+	"DescribeDBParameters":                       func() interface{} { return &DBParameterGroupDetails{} },
+	"DescribeDBProxies":                          func() interface{} { return &DescribeDBProxiesResponse{} },
+	"DescribeDBProxyEndpoints":                   func() interface{} { return &DescribeDBProxyEndpointsResponse{} },
+	"DescribeDBProxyTargetGroups":                func() interface{} { return &DescribeDBProxyTargetGroupsResponse{} },
+	"DescribeDBProxyTargets":                     func() interface{} { return &DescribeDBProxyTargetsResponse{} },
+	"DescribeDBRecommendations":                  func() interface{} { return &DBRecommendationsMessage{} },
+	"DescribeDBSecurityGroups":                   func() interface{} { return &DBSecurityGroupMessage{} },
+	"DescribeDBShardGroups":                      func() interface{} { return &DescribeDBShardGroupsResponse{} },
+	"DescribeDBSnapshotAttributes":               func() interface{} { return &DescribeDBSnapshotAttributesResult{} },
+	"DescribeDBSnapshotTenantDatabases":          func() interface{} { return &DBSnapshotTenantDatabasesMessage{} },
+	"DescribeDBSnapshots":                        func() interface{} { return &DBSnapshotMessage{} },
+	"DescribeDBSubnetGroups":                     func() interface{} { return &DBSubnetGroupMessage{} },
+	"DescribeEngineDefaultClusterParameters":     func() interface{} { return &DescribeEngineDefaultClusterParametersResult{} },
+	"DescribeEngineDefaultParameters":            func() interface{} { return &DescribeEngineDefaultParametersResult{} },
+	"DescribeEventCategories":                    func() interface{} { return &EventCategoriesMessage{} },
+	"DescribeEventSubscriptions":                 func() interface{} { return &EventSubscriptionsMessage{} },
+	"DescribeEvents":                             func() interface{} { return &EventsMessage{} },
+	"DescribeExportTasks":                        func() interface{} { return &ExportTasksMessage{} },
+	"DescribeGlobalClusters":                     func() interface{} { return &GlobalClustersMessage{} },
+	"DescribeIntegrations":                       func() interface{} { return &DescribeIntegrationsResponse{} },
+	"DescribeOptionGroupOptions":                 func() interface{} { return &OptionGroupOptionsMessage{} },
+	"DescribeOptionGroups":                       func() interface{} { return &OptionGroups{} },
+	"DescribeOrderableDBInstanceOptions":         func() interface{} { return &OrderableDBInstanceOptionsMessage{} },
+	"DescribePendingMaintenanceActions":          func() interface{} { return &PendingMaintenanceActionsMessage{} },
+	"DescribeReservedDBInstances":                func() interface{} { return &ReservedDBInstanceMessage{} },
+	"DescribeReservedDBInstancesOfferings":       func() interface{} { return &ReservedDBInstancesOfferingMessage{} },
+	"DescribeSourceRegions":                      func() interface{} { return &SourceRegionMessage{} },
+	"DescribeTenantDatabases":                    func() interface{} { return &TenantDatabasesMessage{} },
+	"DescribeValidDBInstanceModifications":       func() interface{} { return &DescribeValidDBInstanceModificationsResult{} },
+	"DisableHttpEndpoint":                        func() interface{} { return &DisableHttpEndpointResponse{} },
+	"DownloadDBLogFilePortion":                   func() interface{} { return &DownloadDBLogFilePortionDetails{} },
+	"EnableHttpEndpoint":                         func() interface{} { return &EnableHttpEndpointResponse{} },
+	"FailoverDBCluster":                          func() interface{} { return &FailoverDBClusterResult{} },
+	"FailoverGlobalCluster":                      func() interface{} { return &FailoverGlobalClusterResult{} },
+	"ListTagsForResource":                        func() interface{} { return &TagListMessage{} },
+	"ModifyActivityStream":                       func() interface{} { return &ModifyActivityStreamResponse{} },
+	"ModifyCertificates":                         func() interface{} { return &ModifyCertificatesResult{} },
+	"ModifyCurrentDBClusterCapacity":             func() interface{} { return &DBClusterCapacityInfo{} },
+	"ModifyCustomDBEngineVersion":                func() interface{} { return &DBEngineVersion{} },
+	"ModifyDBCluster":                            func() interface{} { return &ModifyDBClusterResult{} },
+	"ModifyDBClusterEndpoint":                    func() interface{} { return &DBClusterEndpoint{} },
+	"ModifyDBClusterParameterGroup":              func() interface{} { return &DBClusterParameterGroupNameMessage{} },
+	"ModifyDBClusterSnapshotAttribute":           func() interface{} { return &ModifyDBClusterSnapshotAttributeResult{} },
+	"ModifyDBInstance":                           func() interface{} { return &ModifyDBInstanceResult{} },
+	"ModifyDBParameterGroup":                     func() interface{} { return &DBParameterGroupNameMessage{} },
+	"ModifyDBProxy":                              func() interface{} { return &ModifyDBProxyResponse{} },
+	"ModifyDBProxyEndpoint":                      func() interface{} { return &ModifyDBProxyEndpointResponse{} },
+	"ModifyDBProxyTargetGroup":                   func() interface{} { return &ModifyDBProxyTargetGroupResponse{} },
+	"ModifyDBRecommendation":                     func() interface{} { return &DBRecommendationMessage{} },
+	"ModifyDBShardGroup":                         func() interface{} { return &DBShardGroup{} },
+	"ModifyDBSnapshot":                           func() interface{} { return &ModifyDBSnapshotResult{} },
+	"ModifyDBSnapshotAttribute":                  func() interface{} { return &ModifyDBSnapshotAttributeResult{} },
+	"ModifyDBSubnetGroup":                        func() interface{} { return &ModifyDBSubnetGroupResult{} },
+	"ModifyEventSubscription":                    func() interface{} { return &ModifyEventSubscriptionResult{} },
+	"ModifyGlobalCluster":                        func() interface{} { return &ModifyGlobalClusterResult{} },
+	"ModifyIntegration":                          func() interface{} { return &Integration{} },
+	"ModifyOptionGroup":                          func() interface{} { return &ModifyOptionGroupResult{} },
+	"ModifyTenantDatabase":                       func() interface{} { return &ModifyTenantDatabaseResult{} },
+	"PromoteReadReplica":                         func() interface{} { return &PromoteReadReplicaResult{} },
+	"PromoteReadReplicaDBCluster":                func() interface{} { return &PromoteReadReplicaDBClusterResult{} },
+	"PurchaseReservedDBInstancesOffering":        func() interface{} { return &PurchaseReservedDBInstancesOfferingResult{} },
+	"RebootDBCluster":                            func() interface{} { return &RebootDBClusterResult{} },
+	"RebootDBInstance":                           func() interface{} { return &RebootDBInstanceResult{} },
+	"RebootDBShardGroup":                         func() interface{} { return &DBShardGroup{} },
+	"RegisterDBProxyTargets":                     func() interface{} { return &RegisterDBProxyTargetsResponse{} },
+	"RemoveFromGlobalCluster":                    func() interface{} { return &RemoveFromGlobalClusterResult{} },
+	"RemoveSourceIdentifierFromSubscription":     func() interface{} { return &RemoveSourceIdentifierFromSubscriptionResult{} },
+	"ResetDBClusterParameterGroup":               func() interface{} { return &DBClusterParameterGroupNameMessage{} },
+	"ResetDBParameterGroup":                      func() interface{} { return &DBParameterGroupNameMessage{} },
+	"RestoreDBClusterFromS3":                     func() interface{} { return &RestoreDBClusterFromS3Result{} },
+	"RestoreDBClusterFromSnapshot":               func() interface{} { return &RestoreDBClusterFromSnapshotResult{} },
+	"RestoreDBClusterToPointInTime":              func() interface{} { return &RestoreDBClusterToPointInTimeResult{} },
+	"RestoreDBInstanceFromDBSnapshot":            func() interface{} { return &RestoreDBInstanceFromDBSnapshotResult{} },
+	"RestoreDBInstanceFromS3":                    func() interface{} { return &RestoreDBInstanceFromS3Result{} },
+	"RestoreDBInstanceToPointInTime":             func() interface{} { return &RestoreDBInstanceToPointInTimeResult{} },
+	"RevokeDBSecurityGroupIngress":               func() interface{} { return &RevokeDBSecurityGroupIngressResult{} },
+	"StartActivityStream":                        func() interface{} { return &StartActivityStreamResponse{} },
+	"StartDBCluster":                             func() interface{} { return &StartDBClusterResult{} },
+	"StartDBInstance":                            func() interface{} { return &StartDBInstanceResult{} },
+	"StartDBInstanceAutomatedBackupsReplication": func() interface{} { return &StartDBInstanceAutomatedBackupsReplicationResult{} },
+	"StartExportTask":                            func() interface{} { return &ExportTask{} },
+	"StopActivityStream":                         func() interface{} { return &StopActivityStreamResponse{} },
+	"StopDBCluster":                              func() interface{} { return &StopDBClusterResult{} },
+	"StopDBInstance":                             func() interface{} { return &StopDBInstanceResult{} },
+	"StopDBInstanceAutomatedBackupsReplication":  func() interface{} { return &StopDBInstanceAutomatedBackupsReplicationResult{} },
+	"SwitchoverBlueGreenDeployment":              func() interface{} { return &SwitchoverBlueGreenDeploymentResponse{} },
+	"SwitchoverGlobalCluster":                    func() interface{} { return &SwitchoverGlobalClusterResult{} },
+	"SwitchoverReadReplica":                      func() interface{} { return &SwitchoverReadReplicaResult{} },
+}
+
 // Enum type aliases
 
 type ActivityStreamMode string
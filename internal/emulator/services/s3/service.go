@@ -2,32 +2,79 @@ package s3
 
 import (
 	"context"
+	"crypto/md5"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/robmorgan/infraspec/internal/emulator/core"
 )
 
+// s3TimestampFormat is the ISO 8601 format S3 uses for timestamps in XML responses
+const s3TimestampFormat = "2006-01-02T15:04:05.000Z"
+
+// LocationStyle is how CreateBucket's Location header addresses the new bucket
+type LocationStyle string
+
+const (
+	// LocationStylePath addresses the bucket by path, e.g. "/my-bucket"
+	LocationStylePath LocationStyle = "path"
+
+	// LocationStyleVirtualHosted addresses the bucket by a virtual-hosted URL on the request's
+	// S3 host, e.g. "http://my-bucket.s3.localhost:3687/"
+	LocationStyleVirtualHosted LocationStyle = "virtual-hosted"
+)
+
 type S3Service struct {
-	state     emulator.StateManager
-	validator emulator.Validator
+	state         emulator.StateManager
+	validator     emulator.Validator
+	clock         emulator.Clock
+	locationStyle LocationStyle
 }
 
 func NewS3Service(state emulator.StateManager, validator emulator.Validator) *S3Service {
 	return &S3Service{
-		state:     state,
-		validator: validator,
+		state:         state,
+		validator:     validator,
+		clock:         emulator.SystemClock{},
+		locationStyle: LocationStylePath,
 	}
 }
 
+// SetClock sets the clock used to timestamp buckets and objects
+func (s *S3Service) SetClock(clock emulator.Clock) {
+	s.clock = clock
+}
+
+// SetLocationStyle sets how CreateBucket's Location header addresses the new bucket. It
+// defaults to LocationStylePath.
+func (s *S3Service) SetLocationStyle(style LocationStyle) {
+	s.locationStyle = style
+}
+
 func (s *S3Service) ServiceName() string {
 	return "s3"
 }
 
+// Protocol returns the AWS protocol the service speaks
+func (s *S3Service) Protocol() emulator.ProtocolType {
+	return emulator.ProtocolRESTXML
+}
+
+// ResponseTypes returns constructors for the CloudMirror-generated response types, keyed by action.
+func (s *S3Service) ResponseTypes() map[string]func() interface{} {
+	return smithyResponseTypes
+}
+
 func (s *S3Service) HandleRequest(ctx context.Context, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
 	if err := s.validator.ValidateRequest(req); err != nil {
 		return s.errorResponse(400, "ValidationException", err.Error()), nil
@@ -43,7 +90,7 @@ func (s *S3Service) HandleRequest(ctx context.Context, req *emulator.AWSRequest)
 		return s.errorResponse(400, "InvalidAction", "Missing or invalid action"), nil
 	}
 
-	params, err := s.parseParameters(req)
+	params, err := s.parseParameters(action, req)
 	if err != nil {
 		return s.errorResponse(400, "InvalidParameterValue", err.Error()), nil
 	}
@@ -60,7 +107,13 @@ func (s *S3Service) HandleRequest(ctx context.Context, req *emulator.AWSRequest)
 	case "CreateBucketMetadataTableConfiguration":
 		return s.createBucketMetadataTableConfiguration(ctx, params)
 	case "CreateMultipartUpload":
-		return s.createMultipartUpload(ctx, params)
+		return s.createMultipartUpload(ctx, params, req)
+	case "UploadPart":
+		return s.uploadPart(ctx, params, req)
+	case "CompleteMultipartUpload":
+		return s.completeMultipartUpload(ctx, params, req)
+	case "AbortMultipartUpload":
+		return s.abortMultipartUpload(ctx, params, req)
 	case "CreateSession":
 		return s.createSession(ctx, params)
 	case "DeleteBucket":
@@ -91,12 +144,40 @@ func (s *S3Service) HandleRequest(ctx context.Context, req *emulator.AWSRequest)
 		return s.getBucketLogging(ctx, params, req)
 	case "PutBucketLogging":
 		return s.putBucketLogging(ctx, params, req)
+	case "GetBucketAcl":
+		return s.getBucketAcl(ctx, params, req)
+	case "PutBucketAcl":
+		return s.putBucketAcl(ctx, params, req)
+	case "GetBucketRequestPayment":
+		return s.getBucketRequestPayment(ctx, params, req)
+	case "PutBucketRequestPayment":
+		return s.putBucketRequestPayment(ctx, params, req)
+	case "GetBucketAccelerateConfiguration":
+		return s.getBucketAccelerateConfiguration(ctx, params, req)
+	case "PutBucketAccelerateConfiguration":
+		return s.putBucketAccelerateConfiguration(ctx, params, req)
+	case "GetBucketLifecycleConfiguration":
+		return s.getBucketLifecycleConfiguration(ctx, params, req)
+	case "PutBucketLifecycleConfiguration":
+		return s.putBucketLifecycleConfiguration(ctx, params, req)
+	case "DeleteBucketLifecycle":
+		return s.deleteBucketLifecycle(ctx, params, req)
+	case "ListMultipartUploads":
+		return s.listMultipartUploads(ctx, params, req)
 	case "PutObject":
 		return s.putObject(ctx, params, req)
 	case "GetObject":
 		return s.getObject(ctx, params, req)
+	case "HeadObject":
+		return s.headObject(ctx, params, req)
+	case "GetObjectAttributes":
+		return s.getObjectAttributes(ctx, params, req)
+	case "DeleteObjects":
+		return s.deleteObjects(ctx, params, req)
 	case "HeadBucket":
 		return s.headBucket(ctx, params, req)
+	case "GetBucketLocation":
+		return s.getBucketLocation(ctx, params, req)
 	case "ListObjectsV2":
 		return s.listObjectsV2(ctx, params, req)
 	default:
@@ -151,24 +232,24 @@ func (s *S3Service) deriveS3ActionFromRequest(req *emulator.AWSRequest) string {
 			queryString = req.Path[idx+1:]
 		}
 
-		// Parse query parameters more carefully
-		// AWS S3 uses query parameters like: ?versioning, ?encryption, ?policy, ?logging
-		// Not ?versioning=something, but just the parameter name
+		// Subresources are query parameters without a value, like ?versioning or ?policy. They
+		// are matched by name only, so a prefix or key that contains one, like
+		// ?list-type=2&prefix=policy/, isn't mistaken for it.
 		query, _ := url.ParseQuery(queryString)
 
-		if query.Has("versioning") || strings.Contains(queryString, "versioning") {
+		if query.Has("versioning") {
 			if req.Method == "PUT" {
 				return "PutBucketVersioning"
 			}
 			return "GetBucketVersioning"
 		}
-		if query.Has("encryption") || strings.Contains(queryString, "encryption") {
+		if query.Has("encryption") {
 			if req.Method == "PUT" {
 				return "PutBucketEncryption"
 			}
 			return "GetBucketEncryption"
 		}
-		if query.Has("publicAccessBlock") || strings.Contains(queryString, "publicAccessBlock") {
+		if query.Has("publicAccessBlock") {
 			if req.Method == "PUT" {
 				return "PutPublicAccessBlock"
 			} else if req.Method == "DELETE" {
@@ -176,7 +257,7 @@ func (s *S3Service) deriveS3ActionFromRequest(req *emulator.AWSRequest) string {
 			}
 			return "GetPublicAccessBlock"
 		}
-		if query.Has("policy") || strings.Contains(queryString, "policy") {
+		if query.Has("policy") {
 			if req.Method == "PUT" {
 				return "PutBucketPolicy"
 			} else if req.Method == "DELETE" {
@@ -184,13 +265,65 @@ func (s *S3Service) deriveS3ActionFromRequest(req *emulator.AWSRequest) string {
 			}
 			return "GetBucketPolicy"
 		}
-		if query.Has("logging") || strings.Contains(queryString, "logging") {
+		if query.Has("logging") {
 			if req.Method == "PUT" {
 				return "PutBucketLogging"
 			}
 			return "GetBucketLogging"
 		}
-		if query.Has("delete") || strings.Contains(queryString, "delete") {
+		if query.Has("acl") {
+			if req.Method == "PUT" {
+				return "PutBucketAcl"
+			}
+			return "GetBucketAcl"
+		}
+		if query.Has("requestPayment") {
+			if req.Method == "PUT" {
+				return "PutBucketRequestPayment"
+			}
+			return "GetBucketRequestPayment"
+		}
+		if query.Has("accelerate") {
+			if req.Method == "PUT" {
+				return "PutBucketAccelerateConfiguration"
+			}
+			return "GetBucketAccelerateConfiguration"
+		}
+		if query.Has("lifecycle") {
+			if req.Method == "PUT" {
+				return "PutBucketLifecycleConfiguration"
+			} else if req.Method == "DELETE" {
+				return "DeleteBucketLifecycle"
+			}
+			return "GetBucketLifecycleConfiguration"
+		}
+		if query.Has("uploadId") {
+			switch req.Method {
+			case "PUT":
+				return "UploadPart"
+			case "POST":
+				return "CompleteMultipartUpload"
+			case "DELETE":
+				return "AbortMultipartUpload"
+			}
+		}
+		if query.Has("uploads") {
+			if req.Method == "POST" {
+				return "CreateMultipartUpload"
+			} else if req.Method == "GET" {
+				return "ListMultipartUploads"
+			}
+		}
+		if query.Has("attributes") && req.Method == "GET" {
+			return "GetObjectAttributes"
+		}
+		if query.Has("location") && req.Method == "GET" {
+			return "GetBucketLocation"
+		}
+		if query.Get("list-type") == "2" && req.Method == "GET" {
+			return "ListObjectsV2"
+		}
+		if query.Has("delete") {
 			return "DeleteObjects"
 		}
 	}
@@ -270,10 +403,21 @@ func (s *S3Service) deriveS3ActionFromRequest(req *emulator.AWSRequest) string {
 	return ""
 }
 
-func (s *S3Service) parseParameters(req *emulator.AWSRequest) (map[string]interface{}, error) {
+// objectContentActions are the actions whose Content-Type header is the object's, not the
+// request body's, so their body, object data or empty, is never parsed for parameters
+var objectContentActions = map[string]bool{
+	"PutObject":             true,
+	"UploadPart":            true,
+	"CreateMultipartUpload": true,
+}
+
+func (s *S3Service) parseParameters(action string, req *emulator.AWSRequest) (map[string]interface{}, error) {
 	if req.Parameters != nil {
 		return req.Parameters, nil
 	}
+	if objectContentActions[action] {
+		return make(map[string]interface{}), nil
+	}
 
 	contentType := req.Headers["Content-Type"]
 	if strings.Contains(contentType, "application/x-www-form-urlencoded") {
@@ -339,6 +483,22 @@ func (s *S3Service) extractBucketName(req *emulator.AWSRequest) string {
 	return ""
 }
 
+// extractObjectKey returns the key of the object a request addresses: its path without the
+// query string, after the bucket name for path-style requests
+func (s *S3Service) extractObjectKey(req *emulator.AWSRequest) string {
+	path := req.Path
+	if idx := strings.Index(path, "?"); idx >= 0 {
+		path = path[:idx]
+	}
+	path = strings.TrimPrefix(path, "/")
+
+	if emulator.ExtractBucketNameFromHost(req.Headers["Host"]) != "" {
+		return path
+	}
+	_, objectKey, _ := strings.Cut(path, "/")
+	return objectKey
+}
+
 func (s *S3Service) createBucket(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
 	bucketName := s.extractBucketName(req)
 	if bucketName == "" {
@@ -346,10 +506,15 @@ func (s *S3Service) createBucket(ctx context.Context, params map[string]interfac
 	}
 
 	stateKey := "s3:" + bucketName
+	scope := emulator.RequestScopeFromContext(ctx)
 
-	// Check if bucket already exists
+	// Check if bucket already exists. Bucket names are global across accounts.
 	var existing map[string]interface{}
 	if err := s.state.Get(stateKey, &existing); err == nil {
+		if bucketOwner(existing) != scope.AccountID {
+			return s.errorResponse(409, "BucketAlreadyExists", "The requested bucket name is not available. The bucket namespace is shared by all users of the system."), nil
+		}
+
 		// For a testing emulator, we return success (BucketAlreadyOwnedByYou behavior)
 		// This makes bucket creation idempotent which is useful for testing
 		// Real AWS returns 200 OK if you own the bucket, 409 if someone else does
@@ -357,7 +522,7 @@ func (s *S3Service) createBucket(ctx context.Context, params map[string]interfac
 			StatusCode: 200,
 			Headers: map[string]string{
 				"Content-Type": "application/xml",
-				"Location":     "/" + bucketName,
+				"Location":     s.bucketLocation(bucketName, req),
 			},
 			Body: []byte{},
 		}, nil
@@ -365,9 +530,10 @@ func (s *S3Service) createBucket(ctx context.Context, params map[string]interfac
 
 	// Store bucket in state with proper attributes
 	bucket := map[string]interface{}{
-		"Name":         bucketName,
-		"CreationDate": "2024-01-01T00:00:00Z",
-		"Region":       "us-east-1", // Default region
+		"Name":           bucketName,
+		"CreationDate":   s.clock.Now().Format(s3TimestampFormat),
+		"Region":         scope.Region,
+		"OwnerAccountID": scope.AccountID,
 	}
 
 	if err := s.state.Set(stateKey, bucket); err != nil {
@@ -380,12 +546,47 @@ func (s *S3Service) createBucket(ctx context.Context, params map[string]interfac
 		StatusCode: 200,
 		Headers: map[string]string{
 			"Content-Type": "application/xml",
-			"Location":     "/" + bucketName,
+			"Location":     s.bucketLocation(bucketName, req),
 		},
 		Body: []byte{},
 	}, nil
 }
 
+// bucketLocation returns the Location header of a created bucket in the configured style.
+// Virtual-hosted URLs use the request's host, adding the s3 label if it's missing, so a
+// request to localhost:3687 gets http://my-bucket.s3.localhost:3687/.
+func (s *S3Service) bucketLocation(bucketName string, req *emulator.AWSRequest) string {
+	if s.locationStyle != LocationStyleVirtualHosted {
+		return "/" + bucketName
+	}
+
+	scheme := "http"
+	if proto := headerValue(req, "X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	host := req.Headers["Host"]
+	if forwardedHost := headerValue(req, "X-Forwarded-Host"); forwardedHost != "" {
+		host = forwardedHost
+	}
+	if emulator.IsS3VirtualHostedRequest(host) {
+		return fmt.Sprintf("%s://%s/", scheme, host)
+	}
+	if !strings.HasPrefix(host, "s3.") {
+		host = "s3." + host
+	}
+	return fmt.Sprintf("%s://%s.%s/", scheme, bucketName, host)
+}
+
+// bucketOwner returns the account that owns a stored bucket. Buckets stored before owners
+// were recorded belong to the default account.
+func bucketOwner(bucket map[string]interface{}) string {
+	if owner, ok := bucket["OwnerAccountID"].(string); ok && owner != "" {
+		return owner
+	}
+	return emulator.DefaultAccountID
+}
+
 func (s *S3Service) createBucketMetadataConfiguration(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
 	// TODO: Implement CreateBucketMetadataConfiguration
 	// Required parameter: CreateBucketMetadataConfiguration (map[string]interface{}) - Input for CreateBucketMetadataConfiguration
@@ -400,13 +601,6 @@ func (s *S3Service) createBucketMetadataTableConfiguration(ctx context.Context,
 	return s.errorResponse(501, "NotImplemented", "CreateBucketMetadataTableConfiguration is not yet implemented"), nil
 }
 
-func (s *S3Service) createMultipartUpload(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
-	// TODO: Implement CreateMultipartUpload
-	// Required parameter: CreateMultipartUpload (map[string]interface{}) - Input for CreateMultipartUpload
-
-	return s.errorResponse(501, "NotImplemented", "CreateMultipartUpload is not yet implemented"), nil
-}
-
 func (s *S3Service) createSession(ctx context.Context, params map[string]interface{}) (*emulator.AWSResponse, error) {
 	// TODO: Implement CreateSession
 	// Required parameter: CreateSession (map[string]interface{}) - Input for CreateSession
@@ -441,13 +635,15 @@ func (s *S3Service) listBuckets(ctx context.Context, params map[string]interface
 		return s.errorResponse(500, "InternalError", "Failed to list buckets"), nil
 	}
 
-	// Filter out non-bucket keys (e.g., versioning, encryption configs)
+	// Filter out non-bucket keys (e.g., versioning, encryption configs) and buckets
+	// owned by other accounts
+	accountID := emulator.RequestScopeFromContext(ctx).AccountID
 	var buckets []map[string]interface{}
 	for _, key := range keys {
 		// Only include base bucket keys like "s3:bucket-name", not "s3:bucket-name:versioning"
 		if strings.Count(key, ":") == 1 {
 			var bucket map[string]interface{}
-			if err := s.state.Get(key, &bucket); err == nil {
+			if err := s.state.Get(key, &bucket); err == nil && bucketOwner(bucket) == accountID {
 				buckets = append(buckets, bucket)
 			}
 		}
@@ -456,10 +652,7 @@ func (s *S3Service) listBuckets(ctx context.Context, params map[string]interface
 	// Build ListBuckets XML response using response builder
 	result := ListAllMyBucketsResult{
 		Xmlns: "http://s3.amazonaws.com/doc/2006-03-01/",
-		Owner: XMLOwner{
-			ID:          "infraspec-api",
-			DisplayName: "infraspec-api",
-		},
+		Owner: bucketOwnerXML,
 		Buckets: XMLBuckets{
 			Bucket: make([]XMLBucket, 0, len(buckets)),
 		},
@@ -725,29 +918,39 @@ func (s *S3Service) putObject(ctx context.Context, params map[string]interface{}
 		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
 	}
 
-	// Extract object key from path
-	path := strings.TrimPrefix(req.Path, "/")
-	pathParts := strings.Split(path, "/")
-	var objectKey string
-	if len(pathParts) > 1 {
-		objectKey = strings.Join(pathParts[1:], "/")
-	} else if len(pathParts) == 1 && pathParts[0] != bucketName {
-		objectKey = pathParts[0]
-	}
-
+	objectKey := s.extractObjectKey(req)
 	if objectKey == "" {
 		return s.errorResponse(400, "InvalidKey", "Object key is required"), nil
 	}
 
+	storageClass := headerValue(req, "X-Amz-Storage-Class")
+	if storageClass == "" {
+		storageClass = storageClassStandard
+	}
+	if !validStorageClasses[storageClass] {
+		return s.errorResponse(400, "InvalidStorageClass", "The storage class you specified is not valid"), nil
+	}
+
+	metadata := userMetadata(req)
+	if userMetadataSize(metadata) > maxUserMetadataSize {
+		return s.errorResponse(400, "MetadataTooLarge", "Your metadata headers exceed the maximum allowed metadata size."), nil
+	}
+
+	// Like S3, a single-part object's ETag is the MD5 of its content
+	sum := md5.Sum(req.Body)
+
 	// Store object
 	stateKey := "s3:" + bucketName + ":object:" + objectKey
 	object := map[string]interface{}{
 		"Key":          objectKey,
 		"Bucket":       bucketName,
 		"Size":         len(req.Body),
-		"LastModified": "2024-01-01T00:00:00Z",
-		"ETag":         fmt.Sprintf("\"%s\"", uuid.New().String()[:8]),
+		"LastModified": s.clock.Now().Format(s3TimestampFormat),
+		"ETag":         fmt.Sprintf("\"%s\"", hex.EncodeToString(sum[:])),
 		"Body":         string(req.Body),
+		"StorageClass": storageClass,
+		"ContentType":  headerValue(req, "Content-Type"),
+		"Metadata":     metadata,
 	}
 
 	if err := s.state.Set(stateKey, object); err != nil {
@@ -770,16 +973,7 @@ func (s *S3Service) getObject(ctx context.Context, params map[string]interface{}
 		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
 	}
 
-	// Extract object key from path
-	path := strings.TrimPrefix(req.Path, "/")
-	pathParts := strings.Split(path, "/")
-	var objectKey string
-	if len(pathParts) > 1 {
-		objectKey = strings.Join(pathParts[1:], "/")
-	} else if len(pathParts) == 1 && pathParts[0] != bucketName {
-		objectKey = pathParts[0]
-	}
-
+	objectKey := s.extractObjectKey(req)
 	if objectKey == "" {
 		return s.errorResponse(400, "InvalidKey", "Object key is required"), nil
 	}
@@ -793,53 +987,175 @@ func (s *S3Service) getObject(ctx context.Context, params map[string]interface{}
 
 	body := []byte(objMap["Body"].(string))
 
+	byteRange, err := parseRange(headerValue(req, "Range"), len(body))
+	if err != nil {
+		resp := s.errorResponse(416, "InvalidRange", "The requested range is not satisfiable")
+		resp.Headers["Content-Range"] = fmt.Sprintf("bytes */%d", len(body))
+		return resp, nil
+	}
+
+	statusCode := 200
+	headers := map[string]string{
+		"ETag":          objMap["ETag"].(string),
+		"Accept-Ranges": "bytes",
+	}
+	setObjectMetadataHeaders(headers, objMap)
+	if byteRange != nil {
+		statusCode = 206
+		headers["Content-Range"] = fmt.Sprintf("bytes %d-%d/%d", byteRange.start, byteRange.end, len(body))
+		body = body[byteRange.start : byteRange.end+1]
+	}
+	headers["Content-Length"] = fmt.Sprintf("%d", len(body))
+	if lastModified, ok := lastModifiedHeader(objMap); ok {
+		headers["Last-Modified"] = lastModified
+	}
+
 	return &emulator.AWSResponse{
-		StatusCode: 200,
-		Headers: map[string]string{
-			"Content-Type":   "application/octet-stream",
-			"Content-Length": fmt.Sprintf("%d", len(body)),
-			"ETag":           objMap["ETag"].(string),
-		},
-		Body: body,
+		StatusCode: statusCode,
+		Headers:    headers,
+		Body:       body,
 	}, nil
 }
 
-func (s *S3Service) headBucket(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+// byteRange is the offsets of the first and last bytes of the part of an object a GetObject
+// request's Range header asks for
+type byteRange struct {
+	start, end int
+}
+
+// errRangeNotSatisfiable is returned for a range that starts after the end of the object
+var errRangeNotSatisfiable = errors.New("range not satisfiable")
+
+// parseRange parses a Range header for an object of size bytes: bytes=start-end, bytes=start-
+// to the end of the object, or bytes=-n for its last n bytes. An end past the end of the object
+// is the last byte. Like S3, it returns nil for a header to ignore, one that's missing,
+// malformed or has several ranges, so the whole object is returned.
+func parseRange(header string, size int) (*byteRange, error) {
+	spec, ok := strings.CutPrefix(strings.TrimSpace(header), "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return nil, nil
+	}
+	first, last, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return nil, nil
+	}
+
+	// A suffix range, the last n bytes
+	if first == "" {
+		n, err := strconv.Atoi(last)
+		if err != nil || n < 0 {
+			return nil, nil
+		}
+		if n == 0 || size == 0 {
+			return nil, errRangeNotSatisfiable
+		}
+		return &byteRange{start: max(size-n, 0), end: size - 1}, nil
+	}
+
+	start, err := strconv.Atoi(first)
+	if err != nil || start < 0 {
+		return nil, nil
+	}
+	end := size - 1
+	if last != "" {
+		if end, err = strconv.Atoi(last); err != nil || end < start {
+			return nil, nil
+		}
+	}
+	if start >= size {
+		return nil, errRangeNotSatisfiable
+	}
+	return &byteRange{start: start, end: min(end, size-1)}, nil
+}
+
+// headObject returns the object's metadata in headers, without its body. Like S3, the
+// x-amz-storage-class header is only set for objects that aren't STANDARD.
+func (s *S3Service) headObject(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
 	bucketName := s.extractBucketName(req)
 	if bucketName == "" {
 		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
 	}
 
-	stateKey := "s3:" + bucketName
+	objectKey := s.extractObjectKey(req)
+	if objectKey == "" {
+		return s.errorResponse(400, "InvalidKey", "Object key is required"), nil
+	}
 
-	// Check if bucket exists
-	var bucket map[string]interface{}
-	if err := s.state.Get(stateKey, &bucket); err != nil {
-		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+	stateKey := "s3:" + bucketName + ":object:" + objectKey
+	var objMap map[string]interface{}
+	if err := s.state.Get(stateKey, &objMap); err != nil {
+		// HEAD responses have no body, so the error is only in the status code
+		return &emulator.AWSResponse{
+			StatusCode: 404,
+			Headers:    map[string]string{},
+			Body:       []byte{},
+		}, nil
+	}
+
+	body, _ := objMap["Body"].(string)
+	headers := map[string]string{
+		"Content-Length": fmt.Sprintf("%d", len(body)),
+		"ETag":           objMap["ETag"].(string),
+	}
+	setObjectMetadataHeaders(headers, objMap)
+	if lastModified, ok := lastModifiedHeader(objMap); ok {
+		headers["Last-Modified"] = lastModified
+	}
+	if storageClass := objectStorageClass(objMap); storageClass != storageClassStandard {
+		headers["X-Amz-Storage-Class"] = storageClass
 	}
 
 	return &emulator.AWSResponse{
 		StatusCode: 200,
-		Headers:    map[string]string{},
+		Headers:    headers,
 		Body:       []byte{},
 	}, nil
 }
 
-func (s *S3Service) listObjectsV2(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+// maxDeleteObjects is the most objects a DeleteObjects request can delete
+const maxDeleteObjects = 1000
+
+// deleteObjects deletes the objects listed in the request body. Like S3, deleting an object
+// that doesn't exist succeeds, and a quiet request only lists the objects it couldn't delete.
+func (s *S3Service) deleteObjects(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
 	bucketName := s.extractBucketName(req)
 	if bucketName == "" {
 		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
 	}
 
-	// Build ListBucketResult using struct-based response
-	result := ListBucketResult{
-		Xmlns:       "http://s3.amazonaws.com/doc/2006-03-01/",
-		Name:        bucketName,
-		Prefix:      "",
-		KeyCount:    0,
-		MaxKeys:     1000,
-		IsTruncated: false,
-		Contents:    []XMLObject{},
+	var bucket map[string]interface{}
+	if err := s.state.Get("s3:"+bucketName, &bucket); err != nil {
+		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+	}
+
+	var request Delete
+	if err := xml.Unmarshal(req.Body, &request); err != nil || len(request.Objects) == 0 || len(request.Objects) > maxDeleteObjects {
+		return s.errorResponse(400, "MalformedXML", "The XML you provided was not well-formed or did not validate against our published schema"), nil
+	}
+
+	result := DeleteResult{Xmlns: "http://s3.amazonaws.com/doc/2006-03-01/"}
+	for _, object := range request.Objects {
+		if object.Key == "" {
+			result.Errors = append(result.Errors, XMLDeleteError{
+				Key:     object.Key,
+				Code:    "UserKeyMustBeSpecified",
+				Message: "The bucket POST must contain the specified field name. If it is specified, please check the order of the fields.",
+			})
+			continue
+		}
+
+		stateKey := "s3:" + bucketName + ":object:" + object.Key
+		if s.state.Exists(stateKey) && s.state.Delete(stateKey) != nil {
+			result.Errors = append(result.Errors, XMLDeleteError{
+				Key:     object.Key,
+				Code:    "InternalError",
+				Message: "We encountered an internal error. Please try again.",
+			})
+			continue
+		}
+		if !request.Quiet {
+			result.Deleted = append(result.Deleted, XMLDeletedObject{Key: object.Key})
+		}
 	}
 
 	resp, err := emulator.BuildS3StructResponse(result)
@@ -849,92 +1165,228 @@ func (s *S3Service) listObjectsV2(ctx context.Context, params map[string]interfa
 	return resp, nil
 }
 
-func (s *S3Service) errorResponse(statusCode int, code, message string) *emulator.AWSResponse {
-	return emulator.BuildRESTXMLErrorResponse(statusCode, code, message)
+// objectAttributes are the attributes GetObjectAttributes can return
+var objectAttributes = map[string]bool{
+	"ETag":         true,
+	"Checksum":     true,
+	"ObjectParts":  true,
+	"StorageClass": true,
+	"ObjectSize":   true,
 }
 
-// getBucketPolicy returns the bucket policy (NoSuchBucketPolicy if not set)
-func (s *S3Service) getBucketPolicy(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+func (s *S3Service) getObjectAttributes(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
 	bucketName := s.extractBucketName(req)
 	if bucketName == "" {
 		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
 	}
 
-	stateKey := "s3:" + bucketName
-	var bucket map[string]interface{}
-	if err := s.state.Get(stateKey, &bucket); err != nil {
-		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+	objectKey := s.extractObjectKey(req)
+	if objectKey == "" {
+		return s.errorResponse(400, "InvalidKey", "Object key is required"), nil
 	}
 
-	// Check if policy exists
-	if policy, ok := bucket["Policy"].(string); ok && policy != "" {
-		return &emulator.AWSResponse{
-			StatusCode: 200,
-			Headers: map[string]string{
-				"Content-Type": "application/json",
-			},
-			Body: []byte(policy),
-		}, nil
+	requested := map[string]bool{}
+	for _, attribute := range strings.Split(headerValue(req, "X-Amz-Object-Attributes"), ",") {
+		attribute = strings.TrimSpace(attribute)
+		if attribute == "" {
+			continue
+		}
+		if !objectAttributes[attribute] {
+			return s.errorResponse(400, "InvalidArgument", "Invalid attribute name specified."), nil
+		}
+		requested[attribute] = true
+	}
+	if len(requested) == 0 {
+		return s.errorResponse(400, "InvalidArgument", "The x-amz-object-attributes header specifying the attributes to be retrieved is either missing or empty"), nil
 	}
 
-	// No policy set - return NoSuchBucketPolicy error
-	return s.errorResponse(404, "NoSuchBucketPolicy", "The bucket policy does not exist"), nil
+	maxParts := 1000
+	if value := headerValue(req, "X-Amz-Max-Parts"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			return s.errorResponse(400, "InvalidArgument", "Provided max-parts not an integer or within integer range"), nil
+		}
+		maxParts = min(parsed, 1000)
+	}
+	partNumberMarker := 0
+	if value := headerValue(req, "X-Amz-Part-Number-Marker"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			return s.errorResponse(400, "InvalidArgument", "Provided part-number-marker not an integer or within integer range"), nil
+		}
+		partNumberMarker = parsed
+	}
+
+	var objMap map[string]interface{}
+	if err := s.state.Get("s3:"+bucketName+":object:"+objectKey, &objMap); err != nil {
+		return s.errorResponse(404, "NoSuchKey", "The specified key does not exist."), nil
+	}
+
+	result := GetObjectAttributesResponse{Xmlns: "http://s3.amazonaws.com/doc/2006-03-01/"}
+	if requested["ETag"] {
+		// Unlike the ETag header of other operations, the attribute isn't quoted
+		etag, _ := objMap["ETag"].(string)
+		result.ETag = strings.Trim(etag, "\"")
+	}
+	if requested["StorageClass"] {
+		result.StorageClass = objectStorageClass(objMap)
+	}
+	if requested["ObjectSize"] {
+		body, _ := objMap["Body"].(string)
+		size := int64(len(body))
+		result.ObjectSize = &size
+	}
+	// Only objects uploaded with a multipart upload have parts
+	if parts, ok := objMap["Parts"].([]interface{}); ok && requested["ObjectParts"] {
+		result.ObjectParts = objectAttributesParts(parts, partNumberMarker, maxParts)
+	}
+
+	resp, err := emulator.BuildS3StructResponse(result)
+	if err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to marshal response"), nil
+	}
+	if lastModified, ok := lastModifiedHeader(objMap); ok {
+		resp.Headers["Last-Modified"] = lastModified
+	}
+	return resp, nil
 }
 
-// putBucketPolicy sets the bucket policy
-func (s *S3Service) putBucketPolicy(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
-	bucketName := s.extractBucketName(req)
-	if bucketName == "" {
-		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+// objectAttributesParts returns the page of a multipart object's stored parts after the
+// part number marker
+func objectAttributesParts(parts []interface{}, partNumberMarker, maxParts int) *XMLObjectParts {
+	result := &XMLObjectParts{
+		PartsCount:       len(parts),
+		PartNumberMarker: partNumberMarker,
+		MaxParts:         maxParts,
+		Parts:            []XMLObjectPart{},
+	}
+	for _, p := range parts {
+		part, _ := p.(map[string]interface{})
+		partNumber, _ := part["PartNumber"].(float64)
+		if int(partNumber) <= partNumberMarker {
+			continue
+		}
+		if len(result.Parts) == maxParts {
+			result.IsTruncated = true
+			break
+		}
+		size, _ := part["Size"].(float64)
+		result.Parts = append(result.Parts, XMLObjectPart{PartNumber: int(partNumber), Size: int64(size)})
+		result.NextPartNumberMarker = int(partNumber)
 	}
+	return result
+}
 
-	stateKey := "s3:" + bucketName
-	var bucket map[string]interface{}
-	if err := s.state.Get(stateKey, &bucket); err != nil {
-		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+// objectStorageClass returns a stored object's storage class. Objects stored before
+// storage classes were recorded are STANDARD.
+func objectStorageClass(objMap map[string]interface{}) string {
+	if storageClass, ok := objMap["StorageClass"].(string); ok && storageClass != "" {
+		return storageClass
 	}
+	return storageClassStandard
+}
 
-	// Store policy
-	bucket["Policy"] = string(req.Body)
-	if err := s.state.Set(stateKey, bucket); err != nil {
-		return s.errorResponse(500, "InternalError", "Failed to set bucket policy"), nil
+// userMetadataPrefix is the prefix of the headers that set and return an object's user metadata
+const userMetadataPrefix = "x-amz-meta-"
+
+// maxUserMetadataSize is the most bytes the names and values of an object's user metadata
+// can add up to
+const maxUserMetadataSize = 2048
+
+// defaultContentType is the Content-Type of objects stored without one
+const defaultContentType = "application/octet-stream"
+
+// userMetadata returns the user metadata set by a request's x-amz-meta-* headers, keyed by
+// the lowercase name after the prefix
+func userMetadata(req *emulator.AWSRequest) map[string]interface{} {
+	metadata := map[string]interface{}{}
+	for name, value := range req.Headers {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, userMetadataPrefix) && len(name) > len(userMetadataPrefix) {
+			metadata[strings.TrimPrefix(name, userMetadataPrefix)] = value
+		}
 	}
+	return metadata
+}
 
-	return &emulator.AWSResponse{
-		StatusCode: 204,
-		Headers:    map[string]string{},
-		Body:       []byte{},
-	}, nil
+// userMetadataSize returns the size S3 limits user metadata by, the length of its names and
+// values
+func userMetadataSize(metadata map[string]interface{}) int {
+	size := 0
+	for name, value := range metadata {
+		size += len(name) + len(fmt.Sprint(value))
+	}
+	return size
 }
 
-// deleteBucketPolicy deletes the bucket policy
-func (s *S3Service) deleteBucketPolicy(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+// setObjectMetadataHeaders sets the Content-Type and x-amz-meta-* headers of a stored object
+func setObjectMetadataHeaders(headers map[string]string, objMap map[string]interface{}) {
+	contentType, _ := objMap["ContentType"].(string)
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+	headers["Content-Type"] = contentType
+
+	metadata, _ := objMap["Metadata"].(map[string]interface{})
+	for name, value := range metadata {
+		if value, ok := value.(string); ok {
+			headers[userMetadataPrefix+name] = value
+		}
+	}
+}
+
+// storageClassStandard is the storage class of objects stored without one
+const storageClassStandard = "STANDARD"
+
+// validStorageClasses are the storage classes PutObject accepts
+var validStorageClasses = map[string]bool{
+	storageClassStandard:  true,
+	"REDUCED_REDUNDANCY":  true,
+	"STANDARD_IA":         true,
+	"ONEZONE_IA":          true,
+	"INTELLIGENT_TIERING": true,
+	"GLACIER":             true,
+	"DEEP_ARCHIVE":        true,
+	"OUTPOSTS":            true,
+	"GLACIER_IR":          true,
+	"SNOW":                true,
+	"EXPRESS_ONEZONE":     true,
+}
+
+// lastModifiedHeader formats a stored object's write time for the Last-Modified header
+func lastModifiedHeader(objMap map[string]interface{}) (string, bool) {
+	stored, _ := objMap["LastModified"].(string)
+	lastModified, err := time.Parse(time.RFC3339, stored)
+	if err != nil {
+		return "", false
+	}
+	return lastModified.UTC().Format(http.TimeFormat), true
+}
+
+func (s *S3Service) headBucket(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
 	bucketName := s.extractBucketName(req)
 	if bucketName == "" {
 		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
 	}
 
 	stateKey := "s3:" + bucketName
+
+	// Check if bucket exists
 	var bucket map[string]interface{}
 	if err := s.state.Get(stateKey, &bucket); err != nil {
 		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
 	}
 
-	// Remove policy
-	delete(bucket, "Policy")
-	if err := s.state.Set(stateKey, bucket); err != nil {
-		return s.errorResponse(500, "InternalError", "Failed to delete bucket policy"), nil
-	}
-
 	return &emulator.AWSResponse{
-		StatusCode: 204,
+		StatusCode: 200,
 		Headers:    map[string]string{},
 		Body:       []byte{},
 	}, nil
 }
 
-// getBucketLogging returns the bucket logging configuration
-func (s *S3Service) getBucketLogging(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+// getBucketLocation returns the region the bucket was created in. Like S3, buckets in
+// us-east-1 have an empty location constraint.
+func (s *S3Service) getBucketLocation(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
 	bucketName := s.extractBucketName(req)
 	if bucketName == "" {
 		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
@@ -946,37 +1398,260 @@ func (s *S3Service) getBucketLogging(ctx context.Context, params map[string]inte
 		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
 	}
 
-	result := BucketLoggingStatus{
-		Xmlns: "http://s3.amazonaws.com/doc/2006-03-01/",
-	}
-
-	// Check if logging is configured
-	if logging, ok := bucket["Logging"].(map[string]interface{}); ok {
-		targetBucket := ""
-		targetPrefix := ""
-		if tb, ok := logging["TargetBucket"].(string); ok {
-			targetBucket = tb
-		}
-		if tp, ok := logging["TargetPrefix"].(string); ok {
-			targetPrefix = tp
-		}
-
-		result.LoggingEnabled = &XMLLoggingEnabled{
-			TargetBucket: targetBucket,
-			TargetPrefix: targetPrefix,
-		}
+	region, _ := bucket["Region"].(string)
+	if region == "us-east-1" {
+		region = ""
 	}
-	// When logging is not configured, LoggingEnabled is omitted
 
-	resp, err := emulator.BuildS3StructResponse(result)
+	resp, err := emulator.BuildS3StructResponse(LocationConstraint{
+		Xmlns:  "http://s3.amazonaws.com/doc/2006-03-01/",
+		Region: region,
+	})
 	if err != nil {
 		return s.errorResponse(500, "InternalError", "Failed to marshal response"), nil
 	}
 	return resp, nil
 }
 
-// putBucketLogging sets the bucket logging configuration
-func (s *S3Service) putBucketLogging(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+// listObjectsV2 lists the bucket's objects in key order. Keys that contain the delimiter after
+// the prefix are grouped into CommonPrefixes, which count towards max-keys like objects do. The
+// continuation token of a truncated list holds the last key or common prefix returned.
+func (s *S3Service) listObjectsV2(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	bucketName := s.extractBucketName(req)
+	if bucketName == "" {
+		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+	}
+
+	var bucket map[string]interface{}
+	if err := s.state.Get("s3:"+bucketName, &bucket); err != nil {
+		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+	}
+
+	query := requestQuery(req)
+	prefix, delimiter := query.Get("prefix"), query.Get("delimiter")
+
+	maxKeys := 1000
+	if value := query.Get("max-keys"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return s.errorResponse(400, "InvalidArgument", "Provided max-keys not an integer or within integer range"), nil
+		}
+		maxKeys = min(n, 1000)
+	}
+
+	// Listing resumes after the continuation token's position, or after start-after
+	operation := "s3:ListObjectsV2:" + bucketName
+	position := query.Get("start-after")
+	if token := query.Get("continuation-token"); token != "" {
+		decoded, err := emulator.DecodePageToken(operation, token)
+		if err != nil {
+			return s.errorResponse(400, "InvalidArgument", "The continuation token provided is incorrect"), nil
+		}
+		position = decoded
+	}
+
+	objectPrefix := "s3:" + bucketName + ":object:"
+	keys, err := s.state.List(objectPrefix + prefix)
+	if err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to list objects"), nil
+	}
+	// S3 lists keys in UTF-8 binary order
+	sort.Strings(keys)
+
+	contents := make([]XMLObject, 0, min(len(keys), maxKeys))
+	var commonPrefixes []XMLCommonPrefix
+	var last string
+	truncated := false
+	for _, stateKey := range keys {
+		key := strings.TrimPrefix(stateKey, objectPrefix)
+		entry := key
+		if delimiter != "" {
+			if idx := strings.Index(key[len(prefix):], delimiter); idx >= 0 {
+				entry = key[:len(prefix)+idx+len(delimiter)]
+			}
+		}
+		if entry <= position || entry == last {
+			continue
+		}
+		if len(contents)+len(commonPrefixes) == maxKeys {
+			truncated = maxKeys > 0
+			break
+		}
+		last = entry
+
+		if entry != key {
+			commonPrefixes = append(commonPrefixes, XMLCommonPrefix{Prefix: entry})
+			continue
+		}
+		var objMap map[string]interface{}
+		if err := s.state.Get(stateKey, &objMap); err != nil {
+			continue
+		}
+		etag, _ := objMap["ETag"].(string)
+		lastModified, _ := objMap["LastModified"].(string)
+		size, _ := objMap["Size"].(float64)
+		contents = append(contents, XMLObject{
+			Key:          key,
+			LastModified: lastModified,
+			ETag:         etag,
+			Size:         int64(size),
+			StorageClass: objectStorageClass(objMap),
+		})
+	}
+
+	// Build ListBucketResult using struct-based response
+	result := ListBucketResult{
+		Xmlns:             "http://s3.amazonaws.com/doc/2006-03-01/",
+		Name:              bucketName,
+		Prefix:            prefix,
+		Delimiter:         delimiter,
+		StartAfter:        query.Get("start-after"),
+		ContinuationToken: query.Get("continuation-token"),
+		KeyCount:          len(contents) + len(commonPrefixes),
+		MaxKeys:           maxKeys,
+		IsTruncated:       truncated,
+		Contents:          contents,
+		CommonPrefixes:    commonPrefixes,
+	}
+	if truncated {
+		result.NextContinuationToken = emulator.EncodePageToken(operation, last)
+	}
+
+	resp, err := emulator.BuildS3StructResponse(result)
+	if err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to marshal response"), nil
+	}
+	return resp, nil
+}
+
+func (s *S3Service) errorResponse(statusCode int, code, message string) *emulator.AWSResponse {
+	return emulator.BuildRESTXMLErrorResponse(statusCode, code, message)
+}
+
+// getBucketPolicy returns the bucket policy (NoSuchBucketPolicy if not set)
+func (s *S3Service) getBucketPolicy(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	bucketName := s.extractBucketName(req)
+	if bucketName == "" {
+		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+	}
+
+	stateKey := "s3:" + bucketName
+	var bucket map[string]interface{}
+	if err := s.state.Get(stateKey, &bucket); err != nil {
+		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+	}
+
+	// Check if policy exists
+	if policy, ok := bucket["Policy"].(string); ok && policy != "" {
+		return &emulator.AWSResponse{
+			StatusCode: 200,
+			Headers: map[string]string{
+				"Content-Type": "application/json",
+			},
+			Body: []byte(policy),
+		}, nil
+	}
+
+	// No policy set - return NoSuchBucketPolicy error
+	return s.errorResponse(404, "NoSuchBucketPolicy", "The bucket policy does not exist"), nil
+}
+
+// putBucketPolicy sets the bucket policy
+func (s *S3Service) putBucketPolicy(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	bucketName := s.extractBucketName(req)
+	if bucketName == "" {
+		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+	}
+
+	stateKey := "s3:" + bucketName
+	var bucket map[string]interface{}
+	if err := s.state.Get(stateKey, &bucket); err != nil {
+		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+	}
+
+	// Store policy
+	bucket["Policy"] = string(req.Body)
+	if err := s.state.Set(stateKey, bucket); err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to set bucket policy"), nil
+	}
+
+	return &emulator.AWSResponse{
+		StatusCode: 204,
+		Headers:    map[string]string{},
+		Body:       []byte{},
+	}, nil
+}
+
+// deleteBucketPolicy deletes the bucket policy
+func (s *S3Service) deleteBucketPolicy(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	bucketName := s.extractBucketName(req)
+	if bucketName == "" {
+		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+	}
+
+	stateKey := "s3:" + bucketName
+	var bucket map[string]interface{}
+	if err := s.state.Get(stateKey, &bucket); err != nil {
+		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+	}
+
+	// Remove policy
+	delete(bucket, "Policy")
+	if err := s.state.Set(stateKey, bucket); err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to delete bucket policy"), nil
+	}
+
+	return &emulator.AWSResponse{
+		StatusCode: 204,
+		Headers:    map[string]string{},
+		Body:       []byte{},
+	}, nil
+}
+
+// getBucketLogging returns the bucket logging configuration
+func (s *S3Service) getBucketLogging(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	bucketName := s.extractBucketName(req)
+	if bucketName == "" {
+		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+	}
+
+	stateKey := "s3:" + bucketName
+	var bucket map[string]interface{}
+	if err := s.state.Get(stateKey, &bucket); err != nil {
+		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+	}
+
+	result := BucketLoggingStatus{
+		Xmlns: "http://s3.amazonaws.com/doc/2006-03-01/",
+	}
+
+	// Check if logging is configured
+	if logging, ok := bucket["Logging"].(map[string]interface{}); ok {
+		targetBucket := ""
+		targetPrefix := ""
+		if tb, ok := logging["TargetBucket"].(string); ok {
+			targetBucket = tb
+		}
+		if tp, ok := logging["TargetPrefix"].(string); ok {
+			targetPrefix = tp
+		}
+
+		result.LoggingEnabled = &XMLLoggingEnabled{
+			TargetBucket: targetBucket,
+			TargetPrefix: targetPrefix,
+		}
+	}
+	// When logging is not configured, LoggingEnabled is omitted
+
+	resp, err := emulator.BuildS3StructResponse(result)
+	if err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to marshal response"), nil
+	}
+	return resp, nil
+}
+
+// putBucketLogging sets the bucket logging configuration
+func (s *S3Service) putBucketLogging(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
 	bucketName := s.extractBucketName(req)
 	if bucketName == "" {
 		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
@@ -984,37 +1659,719 @@ func (s *S3Service) putBucketLogging(ctx context.Context, params map[string]inte
 
 	stateKey := "s3:" + bucketName
 	var bucket map[string]interface{}
-	if err := s.state.Get(stateKey, &bucket); err != nil {
+	if err := s.state.Get(stateKey, &bucket); err != nil {
+		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+	}
+
+	// Parse logging configuration from XML body
+	type LoggingEnabled struct {
+		TargetBucket string `xml:"TargetBucket"`
+		TargetPrefix string `xml:"TargetPrefix"`
+	}
+	type BucketLoggingStatus struct {
+		XMLName        xml.Name       `xml:"BucketLoggingStatus"`
+		LoggingEnabled LoggingEnabled `xml:"LoggingEnabled"`
+	}
+
+	// A status without a target bucket, like an empty <BucketLoggingStatus />, disables logging
+	var loggingConfig BucketLoggingStatus
+	if err := xml.Unmarshal(req.Body, &loggingConfig); err == nil && loggingConfig.LoggingEnabled.TargetBucket != "" {
+		// Store logging configuration
+		bucket["Logging"] = map[string]interface{}{
+			"TargetBucket": loggingConfig.LoggingEnabled.TargetBucket,
+			"TargetPrefix": loggingConfig.LoggingEnabled.TargetPrefix,
+		}
+	} else {
+		delete(bucket, "Logging")
+	}
+
+	if err := s.state.Set(stateKey, bucket); err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to set bucket logging"), nil
+	}
+
+	// AWS S3 PutBucketLogging returns 204 No Content on success
+	return &emulator.AWSResponse{
+		StatusCode: 204,
+		Headers:    map[string]string{},
+		Body:       []byte{},
+	}, nil
+}
+
+// getBucketAcl returns the bucket's access control policy. Buckets without one are private,
+// i.e. only the owner has FULL_CONTROL.
+func (s *S3Service) getBucketAcl(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	bucketName := s.extractBucketName(req)
+	if bucketName == "" {
+		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+	}
+
+	var bucket map[string]interface{}
+	if err := s.state.Get("s3:"+bucketName, &bucket); err != nil {
+		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+	}
+
+	var grants []XMLGrant
+	if err := s.state.Get("s3:"+bucketName+":acl", &grants); err != nil {
+		grants, _ = cannedACLGrants("private")
+	}
+
+	result := AccessControlPolicy{
+		Xmlns:             "http://s3.amazonaws.com/doc/2006-03-01/",
+		Owner:             bucketOwnerXML,
+		AccessControlList: XMLAccessControlList{Grants: grants},
+	}
+
+	resp, err := emulator.BuildS3StructResponse(result)
+	if err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to marshal response"), nil
+	}
+	return resp, nil
+}
+
+// putBucketAcl sets the bucket's access control policy, either from a canned ACL in the
+// x-amz-acl header or from the AccessControlPolicy in the body
+func (s *S3Service) putBucketAcl(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	bucketName := s.extractBucketName(req)
+	if bucketName == "" {
+		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+	}
+
+	var bucket map[string]interface{}
+	if err := s.state.Get("s3:"+bucketName, &bucket); err != nil {
+		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+	}
+
+	var grants []XMLGrant
+	if cannedACL := headerValue(req, "X-Amz-Acl"); cannedACL != "" {
+		var ok bool
+		grants, ok = cannedACLGrants(cannedACL)
+		if !ok {
+			return s.errorResponse(400, "InvalidArgument", fmt.Sprintf("Invalid canned ACL: %s", cannedACL)), nil
+		}
+	} else {
+		if len(req.Body) == 0 {
+			return s.errorResponse(400, "MissingSecurityHeader", "Your request was missing a required header: x-amz-acl"), nil
+		}
+
+		var policy AccessControlPolicy
+		if err := xml.Unmarshal(req.Body, &policy); err != nil {
+			return s.errorResponse(400, "MalformedACLError", "The XML you provided was not well-formed or did not validate against our published schema"), nil
+		}
+		for _, grant := range policy.AccessControlList.Grants {
+			grant.Grantee = granteeXML(grant.Grantee)
+			if grant.Grantee.Type == "" {
+				return s.errorResponse(400, "MalformedACLError", "Each grantee must have an ID, URI or EmailAddress"), nil
+			}
+			grants = append(grants, grant)
+		}
+	}
+
+	if err := s.state.Set("s3:"+bucketName+":acl", grants); err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to set bucket ACL"), nil
+	}
+
+	return &emulator.AWSResponse{
+		StatusCode: 200,
+		Headers:    map[string]string{},
+		Body:       []byte{},
+	}, nil
+}
+
+// bucketOwnerXML is the canonical user that owns every bucket
+var bucketOwnerXML = XMLOwner{
+	ID:          "infraspec-api",
+	DisplayName: "infraspec-api",
+}
+
+// S3 predefined groups that grants can be made to
+const (
+	allUsersGroupURI           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	authenticatedUsersGroupURI = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+	logDeliveryGroupURI        = "http://acs.amazonaws.com/groups/s3/LogDelivery"
+)
+
+// cannedACLGrants returns the grants of a canned ACL, which always include FULL_CONTROL for
+// the owner. bucket-owner-read and bucket-owner-full-control only apply to objects, so on a
+// bucket they're the same as private.
+func cannedACLGrants(cannedACL string) ([]XMLGrant, bool) {
+	grants := []XMLGrant{{
+		Grantee:    granteeXML(XMLGrantee{ID: bucketOwnerXML.ID, DisplayName: bucketOwnerXML.DisplayName}),
+		Permission: "FULL_CONTROL",
+	}}
+	groupGrant := func(uri, permission string) XMLGrant {
+		return XMLGrant{Grantee: granteeXML(XMLGrantee{URI: uri}), Permission: permission}
+	}
+
+	switch cannedACL {
+	case "private", "bucket-owner-read", "bucket-owner-full-control":
+	case "public-read":
+		grants = append(grants, groupGrant(allUsersGroupURI, "READ"))
+	case "public-read-write":
+		grants = append(grants, groupGrant(allUsersGroupURI, "READ"), groupGrant(allUsersGroupURI, "WRITE"))
+	case "authenticated-read":
+		grants = append(grants, groupGrant(authenticatedUsersGroupURI, "READ"))
+	case "log-delivery-write":
+		grants = append(grants, groupGrant(logDeliveryGroupURI, "WRITE"), groupGrant(logDeliveryGroupURI, "READ_ACP"))
+	default:
+		return nil, false
+	}
+	return grants, true
+}
+
+// granteeXML sets the xsi:type of a grantee from the field that identifies it. The type
+// attribute of a request's grantees isn't decoded, as its namespace prefix is expanded.
+func granteeXML(grantee XMLGrantee) XMLGrantee {
+	grantee.XmlnsXsi = "http://www.w3.org/2001/XMLSchema-instance"
+	switch {
+	case grantee.ID != "":
+		grantee.Type = "CanonicalUser"
+	case grantee.URI != "":
+		grantee.Type = "Group"
+	case grantee.EmailAddress != "":
+		grantee.Type = "AmazonCustomerByEmail"
+	default:
+		grantee.Type = ""
+	}
+	return grantee
+}
+
+// headerValue returns a request header, whether or not its name was canonicalized
+func headerValue(req *emulator.AWSRequest, name string) string {
+	if value, ok := req.Headers[name]; ok {
+		return value
+	}
+	return req.Headers[strings.ToLower(name)]
+}
+
+// getBucketRequestPayment returns who pays for requests to the bucket, the bucket owner
+// unless it was changed to the requester
+func (s *S3Service) getBucketRequestPayment(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	bucketName := s.extractBucketName(req)
+	if bucketName == "" {
+		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+	}
+
+	var bucket map[string]interface{}
+	if err := s.state.Get("s3:"+bucketName, &bucket); err != nil {
+		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+	}
+
+	payer := "BucketOwner"
+	var requestPayment map[string]interface{}
+	if err := s.state.Get("s3:"+bucketName+":requestPayment", &requestPayment); err == nil {
+		if p, ok := requestPayment["Payer"].(string); ok {
+			payer = p
+		}
+	}
+
+	resp, err := emulator.BuildS3StructResponse(RequestPaymentConfiguration{
+		Xmlns: "http://s3.amazonaws.com/doc/2006-03-01/",
+		Payer: payer,
+	})
+	if err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to marshal response"), nil
+	}
+	return resp, nil
+}
+
+// putBucketRequestPayment sets who pays for requests to the bucket
+func (s *S3Service) putBucketRequestPayment(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	bucketName := s.extractBucketName(req)
+	if bucketName == "" {
+		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+	}
+
+	var bucket map[string]interface{}
+	if err := s.state.Get("s3:"+bucketName, &bucket); err != nil {
+		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+	}
+
+	var config RequestPaymentConfiguration
+	if err := xml.Unmarshal(req.Body, &config); err != nil {
+		return s.errorResponse(400, "MalformedXML", "The XML you provided was not well-formed"), nil
+	}
+	if config.Payer != "BucketOwner" && config.Payer != "Requester" {
+		return s.errorResponse(400, "MalformedXML", "Payer must be BucketOwner or Requester"), nil
+	}
+
+	requestPayment := map[string]interface{}{
+		"Payer": config.Payer,
+	}
+	if err := s.state.Set("s3:"+bucketName+":requestPayment", requestPayment); err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to put bucket request payment"), nil
+	}
+
+	return &emulator.AWSResponse{
+		StatusCode: 200,
+		Headers:    map[string]string{},
+		Body:       []byte{},
+	}, nil
+}
+
+// getBucketAccelerateConfiguration returns the bucket's transfer acceleration status, which
+// is omitted if acceleration was never configured
+func (s *S3Service) getBucketAccelerateConfiguration(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	bucketName := s.extractBucketName(req)
+	if bucketName == "" {
+		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+	}
+
+	var bucket map[string]interface{}
+	if err := s.state.Get("s3:"+bucketName, &bucket); err != nil {
+		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+	}
+
+	result := AccelerateConfiguration{
+		Xmlns: "http://s3.amazonaws.com/doc/2006-03-01/",
+	}
+	var accelerate map[string]interface{}
+	if err := s.state.Get("s3:"+bucketName+":accelerate", &accelerate); err == nil {
+		if status, ok := accelerate["Status"].(string); ok {
+			result.Status = status
+		}
+	}
+
+	resp, err := emulator.BuildS3StructResponse(result)
+	if err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to marshal response"), nil
+	}
+	return resp, nil
+}
+
+// putBucketAccelerateConfiguration enables or suspends transfer acceleration for the bucket
+func (s *S3Service) putBucketAccelerateConfiguration(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	bucketName := s.extractBucketName(req)
+	if bucketName == "" {
+		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+	}
+
+	var bucket map[string]interface{}
+	if err := s.state.Get("s3:"+bucketName, &bucket); err != nil {
 		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
 	}
 
-	// Parse logging configuration from XML body
-	type LoggingEnabled struct {
-		TargetBucket string `xml:"TargetBucket"`
-		TargetPrefix string `xml:"TargetPrefix"`
+	var config AccelerateConfiguration
+	if err := xml.Unmarshal(req.Body, &config); err != nil {
+		return s.errorResponse(400, "MalformedXML", "The XML you provided was not well-formed"), nil
 	}
-	type BucketLoggingStatus struct {
-		XMLName        xml.Name       `xml:"BucketLoggingStatus"`
-		LoggingEnabled LoggingEnabled `xml:"LoggingEnabled"`
+	if config.Status != "Enabled" && config.Status != "Suspended" {
+		return s.errorResponse(400, "MalformedXML", "Status must be Enabled or Suspended"), nil
 	}
 
-	var loggingConfig BucketLoggingStatus
-	if err := xml.Unmarshal(req.Body, &loggingConfig); err == nil {
-		// Store logging configuration
-		bucket["Logging"] = map[string]interface{}{
-			"TargetBucket": loggingConfig.LoggingEnabled.TargetBucket,
-			"TargetPrefix": loggingConfig.LoggingEnabled.TargetPrefix,
+	// Like S3, bucket names with dots can't use acceleration
+	if config.Status == "Enabled" && strings.Contains(bucketName, ".") {
+		return s.errorResponse(400, "InvalidRequest", "S3 Transfer Acceleration is not supported for buckets with periods (.) in their names"), nil
+	}
+
+	accelerate := map[string]interface{}{
+		"Status": config.Status,
+	}
+	if err := s.state.Set("s3:"+bucketName+":accelerate", accelerate); err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to put bucket accelerate configuration"), nil
+	}
+
+	return &emulator.AWSResponse{
+		StatusCode: 200,
+		Headers:    map[string]string{},
+		Body:       []byte{},
+	}, nil
+}
+
+// getBucketLifecycleConfiguration returns the lifecycle configuration stored for the bucket
+func (s *S3Service) getBucketLifecycleConfiguration(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	bucketName := s.extractBucketName(req)
+	if bucketName == "" {
+		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+	}
+
+	var bucket map[string]interface{}
+	if err := s.state.Get("s3:"+bucketName, &bucket); err != nil {
+		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+	}
+
+	var lifecycle map[string]interface{}
+	if err := s.state.Get("s3:"+bucketName+":lifecycle", &lifecycle); err != nil {
+		return s.errorResponse(404, "NoSuchLifecycleConfiguration", "The lifecycle configuration does not exist"), nil
+	}
+	configuration, _ := lifecycle["Configuration"].(string)
+
+	return &emulator.AWSResponse{
+		StatusCode: 200,
+		Headers: map[string]string{
+			"Content-Type": "application/xml",
+		},
+		Body: []byte(configuration),
+	}, nil
+}
+
+// putBucketLifecycleConfiguration replaces the bucket's lifecycle configuration. The XML is
+// stored as sent, once its rules have been checked, and returned verbatim by
+// getBucketLifecycleConfiguration.
+func (s *S3Service) putBucketLifecycleConfiguration(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	bucketName := s.extractBucketName(req)
+	if bucketName == "" {
+		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+	}
+
+	var bucket map[string]interface{}
+	if err := s.state.Get("s3:"+bucketName, &bucket); err != nil {
+		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+	}
+
+	var config LifecycleConfiguration
+	if err := xml.Unmarshal(req.Body, &config); err != nil {
+		return s.errorResponse(400, "MalformedXML", "The XML you provided was not well-formed"), nil
+	}
+	if len(config.Rules) == 0 {
+		return s.errorResponse(400, "MalformedXML", "The lifecycle configuration must have at least one rule"), nil
+	}
+	for _, rule := range config.Rules {
+		if rule.Status != "Enabled" && rule.Status != "Disabled" {
+			return s.errorResponse(400, "MalformedXML", "Rule status must be Enabled or Disabled"), nil
 		}
-	} else {
-		// Empty body or invalid XML - disable logging
-		delete(bucket, "Logging")
 	}
 
-	if err := s.state.Set(stateKey, bucket); err != nil {
-		return s.errorResponse(500, "InternalError", "Failed to set bucket logging"), nil
+	lifecycle := map[string]interface{}{
+		"Configuration": string(req.Body),
+	}
+	if err := s.state.Set("s3:"+bucketName+":lifecycle", lifecycle); err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to put bucket lifecycle configuration"), nil
+	}
+
+	return &emulator.AWSResponse{
+		StatusCode: 200,
+		Headers:    map[string]string{},
+		Body:       []byte{},
+	}, nil
+}
+
+// deleteBucketLifecycle removes the bucket's lifecycle configuration
+func (s *S3Service) deleteBucketLifecycle(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	bucketName := s.extractBucketName(req)
+	if bucketName == "" {
+		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+	}
+
+	var bucket map[string]interface{}
+	if err := s.state.Get("s3:"+bucketName, &bucket); err != nil {
+		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+	}
+
+	// Like S3, deleting a configuration that doesn't exist succeeds
+	_ = s.state.Delete("s3:" + bucketName + ":lifecycle")
+
+	return &emulator.AWSResponse{
+		StatusCode: 204,
+		Headers:    map[string]string{},
+		Body:       []byte{},
+	}, nil
+}
+
+// listMultipartUploads lists the bucket's in-progress multipart uploads, stored under
+// "s3:<bucket>:mpu:<upload id>", in key order and then by when they were initiated. The
+// prefix query parameter limits the uploads to keys that begin with it.
+func (s *S3Service) listMultipartUploads(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	bucketName := s.extractBucketName(req)
+	if bucketName == "" {
+		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+	}
+
+	var bucket map[string]interface{}
+	if err := s.state.Get("s3:"+bucketName, &bucket); err != nil {
+		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+	}
+
+	prefix := ""
+	if idx := strings.Index(req.Path, "?"); idx >= 0 {
+		query, _ := url.ParseQuery(req.Path[idx+1:])
+		prefix = query.Get("prefix")
+	}
+
+	keys, err := s.state.List("s3:" + bucketName + ":mpu:")
+	if err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to list multipart uploads"), nil
+	}
+
+	uploads := make([]XMLMultipartUpload, 0, len(keys))
+	for _, key := range keys {
+		var upload map[string]interface{}
+		if err := s.state.Get(key, &upload); err != nil {
+			continue
+		}
+		objectKey, _ := upload["Key"].(string)
+		if !strings.HasPrefix(objectKey, prefix) {
+			continue
+		}
+		uploadID, _ := upload["UploadId"].(string)
+		initiated, _ := upload["Initiated"].(string)
+		uploads = append(uploads, XMLMultipartUpload{
+			Key:          objectKey,
+			UploadId:     uploadID,
+			Initiator:    bucketOwnerXML,
+			Owner:        bucketOwnerXML,
+			StorageClass: objectStorageClass(upload),
+			Initiated:    initiated,
+		})
+	}
+	sort.Slice(uploads, func(i, j int) bool {
+		if uploads[i].Key != uploads[j].Key {
+			return uploads[i].Key < uploads[j].Key
+		}
+		return uploads[i].Initiated < uploads[j].Initiated
+	})
+
+	result := ListMultipartUploadsResult{
+		Xmlns:       "http://s3.amazonaws.com/doc/2006-03-01/",
+		Bucket:      bucketName,
+		Prefix:      prefix,
+		MaxUploads:  1000,
+		IsTruncated: false,
+		Uploads:     uploads,
+	}
+
+	resp, err := emulator.BuildS3StructResponse(result)
+	if err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to marshal response"), nil
+	}
+	return resp, nil
+}
+
+// maxPartNumber is the highest part number a multipart upload can have
+const maxPartNumber = 10000
+
+// noSuchUploadMessage is the message of NoSuchUpload errors
+const noSuchUploadMessage = "The specified upload does not exist. The upload ID may be invalid, or the upload may have been aborted or completed."
+
+// errNoSuchUpload is returned updating an upload that's for another object
+var errNoSuchUpload = errors.New("no such upload")
+
+// multipartUploadKey returns the state key of an in-progress multipart upload
+func multipartUploadKey(bucketName, uploadID string) string {
+	return "s3:" + bucketName + ":mpu:" + uploadID
+}
+
+// requestQuery returns the query parameters of a request, whose path includes its query string
+func requestQuery(req *emulator.AWSRequest) url.Values {
+	if idx := strings.Index(req.Path, "?"); idx >= 0 {
+		query, _ := url.ParseQuery(req.Path[idx+1:])
+		return query
+	}
+	return url.Values{}
+}
+
+// createMultipartUpload starts a multipart upload of an object, storing it under
+// "s3:<bucket>:mpu:<upload id>" until it's completed or aborted
+func (s *S3Service) createMultipartUpload(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	bucketName := s.extractBucketName(req)
+	if bucketName == "" {
+		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+	}
+
+	objectKey := s.extractObjectKey(req)
+	if objectKey == "" {
+		return s.errorResponse(400, "InvalidKey", "Object key is required"), nil
+	}
+
+	var bucket map[string]interface{}
+	if err := s.state.Get("s3:"+bucketName, &bucket); err != nil {
+		return s.errorResponse(404, "NoSuchBucket", "The specified bucket does not exist"), nil
+	}
+
+	storageClass := headerValue(req, "X-Amz-Storage-Class")
+	if storageClass == "" {
+		storageClass = storageClassStandard
+	}
+	if !validStorageClasses[storageClass] {
+		return s.errorResponse(400, "InvalidStorageClass", "The storage class you specified is not valid"), nil
+	}
+
+	metadata := userMetadata(req)
+	if userMetadataSize(metadata) > maxUserMetadataSize {
+		return s.errorResponse(400, "MetadataTooLarge", "Your metadata headers exceed the maximum allowed metadata size."), nil
+	}
+
+	uploadID := uuid.New().String()
+	upload := map[string]interface{}{
+		"UploadId":     uploadID,
+		"Key":          objectKey,
+		"Bucket":       bucketName,
+		"Initiated":    s.clock.Now().Format(s3TimestampFormat),
+		"StorageClass": storageClass,
+		"ContentType":  headerValue(req, "Content-Type"),
+		"Metadata":     metadata,
+		"Parts":        map[string]interface{}{},
+	}
+	if err := s.state.Set(multipartUploadKey(bucketName, uploadID), upload); err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to create multipart upload"), nil
+	}
+
+	resp, err := emulator.BuildS3StructResponse(InitiateMultipartUploadResult{
+		Xmlns:    "http://s3.amazonaws.com/doc/2006-03-01/",
+		Bucket:   bucketName,
+		Key:      objectKey,
+		UploadId: uploadID,
+	})
+	if err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to marshal response"), nil
+	}
+	return resp, nil
+}
+
+// uploadPart stores a part of a multipart upload by its part number, replacing any part
+// uploaded with the same number. The part's ETag is the MD5 of its content.
+func (s *S3Service) uploadPart(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	bucketName := s.extractBucketName(req)
+	if bucketName == "" {
+		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+	}
+
+	query := requestQuery(req)
+	partNumber, err := strconv.Atoi(query.Get("partNumber"))
+	if err != nil || partNumber < 1 || partNumber > maxPartNumber {
+		return s.errorResponse(400, "InvalidArgument", fmt.Sprintf("Part number must be an integer between 1 and %d, inclusive", maxPartNumber)), nil
+	}
+
+	sum := md5.Sum(req.Body)
+	etag := fmt.Sprintf("\"%s\"", hex.EncodeToString(sum[:]))
+
+	objectKey := s.extractObjectKey(req)
+	var upload map[string]interface{}
+	err = s.state.Update(multipartUploadKey(bucketName, query.Get("uploadId")), &upload, func() error {
+		if key, _ := upload["Key"].(string); key != objectKey {
+			return errNoSuchUpload
+		}
+		parts, _ := upload["Parts"].(map[string]interface{})
+		if parts == nil {
+			parts = map[string]interface{}{}
+		}
+		parts[strconv.Itoa(partNumber)] = map[string]interface{}{
+			"PartNumber":   partNumber,
+			"Size":         len(req.Body),
+			"ETag":         etag,
+			"Body":         string(req.Body),
+			"LastModified": s.clock.Now().Format(s3TimestampFormat),
+		}
+		upload["Parts"] = parts
+		return nil
+	})
+	if err != nil {
+		return s.errorResponse(404, "NoSuchUpload", noSuchUploadMessage), nil
+	}
+
+	return &emulator.AWSResponse{
+		StatusCode: 200,
+		Headers: map[string]string{
+			"Content-Type": "application/xml",
+			"ETag":         etag,
+		},
+		Body: []byte{},
+	}, nil
+}
+
+// completeMultipartUpload joins the listed parts of a multipart upload, in order, into the
+// object and removes the upload. Like S3, the object's ETag is the MD5 of the parts' MD5s
+// followed by the number of parts, e.g. "-2".
+func (s *S3Service) completeMultipartUpload(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	bucketName := s.extractBucketName(req)
+	if bucketName == "" {
+		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+	}
+
+	objectKey := s.extractObjectKey(req)
+	uploadKey := multipartUploadKey(bucketName, requestQuery(req).Get("uploadId"))
+	var upload map[string]interface{}
+	if err := s.state.Get(uploadKey, &upload); err != nil {
+		return s.errorResponse(404, "NoSuchUpload", noSuchUploadMessage), nil
+	}
+	if key, _ := upload["Key"].(string); key != objectKey {
+		return s.errorResponse(404, "NoSuchUpload", noSuchUploadMessage), nil
+	}
+
+	var completed CompleteMultipartUpload
+	if err := xml.Unmarshal(req.Body, &completed); err != nil || len(completed.Parts) == 0 {
+		return s.errorResponse(400, "MalformedXML", "The XML you provided was not well-formed or did not validate against our published schema"), nil
+	}
+
+	stored, _ := upload["Parts"].(map[string]interface{})
+	var body strings.Builder
+	var digests []byte
+	objectParts := make([]interface{}, 0, len(completed.Parts))
+	for i, part := range completed.Parts {
+		if i > 0 && part.PartNumber <= completed.Parts[i-1].PartNumber {
+			return s.errorResponse(400, "InvalidPartOrder", "The list of parts was not in ascending order. The parts list must be specified in order by part number."), nil
+		}
+
+		storedPart, _ := stored[strconv.Itoa(part.PartNumber)].(map[string]interface{})
+		etag, _ := storedPart["ETag"].(string)
+		if storedPart == nil || strings.Trim(part.ETag, "\"") != strings.Trim(etag, "\"") {
+			return s.errorResponse(400, "InvalidPart", "One or more of the specified parts could not be found. The part might not have been uploaded, or the specified entity tag might not have matched the part's entity tag."), nil
+		}
+
+		partBody, _ := storedPart["Body"].(string)
+		body.WriteString(partBody)
+		digest, _ := hex.DecodeString(strings.Trim(etag, "\""))
+		digests = append(digests, digest...)
+		objectParts = append(objectParts, map[string]interface{}{
+			"PartNumber": part.PartNumber,
+			"Size":       len(partBody),
+			"ETag":       etag,
+		})
+	}
+
+	sum := md5.Sum(digests)
+	etag := fmt.Sprintf("\"%s-%d\"", hex.EncodeToString(sum[:]), len(completed.Parts))
+	object := map[string]interface{}{
+		"Key":          objectKey,
+		"Bucket":       bucketName,
+		"Size":         body.Len(),
+		"LastModified": s.clock.Now().Format(s3TimestampFormat),
+		"ETag":         etag,
+		"Body":         body.String(),
+		"StorageClass": objectStorageClass(upload),
+		"ContentType":  upload["ContentType"],
+		"Metadata":     upload["Metadata"],
+		"Parts":        objectParts,
+	}
+	if err := s.state.Set("s3:"+bucketName+":object:"+objectKey, object); err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to complete multipart upload"), nil
+	}
+	if err := s.state.Delete(uploadKey); err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to complete multipart upload"), nil
+	}
+
+	resp, err := emulator.BuildS3StructResponse(CompleteMultipartUploadResult{
+		Xmlns:    "http://s3.amazonaws.com/doc/2006-03-01/",
+		Location: strings.TrimSuffix(s.bucketLocation(bucketName, req), "/") + "/" + objectKey,
+		Bucket:   bucketName,
+		Key:      objectKey,
+		ETag:     etag,
+	})
+	if err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to marshal response"), nil
+	}
+	return resp, nil
+}
+
+// abortMultipartUpload removes a multipart upload and the parts uploaded for it
+func (s *S3Service) abortMultipartUpload(ctx context.Context, params map[string]interface{}, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	bucketName := s.extractBucketName(req)
+	if bucketName == "" {
+		return s.errorResponse(400, "InvalidBucketName", "Bucket name is required"), nil
+	}
+
+	uploadKey := multipartUploadKey(bucketName, requestQuery(req).Get("uploadId"))
+	var upload map[string]interface{}
+	if err := s.state.Get(uploadKey, &upload); err != nil {
+		return s.errorResponse(404, "NoSuchUpload", noSuchUploadMessage), nil
+	}
+	if key, _ := upload["Key"].(string); key != s.extractObjectKey(req) {
+		return s.errorResponse(404, "NoSuchUpload", noSuchUploadMessage), nil
+	}
+
+	if err := s.state.Delete(uploadKey); err != nil {
+		return s.errorResponse(500, "InternalError", "Failed to abort multipart upload"), nil
 	}
 
-	// AWS S3 PutBucketLogging returns 204 No Content on success
 	return &emulator.AWSResponse{
 		StatusCode: 204,
 		Headers:    map[string]string{},
@@ -1041,10 +2398,55 @@ func (s *S3Service) isS3ControlRequest(req *emulator.AWSRequest) bool {
 	return strings.HasPrefix(req.Path, "/v20180820/")
 }
 
+// S3ControlService serves the S3 Control API from the state of an S3Service. The router
+// resolves S3 Control requests to their own service name, since they're signed as "s3".
+type S3ControlService struct {
+	s3 *S3Service
+}
+
+func NewS3ControlService(s3 *S3Service) *S3ControlService {
+	return &S3ControlService{s3: s3}
+}
+
+func (s *S3ControlService) ServiceName() string {
+	return "s3control"
+}
+
+// Protocol returns the AWS protocol the service speaks
+func (s *S3ControlService) Protocol() emulator.ProtocolType {
+	return emulator.ProtocolRESTXML
+}
+
+// ExtractAction implements the ActionExtractor interface, deriving the action from the
+// HTTP method and path like S3Service does
+func (s *S3ControlService) ExtractAction(req *emulator.AWSRequest) string {
+	if req.Action != "" || !strings.Contains(req.Path, "/v20180820/tags/") {
+		return req.Action
+	}
+
+	switch req.Method {
+	case "GET":
+		return "ListTagsForResource"
+	case "POST", "PUT":
+		return "TagResource"
+	case "DELETE":
+		return "UntagResource"
+	}
+	return req.Action
+}
+
+func (s *S3ControlService) HandleRequest(ctx context.Context, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	if err := s.s3.validator.ValidateRequest(req); err != nil {
+		return s.s3.errorResponse(400, "ValidationException", err.Error()), nil
+	}
+	return s.s3.handleS3ControlRequest(ctx, req)
+}
+
 // handleS3ControlRequest handles S3 Control API requests
 func (s *S3Service) handleS3ControlRequest(ctx context.Context, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
 	// S3 Control API uses REST paths like:
 	// GET /v20180820/tags/{resourceArn+} - GetBucketTagging / ListTagsForResource
+	// POST /v20180820/tags/{resourceArn+} - TagResource
 	// PUT /v20180820/tags/{resourceArn+} - PutBucketTagging / PutResourceTagging
 	// DELETE /v20180820/tags/{resourceArn+} - DeleteBucketTagging
 
@@ -1055,7 +2457,7 @@ func (s *S3Service) handleS3ControlRequest(ctx context.Context, req *emulator.AW
 		switch req.Method {
 		case "GET":
 			return s.s3ControlGetResourceTagging(ctx, req)
-		case "PUT":
+		case "POST", "PUT":
 			return s.s3ControlPutResourceTagging(ctx, req)
 		case "DELETE":
 			return s.s3ControlDeleteResourceTagging(ctx, req)
@@ -1069,7 +2471,10 @@ func (s *S3Service) handleS3ControlRequest(ctx context.Context, req *emulator.AW
 // Path format: /v20180820/tags/{resourceArn+}
 // Example: /v20180820/tags/arn%3Aaws%3As3%3Aus-east-1%3A123456789012%3Abucket%2Fmy-bucket
 func (s *S3Service) extractResourceArnFromPath(path string) string {
-	// Remove the /v20180820/tags/ prefix
+	// Remove the query string and the /v20180820/tags/ prefix
+	if idx := strings.Index(path, "?"); idx >= 0 {
+		path = path[:idx]
+	}
 	if idx := strings.Index(path, "/v20180820/tags/"); idx >= 0 {
 		arnEncoded := path[idx+len("/v20180820/tags/"):]
 		// URL decode the ARN
@@ -1106,7 +2511,7 @@ func (s *S3Service) extractBucketNameFromArn(arn string) string {
 	return ""
 }
 
-// s3ControlGetResourceTagging handles GetBucketTagging / ListTagsForResource
+// s3ControlGetResourceTagging handles ListTagsForResource
 func (s *S3Service) s3ControlGetResourceTagging(ctx context.Context, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
 	resourceArn := s.extractResourceArnFromPath(req.Path)
 	bucketName := s.extractBucketNameFromArn(resourceArn)
@@ -1125,17 +2530,22 @@ func (s *S3Service) s3ControlGetResourceTagging(ctx context.Context, req *emulat
 	}
 
 	// Build S3 Control tagging response XML using type-safe marshaling
-	result := XMLGetBucketTaggingOutput{
-		Xmlns: "http://s3.amazonaws.com/doc/2006-03-01/",
-		TagSet: XMLTagSet{
+	result := XMLListTagsForResourceOutput{
+		Xmlns: "http://awss3control.amazonaws.com/doc/2018-08-20/",
+		Tags: XMLTagSet{
 			Tags: make([]XMLTag, 0, len(tags)),
 		},
 	}
 
-	for key, value := range tags {
-		result.TagSet.Tags = append(result.TagSet.Tags, XMLTag{
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		result.Tags.Tags = append(result.Tags.Tags, XMLTag{
 			Key:   key,
-			Value: value,
+			Value: tags[key],
 		})
 	}
 
@@ -1146,7 +2556,8 @@ func (s *S3Service) s3ControlGetResourceTagging(ctx context.Context, req *emulat
 	return resp, nil
 }
 
-// s3ControlPutResourceTagging handles PutBucketTagging / PutResourceTagging
+// s3ControlPutResourceTagging handles TagResource, which adds to the resource's tags, and a
+// <Tagging> document, which replaces them
 func (s *S3Service) s3ControlPutResourceTagging(ctx context.Context, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
 	resourceArn := s.extractResourceArnFromPath(req.Path)
 	bucketName := s.extractBucketNameFromArn(resourceArn)
@@ -1164,8 +2575,9 @@ func (s *S3Service) s3ControlPutResourceTagging(ctx context.Context, req *emulat
 		Tags []Tag `xml:"Tag"`
 	}
 	type Tagging struct {
-		XMLName xml.Name `xml:"Tagging"`
-		TagSet  TagSet   `xml:"TagSet"`
+		XMLName xml.Name
+		TagSet  TagSet `xml:"TagSet"`
+		Tags    TagSet `xml:"Tags"`
 	}
 
 	var tagging Tagging
@@ -1173,14 +2585,20 @@ func (s *S3Service) s3ControlPutResourceTagging(ctx context.Context, req *emulat
 		return s.errorResponse(400, "MalformedXML", "The XML you provided was not well-formed"), nil
 	}
 
-	// Convert to map
+	stateKey := "s3:" + bucketName + ":tags"
 	tags := make(map[string]string)
-	for _, tag := range tagging.TagSet.Tags {
+	newTags := tagging.TagSet.Tags
+	if tagging.XMLName.Local == "TagResourceRequest" {
+		if err := s.state.Get(stateKey, &tags); err != nil || tags == nil {
+			tags = make(map[string]string)
+		}
+		newTags = tagging.Tags.Tags
+	}
+	for _, tag := range newTags {
 		tags[tag.Key] = tag.Value
 	}
 
 	// Store tags in state
-	stateKey := "s3:" + bucketName + ":tags"
 	if err := s.state.Set(stateKey, tags); err != nil {
 		return s.errorResponse(500, "InternalError", "Failed to store tags"), nil
 	}
@@ -1192,7 +2610,8 @@ func (s *S3Service) s3ControlPutResourceTagging(ctx context.Context, req *emulat
 	}, nil
 }
 
-// s3ControlDeleteResourceTagging handles DeleteBucketTagging
+// s3ControlDeleteResourceTagging handles UntagResource, removing the tags named by the
+// tagKeys query parameter, or all of them when there is none
 func (s *S3Service) s3ControlDeleteResourceTagging(ctx context.Context, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
 	resourceArn := s.extractResourceArnFromPath(req.Path)
 	bucketName := s.extractBucketNameFromArn(resourceArn)
@@ -1201,9 +2620,25 @@ func (s *S3Service) s3ControlDeleteResourceTagging(ctx context.Context, req *emu
 		return s.errorResponse(400, "InvalidRequest", "Could not extract bucket name from resource ARN"), nil
 	}
 
-	// Delete tags from state
 	stateKey := "s3:" + bucketName + ":tags"
-	_ = s.state.Delete(stateKey) // Ignore error if tags don't exist
+	var query url.Values
+	if idx := strings.Index(req.Path, "?"); idx >= 0 {
+		query, _ = url.ParseQuery(req.Path[idx+1:])
+	}
+	if tagKeys := query["tagKeys"]; len(tagKeys) > 0 {
+		var tags map[string]string
+		if err := s.state.Get(stateKey, &tags); err == nil {
+			for _, key := range tagKeys {
+				delete(tags, key)
+			}
+			if err := s.state.Set(stateKey, tags); err != nil {
+				return s.errorResponse(500, "InternalError", "Failed to store tags"), nil
+			}
+		}
+	} else {
+		// Delete tags from state
+		_ = s.state.Delete(stateKey) // Ignore error if tags don't exist
+	}
 
 	return &emulator.AWSResponse{
 		StatusCode: 204,
@@ -1211,3 +2646,11 @@ func (s *S3Service) s3ControlDeleteResourceTagging(ctx context.Context, req *emu
 		Body:       []byte{},
 	}, nil
 }
+
+var (
+	_ emulator.Service              = (*S3Service)(nil)
+	_ emulator.ActionExtractor      = (*S3Service)(nil)
+	_ emulator.ResponseTypeProvider = (*S3Service)(nil)
+	_ emulator.Service              = (*S3ControlService)(nil)
+	_ emulator.ActionExtractor      = (*S3ControlService)(nil)
+)
@@ -2,8 +2,14 @@ package s3
 
 import (
 	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/robmorgan/infraspec/internal/emulator/core"
 	testhelpers "github.com/robmorgan/infraspec/internal/emulator/testing"
@@ -56,6 +62,45 @@ func TestCreateBucket_Success(t *testing.T) {
 	testhelpers.AssertResponseStatus(t, resp, 200)
 }
 
+func TestCreateBucket_LocationStyle(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    LocationStyle
+		host     string
+		path     string
+		expected string
+	}{
+		{name: "path", style: LocationStylePath, host: "s3.localhost:3687", path: "/path-bucket", expected: "/path-bucket"},
+		{name: "virtual-hosted on the S3 host", style: LocationStyleVirtualHosted, host: "s3.localhost:3687", path: "/vh-bucket", expected: "http://vh-bucket.s3.localhost:3687/"},
+		{name: "virtual-hosted without the s3 label", style: LocationStyleVirtualHosted, host: "localhost:3687", path: "/bare-bucket", expected: "http://bare-bucket.s3.localhost:3687/"},
+		{name: "virtual-hosted request", style: LocationStyleVirtualHosted, host: "host-bucket.s3.localhost:3687", path: "/", expected: "http://host-bucket.s3.localhost:3687/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewS3Service(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+			service.SetLocationStyle(tt.style)
+
+			req := &emulator.AWSRequest{
+				Method:  "PUT",
+				Path:    tt.path,
+				Headers: map[string]string{"Host": tt.host},
+				Body:    []byte{},
+				Action:  "CreateBucket",
+			}
+			resp, err := service.HandleRequest(context.Background(), req)
+			if err != nil {
+				t.Fatalf("HandleRequest failed: %v", err)
+			}
+
+			testhelpers.AssertResponseStatus(t, resp, 200)
+			if resp.Headers["Location"] != tt.expected {
+				t.Errorf("Expected Location %s, got %s", tt.expected, resp.Headers["Location"])
+			}
+		})
+	}
+}
+
 func TestCreateBucket_AlreadyExists(t *testing.T) {
 	state := emulator.NewMemoryStateManager()
 	validator := emulator.NewSchemaValidator()
@@ -86,6 +131,102 @@ func TestCreateBucket_AlreadyExists(t *testing.T) {
 	testhelpers.AssertResponseStatus(t, resp, 200)
 }
 
+func TestCreateBucket_OwnedByAnotherAccount(t *testing.T) {
+	state := emulator.NewMemoryStateManager()
+	validator := emulator.NewSchemaValidator()
+	service := NewS3Service(state, validator)
+
+	createTestBucket(t, service, "test-bucket")
+
+	other := emulator.WithRequestScope(context.Background(), emulator.RequestScope{AccountID: "111122223333", Region: "eu-west-1"})
+	req := &emulator.AWSRequest{
+		Method: "PUT",
+		Path:   "/test-bucket",
+		Headers: map[string]string{
+			"Content-Type": "application/xml",
+			"Host":         "s3.localhost:3687",
+		},
+		Body:   []byte{},
+		Action: "CreateBucket",
+	}
+
+	resp, err := service.HandleRequest(other, req)
+	if err != nil {
+		t.Fatalf("HandleRequest failed: %v", err)
+	}
+	testhelpers.AssertResponseStatus(t, resp, 409)
+
+	// The other account can still see the bucket, but doesn't list it as its own
+	headReq := &emulator.AWSRequest{
+		Method:  "HEAD",
+		Path:    "/test-bucket",
+		Headers: map[string]string{"Host": "s3.localhost:3687"},
+		Action:  "HeadBucket",
+	}
+	resp, err = service.HandleRequest(other, headReq)
+	if err != nil {
+		t.Fatalf("HandleRequest failed: %v", err)
+	}
+	testhelpers.AssertResponseStatus(t, resp, 200)
+
+	listReq := &emulator.AWSRequest{
+		Method:  "GET",
+		Path:    "/",
+		Headers: map[string]string{"Host": "s3.localhost:3687"},
+		Action:  "ListBuckets",
+	}
+	resp, err = service.HandleRequest(other, listReq)
+	if err != nil {
+		t.Fatalf("HandleRequest failed: %v", err)
+	}
+	if strings.Contains(string(resp.Body), "test-bucket") {
+		t.Errorf("expected bucket to be listed only for its owner, got %s", resp.Body)
+	}
+}
+
+func TestGetBucketLocation(t *testing.T) {
+	service := NewS3Service(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	createTestBucket(t, service, "us-bucket")
+
+	euBucket := &emulator.AWSRequest{
+		Method:  "PUT",
+		Path:    "/eu-bucket",
+		Headers: map[string]string{"Host": "s3.localhost:3687"},
+		Body:    []byte{},
+		Action:  "CreateBucket",
+	}
+	eu := emulator.WithRequestScope(context.Background(), emulator.RequestScope{AccountID: emulator.DefaultAccountID, Region: "eu-west-1"})
+	if _, err := service.HandleRequest(eu, euBucket); err != nil {
+		t.Fatalf("Failed to create bucket: %v", err)
+	}
+
+	tests := map[string]string{
+		"us-bucket": `<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></LocationConstraint>`,
+		"eu-bucket": `<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">eu-west-1</LocationConstraint>`,
+	}
+	for bucketName, expected := range tests {
+		req := &emulator.AWSRequest{
+			Method:  "GET",
+			Path:    "/" + bucketName + "?location",
+			Headers: map[string]string{"Host": "s3.localhost:3687"},
+		}
+		// The action is derived from the ?location query, as the HTTP handler does
+		req.Action = service.ExtractAction(req)
+		if req.Action != "GetBucketLocation" {
+			t.Fatalf("Expected GetBucketLocation, got %s", req.Action)
+		}
+
+		resp, err := service.HandleRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("HandleRequest failed: %v", err)
+		}
+		testhelpers.AssertResponseStatus(t, resp, 200)
+		if !strings.Contains(string(resp.Body), expected) {
+			t.Errorf("Expected %s location %s, got %s", bucketName, expected, resp.Body)
+		}
+	}
+}
+
 // ============================================================================
 // DeleteBucket Tests
 // ============================================================================
@@ -371,6 +512,59 @@ func TestGetObject_Success(t *testing.T) {
 	}
 }
 
+func TestGetObject_LastModified(t *testing.T) {
+	service := NewS3Service(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	clock := &emulator.FixedClock{Time: time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)}
+	service.SetClock(clock)
+
+	createTestBucket(t, service, "test-bucket")
+
+	putAndGet := func() *emulator.AWSResponse {
+		t.Helper()
+		_, err := service.HandleRequest(context.Background(), &emulator.AWSRequest{
+			Method:  "PUT",
+			Path:    "/test-bucket/test-key",
+			Headers: map[string]string{"Host": "s3.localhost:3687"},
+			Body:    []byte("Hello, World!"),
+			Action:  "PutObject",
+		})
+		if err != nil {
+			t.Fatalf("PutObject failed: %v", err)
+		}
+		resp, err := service.HandleRequest(context.Background(), &emulator.AWSRequest{
+			Method:  "GET",
+			Path:    "/test-bucket/test-key",
+			Headers: map[string]string{"Host": "s3.localhost:3687"},
+			Action:  "GetObject",
+		})
+		if err != nil {
+			t.Fatalf("GetObject failed: %v", err)
+		}
+		return resp
+	}
+
+	resp := putAndGet()
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	if got := resp.Headers["Last-Modified"]; got != "Fri, 14 Mar 2025 15:09:26 GMT" {
+		t.Errorf("Expected Last-Modified of the write time, got %q", got)
+	}
+
+	// Overwriting the object updates its write time
+	clock.Advance(time.Hour)
+	resp = putAndGet()
+	if got := resp.Headers["Last-Modified"]; got != "Fri, 14 Mar 2025 16:09:26 GMT" {
+		t.Errorf("Expected Last-Modified of the second write, got %q", got)
+	}
+
+	var bucket map[string]interface{}
+	if err := service.state.Get("s3:test-bucket", &bucket); err != nil {
+		t.Fatalf("Failed to read bucket: %v", err)
+	}
+	if bucket["CreationDate"] != "2025-03-14T15:09:26.000Z" {
+		t.Errorf("Expected bucket CreationDate of the creation time, got %v", bucket["CreationDate"])
+	}
+}
+
 func TestGetObject_NotFound(t *testing.T) {
 	state := emulator.NewMemoryStateManager()
 	validator := emulator.NewSchemaValidator()
@@ -396,6 +590,215 @@ func TestGetObject_NotFound(t *testing.T) {
 	testhelpers.AssertErrorResponse(t, resp, "NoSuchKey", emulator.ProtocolRESTXML)
 }
 
+func TestGetObject_Range(t *testing.T) {
+	service := NewS3Service(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	createTestBucket(t, service, "test-bucket")
+
+	_, err := service.HandleRequest(context.Background(), &emulator.AWSRequest{
+		Method:  "PUT",
+		Path:    "/test-bucket/digits.txt",
+		Headers: map[string]string{"Host": "s3.localhost:3687"},
+		Body:    []byte("0123456789"),
+		Action:  "PutObject",
+	})
+	if err != nil {
+		t.Fatalf("HandleRequest failed: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		rangeHeader  string
+		status       int
+		body         string
+		contentRange string
+	}{
+		{name: "no range", status: 200, body: "0123456789"},
+		{name: "start and end", rangeHeader: "bytes=2-5", status: 206, body: "2345", contentRange: "bytes 2-5/10"},
+		{name: "open-ended", rangeHeader: "bytes=7-", status: 206, body: "789", contentRange: "bytes 7-9/10"},
+		{name: "suffix", rangeHeader: "bytes=-3", status: 206, body: "789", contentRange: "bytes 7-9/10"},
+		{name: "suffix longer than the object", rangeHeader: "bytes=-20", status: 206, body: "0123456789", contentRange: "bytes 0-9/10"},
+		{name: "end past the object", rangeHeader: "bytes=5-100", status: 206, body: "56789", contentRange: "bytes 5-9/10"},
+		{name: "start past the object", rangeHeader: "bytes=10-20", status: 416, contentRange: "bytes */10"},
+		{name: "empty suffix", rangeHeader: "bytes=-0", status: 416, contentRange: "bytes */10"},
+		{name: "malformed range is ignored", rangeHeader: "bytes=5-2", status: 200, body: "0123456789"},
+		{name: "several ranges are ignored", rangeHeader: "bytes=0-1,4-5", status: 200, body: "0123456789"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := map[string]string{"Host": "s3.localhost:3687"}
+			if tt.rangeHeader != "" {
+				headers["Range"] = tt.rangeHeader
+			}
+			resp, err := service.HandleRequest(context.Background(), &emulator.AWSRequest{
+				Method:  "GET",
+				Path:    "/test-bucket/digits.txt",
+				Headers: headers,
+				Action:  "GetObject",
+			})
+			if err != nil {
+				t.Fatalf("HandleRequest failed: %v", err)
+			}
+
+			testhelpers.AssertResponseStatus(t, resp, tt.status)
+			if resp.Headers["Content-Range"] != tt.contentRange {
+				t.Errorf("Expected Content-Range %q, got %q", tt.contentRange, resp.Headers["Content-Range"])
+			}
+			if tt.status == 416 {
+				testhelpers.AssertErrorResponse(t, resp, "InvalidRange", emulator.ProtocolRESTXML)
+				return
+			}
+			if string(resp.Body) != tt.body {
+				t.Errorf("Expected body %q, got %q", tt.body, resp.Body)
+			}
+			if resp.Headers["Content-Length"] != strconv.Itoa(len(tt.body)) {
+				t.Errorf("Expected Content-Length %d, got %s", len(tt.body), resp.Headers["Content-Length"])
+			}
+		})
+	}
+}
+
+func TestHeadObject(t *testing.T) {
+	service := NewS3Service(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	createTestBucket(t, service, "test-bucket")
+
+	request := func(method, path string, body []byte) *emulator.AWSResponse {
+		t.Helper()
+		req := &emulator.AWSRequest{
+			Method:  method,
+			Path:    path,
+			Headers: map[string]string{"Host": "s3.localhost:3687"},
+			Body:    body,
+		}
+		req.Action = service.ExtractAction(req)
+
+		resp, err := service.HandleRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("HandleRequest failed: %v", err)
+		}
+		return resp
+	}
+
+	body := []byte("Hello, World!")
+	put := request("PUT", "/test-bucket/docs/readme.txt", body)
+	testhelpers.AssertResponseStatus(t, put, 200)
+
+	resp := request("HEAD", "/test-bucket/docs/readme.txt", nil)
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	if got := resp.Headers["Content-Length"]; got != strconv.Itoa(len(body)) {
+		t.Errorf("Expected Content-Length %d, got %s", len(body), got)
+	}
+	if resp.Headers["ETag"] != put.Headers["ETag"] || resp.Headers["Content-Type"] == "" || resp.Headers["Last-Modified"] == "" {
+		t.Errorf("Expected the object's ETag, Content-Type and Last-Modified headers, got %v", resp.Headers)
+	}
+	if len(resp.Body) != 0 {
+		t.Errorf("Expected no body, got %q", resp.Body)
+	}
+
+	resp = request("HEAD", "/test-bucket/docs/missing.txt", nil)
+	testhelpers.AssertResponseStatus(t, resp, 404)
+	if len(resp.Body) != 0 {
+		t.Errorf("Expected no body, got %q", resp.Body)
+	}
+}
+
+func TestObjectContentTypeAndMetadata(t *testing.T) {
+	service := NewS3Service(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	createTestBucket(t, service, "test-bucket")
+
+	request := func(method, path string, headers map[string]string, body string) *emulator.AWSResponse {
+		t.Helper()
+		req := &emulator.AWSRequest{
+			Method:  method,
+			Path:    path,
+			Headers: map[string]string{"Host": "s3.localhost:3687"},
+			Body:    []byte(body),
+		}
+		for key, value := range headers {
+			req.Headers[key] = value
+		}
+		req.Action = service.ExtractAction(req)
+
+		resp, err := service.HandleRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("HandleRequest failed: %v", err)
+		}
+		return resp
+	}
+
+	put := request("PUT", "/test-bucket/site/config.json", map[string]string{
+		"Content-Type":      "application/json",
+		"X-Amz-Meta-Author": "platform-team",
+		"x-amz-meta-build":  "1234",
+	}, `{"theme":"dark"}`)
+	testhelpers.AssertResponseStatus(t, put, 200)
+
+	want := map[string]string{
+		"Content-Type":      "application/json",
+		"x-amz-meta-author": "platform-team",
+		"x-amz-meta-build":  "1234",
+	}
+	for _, method := range []string{"GET", "HEAD"} {
+		resp := request(method, "/test-bucket/site/config.json", nil, "")
+		testhelpers.AssertResponseStatus(t, resp, 200)
+		for key, value := range want {
+			if got := resp.Headers[key]; got != value {
+				t.Errorf("%s: expected %s %q, got %q", method, key, value, got)
+			}
+		}
+	}
+
+	// The body is object data whatever its type, not request parameters to parse
+	bodies := map[string][2]string{
+		"list.json":   {"application/json", "[1,2,3]"},
+		"broken.json": {"application/json", `{"theme":`},
+		"empty.json":  {"application/json", ""},
+		"form.txt":    {"application/x-www-form-urlencoded", "a=%zz"},
+		"notes.txt":   {"text/plain; charset=utf-8", "not json"},
+	}
+	for key, body := range bodies {
+		put := request("PUT", "/test-bucket/"+key, map[string]string{"Content-Type": body[0]}, body[1])
+		testhelpers.AssertResponseStatus(t, put, 200)
+		resp := request("GET", "/test-bucket/"+key, nil, "")
+		testhelpers.AssertResponseStatus(t, resp, 200)
+		if string(resp.Body) != body[1] || resp.Headers["Content-Type"] != body[0] {
+			t.Errorf("%s: expected %q as %s, got %q as %s", key, body[1], body[0], resp.Body, resp.Headers["Content-Type"])
+		}
+	}
+
+	// So is a part's
+	resp := request("POST", "/test-bucket/parts.json?uploads", map[string]string{"Content-Type": "application/json"}, "")
+	var upload InitiateMultipartUploadResult
+	if err := xml.Unmarshal(resp.Body, &upload); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	part := request("PUT", "/test-bucket/parts.json?partNumber=1&uploadId="+upload.UploadId, map[string]string{"Content-Type": "application/json"}, "[1,2,3]")
+	testhelpers.AssertResponseStatus(t, part, 200)
+	resp = request("POST", "/test-bucket/parts.json?uploadId="+upload.UploadId, nil,
+		"<CompleteMultipartUpload><Part><PartNumber>1</PartNumber><ETag>"+part.Headers["ETag"]+"</ETag></Part></CompleteMultipartUpload>")
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	resp = request("HEAD", "/test-bucket/parts.json", nil, "")
+	if resp.Headers["Content-Type"] != "application/json" {
+		t.Errorf("Expected the upload's Content-Type on the completed object, got %q", resp.Headers["Content-Type"])
+	}
+
+	// Objects stored without a Content-Type are binary, and have no metadata
+	testhelpers.AssertResponseStatus(t, request("PUT", "/test-bucket/data.bin", nil, "data"), 200)
+	resp = request("GET", "/test-bucket/data.bin", nil, "")
+	if resp.Headers["Content-Type"] != "application/octet-stream" {
+		t.Errorf("Expected the default Content-Type, got %q", resp.Headers["Content-Type"])
+	}
+	for key := range resp.Headers {
+		if strings.HasPrefix(strings.ToLower(key), "x-amz-meta-") {
+			t.Errorf("Expected no metadata headers, got %s", key)
+		}
+	}
+
+	// Like S3, the names and values of an object's metadata can add up to 2 KB
+	resp = request("PUT", "/test-bucket/big-metadata.txt", map[string]string{"X-Amz-Meta-Notes": strings.Repeat("a", 2048)}, "data")
+	testhelpers.AssertResponseStatus(t, resp, 400)
+	testhelpers.AssertErrorResponse(t, resp, "MetadataTooLarge", emulator.ProtocolRESTXML)
+}
+
 // ============================================================================
 // Bucket Versioning Tests
 // ============================================================================
@@ -571,8 +974,224 @@ func TestListObjectsV2_WithObjects(t *testing.T) {
 	testhelpers.AssertResponseStatus(t, resp, 200)
 	testhelpers.AssertXMLStructure(t, resp, "ListBucketResult")
 
-	// Note: Current implementation may not include all object details in listing
-	// This test verifies the basic response structure is correct
+	var result ListBucketResult
+	if err := xml.Unmarshal(resp.Body, &result); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if result.KeyCount != 3 || len(result.Contents) != 3 || result.Contents[2].Key != "folder/file3.txt" || result.Contents[2].Size != 7 {
+		t.Errorf("Expected the three objects in key order, got %+v", result)
+	}
+}
+
+func TestListObjectsV2_PrefixDelimiterAndPagination(t *testing.T) {
+	service := NewS3Service(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	createTestBucket(t, service, "test-bucket")
+
+	keys := []string{"a.txt", "b.txt", "logs/2024/1.log", "logs/2024/2.log", "logs/2025/1.log", "photos/x.jpg"}
+	for _, key := range keys {
+		resp, err := service.HandleRequest(context.Background(), &emulator.AWSRequest{
+			Method:  "PUT",
+			Path:    "/test-bucket/" + key,
+			Headers: map[string]string{"Host": "s3.localhost:3687"},
+			Body:    []byte(key),
+			Action:  "PutObject",
+		})
+		if err != nil {
+			t.Fatalf("HandleRequest failed: %v", err)
+		}
+		testhelpers.AssertResponseStatus(t, resp, 200)
+	}
+
+	list := func(query string) ListBucketResult {
+		t.Helper()
+		resp, err := service.HandleRequest(context.Background(), &emulator.AWSRequest{
+			Method:  "GET",
+			Path:    "/test-bucket?list-type=2" + query,
+			Headers: map[string]string{"Host": "s3.localhost:3687"},
+			Action:  "ListObjectsV2",
+		})
+		if err != nil {
+			t.Fatalf("HandleRequest failed: %v", err)
+		}
+		testhelpers.AssertResponseStatus(t, resp, 200)
+		var result ListBucketResult
+		if err := xml.Unmarshal(resp.Body, &result); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return result
+	}
+	entries := func(result ListBucketResult) []string {
+		var names []string
+		for _, object := range result.Contents {
+			names = append(names, object.Key)
+		}
+		for _, prefix := range result.CommonPrefixes {
+			names = append(names, prefix.Prefix+"*")
+		}
+		return names
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "prefix", query: "&prefix=logs/2024/", expected: "logs/2024/1.log,logs/2024/2.log"},
+		{name: "delimiter", query: "&delimiter=/", expected: "a.txt,b.txt,logs/*,photos/*"},
+		{name: "prefix and delimiter", query: "&prefix=logs/&delimiter=/", expected: "logs/2024/*,logs/2025/*"},
+		{name: "start-after", query: "&start-after=logs/2024/2.log", expected: "logs/2025/1.log,photos/x.jpg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := list(tt.query)
+			if got := strings.Join(entries(result), ","); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+			if result.IsTruncated || result.KeyCount != len(strings.Split(tt.expected, ",")) {
+				t.Errorf("Expected an untruncated list of %s, got %+v", tt.expected, result)
+			}
+		})
+	}
+
+	// Common prefixes count towards max-keys and pages don't repeat them
+	var pages []string
+	token := ""
+	for {
+		query := "&delimiter=/&max-keys=3"
+		if token != "" {
+			query += "&continuation-token=" + url.QueryEscape(token)
+		}
+		result := list(query)
+		pages = append(pages, strings.Join(entries(result), ","))
+		if !result.IsTruncated {
+			if result.NextContinuationToken != "" {
+				t.Errorf("Expected no continuation token on the last page, got %s", result.NextContinuationToken)
+			}
+			break
+		}
+		if result.KeyCount != 3 || result.NextContinuationToken == "" {
+			t.Fatalf("Expected a full page with a continuation token, got %+v", result)
+		}
+		token = result.NextContinuationToken
+	}
+	if expected := []string{"a.txt,b.txt,logs/*", "photos/*"}; strings.Join(pages, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected pages %v, got %v", expected, pages)
+	}
+
+	for _, query := range []string{"&continuation-token=bogus", "&max-keys=-1", "&max-keys=ten"} {
+		resp, err := service.HandleRequest(context.Background(), &emulator.AWSRequest{
+			Method:  "GET",
+			Path:    "/test-bucket?list-type=2" + query,
+			Headers: map[string]string{"Host": "s3.localhost:3687"},
+			Action:  "ListObjectsV2",
+		})
+		if err != nil {
+			t.Fatalf("HandleRequest failed: %v", err)
+		}
+		testhelpers.AssertResponseStatus(t, resp, 400)
+		testhelpers.AssertErrorResponse(t, resp, "InvalidArgument", emulator.ProtocolRESTXML)
+	}
+}
+
+func TestListObjectsV2_PrefixNamedLikeASubresource(t *testing.T) {
+	service := NewS3Service(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	createTestBucket(t, service, "test-bucket")
+
+	request := func(method, path string) *emulator.AWSResponse {
+		t.Helper()
+		req := &emulator.AWSRequest{
+			Method:  method,
+			Path:    path,
+			Headers: map[string]string{"Host": "s3.localhost:3687"},
+			Body:    []byte("data"),
+		}
+		req.Action = service.ExtractAction(req)
+
+		resp, err := service.HandleRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("HandleRequest failed: %v", err)
+		}
+		return resp
+	}
+
+	for _, key := range []string{"policy/a.json", "logging/b.log", "versioning/c.txt", "delete/d.txt"} {
+		testhelpers.AssertResponseStatus(t, request("PUT", "/test-bucket/"+key), 200)
+	}
+
+	// A prefix or start-after that contains a subresource's name is still a listing
+	tests := map[string]string{
+		"?list-type=2&prefix=policy/":                       "policy/a.json",
+		"?list-type=2&prefix=logging/":                      "logging/b.log",
+		"?list-type=2&prefix=versioning":                    "versioning/c.txt",
+		"?list-type=2&prefix=delete/":                       "delete/d.txt",
+		"?list-type=2&start-after=logging/b.log&max-keys=1": "policy/a.json",
+	}
+	for query, want := range tests {
+		resp := request("GET", "/test-bucket"+query)
+		testhelpers.AssertResponseStatus(t, resp, 200)
+		var result ListBucketResult
+		if err := xml.Unmarshal(resp.Body, &result); err != nil {
+			t.Fatalf("%s: failed to parse response: %v", query, err)
+		}
+		if len(result.Contents) != 1 || result.Contents[0].Key != want {
+			t.Errorf("%s: expected %s to be listed, got %+v", query, want, result.Contents)
+		}
+	}
+}
+
+func TestObjectStorageClass(t *testing.T) {
+	service := NewS3Service(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	createTestBucket(t, service, "test-bucket")
+
+	request := func(method, path string, headers map[string]string) *emulator.AWSResponse {
+		t.Helper()
+		req := &emulator.AWSRequest{
+			Method:  method,
+			Path:    path,
+			Headers: map[string]string{"Host": "s3.localhost:3687"},
+			Body:    []byte("content"),
+		}
+		for name, value := range headers {
+			req.Headers[name] = value
+		}
+		req.Action = service.ExtractAction(req)
+
+		resp, err := service.HandleRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("HandleRequest failed: %v", err)
+		}
+		return resp
+	}
+
+	testhelpers.AssertResponseStatus(t, request("PUT", "/test-bucket/archive.tar", map[string]string{"X-Amz-Storage-Class": "GLACIER"}), 200)
+	testhelpers.AssertResponseStatus(t, request("PUT", "/test-bucket/index.html", nil), 200)
+
+	resp := request("HEAD", "/test-bucket/archive.tar", nil)
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	testhelpers.AssertHeader(t, resp, "X-Amz-Storage-Class", "GLACIER")
+
+	// Like S3, STANDARD objects have no storage class header
+	resp = request("HEAD", "/test-bucket/index.html", nil)
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	if got, ok := resp.Headers["X-Amz-Storage-Class"]; ok {
+		t.Errorf("Expected no storage class header, got %q", got)
+	}
+
+	resp = request("GET", "/test-bucket?list-type=2", nil)
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	body := string(resp.Body)
+	if !strings.Contains(body, "<Key>archive.tar</Key>") || !strings.Contains(body, "<StorageClass>GLACIER</StorageClass>") {
+		t.Errorf("Expected archive.tar to be listed in GLACIER, got %s", body)
+	}
+	if !strings.Contains(body, "<StorageClass>STANDARD</StorageClass>") {
+		t.Errorf("Expected index.html to be listed in STANDARD, got %s", body)
+	}
+
+	resp = request("PUT", "/test-bucket/cold.tar", map[string]string{"X-Amz-Storage-Class": "FROZEN"})
+	testhelpers.AssertResponseStatus(t, resp, 400)
+	testhelpers.AssertErrorResponse(t, resp, "InvalidStorageClass", emulator.ProtocolRESTXML)
+
+	testhelpers.AssertResponseStatus(t, request("HEAD", "/test-bucket/missing.txt", nil), 404)
 }
 
 // ============================================================================
@@ -822,6 +1441,178 @@ func TestBucketEncryption_BucketKeyEnabledFalse(t *testing.T) {
 	}
 }
 
+// ============================================================================
+// Bucket ACL Tests
+// ============================================================================
+
+func TestBucketAcl_RoundTrip(t *testing.T) {
+	service := NewS3Service(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	createTestBucket(t, service, "test-bucket")
+
+	request := func(method string, headers map[string]string, body string) *emulator.AWSResponse {
+		t.Helper()
+		headers["Host"] = "s3.localhost:3687"
+		req := &emulator.AWSRequest{
+			Method:  method,
+			Path:    "/test-bucket?acl",
+			Headers: headers,
+			Body:    []byte(body),
+		}
+		// The action is derived from the ?acl query, as the HTTP handler does
+		req.Action = service.ExtractAction(req)
+
+		resp, err := service.HandleRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("HandleRequest failed: %v", err)
+		}
+		return resp
+	}
+
+	// A bucket without an ACL is private
+	resp := request("GET", map[string]string{}, "")
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	body := string(resp.Body)
+	if !strings.Contains(body, "<Permission>FULL_CONTROL</Permission>") || strings.Contains(body, "AllUsers") {
+		t.Errorf("Expected a private ACL, got %s", body)
+	}
+
+	// Canned ACLs are translated to their grants
+	resp = request("PUT", map[string]string{"X-Amz-Acl": "public-read"}, "")
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	body = string(request("GET", map[string]string{}, "").Body)
+	if !strings.Contains(body, `xsi:type="Group"`) || !strings.Contains(body, "<URI>http://acs.amazonaws.com/groups/global/AllUsers</URI>") || !strings.Contains(body, "<Permission>READ</Permission>") {
+		t.Errorf("Expected public-read grants, got %s", body)
+	}
+
+	resp = request("PUT", map[string]string{"X-Amz-Acl": "world-writable"}, "")
+	testhelpers.AssertResponseStatus(t, resp, 400)
+
+	// An access control policy in the body replaces the grants
+	resp = request("PUT", map[string]string{"Content-Type": "application/xml"}, `<AccessControlPolicy xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+		<Owner><ID>infraspec-api</ID></Owner>
+		<AccessControlList>
+			<Grant>
+				<Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CanonicalUser"><ID>reader-id</ID></Grantee>
+				<Permission>READ_ACP</Permission>
+			</Grant>
+		</AccessControlList>
+	</AccessControlPolicy>`)
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	body = string(request("GET", map[string]string{}, "").Body)
+	if !strings.Contains(body, `xsi:type="CanonicalUser"`) || !strings.Contains(body, "<ID>reader-id</ID>") || strings.Contains(body, "AllUsers") {
+		t.Errorf("Expected the grants from the body, got %s", body)
+	}
+
+	resp = request("PUT", map[string]string{}, "")
+	testhelpers.AssertResponseStatus(t, resp, 400)
+}
+
+// ============================================================================
+// Request Payment and Accelerate Configuration Tests
+// ============================================================================
+
+func TestBucketRequestPaymentAndAccelerate_RoundTrip(t *testing.T) {
+	service := NewS3Service(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	createTestBucket(t, service, "test-bucket")
+
+	request := func(method, subresource, body string) *emulator.AWSResponse {
+		t.Helper()
+		req := &emulator.AWSRequest{
+			Method:  method,
+			Path:    "/test-bucket?" + subresource,
+			Headers: map[string]string{"Host": "s3.localhost:3687"},
+			Body:    []byte(body),
+		}
+		req.Action = service.ExtractAction(req)
+
+		resp, err := service.HandleRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("HandleRequest failed: %v", err)
+		}
+		return resp
+	}
+
+	// Standard buckets are paid for by their owner and have never configured acceleration
+	resp := request("GET", "requestPayment", "")
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	if !strings.Contains(string(resp.Body), "<Payer>BucketOwner</Payer>") {
+		t.Errorf("Expected the bucket owner to pay, got %s", resp.Body)
+	}
+	resp = request("GET", "accelerate", "")
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	if strings.Contains(string(resp.Body), "<Status>") {
+		t.Errorf("Expected no acceleration status, got %s", resp.Body)
+	}
+
+	resp = request("PUT", "requestPayment", `<RequestPaymentConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Payer>Requester</Payer></RequestPaymentConfiguration>`)
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	if body := string(request("GET", "requestPayment", "").Body); !strings.Contains(body, "<Payer>Requester</Payer>") {
+		t.Errorf("Expected the requester to pay, got %s", body)
+	}
+
+	resp = request("PUT", "accelerate", `<AccelerateConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>Suspended</Status></AccelerateConfiguration>`)
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	if body := string(request("GET", "accelerate", "").Body); !strings.Contains(body, "<Status>Suspended</Status>") {
+		t.Errorf("Expected acceleration to be suspended, got %s", body)
+	}
+
+	resp = request("PUT", "accelerate", `<AccelerateConfiguration><Status>On</Status></AccelerateConfiguration>`)
+	testhelpers.AssertResponseStatus(t, resp, 400)
+}
+
+// ============================================================================
+// Lifecycle Configuration Tests
+// ============================================================================
+
+func TestBucketLifecycleConfiguration_RoundTrip(t *testing.T) {
+	service := NewS3Service(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	createTestBucket(t, service, "test-bucket")
+
+	request := func(method, body string) *emulator.AWSResponse {
+		t.Helper()
+		req := &emulator.AWSRequest{
+			Method:  method,
+			Path:    "/test-bucket?lifecycle",
+			Headers: map[string]string{"Host": "s3.localhost:3687"},
+			Body:    []byte(body),
+		}
+		req.Action = service.ExtractAction(req)
+
+		resp, err := service.HandleRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("HandleRequest failed: %v", err)
+		}
+		return resp
+	}
+
+	resp := request("GET", "")
+	testhelpers.AssertResponseStatus(t, resp, 404)
+	testhelpers.AssertErrorResponse(t, resp, "NoSuchLifecycleConfiguration", emulator.ProtocolRESTXML)
+
+	config := `<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><ID>archive</ID><Filter><Prefix>logs/</Prefix></Filter><Status>Enabled</Status><Transition><Days>90</Days><StorageClass>GLACIER</StorageClass></Transition></Rule></LifecycleConfiguration>`
+	resp = request("PUT", config)
+	testhelpers.AssertResponseStatus(t, resp, 200)
+
+	resp = request("GET", "")
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	if string(resp.Body) != config {
+		t.Errorf("Expected the stored lifecycle configuration, got %s", resp.Body)
+	}
+
+	resp = request("PUT", `<LifecycleConfiguration><Rule><Status>On</Status></Rule></LifecycleConfiguration>`)
+	testhelpers.AssertResponseStatus(t, resp, 400)
+
+	resp = request("DELETE", "")
+	testhelpers.AssertResponseStatus(t, resp, 204)
+	testhelpers.AssertResponseStatus(t, request("GET", ""), 404)
+
+	// The bucket itself must survive deleting its lifecycle configuration
+	var bucket map[string]interface{}
+	if err := service.state.Get("s3:test-bucket", &bucket); err != nil {
+		t.Errorf("Expected the bucket to still exist: %v", err)
+	}
+}
+
 // ============================================================================
 // Invalid Action Tests
 // ============================================================================
@@ -848,3 +1639,441 @@ func TestInvalidAction(t *testing.T) {
 	testhelpers.AssertResponseStatus(t, resp, 400)
 	testhelpers.AssertErrorResponse(t, resp, "InvalidAction", emulator.ProtocolRESTXML)
 }
+
+// ============================================================================
+// S3 Control Tests
+// ============================================================================
+
+func TestS3ControlService_SharesBucketTags(t *testing.T) {
+	state := emulator.NewMemoryStateManager()
+	validator := emulator.NewSchemaValidator()
+	control := NewS3ControlService(NewS3Service(state, validator))
+
+	put := &emulator.AWSRequest{
+		Method:  "PUT",
+		Path:    "/v20180820/tags/arn%3Aaws%3As3%3A%3A%3Atest-bucket",
+		Headers: map[string]string{"X-Amz-Account-Id": "123456789012"},
+		Body:    []byte(`<Tagging><TagSet><Tag><Key>env</Key><Value>prod</Value></Tag></TagSet></Tagging>`),
+	}
+	put.Action = control.ExtractAction(put)
+	resp, err := control.HandleRequest(context.Background(), put)
+	if err != nil {
+		t.Fatalf("HandleRequest failed: %v", err)
+	}
+	testhelpers.AssertResponseStatus(t, resp, 204)
+
+	var tags map[string]string
+	if err := state.Get("s3:test-bucket:tags", &tags); err != nil {
+		t.Fatalf("Failed to read bucket tags: %v", err)
+	}
+	if tags["env"] != "prod" {
+		t.Errorf("Expected tag env=prod, got %v", tags)
+	}
+}
+
+func TestS3Control_TagResourceAndUntagResource(t *testing.T) {
+	state := emulator.NewMemoryStateManager()
+	validator := emulator.NewSchemaValidator()
+	control := NewS3ControlService(NewS3Service(state, validator))
+
+	send := func(method, path, body string) *emulator.AWSResponse {
+		t.Helper()
+		req := &emulator.AWSRequest{
+			Method:  method,
+			Path:    path,
+			Headers: map[string]string{"X-Amz-Account-Id": "123456789012"},
+			Body:    []byte(body),
+		}
+		req.Action = control.ExtractAction(req)
+		resp, err := control.HandleRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("HandleRequest failed: %v", err)
+		}
+		return resp
+	}
+
+	path := "/v20180820/tags/arn:aws:s3:::test-bucket"
+	testhelpers.AssertResponseStatus(t, send("POST", path, `<TagResourceRequest><Tags><Tag><Key>env</Key><Value>prod</Value></Tag></Tags></TagResourceRequest>`), 204)
+	testhelpers.AssertResponseStatus(t, send("POST", path, `<TagResourceRequest><Tags><Tag><Key>team</Key><Value>payments</Value></Tag></Tags></TagResourceRequest>`), 204)
+	testhelpers.AssertResponseStatus(t, send("DELETE", path+"?tagKeys=env", ""), 204)
+
+	resp := send("GET", path, "")
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	body := string(resp.Body)
+	if !strings.Contains(body, "<ListTagsForResourceResult") || !strings.Contains(body, "<Key>team</Key>") || !strings.Contains(body, "<Value>payments</Value>") {
+		t.Errorf("Expected only the team tag in a ListTagsForResource response, got: %s", body)
+	}
+	if strings.Contains(body, "env") {
+		t.Errorf("Expected the env tag to be removed, got: %s", body)
+	}
+}
+
+func TestBucketLogging_EmptyStatusDisablesLogging(t *testing.T) {
+	service := NewS3Service(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	createTestBucket(t, service, "test-bucket")
+
+	request := func(method, body string) *emulator.AWSResponse {
+		t.Helper()
+		req := &emulator.AWSRequest{
+			Method:  method,
+			Path:    "/test-bucket?logging",
+			Headers: map[string]string{"Host": "s3.localhost:3687"},
+			Body:    []byte(body),
+		}
+		req.Action = service.ExtractAction(req)
+
+		resp, err := service.HandleRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("HandleRequest failed: %v", err)
+		}
+		return resp
+	}
+
+	resp := request("PUT", `<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><LoggingEnabled><TargetBucket>log-bucket</TargetBucket><TargetPrefix>logs/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`)
+	testhelpers.AssertResponseStatus(t, resp, 204)
+
+	resp = request("GET", "")
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	if !strings.Contains(string(resp.Body), "<TargetBucket>log-bucket</TargetBucket>") || !strings.Contains(string(resp.Body), "<TargetPrefix>logs/</TargetPrefix>") {
+		t.Errorf("Expected the logging target, got %s", resp.Body)
+	}
+
+	// An empty status, as sent to turn logging off, removes the configuration
+	testhelpers.AssertResponseStatus(t, request("PUT", `<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></BucketLoggingStatus>`), 204)
+
+	resp = request("GET", "")
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	if strings.Contains(string(resp.Body), "LoggingEnabled") {
+		t.Errorf("Expected logging to be disabled, got %s", resp.Body)
+	}
+}
+
+func TestListMultipartUploads(t *testing.T) {
+	state := emulator.NewMemoryStateManager()
+	service := NewS3Service(state, emulator.NewSchemaValidator())
+	createTestBucket(t, service, "test-bucket")
+
+	request := func(method, path string) *emulator.AWSResponse {
+		t.Helper()
+		req := &emulator.AWSRequest{
+			Method:  method,
+			Path:    path,
+			Headers: map[string]string{"Host": "s3.localhost:3687"},
+		}
+		req.Action = service.ExtractAction(req)
+
+		resp, err := service.HandleRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("HandleRequest failed: %v", err)
+		}
+		return resp
+	}
+
+	if action := service.ExtractAction(&emulator.AWSRequest{Method: "POST", Path: "/test-bucket/big.bin?uploads", Headers: map[string]string{"Host": "s3.localhost:3687"}}); action != "CreateMultipartUpload" {
+		t.Errorf("Expected POST ?uploads to be CreateMultipartUpload, got %s", action)
+	}
+
+	resp := request("GET", "/test-bucket?uploads")
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	if strings.Contains(string(resp.Body), "<Upload>") {
+		t.Errorf("Expected no uploads, got %s", resp.Body)
+	}
+
+	for id, key := range map[string]string{"upload-2": "videos/b.mp4", "upload-1": "videos/a.mp4", "upload-3": "backups/db.tar"} {
+		if err := state.Set("s3:test-bucket:mpu:"+id, map[string]interface{}{
+			"UploadId":  id,
+			"Key":       key,
+			"Initiated": "2024-01-01T00:00:00.000Z",
+		}); err != nil {
+			t.Fatalf("Failed to store upload: %v", err)
+		}
+	}
+
+	resp = request("GET", "/test-bucket?uploads")
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	body := string(resp.Body)
+	first, second, third := strings.Index(body, "backups/db.tar"), strings.Index(body, "videos/a.mp4"), strings.Index(body, "videos/b.mp4")
+	if first < 0 || second < first || third < second {
+		t.Errorf("Expected all three uploads in key order, got %s", body)
+	}
+	if !strings.Contains(body, "<UploadId>upload-1</UploadId>") || !strings.Contains(body, "<StorageClass>STANDARD</StorageClass>") {
+		t.Errorf("Expected upload details, got %s", body)
+	}
+
+	resp = request("GET", "/test-bucket?uploads&prefix=videos/")
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	if body := string(resp.Body); strings.Contains(body, "backups/db.tar") || strings.Count(body, "<Upload>") != 2 {
+		t.Errorf("Expected only the uploads under videos/, got %s", body)
+	}
+
+	resp = request("GET", "/missing-bucket?uploads")
+	testhelpers.AssertResponseStatus(t, resp, 404)
+	testhelpers.AssertErrorResponse(t, resp, "NoSuchBucket", emulator.ProtocolRESTXML)
+}
+
+func TestMultipartUpload(t *testing.T) {
+	state := emulator.NewMemoryStateManager()
+	service := NewS3Service(state, emulator.NewSchemaValidator())
+	createTestBucket(t, service, "test-bucket")
+
+	request := func(method, path, body string) *emulator.AWSResponse {
+		t.Helper()
+		req := &emulator.AWSRequest{
+			Method:  method,
+			Path:    path,
+			Headers: map[string]string{"Host": "s3.localhost:3687"},
+			Body:    []byte(body),
+		}
+		req.Action = service.ExtractAction(req)
+
+		resp, err := service.HandleRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("HandleRequest failed: %v", err)
+		}
+		return resp
+	}
+	createUpload := func(key string) string {
+		t.Helper()
+		resp := request("POST", "/test-bucket/"+key+"?uploads", "")
+		testhelpers.AssertResponseStatus(t, resp, 200)
+		var result InitiateMultipartUploadResult
+		if err := xml.Unmarshal(resp.Body, &result); err != nil || result.UploadId == "" {
+			t.Fatalf("Expected an upload ID, got %s", resp.Body)
+		}
+		return result.UploadId
+	}
+
+	uploadID := createUpload("videos/big.bin")
+	resp := request("GET", "/test-bucket?uploads", "")
+	if !strings.Contains(string(resp.Body), "<UploadId>"+uploadID+"</UploadId>") {
+		t.Errorf("Expected the upload to be listed, got %s", resp.Body)
+	}
+
+	// Parts can be uploaded in any order
+	second := request("PUT", "/test-bucket/videos/big.bin?partNumber=2&uploadId="+uploadID, "world")
+	testhelpers.AssertResponseStatus(t, second, 200)
+	first := request("PUT", "/test-bucket/videos/big.bin?partNumber=1&uploadId="+uploadID, "hello ")
+	testhelpers.AssertResponseStatus(t, first, 200)
+
+	for _, partNumber := range []string{"0", "10001", "one"} {
+		resp := request("PUT", "/test-bucket/videos/big.bin?partNumber="+partNumber+"&uploadId="+uploadID, "x")
+		testhelpers.AssertResponseStatus(t, resp, 400)
+		testhelpers.AssertErrorResponse(t, resp, "InvalidArgument", emulator.ProtocolRESTXML)
+	}
+
+	complete := func(parts ...string) string {
+		body := "<CompleteMultipartUpload>"
+		for i := 0; i+1 < len(parts); i += 2 {
+			body += "<Part><PartNumber>" + parts[i] + "</PartNumber><ETag>" + parts[i+1] + "</ETag></Part>"
+		}
+		return body + "</CompleteMultipartUpload>"
+	}
+	firstETag, secondETag := first.Headers["ETag"], second.Headers["ETag"]
+
+	resp = request("POST", "/test-bucket/videos/big.bin?uploadId="+uploadID, complete("2", secondETag, "1", firstETag))
+	testhelpers.AssertResponseStatus(t, resp, 400)
+	testhelpers.AssertErrorResponse(t, resp, "InvalidPartOrder", emulator.ProtocolRESTXML)
+
+	resp = request("POST", "/test-bucket/videos/big.bin?uploadId="+uploadID, complete("1", firstETag, "2", firstETag))
+	testhelpers.AssertResponseStatus(t, resp, 400)
+	testhelpers.AssertErrorResponse(t, resp, "InvalidPart", emulator.ProtocolRESTXML)
+
+	resp = request("POST", "/test-bucket/videos/big.bin?uploadId="+uploadID, complete("1", firstETag, "2", secondETag))
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	var result CompleteMultipartUploadResult
+	if err := xml.Unmarshal(resp.Body, &result); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if result.Key != "videos/big.bin" || !strings.HasSuffix(result.ETag, "-2\"") {
+		t.Errorf("Expected the object's key and a two-part ETag, got %+v", result)
+	}
+
+	resp = request("GET", "/test-bucket/videos/big.bin", "")
+	testhelpers.AssertResponseStatus(t, resp, 200)
+	if string(resp.Body) != "hello world" {
+		t.Errorf("Expected the parts joined in order, got %q", resp.Body)
+	}
+	if resp.Headers["ETag"] != result.ETag {
+		t.Errorf("Expected ETag %s, got %s", result.ETag, resp.Headers["ETag"])
+	}
+
+	// A completed upload is gone
+	resp = request("PUT", "/test-bucket/videos/big.bin?partNumber=3&uploadId="+uploadID, "more")
+	testhelpers.AssertResponseStatus(t, resp, 404)
+	testhelpers.AssertErrorResponse(t, resp, "NoSuchUpload", emulator.ProtocolRESTXML)
+
+	aborted := createUpload("aborted.bin")
+	testhelpers.AssertResponseStatus(t, request("PUT", "/test-bucket/aborted.bin?partNumber=1&uploadId="+aborted, "partial"), 200)
+	testhelpers.AssertResponseStatus(t, request("DELETE", "/test-bucket/aborted.bin?uploadId="+aborted, ""), 204)
+	if state.Exists("s3:test-bucket:mpu:" + aborted) {
+		t.Error("Expected aborting to remove the upload")
+	}
+	testhelpers.AssertResponseStatus(t, request("DELETE", "/test-bucket/aborted.bin?uploadId="+aborted, ""), 404)
+	testhelpers.AssertResponseStatus(t, request("GET", "/test-bucket/aborted.bin", ""), 404)
+}
+
+func TestGetObjectAttributes(t *testing.T) {
+	service := NewS3Service(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	createTestBucket(t, service, "test-bucket")
+
+	request := func(method, path, attributes, body string) *emulator.AWSResponse {
+		t.Helper()
+		req := &emulator.AWSRequest{
+			Method:  method,
+			Path:    path,
+			Headers: map[string]string{"Host": "s3.localhost:3687"},
+			Body:    []byte(body),
+		}
+		if attributes != "" {
+			req.Headers["X-Amz-Object-Attributes"] = attributes
+		}
+		req.Action = service.ExtractAction(req)
+
+		resp, err := service.HandleRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("HandleRequest failed: %v", err)
+		}
+		return resp
+	}
+	attributes := func(key, requested string) GetObjectAttributesResponse {
+		t.Helper()
+		resp := request("GET", "/test-bucket/"+key+"?attributes", requested, "")
+		testhelpers.AssertResponseStatus(t, resp, 200)
+		if resp.Headers["Last-Modified"] == "" {
+			t.Errorf("Expected a Last-Modified header, got %v", resp.Headers)
+		}
+		var result GetObjectAttributesResponse
+		if err := xml.Unmarshal(resp.Body, &result); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return result
+	}
+
+	put := request("PUT", "/test-bucket/docs/readme.txt", "", "Hello, World!")
+	testhelpers.AssertResponseStatus(t, put, 200)
+
+	result := attributes("docs/readme.txt", "ETag, ObjectSize,StorageClass,ObjectParts")
+	if result.ETag != strings.Trim(put.Headers["ETag"], "\"") {
+		t.Errorf("Expected the unquoted ETag %s, got %s", put.Headers["ETag"], result.ETag)
+	}
+	if result.ObjectSize == nil || *result.ObjectSize != 13 || result.StorageClass != "STANDARD" {
+		t.Errorf("Expected the object's size and storage class, got %+v", result)
+	}
+	if result.ObjectParts != nil {
+		t.Errorf("Expected no parts for an object not uploaded in parts, got %+v", result.ObjectParts)
+	}
+
+	// Only the requested attributes are returned
+	result = attributes("docs/readme.txt", "ObjectSize")
+	if result.ETag != "" || result.StorageClass != "" || result.ObjectSize == nil {
+		t.Errorf("Expected only the object's size, got %+v", result)
+	}
+
+	resp := request("POST", "/test-bucket/videos/big.bin?uploads", "", "")
+	var upload InitiateMultipartUploadResult
+	if err := xml.Unmarshal(resp.Body, &upload); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	first := request("PUT", "/test-bucket/videos/big.bin?partNumber=1&uploadId="+upload.UploadId, "", "hello ")
+	second := request("PUT", "/test-bucket/videos/big.bin?partNumber=2&uploadId="+upload.UploadId, "", "world")
+	resp = request("POST", "/test-bucket/videos/big.bin?uploadId="+upload.UploadId, "",
+		"<CompleteMultipartUpload><Part><PartNumber>1</PartNumber><ETag>"+first.Headers["ETag"]+"</ETag></Part>"+
+			"<Part><PartNumber>2</PartNumber><ETag>"+second.Headers["ETag"]+"</ETag></Part></CompleteMultipartUpload>")
+	testhelpers.AssertResponseStatus(t, resp, 200)
+
+	result = attributes("videos/big.bin", "ObjectParts,ETag")
+	if !strings.HasSuffix(result.ETag, "-2") {
+		t.Errorf("Expected a two-part ETag, got %s", result.ETag)
+	}
+	parts := result.ObjectParts
+	if parts == nil || parts.PartsCount != 2 || parts.IsTruncated || len(parts.Parts) != 2 {
+		t.Fatalf("Expected both parts, got %+v", parts)
+	}
+	if parts.Parts[0] != (XMLObjectPart{PartNumber: 1, Size: 6}) || parts.Parts[1] != (XMLObjectPart{PartNumber: 2, Size: 5}) {
+		t.Errorf("Expected the parts' numbers and sizes, got %+v", parts.Parts)
+	}
+
+	for _, requested := range []string{"", "ETag,Colour"} {
+		resp := request("GET", "/test-bucket/docs/readme.txt?attributes", requested, "")
+		testhelpers.AssertResponseStatus(t, resp, 400)
+		testhelpers.AssertErrorResponse(t, resp, "InvalidArgument", emulator.ProtocolRESTXML)
+	}
+
+	resp = request("GET", "/test-bucket/docs/missing.txt?attributes", "ETag", "")
+	testhelpers.AssertResponseStatus(t, resp, 404)
+	testhelpers.AssertErrorResponse(t, resp, "NoSuchKey", emulator.ProtocolRESTXML)
+}
+
+func TestDeleteObjects(t *testing.T) {
+	service := NewS3Service(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	createTestBucket(t, service, "test-bucket")
+
+	request := func(method, path, body string) *emulator.AWSResponse {
+		t.Helper()
+		req := &emulator.AWSRequest{
+			Method:  method,
+			Path:    path,
+			Headers: map[string]string{"Host": "s3.localhost:3687"},
+			Body:    []byte(body),
+		}
+		req.Action = service.ExtractAction(req)
+
+		resp, err := service.HandleRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("HandleRequest failed: %v", err)
+		}
+		return resp
+	}
+	deleteObjects := func(quiet bool, keys ...string) DeleteResult {
+		t.Helper()
+		body := fmt.Sprintf("<Delete><Quiet>%t</Quiet>", quiet)
+		for _, key := range keys {
+			body += "<Object><Key>" + key + "</Key></Object>"
+		}
+		resp := request("POST", "/test-bucket?delete", body+"</Delete>")
+		testhelpers.AssertResponseStatus(t, resp, 200)
+		var result DeleteResult
+		if err := xml.Unmarshal(resp.Body, &result); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return result
+	}
+
+	for _, key := range []string{"a.txt", "b.txt", "logs/c.txt"} {
+		testhelpers.AssertResponseStatus(t, request("PUT", "/test-bucket/"+key, "data"), 200)
+	}
+
+	// Like S3, deleting a missing key succeeds, and only keys that can't be deleted are errors
+	result := deleteObjects(false, "a.txt", "missing.txt", "", "logs/c.txt")
+	deleted := make([]string, 0, len(result.Deleted))
+	for _, object := range result.Deleted {
+		deleted = append(deleted, object.Key)
+	}
+	if want := []string{"a.txt", "missing.txt", "logs/c.txt"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("Expected %v to be deleted, got %v", want, deleted)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Code != "UserKeyMustBeSpecified" {
+		t.Errorf("Expected an error for the empty key, got %+v", result.Errors)
+	}
+
+	testhelpers.AssertResponseStatus(t, request("GET", "/test-bucket/a.txt", ""), 404)
+	testhelpers.AssertResponseStatus(t, request("GET", "/test-bucket/logs/c.txt", ""), 404)
+	testhelpers.AssertResponseStatus(t, request("GET", "/test-bucket/b.txt", ""), 200)
+
+	// Quiet requests only list errors
+	result = deleteObjects(true, "b.txt")
+	if len(result.Deleted) != 0 || len(result.Errors) != 0 {
+		t.Errorf("Expected an empty quiet result, got %+v", result)
+	}
+	testhelpers.AssertResponseStatus(t, request("GET", "/test-bucket/b.txt", ""), 404)
+
+	for _, body := range []string{"", "<Delete></Delete>", "<Delete><Object>"} {
+		resp := request("POST", "/test-bucket?delete", body)
+		testhelpers.AssertResponseStatus(t, resp, 400)
+		testhelpers.AssertErrorResponse(t, resp, "MalformedXML", emulator.ProtocolRESTXML)
+	}
+
+	resp := request("POST", "/missing-bucket?delete", "<Delete><Object><Key>a.txt</Key></Object></Delete>")
+	testhelpers.AssertResponseStatus(t, resp, 404)
+	testhelpers.AssertErrorResponse(t, resp, "NoSuchBucket", emulator.ProtocolRESTXML)
+}
@@ -10,6 +10,55 @@ import (
 	"time"
 )
 
+// smithyResponseTypes maps operation names to constructors for their generated response types.
+var smithyResponseTypes = map[string]func() interface{}{
+	"AbortMultipartUpload":                       func() interface{} { return &AbortMultipartUploadOutput{} },
+	"CompleteMultipartUpload":                    func() interface{} { return &CompleteMultipartUploadOutput{} },
+	"CreateBucket":                               func() interface{} { return &CreateBucketOutput{} },
+	"CreateMultipartUpload":                      func() interface{} { return &CreateMultipartUploadOutput{} },
+	"CreateSession":                              func() interface{} { return &CreateSessionOutput{} },
+	"DeleteObject":                               func() interface{} { return &DeleteObjectOutput{} },
+	"DeleteObjectTagging":                        func() interface{} { return &DeleteObjectTaggingOutput{} },
+	"DeleteObjects":                              func() interface{} { return &DeleteObjectsOutput{} },
+	"GetBucketAccelerateConfiguration":           func() interface{} { return &GetBucketAccelerateConfigurationOutput{} },
+	"GetBucketAcl":                               func() interface{} { return &GetBucketAclOutput{} },
+	"GetBucketCors":                              func() interface{} { return &GetBucketCorsOutput{} },
+	"GetBucketLifecycleConfiguration":            func() interface{} { return &GetBucketLifecycleConfigurationOutput{} },
+	"GetBucketLocation":                          func() interface{} { return &GetBucketLocationOutput{} },
+	"GetBucketLogging":                           func() interface{} { return &GetBucketLoggingOutput{} },
+	"GetBucketNotificationConfiguration":         func() interface{} { return &NotificationConfiguration{} },
+	"GetBucketRequestPayment":                    func() interface{} { return &GetBucketRequestPaymentOutput{} },
+	"GetBucketTagging":                           func() interface{} { return &GetBucketTaggingOutput{} },
+	"GetBucketVersioning":                        func() interface{} { return &GetBucketVersioningOutput{} },
+	"GetBucketWebsite":                           func() interface{} { return &GetBucketWebsiteOutput{} },
+	"GetObjectAcl":                               func() interface{} { return &GetObjectAclOutput{} },
+	"GetObjectAttributes":                        func() interface{} { return &GetObjectAttributesOutput{} },
+	"GetObjectTagging":                           func() interface{} { return &GetObjectTaggingOutput{} },
+	"HeadBucket":                                 func() interface{} { return &HeadBucketOutput{} },
+	"HeadObject":                                 func() interface{} { return &HeadObjectOutput{} },
+	"ListBucketAnalyticsConfigurations":          func() interface{} { return &ListBucketAnalyticsConfigurationsOutput{} },
+	"ListBucketIntelligentTieringConfigurations": func() interface{} { return &ListBucketIntelligentTieringConfigurationsOutput{} },
+	"ListBucketInventoryConfigurations":          func() interface{} { return &ListBucketInventoryConfigurationsOutput{} },
+	"ListBucketMetricsConfigurations":            func() interface{} { return &ListBucketMetricsConfigurationsOutput{} },
+	"ListBuckets":                                func() interface{} { return &ListBucketsOutput{} },
+	"ListDirectoryBuckets":                       func() interface{} { return &ListDirectoryBucketsOutput{} },
+	"ListMultipartUploads":                       func() interface{} { return &ListMultipartUploadsOutput{} },
+	"ListObjectVersions":                         func() interface{} { return &ListObjectVersionsOutput{} },
+	"ListObjects":                                func() interface{} { return &ListObjectsOutput{} },
+	"ListObjectsV2":                              func() interface{} { return &ListObjectsV2Output{} },
+	"ListParts":                                  func() interface{} { return &ListPartsOutput{} },
+	"PutBucketLifecycleConfiguration":            func() interface{} { return &PutBucketLifecycleConfigurationOutput{} },
+	"PutObject":                                  func() interface{} { return &PutObjectOutput{} },
+	"PutObjectAcl":                               func() interface{} { return &PutObjectAclOutput{} },
+	"PutObjectLegalHold":                         func() interface{} { return &PutObjectLegalHoldOutput{} },
+	"PutObjectLockConfiguration":                 func() interface{} { return &PutObjectLockConfigurationOutput{} },
+	"PutObjectRetention":                         func() interface{} { return &PutObjectRetentionOutput{} },
+	"PutObjectTagging":                           func() interface{} { return &PutObjectTaggingOutput{} },
+	"RenameObject":                               func() interface{} { return &RenameObjectOutput{} },
+	"RestoreObject":                              func() interface{} { return &RestoreObjectOutput{} },
+	"UploadPart":                                 func() interface{} { return &UploadPartOutput{} },
+}
+
 // Enum type aliases
 
 type AnalyticsS3ExportFileFormat string
@@ -925,9 +974,9 @@ type Grant struct {
 type Grantee struct {
 	DisplayName  *string `xml:"DisplayName,omitempty"`
 	EmailAddress *string `xml:"EmailAddress,omitempty"`
-	ID           *string `xml:"ID,omitempty"`  // The canonical user ID of the grantee.
-	Type         Type    `xml:"xsi:type"`      // Type of grantee
-	URI          *string `xml:"URI,omitempty"` // URI of the grantee group.
+	ID           *string `xml:"ID,omitempty"`                                        // The canonical user ID of the grantee.
+	Type         Type    `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr"` // Type of grantee
+	URI          *string `xml:"URI,omitempty"`                                       // URI of the grantee group.
 }
 
 // IndexDocument Container for the `Suffix` element.
@@ -40,6 +40,13 @@ type XMLGetBucketTaggingOutput struct {
 	TagSet  XMLTagSet `xml:"TagSet"`
 }
 
+// XMLListTagsForResourceOutput represents the response for the S3 Control ListTagsForResource
+type XMLListTagsForResourceOutput struct {
+	XMLName xml.Name  `xml:"ListTagsForResourceResult"`
+	Xmlns   string    `xml:"xmlns,attr"`
+	Tags    XMLTagSet `xml:"Tags"`
+}
+
 // XMLTagSet is a container for XMLTag elements
 type XMLTagSet struct {
 	Tags []XMLTag `xml:"Tag"`
@@ -58,16 +65,33 @@ type VersioningConfiguration struct {
 	Status  string   `xml:"Status,omitempty"`
 }
 
+// LocationConstraint represents the response for GetBucketLocation
+type LocationConstraint struct {
+	XMLName xml.Name `xml:"LocationConstraint"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Region  string   `xml:",chardata"`
+}
+
 // ListBucketResult represents the response for ListObjectsV2
 type ListBucketResult struct {
-	XMLName     xml.Name    `xml:"ListBucketResult"`
-	Xmlns       string      `xml:"xmlns,attr"`
-	Name        string      `xml:"Name"`
-	Prefix      string      `xml:"Prefix"`
-	KeyCount    int         `xml:"KeyCount"`
-	MaxKeys     int         `xml:"MaxKeys"`
-	IsTruncated bool        `xml:"IsTruncated"`
-	Contents    []XMLObject `xml:"Contents,omitempty"`
+	XMLName               xml.Name          `xml:"ListBucketResult"`
+	Xmlns                 string            `xml:"xmlns,attr"`
+	Name                  string            `xml:"Name"`
+	Prefix                string            `xml:"Prefix"`
+	Delimiter             string            `xml:"Delimiter,omitempty"`
+	StartAfter            string            `xml:"StartAfter,omitempty"`
+	ContinuationToken     string            `xml:"ContinuationToken,omitempty"`
+	NextContinuationToken string            `xml:"NextContinuationToken,omitempty"`
+	KeyCount              int               `xml:"KeyCount"`
+	MaxKeys               int               `xml:"MaxKeys"`
+	IsTruncated           bool              `xml:"IsTruncated"`
+	Contents              []XMLObject       `xml:"Contents,omitempty"`
+	CommonPrefixes        []XMLCommonPrefix `xml:"CommonPrefixes,omitempty"`
+}
+
+// XMLCommonPrefix is a group of keys that share a prefix up to the delimiter in list responses
+type XMLCommonPrefix struct {
+	Prefix string `xml:"Prefix"`
 }
 
 // XMLObject represents an S3 object in list responses
@@ -79,6 +103,123 @@ type XMLObject struct {
 	StorageClass string `xml:"StorageClass"`
 }
 
+// ListMultipartUploadsResult represents the response for ListMultipartUploads
+type ListMultipartUploadsResult struct {
+	XMLName            xml.Name             `xml:"ListMultipartUploadsResult"`
+	Xmlns              string               `xml:"xmlns,attr"`
+	Bucket             string               `xml:"Bucket"`
+	KeyMarker          string               `xml:"KeyMarker"`
+	UploadIdMarker     string               `xml:"UploadIdMarker"`
+	NextKeyMarker      string               `xml:"NextKeyMarker"`
+	NextUploadIdMarker string               `xml:"NextUploadIdMarker"`
+	Prefix             string               `xml:"Prefix"`
+	MaxUploads         int                  `xml:"MaxUploads"`
+	IsTruncated        bool                 `xml:"IsTruncated"`
+	Uploads            []XMLMultipartUpload `xml:"Upload,omitempty"`
+}
+
+// XMLMultipartUpload is an in-progress multipart upload in list responses
+type XMLMultipartUpload struct {
+	Key          string   `xml:"Key"`
+	UploadId     string   `xml:"UploadId"`
+	Initiator    XMLOwner `xml:"Initiator"`
+	Owner        XMLOwner `xml:"Owner"`
+	StorageClass string   `xml:"StorageClass"`
+	Initiated    string   `xml:"Initiated"`
+}
+
+// InitiateMultipartUploadResult represents the response for CreateMultipartUpload
+type InitiateMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	UploadId string   `xml:"UploadId"`
+}
+
+// CompleteMultipartUpload is the request body of CompleteMultipartUpload, the parts to join
+// into the object in order
+type CompleteMultipartUpload struct {
+	XMLName xml.Name           `xml:"CompleteMultipartUpload"`
+	Parts   []XMLCompletedPart `xml:"Part"`
+}
+
+// XMLCompletedPart is an uploaded part in a CompleteMultipartUpload request
+type XMLCompletedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// CompleteMultipartUploadResult represents the response for CompleteMultipartUpload
+type CompleteMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"CompleteMultipartUploadResult"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Location string   `xml:"Location"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	ETag     string   `xml:"ETag"`
+}
+
+// Delete is the request body of DeleteObjects, the objects to delete
+type Delete struct {
+	XMLName xml.Name              `xml:"Delete"`
+	Quiet   bool                  `xml:"Quiet"`
+	Objects []XMLObjectIdentifier `xml:"Object"`
+}
+
+// XMLObjectIdentifier is an object to delete in a DeleteObjects request
+type XMLObjectIdentifier struct {
+	Key string `xml:"Key"`
+}
+
+// DeleteResult represents the response for DeleteObjects
+type DeleteResult struct {
+	XMLName xml.Name           `xml:"DeleteResult"`
+	Xmlns   string             `xml:"xmlns,attr"`
+	Deleted []XMLDeletedObject `xml:"Deleted"`
+	Errors  []XMLDeleteError   `xml:"Error"`
+}
+
+// XMLDeletedObject is an object DeleteObjects deleted
+type XMLDeletedObject struct {
+	Key string `xml:"Key"`
+}
+
+// XMLDeleteError is an object DeleteObjects couldn't delete
+type XMLDeleteError struct {
+	Key     string `xml:"Key"`
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// GetObjectAttributesResponse represents the response for GetObjectAttributes. Only the
+// attributes the request asks for are set.
+type GetObjectAttributesResponse struct {
+	XMLName      xml.Name        `xml:"GetObjectAttributesResponse"`
+	Xmlns        string          `xml:"xmlns,attr"`
+	ETag         string          `xml:"ETag,omitempty"`
+	ObjectParts  *XMLObjectParts `xml:"ObjectParts,omitempty"`
+	StorageClass string          `xml:"StorageClass,omitempty"`
+	ObjectSize   *int64          `xml:"ObjectSize,omitempty"`
+}
+
+// XMLObjectParts is the parts of an object uploaded with a multipart upload in
+// GetObjectAttributes responses
+type XMLObjectParts struct {
+	PartsCount           int             `xml:"PartsCount"`
+	PartNumberMarker     int             `xml:"PartNumberMarker"`
+	NextPartNumberMarker int             `xml:"NextPartNumberMarker"`
+	MaxParts             int             `xml:"MaxParts"`
+	IsTruncated          bool            `xml:"IsTruncated"`
+	Parts                []XMLObjectPart `xml:"Part"`
+}
+
+// XMLObjectPart is a part of an object in GetObjectAttributes responses
+type XMLObjectPart struct {
+	PartNumber int   `xml:"PartNumber"`
+	Size       int64 `xml:"Size"`
+}
+
 // BucketLoggingStatus represents the response for GetBucketLogging
 type BucketLoggingStatus struct {
 	XMLName        xml.Name           `xml:"BucketLoggingStatus"`
@@ -121,3 +262,62 @@ type XMLPublicAccessBlockConfiguration struct {
 	IgnorePublicAcls      bool     `xml:"IgnorePublicAcls"`
 	RestrictPublicBuckets bool     `xml:"RestrictPublicBuckets"`
 }
+
+// AccessControlPolicy represents the response for GetBucketAcl
+// Also used as input type for PutBucketAcl
+type AccessControlPolicy struct {
+	XMLName           xml.Name             `xml:"AccessControlPolicy"`
+	Xmlns             string               `xml:"xmlns,attr"`
+	Owner             XMLOwner             `xml:"Owner"`
+	AccessControlList XMLAccessControlList `xml:"AccessControlList"`
+}
+
+// XMLAccessControlList is a container for XMLGrant elements
+type XMLAccessControlList struct {
+	Grants []XMLGrant `xml:"Grant"`
+}
+
+// XMLGrant grants a permission to a grantee
+type XMLGrant struct {
+	Grantee    XMLGrantee `xml:"Grantee"`
+	Permission string     `xml:"Permission"`
+}
+
+// XMLGrantee is a canonical user, identified by ID, or a predefined group, identified by URI
+type XMLGrantee struct {
+	XmlnsXsi     string `xml:"xmlns:xsi,attr,omitempty"`
+	Type         string `xml:"xsi:type,attr,omitempty"`
+	ID           string `xml:"ID,omitempty"`
+	DisplayName  string `xml:"DisplayName,omitempty"`
+	EmailAddress string `xml:"EmailAddress,omitempty"`
+	URI          string `xml:"URI,omitempty"`
+}
+
+// LifecycleConfiguration is the input to PutBucketLifecycleConfiguration. Only the status of
+// each rule is checked; the configuration is stored as sent.
+type LifecycleConfiguration struct {
+	XMLName xml.Name           `xml:"LifecycleConfiguration"`
+	Rules   []XMLLifecycleRule `xml:"Rule"`
+}
+
+// XMLLifecycleRule is a rule of a lifecycle configuration
+type XMLLifecycleRule struct {
+	ID     string `xml:"ID,omitempty"`
+	Status string `xml:"Status"`
+}
+
+// RequestPaymentConfiguration represents the response for GetBucketRequestPayment
+// Also used as input type for PutBucketRequestPayment
+type RequestPaymentConfiguration struct {
+	XMLName xml.Name `xml:"RequestPaymentConfiguration"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Payer   string   `xml:"Payer"`
+}
+
+// AccelerateConfiguration represents the response for GetBucketAccelerateConfiguration
+// Also used as input type for PutBucketAccelerateConfiguration
+type AccelerateConfiguration struct {
+	XMLName xml.Name `xml:"AccelerateConfiguration"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Status  string   `xml:"Status,omitempty"`
+}
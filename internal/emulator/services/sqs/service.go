@@ -16,35 +16,69 @@ import (
 )
 
 const (
-	defaultAccountID              = "123456789012"
-	defaultRegion                 = "us-east-1"
 	defaultVisibilityTimeout      = 30
 	defaultMaxMessageSize         = 262144 // 256 KB
 	defaultMessageRetentionPeriod = 345600 // 4 days
 	defaultDelaySeconds           = 0
 	defaultReceiveWaitTime        = 0
 	defaultKmsReusePeriod         = 300
+
+	// defaultMaxInFlightMessages is the AWS quota on the messages a queue can have in flight
+	defaultMaxInFlightMessages = 120000
 )
 
 // SQSService implements the AWS SQS service emulator
 type SQSService struct {
 	state     emulator.StateManager
 	validator emulator.Validator
+	clock     emulator.Clock
+
+	// maxInFlight is the most messages a queue can have in flight before ReceiveMessage and
+	// SendMessage fail with OverLimit
+	maxInFlight int
 }
 
 // NewSQSService creates a new SQS service instance
 func NewSQSService(state emulator.StateManager, validator emulator.Validator) *SQSService {
 	return &SQSService{
-		state:     state,
-		validator: validator,
+		state:       state,
+		validator:   validator,
+		clock:       emulator.SystemClock{},
+		maxInFlight: defaultMaxInFlightMessages,
 	}
 }
 
+// SetClock sets the clock used to timestamp messages and expire their visibility timeouts
+func (s *SQSService) SetClock(clock emulator.Clock) {
+	s.clock = clock
+}
+
+// SetMaxInFlightMessages sets how many messages a queue can have in flight. Once a queue
+// reaches the limit, ReceiveMessage fails with OverLimit until messages are deleted or become
+// visible again, and so do SendMessage and SendMessageBatch, so producers can test their
+// backpressure handling. A limit below 1 restores the default, the AWS quota of 120,000.
+func (s *SQSService) SetMaxInFlightMessages(limit int) {
+	if limit < 1 {
+		limit = defaultMaxInFlightMessages
+	}
+	s.maxInFlight = limit
+}
+
 // ServiceName returns the service identifier
 func (s *SQSService) ServiceName() string {
 	return "sqs"
 }
 
+// Protocol returns the AWS protocol the service speaks
+func (s *SQSService) Protocol() emulator.ProtocolType {
+	return emulator.ProtocolJSON
+}
+
+// ResponseTypes returns constructors for the CloudMirror-generated response types, keyed by action.
+func (s *SQSService) ResponseTypes() map[string]func() interface{} {
+	return smithyResponseTypes
+}
+
 // SupportedActions returns the list of AWS API actions this service handles.
 // Used by the router to determine which service handles a given Query Protocol request.
 func (s *SQSService) SupportedActions() []string {
@@ -88,43 +122,43 @@ func (s *SQSService) HandleRequest(ctx context.Context, req *emulator.AWSRequest
 	case "CreateQueue":
 		input, err := emulator.ParseJSONRequest[CreateQueueRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.createQueue(ctx, input)
 	case "DeleteQueue":
 		input, err := emulator.ParseJSONRequest[DeleteQueueRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.deleteQueue(ctx, input)
 	case "ListQueues":
 		input, err := emulator.ParseJSONRequest[ListQueuesRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.listQueues(ctx, input)
 	case "GetQueueUrl":
 		input, err := emulator.ParseJSONRequest[GetQueueUrlRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.getQueueUrl(ctx, input)
 	case "GetQueueAttributes":
 		input, err := emulator.ParseJSONRequest[GetQueueAttributesRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.getQueueAttributes(ctx, input)
 	case "SetQueueAttributes":
 		input, err := emulator.ParseJSONRequest[SetQueueAttributesRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.setQueueAttributes(ctx, input)
 	case "PurgeQueue":
 		input, err := emulator.ParseJSONRequest[PurgeQueueRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.purgeQueue(ctx, input)
 
@@ -132,25 +166,25 @@ func (s *SQSService) HandleRequest(ctx context.Context, req *emulator.AWSRequest
 	case "SendMessage":
 		input, err := emulator.ParseJSONRequest[SendMessageRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.sendMessage(ctx, input)
 	case "ReceiveMessage":
 		input, err := emulator.ParseJSONRequest[ReceiveMessageRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.receiveMessage(ctx, input)
 	case "DeleteMessage":
 		input, err := emulator.ParseJSONRequest[DeleteMessageRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.deleteMessage(ctx, input)
 	case "ChangeMessageVisibility":
 		input, err := emulator.ParseJSONRequest[ChangeMessageVisibilityRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.changeMessageVisibility(ctx, input)
 
@@ -158,13 +192,13 @@ func (s *SQSService) HandleRequest(ctx context.Context, req *emulator.AWSRequest
 	case "SendMessageBatch":
 		input, err := emulator.ParseJSONRequest[SendMessageBatchRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.sendMessageBatch(ctx, input)
 	case "DeleteMessageBatch":
 		input, err := emulator.ParseJSONRequest[DeleteMessageBatchRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.deleteMessageBatch(ctx, input)
 
@@ -172,19 +206,19 @@ func (s *SQSService) HandleRequest(ctx context.Context, req *emulator.AWSRequest
 	case "TagQueue":
 		input, err := emulator.ParseJSONRequest[TagQueueRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.tagQueue(ctx, input)
 	case "UntagQueue":
 		input, err := emulator.ParseJSONRequest[UntagQueueRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.untagQueue(ctx, input)
 	case "ListQueueTags":
 		input, err := emulator.ParseJSONRequest[ListQueueTagsRequest](req.Body)
 		if err != nil {
-			return s.errorResponse(400, "SerializationException", err.Error()), nil
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
 		}
 		return s.listQueueTags(ctx, input)
 
@@ -227,6 +261,11 @@ func (s *SQSService) createQueue(ctx context.Context, input *CreateQueueRequest)
 	// Check if FIFO queue (name must end with .fifo)
 	isFifo := strings.HasSuffix(queueName, ".fifo")
 
+	// Validate that the FIFO attributes agree with the queue name
+	if err := validateFifoAttributes(isFifo, input.Attributes); err != nil {
+		return s.errorResponse(400, "InvalidParameterValue", err.Error()), nil
+	}
+
 	// Check if queue already exists
 	stateKey := fmt.Sprintf("sqs:queue:%s", queueName)
 	if s.state.Exists(stateKey) {
@@ -238,9 +277,10 @@ func (s *SQSService) createQueue(ctx context.Context, input *CreateQueueRequest)
 		}
 	}
 
-	now := time.Now().Unix()
-	queueUrl := fmt.Sprintf("https://sqs.%s.amazonaws.com/%s/%s", defaultRegion, defaultAccountID, queueName)
-	queueArn := fmt.Sprintf("arn:aws:sqs:%s:%s:%s", defaultRegion, defaultAccountID, queueName)
+	now := s.clock.Now().Unix()
+	scope := emulator.RequestScopeFromContext(ctx)
+	queueUrl := fmt.Sprintf("https://sqs.%s.%s/%s/%s", scope.Region, scope.DNSSuffix(), scope.AccountID, queueName)
+	queueArn := fmt.Sprintf("arn:%s:sqs:%s:%s:%s", scope.Partition(), scope.Region, scope.AccountID, queueName)
 
 	queue := Queue{
 		QueueName:              queueName,
@@ -370,6 +410,13 @@ func (s *SQSService) getQueueAttributes(ctx context.Context, input *GetQueueAttr
 		requestedAttrs = append(requestedAttrs, string(attr))
 	}
 
+	// Count the messages as of now, so in-flight and delayed messages are reported accurately
+	msgKey := fmt.Sprintf("sqs:messages:%s", queueName)
+	var queueMsgs QueueMessages
+	if err := s.state.Get(msgKey, &queueMsgs); err == nil {
+		s.countMessages(&queue, queueMsgs.Messages)
+	}
+
 	// Build attributes map (JSON format uses map, not array)
 	attrs := s.buildQueueAttributesMap(&queue, requestedAttrs)
 
@@ -395,7 +442,7 @@ func (s *SQSService) setQueueAttributes(ctx context.Context, input *SetQueueAttr
 
 	// Apply new attributes
 	s.applyQueueAttributesFromMap(&queue, input.Attributes)
-	queue.LastModifiedTimestamp = time.Now().Unix()
+	queue.LastModifiedTimestamp = s.clock.Now().Unix()
 
 	if err := s.state.Set(stateKey, &queue); err != nil {
 		return s.errorResponse(500, "InternalFailure", "Failed to update queue"), nil
@@ -415,16 +462,22 @@ func (s *SQSService) purgeQueue(ctx context.Context, input *PurgeQueueRequest) (
 	}
 
 	stateKey := fmt.Sprintf("sqs:queue:%s", queueName)
-	if !s.state.Exists(stateKey) {
+	var queue Queue
+	if err := s.state.Get(stateKey, &queue); err != nil {
 		return s.errorResponse(400, "AWS.SimpleQueueService.NonExistentQueue", "The specified queue does not exist"), nil
 	}
 
-	// Clear all messages
+	// Clear all messages, including those in flight
 	msgKey := fmt.Sprintf("sqs:messages:%s", queueName)
 	if err := s.state.Set(msgKey, &QueueMessages{Messages: []StoredMessage{}}); err != nil {
 		return s.errorResponse(500, "InternalFailure", "Failed to purge queue"), nil
 	}
 
+	s.countMessages(&queue, nil)
+	if err := s.state.Set(stateKey, &queue); err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to purge queue"), nil
+	}
+
 	return s.successResponse("PurgeQueue", EmptyResult{})
 }
 
@@ -464,7 +517,7 @@ func (s *SQSService) sendMessage(ctx context.Context, input *SendMessageRequest)
 
 	// Create message
 	messageId := uuid.New().String()
-	now := time.Now()
+	now := s.clock.Now()
 
 	delaySeconds := queue.DelaySeconds
 	if input.DelaySeconds != nil {
@@ -509,6 +562,10 @@ func (s *SQSService) sendMessage(ctx context.Context, input *SendMessageRequest)
 		queueMsgs = QueueMessages{Messages: []StoredMessage{}}
 	}
 
+	if s.inFlightCount(queueMsgs.Messages) >= s.maxInFlight {
+		return s.overLimitResponse(), nil
+	}
+
 	queueMsgs.Messages = append(queueMsgs.Messages, msg)
 	if err := s.state.Set(msgKey, &queueMsgs); err != nil {
 		return s.errorResponse(500, "InternalFailure", "Failed to store message"), nil
@@ -558,21 +615,24 @@ func (s *SQSService) receiveMessage(ctx context.Context, input *ReceiveMessageRe
 		queueMsgs = QueueMessages{Messages: []StoredMessage{}}
 	}
 
-	now := time.Now()
+	// Receive no more messages than would take the queue to its in-flight limit
+	inFlight := s.inFlightCount(queueMsgs.Messages)
+	if inFlight >= s.maxInFlight {
+		return s.overLimitResponse(), nil
+	}
+	if available := s.maxInFlight - inFlight; int(maxMessages) > available {
+		maxMessages = int32(available)
+	}
+
+	now := s.clock.Now()
 	var receivedMsgs []JSONReceivedMessage
 	var updatedMsgs []StoredMessage
 
 	for i := range queueMsgs.Messages {
 		msg := &queueMsgs.Messages[i]
 
-		// Skip messages that are not yet visible
-		if msg.VisibleAt.After(now) {
-			updatedMsgs = append(updatedMsgs, *msg)
-			continue
-		}
-
-		// Skip messages still in delay
-		if !msg.DelayUntil.IsZero() && msg.DelayUntil.After(now) {
+		// Skip messages that are in flight or still in delay
+		if !messageVisible(*msg, now) {
 			updatedMsgs = append(updatedMsgs, *msg)
 			continue
 		}
@@ -589,7 +649,7 @@ func (s *SQSService) receiveMessage(ctx context.Context, input *ReceiveMessageRe
 
 			// Build message attributes for JSON response (map instead of array)
 			attrs := map[string]string{
-				"SenderId":                         defaultAccountID,
+				"SenderId":                         emulator.RequestScopeFromContext(ctx).AccountID,
 				"SentTimestamp":                    strconv.FormatInt(msg.SentTimestamp, 10),
 				"ApproximateReceiveCount":          strconv.Itoa(msg.ApproximateReceiveCount),
 				"ApproximateFirstReceiveTimestamp": strconv.FormatInt(msg.FirstReceiveTimestamp, 10),
@@ -639,11 +699,16 @@ func (s *SQSService) deleteMessage(ctx context.Context, input *DeleteMessageRequ
 		return s.errorResponse(400, "AWS.SimpleQueueService.NonExistentQueue", "The specified queue does not exist"), nil
 	}
 
-	// Find and delete message
+	if !isWellFormedReceiptHandle(receiptHandle) {
+		return s.errorResponse(400, "ReceiptHandleIsInvalid", "The receipt handle provided is not valid"), nil
+	}
+
+	// Find and delete message. Like AWS, deleting with a stale handle (the message was already
+	// deleted or purged) succeeds, so consumers that delete a message twice don't fail.
 	msgKey := fmt.Sprintf("sqs:messages:%s", queueName)
 	var queueMsgs QueueMessages
 	if err := s.state.Get(msgKey, &queueMsgs); err != nil {
-		return s.errorResponse(400, "ReceiptHandleIsInvalid", "The receipt handle provided is not valid"), nil
+		return s.successResponse("DeleteMessage", EmptyResult{})
 	}
 
 	found := false
@@ -657,7 +722,7 @@ func (s *SQSService) deleteMessage(ctx context.Context, input *DeleteMessageRequ
 	}
 
 	if !found {
-		return s.errorResponse(400, "ReceiptHandleIsInvalid", "The receipt handle provided is not valid"), nil
+		return s.successResponse("DeleteMessage", EmptyResult{})
 	}
 
 	queueMsgs.Messages = newMsgs
@@ -697,7 +762,7 @@ func (s *SQSService) changeMessageVisibility(ctx context.Context, input *ChangeM
 	found := false
 	for i := range queueMsgs.Messages {
 		if queueMsgs.Messages[i].ReceiptHandle == receiptHandle {
-			queueMsgs.Messages[i].VisibleAt = time.Now().Add(time.Duration(visibilityTimeout) * time.Second)
+			queueMsgs.Messages[i].VisibleAt = s.clock.Now().Add(time.Duration(visibilityTimeout) * time.Second)
 			found = true
 			break
 		}
@@ -734,6 +799,11 @@ func (s *SQSService) sendMessageBatch(ctx context.Context, input *SendMessageBat
 		return s.errorResponse(400, "AWS.SimpleQueueService.NonExistentQueue", "The specified queue does not exist"), nil
 	}
 
+	var existing QueueMessages
+	if err := s.state.Get(fmt.Sprintf("sqs:messages:%s", queueName), &existing); err == nil && s.inFlightCount(existing.Messages) >= s.maxInFlight {
+		return s.overLimitResponse(), nil
+	}
+
 	var successful []JSONSendMessageBatchResultEntry
 	var failed []JSONBatchResultErrorEntry
 
@@ -755,8 +825,8 @@ func (s *SQSService) sendMessageBatch(ctx context.Context, input *SendMessageBat
 			MessageId:     messageId,
 			MD5OfBody:     md5Str,
 			Body:          body,
-			SentTimestamp: time.Now().Unix() * 1000,
-			VisibleAt:     time.Now(),
+			SentTimestamp: s.clock.Now().Unix() * 1000,
+			VisibleAt:     s.clock.Now(),
 		}
 
 		// Store message
@@ -823,28 +893,25 @@ func (s *SQSService) deleteMessageBatch(ctx context.Context, input *DeleteMessag
 		id := *entry.Id
 		handle := *entry.ReceiptHandle
 
-		// Find and remove message
-		found := false
-		newMsgs := make([]StoredMessage, 0, len(queueMsgs.Messages))
-		for _, msg := range queueMsgs.Messages {
-			if msg.ReceiptHandle == handle {
-				found = true
-			} else {
-				newMsgs = append(newMsgs, msg)
-			}
-		}
-
-		if found {
-			queueMsgs.Messages = newMsgs
-			successful = append(successful, JSONDeleteMessageBatchResultEntry{Id: id})
-		} else {
+		if !isWellFormedReceiptHandle(handle) {
 			failed = append(failed, JSONBatchResultErrorEntry{
 				Id:          id,
 				SenderFault: true,
 				Code:        "ReceiptHandleIsInvalid",
 				Message:     "The receipt handle provided is not valid",
 			})
+			continue
 		}
+
+		// Remove the message, if it's still there. A stale handle is a successful no-op, as with DeleteMessage.
+		newMsgs := make([]StoredMessage, 0, len(queueMsgs.Messages))
+		for _, msg := range queueMsgs.Messages {
+			if msg.ReceiptHandle != handle {
+				newMsgs = append(newMsgs, msg)
+			}
+		}
+		queueMsgs.Messages = newMsgs
+		successful = append(successful, JSONDeleteMessageBatchResultEntry{Id: id})
 	}
 
 	if err := s.state.Set(msgKey, &queueMsgs); err != nil {
@@ -1008,6 +1075,44 @@ func (s *SQSService) setQueueAttribute(queue *Queue, name, value string) {
 	}
 }
 
+// messageVisible reports whether a message can be received at now. A message becomes visible at
+// the instant its visibility timeout or delay ends, so a timeout of 0 redelivers it straight away.
+func messageVisible(msg StoredMessage, now time.Time) bool {
+	return !msg.VisibleAt.After(now) && (msg.DelayUntil.IsZero() || !msg.DelayUntil.After(now))
+}
+
+// countMessages sets the queue's approximate message counts. A message that has been received and
+// whose visibility timeout hasn't expired is in flight (not visible), and one that hasn't been
+// received yet but isn't visible is delayed.
+func (s *SQSService) countMessages(queue *Queue, messages []StoredMessage) {
+	now := s.clock.Now()
+	queue.ApproximateNumberOfMsgs = 0
+	queue.ApproximateNumMsgsNotVis = 0
+	queue.ApproximateNumMsgsDelayed = 0
+
+	for _, msg := range messages {
+		switch {
+		case messageVisible(msg, now):
+			queue.ApproximateNumberOfMsgs++
+		case msg.ApproximateReceiveCount > 0:
+			queue.ApproximateNumMsgsNotVis++
+		default:
+			queue.ApproximateNumMsgsDelayed++
+		}
+	}
+}
+
+// inFlightCount returns how many of the messages are in flight
+func (s *SQSService) inFlightCount(messages []StoredMessage) int {
+	var counts Queue
+	s.countMessages(&counts, messages)
+	return int(counts.ApproximateNumMsgsNotVis)
+}
+
+func (s *SQSService) overLimitResponse() *emulator.AWSResponse {
+	return s.errorResponse(403, "OverLimit", fmt.Sprintf("The maximum number of in flight messages (%d) has been reached", s.maxInFlight))
+}
+
 // buildQueueAttributesMap returns attributes as a map for JSON responses
 func (s *SQSService) buildQueueAttributesMap(queue *Queue, requestedAttrs []string) map[string]string {
 	allAttrs := map[string]string{
@@ -1087,6 +1192,43 @@ func validateQueueName(name string) error {
 	return nil
 }
 
+// fifoOnlyAttributes are queue attributes that may only be set on FIFO queues.
+var fifoOnlyAttributes = []string{
+	"ContentBasedDeduplication",
+	"DeduplicationScope",
+	"FifoThroughputLimit",
+}
+
+// validateFifoAttributes checks that the FifoQueue attribute matches the queue
+// name suffix and that FIFO-only attributes are not set on standard queues.
+// A .fifo name without an explicit FifoQueue attribute implies FifoQueue=true.
+func validateFifoAttributes(isFifo bool, attrs map[string]string) error {
+	if value, ok := attrs["FifoQueue"]; ok {
+		fifoAttr, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for the FifoQueue attribute: %s", value)
+		}
+		if fifoAttr && !isFifo {
+			return fmt.Errorf("the name of a FIFO queue must end with the .fifo suffix")
+		}
+		if !fifoAttr && isFifo {
+			return fmt.Errorf("queues with the .fifo suffix must have the FifoQueue attribute set to true")
+		}
+	}
+
+	if isFifo {
+		return nil
+	}
+
+	for _, name := range fifoOnlyAttributes {
+		if _, ok := attrs[name]; ok {
+			return fmt.Errorf("attribute %s can only be set on FIFO queues", name)
+		}
+	}
+
+	return nil
+}
+
 func extractQueueNameFromUrl(queueUrl string) string {
 	// Extract queue name from URL like https://sqs.us-east-1.amazonaws.com/123456789012/my-queue
 	parts := strings.Split(queueUrl, "/")
@@ -1102,7 +1244,20 @@ func generateReceiptHandle() string {
 	return base64.StdEncoding.EncodeToString(b)
 }
 
+// isWellFormedReceiptHandle reports whether handle could have been issued by generateReceiptHandle,
+// whether or not its message still exists
+func isWellFormedReceiptHandle(handle string) bool {
+	b, err := base64.StdEncoding.DecodeString(handle)
+	return err == nil && len(b) == 64
+}
+
 func generateSequenceNumber() string {
 	// Generate a sequence number similar to AWS FIFO queues
 	return strconv.FormatInt(time.Now().UnixNano(), 10)
 }
+
+var (
+	_ emulator.Service              = (*SQSService)(nil)
+	_ emulator.ActionProvider       = (*SQSService)(nil)
+	_ emulator.ResponseTypeProvider = (*SQSService)(nil)
+)
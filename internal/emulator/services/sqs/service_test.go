@@ -0,0 +1,469 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+)
+
+func newTestSQSService() *SQSService {
+	return NewSQSService(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+}
+
+// callSQS sends a JSON protocol request for the given action to the service.
+func callSQS(t *testing.T, service *SQSService, action string, input interface{}) *emulator.AWSResponse {
+	t.Helper()
+	body, err := json.Marshal(input)
+	require.NoError(t, err)
+
+	resp, err := service.HandleRequest(context.Background(), &emulator.AWSRequest{
+		Method: "POST",
+		Path:   "/",
+		Headers: map[string]string{
+			"Content-Type": "application/x-amz-json-1.0",
+			"X-Amz-Target": "AmazonSQS." + action,
+		},
+		Body:   body,
+		Action: action,
+	})
+	require.NoError(t, err)
+	return resp
+}
+
+// errorCode extracts the __type field from a JSON error response.
+func errorCode(t *testing.T, resp *emulator.AWSResponse) string {
+	t.Helper()
+	var errResp map[string]interface{}
+	require.NoError(t, json.Unmarshal(resp.Body, &errResp))
+	code, _ := errResp["__type"].(string)
+	return code
+}
+
+func TestCreateQueue_UrlUsesRequestScope(t *testing.T) {
+	service := newTestSQSService()
+	ctx := emulator.WithRequestScope(context.Background(), emulator.RequestScope{AccountID: "111122223333", Region: "eu-west-1"})
+
+	resp, err := service.HandleRequest(ctx, &emulator.AWSRequest{
+		Method: "POST",
+		Path:   "/",
+		Headers: map[string]string{
+			"Content-Type": "application/x-amz-json-1.0",
+			"X-Amz-Target": "AmazonSQS.CreateQueue",
+		},
+		Body:   []byte(`{"QueueName":"scoped-queue"}`),
+		Action: "CreateQueue",
+	})
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+
+	var queue Queue
+	require.NoError(t, service.state.Get("sqs:queue:scoped-queue", &queue))
+	assert.Equal(t, "https://sqs.eu-west-1.amazonaws.com/111122223333/scoped-queue", queue.QueueUrl)
+	assert.Equal(t, "arn:aws:sqs:eu-west-1:111122223333:scoped-queue", queue.QueueArn)
+}
+
+func TestCreateQueue_UrlUsesRegionPartition(t *testing.T) {
+	service := newTestSQSService()
+	ctx := emulator.WithRequestScope(context.Background(), emulator.RequestScope{AccountID: "111122223333", Region: "cn-north-1"})
+
+	resp, err := service.HandleRequest(ctx, &emulator.AWSRequest{
+		Method: "POST",
+		Path:   "/",
+		Headers: map[string]string{
+			"Content-Type": "application/x-amz-json-1.0",
+			"X-Amz-Target": "AmazonSQS.CreateQueue",
+		},
+		Body:   []byte(`{"QueueName":"china-queue"}`),
+		Action: "CreateQueue",
+	})
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+
+	var queue Queue
+	require.NoError(t, service.state.Get("sqs:queue:china-queue", &queue))
+	assert.Equal(t, "https://sqs.cn-north-1.amazonaws.com.cn/111122223333/china-queue", queue.QueueUrl)
+	assert.Equal(t, "arn:aws-cn:sqs:cn-north-1:111122223333:china-queue", queue.QueueArn)
+}
+
+// ============================================================================
+// CreateQueue FIFO Validation Tests
+// ============================================================================
+
+func TestCreateQueue_FifoValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		queueName  string
+		attributes map[string]string
+		wantErr    bool
+	}{
+		{
+			name:      "standard queue without attributes",
+			queueName: "standard-queue",
+		},
+		{
+			name:      "fifo suffix implies FifoQueue",
+			queueName: "implied.fifo",
+		},
+		{
+			name:       "fifo suffix with FifoQueue true",
+			queueName:  "explicit.fifo",
+			attributes: map[string]string{"FifoQueue": "true", "ContentBasedDeduplication": "true"},
+		},
+		{
+			name:       "standard queue with FifoQueue false",
+			queueName:  "explicit-standard",
+			attributes: map[string]string{"FifoQueue": "false"},
+		},
+		{
+			name:       "fifo suffix with FifoQueue false",
+			queueName:  "mismatch.fifo",
+			attributes: map[string]string{"FifoQueue": "false"},
+			wantErr:    true,
+		},
+		{
+			name:       "standard name with FifoQueue true",
+			queueName:  "missing-suffix",
+			attributes: map[string]string{"FifoQueue": "true"},
+			wantErr:    true,
+		},
+		{
+			name:       "invalid FifoQueue value",
+			queueName:  "invalid.fifo",
+			attributes: map[string]string{"FifoQueue": "yes"},
+			wantErr:    true,
+		},
+		{
+			name:       "ContentBasedDeduplication on standard queue",
+			queueName:  "standard-dedup",
+			attributes: map[string]string{"ContentBasedDeduplication": "true"},
+			wantErr:    true,
+		},
+		{
+			name:       "DeduplicationScope on standard queue",
+			queueName:  "standard-scope",
+			attributes: map[string]string{"DeduplicationScope": "messageGroup"},
+			wantErr:    true,
+		},
+		{
+			name:       "FifoThroughputLimit on standard queue",
+			queueName:  "standard-throughput",
+			attributes: map[string]string{"FifoThroughputLimit": "perQueue"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newTestSQSService()
+			resp := callSQS(t, service, "CreateQueue", map[string]interface{}{
+				"QueueName":  tt.queueName,
+				"Attributes": tt.attributes,
+			})
+
+			if tt.wantErr {
+				assert.Equal(t, 400, resp.StatusCode)
+				assert.Equal(t, "InvalidParameterValue", errorCode(t, resp))
+				assert.False(t, service.state.Exists("sqs:queue:"+tt.queueName))
+				return
+			}
+
+			assert.Equal(t, 200, resp.StatusCode)
+			assert.True(t, service.state.Exists("sqs:queue:"+tt.queueName))
+		})
+	}
+}
+
+// ============================================================================
+// Visibility Timeout Tests
+// ============================================================================
+
+// queueAttributes returns the queue's attributes from GetQueueAttributes
+func queueAttributes(t *testing.T, service *SQSService, queueUrl string) map[string]string {
+	t.Helper()
+	resp := callSQS(t, service, "GetQueueAttributes", map[string]interface{}{
+		"QueueUrl":       queueUrl,
+		"AttributeNames": []string{"All"},
+	})
+	require.Equal(t, 200, resp.StatusCode)
+	var result JSONGetQueueAttributesResult
+	require.NoError(t, json.Unmarshal(resp.Body, &result))
+	return result.Attributes
+}
+
+func receiveMessages(t *testing.T, service *SQSService, queueUrl string) []JSONReceivedMessage {
+	t.Helper()
+	resp := callSQS(t, service, "ReceiveMessage", map[string]interface{}{"QueueUrl": queueUrl})
+	require.Equal(t, 200, resp.StatusCode)
+	var result JSONReceiveMessageResult
+	require.NoError(t, json.Unmarshal(resp.Body, &result))
+	return result.Messages
+}
+
+func TestReceiveMessage_RedeliversAfterVisibilityTimeout(t *testing.T) {
+	service := newTestSQSService()
+	clock := &emulator.FixedClock{Time: time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)}
+	service.SetClock(clock)
+
+	resp := callSQS(t, service, "CreateQueue", map[string]interface{}{
+		"QueueName":  "jobs",
+		"Attributes": map[string]string{"VisibilityTimeout": "30"},
+	})
+	require.Equal(t, 200, resp.StatusCode)
+	var created JSONCreateQueueResult
+	require.NoError(t, json.Unmarshal(resp.Body, &created))
+
+	resp = callSQS(t, service, "SendMessage", map[string]interface{}{"QueueUrl": created.QueueUrl, "MessageBody": "hello"})
+	require.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "1", queueAttributes(t, service, created.QueueUrl)["ApproximateNumberOfMessages"])
+
+	first := receiveMessages(t, service, created.QueueUrl)
+	require.Len(t, first, 1)
+	assert.Equal(t, "1", first[0].Attributes["ApproximateReceiveCount"])
+
+	// The message is in flight until its visibility timeout expires
+	attrs := queueAttributes(t, service, created.QueueUrl)
+	assert.Equal(t, "0", attrs["ApproximateNumberOfMessages"])
+	assert.Equal(t, "1", attrs["ApproximateNumberOfMessagesNotVisible"])
+	clock.Advance(29 * time.Second)
+	assert.Empty(t, receiveMessages(t, service, created.QueueUrl))
+
+	clock.Advance(2 * time.Second)
+	assert.Equal(t, "1", queueAttributes(t, service, created.QueueUrl)["ApproximateNumberOfMessages"])
+
+	second := receiveMessages(t, service, created.QueueUrl)
+	require.Len(t, second, 1)
+	assert.Equal(t, first[0].MessageId, second[0].MessageId)
+	assert.NotEqual(t, first[0].ReceiptHandle, second[0].ReceiptHandle)
+	assert.Equal(t, "2", second[0].Attributes["ApproximateReceiveCount"])
+	assert.Equal(t, "1", queueAttributes(t, service, created.QueueUrl)["ApproximateNumberOfMessagesNotVisible"])
+}
+
+func TestChangeMessageVisibility_ZeroTimeoutRedeliversImmediately(t *testing.T) {
+	service := newTestSQSService()
+	// The clock doesn't move, so the message must be visible at the instant its timeout is changed
+	service.SetClock(&emulator.FixedClock{Time: time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)})
+
+	resp := callSQS(t, service, "CreateQueue", map[string]interface{}{
+		"QueueName":  "retries",
+		"Attributes": map[string]string{"VisibilityTimeout": "30"},
+	})
+	require.Equal(t, 200, resp.StatusCode)
+	var created JSONCreateQueueResult
+	require.NoError(t, json.Unmarshal(resp.Body, &created))
+
+	resp = callSQS(t, service, "SendMessage", map[string]interface{}{"QueueUrl": created.QueueUrl, "MessageBody": "retry me"})
+	require.Equal(t, 200, resp.StatusCode)
+
+	first := receiveMessages(t, service, created.QueueUrl)
+	require.Len(t, first, 1)
+	assert.Empty(t, receiveMessages(t, service, created.QueueUrl))
+
+	resp = callSQS(t, service, "ChangeMessageVisibility", map[string]interface{}{
+		"QueueUrl":          created.QueueUrl,
+		"ReceiptHandle":     first[0].ReceiptHandle,
+		"VisibilityTimeout": 0,
+	})
+	require.Equal(t, 200, resp.StatusCode)
+
+	attrs := queueAttributes(t, service, created.QueueUrl)
+	assert.Equal(t, "1", attrs["ApproximateNumberOfMessages"])
+	assert.Equal(t, "0", attrs["ApproximateNumberOfMessagesNotVisible"])
+
+	second := receiveMessages(t, service, created.QueueUrl)
+	require.Len(t, second, 1)
+	assert.Equal(t, first[0].MessageId, second[0].MessageId)
+	assert.Equal(t, "2", second[0].Attributes["ApproximateReceiveCount"])
+}
+
+func TestReceiveMessage_ZeroVisibilityTimeoutLeavesMessageVisible(t *testing.T) {
+	service := newTestSQSService()
+	service.SetClock(&emulator.FixedClock{Time: time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)})
+
+	resp := callSQS(t, service, "CreateQueue", map[string]interface{}{"QueueName": "peek"})
+	require.Equal(t, 200, resp.StatusCode)
+	var created JSONCreateQueueResult
+	require.NoError(t, json.Unmarshal(resp.Body, &created))
+
+	resp = callSQS(t, service, "SendMessage", map[string]interface{}{"QueueUrl": created.QueueUrl, "MessageBody": "hello"})
+	require.Equal(t, 200, resp.StatusCode)
+
+	// Each receive leaves the message visible for the next one
+	for _, receiveCount := range []string{"1", "2"} {
+		resp = callSQS(t, service, "ReceiveMessage", map[string]interface{}{"QueueUrl": created.QueueUrl, "VisibilityTimeout": 0})
+		require.Equal(t, 200, resp.StatusCode)
+		var result JSONReceiveMessageResult
+		require.NoError(t, json.Unmarshal(resp.Body, &result))
+		require.Len(t, result.Messages, 1)
+		assert.Equal(t, receiveCount, result.Messages[0].Attributes["ApproximateReceiveCount"])
+	}
+}
+
+func TestGetQueueAttributes_CountsDelayedMessages(t *testing.T) {
+	service := newTestSQSService()
+	clock := &emulator.FixedClock{Time: time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)}
+	service.SetClock(clock)
+
+	resp := callSQS(t, service, "CreateQueue", map[string]interface{}{"QueueName": "delayed"})
+	require.Equal(t, 200, resp.StatusCode)
+	var created JSONCreateQueueResult
+	require.NoError(t, json.Unmarshal(resp.Body, &created))
+
+	resp = callSQS(t, service, "SendMessage", map[string]interface{}{"QueueUrl": created.QueueUrl, "MessageBody": "later", "DelaySeconds": 10})
+	require.Equal(t, 200, resp.StatusCode)
+
+	attrs := queueAttributes(t, service, created.QueueUrl)
+	assert.Equal(t, "1", attrs["ApproximateNumberOfMessagesDelayed"])
+	assert.Equal(t, "0", attrs["ApproximateNumberOfMessagesNotVisible"])
+
+	clock.Advance(10 * time.Second)
+	attrs = queueAttributes(t, service, created.QueueUrl)
+	assert.Equal(t, "0", attrs["ApproximateNumberOfMessagesDelayed"])
+	assert.Equal(t, "1", attrs["ApproximateNumberOfMessages"])
+}
+
+func TestPurgeQueue_RemovesInFlightMessages(t *testing.T) {
+	service := newTestSQSService()
+
+	resp := callSQS(t, service, "CreateQueue", map[string]interface{}{"QueueName": "purged"})
+	require.Equal(t, 200, resp.StatusCode)
+	var created JSONCreateQueueResult
+	require.NoError(t, json.Unmarshal(resp.Body, &created))
+
+	for _, body := range []string{"one", "two"} {
+		resp = callSQS(t, service, "SendMessage", map[string]interface{}{"QueueUrl": created.QueueUrl, "MessageBody": body})
+		require.Equal(t, 200, resp.StatusCode)
+	}
+	received := receiveMessages(t, service, created.QueueUrl)
+	require.Len(t, received, 1)
+
+	resp = callSQS(t, service, "PurgeQueue", map[string]interface{}{"QueueUrl": created.QueueUrl})
+	require.Equal(t, 200, resp.StatusCode)
+
+	attrs := queueAttributes(t, service, created.QueueUrl)
+	assert.Equal(t, "0", attrs["ApproximateNumberOfMessages"])
+	assert.Equal(t, "0", attrs["ApproximateNumberOfMessagesNotVisible"])
+
+	// Deleting the purged message is a no-op
+	resp = callSQS(t, service, "DeleteMessage", map[string]interface{}{"QueueUrl": created.QueueUrl, "ReceiptHandle": received[0].ReceiptHandle})
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestDeleteMessage_StaleReceiptHandleIsNoOp(t *testing.T) {
+	service := newTestSQSService()
+
+	resp := callSQS(t, service, "CreateQueue", map[string]interface{}{"QueueName": "deletes"})
+	require.Equal(t, 200, resp.StatusCode)
+	var created JSONCreateQueueResult
+	require.NoError(t, json.Unmarshal(resp.Body, &created))
+
+	resp = callSQS(t, service, "SendMessage", map[string]interface{}{"QueueUrl": created.QueueUrl, "MessageBody": "once"})
+	require.Equal(t, 200, resp.StatusCode)
+	received := receiveMessages(t, service, created.QueueUrl)
+	require.Len(t, received, 1)
+
+	for i := 0; i < 2; i++ {
+		resp = callSQS(t, service, "DeleteMessage", map[string]interface{}{"QueueUrl": created.QueueUrl, "ReceiptHandle": received[0].ReceiptHandle})
+		assert.Equal(t, 200, resp.StatusCode, "delete %d", i+1)
+	}
+
+	resp = callSQS(t, service, "DeleteMessageBatch", map[string]interface{}{
+		"QueueUrl": created.QueueUrl,
+		"Entries":  []map[string]interface{}{{"Id": "stale", "ReceiptHandle": received[0].ReceiptHandle}},
+	})
+	require.Equal(t, 200, resp.StatusCode)
+	var batch JSONDeleteMessageBatchResult
+	require.NoError(t, json.Unmarshal(resp.Body, &batch))
+	assert.Len(t, batch.Successful, 1)
+	assert.Empty(t, batch.Failed)
+}
+
+func TestDeleteMessage_MalformedReceiptHandle(t *testing.T) {
+	service := newTestSQSService()
+
+	resp := callSQS(t, service, "CreateQueue", map[string]interface{}{"QueueName": "deletes"})
+	require.Equal(t, 200, resp.StatusCode)
+	var created JSONCreateQueueResult
+	require.NoError(t, json.Unmarshal(resp.Body, &created))
+
+	resp = callSQS(t, service, "DeleteMessage", map[string]interface{}{"QueueUrl": created.QueueUrl, "ReceiptHandle": "not-a-receipt-handle"})
+	assert.Equal(t, 400, resp.StatusCode)
+	assert.Equal(t, "ReceiptHandleIsInvalid", errorCode(t, resp))
+
+	resp = callSQS(t, service, "DeleteMessageBatch", map[string]interface{}{
+		"QueueUrl": created.QueueUrl,
+		"Entries":  []map[string]interface{}{{"Id": "bad", "ReceiptHandle": "not-a-receipt-handle"}},
+	})
+	require.Equal(t, 200, resp.StatusCode)
+	var batch JSONDeleteMessageBatchResult
+	require.NoError(t, json.Unmarshal(resp.Body, &batch))
+	assert.Empty(t, batch.Successful)
+	require.Len(t, batch.Failed, 1)
+	assert.Equal(t, "ReceiptHandleIsInvalid", batch.Failed[0].Code)
+}
+
+func TestMaxInFlightMessages_ReturnsOverLimit(t *testing.T) {
+	service := newTestSQSService()
+	service.SetMaxInFlightMessages(2)
+
+	resp := callSQS(t, service, "CreateQueue", map[string]interface{}{"QueueName": "limited"})
+	require.Equal(t, 200, resp.StatusCode)
+	var created JSONCreateQueueResult
+	require.NoError(t, json.Unmarshal(resp.Body, &created))
+
+	for _, body := range []string{"one", "two", "three"} {
+		resp = callSQS(t, service, "SendMessage", map[string]interface{}{"QueueUrl": created.QueueUrl, "MessageBody": body})
+		require.Equal(t, 200, resp.StatusCode)
+	}
+
+	// A receive is capped at the messages left before the limit
+	resp = callSQS(t, service, "ReceiveMessage", map[string]interface{}{"QueueUrl": created.QueueUrl, "MaxNumberOfMessages": 10})
+	require.Equal(t, 200, resp.StatusCode)
+	var received JSONReceiveMessageResult
+	require.NoError(t, json.Unmarshal(resp.Body, &received))
+	require.Len(t, received.Messages, 2)
+
+	resp = callSQS(t, service, "ReceiveMessage", map[string]interface{}{"QueueUrl": created.QueueUrl})
+	assert.Equal(t, 403, resp.StatusCode)
+	assert.Equal(t, "OverLimit", errorCode(t, resp))
+
+	resp = callSQS(t, service, "SendMessage", map[string]interface{}{"QueueUrl": created.QueueUrl, "MessageBody": "four"})
+	assert.Equal(t, 403, resp.StatusCode)
+	assert.Equal(t, "OverLimit", errorCode(t, resp))
+
+	resp = callSQS(t, service, "SendMessageBatch", map[string]interface{}{
+		"QueueUrl": created.QueueUrl,
+		"Entries":  []map[string]string{{"Id": "1", "MessageBody": "five"}},
+	})
+	assert.Equal(t, 403, resp.StatusCode)
+
+	// Deleting an in-flight message frees up room
+	resp = callSQS(t, service, "DeleteMessage", map[string]interface{}{"QueueUrl": created.QueueUrl, "ReceiptHandle": received.Messages[0].ReceiptHandle})
+	require.Equal(t, 200, resp.StatusCode)
+	assert.Len(t, receiveMessages(t, service, created.QueueUrl), 1)
+}
+
+func TestSendMessage_RejectsOversizedMessages(t *testing.T) {
+	service := newTestSQSService()
+
+	resp := callSQS(t, service, "CreateQueue", map[string]interface{}{
+		"QueueName":  "small",
+		"Attributes": map[string]string{"MaximumMessageSize": "1024"},
+	})
+	require.Equal(t, 200, resp.StatusCode)
+	var created JSONCreateQueueResult
+	require.NoError(t, json.Unmarshal(resp.Body, &created))
+
+	resp = callSQS(t, service, "SendMessage", map[string]interface{}{"QueueUrl": created.QueueUrl, "MessageBody": strings.Repeat("x", 1025)})
+	assert.Equal(t, 400, resp.StatusCode)
+	assert.Equal(t, "InvalidParameterValue", errorCode(t, resp))
+
+	resp = callSQS(t, service, "SendMessage", map[string]interface{}{"QueueUrl": created.QueueUrl, "MessageBody": strings.Repeat("x", 1024)})
+	assert.Equal(t, 200, resp.StatusCode)
+}
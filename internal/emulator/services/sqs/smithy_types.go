@@ -6,6 +6,24 @@
 
 package sqs
 
+// smithyResponseTypes maps operation names to constructors for their generated response types.
+var smithyResponseTypes = map[string]func() interface{}{
+	"CancelMessageMoveTask":        func() interface{} { return &CancelMessageMoveTaskResult{} },
+	"ChangeMessageVisibilityBatch": func() interface{} { return &ChangeMessageVisibilityBatchResult{} },
+	"CreateQueue":                  func() interface{} { return &CreateQueueResult{} },
+	"DeleteMessageBatch":           func() interface{} { return &DeleteMessageBatchResult{} },
+	"GetQueueAttributes":           func() interface{} { return &GetQueueAttributesResult{} },
+	"GetQueueUrl":                  func() interface{} { return &GetQueueUrlResult{} },
+	"ListDeadLetterSourceQueues":   func() interface{} { return &ListDeadLetterSourceQueuesResult{} },
+	"ListMessageMoveTasks":         func() interface{} { return &ListMessageMoveTasksResult{} },
+	"ListQueueTags":                func() interface{} { return &ListQueueTagsResult{} },
+	"ListQueues":                   func() interface{} { return &ListQueuesResult{} },
+	"ReceiveMessage":               func() interface{} { return &ReceiveMessageResult{} },
+	"SendMessage":                  func() interface{} { return &SendMessageResult{} },
+	"SendMessageBatch":             func() interface{} { return &SendMessageBatchResult{} },
+	"StartMessageMoveTask":         func() interface{} { return &StartMessageMoveTaskResult{} },
+}
+
 // Enum type aliases
 
 type MessageSystemAttributeName string
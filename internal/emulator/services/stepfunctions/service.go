@@ -0,0 +1,525 @@
+package stepfunctions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+)
+
+const (
+	defaultStateMachineType = "STANDARD"
+	defaultListLimit        = 100
+)
+
+// namePattern matches valid state machine and execution names
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,80}$`)
+
+// StepFunctionsService implements the AWS Step Functions emulator. State machines are stored
+// but never run: an execution succeeds as soon as it starts, with its input as its output.
+type StepFunctionsService struct {
+	state     emulator.StateManager
+	validator emulator.Validator
+}
+
+// NewStepFunctionsService creates a new Step Functions service instance
+func NewStepFunctionsService(state emulator.StateManager, validator emulator.Validator) *StepFunctionsService {
+	return &StepFunctionsService{
+		state:     state,
+		validator: validator,
+	}
+}
+
+// ServiceName returns the service identifier
+func (s *StepFunctionsService) ServiceName() string {
+	return "states"
+}
+
+// Protocol returns the AWS protocol the service speaks
+func (s *StepFunctionsService) Protocol() emulator.ProtocolType {
+	return emulator.ProtocolJSON
+}
+
+// HandleRequest routes incoming requests to the appropriate handler
+func (s *StepFunctionsService) HandleRequest(ctx context.Context, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	if err := s.validator.ValidateRequest(req); err != nil {
+		return s.errorResponse(400, "ValidationException", err.Error()), nil
+	}
+
+	action := s.extractAction(req)
+	if action == "" {
+		return s.errorResponse(400, "InvalidAction", "Missing or invalid action"), nil
+	}
+
+	switch action {
+	case "CreateStateMachine":
+		input, err := emulator.ParseJSONRequest[CreateStateMachineInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.createStateMachine(ctx, input)
+	case "DescribeStateMachine":
+		input, err := emulator.ParseJSONRequest[DescribeStateMachineInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.describeStateMachine(ctx, input)
+	case "DeleteStateMachine":
+		input, err := emulator.ParseJSONRequest[DeleteStateMachineInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.deleteStateMachine(ctx, input)
+	case "ListStateMachines":
+		input, err := emulator.ParseJSONRequest[ListStateMachinesInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.listStateMachines(ctx, input)
+	case "StartExecution":
+		input, err := emulator.ParseJSONRequest[StartExecutionInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.startExecution(ctx, input)
+	case "DescribeExecution":
+		input, err := emulator.ParseJSONRequest[DescribeExecutionInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.describeExecution(ctx, input)
+	case "ListTagsForResource":
+		input, err := emulator.ParseJSONRequest[ListTagsForResourceInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.listTagsForResource(ctx, input)
+	case "TagResource":
+		input, err := emulator.ParseJSONRequest[TagResourceInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.tagResource(ctx, input)
+	case "UntagResource":
+		input, err := emulator.ParseJSONRequest[UntagResourceInput](req.Body)
+		if err != nil {
+			return s.errorResponse(400, emulator.RequestErrorCode(err, "SerializationException"), err.Error()), nil
+		}
+		return s.untagResource(ctx, input)
+	default:
+		return s.errorResponse(400, "InvalidAction", fmt.Sprintf("Unknown action: %s", action)), nil
+	}
+}
+
+func (s *StepFunctionsService) extractAction(req *emulator.AWSRequest) string {
+	if req.Action != "" {
+		return req.Action
+	}
+
+	// Step Functions uses X-Amz-Target header: "AWSStepFunctions.CreateStateMachine"
+	target := req.Headers["X-Amz-Target"]
+	if target != "" {
+		parts := strings.Split(target, ".")
+		if len(parts) >= 2 {
+			return parts[len(parts)-1]
+		}
+	}
+
+	return ""
+}
+
+func (s *StepFunctionsService) createStateMachine(ctx context.Context, input *CreateStateMachineInput) (*emulator.AWSResponse, error) {
+	if input.Name == nil || !namePattern.MatchString(*input.Name) {
+		return s.errorResponse(400, "InvalidName", fmt.Sprintf("Invalid Name: '%s'", stringValue(input.Name))), nil
+	}
+	if input.Definition == nil || *input.Definition == "" {
+		return s.errorResponse(400, "ValidationException", "definition is required"), nil
+	}
+	if err := validateDefinition(*input.Definition); err != nil {
+		return s.errorResponse(400, "InvalidDefinition", err.Error()), nil
+	}
+	if input.RoleArn == nil || !strings.HasPrefix(*input.RoleArn, "arn:") {
+		return s.errorResponse(400, "InvalidArn", fmt.Sprintf("Invalid Arn: 'Resource type not valid in this context: %s'", stringValue(input.RoleArn))), nil
+	}
+	machineType := defaultStateMachineType
+	if input.Type != nil && *input.Type != "" {
+		machineType = *input.Type
+	}
+	if machineType != "STANDARD" && machineType != "EXPRESS" {
+		return s.errorResponse(400, "ValidationException", fmt.Sprintf("Value '%s' at 'type' failed to satisfy constraint: Member must satisfy enum value set: [STANDARD, EXPRESS]", machineType)), nil
+	}
+
+	stateKey := stateMachineStateKey(*input.Name)
+
+	// Creating an identical state machine again succeeds and returns the existing one
+	var existing StateMachine
+	if err := s.state.Get(stateKey, &existing); err == nil {
+		if existing.Definition != *input.Definition || existing.RoleArn != *input.RoleArn || existing.Type != machineType {
+			return s.errorResponse(400, "StateMachineAlreadyExists", fmt.Sprintf("State Machine Already Exists: '%s'", existing.Arn)), nil
+		}
+		return s.jsonResponse(200, map[string]interface{}{
+			"stateMachineArn": existing.Arn,
+			"creationDate":    epochSeconds(existing.CreationDate),
+		})
+	}
+
+	scope := emulator.RequestScopeFromContext(ctx)
+	machine := StateMachine{
+		Name:                 *input.Name,
+		Arn:                  fmt.Sprintf("arn:aws:states:%s:%s:stateMachine:%s", scope.Region, scope.AccountID, *input.Name),
+		Definition:           *input.Definition,
+		RoleArn:              *input.RoleArn,
+		Type:                 machineType,
+		RevisionId:           uuid.New().String(),
+		LoggingConfiguration: input.LoggingConfiguration,
+		TracingConfiguration: input.TracingConfiguration,
+		CreationDate:         time.Now().UTC(),
+		Tags:                 map[string]string{},
+	}
+	for _, tag := range input.Tags {
+		if tag.Key != nil {
+			machine.Tags[*tag.Key] = stringValue(tag.Value)
+		}
+	}
+
+	if err := s.state.Set(stateKey, &machine); err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to store state machine"), nil
+	}
+
+	return s.jsonResponse(200, map[string]interface{}{
+		"stateMachineArn": machine.Arn,
+		"creationDate":    epochSeconds(machine.CreationDate),
+	})
+}
+
+func (s *StepFunctionsService) describeStateMachine(ctx context.Context, input *DescribeStateMachineInput) (*emulator.AWSResponse, error) {
+	machine, errResp := s.loadStateMachine(input.StateMachineArn)
+	if errResp != nil {
+		return errResp, nil
+	}
+
+	loggingConfiguration := machine.LoggingConfiguration
+	if loggingConfiguration == nil {
+		loggingConfiguration = map[string]interface{}{"level": "OFF", "includeExecutionData": false}
+	}
+	tracingConfiguration := machine.TracingConfiguration
+	if tracingConfiguration == nil {
+		tracingConfiguration = map[string]interface{}{"enabled": false}
+	}
+
+	return s.jsonResponse(200, map[string]interface{}{
+		"stateMachineArn":      machine.Arn,
+		"name":                 machine.Name,
+		"status":               "ACTIVE",
+		"definition":           machine.Definition,
+		"roleArn":              machine.RoleArn,
+		"type":                 machine.Type,
+		"creationDate":         epochSeconds(machine.CreationDate),
+		"revisionId":           machine.RevisionId,
+		"loggingConfiguration": loggingConfiguration,
+		"tracingConfiguration": tracingConfiguration,
+	})
+}
+
+func (s *StepFunctionsService) deleteStateMachine(ctx context.Context, input *DeleteStateMachineInput) (*emulator.AWSResponse, error) {
+	name, errResp := s.stateMachineName(input.StateMachineArn)
+	if errResp != nil {
+		return errResp, nil
+	}
+
+	// Deleting a state machine that doesn't exist succeeds
+	if !s.state.Exists(stateMachineStateKey(name)) {
+		return s.jsonResponse(200, map[string]interface{}{})
+	}
+
+	executions, err := s.state.List(fmt.Sprintf("states:execution:%s:", name))
+	if err == nil {
+		for _, key := range executions {
+			_ = s.state.Delete(key)
+		}
+	}
+	if err := s.state.Delete(stateMachineStateKey(name)); err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to delete state machine"), nil
+	}
+
+	return s.jsonResponse(200, map[string]interface{}{})
+}
+
+func (s *StepFunctionsService) listStateMachines(ctx context.Context, input *ListStateMachinesInput) (*emulator.AWSResponse, error) {
+	keys, err := s.state.List("states:statemachine:")
+	if err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to list state machines"), nil
+	}
+
+	machines := make([]StateMachine, 0, len(keys))
+	for _, key := range keys {
+		var machine StateMachine
+		if err := s.state.Get(key, &machine); err == nil {
+			machines = append(machines, machine)
+		}
+	}
+	sort.Slice(machines, func(i, j int) bool { return machines[i].Name < machines[j].Name })
+
+	// The token holds the name of the first state machine on the next page
+	start := 0
+	if input.NextToken != nil && *input.NextToken != "" {
+		position, err := emulator.DecodePageToken("states:ListStateMachines", *input.NextToken)
+		if err != nil {
+			return s.errorResponse(400, "InvalidToken", "Invalid Token: the nextToken is malformed or has expired"), nil
+		}
+		start = sort.Search(len(machines), func(i int) bool { return machines[i].Name >= position })
+	}
+	pageSize := defaultListLimit
+	if input.MaxResults != nil && *input.MaxResults > 0 {
+		pageSize = int(*input.MaxResults)
+	}
+	end := start + pageSize
+	if end > len(machines) {
+		end = len(machines)
+	}
+
+	result := make([]interface{}, 0, end-start)
+	for _, machine := range machines[start:end] {
+		result = append(result, map[string]interface{}{
+			"stateMachineArn": machine.Arn,
+			"name":            machine.Name,
+			"type":            machine.Type,
+			"creationDate":    epochSeconds(machine.CreationDate),
+		})
+	}
+
+	response := map[string]interface{}{
+		"stateMachines": result,
+	}
+	if end < len(machines) {
+		response["nextToken"] = emulator.EncodePageToken("states:ListStateMachines", machines[end].Name)
+	}
+	return s.jsonResponse(200, response)
+}
+
+func (s *StepFunctionsService) startExecution(ctx context.Context, input *StartExecutionInput) (*emulator.AWSResponse, error) {
+	machine, errResp := s.loadStateMachine(input.StateMachineArn)
+	if errResp != nil {
+		return errResp, nil
+	}
+
+	name := uuid.New().String()
+	if input.Name != nil {
+		if !namePattern.MatchString(*input.Name) {
+			return s.errorResponse(400, "InvalidName", fmt.Sprintf("Invalid Name: '%s'", *input.Name)), nil
+		}
+		name = *input.Name
+	}
+	executionInput := "{}"
+	if input.Input != nil {
+		executionInput = *input.Input
+	}
+	if !json.Valid([]byte(executionInput)) {
+		return s.errorResponse(400, "InvalidExecutionInput", "Invalid State Machine Execution Input: 'Unexpected input'"), nil
+	}
+
+	// Starting an execution again with the same name and input returns the existing one
+	stateKey := executionStateKey(machine.Name, name)
+	var existing Execution
+	if err := s.state.Get(stateKey, &existing); err == nil {
+		if existing.Input != executionInput {
+			return s.errorResponse(400, "ExecutionAlreadyExists", fmt.Sprintf("Execution Already Exists: '%s'", existing.Arn)), nil
+		}
+		return s.jsonResponse(200, map[string]interface{}{
+			"executionArn": existing.Arn,
+			"startDate":    epochSeconds(existing.StartDate),
+		})
+	}
+
+	scope := emulator.RequestScopeFromContext(ctx)
+	now := time.Now().UTC()
+	execution := Execution{
+		Name:            name,
+		Arn:             fmt.Sprintf("arn:aws:states:%s:%s:execution:%s:%s", scope.Region, scope.AccountID, machine.Name, name),
+		StateMachineArn: machine.Arn,
+		Status:          "SUCCEEDED",
+		Input:           executionInput,
+		Output:          executionInput,
+		StartDate:       now,
+		StopDate:        now,
+	}
+	if err := s.state.Set(stateKey, &execution); err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to store execution"), nil
+	}
+
+	return s.jsonResponse(200, map[string]interface{}{
+		"executionArn": execution.Arn,
+		"startDate":    epochSeconds(execution.StartDate),
+	})
+}
+
+func (s *StepFunctionsService) describeExecution(ctx context.Context, input *DescribeExecutionInput) (*emulator.AWSResponse, error) {
+	// arn:aws:states:{region}:{account}:execution:{stateMachine}:{name}
+	arn := stringValue(input.ExecutionArn)
+	_, resource, ok := strings.Cut(arn, ":execution:")
+	machineName, name, hasName := strings.Cut(resource, ":")
+	if !ok || !hasName {
+		return s.errorResponse(400, "InvalidArn", fmt.Sprintf("Invalid Arn: 'Resource type not valid in this context: %s'", arn)), nil
+	}
+
+	var execution Execution
+	if err := s.state.Get(executionStateKey(machineName, name), &execution); err != nil || execution.Arn != arn {
+		return s.errorResponse(400, "ExecutionDoesNotExist", fmt.Sprintf("Execution Does Not Exist: '%s'", arn)), nil
+	}
+
+	return s.jsonResponse(200, map[string]interface{}{
+		"executionArn":    execution.Arn,
+		"stateMachineArn": execution.StateMachineArn,
+		"name":            execution.Name,
+		"status":          execution.Status,
+		"startDate":       epochSeconds(execution.StartDate),
+		"stopDate":        epochSeconds(execution.StopDate),
+		"input":           execution.Input,
+		"inputDetails":    map[string]interface{}{"included": true},
+		"output":          execution.Output,
+		"outputDetails":   map[string]interface{}{"included": true},
+	})
+}
+
+func (s *StepFunctionsService) listTagsForResource(ctx context.Context, input *ListTagsForResourceInput) (*emulator.AWSResponse, error) {
+	machine, errResp := s.loadStateMachine(input.ResourceArn)
+	if errResp != nil {
+		return errResp, nil
+	}
+
+	keys := make([]string, 0, len(machine.Tags))
+	for key := range machine.Tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	tags := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		tags = append(tags, map[string]string{"key": key, "value": machine.Tags[key]})
+	}
+
+	return s.jsonResponse(200, map[string]interface{}{
+		"tags": tags,
+	})
+}
+
+func (s *StepFunctionsService) tagResource(ctx context.Context, input *TagResourceInput) (*emulator.AWSResponse, error) {
+	machine, errResp := s.loadStateMachine(input.ResourceArn)
+	if errResp != nil {
+		return errResp, nil
+	}
+
+	if machine.Tags == nil {
+		machine.Tags = map[string]string{}
+	}
+	for _, tag := range input.Tags {
+		if tag.Key != nil {
+			machine.Tags[*tag.Key] = stringValue(tag.Value)
+		}
+	}
+	if err := s.state.Set(stateMachineStateKey(machine.Name), machine); err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to store state machine"), nil
+	}
+
+	return s.jsonResponse(200, map[string]interface{}{})
+}
+
+func (s *StepFunctionsService) untagResource(ctx context.Context, input *UntagResourceInput) (*emulator.AWSResponse, error) {
+	machine, errResp := s.loadStateMachine(input.ResourceArn)
+	if errResp != nil {
+		return errResp, nil
+	}
+
+	for _, key := range input.TagKeys {
+		delete(machine.Tags, key)
+	}
+	if err := s.state.Set(stateMachineStateKey(machine.Name), machine); err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to store state machine"), nil
+	}
+
+	return s.jsonResponse(200, map[string]interface{}{})
+}
+
+// validateDefinition checks that a definition is an Amazon States Language document whose
+// StartAt state exists. The states themselves aren't validated.
+func validateDefinition(definition string) error {
+	var document struct {
+		StartAt string                     `json:"StartAt"`
+		States  map[string]json.RawMessage `json:"States"`
+	}
+	if err := json.Unmarshal([]byte(definition), &document); err != nil {
+		return fmt.Errorf("Invalid State Machine Definition: 'INVALID_JSON_DESCRIPTION: %s'", err.Error())
+	}
+	if document.StartAt == "" {
+		return fmt.Errorf("Invalid State Machine Definition: 'SCHEMA_VALIDATION_FAILED: The field 'StartAt' is required at /'")
+	}
+	if _, ok := document.States[document.StartAt]; !ok {
+		return fmt.Errorf("Invalid State Machine Definition: 'MISSING_TRANSITION_TARGET: Missing 'Next' target: %s at /StartAt'", document.StartAt)
+	}
+	return nil
+}
+
+// stateMachineName extracts the state machine name from its ARN, or returns an InvalidArn response
+func (s *StepFunctionsService) stateMachineName(arn *string) (string, *emulator.AWSResponse) {
+	// arn:aws:states:{region}:{account}:stateMachine:{name}
+	_, name, ok := strings.Cut(stringValue(arn), ":stateMachine:")
+	if !ok || name == "" {
+		return "", s.errorResponse(400, "InvalidArn", fmt.Sprintf("Invalid Arn: 'Resource type not valid in this context: %s'", stringValue(arn)))
+	}
+	return name, nil
+}
+
+// loadStateMachine reads the state machine with the ARN, or returns a StateMachineDoesNotExist response
+func (s *StepFunctionsService) loadStateMachine(arn *string) (*StateMachine, *emulator.AWSResponse) {
+	name, errResp := s.stateMachineName(arn)
+	if errResp != nil {
+		return nil, errResp
+	}
+
+	var machine StateMachine
+	if err := s.state.Get(stateMachineStateKey(name), &machine); err != nil || machine.Arn != *arn {
+		return nil, s.errorResponse(400, "StateMachineDoesNotExist", fmt.Sprintf("State Machine Does Not Exist: '%s'", *arn))
+	}
+	return &machine, nil
+}
+
+func stateMachineStateKey(name string) string {
+	return fmt.Sprintf("states:statemachine:%s", name)
+}
+
+func executionStateKey(stateMachineName, name string) string {
+	return fmt.Sprintf("states:execution:%s:%s", stateMachineName, name)
+}
+
+// epochSeconds formats a timestamp the way the JSON protocol expects
+func epochSeconds(t time.Time) float64 {
+	return float64(t.UnixMilli()) / 1000
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func (s *StepFunctionsService) jsonResponse(statusCode int, data interface{}) (*emulator.AWSResponse, error) {
+	resp, err := emulator.BuildJSONResponse(statusCode, data)
+	if err != nil {
+		return s.errorResponse(500, "InternalFailure", "Failed to marshal response"), nil
+	}
+	return resp, nil
+}
+
+func (s *StepFunctionsService) errorResponse(statusCode int, code, message string) *emulator.AWSResponse {
+	return emulator.BuildJSONErrorResponse(statusCode, code, message)
+}
+
+var (
+	_ emulator.Service = (*StepFunctionsService)(nil)
+)
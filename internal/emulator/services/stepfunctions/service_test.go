@@ -0,0 +1,131 @@
+package stepfunctions
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robmorgan/infraspec/internal/emulator/core"
+)
+
+const testDefinition = `{"StartAt": "Done", "States": {"Done": {"Type": "Succeed"}}}`
+
+// call sends a JSON request for action and decodes the response body
+func call(t *testing.T, service emulator.Service, action string, input interface{}) (int, map[string]interface{}) {
+	t.Helper()
+	body, err := json.Marshal(input)
+	require.NoError(t, err)
+	resp, err := service.HandleRequest(context.Background(), &emulator.AWSRequest{
+		Method: "POST",
+		Action: action,
+		Headers: map[string]string{
+			"Content-Type": "application/x-amz-json-1.0",
+			"X-Amz-Target": "AWSStepFunctions." + action,
+		},
+		Body: body,
+	})
+	require.NoError(t, err)
+	var out map[string]interface{}
+	require.NoError(t, json.Unmarshal(resp.Body, &out))
+	return resp.StatusCode, out
+}
+
+func TestStateMachineLifecycle(t *testing.T) {
+	service := NewStepFunctionsService(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	create := map[string]interface{}{
+		"name":       "orders",
+		"definition": testDefinition,
+		"roleArn":    "arn:aws:iam::123456789012:role/sfn",
+		"tags":       []map[string]string{{"key": "team", "value": "payments"}},
+	}
+
+	status, out := call(t, service, "CreateStateMachine", create)
+	require.Equal(t, 200, status, out)
+	arn := out["stateMachineArn"]
+	assert.Equal(t, "arn:aws:states:us-east-1:123456789012:stateMachine:orders", arn)
+
+	// Creating the same state machine again returns it, but a different one conflicts
+	status, out = call(t, service, "CreateStateMachine", create)
+	require.Equal(t, 200, status, out)
+	assert.Equal(t, arn, out["stateMachineArn"])
+	create["roleArn"] = "arn:aws:iam::123456789012:role/other"
+	status, out = call(t, service, "CreateStateMachine", create)
+	assert.Equal(t, 400, status)
+	assert.Equal(t, "StateMachineAlreadyExists", out["__type"])
+
+	status, out = call(t, service, "DescribeStateMachine", map[string]interface{}{"stateMachineArn": arn})
+	require.Equal(t, 200, status, out)
+	assert.Equal(t, "ACTIVE", out["status"])
+	assert.Equal(t, "STANDARD", out["type"])
+	assert.Equal(t, "arn:aws:iam::123456789012:role/sfn", out["roleArn"])
+	assert.Equal(t, testDefinition, out["definition"])
+
+	status, out = call(t, service, "ListTagsForResource", map[string]interface{}{"resourceArn": arn})
+	require.Equal(t, 200, status, out)
+	assert.Equal(t, []interface{}{map[string]interface{}{"key": "team", "value": "payments"}}, out["tags"])
+
+	status, out = call(t, service, "ListStateMachines", map[string]interface{}{})
+	require.Equal(t, 200, status, out)
+	assert.Len(t, out["stateMachines"], 1)
+
+	status, out = call(t, service, "DeleteStateMachine", map[string]interface{}{"stateMachineArn": arn})
+	require.Equal(t, 200, status, out)
+	status, out = call(t, service, "DescribeStateMachine", map[string]interface{}{"stateMachineArn": arn})
+	assert.Equal(t, 400, status)
+	assert.Equal(t, "StateMachineDoesNotExist", out["__type"])
+}
+
+func TestCreateStateMachine_InvalidDefinition(t *testing.T) {
+	service := NewStepFunctionsService(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+
+	for _, definition := range []string{`not json`, `{"States": {}}`, `{"StartAt": "Missing", "States": {"Done": {"Type": "Succeed"}}}`} {
+		status, out := call(t, service, "CreateStateMachine", map[string]interface{}{
+			"name":       "bad",
+			"definition": definition,
+			"roleArn":    "arn:aws:iam::123456789012:role/sfn",
+		})
+		assert.Equal(t, 400, status, definition)
+		assert.Equal(t, "InvalidDefinition", out["__type"], definition)
+	}
+}
+
+func TestExecutionSucceedsWithInputAsOutput(t *testing.T) {
+	service := NewStepFunctionsService(emulator.NewMemoryStateManager(), emulator.NewSchemaValidator())
+	_, out := call(t, service, "CreateStateMachine", map[string]interface{}{
+		"name":       "orders",
+		"definition": testDefinition,
+		"roleArn":    "arn:aws:iam::123456789012:role/sfn",
+	})
+	machineArn := out["stateMachineArn"]
+
+	status, out := call(t, service, "StartExecution", map[string]interface{}{
+		"stateMachineArn": machineArn,
+		"name":            "run-1",
+		"input":           `{"orderId": 42}`,
+	})
+	require.Equal(t, 200, status, out)
+	executionArn := out["executionArn"]
+	assert.Equal(t, "arn:aws:states:us-east-1:123456789012:execution:orders:run-1", executionArn)
+
+	status, out = call(t, service, "DescribeExecution", map[string]interface{}{"executionArn": executionArn})
+	require.Equal(t, 200, status, out)
+	assert.Equal(t, "SUCCEEDED", out["status"])
+	assert.Equal(t, machineArn, out["stateMachineArn"])
+	assert.JSONEq(t, `{"orderId": 42}`, out["output"].(string))
+
+	// Reusing the name with different input conflicts
+	status, out = call(t, service, "StartExecution", map[string]interface{}{
+		"stateMachineArn": machineArn,
+		"name":            "run-1",
+		"input":           `{"orderId": 43}`,
+	})
+	assert.Equal(t, 400, status)
+	assert.Equal(t, "ExecutionAlreadyExists", out["__type"])
+
+	status, out = call(t, service, "DescribeExecution", map[string]interface{}{"executionArn": "arn:aws:states:us-east-1:123456789012:execution:orders:missing"})
+	assert.Equal(t, 400, status)
+	assert.Equal(t, "ExecutionDoesNotExist", out["__type"])
+}
@@ -0,0 +1,90 @@
+package stepfunctions
+
+import "time"
+
+// ============================================================================
+// Internal Storage Types
+// ============================================================================
+
+// StateMachine is a Step Functions state machine stored in state
+type StateMachine struct {
+	Name                 string                 `json:"name"`
+	Arn                  string                 `json:"arn"`
+	Definition           string                 `json:"definition"`
+	RoleArn              string                 `json:"roleArn"`
+	Type                 string                 `json:"type"`
+	RevisionId           string                 `json:"revisionId"`
+	LoggingConfiguration map[string]interface{} `json:"loggingConfiguration,omitempty"`
+	TracingConfiguration map[string]interface{} `json:"tracingConfiguration,omitempty"`
+	CreationDate         time.Time              `json:"creationDate"`
+	Tags                 map[string]string      `json:"tags,omitempty"`
+}
+
+// Execution is a state machine execution stored in state. Executions are stubs that succeed
+// as soon as they start, with their input as their output.
+type Execution struct {
+	Name            string    `json:"name"`
+	Arn             string    `json:"arn"`
+	StateMachineArn string    `json:"stateMachineArn"`
+	Status          string    `json:"status"`
+	Input           string    `json:"input"`
+	Output          string    `json:"output"`
+	StartDate       time.Time `json:"startDate"`
+	StopDate        time.Time `json:"stopDate"`
+}
+
+// ============================================================================
+// Request Types
+// ============================================================================
+
+type Tag struct {
+	Key   *string `json:"key,omitempty"`
+	Value *string `json:"value,omitempty"`
+}
+
+type CreateStateMachineInput struct {
+	Name                 *string                `json:"name,omitempty"`
+	Definition           *string                `json:"definition,omitempty"`
+	RoleArn              *string                `json:"roleArn,omitempty"`
+	Type                 *string                `json:"type,omitempty"`
+	LoggingConfiguration map[string]interface{} `json:"loggingConfiguration,omitempty"`
+	TracingConfiguration map[string]interface{} `json:"tracingConfiguration,omitempty"`
+	Tags                 []Tag                  `json:"tags,omitempty"`
+}
+
+type DescribeStateMachineInput struct {
+	StateMachineArn *string `json:"stateMachineArn,omitempty"`
+}
+
+type DeleteStateMachineInput struct {
+	StateMachineArn *string `json:"stateMachineArn,omitempty"`
+}
+
+type ListStateMachinesInput struct {
+	MaxResults *int32  `json:"maxResults,omitempty"`
+	NextToken  *string `json:"nextToken,omitempty"`
+}
+
+type StartExecutionInput struct {
+	StateMachineArn *string `json:"stateMachineArn,omitempty"`
+	Name            *string `json:"name,omitempty"`
+	Input           *string `json:"input,omitempty"`
+}
+
+type DescribeExecutionInput struct {
+	ExecutionArn *string `json:"executionArn,omitempty"`
+}
+
+type ListTagsForResourceInput struct {
+	ResourceArn *string `json:"resourceArn,omitempty"`
+}
+
+type TagResourceInput struct {
+	ResourceArn *string `json:"resourceArn,omitempty"`
+	Tags        []Tag   `json:"tags,omitempty"`
+}
+
+type UntagResourceInput struct {
+	ResourceArn *string  `json:"resourceArn,omitempty"`
+	TagKeys     []string `json:"tagKeys,omitempty"`
+}
@@ -27,6 +27,16 @@ func (s *StsService) ServiceName() string {
 	return "sts"
 }
 
+// Protocol returns the AWS protocol the service speaks
+func (s *StsService) Protocol() emulator.ProtocolType {
+	return emulator.ProtocolQuery
+}
+
+// ResponseTypes returns constructors for the CloudMirror-generated response types, keyed by action.
+func (s *StsService) ResponseTypes() map[string]func() interface{} {
+	return smithyResponseTypes
+}
+
 // SupportedActions returns the list of AWS API actions this service handles.
 // Used by the router to determine which service handles a given Query Protocol request.
 func (s *StsService) SupportedActions() []string {
@@ -191,9 +201,10 @@ func (s *StsService) getCallerIdentity(ctx context.Context, params map[string]in
 	// This is used by Terraform and AWS SDK to validate credentials
 
 	// Create a mock caller identity response
-	accountID := "123456789012"                  // Mock AWS account ID
+	scope := emulator.RequestScopeFromContext(ctx)
+	accountID := scope.AccountID                 // Account selected by the request's credentials
 	userID := "AIDAI" + uuid.New().String()[:13] // Mock user ID
-	arn := fmt.Sprintf("arn:aws:iam::%s:user/infraspec-emulator", accountID)
+	arn := fmt.Sprintf("arn:%s:iam::%s:user/infraspec-emulator", scope.Partition(), accountID)
 
 	return s.successResponse("GetCallerIdentity", GetCallerIdentityResponse{
 		UserId:  &userID,
@@ -273,3 +284,9 @@ func getBoolParam(params map[string]interface{}, key string, defaultValue bool)
 	}
 	return &defaultValue
 }
+
+var (
+	_ emulator.Service              = (*StsService)(nil)
+	_ emulator.ActionProvider       = (*StsService)(nil)
+	_ emulator.ResponseTypeProvider = (*StsService)(nil)
+)
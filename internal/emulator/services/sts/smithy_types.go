@@ -11,6 +11,21 @@ import (
 	"time"
 )
 
+// smithyResponseTypes maps operation names to constructors for their generated response types.
+var smithyResponseTypes = map[string]func() interface{}{
+	"AssumeRole":                 func() interface{} { return &AssumeRoleResponse{} },
+	"AssumeRoleWithSAML":         func() interface{} { return &AssumeRoleWithSAMLResponse{} },
+	"AssumeRoleWithWebIdentity":  func() interface{} { return &AssumeRoleWithWebIdentityResponse{} },
+	"AssumeRoot":                 func() interface{} { return &AssumeRootResponse{} },
+	"DecodeAuthorizationMessage": func() interface{} { return &DecodeAuthorizationMessageResponse{} },
+	"GetAccessKeyInfo":           func() interface{} { return &GetAccessKeyInfoResponse{} },
+	"GetCallerIdentity":          func() interface{} { return &GetCallerIdentityResponse{} },
+	"GetDelegatedAccessToken":    func() interface{} { return &GetDelegatedAccessTokenResponse{} },
+	"GetFederationToken":         func() interface{} { return &GetFederationTokenResponse{} },
+	"GetSessionToken":            func() interface{} { return &GetSessionTokenResponse{} },
+	"GetWebIdentityToken":        func() interface{} { return &GetWebIdentityTokenResponse{} },
+}
+
 // AssumeRoleResponse Contains the response to a successful AssumeRole request, including temporary Amazon Web Services...
 type AssumeRoleResponse struct {
 	XMLName          xml.Name         `xml:"AssumeRoleResult"`
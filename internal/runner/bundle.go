@@ -0,0 +1,155 @@
+package runner
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IsFeatureBundle reports whether path names a feature bundle: a .tar.gz, .tgz or .zip
+// archive of a feature suite.
+func IsFeatureBundle(path string) bool {
+	lower := strings.ToLower(path)
+	return strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".tgz") || strings.HasSuffix(lower, ".zip")
+}
+
+// ExtractFeatureBundle extracts the feature bundle at path into a new temporary directory and
+// returns that directory, along with a function that removes it. Every file in the bundle is
+// extracted, so features can refer to the files packaged alongside them. It's an error for the
+// bundle to contain no .feature files.
+func ExtractFeatureBundle(path string) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "infraspec-bundle-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create a directory for bundle %s: %w", path, err)
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	if strings.HasSuffix(strings.ToLower(path), ".zip") {
+		err = extractZip(path, dir)
+	} else {
+		err = extractTarGz(path, dir)
+	}
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to extract bundle %s: %w", path, err)
+	}
+
+	if _, err := DiscoverFeatureFiles(dir); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("no .feature files found in bundle: %s", path)
+	}
+	return dir, cleanup, nil
+}
+
+func extractTarGz(path, dir string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// Links and special files are skipped, only directories and regular files are extracted
+		switch header.Typeflag {
+		case tar.TypeDir:
+			target, err := bundleEntryPath(dir, header.Name)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeBundleEntry(dir, header.Name, tr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func extractZip(path, dir string) error {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	for _, file := range zr.File {
+		if file.FileInfo().IsDir() {
+			target, err := bundleEntryPath(dir, file.Name)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+			continue
+		}
+		if !file.Mode().IsRegular() {
+			continue
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			return err
+		}
+		err = writeBundleEntry(dir, file.Name, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeBundleEntry writes the contents of the named bundle entry below dir
+func writeBundleEntry(dir, name string, r io.Reader) error {
+	target, err := bundleEntryPath(dir, name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// bundleEntryPath returns where the named bundle entry is extracted to, rejecting names that
+// would escape dir
+func bundleEntryPath(dir, name string) (string, error) {
+	target := filepath.Join(dir, filepath.FromSlash(name))
+	if target != dir && !strings.HasPrefix(target, dir+string(os.PathSeparator)) {
+		return "", fmt.Errorf("bundle entry %s is outside the bundle", name)
+	}
+	return target, nil
+}
@@ -0,0 +1,102 @@
+package runner
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTarGz(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+}
+
+func writeZip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+}
+
+func TestIsFeatureBundle(t *testing.T) {
+	assert.True(t, IsFeatureBundle("suite.tar.gz"))
+	assert.True(t, IsFeatureBundle("suite.TGZ"))
+	assert.True(t, IsFeatureBundle("suite.zip"))
+	assert.False(t, IsFeatureBundle("suite.feature"))
+	assert.False(t, IsFeatureBundle("features"))
+}
+
+func TestExtractFeatureBundle(t *testing.T) {
+	files := map[string]string{
+		"features/s3.feature":       "Feature: S3",
+		"features/iam/role.feature": "Feature: IAM",
+		"fixtures/policy.json":      "{}",
+	}
+
+	for _, name := range []string{"suite.tar.gz", "suite.zip"} {
+		t.Run(name, func(t *testing.T) {
+			bundle := filepath.Join(t.TempDir(), name)
+			if name == "suite.zip" {
+				writeZip(t, bundle, files)
+			} else {
+				writeTarGz(t, bundle, files)
+			}
+
+			dir, cleanup, err := ExtractFeatureBundle(bundle)
+			require.NoError(t, err)
+
+			features, err := DiscoverFeatureFiles(dir)
+			require.NoError(t, err)
+			assert.Len(t, features, 2)
+			assert.FileExists(t, filepath.Join(dir, "fixtures", "policy.json"))
+
+			cleanup()
+			assert.NoDirExists(t, dir)
+		})
+	}
+}
+
+func TestExtractFeatureBundle_NoFeatures(t *testing.T) {
+	bundle := filepath.Join(t.TempDir(), "suite.tar.gz")
+	writeTarGz(t, bundle, map[string]string{"README.md": "# Suite"})
+
+	_, _, err := ExtractFeatureBundle(bundle)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no .feature files found in bundle")
+}
+
+func TestExtractFeatureBundle_RejectsEntriesOutsideTheBundle(t *testing.T) {
+	bundle := filepath.Join(t.TempDir(), "suite.zip")
+	writeZip(t, bundle, map[string]string{"../escape.feature": "Feature: Escape"})
+
+	_, _, err := ExtractFeatureBundle(bundle)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "outside the bundle")
+}
@@ -0,0 +1,105 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/cucumber/godog"
+
+	"github.com/robmorgan/infraspec/internal/contexthelpers"
+	"github.com/robmorgan/infraspec/pkg/iacprovisioner"
+)
+
+// referencePattern matches a ${...} reference in a step argument
+var referencePattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// interpolateStep replaces the ${...} references in the step's text, doc string and table
+// cells before the step is matched and run.
+func interpolateStep(ctx context.Context, st *godog.Step) error {
+	resolve := func(ref string) (string, error) {
+		return resolveReference(ctx, ref)
+	}
+
+	text, err := interpolate(st.Text, resolve)
+	if err != nil {
+		return err
+	}
+	st.Text = text
+
+	if st.Argument == nil {
+		return nil
+	}
+	if doc := st.Argument.DocString; doc != nil {
+		if doc.Content, err = interpolate(doc.Content, resolve); err != nil {
+			return err
+		}
+	}
+	if table := st.Argument.DataTable; table != nil {
+		for _, row := range table.Rows {
+			for _, cell := range row.Cells {
+				if cell.Value, err = interpolate(cell.Value, resolve); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// interpolate replaces each ${...} reference in s with its resolved value
+func interpolate(s string, resolve func(ref string) (string, error)) (string, error) {
+	var err error
+	result := referencePattern.ReplaceAllStringFunc(s, func(match string) string {
+		if err != nil {
+			return match
+		}
+		var value string
+		value, err = resolve(referencePattern.FindStringSubmatch(match)[1])
+		return value
+	})
+	if err != nil {
+		return s, err
+	}
+	return result, nil
+}
+
+// resolveReference returns the value of an "env:NAME" environment variable, an "output:name"
+// Terraform output, or a "var:name" scenario variable. A reference without a prefix is a
+// scenario variable.
+func resolveReference(ctx context.Context, ref string) (string, error) {
+	kind, name, found := strings.Cut(ref, ":")
+	if !found {
+		kind, name = "var", ref
+	}
+
+	switch kind {
+	case "env":
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return value, nil
+	case "output":
+		options := contexthelpers.GetIacProvisionerOptions(ctx)
+		if options == nil {
+			return "", fmt.Errorf("no Terraform configuration to read output %s from", name)
+		}
+		value, err := iacprovisioner.Output(options, name)
+		if err != nil {
+			return "", fmt.Errorf("failed to get output %s: %w", name, err)
+		}
+		return value, nil
+	case "var":
+		if store := contexthelpers.GetScenarioStore(ctx); store != nil {
+			if value, ok := store.Get(name); ok {
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("undefined variable %s", name)
+	default:
+		return "", fmt.Errorf("unknown reference ${%s}, expected ${env:NAME}, ${output:name} or ${var:name}", ref)
+	}
+}
@@ -0,0 +1,71 @@
+package runner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cucumber/godog"
+	messages "github.com/cucumber/messages/go/v21"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robmorgan/infraspec/internal/contexthelpers"
+)
+
+func TestResolveReference(t *testing.T) {
+	store := contexthelpers.NewScenarioStore()
+	store.Set("vpc_cidr", "10.0.0.0/16")
+	ctx := context.WithValue(context.Background(), contexthelpers.ScenarioStoreCtxKey{}, store)
+	t.Setenv("INFRASPEC_TEST_ACCOUNT", "123456789012")
+
+	tests := []struct {
+		ref     string
+		want    string
+		wantErr string
+	}{
+		{ref: "vpc_cidr", want: "10.0.0.0/16"},
+		{ref: "var:vpc_cidr", want: "10.0.0.0/16"},
+		{ref: "env:INFRASPEC_TEST_ACCOUNT", want: "123456789012"},
+		{ref: "missing", wantErr: "undefined variable missing"},
+		{ref: "env:INFRASPEC_TEST_UNSET", wantErr: "environment variable INFRASPEC_TEST_UNSET is not set"},
+		{ref: "output:bucket_name", wantErr: "no Terraform configuration to read output bucket_name from"},
+		{ref: "secret:token", wantErr: "unknown reference ${secret:token}, expected ${env:NAME}, ${output:name} or ${var:name}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := resolveReference(ctx, tt.ref)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInterpolateStep(t *testing.T) {
+	store := contexthelpers.NewScenarioStore()
+	store.Set("team", "payments")
+	store.Set("vpc_cidr", "10.0.0.0/16")
+	ctx := context.WithValue(context.Background(), contexthelpers.ScenarioStoreCtxKey{}, store)
+	t.Setenv("INFRASPEC_TEST_REGION", "eu-west-1")
+
+	st := &godog.Step{
+		Text: `the VPC "vpc-1" in ${env:INFRASPEC_TEST_REGION} CIDR block should be "${vpc_cidr}"`,
+		Argument: &messages.PickleStepArgument{
+			DataTable: &messages.PickleTable{Rows: []*messages.PickleTableRow{
+				{Cells: []*messages.PickleTableCell{{Value: "Key"}, {Value: "Value"}}},
+				{Cells: []*messages.PickleTableCell{{Value: "Team"}, {Value: "${var:team}"}}},
+			}},
+		},
+	}
+	require.NoError(t, interpolateStep(ctx, st))
+	assert.Equal(t, `the VPC "vpc-1" in eu-west-1 CIDR block should be "10.0.0.0/16"`, st.Text)
+	assert.Equal(t, "payments", st.Argument.DataTable.Rows[1].Cells[1].Value)
+
+	st = &godog.Step{Text: `the output "team" should equal "${unknown}"`}
+	assert.EqualError(t, interpolateStep(ctx, st), "undefined variable unknown")
+	assert.Equal(t, `the output "team" should equal "${unknown}"`, st.Text)
+}
@@ -44,6 +44,7 @@ func (s FeatureStatus) String() string {
 type ParallelConfig struct {
 	MaxWorkers int           // Maximum concurrent feature executions
 	Timeout    time.Duration // Per-feature timeout (0 = no timeout)
+	FailFast   bool          // Cancel the remaining features once one fails
 }
 
 // FeatureResult captures the result of a single feature file execution.
@@ -70,6 +71,9 @@ type ParallelRunner struct {
 	progress    *ProgressTracker
 	cancel      context.CancelFunc
 	wg          sync.WaitGroup
+
+	// results records the outcome of each assertion in every feature, if set
+	results *ResultsCollector
 }
 
 // NewParallelRunner creates a new parallel runner.
@@ -80,6 +84,12 @@ func NewParallelRunner(cfg *config.Config, parallelCfg ParallelConfig) *Parallel
 	}
 }
 
+// SetResultsCollector makes every feature's runner record the outcome of its assertions in
+// the collector
+func (pr *ParallelRunner) SetResultsCollector(results *ResultsCollector) {
+	pr.results = results
+}
+
 // RunParallel executes multiple feature files in parallel.
 func (pr *ParallelRunner) RunParallel(ctx context.Context, featurePaths []string, format string) (*AggregatedResults, error) {
 	ctx, pr.cancel = context.WithCancel(ctx)
@@ -139,6 +149,9 @@ func (pr *ParallelRunner) RunParallel(ctx context.Context, featurePaths []string
 	var results []FeatureResult
 	for result := range resultsChan {
 		results = append(results, result)
+		if pr.parallelCfg.FailFast && result.Status != StatusPassed && result.Status != StatusCanceled {
+			pr.cancel()
+		}
 	}
 
 	// Sort results by original order (by feature path)
@@ -163,6 +176,7 @@ func (pr *ParallelRunner) runSingleFeature(ctx context.Context, featurePath, for
 	}
 
 	// Apply per-feature timeout if configured
+	runCtx := ctx
 	if pr.parallelCfg.Timeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, pr.parallelCfg.Timeout)
@@ -175,7 +189,8 @@ func (pr *ParallelRunner) runSingleFeature(ctx context.Context, featurePath, for
 	go func() {
 		// Create isolated runner
 		runner := New(pr.cfg)
-		done <- runner.RunWithFormat(featurePath, format)
+		runner.SetResultsCollector(pr.results)
+		done <- runner.RunWithContext(ctx, featurePath, format)
 	}()
 
 	// Wait for completion or cancellation
@@ -189,8 +204,11 @@ func (pr *ParallelRunner) runSingleFeature(ctx context.Context, featurePath, for
 			result.Status = StatusPassed
 		}
 	case <-ctx.Done():
+		// Wait for the runner to interrupt its IaC commands and destroy what it applied
+		<-done
 		result.Duration = time.Since(startTime)
-		if ctx.Err() == context.DeadlineExceeded {
+		// The whole run ending, like when it times out, cancels the feature rather than timing it out
+		if ctx.Err() == context.DeadlineExceeded && runCtx.Err() == nil {
 			result.Status = StatusTimeout
 			result.Error = fmt.Errorf("feature execution timed out after %v", pr.parallelCfg.Timeout)
 		} else {
@@ -204,6 +222,12 @@ func (pr *ParallelRunner) runSingleFeature(ctx context.Context, featurePath, for
 
 // aggregateResults combines individual results into summary.
 func (pr *ParallelRunner) aggregateResults(results []FeatureResult, totalDuration time.Duration) *AggregatedResults {
+	return AggregateResults(results, totalDuration)
+}
+
+// AggregateResults combines the results of features that ran in parallel or sequentially
+// into a summary.
+func AggregateResults(results []FeatureResult, totalDuration time.Duration) *AggregatedResults {
 	agg := &AggregatedResults{
 		TotalFeatures: len(results),
 		TotalDuration: totalDuration,
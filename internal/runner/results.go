@@ -0,0 +1,220 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cucumber/godog"
+	messages "github.com/cucumber/messages/go/v21"
+)
+
+var (
+	// quotedArgPattern matches the quoted arguments of a step, like the bucket name in
+	// `the S3 bucket "my-bucket" should exist`
+	quotedArgPattern = regexp.MustCompile(`"([^"]*)"`)
+
+	// actualValuePattern matches the value an assertion's failure message says it found, like
+	// "got 404" in "expected status 200, got 404"
+	actualValuePattern = regexp.MustCompile(`(?i)\bgot:?\s+(.+)$`)
+)
+
+// AssertionResult is the outcome of an assertion (Then) step
+type AssertionResult struct {
+	Feature  string `json:"feature"`
+	Scenario string `json:"scenario"`
+	Step     string `json:"step"`
+
+	// Resource is the step's first quoted argument, which names the resource it checks
+	Resource string `json:"resource,omitempty"`
+
+	// Expected is the step's last quoted argument, or, for steps with fewer arguments, what
+	// follows "should", like "exist"
+	Expected string `json:"expected,omitempty"`
+
+	// Actual is the value the failure message says the assertion found, if it says
+	Actual string `json:"actual,omitempty"`
+
+	// Status is godog's status for the step: passed, failed, skipped, undefined, pending or
+	// ambiguous
+	Status          string  `json:"status"`
+	Passed          bool    `json:"passed"`
+	Error           string  `json:"error,omitempty"`
+	DurationSeconds float64 `json:"duration_seconds"`
+}
+
+// ResultsSummary counts the assertions in a results report
+type ResultsSummary struct {
+	Total   int `json:"total"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+}
+
+// ResultsReport is the JSON document the results collector writes
+type ResultsReport struct {
+	Summary    ResultsSummary    `json:"summary"`
+	Assertions []AssertionResult `json:"assertions"`
+}
+
+// ResultsCollector records the outcome of every assertion in a run, across features and
+// parallel runners, for a machine-readable report
+type ResultsCollector struct {
+	mu      sync.Mutex
+	results []AssertionResult
+}
+
+// NewResultsCollector creates an empty results collector
+func NewResultsCollector() *ResultsCollector {
+	return &ResultsCollector{}
+}
+
+// Record adds the outcome of an assertion
+func (c *ResultsCollector) Record(result AssertionResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results = append(c.results, result)
+}
+
+// Report returns the recorded assertions, grouped by feature in the order they ran, and
+// their counts
+func (c *ResultsCollector) Report() ResultsReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	report := ResultsReport{Assertions: make([]AssertionResult, len(c.results))}
+	copy(report.Assertions, c.results)
+	// Features run in parallel record their assertions interleaved
+	sort.SliceStable(report.Assertions, func(i, j int) bool {
+		return report.Assertions[i].Feature < report.Assertions[j].Feature
+	})
+
+	for _, result := range report.Assertions {
+		report.Summary.Total++
+		switch {
+		case result.Passed:
+			report.Summary.Passed++
+		case result.Status == godog.StepFailed.String():
+			report.Summary.Failed++
+		default:
+			report.Summary.Skipped++
+		}
+	}
+	return report
+}
+
+// WriteFile writes the report as JSON to path
+func (c *ResultsCollector) WriteFile(path string) error {
+	data, err := json.MarshalIndent(c.Report(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode results: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write results file: %w", err)
+	}
+	return nil
+}
+
+type assertionResultsCtxKey struct{}
+
+// scenarioAssertions times the assertion steps of a scenario for the results collector
+type scenarioAssertions struct {
+	mu       sync.Mutex
+	feature  string
+	scenario string
+	started  map[string]assertionStart
+}
+
+// assertionStart is when an assertion step started, and how many soft assertions had
+// failed by then, so a failure the step recorded softly can be told apart
+type assertionStart struct {
+	at           time.Time
+	softFailures int
+}
+
+func newScenarioAssertions(sc *godog.Scenario) *scenarioAssertions {
+	return &scenarioAssertions{
+		feature:  sc.Uri,
+		scenario: sc.Name,
+		started:  make(map[string]assertionStart),
+	}
+}
+
+func scenarioAssertionsFromContext(ctx context.Context) *scenarioAssertions {
+	s, _ := ctx.Value(assertionResultsCtxKey{}).(*scenarioAssertions)
+	return s
+}
+
+// start records that an assertion step is starting
+func (s *scenarioAssertions) start(st *godog.Step, soft *softAssertions) {
+	if st.Type != messages.PickleStepType_OUTCOME {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.started[st.Id] = assertionStart{at: time.Now(), softFailures: soft.failureCount()}
+}
+
+// finish returns the result of an assertion step that has run, and false for other steps.
+// Steps skipped after a failure never start, so they take no time.
+func (s *scenarioAssertions) finish(st *godog.Step, status godog.StepResultStatus, err error, soft *softAssertions) (AssertionResult, bool) {
+	if st.Type != messages.PickleStepType_OUTCOME {
+		return AssertionResult{}, false
+	}
+	s.mu.Lock()
+	started, ok := s.started[st.Id]
+	delete(s.started, st.Id)
+	s.mu.Unlock()
+
+	result := AssertionResult{
+		Feature:  s.feature,
+		Scenario: s.scenario,
+		Step:     st.Text,
+		Status:   status.String(),
+	}
+	if ok {
+		result.DurationSeconds = time.Since(started.at).Seconds()
+	}
+
+	// A failed soft assertion is reported to godog as passed and fails the scenario at its end
+	message := ""
+	if err != nil {
+		message = err.Error()
+	} else if failure, failed := soft.failureSince(started.softFailures); ok && failed {
+		message = failure
+		result.Status = godog.StepFailed.String()
+	}
+	result.Passed = result.Status == godog.StepPassed.String()
+	if message != "" && !result.Passed {
+		result.Error = message
+		result.Actual = actualValue(message)
+	}
+
+	args := quotedArgPattern.FindAllStringSubmatch(st.Text, -1)
+	if len(args) > 0 {
+		result.Resource = args[0][1]
+	}
+	if len(args) > 1 {
+		result.Expected = args[len(args)-1][1]
+	} else if _, expectation, found := strings.Cut(st.Text, " should "); found {
+		result.Expected = strings.TrimPrefix(expectation, "be ")
+	}
+	return result, true
+}
+
+// actualValue returns the value a failure message says the assertion found, or "" if it
+// doesn't say
+func actualValue(message string) string {
+	firstLine, _, _ := strings.Cut(message, "\n")
+	match := actualValuePattern.FindStringSubmatch(firstLine)
+	if match == nil {
+		return ""
+	}
+	return strings.Trim(match[1], `'"`)
+}
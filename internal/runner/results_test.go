@@ -0,0 +1,100 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robmorgan/infraspec/internal/config"
+)
+
+func TestResultsCollector_RecordsAssertions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Env", "prod")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "results.feature")
+	feature := fmt.Sprintf(`Feature: Results
+
+  Scenario: checks the response
+    Given I have a HTTP endpoint at "%[1]s"
+    When I send a GET request
+    Then the HTTP response header "X-Env" should be "prod"
+    And the HTTP response status should be 200
+    And the HTTP response header "X-Env" should be "staging"
+
+  @soft-assertions
+  Scenario: checks the response softly
+    Given I have a HTTP endpoint at "%[1]s"
+    When I send a GET request
+    Then the HTTP response header "X-Env" should be "staging"
+    And the HTTP response status should be 201
+`, server.URL)
+	require.NoError(t, os.WriteFile(path, []byte(feature), 0o644))
+
+	results := NewResultsCollector()
+	runner := New(&config.Config{})
+	runner.SetResultsCollector(results)
+	require.Error(t, runner.RunWithFormat(path, "progress"))
+
+	report := results.Report()
+	assert.Equal(t, ResultsSummary{Total: 5, Passed: 2, Failed: 2, Skipped: 1}, report.Summary)
+	require.Len(t, report.Assertions, 5)
+
+	passed := report.Assertions[0]
+	assert.Equal(t, path, passed.Feature)
+	assert.Equal(t, "checks the response", passed.Scenario)
+	assert.Equal(t, `the HTTP response header "X-Env" should be "prod"`, passed.Step)
+	assert.Equal(t, "X-Env", passed.Resource)
+	assert.Equal(t, "prod", passed.Expected)
+	assert.Equal(t, "passed", passed.Status)
+	assert.True(t, passed.Passed)
+	assert.Empty(t, passed.Error)
+
+	failed := report.Assertions[1]
+	assert.Equal(t, "failed", failed.Status)
+	assert.False(t, failed.Passed)
+	assert.Equal(t, "200", failed.Expected)
+	assert.Equal(t, "201", failed.Actual)
+	assert.Equal(t, "expected status 200, got 201", failed.Error)
+
+	// Steps after a failure are skipped
+	assert.Equal(t, "skipped", report.Assertions[2].Status)
+	assert.False(t, report.Assertions[2].Passed)
+
+	// A soft assertion's failure is reported even though the scenario carries on
+	soft := report.Assertions[3]
+	assert.Equal(t, "failed", soft.Status)
+	assert.Equal(t, "staging", soft.Expected)
+	assert.Equal(t, "prod", soft.Actual)
+	assert.True(t, report.Assertions[4].Passed)
+
+	resultsFile := filepath.Join(t.TempDir(), "results.json")
+	require.NoError(t, results.WriteFile(resultsFile))
+	data, err := os.ReadFile(resultsFile)
+	require.NoError(t, err)
+	var written ResultsReport
+	require.NoError(t, json.Unmarshal(data, &written))
+	assert.Equal(t, report, written)
+}
+
+func TestActualValue(t *testing.T) {
+	tests := map[string]string{
+		"expected status 200, got 404":                        "404",
+		"expected header 'X-Env' to be 'prod', got 'staging'": "staging",
+		"expected object a.txt to have storage class GLACIER, but got STANDARD\nemulator state for s3:bucket:": "STANDARD",
+		"bucket orders does not exist or is not accessible":                                                    "",
+	}
+	for message, want := range tests {
+		assert.Equal(t, want, actualValue(message), message)
+	}
+}
@@ -14,13 +14,28 @@ import (
 	"github.com/robmorgan/infraspec/internal/config"
 	"github.com/robmorgan/infraspec/internal/contexthelpers"
 	"github.com/robmorgan/infraspec/internal/formatter"
+	"github.com/robmorgan/infraspec/pkg/embedded"
 	"github.com/robmorgan/infraspec/pkg/steps"
 	"github.com/robmorgan/infraspec/pkg/steps/terraform"
 )
 
+// ProgressFormat is godog's formatter that prints a character per step. Failed steps and
+// scenarios aren't logged as they run with it, so the characters stay on one line; the
+// formatter's summary lists them at the end instead.
+const ProgressFormat = "progress"
+
 // Runner handles the execution of feature files
 type Runner struct {
 	cfg *config.Config
+
+	// softAssertions is set when scenarios in the feature may collect their failed assertions
+	softAssertions bool
+
+	// format is the godog formatter the feature runs with
+	format string
+
+	// results records the outcome of each assertion, if set
+	results *ResultsCollector
 }
 
 func New(cfg *config.Config) *Runner {
@@ -29,6 +44,12 @@ func New(cfg *config.Config) *Runner {
 	}
 }
 
+// SetResultsCollector makes the runner record the outcome of each assertion (Then) step in
+// the collector, which can be shared by runners running in parallel
+func (r *Runner) SetResultsCollector(results *ResultsCollector) {
+	r.results = results
+}
+
 // Run executes the specified feature file
 func (r *Runner) Run(featurePath string) error {
 	return r.RunWithFormat(featurePath, "pretty")
@@ -36,6 +57,13 @@ func (r *Runner) Run(featurePath string) error {
 
 // RunWithFormat executes the specified feature file with a custom formatter
 func (r *Runner) RunWithFormat(featurePath, format string) error {
+	return r.RunWithContext(context.Background(), featurePath, format)
+}
+
+// RunWithContext executes the specified feature file with a custom formatter. The steps run with
+// ctx, so canceling it interrupts the IaC commands they're running; the configurations they
+// applied are still destroyed.
+func (r *Runner) RunWithContext(ctx context.Context, featurePath, format string) error {
 	defer config.Logging.Logger.Sync() //nolint:errcheck // flushes buffer, if any
 
 	// Validate feature file exists
@@ -45,10 +73,24 @@ func (r *Runner) RunWithFormat(featurePath, format string) error {
 
 	config.Logging.Logger.Infof("Starting test execution using: %s", featurePath)
 
+	r.softAssertions = r.cfg.SoftAssertions || usesSoftAssertions(featurePath)
+	r.format = format
+
+	paths := []string{featurePath}
+	if r.cfg.ScenarioName != "" {
+		var err error
+		if paths, err = scenarioPaths(featurePath, r.cfg.ScenarioName); err != nil {
+			return err
+		}
+	}
+
 	options := &godog.Options{
-		Format:   format,
-		Paths:    []string{featurePath},
-		TestingT: nil,
+		Format:         format,
+		Paths:          paths,
+		TestingT:       nil,
+		StopOnFailure:  r.cfg.FailFast,
+		Strict:         r.cfg.Strict,
+		DefaultContext: ctx,
 	}
 
 	// Register custom InfraSpec formatter
@@ -87,6 +129,24 @@ func (r *Runner) RunWithFormat(featurePath, format string) error {
 func (r *Runner) initializeScenario(sc *godog.ScenarioContext) {
 	// Initialize test context for each scenario
 	sc.Before(func(ctx context.Context, sc *godog.Scenario) (context.Context, error) {
+		// Count the emulator's requests per scenario
+		if emu := embedded.GetInstance(); emu != nil {
+			emu.ResetRequestCounts()
+		}
+
+		// collect failed assertions instead of stopping at the first one
+		if hasSoftAssertions(sc, r.cfg.SoftAssertions) {
+			ctx = context.WithValue(ctx, softAssertionsCtxKey{}, &softAssertions{})
+		}
+
+		// time the assertions for the results file
+		if r.results != nil {
+			ctx = context.WithValue(ctx, assertionResultsCtxKey{}, newScenarioAssertions(sc))
+		}
+
+		// hold the variables captured by the scenario's steps
+		ctx = context.WithValue(ctx, contexthelpers.ScenarioStoreCtxKey{}, contexthelpers.NewScenarioStore())
+
 		// embed the config
 		ctx = context.WithValue(ctx, contexthelpers.ConfigCtxKey{}, r.cfg)
 
@@ -95,27 +155,56 @@ func (r *Runner) initializeScenario(sc *godog.ScenarioContext) {
 	})
 
 	// Register step definitions
-	steps.RegisterSteps(sc)
+	if r.softAssertions {
+		steps.RegisterSteps(softAssertingSteps{sc: sc})
+	} else {
+		steps.RegisterSteps(sc)
+	}
 
 	// Add hooks for logging
 	sc.StepContext().Before(func(ctx context.Context, st *godog.Step) (context.Context, error) {
 		config.Logging.Logger.Debug("Executing step", st, st.Text)
-		return ctx, nil
+		soft := softAssertionsFromContext(ctx)
+		if soft != nil {
+			soft.setStep(st)
+		}
+
+		// resolve ${name} references before the step is matched
+		err := interpolateStep(ctx, st)
+		if assertions := scenarioAssertionsFromContext(ctx); assertions != nil {
+			assertions.start(st, soft)
+		}
+		return ctx, err
 	})
 
 	sc.StepContext().After(func(ctx context.Context, st *godog.Step, status godog.StepResultStatus, err error) (context.Context, error) {
-		if err != nil {
+		if err != nil && r.format != ProgressFormat {
 			config.Logging.Logger.Error("Step failed", "step", st.Text, "error", err)
-		} else {
+		} else if err == nil {
 			config.Logging.Logger.Debug("Step completed successfully", "step", st.Text)
 		}
+
+		if assertions := scenarioAssertionsFromContext(ctx); assertions != nil {
+			if result, ok := assertions.finish(st, status, err, softAssertionsFromContext(ctx)); ok {
+				r.results.Record(result)
+			}
+		}
 		return ctx, nil
 	})
 
 	sc.After(func(ctx context.Context, sc *godog.Scenario, err error) (context.Context, error) {
-		if err != nil {
+		// Report the assertions that failed softly at the end of the scenario
+		var softErr error
+		if soft := softAssertionsFromContext(ctx); soft != nil {
+			softErr = soft.err()
+		}
+		if err == nil {
+			err = softErr
+		}
+
+		if err != nil && r.format != ProgressFormat {
 			config.Logging.Logger.Error("Scenario failed", "scenario", sc.Name, "error", err)
-		} else {
+		} else if err == nil {
 			config.Logging.Logger.Debugf("Scenario completed successfully: %s", sc.Name)
 		}
 
@@ -127,7 +216,7 @@ func (r *Runner) initializeScenario(sc *godog.ScenarioContext) {
 				config.Logging.Logger.Error("Error destroying Terraform resources", err)
 			}
 		}
-		return ctx, nil
+		return ctx, softErr
 	})
 }
 
@@ -0,0 +1,21 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robmorgan/infraspec/internal/config"
+)
+
+func TestRunWithFormat_StrictFailsOnUndefinedSteps(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "undefined.feature")
+	feature := "Feature: Undefined\n\n  Scenario: has an undefined step\n    Given a step with no definition\n"
+	require.NoError(t, os.WriteFile(path, []byte(feature), 0o644))
+
+	assert.NoError(t, New(&config.Config{}).RunWithFormat(path, "progress"))
+	assert.Error(t, New(&config.Config{Strict: true}).RunWithFormat(path, "progress"))
+}
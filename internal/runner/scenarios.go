@@ -0,0 +1,82 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	gherkin "github.com/cucumber/gherkin/go/v26"
+	messages "github.com/cucumber/messages/go/v21"
+)
+
+// ScenarioLines returns the lines of the scenarios named name in the feature file, including
+// the scenarios of its rules. Names are compared ignoring surrounding whitespace.
+func ScenarioLines(featurePath, name string) ([]int64, error) {
+	file, err := os.Open(featurePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	doc, err := gherkin.ParseGherkinDocument(file, (&messages.Incrementing{}).NewId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", featurePath, err)
+	}
+	if doc.Feature == nil {
+		return nil, nil
+	}
+
+	name = strings.TrimSpace(name)
+	var lines []int64
+	addScenario := func(scenario *messages.Scenario) {
+		if scenario != nil && strings.TrimSpace(scenario.Name) == name {
+			lines = append(lines, scenario.Location.Line)
+		}
+	}
+	for _, child := range doc.Feature.Children {
+		addScenario(child.Scenario)
+		if child.Rule != nil {
+			for _, ruleChild := range child.Rule.Children {
+				addScenario(ruleChild.Scenario)
+			}
+		}
+	}
+	return lines, nil
+}
+
+// FilterFeaturesByScenario returns the feature files that have a scenario named name.
+func FilterFeaturesByScenario(featureFiles []string, name string) ([]string, error) {
+	var matched []string
+	for _, featureFile := range featureFiles {
+		lines, err := ScenarioLines(featureFile, name)
+		if err != nil {
+			return nil, err
+		}
+		if len(lines) > 0 {
+			matched = append(matched, featureFile)
+		}
+	}
+
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("no scenario named %q found", name)
+	}
+	return matched, nil
+}
+
+// scenarioPaths returns the godog paths that run only the scenarios named name in the feature
+// file, in the form path:line.
+func scenarioPaths(featurePath, name string) ([]string, error) {
+	lines, err := ScenarioLines(featurePath, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("no scenario named %q in %s", name, featurePath)
+	}
+
+	paths := make([]string, 0, len(lines))
+	for _, line := range lines {
+		paths = append(paths, fmt.Sprintf("%s:%d", featurePath, line))
+	}
+	return paths, nil
+}
@@ -0,0 +1,59 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const scenariosFeature = `Feature: Buckets
+
+  Scenario: creates a bucket
+    Given I have a bucket
+
+  Scenario: deletes a bucket
+    Given I have a bucket
+
+  Rule: versioning
+
+    Scenario Outline:   creates a bucket
+      Given I have <count> buckets
+
+      Examples:
+        | count |
+        | 1     |
+`
+
+func TestScenarioLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "buckets.feature")
+	require.NoError(t, os.WriteFile(path, []byte(scenariosFeature), 0o644))
+
+	lines, err := ScenarioLines(path, "creates a bucket")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3, 11}, lines)
+
+	paths, err := scenarioPaths(path, "deletes a bucket")
+	require.NoError(t, err)
+	assert.Equal(t, []string{path + ":6"}, paths)
+
+	_, err = scenarioPaths(path, "missing")
+	assert.EqualError(t, err, `no scenario named "missing" in `+path)
+}
+
+func TestFilterFeaturesByScenario(t *testing.T) {
+	dir := t.TempDir()
+	buckets := filepath.Join(dir, "buckets.feature")
+	queues := filepath.Join(dir, "queues.feature")
+	require.NoError(t, os.WriteFile(buckets, []byte(scenariosFeature), 0o644))
+	require.NoError(t, os.WriteFile(queues, []byte("Feature: Queues\n\n  Scenario: creates a queue\n    Given I have a queue\n"), 0o644))
+
+	files, err := FilterFeaturesByScenario([]string{buckets, queues}, "creates a queue")
+	require.NoError(t, err)
+	assert.Equal(t, []string{queues}, files)
+
+	_, err = FilterFeaturesByScenario([]string{buckets, queues}, "creates a topic")
+	assert.EqualError(t, err, `no scenario named "creates a topic" found`)
+}
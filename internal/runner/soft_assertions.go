@@ -0,0 +1,165 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/cucumber/godog"
+	messages "github.com/cucumber/messages/go/v21"
+)
+
+// softAssertionsTag makes a scenario collect its failed assertions instead of stopping at the first one
+const softAssertionsTag = "@soft-assertions"
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+type softAssertionsCtxKey struct{}
+
+// softAssertions collects the failed assertion steps of a scenario
+type softAssertions struct {
+	mu        sync.Mutex
+	assertion bool // the running step is an assertion (Then) step
+	failures  []string
+}
+
+// record keeps the failure if the running step is an assertion, reporting whether it did
+func (s *softAssertions) record(err error) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.assertion {
+		return false
+	}
+	s.failures = append(s.failures, err.Error())
+	return true
+}
+
+// failureCount returns how many failures have been recorded. A nil collector has none.
+func (s *softAssertions) failureCount() int {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.failures)
+}
+
+// failureSince returns the last failure if any were recorded after the first n
+func (s *softAssertions) failureSince(n int) (string, bool) {
+	if s == nil {
+		return "", false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.failures) <= n {
+		return "", false
+	}
+	return s.failures[len(s.failures)-1], true
+}
+
+func (s *softAssertions) setStep(st *godog.Step) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.assertion = st.Type == messages.PickleStepType_OUTCOME
+}
+
+// err returns a single error listing every recorded failure, or nil if there were none
+func (s *softAssertions) err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.failures) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d soft assertion(s) failed:\n  - %s", len(s.failures), strings.Join(s.failures, "\n  - "))
+}
+
+func softAssertionsFromContext(ctx context.Context) *softAssertions {
+	s, _ := ctx.Value(softAssertionsCtxKey{}).(*softAssertions)
+	return s
+}
+
+// hasSoftAssertions reports whether the scenario should collect its assertion failures
+func hasSoftAssertions(sc *godog.Scenario, enabled bool) bool {
+	if enabled {
+		return true
+	}
+	for _, tag := range sc.Tags {
+		if tag.Name == softAssertionsTag {
+			return true
+		}
+	}
+	return false
+}
+
+// usesSoftAssertions reports whether the feature file tags any scenario with @soft-assertions.
+// Unreadable paths are assumed to, so the steps are still wrapped.
+func usesSoftAssertions(featurePath string) bool {
+	content, err := os.ReadFile(featurePath)
+	if err != nil {
+		return true
+	}
+	return bytes.Contains(content, []byte(softAssertionsTag))
+}
+
+// softAssertingSteps wraps each registered step so failed assertions can be recorded rather
+// than failing the step when the scenario collects soft assertions.
+type softAssertingSteps struct {
+	sc *godog.ScenarioContext
+}
+
+func (s softAssertingSteps) Step(expr, stepFunc interface{}) {
+	s.sc.Step(expr, softStep(stepFunc))
+}
+
+// softStep returns a handler with the same results as stepFunc that takes the step context,
+// so a returned error can be recorded against the scenario's soft assertions.
+func softStep(stepFunc interface{}) interface{} {
+	fn := reflect.ValueOf(stepFunc)
+	fnType := fn.Type()
+	if fnType.Kind() != reflect.Func || fnType.IsVariadic() {
+		return stepFunc
+	}
+	errIndex := fnType.NumOut() - 1
+	if errIndex < 0 || fnType.Out(errIndex) != errorType {
+		return stepFunc
+	}
+
+	takesCtx := fnType.NumIn() > 0 && fnType.In(0) == contextType
+	in := make([]reflect.Type, 0, fnType.NumIn()+1)
+	if !takesCtx {
+		in = append(in, contextType)
+	}
+	for i := 0; i < fnType.NumIn(); i++ {
+		in = append(in, fnType.In(i))
+	}
+	out := make([]reflect.Type, 0, fnType.NumOut())
+	for i := 0; i < fnType.NumOut(); i++ {
+		out = append(out, fnType.Out(i))
+	}
+
+	return reflect.MakeFunc(reflect.FuncOf(in, out, false), func(args []reflect.Value) []reflect.Value {
+		ctx, _ := args[0].Interface().(context.Context)
+		if !takesCtx {
+			args = args[1:]
+		}
+
+		results := fn.Call(args)
+		if err, _ := results[errIndex].Interface().(error); err != nil && ctx != nil {
+			if soft := softAssertionsFromContext(ctx); soft != nil && soft.record(err) {
+				results[errIndex] = reflect.Zero(errorType)
+				// godog rejects a nil context alongside a nil error, so keep the incoming one
+				if errIndex == 1 && results[0].IsNil() {
+					results[0] = reflect.ValueOf(&ctx).Elem()
+				}
+			}
+		}
+		return results
+	}).Interface()
+}
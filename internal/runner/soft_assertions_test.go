@@ -0,0 +1,60 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cucumber/godog"
+	messages "github.com/cucumber/messages/go/v21"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoftStep_RecordsAssertionFailures(t *testing.T) {
+	soft := &softAssertions{}
+	ctx := context.WithValue(context.Background(), softAssertionsCtxKey{}, soft)
+
+	// A handler without a context gets one prepended
+	wrapped, ok := softStep(func(name string) error {
+		return errors.New("bucket " + name + " not found")
+	}).(func(context.Context, string) error)
+	require.True(t, ok)
+
+	// Failures outside assertion steps still fail the step
+	soft.setStep(&godog.Step{Type: messages.PickleStepType_ACTION})
+	assert.EqualError(t, wrapped(ctx, "a"), "bucket a not found")
+
+	soft.setStep(&godog.Step{Type: messages.PickleStepType_OUTCOME})
+	assert.NoError(t, wrapped(ctx, "b"))
+	assert.NoError(t, wrapped(ctx, "c"))
+	assert.EqualError(t, soft.err(), "2 soft assertion(s) failed:\n  - bucket b not found\n  - bucket c not found")
+
+	// Without soft assertions in the context the error is returned
+	assert.Error(t, wrapped(context.Background(), "d"))
+}
+
+func TestSoftStep_KeepsContextOnRecordedFailure(t *testing.T) {
+	soft := &softAssertions{}
+	soft.setStep(&godog.Step{Type: messages.PickleStepType_OUTCOME})
+	ctx := context.WithValue(context.Background(), softAssertionsCtxKey{}, soft)
+
+	wrapped, ok := softStep(func(ctx context.Context) (context.Context, error) {
+		return nil, errors.New("output mismatch")
+	}).(func(context.Context) (context.Context, error))
+	require.True(t, ok)
+
+	got, err := wrapped(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, ctx, got)
+	assert.Error(t, soft.err())
+}
+
+func TestHasSoftAssertions(t *testing.T) {
+	tagged := &godog.Scenario{Tags: []*messages.PickleTag{{Name: "@soft-assertions"}}}
+	untagged := &godog.Scenario{Tags: []*messages.PickleTag{{Name: "@s3"}}}
+
+	assert.True(t, hasSoftAssertions(tagged, false))
+	assert.False(t, hasSoftAssertions(untagged, false))
+	assert.True(t, hasSoftAssertions(untagged, true))
+}
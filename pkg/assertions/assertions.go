@@ -20,6 +20,12 @@ type Asserter interface {
 	GetName() string
 }
 
+// FailureDebugger is implemented by asserters that can attach the state of the resource they
+// check to failed assertions, e.g. a snapshot of the embedded emulator's state
+type FailureDebugger interface {
+	SetDebugOnFailure(enabled bool)
+}
+
 // Factory function to create new asserters
 func New(provider string) (Asserter, error) {
 	switch provider {
@@ -1,7 +1,10 @@
 package aws
 
 // AWSAsserter implements assertions for AWS resources
-type AWSAsserter struct{}
+type AWSAsserter struct {
+	// debugOnFailure attaches a snapshot of the emulator state to failed assertions
+	debugOnFailure bool
+}
 
 // NewAWSAsserter creates a new AWSAsserter instance
 func NewAWSAsserter() *AWSAsserter {
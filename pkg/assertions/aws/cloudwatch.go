@@ -0,0 +1,86 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+
+	"github.com/robmorgan/infraspec/pkg/awshelpers"
+)
+
+// metricDatapointWindow is how far back datapoints are counted
+const metricDatapointWindow = 14 * 24 * time.Hour
+
+// Ensure the `AWSAsserter` struct implements the `CloudWatchAsserter` interface.
+var _ CloudWatchAsserter = (*AWSAsserter)(nil)
+
+// CloudWatchAsserter defines CloudWatch-specific assertions
+type CloudWatchAsserter interface {
+	AssertMetricDatapointCount(namespace, metricName string, count int) error
+}
+
+// AssertMetricDatapointCount checks how many datapoints have been published to the metric over
+// the last two weeks, across all of its dimensions
+func (a *AWSAsserter) AssertMetricDatapointCount(namespace, metricName string, count int) error {
+	client, err := a.createCloudWatchClient()
+	if err != nil {
+		return err
+	}
+
+	end := time.Now().Add(time.Hour)
+	start := end.Add(-metricDatapointWindow)
+	actual := 0
+	paginator := cloudwatch.NewListMetricsPaginator(client, &cloudwatch.ListMetricsInput{
+		Namespace:  aws.String(namespace),
+		MetricName: aws.String(metricName),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return fmt.Errorf("error listing CloudWatch metric %s in namespace %s: %w", metricName, namespace, err)
+		}
+
+		for _, metric := range page.Metrics {
+			stats, err := client.GetMetricStatistics(context.TODO(), &cloudwatch.GetMetricStatisticsInput{
+				Namespace:  metric.Namespace,
+				MetricName: metric.MetricName,
+				Dimensions: metric.Dimensions,
+				StartTime:  aws.Time(start),
+				EndTime:    aws.Time(end),
+				Period:     aws.Int32(int32((24 * time.Hour).Seconds())),
+				Statistics: []types.Statistic{types.StatisticSampleCount},
+			})
+			if err != nil {
+				return fmt.Errorf("error getting statistics for CloudWatch metric %s in namespace %s: %w", metricName, namespace, err)
+			}
+			for _, datapoint := range stats.Datapoints {
+				actual += int(aws.ToFloat64(datapoint.SampleCount))
+			}
+		}
+	}
+
+	if actual != count {
+		return fmt.Errorf("expected CloudWatch metric %s in namespace %s to have %d datapoints, got %d", metricName, namespace, count, actual)
+	}
+	return nil
+}
+
+func (a *AWSAsserter) createCloudWatchClient() (*cloudwatch.Client, error) {
+	cfg, err := awshelpers.NewAuthenticatedSessionWithDefaultRegion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %w", err)
+	}
+
+	opts := make([]func(*cloudwatch.Options), 0, 1)
+	if endpoint, ok := awshelpers.GetVirtualCloudEndpoint("monitoring"); ok {
+		opts = append(opts, func(o *cloudwatch.Options) {
+			o.BaseEndpoint = aws.String(endpoint)
+		})
+	}
+
+	return cloudwatch.NewFromConfig(*cfg, opts...), nil
+}
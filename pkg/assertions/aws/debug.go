@@ -0,0 +1,93 @@
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/robmorgan/infraspec/pkg/embedded"
+)
+
+const (
+	// maxSnapshotKeys limits how many state keys a failure snapshot lists
+	maxSnapshotKeys = 20
+	// maxSnapshotValueLength truncates each state value in a failure snapshot
+	maxSnapshotValueLength = 200
+)
+
+// StateSnapshotError is a failed assertion along with a snapshot of the emulator state it relates to
+type StateSnapshotError struct {
+	Err      error
+	Snapshot string
+}
+
+func (e *StateSnapshotError) Error() string {
+	return e.Err.Error() + "\n" + e.Snapshot
+}
+
+func (e *StateSnapshotError) Unwrap() error {
+	return e.Err
+}
+
+// SetDebugOnFailure makes failed assertions include a snapshot of the embedded emulator's
+// state for the resource they check
+func (a *AWSAsserter) SetDebugOnFailure(enabled bool) {
+	a.debugOnFailure = enabled
+}
+
+// withStateSnapshot attaches a snapshot of the emulator state to *err when debugging on failure
+// is enabled and the assertion ran against the embedded emulator. It's deferred by assertions
+// with a named error result.
+//
+// The snapshot holds the state of the resource stored at resourceKey, including its sub-keys
+// such as an S3 bucket's objects. When the resource isn't in the state, the resources stored
+// directly under collectionPrefix are listed instead, showing which ones do exist.
+func (a *AWSAsserter) withStateSnapshot(err *error, resourceKey, collectionPrefix string) {
+	if *err == nil || !a.debugOnFailure {
+		return
+	}
+	emu := embedded.GetInstance()
+	if emu == nil {
+		return
+	}
+
+	var lines []string
+	resource := emu.StateSnapshot(resourceKey)
+	for key, value := range resource {
+		stateKey := embedded.KeyInPartition(key)
+		if stateKey != resourceKey && !strings.HasPrefix(stateKey, resourceKey+":") {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %s = %s", key, truncate(string(value), maxSnapshotValueLength)))
+	}
+
+	header := fmt.Sprintf("emulator state for %s:", resourceKey)
+	if len(lines) == 0 {
+		header = fmt.Sprintf("%s is not in the emulator state, which has:", resourceKey)
+		for key := range emu.StateSnapshot(collectionPrefix) {
+			if !strings.Contains(strings.TrimPrefix(embedded.KeyInPartition(key), collectionPrefix), ":") {
+				lines = append(lines, "  "+key)
+			}
+		}
+		if len(lines) == 0 {
+			lines = append(lines, fmt.Sprintf("  no keys under %s", collectionPrefix))
+		}
+	}
+
+	sort.Strings(lines)
+	if len(lines) > maxSnapshotKeys {
+		lines = append(lines[:maxSnapshotKeys], fmt.Sprintf("  ... and %d more", len(lines)-maxSnapshotKeys))
+	}
+
+	*err = &StateSnapshotError{
+		Err:      *err,
+		Snapshot: header + "\n" + strings.Join(lines, "\n"),
+	}
+}
+
+func truncate(s string, length int) string {
+	if len(s) <= length {
+		return s
+	}
+	return s[:length] + "..."
+}
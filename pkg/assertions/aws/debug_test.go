@@ -0,0 +1,67 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robmorgan/infraspec/pkg/embedded"
+)
+
+func TestWithStateSnapshot(t *testing.T) {
+	emu := embedded.New()
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+	t.Setenv("AWS_ENDPOINT_URL_SQS", emu.Endpoint())
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_REGION", "us-east-1")
+
+	a := NewAWSAsserter()
+	client, err := a.createSQSClient()
+	require.NoError(t, err)
+	_, err = client.CreateQueue(context.Background(), &sqs.CreateQueueInput{
+		QueueName:  aws.String("orders"),
+		Attributes: map[string]string{"VisibilityTimeout": "45"},
+	})
+	require.NoError(t, err)
+
+	t.Run("disabled", func(t *testing.T) {
+		err := a.AssertQueueExists("missing")
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "emulator state")
+	})
+
+	a.SetDebugOnFailure(true)
+
+	t.Run("missing resource lists the existing ones", func(t *testing.T) {
+		err := a.AssertQueueExists("missing")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "sqs:queue:missing is not in the emulator state, which has:\n  sqs:queue:orders")
+
+		var snapshotErr *StateSnapshotError
+		require.True(t, errors.As(err, &snapshotErr))
+		assert.Contains(t, snapshotErr.Err.Error(), "missing")
+	})
+
+	t.Run("failed assertion shows the resource", func(t *testing.T) {
+		err := a.AssertQueueVisibilityTimeout("orders", 30)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "emulator state for sqs:queue:orders:\n  sqs:queue:orders = {")
+		assert.Contains(t, err.Error(), "45")
+	})
+
+	t.Run("passing assertion", func(t *testing.T) {
+		assert.NoError(t, a.AssertQueueVisibilityTimeout("orders", 45))
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", truncate("short", 10))
+	assert.Equal(t, "0123456789...", truncate("0123456789abcdef", 10))
+}
@@ -3,11 +3,14 @@ package aws
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"slices"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodbstreams"
+	streamtypes "github.com/aws/aws-sdk-go-v2/service/dynamodbstreams/types"
 
 	"github.com/robmorgan/infraspec/pkg/awshelpers"
 )
@@ -18,13 +21,18 @@ var _ DynamoDBAsserter = (*AWSAsserter)(nil)
 // DynamoDBAsserter defines DynamoDB-specific assertions
 type DynamoDBAsserter interface {
 	AssertTableExists(tableName string) error
-	AssertTableTags(tableName string, expectedTags map[string]string) error
+	AssertTableTags(tableName string, expectedTags map[string]string, mode TagMatchMode) error
 	AssertBillingMode(tableName, expectedMode string) error
 	AssertCapacity(tableName string, readCapacity, writeCapacity int64) error
+	AssertStreamRecordCount(tableName string, expected int, eventName string) error
+	AssertGlobalSecondaryIndex(tableName, indexName, hashKey string) error
+	AssertItem(tableName string, expected map[string]interface{}) error
 }
 
 // AssertTableExists checks if the DynamoDB table exists.
-func (a *AWSAsserter) AssertTableExists(tableName string) error {
+func (a *AWSAsserter) AssertTableExists(tableName string) (err error) {
+	defer a.withStateSnapshot(&err, "dynamodb:table:"+tableName, "dynamodb:table:")
+
 	client, err := a.createDynamoDBClient()
 	if err != nil {
 		return err
@@ -45,8 +53,10 @@ func (a *AWSAsserter) AssertTableExists(tableName string) error {
 	return fmt.Errorf("table %s does not exist", tableName)
 }
 
-// AssertTableTags checks if the DynamoDB table has the expected tags.
-func (a *AWSAsserter) AssertTableTags(tableName string, expectedTags map[string]string) error {
+// AssertTableTags checks if the DynamoDB table has the expected tags, compared according to mode.
+func (a *AWSAsserter) AssertTableTags(tableName string, expectedTags map[string]string, mode TagMatchMode) (err error) {
+	defer a.withStateSnapshot(&err, "dynamodb:table:"+tableName, "dynamodb:table:")
+
 	client, err := a.createDynamoDBClient()
 	if err != nil {
 		return err
@@ -74,22 +84,13 @@ func (a *AWSAsserter) AssertTableTags(tableName string, expectedTags map[string]
 		actualTags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
 	}
 
-	// Compare the expected and actual tags
-	for key, value := range expectedTags {
-		actualValue, exists := actualTags[key]
-		if !exists {
-			return fmt.Errorf("expected tag %s not found", key)
-		}
-		if actualValue != value {
-			return fmt.Errorf("expected tag %s to have value %s, but got %s", key, value, actualValue)
-		}
-	}
-
-	return nil
+	return CompareTags(actualTags, expectedTags, mode)
 }
 
 // AssertBillingMode checks if the DynamoDB table has the expected billing mode.
-func (a *AWSAsserter) AssertBillingMode(tableName, expectedMode string) error {
+func (a *AWSAsserter) AssertBillingMode(tableName, expectedMode string) (err error) {
+	defer a.withStateSnapshot(&err, "dynamodb:table:"+tableName, "dynamodb:table:")
+
 	table, err := a.getDynamoDBTable(tableName)
 	if err != nil {
 		return err
@@ -109,7 +110,9 @@ func (a *AWSAsserter) AssertBillingMode(tableName, expectedMode string) error {
 }
 
 // AssertCapacity checks if the DynamoDB table has the expected read and write capacity.
-func (a *AWSAsserter) AssertCapacity(tableName string, readCapacity, writeCapacity int64) error {
+func (a *AWSAsserter) AssertCapacity(tableName string, readCapacity, writeCapacity int64) (err error) {
+	defer a.withStateSnapshot(&err, "dynamodb:table:"+tableName, "dynamodb:table:")
+
 	table, err := a.getDynamoDBTable(tableName)
 	if err != nil {
 		return err
@@ -138,6 +141,171 @@ func (a *AWSAsserter) AssertCapacity(tableName string, readCapacity, writeCapaci
 	return nil
 }
 
+// AssertStreamRecordCount drains the DynamoDB table's stream from the start of every shard and
+// checks the number of records. If eventName is set (INSERT, MODIFY or REMOVE), only records of
+// that type are counted.
+func (a *AWSAsserter) AssertStreamRecordCount(tableName string, expected int, eventName string) (err error) {
+	defer a.withStateSnapshot(&err, "dynamodb:table:"+tableName, "dynamodb:table:")
+
+	table, err := a.getDynamoDBTable(tableName)
+	if err != nil {
+		return err
+	}
+	if table.LatestStreamArn == nil {
+		return fmt.Errorf("table %s does not have a stream enabled", tableName)
+	}
+
+	client, err := a.createDynamoDBStreamsClient()
+	if err != nil {
+		return err
+	}
+
+	stream, err := client.DescribeStream(context.TODO(), &dynamodbstreams.DescribeStreamInput{
+		StreamArn: table.LatestStreamArn,
+	})
+	if err != nil {
+		return fmt.Errorf("error describing stream for table %s: %w", tableName, err)
+	}
+
+	count := 0
+	for _, shard := range stream.StreamDescription.Shards {
+		iterator, err := client.GetShardIterator(context.TODO(), &dynamodbstreams.GetShardIteratorInput{
+			StreamArn:         table.LatestStreamArn,
+			ShardId:           shard.ShardId,
+			ShardIteratorType: streamtypes.ShardIteratorTypeTrimHorizon,
+		})
+		if err != nil {
+			return fmt.Errorf("error getting shard iterator for table %s: %w", tableName, err)
+		}
+
+		// Open shards always return a next iterator, so stop at the first empty page
+		next := iterator.ShardIterator
+		for next != nil {
+			result, err := client.GetRecords(context.TODO(), &dynamodbstreams.GetRecordsInput{
+				ShardIterator: next,
+			})
+			if err != nil {
+				return fmt.Errorf("error getting stream records for table %s: %w", tableName, err)
+			}
+			if len(result.Records) == 0 {
+				break
+			}
+			for _, record := range result.Records {
+				if eventName == "" || string(record.EventName) == eventName {
+					count++
+				}
+			}
+			next = result.NextShardIterator
+		}
+	}
+
+	if count != expected {
+		if eventName != "" {
+			return fmt.Errorf("expected table %s stream to have %d %s records, but got %d", tableName, expected, eventName, count)
+		}
+		return fmt.Errorf("expected table %s stream to have %d records, but got %d", tableName, expected, count)
+	}
+
+	return nil
+}
+
+// AssertGlobalSecondaryIndex checks that the DynamoDB table has the global secondary index.
+// If hashKey is set, the index's partition key must be that attribute.
+func (a *AWSAsserter) AssertGlobalSecondaryIndex(tableName, indexName, hashKey string) (err error) {
+	defer a.withStateSnapshot(&err, "dynamodb:table:"+tableName, "dynamodb:table:")
+
+	table, err := a.getDynamoDBTable(tableName)
+	if err != nil {
+		return err
+	}
+
+	indexNames := make([]string, 0, len(table.GlobalSecondaryIndexes))
+	for _, index := range table.GlobalSecondaryIndexes {
+		if aws.ToString(index.IndexName) != indexName {
+			indexNames = append(indexNames, aws.ToString(index.IndexName))
+			continue
+		}
+		if hashKey == "" {
+			return nil
+		}
+
+		for _, key := range index.KeySchema {
+			if key.KeyType != types.KeyTypeHash {
+				continue
+			}
+			if aws.ToString(key.AttributeName) != hashKey {
+				return fmt.Errorf("expected global secondary index %s of table %s to have hash key %s, but got %s", indexName, tableName, hashKey, aws.ToString(key.AttributeName))
+			}
+			return nil
+		}
+		return fmt.Errorf("global secondary index %s of table %s has no hash key", indexName, tableName)
+	}
+
+	return fmt.Errorf("table %s does not have global secondary index %s, it has %v", tableName, indexName, indexNames)
+}
+
+// AssertItem checks that the DynamoDB table has an item with the expected attributes. The item
+// is read by the table's key attributes, which expected must include, and the other attributes
+// in expected are compared with the item's decoded values. Attributes of the item that aren't
+// in expected are ignored.
+func (a *AWSAsserter) AssertItem(tableName string, expected map[string]interface{}) (err error) {
+	defer a.withStateSnapshot(&err, "dynamodb:table:"+tableName, "dynamodb:table:")
+
+	table, err := a.getDynamoDBTable(tableName)
+	if err != nil {
+		return err
+	}
+
+	keyValues := make(map[string]interface{}, len(table.KeySchema))
+	for _, key := range table.KeySchema {
+		name := aws.ToString(key.AttributeName)
+		value, ok := expected[name]
+		if !ok {
+			return fmt.Errorf("the expected item must include the key attribute %s of table %s", name, tableName)
+		}
+		keyValues[name] = value
+	}
+
+	key, err := MarshalItem(keyValues)
+	if err != nil {
+		return fmt.Errorf("invalid key for table %s: %w", tableName, err)
+	}
+
+	client, err := a.createDynamoDBClient()
+	if err != nil {
+		return err
+	}
+
+	result, err := client.GetItem(context.TODO(), &dynamodb.GetItemInput{
+		TableName:      aws.String(tableName),
+		Key:            key,
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return fmt.Errorf("error getting item from table %s: %w", tableName, err)
+	}
+	if len(result.Item) == 0 {
+		return fmt.Errorf("table %s has no item with key %v", tableName, keyValues)
+	}
+
+	item, err := UnmarshalItem(result.Item)
+	if err != nil {
+		return fmt.Errorf("error decoding item from table %s: %w", tableName, err)
+	}
+
+	for name, expectedValue := range expected {
+		actualValue, ok := item[name]
+		if !ok {
+			return fmt.Errorf("item with key %v in table %s has no attribute %s", keyValues, tableName, name)
+		}
+		if !reflect.DeepEqual(actualValue, expectedValue) {
+			return fmt.Errorf("expected attribute %s of item with key %v in table %s to be %v, but got %v", name, keyValues, tableName, expectedValue, actualValue)
+		}
+	}
+
+	return nil
+}
+
 // Helper method to get a DynamoDB table
 func (a *AWSAsserter) getDynamoDBTable(tableName string) (*types.TableDescription, error) {
 	client, err := a.createDynamoDBClient()
@@ -175,6 +343,23 @@ func (a *AWSAsserter) createDynamoDBClient() (*dynamodb.Client, error) {
 	return dynamodb.NewFromConfig(*cfg, opts...), nil
 }
 
+// Helper method to create a DynamoDB Streams client
+func (a *AWSAsserter) createDynamoDBStreamsClient() (*dynamodbstreams.Client, error) {
+	cfg, err := awshelpers.NewAuthenticatedSessionWithDefaultRegion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %w", err)
+	}
+
+	opts := make([]func(*dynamodbstreams.Options), 0, 1)
+	if endpoint, ok := awshelpers.GetVirtualCloudEndpoint("dynamodbstreams"); ok {
+		opts = append(opts, func(o *dynamodbstreams.Options) {
+			o.EndpointResolver = dynamodbstreams.EndpointResolverFromURL(endpoint)
+		})
+	}
+
+	return dynamodbstreams.NewFromConfig(*cfg, opts...), nil
+}
+
 // Helper method to get the billing mode of a DynamoDB table
 func getDynamoDBBTableBillingMode(tableDesc *types.TableDescription) (types.BillingMode, error) {
 	if tableDesc == nil {
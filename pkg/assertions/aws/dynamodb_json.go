@@ -0,0 +1,331 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// MarshalAttributeValue converts a Go value to a DynamoDB attribute value. Strings become S,
+// numbers and json.Numbers N, bools BOOL, nil NULL, byte slices B, other slices and arrays L,
+// and maps with string keys M.
+func MarshalAttributeValue(v interface{}) (types.AttributeValue, error) {
+	switch value := v.(type) {
+	case nil:
+		return &types.AttributeValueMemberNULL{Value: true}, nil
+	case types.AttributeValue:
+		return value, nil
+	case string:
+		return &types.AttributeValueMemberS{Value: value}, nil
+	case json.Number:
+		return &types.AttributeValueMemberN{Value: value.String()}, nil
+	case bool:
+		return &types.AttributeValueMemberBOOL{Value: value}, nil
+	case []byte:
+		return &types.AttributeValueMemberB{Value: value}, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &types.AttributeValueMemberN{Value: strconv.FormatInt(rv.Int(), 10)}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &types.AttributeValueMemberN{Value: strconv.FormatUint(rv.Uint(), 10)}, nil
+	case reflect.Float32, reflect.Float64:
+		return &types.AttributeValueMemberN{Value: strconv.FormatFloat(rv.Float(), 'f', -1, 64)}, nil
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return &types.AttributeValueMemberNULL{Value: true}, nil
+		}
+		return MarshalAttributeValue(rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		list := make([]types.AttributeValue, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			element, err := MarshalAttributeValue(rv.Index(i).Interface())
+			if err != nil {
+				return nil, fmt.Errorf("list element %d: %w", i, err)
+			}
+			list = append(list, element)
+		}
+		return &types.AttributeValueMemberL{Value: list}, nil
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("cannot marshal map with %s keys to a DynamoDB attribute value", rv.Type().Key())
+		}
+		m := make(map[string]types.AttributeValue, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			element, err := MarshalAttributeValue(iter.Value().Interface())
+			if err != nil {
+				return nil, fmt.Errorf("map key %s: %w", iter.Key().String(), err)
+			}
+			m[iter.Key().String()] = element
+		}
+		return &types.AttributeValueMemberM{Value: m}, nil
+	}
+
+	return nil, fmt.Errorf("cannot marshal %T to a DynamoDB attribute value", v)
+}
+
+// UnmarshalAttributeValue converts a DynamoDB attribute value to a Go value. N becomes a
+// float64, like a number decoded by encoding/json, L and the sets become []interface{}, M
+// becomes map[string]interface{} and NULL becomes nil, so decoded items can be compared with
+// values decoded from JSON.
+func UnmarshalAttributeValue(av types.AttributeValue) (interface{}, error) {
+	switch value := av.(type) {
+	case *types.AttributeValueMemberS:
+		return value.Value, nil
+	case *types.AttributeValueMemberN:
+		return parseAttributeNumber(value.Value)
+	case *types.AttributeValueMemberBOOL:
+		return value.Value, nil
+	case *types.AttributeValueMemberNULL:
+		return nil, nil
+	case *types.AttributeValueMemberB:
+		return value.Value, nil
+	case *types.AttributeValueMemberL:
+		list := make([]interface{}, 0, len(value.Value))
+		for i, element := range value.Value {
+			decoded, err := UnmarshalAttributeValue(element)
+			if err != nil {
+				return nil, fmt.Errorf("list element %d: %w", i, err)
+			}
+			list = append(list, decoded)
+		}
+		return list, nil
+	case *types.AttributeValueMemberM:
+		return UnmarshalItem(value.Value)
+	case *types.AttributeValueMemberSS:
+		list := make([]interface{}, 0, len(value.Value))
+		for _, s := range value.Value {
+			list = append(list, s)
+		}
+		return list, nil
+	case *types.AttributeValueMemberNS:
+		list := make([]interface{}, 0, len(value.Value))
+		for _, n := range value.Value {
+			number, err := parseAttributeNumber(n)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, number)
+		}
+		return list, nil
+	case *types.AttributeValueMemberBS:
+		list := make([]interface{}, 0, len(value.Value))
+		for _, b := range value.Value {
+			list = append(list, b)
+		}
+		return list, nil
+	}
+
+	return nil, fmt.Errorf("unsupported DynamoDB attribute value %T", av)
+}
+
+// MarshalItem converts a map of Go values to a DynamoDB item
+func MarshalItem(item map[string]interface{}) (map[string]types.AttributeValue, error) {
+	attributes := make(map[string]types.AttributeValue, len(item))
+	for name, value := range item {
+		av, err := MarshalAttributeValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %w", name, err)
+		}
+		attributes[name] = av
+	}
+	return attributes, nil
+}
+
+// UnmarshalItem converts a DynamoDB item to a map of Go values
+func UnmarshalItem(item map[string]types.AttributeValue) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(item))
+	for name, av := range item {
+		value, err := UnmarshalAttributeValue(av)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %w", name, err)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// MarshalAttributeValueJSON encodes a Go value as DynamoDB attribute value JSON, e.g. "abc"
+// as {"S":"abc"}
+func MarshalAttributeValueJSON(v interface{}) ([]byte, error) {
+	av, err := MarshalAttributeValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(attributeValueWire(av))
+}
+
+// UnmarshalAttributeValueJSON decodes DynamoDB attribute value JSON, like {"N":"42"}, to a Go value
+func UnmarshalAttributeValueJSON(data []byte) (interface{}, error) {
+	av, err := parseAttributeValueJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalAttributeValue(av)
+}
+
+// MarshalItemJSON encodes a map of Go values as a DynamoDB item in JSON, e.g.
+// {"id":{"S":"abc"},"total":{"N":"42"}}
+func MarshalItemJSON(item map[string]interface{}) ([]byte, error) {
+	attributes, err := MarshalItem(item)
+	if err != nil {
+		return nil, err
+	}
+	wire := make(map[string]interface{}, len(attributes))
+	for name, av := range attributes {
+		wire[name] = attributeValueWire(av)
+	}
+	return json.Marshal(wire)
+}
+
+// UnmarshalItemJSON decodes a DynamoDB item in JSON to a map of Go values
+func UnmarshalItemJSON(data []byte) (map[string]interface{}, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid DynamoDB item JSON: %w", err)
+	}
+
+	item := make(map[string]types.AttributeValue, len(raw))
+	for name, data := range raw {
+		av, err := parseAttributeValueJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %w", name, err)
+		}
+		item[name] = av
+	}
+	return UnmarshalItem(item)
+}
+
+// attributeValueWire returns the JSON form of an attribute value, a single-key object
+// naming its type
+func attributeValueWire(av types.AttributeValue) interface{} {
+	switch value := av.(type) {
+	case *types.AttributeValueMemberS:
+		return map[string]interface{}{"S": value.Value}
+	case *types.AttributeValueMemberN:
+		return map[string]interface{}{"N": value.Value}
+	case *types.AttributeValueMemberBOOL:
+		return map[string]interface{}{"BOOL": value.Value}
+	case *types.AttributeValueMemberNULL:
+		return map[string]interface{}{"NULL": true}
+	case *types.AttributeValueMemberB:
+		return map[string]interface{}{"B": value.Value}
+	case *types.AttributeValueMemberSS:
+		return map[string]interface{}{"SS": value.Value}
+	case *types.AttributeValueMemberNS:
+		return map[string]interface{}{"NS": value.Value}
+	case *types.AttributeValueMemberBS:
+		return map[string]interface{}{"BS": value.Value}
+	case *types.AttributeValueMemberL:
+		list := make([]interface{}, 0, len(value.Value))
+		for _, element := range value.Value {
+			list = append(list, attributeValueWire(element))
+		}
+		return map[string]interface{}{"L": list}
+	case *types.AttributeValueMemberM:
+		m := make(map[string]interface{}, len(value.Value))
+		for name, element := range value.Value {
+			m[name] = attributeValueWire(element)
+		}
+		return map[string]interface{}{"M": m}
+	}
+	return nil
+}
+
+// parseAttributeValueJSON parses the JSON form of an attribute value
+func parseAttributeValueJSON(data []byte) (types.AttributeValue, error) {
+	var wire map[string]json.RawMessage
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return nil, fmt.Errorf("invalid DynamoDB attribute value JSON: %w", err)
+	}
+	if len(wire) != 1 {
+		names := make([]string, 0, len(wire))
+		for name := range wire {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("a DynamoDB attribute value must have exactly one type, got %v", names)
+	}
+
+	for typeName, raw := range wire {
+		switch typeName {
+		case "S":
+			var s string
+			err := json.Unmarshal(raw, &s)
+			return &types.AttributeValueMemberS{Value: s}, err
+		case "N":
+			var n string
+			err := json.Unmarshal(raw, &n)
+			return &types.AttributeValueMemberN{Value: n}, err
+		case "BOOL":
+			var b bool
+			err := json.Unmarshal(raw, &b)
+			return &types.AttributeValueMemberBOOL{Value: b}, err
+		case "NULL":
+			return &types.AttributeValueMemberNULL{Value: true}, nil
+		case "B":
+			var b []byte
+			err := json.Unmarshal(raw, &b)
+			return &types.AttributeValueMemberB{Value: b}, err
+		case "SS":
+			var ss []string
+			err := json.Unmarshal(raw, &ss)
+			return &types.AttributeValueMemberSS{Value: ss}, err
+		case "NS":
+			var ns []string
+			err := json.Unmarshal(raw, &ns)
+			return &types.AttributeValueMemberNS{Value: ns}, err
+		case "BS":
+			var bs [][]byte
+			err := json.Unmarshal(raw, &bs)
+			return &types.AttributeValueMemberBS{Value: bs}, err
+		case "L":
+			var elements []json.RawMessage
+			if err := json.Unmarshal(raw, &elements); err != nil {
+				return nil, err
+			}
+			list := make([]types.AttributeValue, 0, len(elements))
+			for i, element := range elements {
+				av, err := parseAttributeValueJSON(element)
+				if err != nil {
+					return nil, fmt.Errorf("list element %d: %w", i, err)
+				}
+				list = append(list, av)
+			}
+			return &types.AttributeValueMemberL{Value: list}, nil
+		case "M":
+			var elements map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &elements); err != nil {
+				return nil, err
+			}
+			m := make(map[string]types.AttributeValue, len(elements))
+			for name, element := range elements {
+				av, err := parseAttributeValueJSON(element)
+				if err != nil {
+					return nil, fmt.Errorf("map key %s: %w", name, err)
+				}
+				m[name] = av
+			}
+			return &types.AttributeValueMemberM{Value: m}, nil
+		default:
+			return nil, fmt.Errorf("unsupported DynamoDB attribute value type %s", typeName)
+		}
+	}
+	return nil, nil
+}
+
+// parseAttributeNumber parses the string form of an N attribute value
+func parseAttributeNumber(n string) (float64, error) {
+	number, err := strconv.ParseFloat(n, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid DynamoDB number %q: %w", n, err)
+	}
+	return number, nil
+}
@@ -0,0 +1,81 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalAttributeValueJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{"string", "abc", `{"S":"abc"}`},
+		{"int", 42, `{"N":"42"}`},
+		{"float", 1.5, `{"N":"1.5"}`},
+		{"bool", true, `{"BOOL":true}`},
+		{"nil", nil, `{"NULL":true}`},
+		{"list", []interface{}{"a", 1}, `{"L":[{"S":"a"},{"N":"1"}]}`},
+		{"map", map[string]interface{}{"k": "v"}, `{"M":{"k":{"S":"v"}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := MarshalAttributeValueJSON(tt.value)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(data))
+		})
+	}
+
+	_, err := MarshalAttributeValueJSON(struct{}{})
+	assert.Error(t, err)
+}
+
+func TestUnmarshalAttributeValueJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected interface{}
+	}{
+		{"string", `{"S":"abc"}`, "abc"},
+		{"number", `{"N":"42"}`, float64(42)},
+		{"bool", `{"BOOL":false}`, false},
+		{"null", `{"NULL":true}`, nil},
+		{"list", `{"L":[{"S":"a"},{"N":"1"}]}`, []interface{}{"a", float64(1)}},
+		{"map", `{"M":{"k":{"L":[]}}}`, map[string]interface{}{"k": []interface{}{}}},
+		{"string set", `{"SS":["a","b"]}`, []interface{}{"a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := UnmarshalAttributeValueJSON([]byte(tt.data))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+
+	_, err := UnmarshalAttributeValueJSON([]byte(`{"S":"a","N":"1"}`))
+	assert.ErrorContains(t, err, "exactly one type")
+
+	_, err = UnmarshalAttributeValueJSON([]byte(`{"N":"abc"}`))
+	assert.ErrorContains(t, err, "invalid DynamoDB number")
+}
+
+func TestItemJSONRoundTrip(t *testing.T) {
+	item := map[string]interface{}{
+		"id":    "order-1",
+		"total": float64(42),
+		"tags":  []interface{}{"new", nil},
+	}
+
+	data, err := MarshalItemJSON(item)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":{"S":"order-1"},"total":{"N":"42"},"tags":{"L":[{"S":"new"},{"NULL":true}]}}`, string(data))
+
+	decoded, err := UnmarshalItemJSON(data)
+	require.NoError(t, err)
+	assert.Equal(t, item, decoded)
+}
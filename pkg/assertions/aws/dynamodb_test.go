@@ -0,0 +1,122 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robmorgan/infraspec/pkg/embedded"
+)
+
+func TestAssertGlobalSecondaryIndex(t *testing.T) {
+	emu := embedded.New()
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+	t.Setenv("AWS_ENDPOINT_URL_DYNAMODB", emu.Endpoint())
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_REGION", "us-east-1")
+
+	a := NewAWSAsserter()
+	client, err := a.createDynamoDBClient()
+	require.NoError(t, err)
+
+	_, err = client.CreateTable(context.Background(), &dynamodb.CreateTableInput{
+		TableName:   aws.String("orders"),
+		BillingMode: types.BillingModePayPerRequest,
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("id"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("customer"), AttributeType: types.ScalarAttributeTypeS},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("id"), KeyType: types.KeyTypeHash},
+		},
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndex{{
+			IndexName: aws.String("by-customer"),
+			KeySchema: []types.KeySchemaElement{
+				{AttributeName: aws.String("customer"), KeyType: types.KeyTypeHash},
+				{AttributeName: aws.String("id"), KeyType: types.KeyTypeRange},
+			},
+			Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+		}},
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, a.AssertGlobalSecondaryIndex("orders", "by-customer", ""))
+	assert.NoError(t, a.AssertGlobalSecondaryIndex("orders", "by-customer", "customer"))
+
+	err = a.AssertGlobalSecondaryIndex("orders", "by-customer", "id")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "to have hash key id, but got customer")
+
+	err = a.AssertGlobalSecondaryIndex("orders", "by-date", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not have global secondary index by-date")
+}
+
+func TestAssertItem(t *testing.T) {
+	emu := embedded.New()
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+	t.Setenv("AWS_ENDPOINT_URL_DYNAMODB", emu.Endpoint())
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_REGION", "us-east-1")
+
+	a := NewAWSAsserter()
+	client, err := a.createDynamoDBClient()
+	require.NoError(t, err)
+
+	_, err = client.CreateTable(context.Background(), &dynamodb.CreateTableInput{
+		TableName:   aws.String("orders"),
+		BillingMode: types.BillingModePayPerRequest,
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("id"), AttributeType: types.ScalarAttributeTypeS},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("id"), KeyType: types.KeyTypeHash},
+		},
+	})
+	require.NoError(t, err)
+
+	item, err := MarshalItem(map[string]interface{}{
+		"id":      "order-1",
+		"total":   42,
+		"paid":    true,
+		"lines":   []interface{}{"widget", 2},
+		"address": map[string]interface{}{"city": "Sydney"},
+	})
+	require.NoError(t, err)
+	_, err = client.PutItem(context.Background(), &dynamodb.PutItemInput{TableName: aws.String("orders"), Item: item})
+	require.NoError(t, err)
+
+	assert.NoError(t, a.AssertItem("orders", map[string]interface{}{"id": "order-1"}))
+	assert.NoError(t, a.AssertItem("orders", map[string]interface{}{
+		"id":      "order-1",
+		"total":   float64(42),
+		"paid":    true,
+		"lines":   []interface{}{"widget", float64(2)},
+		"address": map[string]interface{}{"city": "Sydney"},
+	}))
+
+	err = a.AssertItem("orders", map[string]interface{}{"id": "order-1", "total": float64(41)})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected attribute total")
+
+	err = a.AssertItem("orders", map[string]interface{}{"id": "order-1", "status": "shipped"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no attribute status")
+
+	err = a.AssertItem("orders", map[string]interface{}{"id": "order-2"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no item with key")
+
+	err = a.AssertItem("orders", map[string]interface{}{"total": float64(42)})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must include the key attribute id")
+}
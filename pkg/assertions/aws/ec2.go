@@ -3,6 +3,8 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -24,14 +26,15 @@ type EC2Asserter interface {
 	AssertEC2InstanceSubnet(instanceID, subnetID, region string) error
 	AssertEC2InstanceVPC(instanceID, vpcID, region string) error
 	AssertEC2InstanceSecurityGroups(instanceID string, securityGroupIDs []string, region string) error
-	AssertEC2InstanceTags(instanceID string, expectedTags map[string]string, region string) error
+	AssertEC2InstanceTags(instanceID string, expectedTags map[string]string, mode TagMatchMode, region string) error
+	AssertEC2InstanceCountWithTag(tagKey, tagValue string, count int, region string) error
 
 	// VPC assertions
 	AssertVPCExists(vpcID, region string) error
 	AssertVPCState(vpcID, state, region string) error
 	AssertVPCCIDR(vpcID, cidrBlock, region string) error
 	AssertVPCIsDefault(vpcID string, isDefault bool, region string) error
-	AssertVPCTags(vpcID string, expectedTags map[string]string, region string) error
+	AssertVPCTags(vpcID string, expectedTags map[string]string, mode TagMatchMode, region string) error
 
 	// Subnet assertions
 	AssertSubnetExists(subnetID, region string) error
@@ -39,29 +42,40 @@ type EC2Asserter interface {
 	AssertSubnetCIDR(subnetID, cidrBlock, region string) error
 	AssertSubnetVPC(subnetID, vpcID, region string) error
 	AssertSubnetAvailabilityZone(subnetID, az, region string) error
-	AssertSubnetTags(subnetID string, expectedTags map[string]string, region string) error
+	AssertSubnetTags(subnetID string, expectedTags map[string]string, mode TagMatchMode, region string) error
 
 	// Security Group assertions
 	AssertSecurityGroupExists(groupID, region string) error
 	AssertSecurityGroupName(groupID, groupName, region string) error
 	AssertSecurityGroupVPC(groupID, vpcID, region string) error
 	AssertSecurityGroupDescription(groupID, description, region string) error
-	AssertSecurityGroupTags(groupID string, expectedTags map[string]string, region string) error
+	AssertSecurityGroupTags(groupID string, expectedTags map[string]string, mode TagMatchMode, region string) error
+	AssertSecurityGroupAllowsIngress(groupID string, port int, cidr, region string) error
+	AssertSecurityGroupDeniesIngress(groupID string, port int, cidr, region string) error
 
 	// Internet Gateway assertions
 	AssertInternetGatewayExists(igwID, region string) error
 	AssertInternetGatewayAttachedToVPC(igwID, vpcID, region string) error
-	AssertInternetGatewayTags(igwID string, expectedTags map[string]string, region string) error
+	AssertInternetGatewayTags(igwID string, expectedTags map[string]string, mode TagMatchMode, region string) error
+
+	// VPC Endpoint assertions
+	AssertVPCHasEndpoint(vpcID, serviceName, region string) error
 
 	// EBS Volume assertions
 	AssertEBSVolumeExists(volumeID, region string) error
 	AssertEBSVolumeState(volumeID, state, region string) error
 	AssertEBSVolumeSize(volumeID string, sizeGB int32, region string) error
 	AssertEBSVolumeType(volumeID, volumeType, region string) error
-	AssertEBSVolumeTags(volumeID string, expectedTags map[string]string, region string) error
+	AssertEBSVolumeTags(volumeID string, expectedTags map[string]string, mode TagMatchMode, region string) error
 
 	// Key Pair assertions
 	AssertKeyPairExists(keyName, region string) error
+
+	// Attribute lookups, used to capture values into scenario variables
+	GetEC2InstanceAttribute(instanceID, attribute, region string) (string, error)
+	GetVPCAttribute(vpcID, attribute, region string) (string, error)
+	GetSubnetAttribute(subnetID, attribute, region string) (string, error)
+	GetSecurityGroupAttribute(groupID, attribute, region string) (string, error)
 }
 
 // ==================== Instance Assertions ====================
@@ -167,14 +181,46 @@ func (a *AWSAsserter) AssertEC2InstanceSecurityGroups(instanceID string, securit
 	return nil
 }
 
-// AssertEC2InstanceTags checks if an EC2 instance has the expected tags
-func (a *AWSAsserter) AssertEC2InstanceTags(instanceID string, expectedTags map[string]string, region string) error {
+// AssertEC2InstanceTags checks if an EC2 instance has the expected tags, compared according to mode
+func (a *AWSAsserter) AssertEC2InstanceTags(instanceID string, expectedTags map[string]string, mode TagMatchMode, region string) error {
 	instance, err := a.getEC2Instance(instanceID, region)
 	if err != nil {
 		return err
 	}
 
-	return a.checkTags(instance.Tags, expectedTags)
+	return a.checkTags(instance.Tags, expectedTags, mode)
+}
+
+// AssertEC2InstanceCountWithTag checks if exactly count running EC2 instances have the tag
+func (a *AWSAsserter) AssertEC2InstanceCountWithTag(tagKey, tagValue string, count int, region string) error {
+	client, err := awshelpers.NewEc2FullClient(region)
+	if err != nil {
+		return err
+	}
+
+	paginator := ec2.NewDescribeInstancesPaginator(client, &ec2.DescribeInstancesInput{
+		Filters: []types.Filter{
+			{Name: aws.String("tag:" + tagKey), Values: []string{tagValue}},
+			{Name: aws.String("instance-state-name"), Values: []string{string(types.InstanceStateNameRunning)}},
+		},
+	})
+
+	actual := 0
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return fmt.Errorf("error describing instances with tag %s=%s: %w", tagKey, tagValue, err)
+		}
+		for _, reservation := range page.Reservations {
+			actual += len(reservation.Instances)
+		}
+	}
+
+	if actual != count {
+		return fmt.Errorf("expected %d running EC2 instances with tag %s=%s, but found %d", count, tagKey, tagValue, actual)
+	}
+
+	return nil
 }
 
 // ==================== VPC Assertions ====================
@@ -227,14 +273,14 @@ func (a *AWSAsserter) AssertVPCIsDefault(vpcID string, isDefault bool, region st
 	return nil
 }
 
-// AssertVPCTags checks if a VPC has the expected tags
-func (a *AWSAsserter) AssertVPCTags(vpcID string, expectedTags map[string]string, region string) error {
+// AssertVPCTags checks if a VPC has the expected tags, compared according to mode
+func (a *AWSAsserter) AssertVPCTags(vpcID string, expectedTags map[string]string, mode TagMatchMode, region string) error {
 	vpc, err := a.getVPC(vpcID, region)
 	if err != nil {
 		return err
 	}
 
-	return a.checkTags(vpc.Tags, expectedTags)
+	return a.checkTags(vpc.Tags, expectedTags, mode)
 }
 
 // ==================== Subnet Assertions ====================
@@ -301,14 +347,14 @@ func (a *AWSAsserter) AssertSubnetAvailabilityZone(subnetID, az, region string)
 	return nil
 }
 
-// AssertSubnetTags checks if a subnet has the expected tags
-func (a *AWSAsserter) AssertSubnetTags(subnetID string, expectedTags map[string]string, region string) error {
+// AssertSubnetTags checks if a subnet has the expected tags, compared according to mode
+func (a *AWSAsserter) AssertSubnetTags(subnetID string, expectedTags map[string]string, mode TagMatchMode, region string) error {
 	subnet, err := a.getSubnet(subnetID, region)
 	if err != nil {
 		return err
 	}
 
-	return a.checkTags(subnet.Tags, expectedTags)
+	return a.checkTags(subnet.Tags, expectedTags, mode)
 }
 
 // ==================== Security Group Assertions ====================
@@ -361,14 +407,52 @@ func (a *AWSAsserter) AssertSecurityGroupDescription(groupID, description, regio
 	return nil
 }
 
-// AssertSecurityGroupTags checks if a security group has the expected tags
-func (a *AWSAsserter) AssertSecurityGroupTags(groupID string, expectedTags map[string]string, region string) error {
+// AssertSecurityGroupTags checks if a security group has the expected tags, compared according to mode
+func (a *AWSAsserter) AssertSecurityGroupTags(groupID string, expectedTags map[string]string, mode TagMatchMode, region string) error {
 	sg, err := a.getSecurityGroup(groupID, region)
 	if err != nil {
 		return err
 	}
 
-	return a.checkTags(sg.Tags, expectedTags)
+	return a.checkTags(sg.Tags, expectedTags, mode)
+}
+
+// AssertSecurityGroupAllowsIngress checks if a security group has an ingress rule allowing traffic to
+// the port from the CIDR range
+func (a *AWSAsserter) AssertSecurityGroupAllowsIngress(groupID string, port int, cidr, region string) error {
+	rules, err := a.getSecurityGroupIngressRules(groupID, region)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if ruleAllowsPort(rule, port) && ruleCIDR(rule) == cidr {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("security group %s does not allow ingress on port %d from %s", groupID, port, cidr)
+}
+
+// AssertSecurityGroupDeniesIngress checks that no ingress rule of a security group allows traffic to
+// the port from the CIDR range, listing the rules that do
+func (a *AWSAsserter) AssertSecurityGroupDeniesIngress(groupID string, port int, cidr, region string) error {
+	rules, err := a.getSecurityGroupIngressRules(groupID, region)
+	if err != nil {
+		return err
+	}
+
+	var allowing []string
+	for _, rule := range rules {
+		if ruleAllowsPort(rule, port) && ruleCIDR(rule) == cidr {
+			allowing = append(allowing, describeSecurityGroupRule(rule))
+		}
+	}
+
+	if len(allowing) > 0 {
+		return fmt.Errorf("security group %s allows ingress on port %d from %s: %s", groupID, port, cidr, strings.Join(allowing, ", "))
+	}
+	return nil
 }
 
 // ==================== Internet Gateway Assertions ====================
@@ -395,14 +479,49 @@ func (a *AWSAsserter) AssertInternetGatewayAttachedToVPC(igwID, vpcID, region st
 	return fmt.Errorf("internet gateway %s is not attached to VPC %s", igwID, vpcID)
 }
 
-// AssertInternetGatewayTags checks if an internet gateway has the expected tags
-func (a *AWSAsserter) AssertInternetGatewayTags(igwID string, expectedTags map[string]string, region string) error {
+// AssertInternetGatewayTags checks if an internet gateway has the expected tags, compared according to mode
+func (a *AWSAsserter) AssertInternetGatewayTags(igwID string, expectedTags map[string]string, mode TagMatchMode, region string) error {
 	igw, err := a.getInternetGateway(igwID, region)
 	if err != nil {
 		return err
 	}
 
-	return a.checkTags(igw.Tags, expectedTags)
+	return a.checkTags(igw.Tags, expectedTags, mode)
+}
+
+// ==================== VPC Endpoint Assertions ====================
+
+// AssertVPCHasEndpoint checks if a VPC has an endpoint for a service. A service name without
+// dots, like "s3", is the AWS service of that name in the region.
+func (a *AWSAsserter) AssertVPCHasEndpoint(vpcID, serviceName, region string) error {
+	if !strings.Contains(serviceName, ".") {
+		serviceName = fmt.Sprintf("com.amazonaws.%s.%s", region, serviceName)
+	}
+
+	client, err := awshelpers.NewEc2FullClient(region)
+	if err != nil {
+		return err
+	}
+
+	result, err := client.DescribeVpcEndpoints(context.TODO(), &ec2.DescribeVpcEndpointsInput{
+		Filters: []types.Filter{
+			{Name: aws.String("vpc-id"), Values: []string{vpcID}},
+			{Name: aws.String("service-name"), Values: []string{serviceName}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error describing endpoints of VPC %s: %w", vpcID, err)
+	}
+
+	// Deleted endpoints are still described for a while
+	for _, endpoint := range result.VpcEndpoints {
+		state := strings.ToLower(string(endpoint.State))
+		if state != "deleted" && state != "deleting" {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("VPC %s has no endpoint for service %s", vpcID, serviceName)
 }
 
 // ==================== EBS Volume Assertions ====================
@@ -455,14 +574,14 @@ func (a *AWSAsserter) AssertEBSVolumeType(volumeID, volumeType, region string) e
 	return nil
 }
 
-// AssertEBSVolumeTags checks if an EBS volume has the expected tags
-func (a *AWSAsserter) AssertEBSVolumeTags(volumeID string, expectedTags map[string]string, region string) error {
+// AssertEBSVolumeTags checks if an EBS volume has the expected tags, compared according to mode
+func (a *AWSAsserter) AssertEBSVolumeTags(volumeID string, expectedTags map[string]string, mode TagMatchMode, region string) error {
 	volume, err := a.getEBSVolume(volumeID, region)
 	if err != nil {
 		return err
 	}
 
-	return a.checkTags(volume.Tags, expectedTags)
+	return a.checkTags(volume.Tags, expectedTags, mode)
 }
 
 // ==================== Key Pair Assertions ====================
@@ -490,6 +609,86 @@ func (a *AWSAsserter) AssertKeyPairExists(keyName, region string) error {
 	return nil
 }
 
+// ==================== Attribute Lookups ====================
+
+// GetEC2InstanceAttribute returns an attribute of an EC2 instance, e.g. "private IP address"
+func (a *AWSAsserter) GetEC2InstanceAttribute(instanceID, attribute, region string) (string, error) {
+	instance, err := a.getEC2Instance(instanceID, region)
+	if err != nil {
+		return "", err
+	}
+
+	state := ""
+	if instance.State != nil {
+		state = string(instance.State.Name)
+	}
+	return lookupAttribute("EC2 instance", attribute, map[string]string{
+		"instance type":      string(instance.InstanceType),
+		"state":              state,
+		"AMI":                aws.ToString(instance.ImageId),
+		"subnet":             aws.ToString(instance.SubnetId),
+		"VPC":                aws.ToString(instance.VpcId),
+		"private IP address": aws.ToString(instance.PrivateIpAddress),
+		"public IP address":  aws.ToString(instance.PublicIpAddress),
+	})
+}
+
+// GetVPCAttribute returns an attribute of a VPC, e.g. "CIDR block"
+func (a *AWSAsserter) GetVPCAttribute(vpcID, attribute, region string) (string, error) {
+	vpc, err := a.getVPC(vpcID, region)
+	if err != nil {
+		return "", err
+	}
+
+	return lookupAttribute("VPC", attribute, map[string]string{
+		"CIDR block": aws.ToString(vpc.CidrBlock),
+		"state":      string(vpc.State),
+	})
+}
+
+// GetSubnetAttribute returns an attribute of a subnet, e.g. "availability zone"
+func (a *AWSAsserter) GetSubnetAttribute(subnetID, attribute, region string) (string, error) {
+	subnet, err := a.getSubnet(subnetID, region)
+	if err != nil {
+		return "", err
+	}
+
+	return lookupAttribute("subnet", attribute, map[string]string{
+		"CIDR block":        aws.ToString(subnet.CidrBlock),
+		"state":             string(subnet.State),
+		"VPC":               aws.ToString(subnet.VpcId),
+		"availability zone": aws.ToString(subnet.AvailabilityZone),
+	})
+}
+
+// GetSecurityGroupAttribute returns an attribute of a security group, e.g. "name"
+func (a *AWSAsserter) GetSecurityGroupAttribute(groupID, attribute, region string) (string, error) {
+	sg, err := a.getSecurityGroup(groupID, region)
+	if err != nil {
+		return "", err
+	}
+
+	return lookupAttribute("security group", attribute, map[string]string{
+		"name":        aws.ToString(sg.GroupName),
+		"VPC":         aws.ToString(sg.VpcId),
+		"description": aws.ToString(sg.Description),
+	})
+}
+
+// lookupAttribute returns the named attribute, or an error listing the attributes the resource has
+func lookupAttribute(resource, attribute string, attributes map[string]string) (string, error) {
+	if value, ok := attributes[attribute]; ok {
+		return value, nil
+	}
+
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, fmt.Sprintf("%q", name))
+	}
+	sort.Strings(names)
+	return "", fmt.Errorf("unknown %s attribute %q, expected one of %s", resource, attribute, strings.Join(names, ", "))
+}
+
 // ==================== Helper Methods ====================
 
 // getEC2Instance retrieves an EC2 instance by ID
@@ -584,6 +783,69 @@ func (a *AWSAsserter) getSecurityGroup(groupID, region string) (*types.SecurityG
 	return &result.SecurityGroups[0], nil
 }
 
+// getSecurityGroupIngressRules retrieves the ingress rules of a security group
+func (a *AWSAsserter) getSecurityGroupIngressRules(groupID, region string) ([]types.SecurityGroupRule, error) {
+	if _, err := a.getSecurityGroup(groupID, region); err != nil {
+		return nil, err
+	}
+
+	client, err := awshelpers.NewEc2FullClient(region)
+	if err != nil {
+		return nil, err
+	}
+
+	paginator := ec2.NewDescribeSecurityGroupRulesPaginator(client, &ec2.DescribeSecurityGroupRulesInput{
+		Filters: []types.Filter{{Name: aws.String("group-id"), Values: []string{groupID}}},
+	})
+
+	var rules []types.SecurityGroupRule
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("error describing rules of security group %s: %w", groupID, err)
+		}
+		for _, rule := range page.SecurityGroupRules {
+			if !aws.ToBool(rule.IsEgress) {
+				rules = append(rules, rule)
+			}
+		}
+	}
+
+	return rules, nil
+}
+
+// ruleAllowsPort reports whether a security group rule allows traffic to the port. Rules for all
+// protocols allow every port; TCP and UDP rules allow the ports in their range.
+func ruleAllowsPort(rule types.SecurityGroupRule, port int) bool {
+	switch strings.ToLower(aws.ToString(rule.IpProtocol)) {
+	case "-1", "all":
+		return true
+	case "tcp", "udp", "6", "17":
+		return int(aws.ToInt32(rule.FromPort)) <= port && port <= int(aws.ToInt32(rule.ToPort))
+	default:
+		return false
+	}
+}
+
+// ruleCIDR returns the IPv4 or IPv6 CIDR range a security group rule applies to, or "" for a rule
+// that references a security group or prefix list
+func ruleCIDR(rule types.SecurityGroupRule) string {
+	if rule.CidrIpv4 != nil {
+		return aws.ToString(rule.CidrIpv4)
+	}
+	return aws.ToString(rule.CidrIpv6)
+}
+
+// describeSecurityGroupRule returns a rule's ID, protocol and port range, like
+// "sgr-0123456789abcdef0 (tcp 0-65535)"
+func describeSecurityGroupRule(rule types.SecurityGroupRule) string {
+	protocol := aws.ToString(rule.IpProtocol)
+	if protocol == "-1" {
+		return fmt.Sprintf("%s (all traffic)", aws.ToString(rule.SecurityGroupRuleId))
+	}
+	return fmt.Sprintf("%s (%s %d-%d)", aws.ToString(rule.SecurityGroupRuleId), protocol, aws.ToInt32(rule.FromPort), aws.ToInt32(rule.ToPort))
+}
+
 // getInternetGateway retrieves an internet gateway by ID
 func (a *AWSAsserter) getInternetGateway(igwID, region string) (*types.InternetGateway, error) {
 	client, err := awshelpers.NewEc2FullClient(region)
@@ -630,22 +892,12 @@ func (a *AWSAsserter) getEBSVolume(volumeID, region string) (*types.Volume, erro
 	return &result.Volumes[0], nil
 }
 
-// checkTags compares expected tags against actual tags
-func (a *AWSAsserter) checkTags(actualTags []types.Tag, expectedTags map[string]string) error {
+// checkTags compares expected tags against actual tags using the given match mode
+func (a *AWSAsserter) checkTags(actualTags []types.Tag, expectedTags map[string]string, mode TagMatchMode) error {
 	tagMap := make(map[string]string)
 	for _, tag := range actualTags {
 		tagMap[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
 	}
 
-	for key, value := range expectedTags {
-		actualValue, exists := tagMap[key]
-		if !exists {
-			return fmt.Errorf("expected tag %s not found", key)
-		}
-		if actualValue != value {
-			return fmt.Errorf("expected tag %s to have value %s, but got %s", key, value, actualValue)
-		}
-	}
-
-	return nil
+	return CompareTags(tagMap, expectedTags, mode)
 }
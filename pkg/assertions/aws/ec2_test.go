@@ -0,0 +1,37 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleAllowsPort(t *testing.T) {
+	tcpRange := types.SecurityGroupRule{IpProtocol: aws.String("tcp"), FromPort: aws.Int32(8000), ToPort: aws.Int32(8080)}
+	assert.True(t, ruleAllowsPort(tcpRange, 8000))
+	assert.True(t, ruleAllowsPort(tcpRange, 8080))
+	assert.False(t, ruleAllowsPort(tcpRange, 443))
+
+	allTraffic := types.SecurityGroupRule{IpProtocol: aws.String("-1"), FromPort: aws.Int32(-1), ToPort: aws.Int32(-1)}
+	assert.True(t, ruleAllowsPort(allTraffic, 22))
+
+	icmp := types.SecurityGroupRule{IpProtocol: aws.String("icmp"), FromPort: aws.Int32(-1), ToPort: aws.Int32(-1)}
+	assert.False(t, ruleAllowsPort(icmp, 22))
+}
+
+func TestRuleCIDR(t *testing.T) {
+	assert.Equal(t, "0.0.0.0/0", ruleCIDR(types.SecurityGroupRule{CidrIpv4: aws.String("0.0.0.0/0")}))
+	assert.Equal(t, "::/0", ruleCIDR(types.SecurityGroupRule{CidrIpv6: aws.String("::/0")}))
+	assert.Equal(t, "", ruleCIDR(types.SecurityGroupRule{ReferencedGroupInfo: &types.ReferencedSecurityGroup{GroupId: aws.String("sg-1")}}))
+}
+
+func TestDescribeSecurityGroupRule(t *testing.T) {
+	assert.Equal(t, "sgr-1 (tcp 22-22)", describeSecurityGroupRule(types.SecurityGroupRule{
+		SecurityGroupRuleId: aws.String("sgr-1"), IpProtocol: aws.String("tcp"), FromPort: aws.Int32(22), ToPort: aws.Int32(22),
+	}))
+	assert.Equal(t, "sgr-2 (all traffic)", describeSecurityGroupRule(types.SecurityGroupRule{
+		SecurityGroupRuleId: aws.String("sgr-2"), IpProtocol: aws.String("-1"),
+	}))
+}
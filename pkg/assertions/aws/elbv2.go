@@ -0,0 +1,111 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+
+	"github.com/robmorgan/infraspec/pkg/awshelpers"
+)
+
+// Ensure the `AWSAsserter` struct implements the `ELBv2Asserter` interface.
+var _ ELBv2Asserter = (*AWSAsserter)(nil)
+
+// ELBv2Asserter defines Elastic Load Balancing v2 specific assertions
+type ELBv2Asserter interface {
+	AssertLoadBalancerListenerPort(name string, port int32) error
+	AssertTargetGroupHealthyTargets(name string, count int) error
+}
+
+// AssertLoadBalancerListenerPort checks that the load balancer has a listener on the port
+func (a *AWSAsserter) AssertLoadBalancerListenerPort(name string, port int32) error {
+	client, err := a.createELBv2Client()
+	if err != nil {
+		return err
+	}
+
+	lbs, err := client.DescribeLoadBalancers(context.TODO(), &elbv2.DescribeLoadBalancersInput{
+		Names: []string{name},
+	})
+	if err != nil {
+		return fmt.Errorf("error describing load balancer %s: %w", name, err)
+	}
+	if len(lbs.LoadBalancers) == 0 {
+		return fmt.Errorf("load balancer %s not found", name)
+	}
+
+	var ports []int32
+	paginator := elbv2.NewDescribeListenersPaginator(client, &elbv2.DescribeListenersInput{
+		LoadBalancerArn: lbs.LoadBalancers[0].LoadBalancerArn,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return fmt.Errorf("error describing listeners of load balancer %s: %w", name, err)
+		}
+		for _, listener := range page.Listeners {
+			if aws.ToInt32(listener.Port) == port {
+				return nil
+			}
+			ports = append(ports, aws.ToInt32(listener.Port))
+		}
+	}
+
+	return fmt.Errorf("expected load balancer %s to have a listener on port %d, but its listeners are on ports %v", name, port, ports)
+}
+
+// AssertTargetGroupHealthyTargets checks how many of the target group's registered targets are healthy
+func (a *AWSAsserter) AssertTargetGroupHealthyTargets(name string, count int) error {
+	client, err := a.createELBv2Client()
+	if err != nil {
+		return err
+	}
+
+	groups, err := client.DescribeTargetGroups(context.TODO(), &elbv2.DescribeTargetGroupsInput{
+		Names: []string{name},
+	})
+	if err != nil {
+		return fmt.Errorf("error describing target group %s: %w", name, err)
+	}
+	if len(groups.TargetGroups) == 0 {
+		return fmt.Errorf("target group %s not found", name)
+	}
+
+	health, err := client.DescribeTargetHealth(context.TODO(), &elbv2.DescribeTargetHealthInput{
+		TargetGroupArn: groups.TargetGroups[0].TargetGroupArn,
+	})
+	if err != nil {
+		return fmt.Errorf("error describing the health of target group %s: %w", name, err)
+	}
+
+	healthy := 0
+	for _, description := range health.TargetHealthDescriptions {
+		if description.TargetHealth != nil && description.TargetHealth.State == types.TargetHealthStateEnumHealthy {
+			healthy++
+		}
+	}
+
+	if healthy != count {
+		return fmt.Errorf("expected target group %s to have %d healthy targets, got %d of %d registered", name, count, healthy, len(health.TargetHealthDescriptions))
+	}
+	return nil
+}
+
+func (a *AWSAsserter) createELBv2Client() (*elbv2.Client, error) {
+	cfg, err := awshelpers.NewAuthenticatedSessionWithDefaultRegion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %w", err)
+	}
+
+	opts := make([]func(*elbv2.Options), 0, 1)
+	if endpoint, ok := awshelpers.GetVirtualCloudEndpoint("elasticloadbalancing"); ok {
+		opts = append(opts, func(o *elbv2.Options) {
+			o.BaseEndpoint = aws.String(endpoint)
+		})
+	}
+
+	return elbv2.NewFromConfig(*cfg, opts...), nil
+}
@@ -0,0 +1,110 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+
+	"github.com/robmorgan/infraspec/pkg/awshelpers"
+)
+
+// Ensure the `AWSAsserter` struct implements the `EventBridgeAsserter` interface.
+var _ EventBridgeAsserter = (*AWSAsserter)(nil)
+
+// EventBridgeAsserter defines EventBridge-specific assertions
+type EventBridgeAsserter interface {
+	AssertEventBusRoutesToQueue(busName, queueName string) error
+}
+
+// AssertEventBusRoutesToQueue checks that an enabled rule on the event bus targets the SQS
+// queue. An empty busName means the default event bus.
+func (a *AWSAsserter) AssertEventBusRoutesToQueue(busName, queueName string) error {
+	if busName == "" {
+		busName = "default"
+	}
+
+	attributes, err := a.getQueueAttributes(queueName, []sqstypes.QueueAttributeName{sqstypes.QueueAttributeNameQueueArn})
+	if err != nil {
+		return err
+	}
+	queueArn := attributes[string(sqstypes.QueueAttributeNameQueueArn)]
+
+	client, err := a.createEventBridgeClient()
+	if err != nil {
+		return err
+	}
+
+	input := &eventbridge.ListRulesInput{
+		EventBusName: aws.String(busName),
+	}
+	for {
+		result, err := client.ListRules(context.TODO(), input)
+		if err != nil {
+			return fmt.Errorf("error listing rules on event bus %s: %w", busName, err)
+		}
+
+		for _, rule := range result.Rules {
+			if rule.State != types.RuleStateEnabled {
+				continue
+			}
+			routes, err := a.ruleTargetsArn(client, busName, aws.ToString(rule.Name), queueArn)
+			if err != nil {
+				return err
+			}
+			if routes {
+				return nil
+			}
+		}
+
+		if result.NextToken == nil {
+			break
+		}
+		input.NextToken = result.NextToken
+	}
+
+	return fmt.Errorf("no enabled rule on event bus %s routes to queue %s", busName, queueName)
+}
+
+// ruleTargetsArn reports whether one of the rule's targets is the resource with the ARN
+func (a *AWSAsserter) ruleTargetsArn(client *eventbridge.Client, busName, ruleName, arn string) (bool, error) {
+	input := &eventbridge.ListTargetsByRuleInput{
+		Rule:         aws.String(ruleName),
+		EventBusName: aws.String(busName),
+	}
+	for {
+		result, err := client.ListTargetsByRule(context.TODO(), input)
+		if err != nil {
+			return false, fmt.Errorf("error listing targets of rule %s: %w", ruleName, err)
+		}
+		for _, target := range result.Targets {
+			if aws.ToString(target.Arn) == arn {
+				return true, nil
+			}
+		}
+		if result.NextToken == nil {
+			return false, nil
+		}
+		input.NextToken = result.NextToken
+	}
+}
+
+// Helper method to create an EventBridge client
+func (a *AWSAsserter) createEventBridgeClient() (*eventbridge.Client, error) {
+	cfg, err := awshelpers.NewAuthenticatedSessionWithDefaultRegion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %w", err)
+	}
+
+	opts := make([]func(*eventbridge.Options), 0, 1)
+	if endpoint, ok := awshelpers.GetVirtualCloudEndpoint("events"); ok {
+		opts = append(opts, func(o *eventbridge.Options) {
+			o.BaseEndpoint = aws.String(endpoint)
+		})
+	}
+
+	return eventbridge.NewFromConfig(*cfg, opts...), nil
+}
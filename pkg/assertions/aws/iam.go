@@ -20,11 +20,14 @@ type IAMAsserter interface {
 	AssertRoleExists(roleName string) error
 	AssertRolePath(roleName, expectedPath string) error
 	AssertRoleMaxSessionDuration(roleName string, expectedDuration int32) error
-	AssertRoleTags(roleName string, expectedTags map[string]string) error
+	AssertRoleTags(roleName string, expectedTags map[string]string, mode TagMatchMode) error
 	AssertPolicyExists(policyArn string) error
 	AssertPolicyAttachedToRole(roleName, policyArn string) error
+	AssertPolicyAllowsAction(policyArn, action, resource string) error
+	AssertRoleInlinePolicyAllowsAction(roleName, policyName, action, resource string) error
 	AssertInstanceProfileExists(instanceProfileName string) error
 	AssertInstanceProfileHasRole(instanceProfileName, roleName string) error
+	AssertAccountAlias(expectedAlias string) error
 }
 
 // AssertIAMDescribeRoles checks if the AWS account has permission to describe IAM roles
@@ -92,8 +95,8 @@ func (a *AWSAsserter) AssertRoleMaxSessionDuration(roleName string, expectedDura
 	return nil
 }
 
-// AssertRoleTags checks if an IAM role has the expected tags
-func (a *AWSAsserter) AssertRoleTags(roleName string, expectedTags map[string]string) error {
+// AssertRoleTags checks if an IAM role has the expected tags, compared according to mode
+func (a *AWSAsserter) AssertRoleTags(roleName string, expectedTags map[string]string, mode TagMatchMode) error {
 	role, err := a.getRole(roleName)
 	if err != nil {
 		return err
@@ -105,15 +108,8 @@ func (a *AWSAsserter) AssertRoleTags(roleName string, expectedTags map[string]st
 		actualTags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
 	}
 
-	// Check that all expected tags are present with correct values
-	for key, expectedValue := range expectedTags {
-		actualValue, exists := actualTags[key]
-		if !exists {
-			return fmt.Errorf("IAM role %s is missing tag %s", roleName, key)
-		}
-		if actualValue != expectedValue {
-			return fmt.Errorf("IAM role %s has tag %s=%s, expected %s", roleName, key, actualValue, expectedValue)
-		}
+	if err := CompareTags(actualTags, expectedTags, mode); err != nil {
+		return fmt.Errorf("IAM role %s: %w", roleName, err)
 	}
 
 	return nil
@@ -161,6 +157,67 @@ func (a *AWSAsserter) AssertPolicyAttachedToRole(roleName, policyArn string) err
 	return fmt.Errorf("policy %s is not attached to role %s", policyArn, roleName)
 }
 
+// AssertPolicyAllowsAction checks that the default version of an IAM managed policy allows
+// the action on the resource
+func (a *AWSAsserter) AssertPolicyAllowsAction(policyArn, action, resource string) error {
+	client, err := a.createIAMClient()
+	if err != nil {
+		return err
+	}
+
+	policy, err := client.GetPolicy(context.TODO(), &iam.GetPolicyInput{
+		PolicyArn: aws.String(policyArn),
+	})
+	if err != nil {
+		return fmt.Errorf("error getting IAM policy %s: %w", policyArn, err)
+	}
+
+	version, err := client.GetPolicyVersion(context.TODO(), &iam.GetPolicyVersionInput{
+		PolicyArn: aws.String(policyArn),
+		VersionId: policy.Policy.DefaultVersionId,
+	})
+	if err != nil {
+		return fmt.Errorf("error getting version %s of IAM policy %s: %w", aws.ToString(policy.Policy.DefaultVersionId), policyArn, err)
+	}
+
+	document, err := parsePolicyDocument(aws.ToString(version.PolicyVersion.Document))
+	if err != nil {
+		return fmt.Errorf("IAM policy %s: %w", policyArn, err)
+	}
+	if !document.allowsActionOnResource(action, "", resource) {
+		return fmt.Errorf("IAM policy %s does not allow %s on %s", policyArn, action, resource)
+	}
+
+	return nil
+}
+
+// AssertRoleInlinePolicyAllowsAction checks that an inline policy of an IAM role allows the
+// action on the resource
+func (a *AWSAsserter) AssertRoleInlinePolicyAllowsAction(roleName, policyName, action, resource string) error {
+	client, err := a.createIAMClient()
+	if err != nil {
+		return err
+	}
+
+	result, err := client.GetRolePolicy(context.TODO(), &iam.GetRolePolicyInput{
+		RoleName:   aws.String(roleName),
+		PolicyName: aws.String(policyName),
+	})
+	if err != nil {
+		return fmt.Errorf("error getting inline policy %s of IAM role %s: %w", policyName, roleName, err)
+	}
+
+	document, err := parsePolicyDocument(aws.ToString(result.PolicyDocument))
+	if err != nil {
+		return fmt.Errorf("inline policy %s of IAM role %s: %w", policyName, roleName, err)
+	}
+	if !document.allowsActionOnResource(action, "", resource) {
+		return fmt.Errorf("inline policy %s of IAM role %s does not allow %s on %s", policyName, roleName, action, resource)
+	}
+
+	return nil
+}
+
 // AssertInstanceProfileExists checks if an IAM instance profile exists
 func (a *AWSAsserter) AssertInstanceProfileExists(instanceProfileName string) error {
 	client, err := a.createIAMClient()
@@ -193,13 +250,38 @@ func (a *AWSAsserter) AssertInstanceProfileHasRole(instanceProfileName, roleName
 	}
 
 	// Check if the role is in the instance profile
+	roleNames := make([]string, 0, len(result.InstanceProfile.Roles))
 	for _, role := range result.InstanceProfile.Roles {
 		if aws.ToString(role.RoleName) == roleName {
 			return nil
 		}
+		roleNames = append(roleNames, aws.ToString(role.RoleName))
 	}
 
-	return fmt.Errorf("role %s is not in instance profile %s", roleName, instanceProfileName)
+	return fmt.Errorf("role %s is not in instance profile %s, which contains roles %v", roleName, instanceProfileName, roleNames)
+}
+
+// AssertAccountAlias checks if the account's alias is the expected alias
+func (a *AWSAsserter) AssertAccountAlias(expectedAlias string) error {
+	client, err := a.createIAMClient()
+	if err != nil {
+		return err
+	}
+
+	result, err := client.ListAccountAliases(context.TODO(), &iam.ListAccountAliasesInput{})
+	if err != nil {
+		return fmt.Errorf("error listing account aliases: %w", err)
+	}
+
+	// An account has at most one alias
+	if len(result.AccountAliases) == 0 {
+		return fmt.Errorf("expected account alias %s, but the account has no alias", expectedAlias)
+	}
+	if result.AccountAliases[0] != expectedAlias {
+		return fmt.Errorf("expected account alias %s, got %s", expectedAlias, result.AccountAliases[0])
+	}
+
+	return nil
 }
 
 // getRole is a helper method to get an IAM role
@@ -0,0 +1,84 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robmorgan/infraspec/pkg/embedded"
+)
+
+func TestAssertPolicyAllowsAction(t *testing.T) {
+	emu := embedded.New()
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+	t.Setenv("AWS_ENDPOINT_URL_IAM", emu.Endpoint())
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_REGION", "us-east-1")
+
+	a := NewAWSAsserter()
+	client, err := a.createIAMClient()
+	require.NoError(t, err)
+
+	document := `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject", "s3:PutObject"], "Resource": "arn:aws:s3:::uploads/*"}]}`
+	policy, err := client.CreatePolicy(context.Background(), &iam.CreatePolicyInput{
+		PolicyName:     aws.String("uploads"),
+		PolicyDocument: aws.String(document),
+	})
+	require.NoError(t, err)
+	policyArn := aws.ToString(policy.Policy.Arn)
+
+	_, err = client.CreateRole(context.Background(), &iam.CreateRoleInput{
+		RoleName:                 aws.String("uploader"),
+		AssumeRolePolicyDocument: aws.String(`{"Version": "2012-10-17", "Statement": []}`),
+	})
+	require.NoError(t, err)
+	_, err = client.PutRolePolicy(context.Background(), &iam.PutRolePolicyInput{
+		RoleName:       aws.String("uploader"),
+		PolicyName:     aws.String("uploads"),
+		PolicyDocument: aws.String(document),
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, a.AssertPolicyAllowsAction(policyArn, "s3:PutObject", "arn:aws:s3:::uploads/a.txt"))
+	err = a.AssertPolicyAllowsAction(policyArn, "s3:DeleteObject", "arn:aws:s3:::uploads/a.txt")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not allow s3:DeleteObject on arn:aws:s3:::uploads/a.txt")
+
+	assert.NoError(t, a.AssertRoleInlinePolicyAllowsAction("uploader", "uploads", "s3:GetObject", "arn:aws:s3:::uploads/a.txt"))
+	assert.Error(t, a.AssertRoleInlinePolicyAllowsAction("uploader", "uploads", "s3:GetObject", "arn:aws:s3:::backups/a.txt"))
+	assert.Error(t, a.AssertRoleInlinePolicyAllowsAction("uploader", "missing", "s3:GetObject", "arn:aws:s3:::uploads/a.txt"))
+}
+
+func TestAssertAccountAlias(t *testing.T) {
+	emu := embedded.New()
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+	t.Setenv("AWS_ENDPOINT_URL_IAM", emu.Endpoint())
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_REGION", "us-east-1")
+
+	a := NewAWSAsserter()
+	client, err := a.createIAMClient()
+	require.NoError(t, err)
+
+	err = a.AssertAccountAlias("my-company")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the account has no alias")
+
+	_, err = client.CreateAccountAlias(context.Background(), &iam.CreateAccountAliasInput{
+		AccountAlias: aws.String("my-company"),
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, a.AssertAccountAlias("my-company"))
+	err = a.AssertAccountAlias("other-company")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "got my-company")
+}
@@ -42,6 +42,9 @@ type LambdaAsserter interface {
 
 	// Event Source Mappings
 	AssertEventSourceMappingExists(uuid string) error
+
+	// Invocation
+	InvokeFunction(functionName, payload string) (string, error)
 }
 
 // AssertFunctionExists checks if a Lambda function exists
@@ -298,6 +301,29 @@ func (a *AWSAsserter) AssertEventSourceMappingExists(uuid string) error {
 	return nil
 }
 
+// InvokeFunction invokes a Lambda function synchronously with the payload and returns the
+// payload of its response. It fails if the function returns an error.
+func (a *AWSAsserter) InvokeFunction(functionName, payload string) (string, error) {
+	client, err := awshelpers.NewLambdaClientWithDefaultRegion()
+	if err != nil {
+		return "", err
+	}
+
+	result, err := client.Invoke(context.TODO(), &lambda.InvokeInput{
+		FunctionName: aws.String(functionName),
+		Payload:      []byte(payload),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error invoking Lambda function %s: %w", functionName, err)
+	}
+
+	if result.FunctionError != nil {
+		return "", fmt.Errorf("Lambda function %s returned a %s error: %s", functionName, aws.ToString(result.FunctionError), result.Payload)
+	}
+
+	return string(result.Payload), nil
+}
+
 // Helper method to get function configuration
 func (a *AWSAsserter) getFunctionConfiguration(functionName string) (*lambda.GetFunctionConfigurationOutput, error) {
 	client, err := awshelpers.NewLambdaClientWithDefaultRegion()
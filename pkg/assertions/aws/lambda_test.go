@@ -0,0 +1,101 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robmorgan/infraspec/pkg/awshelpers"
+	"github.com/robmorgan/infraspec/pkg/embedded"
+)
+
+func TestAssertFunctionConfiguration(t *testing.T) {
+	emu := embedded.New()
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+	t.Setenv("AWS_ENDPOINT_URL_LAMBDA", emu.Endpoint())
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_REGION", "us-east-1")
+
+	client, err := awshelpers.NewLambdaClientWithDefaultRegion()
+	require.NoError(t, err)
+	_, err = client.CreateFunction(context.Background(), &lambda.CreateFunctionInput{
+		FunctionName: aws.String("orders"),
+		Runtime:      types.RuntimePython312,
+		Handler:      aws.String("index.handler"),
+		Role:         aws.String("arn:aws:iam::123456789012:role/orders"),
+		Code:         &types.FunctionCode{ZipFile: []byte("code")},
+		MemorySize:   aws.Int32(256),
+		Timeout:      aws.Int32(30),
+		Environment:  &types.Environment{Variables: map[string]string{"STAGE": "production"}},
+	})
+	require.NoError(t, err)
+
+	a := NewAWSAsserter()
+	assert.NoError(t, a.AssertFunctionRuntime("orders", "python3.12"))
+	assert.NoError(t, a.AssertFunctionMemory("orders", 256))
+	assert.NoError(t, a.AssertFunctionTimeout("orders", 30))
+	assert.NoError(t, a.AssertFunctionEnvironmentVariable("orders", "STAGE", "production"))
+
+	err = a.AssertFunctionRuntime("orders", "nodejs20.x")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected runtime nodejs20.x, but got python3.12")
+	err = a.AssertFunctionMemory("orders", 128)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected memory 128 MB, but got 256 MB")
+	assert.Error(t, a.AssertFunctionTimeout("orders", 3))
+	assert.Error(t, a.AssertFunctionEnvironmentVariable("orders", "STAGE", "staging"))
+	assert.Error(t, a.AssertFunctionEnvironmentVariable("orders", "REGION", "us-east-1"))
+	assert.Error(t, a.AssertFunctionRuntime("missing", "python3.12"))
+}
+
+func TestInvokeFunction(t *testing.T) {
+	emu := embedded.New()
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+	t.Setenv("AWS_ENDPOINT_URL_LAMBDA", emu.Endpoint())
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_REGION", "us-east-1")
+
+	client, err := awshelpers.NewLambdaClientWithDefaultRegion()
+	require.NoError(t, err)
+	createFunction := func(name string, tags map[string]string) {
+		t.Helper()
+		_, err := client.CreateFunction(context.Background(), &lambda.CreateFunctionInput{
+			FunctionName: aws.String(name),
+			Runtime:      types.RuntimePython312,
+			Handler:      aws.String("index.handler"),
+			Role:         aws.String("arn:aws:iam::123456789012:role/" + name),
+			Code:         &types.FunctionCode{ZipFile: []byte("code")},
+			Tags:         tags,
+		})
+		require.NoError(t, err)
+	}
+	createFunction("greeter", map[string]string{"mock:response": `{"greeting":"hello"}`})
+	createFunction("echo", map[string]string{"mock:echo": "true"})
+	createFunction("broken", map[string]string{"mock:error": "Handled", "mock:errorMessage": "out of stock"})
+
+	a := NewAWSAsserter()
+	response, err := a.InvokeFunction("greeter", `{"name":"world"}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"greeting":"hello"}`, response)
+
+	response, err = a.InvokeFunction("echo", `{"name":"world"}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"world"}`, response)
+
+	_, err = a.InvokeFunction("broken", `{}`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Lambda function broken returned a Handled error")
+	assert.Contains(t, err.Error(), "out of stock")
+
+	_, err = a.InvokeFunction("missing", `{}`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,265 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// accountIDPrincipalPattern matches principals given as a bare 12-digit account ID.
+var accountIDPrincipalPattern = regexp.MustCompile(`^\d{12}$`)
+
+// policyDocument is a minimal representation of an AWS JSON policy document.
+type policyDocument struct {
+	Version   string            `json:"Version"`
+	Statement []policyStatement `json:"Statement"`
+}
+
+// policyStatement is a single statement within a policy document.
+type policyStatement struct {
+	Sid         string                 `json:"Sid"`
+	Effect      string                 `json:"Effect"`
+	Principal   interface{}            `json:"Principal"`
+	Action      stringOrSlice          `json:"Action"`
+	NotAction   stringOrSlice          `json:"NotAction"`
+	Resource    stringOrSlice          `json:"Resource"`
+	NotResource stringOrSlice          `json:"NotResource"`
+	Condition   map[string]interface{} `json:"Condition"`
+}
+
+// stringOrSlice unmarshals policy elements that may be either a string or a list of strings.
+type stringOrSlice []string
+
+func (s *stringOrSlice) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = []string{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*s = multiple
+	return nil
+}
+
+// UnmarshalJSON accepts a Statement element that is either a single statement or a list of statements.
+func (p *policyDocument) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Version   string          `json:"Version"`
+		Statement json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	p.Version = raw.Version
+	p.Statement = nil
+	if len(raw.Statement) == 0 {
+		return nil
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(string(raw.Statement)), "{") {
+		var statement policyStatement
+		if err := json.Unmarshal(raw.Statement, &statement); err != nil {
+			return err
+		}
+		p.Statement = []policyStatement{statement}
+		return nil
+	}
+
+	return json.Unmarshal(raw.Statement, &p.Statement)
+}
+
+// parsePolicyDocument parses a JSON policy document, URL-decoding it first if required
+// (IAM returns URL-encoded policy documents).
+func parsePolicyDocument(document string) (*policyDocument, error) {
+	if !strings.HasPrefix(strings.TrimSpace(document), "{") {
+		decoded, err := url.QueryUnescape(document)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode policy document: %w", err)
+		}
+		document = decoded
+	}
+
+	var policy policyDocument
+	if err := json.Unmarshal([]byte(document), &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy document: %w", err)
+	}
+	return &policy, nil
+}
+
+// allowsAction reports whether the policy grants the action to the principal. An empty
+// principal matches statements for any principal. An explicit Deny for the action always
+// takes precedence over an Allow. Resource, NotPrincipal and Condition elements are not
+// evaluated, so a statement scoped to other resources or conditions still counts; use
+// allowsActionOnResource to evaluate Resource elements.
+func (p *policyDocument) allowsAction(action, principal string) bool {
+	return p.allowsActionOnResource(action, principal, "")
+}
+
+// allowsActionOnResource reports whether the policy grants the action on the resource to the
+// principal, like allowsAction but only counting the statements whose Resource or NotResource
+// element applies to the resource. An empty resource matches statements for any resource.
+func (p *policyDocument) allowsActionOnResource(action, principal, resource string) bool {
+	allowed := false
+	for _, statement := range p.Statement {
+		if !statement.matchesAction(action) || !statement.matchesPrincipal(principal) || !statement.matchesResource(resource) {
+			continue
+		}
+		if strings.EqualFold(statement.Effect, "Deny") {
+			return false
+		}
+		if strings.EqualFold(statement.Effect, "Allow") {
+			allowed = true
+		}
+	}
+	return allowed
+}
+
+// publicAllowStatements returns a description of every unconditional Allow statement
+// that grants access to any principal.
+func (p *policyDocument) publicAllowStatements() []string {
+	var statements []string
+	for i, statement := range p.Statement {
+		if !strings.EqualFold(statement.Effect, "Allow") || len(statement.Condition) > 0 || !statement.isPublic() {
+			continue
+		}
+
+		name := statement.Sid
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		statements = append(statements, name)
+	}
+	return statements
+}
+
+// matchesAction reports whether the statement applies to the action, honouring wildcards and NotAction.
+func (s policyStatement) matchesAction(action string) bool {
+	if len(s.NotAction) > 0 {
+		for _, pattern := range s.NotAction {
+			if policyWildcardMatch(pattern, action) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, pattern := range s.Action {
+		if policyWildcardMatch(pattern, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesResource reports whether the statement applies to the resource, honouring wildcards
+// and NotResource. Statements without a Resource element (resource-based policies) and an
+// empty resource always match.
+func (s policyStatement) matchesResource(resource string) bool {
+	if resource == "" {
+		return true
+	}
+
+	if len(s.NotResource) > 0 {
+		for _, pattern := range s.NotResource {
+			if resourceWildcardMatch(pattern, resource) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if len(s.Resource) == 0 {
+		return true
+	}
+	for _, pattern := range s.Resource {
+		if resourceWildcardMatch(pattern, resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPrincipal reports whether the statement applies to the principal. Statements without
+// a Principal element (identity-based policies) and an empty principal always match. Account
+// IDs are treated as the account's root ARN, as AWS does.
+func (s policyStatement) matchesPrincipal(principal string) bool {
+	if principal == "" || s.Principal == nil {
+		return true
+	}
+
+	var values []interface{}
+	switch p := s.Principal.(type) {
+	case string:
+		values = []interface{}{p}
+	case map[string]interface{}:
+		for _, value := range p {
+			if list, ok := value.([]interface{}); ok {
+				values = append(values, list...)
+			} else {
+				values = append(values, value)
+			}
+		}
+	}
+
+	principal = normalizePrincipal(principal)
+	for _, value := range values {
+		if value, ok := value.(string); ok && (value == "*" || normalizePrincipal(value) == principal) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizePrincipal expands a bare account ID to the account's root ARN.
+func normalizePrincipal(principal string) string {
+	if accountIDPrincipalPattern.MatchString(principal) {
+		return fmt.Sprintf("arn:aws:iam::%s:root", principal)
+	}
+	return principal
+}
+
+// isPublic reports whether the statement's principal is anonymous ("*" or {"AWS": "*"}).
+func (s policyStatement) isPublic() bool {
+	switch principal := s.Principal.(type) {
+	case string:
+		return principal == "*"
+	case map[string]interface{}:
+		switch awsPrincipal := principal["AWS"].(type) {
+		case string:
+			return awsPrincipal == "*"
+		case []interface{}:
+			for _, value := range awsPrincipal {
+				if value == "*" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// policyWildcardMatch matches an IAM action pattern (supporting * and ?) case-insensitively.
+func policyWildcardMatch(pattern, value string) bool {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	matched, err := regexp.MatchString("(?i)^"+expr+"$", value)
+	return err == nil && matched
+}
+
+// resourceWildcardMatch matches a resource ARN pattern (supporting * and ?). Unlike actions,
+// ARNs are case-sensitive.
+func resourceWildcardMatch(pattern, value string) bool {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	matched, err := regexp.MatchString("^"+expr+"$", value)
+	return err == nil && matched
+}
@@ -0,0 +1,72 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyDocument_AllowsAction(t *testing.T) {
+	policy, err := parsePolicyDocument(`{
+		"Version": "2012-10-17",
+		"Statement": [
+			{"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:root"}, "Action": "s3:Get*", "Resource": "*"},
+			{"Effect": "Deny", "Principal": "*", "Action": ["s3:GetObjectAcl"], "Resource": "*"}
+		]
+	}`)
+	require.NoError(t, err)
+
+	assert.True(t, policy.allowsAction("s3:GetObject", ""))
+	assert.True(t, policy.allowsAction("S3:getobject", ""))
+	assert.False(t, policy.allowsAction("s3:GetObjectAcl", ""), "explicit deny should take precedence")
+	assert.False(t, policy.allowsAction("s3:PutObject", ""))
+	assert.Empty(t, policy.publicAllowStatements())
+
+	// A principal restricts evaluation to the statements that apply to it
+	assert.True(t, policy.allowsAction("s3:GetObject", "arn:aws:iam::123456789012:root"))
+	assert.True(t, policy.allowsAction("s3:GetObject", "123456789012"), "account IDs should match the root ARN")
+	assert.False(t, policy.allowsAction("s3:GetObject", "arn:aws:iam::111122223333:root"))
+	assert.False(t, policy.allowsAction("s3:GetObjectAcl", "arn:aws:iam::123456789012:root"), "deny for any principal should apply")
+}
+
+func TestPolicyDocument_PublicAllowStatements(t *testing.T) {
+	policy, err := parsePolicyDocument(`{
+		"Version": "2012-10-17",
+		"Statement": {"Sid": "PublicWrite", "Effect": "Allow", "Principal": {"AWS": ["*"]}, "Action": "s3:PutObject", "Resource": "*"}
+	}`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PublicWrite"}, policy.publicAllowStatements())
+
+	conditional, err := parsePolicyDocument(`{
+		"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "*",
+			"Condition": {"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}}]
+	}`)
+	require.NoError(t, err)
+	assert.Empty(t, conditional.publicAllowStatements())
+}
+
+func TestParsePolicyDocument_URLEncoded(t *testing.T) {
+	policy, err := parsePolicyDocument("%7B%22Statement%22%3A%5B%7B%22Effect%22%3A%22Allow%22%2C%22Action%22%3A%22s3%3A%2A%22%7D%5D%7D")
+	require.NoError(t, err)
+	assert.True(t, policy.allowsAction("s3:ListBucket", ""))
+}
+
+func TestPolicyDocument_AllowsActionOnResource(t *testing.T) {
+	policy, err := parsePolicyDocument(`{
+		"Version": "2012-10-17",
+		"Statement": [
+			{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::reports/*"},
+			{"Effect": "Deny", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::reports/private/*"},
+			{"Effect": "Allow", "Action": "sqs:SendMessage", "NotResource": "arn:aws:sqs:*:*:admin-*"}
+		]
+	}`)
+	require.NoError(t, err)
+
+	assert.True(t, policy.allowsActionOnResource("s3:GetObject", "", "arn:aws:s3:::reports/2024.csv"))
+	assert.False(t, policy.allowsActionOnResource("s3:GetObject", "", "arn:aws:s3:::Reports/2024.csv"), "ARNs should match case-sensitively")
+	assert.False(t, policy.allowsActionOnResource("s3:GetObject", "", "arn:aws:s3:::reports/private/keys"), "explicit deny should take precedence")
+	assert.False(t, policy.allowsActionOnResource("s3:GetObject", "", "arn:aws:s3:::other/2024.csv"))
+	assert.True(t, policy.allowsActionOnResource("sqs:SendMessage", "", "arn:aws:sqs:us-east-1:123456789012:jobs"))
+	assert.False(t, policy.allowsActionOnResource("sqs:SendMessage", "", "arn:aws:sqs:us-east-1:123456789012:admin-jobs"))
+}
@@ -26,7 +26,7 @@ type RDSAsserter interface {
 	AssertDBInstanceMultiAZ(dbInstanceID string, multiAZ bool, region string) error
 	AssertDBInstanceEncryption(dbInstanceID string, encrypted bool, region string) error
 	AssertDBInstancePubliclyAccessible(dbInstanceID string, publiclyAccessible bool, region string) error
-	AssertDBInstanceTags(dbInstanceID string, expectedTags map[string]string, region string) error
+	AssertDBInstanceTags(dbInstanceID string, expectedTags map[string]string, mode TagMatchMode, region string) error
 }
 
 // AssertRDSServiceAccess checks if the AWS account has permission to access the RDS service
@@ -186,8 +186,8 @@ func (a *AWSAsserter) AssertDBInstancePubliclyAccessible(dbInstanceID string, pu
 	return nil
 }
 
-// AssertDBInstanceTags checks if a DB instance has the expected tags
-func (a *AWSAsserter) AssertDBInstanceTags(dbInstanceID string, expectedTags map[string]string, region string) error {
+// AssertDBInstanceTags checks if a DB instance has the expected tags, compared according to mode
+func (a *AWSAsserter) AssertDBInstanceTags(dbInstanceID string, expectedTags map[string]string, mode TagMatchMode, region string) error {
 	client, err := awshelpers.NewRdsClientWithDefaultRegion()
 	if err != nil {
 		return err
@@ -215,18 +215,7 @@ func (a *AWSAsserter) AssertDBInstanceTags(dbInstanceID string, expectedTags map
 		actualTags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
 	}
 
-	// Compare the expected and actual tags
-	for key, value := range expectedTags {
-		actualValue, exists := actualTags[key]
-		if !exists {
-			return fmt.Errorf("expected tag %s not found", key)
-		}
-		if actualValue != value {
-			return fmt.Errorf("expected tag %s to have value %s, but got %s", key, value, actualValue)
-		}
-	}
-
-	return nil
+	return CompareTags(actualTags, expectedTags, mode)
 }
 
 // Helper method to get a DB instance
@@ -0,0 +1,345 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/s3control"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/robmorgan/infraspec/pkg/awshelpers"
+)
+
+// Ensure the `AWSAsserter` struct implements the `ResourceTagsAsserter` interface.
+var _ ResourceTagsAsserter = (*AWSAsserter)(nil)
+
+// ResourceTagsAsserter defines tag assertions for any resource identified by its ARN
+type ResourceTagsAsserter interface {
+	AssertResourceTags(resourceArn string, expectedTags map[string]string, mode TagMatchMode) error
+}
+
+// tagGetter returns the tags of a resource of one service
+type tagGetter func(a *AWSAsserter, resource arn.ARN) (map[string]string, error)
+
+// resourceTagGetters maps the service component of an ARN to its service's tag retrieval
+var resourceTagGetters = map[string]tagGetter{
+	"dynamodb": (*AWSAsserter).getDynamoDBResourceTags,
+	"ec2":      (*AWSAsserter).getEC2ResourceTags,
+	"events":   (*AWSAsserter).getEventBridgeResourceTags,
+	"iam":      (*AWSAsserter).getIAMResourceTags,
+	"lambda":   (*AWSAsserter).getLambdaResourceTags,
+	"rds":      (*AWSAsserter).getRDSResourceTags,
+	"s3":       (*AWSAsserter).getS3ResourceTags,
+	"sqs":      (*AWSAsserter).getSQSResourceTags,
+	"states":   (*AWSAsserter).getStepFunctionsResourceTags,
+}
+
+// AssertResourceTags checks if the resource has the expected tags, compared according to mode.
+// The tags are retrieved from the service named in the ARN.
+func (a *AWSAsserter) AssertResourceTags(resourceArn string, expectedTags map[string]string, mode TagMatchMode) error {
+	actualTags, err := a.getResourceTags(resourceArn)
+	if err != nil {
+		return err
+	}
+
+	if err := CompareTags(actualTags, expectedTags, mode); err != nil {
+		return fmt.Errorf("resource %s: %w", resourceArn, err)
+	}
+	return nil
+}
+
+// getResourceTags parses the ARN and dispatches to the tag getter of its service
+func (a *AWSAsserter) getResourceTags(resourceArn string) (map[string]string, error) {
+	resource, err := arn.Parse(resourceArn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ARN %q: %w", resourceArn, err)
+	}
+
+	getTags, ok := resourceTagGetters[resource.Service]
+	if !ok {
+		services := make([]string, 0, len(resourceTagGetters))
+		for service := range resourceTagGetters {
+			services = append(services, service)
+		}
+		sort.Strings(services)
+		return nil, fmt.Errorf("tags for %s resources are not supported, expected an ARN for one of: %s", resource.Service, strings.Join(services, ", "))
+	}
+
+	tags, err := getTags(a, resource)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tags for %s: %w", resourceArn, err)
+	}
+	return tags, nil
+}
+
+// resourceID returns the last component of an ARN's resource, e.g. the instance ID of
+// "instance/i-0123" or the role name of "role/service/deployer"
+func resourceID(resource arn.ARN) string {
+	id := resource.Resource
+	if i := strings.LastIndexAny(id, "/:"); i >= 0 {
+		id = id[i+1:]
+	}
+	return id
+}
+
+// resourceType returns the first component of an ARN's resource, e.g. "instance" for "instance/i-0123"
+func resourceType(resource arn.ARN) string {
+	if i := strings.IndexAny(resource.Resource, "/:"); i >= 0 {
+		return resource.Resource[:i]
+	}
+	return ""
+}
+
+func (a *AWSAsserter) getDynamoDBResourceTags(resource arn.ARN) (map[string]string, error) {
+	client, err := a.createDynamoDBClient()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.ListTagsOfResource(context.TODO(), &dynamodb.ListTagsOfResourceInput{
+		ResourceArn: aws.String(resource.String()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string]string)
+	for _, tag := range result.Tags {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return tags, nil
+}
+
+func (a *AWSAsserter) getEC2ResourceTags(resource arn.ARN) (map[string]string, error) {
+	client, err := awshelpers.NewEc2FullClient(resource.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.DescribeTags(context.TODO(), &ec2.DescribeTagsInput{
+		Filters: []ec2types.Filter{
+			{Name: aws.String("resource-id"), Values: []string{resourceID(resource)}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string]string)
+	for _, tag := range result.Tags {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return tags, nil
+}
+
+func (a *AWSAsserter) getEventBridgeResourceTags(resource arn.ARN) (map[string]string, error) {
+	client, err := a.createEventBridgeClient()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.ListTagsForResource(context.TODO(), &eventbridge.ListTagsForResourceInput{
+		ResourceARN: aws.String(resource.String()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string]string)
+	for _, tag := range result.Tags {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return tags, nil
+}
+
+// getIAMResourceTags supports roles and users, whose tags IAM lists by name
+func (a *AWSAsserter) getIAMResourceTags(resource arn.ARN) (map[string]string, error) {
+	client, err := a.createIAMClient()
+	if err != nil {
+		return nil, err
+	}
+
+	name := aws.String(resourceID(resource))
+	tags := make(map[string]string)
+	switch resourceType(resource) {
+	case "role":
+		result, err := client.ListRoleTags(context.TODO(), &iam.ListRoleTagsInput{RoleName: name})
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range result.Tags {
+			tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+	case "user":
+		result, err := client.ListUserTags(context.TODO(), &iam.ListUserTagsInput{UserName: name})
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range result.Tags {
+			tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+	default:
+		return nil, fmt.Errorf("tags for IAM %s resources are not supported, expected a role or user", resourceType(resource))
+	}
+	return tags, nil
+}
+
+func (a *AWSAsserter) getLambdaResourceTags(resource arn.ARN) (map[string]string, error) {
+	client, err := awshelpers.NewLambdaClient(resource.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.ListTags(context.TODO(), &lambda.ListTagsInput{
+		Resource: aws.String(resource.String()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Tags, nil
+}
+
+func (a *AWSAsserter) getRDSResourceTags(resource arn.ARN) (map[string]string, error) {
+	client, err := awshelpers.NewRdsClient(resource.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.ListTagsForResource(context.TODO(), &rds.ListTagsForResourceInput{
+		ResourceName: aws.String(resource.String()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string]string)
+	for _, tag := range result.TagList {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return tags, nil
+}
+
+// getS3ResourceTags reads bucket tags through the S3 Control API, which needs the account that
+// owns the bucket. Bucket ARNs without one are looked up for the caller's account.
+func (a *AWSAsserter) getS3ResourceTags(resource arn.ARN) (map[string]string, error) {
+	accountID := resource.AccountID
+	if accountID == "" {
+		client, err := a.createSTSClient()
+		if err != nil {
+			return nil, err
+		}
+		identity, err := client.GetCallerIdentity(context.TODO(), &sts.GetCallerIdentityInput{})
+		if err != nil {
+			return nil, fmt.Errorf("error getting the caller's account: %w", err)
+		}
+		accountID = aws.ToString(identity.Account)
+	}
+
+	client, err := a.createS3ControlClient()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.ListTagsForResource(context.TODO(), &s3control.ListTagsForResourceInput{
+		AccountId:   aws.String(accountID),
+		ResourceArn: aws.String(resource.String()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string]string)
+	for _, tag := range result.Tags {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return tags, nil
+}
+
+// getSQSResourceTags looks up the queue URL from the name and owner in the ARN
+func (a *AWSAsserter) getSQSResourceTags(resource arn.ARN) (map[string]string, error) {
+	client, err := a.createSQSClient()
+	if err != nil {
+		return nil, err
+	}
+
+	queue, err := client.GetQueueUrl(context.TODO(), &sqs.GetQueueUrlInput{
+		QueueName:              aws.String(resource.Resource),
+		QueueOwnerAWSAccountId: aws.String(resource.AccountID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.ListQueueTags(context.TODO(), &sqs.ListQueueTagsInput{
+		QueueUrl: queue.QueueUrl,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Tags, nil
+}
+
+func (a *AWSAsserter) getStepFunctionsResourceTags(resource arn.ARN) (map[string]string, error) {
+	client, err := a.createStepFunctionsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.ListTagsForResource(context.TODO(), &sfn.ListTagsForResourceInput{
+		ResourceArn: aws.String(resource.String()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string]string)
+	for _, tag := range result.Tags {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return tags, nil
+}
+
+func (a *AWSAsserter) createS3ControlClient() (*s3control.Client, error) {
+	cfg, err := awshelpers.NewAuthenticatedSessionWithDefaultRegion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %w", err)
+	}
+
+	opts := make([]func(*s3control.Options), 0, 1)
+	if endpoint, ok := awshelpers.GetVirtualCloudEndpoint("s3-control"); ok {
+		opts = append(opts, func(o *s3control.Options) {
+			o.BaseEndpoint = aws.String(endpoint)
+		})
+	}
+
+	return s3control.NewFromConfig(*cfg, opts...), nil
+}
+
+func (a *AWSAsserter) createSTSClient() (*sts.Client, error) {
+	cfg, err := awshelpers.NewAuthenticatedSessionWithDefaultRegion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %w", err)
+	}
+
+	opts := make([]func(*sts.Options), 0, 1)
+	if endpoint, ok := awshelpers.GetVirtualCloudEndpoint("sts"); ok {
+		opts = append(opts, func(o *sts.Options) {
+			o.BaseEndpoint = aws.String(endpoint)
+		})
+	}
+
+	return sts.NewFromConfig(*cfg, opts...), nil
+}
@@ -0,0 +1,44 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceIDAndType(t *testing.T) {
+	tests := []struct {
+		arn      string
+		wantID   string
+		wantType string
+	}{
+		{arn: "arn:aws:ec2:us-east-1:123456789012:instance/i-0123456789abcdef0", wantID: "i-0123456789abcdef0", wantType: "instance"},
+		{arn: "arn:aws:iam::123456789012:role/service/deployer", wantID: "deployer", wantType: "role"},
+		{arn: "arn:aws:rds:us-east-1:123456789012:db:orders", wantID: "orders", wantType: "db"},
+		{arn: "arn:aws:sqs:us-east-1:123456789012:orders", wantID: "orders", wantType: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arn, func(t *testing.T) {
+			resource, err := arn.Parse(tt.arn)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantID, resourceID(resource))
+			assert.Equal(t, tt.wantType, resourceType(resource))
+		})
+	}
+}
+
+func TestAssertResourceTags_RejectsUnsupportedARNs(t *testing.T) {
+	a := &AWSAsserter{}
+
+	err := a.AssertResourceTags("not-an-arn", nil, TagMatchSubset)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid ARN "not-an-arn"`)
+
+	err = a.AssertResourceTags("arn:aws:kms:us-east-1:123456789012:key/1234", nil, TagMatchSubset)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tags for kms resources are not supported")
+	assert.Contains(t, err.Error(), "dynamodb, ec2, events, iam, lambda, rds, s3, sqs, states")
+}
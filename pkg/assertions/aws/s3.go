@@ -1,12 +1,20 @@
 package aws
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
 
 	"github.com/robmorgan/infraspec/pkg/awshelpers"
 )
@@ -18,10 +26,24 @@ var _ S3Asserter = (*AWSAsserter)(nil)
 type S3Asserter interface {
 	AssertS3DescribeBuckets() error
 	AssertBucketExists(bucketName string) error
+	AssertBucketRegion(bucketName, region string) error
 	AssertBucketVersioning(bucketName string) error
 	AssertBucketEncryption(bucketName string) error
 	AssertBucketPublicAccessBlock(bucketName string) error
+	AssertBucketBlocksAllPublicAccess(bucketName string) error
+	AssertBucketRestrictsPublicBuckets(bucketName string) error
 	AssertBucketServerAccessLogging(bucketName string) error
+	AssertBucketLoggingTarget(bucketName, targetBucket, targetPrefix string) error
+	AssertBucketLoggingDisabled(bucketName string) error
+	AssertBucketPolicyAllows(bucketName, action, principal string) error
+	AssertBucketPolicyDeniesPublicAccess(bucketName string) error
+	AssertObjectMatchesFile(bucketName, key, filePath string, ignoreWhitespace bool) error
+	AssertObjectStorageClass(bucketName, key, storageClass string) error
+	AssertBucketLifecycleTransition(bucketName, storageClass string, days int32) error
+	AssertBucketsHaveSameObjects(bucketName, otherBucketName string, compareETags bool) error
+
+	// GetBucketAttribute returns an attribute of the bucket, used to capture values into scenario variables
+	GetBucketAttribute(bucketName, attribute string) (string, error)
 }
 
 // AssertS3DescribeBuckets checks if the AWS account has permission to describe S3 buckets
@@ -40,7 +62,9 @@ func (a *AWSAsserter) AssertS3DescribeBuckets() error {
 	return nil
 }
 
-func (a *AWSAsserter) AssertBucketExists(bucketName string) error {
+func (a *AWSAsserter) AssertBucketExists(bucketName string) (err error) {
+	defer a.withStateSnapshot(&err, "s3:"+bucketName, "s3:")
+
 	client, err := a.createS3Client()
 	if err != nil {
 		return err
@@ -57,7 +81,70 @@ func (a *AWSAsserter) AssertBucketExists(bucketName string) error {
 	return nil
 }
 
-func (a *AWSAsserter) AssertBucketVersioning(bucketName string) error {
+// AssertBucketRegion checks the region the bucket was created in
+func (a *AWSAsserter) AssertBucketRegion(bucketName, region string) (err error) {
+	defer a.withStateSnapshot(&err, "s3:"+bucketName, "s3:")
+
+	actual, err := a.getBucketRegion(bucketName)
+	if err != nil {
+		return err
+	}
+	if actual != region {
+		return fmt.Errorf("expected bucket %s to be in region %s, got %s", bucketName, region, actual)
+	}
+
+	return nil
+}
+
+// getBucketRegion returns the region the bucket was created in
+func (a *AWSAsserter) getBucketRegion(bucketName string) (string, error) {
+	client, err := a.createS3Client()
+	if err != nil {
+		return "", err
+	}
+
+	result, err := client.GetBucketLocation(context.TODO(), &s3.GetBucketLocationInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error getting bucket location for %s: %w", bucketName, err)
+	}
+
+	// Buckets in us-east-1 have no location constraint, and EU is the legacy name for eu-west-1
+	switch result.LocationConstraint {
+	case "":
+		return "us-east-1", nil
+	case types.BucketLocationConstraintEu:
+		return "eu-west-1", nil
+	}
+	return string(result.LocationConstraint), nil
+}
+
+// GetBucketAttribute returns the bucket's "region" or "versioning status"
+func (a *AWSAsserter) GetBucketAttribute(bucketName, attribute string) (string, error) {
+	switch attribute {
+	case "region":
+		return a.getBucketRegion(bucketName)
+	case "versioning status":
+		client, err := a.createS3Client()
+		if err != nil {
+			return "", err
+		}
+		result, err := client.GetBucketVersioning(context.TODO(), &s3.GetBucketVersioningInput{
+			Bucket: aws.String(bucketName),
+		})
+		if err != nil {
+			return "", fmt.Errorf("error getting bucket versioning for %s: %w", bucketName, err)
+		}
+		return string(result.Status), nil
+	default:
+		return "", fmt.Errorf("unknown S3 bucket attribute %q, expected \"region\" or \"versioning status\"", attribute)
+	}
+}
+
+func (a *AWSAsserter) AssertBucketVersioning(bucketName string) (err error) {
+	defer a.withStateSnapshot(&err, "s3:"+bucketName, "s3:")
+
 	client, err := a.createS3Client()
 	if err != nil {
 		return err
@@ -79,7 +166,9 @@ func (a *AWSAsserter) AssertBucketVersioning(bucketName string) error {
 	return nil
 }
 
-func (a *AWSAsserter) AssertBucketEncryption(bucketName string) error {
+func (a *AWSAsserter) AssertBucketEncryption(bucketName string) (err error) {
+	defer a.withStateSnapshot(&err, "s3:"+bucketName, "s3:")
+
 	client, err := a.createS3Client()
 	if err != nil {
 		return err
@@ -101,7 +190,9 @@ func (a *AWSAsserter) AssertBucketEncryption(bucketName string) error {
 	return nil
 }
 
-func (a *AWSAsserter) AssertBucketPublicAccessBlock(bucketName string) error {
+func (a *AWSAsserter) AssertBucketPublicAccessBlock(bucketName string) (err error) {
+	defer a.withStateSnapshot(&err, "s3:"+bucketName, "s3:")
+
 	client, err := a.createS3Client()
 	if err != nil {
 		return err
@@ -123,28 +214,433 @@ func (a *AWSAsserter) AssertBucketPublicAccessBlock(bucketName string) error {
 	return nil
 }
 
-func (a *AWSAsserter) AssertBucketServerAccessLogging(bucketName string) error {
-	client, err := a.createS3Client()
+// AssertBucketBlocksAllPublicAccess checks that all four settings of the bucket's public access
+// block are enabled
+func (a *AWSAsserter) AssertBucketBlocksAllPublicAccess(bucketName string) (err error) {
+	defer a.withStateSnapshot(&err, "s3:"+bucketName, "s3:")
+
+	config, err := a.getPublicAccessBlock(bucketName)
+	if err != nil {
+		return err
+	}
+
+	settings := []struct {
+		name    string
+		enabled *bool
+	}{
+		{"BlockPublicAcls", config.BlockPublicAcls},
+		{"IgnorePublicAcls", config.IgnorePublicAcls},
+		{"BlockPublicPolicy", config.BlockPublicPolicy},
+		{"RestrictPublicBuckets", config.RestrictPublicBuckets},
+	}
+	var disabled []string
+	for _, setting := range settings {
+		if !aws.ToBool(setting.enabled) {
+			disabled = append(disabled, setting.name)
+		}
+	}
+	if len(disabled) > 0 {
+		return fmt.Errorf("bucket %s does not block all public access, disabled settings: %s", bucketName, strings.Join(disabled, ", "))
+	}
+
+	return nil
+}
+
+// AssertBucketRestrictsPublicBuckets checks that the bucket's public access block has
+// RestrictPublicBuckets enabled
+func (a *AWSAsserter) AssertBucketRestrictsPublicBuckets(bucketName string) (err error) {
+	defer a.withStateSnapshot(&err, "s3:"+bucketName, "s3:")
+
+	config, err := a.getPublicAccessBlock(bucketName)
 	if err != nil {
 		return err
 	}
 
-	input := &s3.GetBucketLoggingInput{
+	if !aws.ToBool(config.RestrictPublicBuckets) {
+		return fmt.Errorf("bucket %s public access block does not restrict public buckets", bucketName)
+	}
+
+	return nil
+}
+
+// getPublicAccessBlock fetches the bucket's public access block, failing if it has none
+func (a *AWSAsserter) getPublicAccessBlock(bucketName string) (*types.PublicAccessBlockConfiguration, error) {
+	client, err := a.createS3Client()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.GetPublicAccessBlock(context.TODO(), &s3.GetPublicAccessBlockInput{
 		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchPublicAccessBlockConfiguration" {
+			return nil, fmt.Errorf("bucket %s does not have public access block configuration", bucketName)
+		}
+		return nil, fmt.Errorf("error getting public access block for %s: %w", bucketName, err)
+	}
+
+	if result.PublicAccessBlockConfiguration == nil {
+		return nil, fmt.Errorf("bucket %s does not have public access block configuration", bucketName)
+	}
+
+	return result.PublicAccessBlockConfiguration, nil
+}
+
+func (a *AWSAsserter) AssertBucketServerAccessLogging(bucketName string) (err error) {
+	defer a.withStateSnapshot(&err, "s3:"+bucketName, "s3:")
+
+	logging, err := a.getBucketLogging(bucketName)
+	if err != nil {
+		return err
+	}
+
+	if logging == nil {
+		return fmt.Errorf("bucket %s does not have server access logging configuration", bucketName)
 	}
 
-	result, err := client.GetBucketLogging(context.TODO(), input)
+	return nil
+}
+
+// AssertBucketLoggingTarget checks if the bucket's server access logs are delivered to the
+// target bucket under the target prefix
+func (a *AWSAsserter) AssertBucketLoggingTarget(bucketName, targetBucket, targetPrefix string) (err error) {
+	defer a.withStateSnapshot(&err, "s3:"+bucketName, "s3:")
+
+	logging, err := a.getBucketLogging(bucketName)
 	if err != nil {
-		return fmt.Errorf("error getting bucket logging for %s: %w", bucketName, err)
+		return err
 	}
 
-	if result.LoggingEnabled == nil {
+	if logging == nil {
 		return fmt.Errorf("bucket %s does not have server access logging configuration", bucketName)
 	}
 
+	if actual := aws.ToString(logging.TargetBucket); actual != targetBucket {
+		return fmt.Errorf("expected bucket %s to log to bucket %s, but it logs to %s", bucketName, targetBucket, actual)
+	}
+
+	if actual := aws.ToString(logging.TargetPrefix); actual != targetPrefix {
+		return fmt.Errorf("expected bucket %s to log with prefix %q, but got %q", bucketName, targetPrefix, actual)
+	}
+
+	return nil
+}
+
+// AssertBucketLoggingDisabled checks if the bucket doesn't have server access logging enabled
+func (a *AWSAsserter) AssertBucketLoggingDisabled(bucketName string) (err error) {
+	defer a.withStateSnapshot(&err, "s3:"+bucketName, "s3:")
+
+	logging, err := a.getBucketLogging(bucketName)
+	if err != nil {
+		return err
+	}
+
+	if logging != nil {
+		return fmt.Errorf("expected bucket %s not to have logging enabled, but it logs to bucket %s", bucketName, aws.ToString(logging.TargetBucket))
+	}
+
+	return nil
+}
+
+// getBucketLogging returns the bucket's logging configuration, or nil if logging isn't enabled
+func (a *AWSAsserter) getBucketLogging(bucketName string) (*types.LoggingEnabled, error) {
+	client, err := a.createS3Client()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.GetBucketLogging(context.TODO(), &s3.GetBucketLoggingInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting bucket logging for %s: %w", bucketName, err)
+	}
+
+	return result.LoggingEnabled, nil
+}
+
+// AssertBucketPolicyAllows checks if the bucket policy grants the given action (e.g. s3:GetObject).
+// If principal is empty, statements for any principal are considered.
+func (a *AWSAsserter) AssertBucketPolicyAllows(bucketName, action, principal string) (err error) {
+	defer a.withStateSnapshot(&err, "s3:"+bucketName, "s3:")
+
+	policy, err := a.getBucketPolicy(bucketName)
+	if err != nil {
+		return err
+	}
+	if policy == nil {
+		return fmt.Errorf("bucket %s does not have a bucket policy", bucketName)
+	}
+
+	if !policy.allowsAction(action, principal) {
+		if principal != "" {
+			return fmt.Errorf("bucket %s policy does not allow %s for %s", bucketName, action, principal)
+		}
+		return fmt.Errorf("bucket %s policy does not allow %s", bucketName, action)
+	}
+
 	return nil
 }
 
+// AssertBucketPolicyDeniesPublicAccess checks that the bucket policy does not grant unconditional access
+// to anonymous principals. A bucket without a policy passes this check.
+func (a *AWSAsserter) AssertBucketPolicyDeniesPublicAccess(bucketName string) (err error) {
+	defer a.withStateSnapshot(&err, "s3:"+bucketName, "s3:")
+
+	policy, err := a.getBucketPolicy(bucketName)
+	if err != nil {
+		return err
+	}
+	if policy == nil {
+		return nil
+	}
+
+	if statements := policy.publicAllowStatements(); len(statements) > 0 {
+		return fmt.Errorf("bucket %s policy allows public access via statement(s): %s", bucketName, strings.Join(statements, ", "))
+	}
+
+	return nil
+}
+
+// AssertObjectMatchesFile checks that the object's content is byte-for-byte the content of the
+// local file. With ignoreWhitespace, whitespace is removed from both before they are compared.
+func (a *AWSAsserter) AssertObjectMatchesFile(bucketName, key, filePath string, ignoreWhitespace bool) (err error) {
+	defer a.withStateSnapshot(&err, "s3:"+bucketName, "s3:")
+
+	expected, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("error reading file %s: %w", filePath, err)
+	}
+
+	client, err := a.createS3Client()
+	if err != nil {
+		return err
+	}
+
+	result, err := client.GetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("error getting object %s from bucket %s: %w", key, bucketName, err)
+	}
+	defer result.Body.Close()
+
+	actual, err := io.ReadAll(result.Body)
+	if err != nil {
+		return fmt.Errorf("error reading object %s from bucket %s: %w", key, bucketName, err)
+	}
+
+	description := "at byte offset"
+	if ignoreWhitespace {
+		expected, actual = removeWhitespace(expected), removeWhitespace(actual)
+		description = "ignoring whitespace, at non-whitespace byte offset"
+	}
+	if offset := firstDifference(actual, expected); offset >= 0 {
+		return fmt.Errorf("object %s in bucket %s differs from file %s %s %d (object is %d bytes, file is %d bytes)",
+			key, bucketName, filePath, description, offset, len(actual), len(expected))
+	}
+
+	return nil
+}
+
+// AssertObjectStorageClass checks the storage class of an object, e.g. "STANDARD_IA" or "GLACIER"
+func (a *AWSAsserter) AssertObjectStorageClass(bucketName, key, storageClass string) (err error) {
+	defer a.withStateSnapshot(&err, "s3:"+bucketName+":object:"+key, "s3:"+bucketName+":object:")
+
+	client, err := a.createS3Client()
+	if err != nil {
+		return err
+	}
+
+	result, err := client.HeadObject(context.TODO(), &s3.HeadObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("error getting object %s from bucket %s: %w", key, bucketName, err)
+	}
+
+	// S3 only returns the storage class of objects that aren't STANDARD
+	actual := string(result.StorageClass)
+	if actual == "" {
+		actual = string(types.StorageClassStandard)
+	}
+	if actual != storageClass {
+		return fmt.Errorf("expected object %s in bucket %s to have storage class %s, but got %s", key, bucketName, storageClass, actual)
+	}
+
+	return nil
+}
+
+// AssertBucketLifecycleTransition checks that an enabled lifecycle rule of the bucket
+// transitions objects to the storage class after the number of days
+func (a *AWSAsserter) AssertBucketLifecycleTransition(bucketName, storageClass string, days int32) (err error) {
+	defer a.withStateSnapshot(&err, "s3:"+bucketName, "s3:")
+
+	client, err := a.createS3Client()
+	if err != nil {
+		return err
+	}
+
+	result, err := client.GetBucketLifecycleConfiguration(context.TODO(), &s3.GetBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		return fmt.Errorf("error getting lifecycle configuration for bucket %s: %w", bucketName, err)
+	}
+
+	transitions := lifecycleTransitions(result.Rules)
+	expected := fmt.Sprintf("%s after %d days", storageClass, days)
+	if !slices.Contains(transitions, expected) {
+		return fmt.Errorf("bucket %s has no enabled lifecycle rule transitioning to %s, its rules transition to %v", bucketName, expected, transitions)
+	}
+
+	return nil
+}
+
+// AssertBucketsHaveSameObjects checks that two buckets have the same object keys, and with
+// compareETags that each object has the same ETag in both, reporting the keys that differ
+func (a *AWSAsserter) AssertBucketsHaveSameObjects(bucketName, otherBucketName string, compareETags bool) (err error) {
+	defer a.withStateSnapshot(&err, "s3:"+bucketName, "s3:")
+
+	objects, err := a.listObjectETags(bucketName)
+	if err != nil {
+		return err
+	}
+	otherObjects, err := a.listObjectETags(otherBucketName)
+	if err != nil {
+		return err
+	}
+
+	if differences := objectDifferences(bucketName, objects, otherBucketName, otherObjects, compareETags); len(differences) > 0 {
+		return fmt.Errorf("bucket %s doesn't have the same objects as bucket %s:\n  %s",
+			bucketName, otherBucketName, strings.Join(differences, "\n  "))
+	}
+
+	return nil
+}
+
+// listObjectETags returns the ETag of every object in the bucket, by key
+func (a *AWSAsserter) listObjectETags(bucketName string) (map[string]string, error) {
+	client, err := a.createS3Client()
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make(map[string]string)
+	paginator := s3.NewListObjectsV2Paginator(client, &s3.ListObjectsV2Input{Bucket: aws.String(bucketName)})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("error listing objects in bucket %s: %w", bucketName, err)
+		}
+		for _, object := range page.Contents {
+			objects[aws.ToString(object.Key)] = aws.ToString(object.ETag)
+		}
+	}
+	return objects, nil
+}
+
+// objectDifferences describes the keys that are only in one of two buckets, and with compareETags
+// the keys whose ETags differ, in key order
+func objectDifferences(bucketName string, objects map[string]string, otherBucketName string, otherObjects map[string]string, compareETags bool) []string {
+	keys := make([]string, 0, len(objects)+len(otherObjects))
+	for key := range objects {
+		keys = append(keys, key)
+	}
+	for key := range otherObjects {
+		if _, ok := objects[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var differences []string
+	for _, key := range keys {
+		etag, ok := objects[key]
+		otherETag, otherOk := otherObjects[key]
+		switch {
+		case !otherOk:
+			differences = append(differences, fmt.Sprintf("%s is only in bucket %s", key, bucketName))
+		case !ok:
+			differences = append(differences, fmt.Sprintf("%s is only in bucket %s", key, otherBucketName))
+		case compareETags && etag != otherETag:
+			differences = append(differences, fmt.Sprintf("%s has ETag %s in bucket %s, but %s in bucket %s", key, etag, bucketName, otherETag, otherBucketName))
+		}
+	}
+	return differences
+}
+
+// lifecycleTransitions describes the day-based transitions of the enabled lifecycle rules,
+// e.g. "GLACIER after 90 days"
+func lifecycleTransitions(rules []types.LifecycleRule) []string {
+	var transitions []string
+	for _, rule := range rules {
+		if rule.Status != types.ExpirationStatusEnabled {
+			continue
+		}
+		for _, transition := range rule.Transitions {
+			if transition.Days == nil {
+				continue
+			}
+			transitions = append(transitions, fmt.Sprintf("%s after %d days", transition.StorageClass, aws.ToInt32(transition.Days)))
+		}
+	}
+	return transitions
+}
+
+// firstDifference returns the offset of the first byte at which a and b differ, or -1 if they
+// are equal. If one is a prefix of the other, it is the length of the shorter.
+func firstDifference(a, b []byte) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	if len(a) != len(b) {
+		return min(len(a), len(b))
+	}
+	return -1
+}
+
+func removeWhitespace(content []byte) []byte {
+	return bytes.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, content)
+}
+
+// getBucketPolicy fetches and parses the bucket policy, returning nil if the bucket has no policy
+func (a *AWSAsserter) getBucketPolicy(bucketName string) (*policyDocument, error) {
+	client, err := a.createS3Client()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.GetBucketPolicy(context.TODO(), &s3.GetBucketPolicyInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchBucketPolicy" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting bucket policy for %s: %w", bucketName, err)
+	}
+
+	policy, err := parsePolicyDocument(aws.ToString(result.Policy))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing bucket policy for %s: %w", bucketName, err)
+	}
+
+	return policy, nil
+}
+
 // Helper method to create an S3 client
 func (a *AWSAsserter) createS3Client() (*s3.Client, error) {
 	cfg, err := awshelpers.NewAuthenticatedSessionWithDefaultRegion()
@@ -0,0 +1,114 @@
+package aws
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robmorgan/infraspec/pkg/embedded"
+)
+
+func TestFirstDifference(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{name: "equal", a: "artifact", b: "artifact", want: -1},
+		{name: "differing byte", a: "artifact-v1", b: "artifact-v2", want: 10},
+		{name: "prefix", a: "artifact", b: "artifact.tar", want: 8},
+		{name: "empty", a: "", b: "x", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, firstDifference([]byte(tt.a), []byte(tt.b)))
+			assert.Equal(t, tt.want, firstDifference([]byte(tt.b), []byte(tt.a)))
+		})
+	}
+}
+
+func TestRemoveWhitespace(t *testing.T) {
+	assert.Equal(t, "{\"name\":\"web\"}", string(removeWhitespace([]byte("{\n  \"name\": \"web\"\r\n}\n"))))
+}
+
+func TestLifecycleTransitions(t *testing.T) {
+	rules := []types.LifecycleRule{
+		{
+			Status: types.ExpirationStatusEnabled,
+			Transitions: []types.Transition{
+				{Days: aws.Int32(30), StorageClass: types.TransitionStorageClassStandardIa},
+				{Days: aws.Int32(90), StorageClass: types.TransitionStorageClassGlacier},
+			},
+		},
+		{
+			Status:      types.ExpirationStatusDisabled,
+			Transitions: []types.Transition{{Days: aws.Int32(7), StorageClass: types.TransitionStorageClassDeepArchive}},
+		},
+	}
+
+	assert.Equal(t, []string{"STANDARD_IA after 30 days", "GLACIER after 90 days"}, lifecycleTransitions(rules))
+	assert.Empty(t, lifecycleTransitions(nil))
+}
+
+func TestObjectDifferences(t *testing.T) {
+	source := map[string]string{"a.txt": `"1"`, "b.txt": `"2"`, "c.txt": `"3"`}
+	replica := map[string]string{"b.txt": `"2"`, "c.txt": `"4"`, "d.txt": `"5"`}
+
+	assert.Equal(t, []string{
+		"a.txt is only in bucket source",
+		"d.txt is only in bucket replica",
+	}, objectDifferences("source", source, "replica", replica, false))
+
+	assert.Equal(t, []string{
+		"a.txt is only in bucket source",
+		`c.txt has ETag "3" in bucket source, but "4" in bucket replica`,
+		"d.txt is only in bucket replica",
+	}, objectDifferences("source", source, "replica", replica, true))
+
+	assert.Empty(t, objectDifferences("source", source, "copy", source, true))
+}
+
+func TestAssertBucketsHaveSameObjects(t *testing.T) {
+	// Over a Unix socket, virtual-hosted bucket addresses don't need to resolve
+	emu := embedded.New()
+	emu.SetListenAddress("unix://" + filepath.Join(t.TempDir(), "infraspec.sock"))
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+	t.Setenv("AWS_ENDPOINT_URL", emu.Endpoint())
+	t.Setenv("AWS_REGION", "us-east-1")
+
+	a := NewAWSAsserter()
+	client, err := a.createS3Client()
+	require.NoError(t, err)
+	put := func(bucket, key, body string) {
+		_, err := client.PutObject(context.Background(), &s3.PutObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+			Body:   strings.NewReader(body),
+		})
+		require.NoError(t, err)
+	}
+	for _, bucket := range []string{"artifacts", "artifacts-replica"} {
+		_, err := client.CreateBucket(context.Background(), &s3.CreateBucketInput{Bucket: aws.String(bucket)})
+		require.NoError(t, err)
+		put(bucket, "app.zip", "release 1.2.0")
+		put(bucket, "checksums.txt", "sha256 app.zip")
+	}
+
+	assert.NoError(t, a.AssertBucketsHaveSameObjects("artifacts", "artifacts-replica", true))
+	assert.NoError(t, a.AssertBucketsHaveSameObjects("artifacts", "artifacts-replica", false))
+
+	put("artifacts-replica", "app.zip", "release 1.1.0")
+	assert.NoError(t, a.AssertBucketsHaveSameObjects("artifacts", "artifacts-replica", false))
+	err = a.AssertBucketsHaveSameObjects("artifacts", "artifacts-replica", true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "app.zip")
+}
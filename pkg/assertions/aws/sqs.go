@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
@@ -26,8 +27,12 @@ type SQSAsserter interface {
 	AssertQueueReceiveMessageWaitTime(queueName string, waitTime int) error
 	AssertQueueIsFifo(queueName string) error
 	AssertQueueHasDeadLetterQueue(queueName string) error
-	AssertQueueTags(queueName string, expectedTags map[string]string) error
+	AssertQueueTags(queueName string, expectedTags map[string]string, mode TagMatchMode) error
 	AssertQueueEncryption(queueName string, expectEncrypted bool) error
+	AssertQueueMessageCount(queueName string, count int) error
+	AssertQueueMessagesInFlight(queueName string, count int) error
+	PurgeQueue(queueName string) error
+	ReceiveMessage(queueName string, timeout time.Duration) (string, error)
 }
 
 // AssertSQSDescribeQueues checks if the AWS account has permission to list SQS queues
@@ -47,7 +52,9 @@ func (a *AWSAsserter) AssertSQSDescribeQueues() error {
 }
 
 // AssertQueueExists checks if an SQS queue exists
-func (a *AWSAsserter) AssertQueueExists(queueName string) error {
+func (a *AWSAsserter) AssertQueueExists(queueName string) (err error) {
+	defer a.withStateSnapshot(&err, "sqs:queue:"+queueName, "sqs:queue:")
+
 	client, err := a.createSQSClient()
 	if err != nil {
 		return err
@@ -65,7 +72,9 @@ func (a *AWSAsserter) AssertQueueExists(queueName string) error {
 }
 
 // AssertQueueVisibilityTimeout checks if a queue has the expected visibility timeout
-func (a *AWSAsserter) AssertQueueVisibilityTimeout(queueName string, timeout int) error {
+func (a *AWSAsserter) AssertQueueVisibilityTimeout(queueName string, timeout int) (err error) {
+	defer a.withStateSnapshot(&err, "sqs:queue:"+queueName, "sqs:queue:")
+
 	attrs, err := a.getQueueAttributes(queueName, []types.QueueAttributeName{types.QueueAttributeNameVisibilityTimeout})
 	if err != nil {
 		return err
@@ -89,7 +98,9 @@ func (a *AWSAsserter) AssertQueueVisibilityTimeout(queueName string, timeout int
 }
 
 // AssertQueueDelaySeconds checks if a queue has the expected delay seconds
-func (a *AWSAsserter) AssertQueueDelaySeconds(queueName string, delay int) error {
+func (a *AWSAsserter) AssertQueueDelaySeconds(queueName string, delay int) (err error) {
+	defer a.withStateSnapshot(&err, "sqs:queue:"+queueName, "sqs:queue:")
+
 	attrs, err := a.getQueueAttributes(queueName, []types.QueueAttributeName{types.QueueAttributeNameDelaySeconds})
 	if err != nil {
 		return err
@@ -113,7 +124,9 @@ func (a *AWSAsserter) AssertQueueDelaySeconds(queueName string, delay int) error
 }
 
 // AssertQueueMaxMessageSize checks if a queue has the expected max message size
-func (a *AWSAsserter) AssertQueueMaxMessageSize(queueName string, size int) error {
+func (a *AWSAsserter) AssertQueueMaxMessageSize(queueName string, size int) (err error) {
+	defer a.withStateSnapshot(&err, "sqs:queue:"+queueName, "sqs:queue:")
+
 	attrs, err := a.getQueueAttributes(queueName, []types.QueueAttributeName{types.QueueAttributeNameMaximumMessageSize})
 	if err != nil {
 		return err
@@ -137,7 +150,9 @@ func (a *AWSAsserter) AssertQueueMaxMessageSize(queueName string, size int) erro
 }
 
 // AssertQueueMessageRetentionPeriod checks if a queue has the expected message retention period
-func (a *AWSAsserter) AssertQueueMessageRetentionPeriod(queueName string, period int) error {
+func (a *AWSAsserter) AssertQueueMessageRetentionPeriod(queueName string, period int) (err error) {
+	defer a.withStateSnapshot(&err, "sqs:queue:"+queueName, "sqs:queue:")
+
 	attrs, err := a.getQueueAttributes(queueName, []types.QueueAttributeName{types.QueueAttributeNameMessageRetentionPeriod})
 	if err != nil {
 		return err
@@ -161,7 +176,9 @@ func (a *AWSAsserter) AssertQueueMessageRetentionPeriod(queueName string, period
 }
 
 // AssertQueueReceiveMessageWaitTime checks if a queue has the expected receive message wait time
-func (a *AWSAsserter) AssertQueueReceiveMessageWaitTime(queueName string, waitTime int) error {
+func (a *AWSAsserter) AssertQueueReceiveMessageWaitTime(queueName string, waitTime int) (err error) {
+	defer a.withStateSnapshot(&err, "sqs:queue:"+queueName, "sqs:queue:")
+
 	attrs, err := a.getQueueAttributes(queueName, []types.QueueAttributeName{types.QueueAttributeNameReceiveMessageWaitTimeSeconds})
 	if err != nil {
 		return err
@@ -185,7 +202,9 @@ func (a *AWSAsserter) AssertQueueReceiveMessageWaitTime(queueName string, waitTi
 }
 
 // AssertQueueIsFifo checks if a queue is a FIFO queue
-func (a *AWSAsserter) AssertQueueIsFifo(queueName string) error {
+func (a *AWSAsserter) AssertQueueIsFifo(queueName string) (err error) {
+	defer a.withStateSnapshot(&err, "sqs:queue:"+queueName, "sqs:queue:")
+
 	attrs, err := a.getQueueAttributes(queueName, []types.QueueAttributeName{types.QueueAttributeNameFifoQueue})
 	if err != nil {
 		return err
@@ -200,7 +219,9 @@ func (a *AWSAsserter) AssertQueueIsFifo(queueName string) error {
 }
 
 // AssertQueueHasDeadLetterQueue checks if a queue has a dead letter queue configured
-func (a *AWSAsserter) AssertQueueHasDeadLetterQueue(queueName string) error {
+func (a *AWSAsserter) AssertQueueHasDeadLetterQueue(queueName string) (err error) {
+	defer a.withStateSnapshot(&err, "sqs:queue:"+queueName, "sqs:queue:")
+
 	attrs, err := a.getQueueAttributes(queueName, []types.QueueAttributeName{types.QueueAttributeNameRedrivePolicy})
 	if err != nil {
 		return err
@@ -214,8 +235,10 @@ func (a *AWSAsserter) AssertQueueHasDeadLetterQueue(queueName string) error {
 	return nil
 }
 
-// AssertQueueTags checks if a queue has the expected tags
-func (a *AWSAsserter) AssertQueueTags(queueName string, expectedTags map[string]string) error {
+// AssertQueueTags checks if a queue has the expected tags, compared according to mode
+func (a *AWSAsserter) AssertQueueTags(queueName string, expectedTags map[string]string, mode TagMatchMode) (err error) {
+	defer a.withStateSnapshot(&err, "sqs:queue:"+queueName, "sqs:queue:")
+
 	client, err := a.createSQSClient()
 	if err != nil {
 		return err
@@ -233,21 +256,17 @@ func (a *AWSAsserter) AssertQueueTags(queueName string, expectedTags map[string]
 		return fmt.Errorf("error getting tags for queue %s: %w", queueName, err)
 	}
 
-	for key, expectedValue := range expectedTags {
-		actualValue, ok := result.Tags[key]
-		if !ok {
-			return fmt.Errorf("queue %s is missing tag %s", queueName, key)
-		}
-		if actualValue != expectedValue {
-			return fmt.Errorf("queue %s tag %s has value %s, expected %s", queueName, key, actualValue, expectedValue)
-		}
+	if err := CompareTags(result.Tags, expectedTags, mode); err != nil {
+		return fmt.Errorf("queue %s: %w", queueName, err)
 	}
 
 	return nil
 }
 
 // AssertQueueEncryption checks if a queue has encryption enabled or disabled
-func (a *AWSAsserter) AssertQueueEncryption(queueName string, expectEncrypted bool) error {
+func (a *AWSAsserter) AssertQueueEncryption(queueName string, expectEncrypted bool) (err error) {
+	defer a.withStateSnapshot(&err, "sqs:queue:"+queueName, "sqs:queue:")
+
 	attrs, err := a.getQueueAttributes(queueName, []types.QueueAttributeName{
 		types.QueueAttributeNameKmsMasterKeyId,
 		types.QueueAttributeNameSqsManagedSseEnabled,
@@ -271,6 +290,103 @@ func (a *AWSAsserter) AssertQueueEncryption(queueName string, expectEncrypted bo
 	return nil
 }
 
+// AssertQueueMessageCount checks the approximate number of messages available to receive
+func (a *AWSAsserter) AssertQueueMessageCount(queueName string, count int) (err error) {
+	defer a.withStateSnapshot(&err, "sqs:queue:"+queueName, "sqs:queue:")
+
+	return a.assertQueueCountAttribute(queueName, types.QueueAttributeNameApproximateNumberOfMessages, "available", count)
+}
+
+// AssertQueueMessagesInFlight checks the approximate number of messages that have been received
+// but not deleted and whose visibility timeout hasn't expired
+func (a *AWSAsserter) AssertQueueMessagesInFlight(queueName string, count int) (err error) {
+	defer a.withStateSnapshot(&err, "sqs:queue:"+queueName, "sqs:queue:")
+
+	return a.assertQueueCountAttribute(queueName, types.QueueAttributeNameApproximateNumberOfMessagesNotVisible, "in flight", count)
+}
+
+// PurgeQueue deletes every message in the queue, including messages in flight
+func (a *AWSAsserter) PurgeQueue(queueName string) error {
+	queueUrl, err := a.getQueueUrl(queueName)
+	if err != nil {
+		return err
+	}
+
+	client, err := a.createSQSClient()
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.PurgeQueue(context.TODO(), &sqs.PurgeQueueInput{QueueUrl: aws.String(queueUrl)}); err != nil {
+		return fmt.Errorf("error purging queue %s: %w", queueName, err)
+	}
+	return nil
+}
+
+// ReceiveMessage long-polls the queue until a message arrives or the timeout passes, then
+// deletes the message, like a consumer would, and returns its body
+func (a *AWSAsserter) ReceiveMessage(queueName string, timeout time.Duration) (string, error) {
+	queueUrl, err := a.getQueueUrl(queueName)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := a.createSQSClient()
+	if err != nil {
+		return "", err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		// SQS long polls for at most 20 seconds
+		waitTime := min(int32(time.Until(deadline).Seconds()), 20)
+		result, err := client.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
+			QueueUrl:            aws.String(queueUrl),
+			MaxNumberOfMessages: 1,
+			WaitTimeSeconds:     max(waitTime, 0),
+		})
+		if err != nil {
+			return "", fmt.Errorf("error receiving a message from queue %s: %w", queueName, err)
+		}
+
+		if len(result.Messages) > 0 {
+			message := result.Messages[0]
+			if _, err := client.DeleteMessage(context.TODO(), &sqs.DeleteMessageInput{
+				QueueUrl:      aws.String(queueUrl),
+				ReceiptHandle: message.ReceiptHandle,
+			}); err != nil {
+				return "", fmt.Errorf("error deleting the message received from queue %s: %w", queueName, err)
+			}
+			return aws.ToString(message.Body), nil
+		}
+
+		if !time.Now().Before(deadline) {
+			return "", fmt.Errorf("no message was received from queue %s within %s", queueName, timeout)
+		}
+
+		// The emulator returns at once rather than long polling, so don't poll it in a busy loop
+		time.Sleep(min(100*time.Millisecond, time.Until(deadline)))
+	}
+}
+
+func (a *AWSAsserter) assertQueueCountAttribute(queueName string, attribute types.QueueAttributeName, description string, count int) error {
+	attrs, err := a.getQueueAttributes(queueName, []types.QueueAttributeName{attribute})
+	if err != nil {
+		return err
+	}
+
+	actual, err := strconv.Atoi(attrs[string(attribute)])
+	if err != nil {
+		return fmt.Errorf("invalid %s value: %s", attribute, attrs[string(attribute)])
+	}
+
+	if actual != count {
+		return fmt.Errorf("queue %s has %d messages %s, expected %d", queueName, actual, description, count)
+	}
+
+	return nil
+}
+
 // Helper method to create an SQS client
 func (a *AWSAsserter) createSQSClient() (*sqs.Client, error) {
 	cfg, err := awshelpers.NewAuthenticatedSessionWithDefaultRegion()
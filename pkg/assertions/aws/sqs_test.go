@@ -0,0 +1,50 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robmorgan/infraspec/pkg/embedded"
+)
+
+func TestReceiveMessage(t *testing.T) {
+	emu := embedded.New()
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+	t.Setenv("AWS_ENDPOINT_URL_SQS", emu.Endpoint())
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	t.Setenv("AWS_REGION", "us-east-1")
+
+	a := NewAWSAsserter()
+	client, err := a.createSQSClient()
+	require.NoError(t, err)
+
+	queue, err := client.CreateQueue(context.Background(), &sqs.CreateQueueInput{QueueName: aws.String("order-events")})
+	require.NoError(t, err)
+	_, err = client.SendMessage(context.Background(), &sqs.SendMessageInput{
+		QueueUrl:    queue.QueueUrl,
+		MessageBody: aws.String(`{"type":"order.created"}`),
+	})
+	require.NoError(t, err)
+
+	body, err := a.ReceiveMessage("order-events", 5*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"order.created"}`, body)
+
+	// The message was deleted, so the queue is empty
+	assert.NoError(t, a.AssertQueueMessageCount("order-events", 0))
+	assert.NoError(t, a.AssertQueueMessagesInFlight("order-events", 0))
+
+	start := time.Now()
+	_, err = a.ReceiveMessage("order-events", time.Second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no message was received from queue order-events within 1s")
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
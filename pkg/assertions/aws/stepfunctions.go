@@ -0,0 +1,95 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sfn"
+	"github.com/aws/aws-sdk-go-v2/service/sfn/types"
+
+	"github.com/robmorgan/infraspec/pkg/awshelpers"
+)
+
+// Ensure the `AWSAsserter` struct implements the `StepFunctionsAsserter` interface.
+var _ StepFunctionsAsserter = (*AWSAsserter)(nil)
+
+// StepFunctionsAsserter defines Step Functions-specific assertions
+type StepFunctionsAsserter interface {
+	AssertStateMachineExists(name string) error
+	AssertStateMachineRole(name, roleArn string) error
+}
+
+// AssertStateMachineExists checks if the state machine exists
+func (a *AWSAsserter) AssertStateMachineExists(name string) error {
+	_, err := a.describeStateMachine(name)
+	return err
+}
+
+// AssertStateMachineRole checks that the state machine runs with the IAM role
+func (a *AWSAsserter) AssertStateMachineRole(name, roleArn string) error {
+	machine, err := a.describeStateMachine(name)
+	if err != nil {
+		return err
+	}
+
+	if actual := aws.ToString(machine.RoleArn); actual != roleArn {
+		return fmt.Errorf("expected state machine %s to have role %s, got %s", name, roleArn, actual)
+	}
+	return nil
+}
+
+// describeStateMachine finds the state machine by name and describes it
+func (a *AWSAsserter) describeStateMachine(name string) (*sfn.DescribeStateMachineOutput, error) {
+	client, err := a.createStepFunctionsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	machine, err := findStateMachine(client, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.DescribeStateMachine(context.TODO(), &sfn.DescribeStateMachineInput{
+		StateMachineArn: machine.StateMachineArn,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error describing state machine %s: %w", name, err)
+	}
+	return result, nil
+}
+
+// findStateMachine lists state machines until it finds the one with the name
+func findStateMachine(client *sfn.Client, name string) (*types.StateMachineListItem, error) {
+	paginator := sfn.NewListStateMachinesPaginator(client, &sfn.ListStateMachinesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, fmt.Errorf("error listing state machines: %w", err)
+		}
+		for _, machine := range page.StateMachines {
+			if aws.ToString(machine.Name) == name {
+				return &machine, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("state machine %s does not exist", name)
+}
+
+// Helper method to create a Step Functions client
+func (a *AWSAsserter) createStepFunctionsClient() (*sfn.Client, error) {
+	cfg, err := awshelpers.NewAuthenticatedSessionWithDefaultRegion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %w", err)
+	}
+
+	opts := make([]func(*sfn.Options), 0, 1)
+	if endpoint, ok := awshelpers.GetVirtualCloudEndpoint("states"); ok {
+		opts = append(opts, func(o *sfn.Options) {
+			o.BaseEndpoint = aws.String(endpoint)
+		})
+	}
+
+	return sfn.NewFromConfig(*cfg, opts...), nil
+}
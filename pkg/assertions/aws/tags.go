@@ -0,0 +1,65 @@
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TagMatchMode controls how a resource's actual tags are compared against the expected tags.
+type TagMatchMode int
+
+const (
+	// TagMatchSubset requires every expected tag to be present with the expected value.
+	// Additional tags on the resource are ignored.
+	TagMatchSubset TagMatchMode = iota
+
+	// TagMatchExact requires the resource to have exactly the expected tags and no others.
+	TagMatchExact
+)
+
+// CompareTags compares actual tags against expected tags using the given match mode.
+// The returned error lists every missing, mismatched and (in exact mode) unexpected key.
+func CompareTags(actualTags, expectedTags map[string]string, mode TagMatchMode) error {
+	var missing, mismatched, extra []string
+
+	for key, value := range expectedTags {
+		actualValue, exists := actualTags[key]
+		if !exists {
+			missing = append(missing, key)
+			continue
+		}
+		if actualValue != value {
+			mismatched = append(mismatched, fmt.Sprintf("%s (expected %q, got %q)", key, value, actualValue))
+		}
+	}
+
+	if mode == TagMatchExact {
+		for key, value := range actualTags {
+			if _, expected := expectedTags[key]; !expected {
+				extra = append(extra, fmt.Sprintf("%s=%s", key, value))
+			}
+		}
+	}
+
+	if len(missing) == 0 && len(mismatched) == 0 && len(extra) == 0 {
+		return nil
+	}
+
+	sort.Strings(missing)
+	sort.Strings(mismatched)
+	sort.Strings(extra)
+
+	var parts []string
+	if len(missing) > 0 {
+		parts = append(parts, fmt.Sprintf("missing tags: %s", strings.Join(missing, ", ")))
+	}
+	if len(mismatched) > 0 {
+		parts = append(parts, fmt.Sprintf("mismatched tags: %s", strings.Join(mismatched, ", ")))
+	}
+	if len(extra) > 0 {
+		parts = append(parts, fmt.Sprintf("unexpected tags: %s", strings.Join(extra, ", ")))
+	}
+
+	return fmt.Errorf("tag mismatch: %s", strings.Join(parts, "; "))
+}
@@ -0,0 +1,56 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareTags(t *testing.T) {
+	actual := map[string]string{
+		"Name":        "web",
+		"Environment": "prod",
+		"ManagedBy":   "terraform",
+	}
+
+	tests := []struct {
+		name     string
+		expected map[string]string
+		mode     TagMatchMode
+		wantErr  string
+	}{
+		{
+			name:     "subset ignores extra tags",
+			expected: map[string]string{"Name": "web"},
+			mode:     TagMatchSubset,
+		},
+		{
+			name:     "subset reports missing and mismatched tags",
+			expected: map[string]string{"Name": "api", "Owner": "team-a", "Cost": "1"},
+			mode:     TagMatchSubset,
+			wantErr:  `tag mismatch: missing tags: Cost, Owner; mismatched tags: Name (expected "api", got "web")`,
+		},
+		{
+			name:     "exact matches identical tags",
+			expected: map[string]string{"Name": "web", "Environment": "prod", "ManagedBy": "terraform"},
+			mode:     TagMatchExact,
+		},
+		{
+			name:     "exact reports unexpected tags",
+			expected: map[string]string{"Name": "web"},
+			mode:     TagMatchExact,
+			wantErr:  "tag mismatch: unexpected tags: Environment=prod, ManagedBy=terraform",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CompareTags(actual, tt.expected, tt.mode)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
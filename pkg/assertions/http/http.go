@@ -3,7 +3,9 @@ package http
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/robmorgan/infraspec/pkg/httphelpers"
 )
@@ -14,6 +16,9 @@ type HTTPAsserter interface {
 	AssertResponseHeader(resp *httphelpers.HttpResponse, headerName, expectedValue string) error
 	AssertResponseContains(resp *httphelpers.HttpResponse, expectedContent string) error
 	AssertResponseJSON(resp *httphelpers.HttpResponse) error
+	AssertResponseTime(resp *httphelpers.HttpResponse, maxDuration time.Duration) error
+	AssertResponseCookie(resp *httphelpers.HttpResponse, cookieName string) error
+	AssertResponseCookieValue(resp *httphelpers.HttpResponse, cookieName, expectedValue string) error
 }
 
 // HTTPAsserter implements HTTP-specific assertions
@@ -66,3 +71,44 @@ func (h *httpAsserter) AssertResponseHeader(resp *httphelpers.HttpResponse, head
 
 	return nil
 }
+
+// AssertResponseTime checks if the HTTP response was received within the maximum duration
+func (h *httpAsserter) AssertResponseTime(resp *httphelpers.HttpResponse, maxDuration time.Duration) error {
+	if resp.Duration > maxDuration {
+		return fmt.Errorf("expected response within %s, took %s", maxDuration, resp.Duration.Round(time.Millisecond))
+	}
+
+	return nil
+}
+
+// AssertResponseCookie checks if the HTTP response sets the cookie
+func (h *httpAsserter) AssertResponseCookie(resp *httphelpers.HttpResponse, cookieName string) error {
+	if responseCookie(resp, cookieName) == nil {
+		return fmt.Errorf("response does not set the cookie '%s'", cookieName)
+	}
+
+	return nil
+}
+
+// AssertResponseCookieValue checks if the HTTP response sets the cookie to the expected value
+func (h *httpAsserter) AssertResponseCookieValue(resp *httphelpers.HttpResponse, cookieName, expectedValue string) error {
+	cookie := responseCookie(resp, cookieName)
+	if cookie == nil {
+		return fmt.Errorf("response does not set the cookie '%s'", cookieName)
+	}
+	if cookie.Value != expectedValue {
+		return fmt.Errorf("expected cookie '%s' to be '%s', got '%s'", cookieName, expectedValue, cookie.Value)
+	}
+
+	return nil
+}
+
+// responseCookie returns the cookie the response's Set-Cookie headers set, or nil if they don't
+func responseCookie(resp *httphelpers.HttpResponse, cookieName string) *http.Cookie {
+	for _, cookie := range (&http.Response{Header: resp.Headers}).Cookies() {
+		if cookie.Name == cookieName {
+			return cookie
+		}
+	}
+	return nil
+}
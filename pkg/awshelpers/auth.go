@@ -19,13 +19,24 @@ const (
 
 // NewAuthenticatedSession creates an AWS Config following to standard AWS authentication workflow.
 // If AWS_ENDPOINT_URL points to localhost (embedded emulator mode), uses dummy credentials.
+// If it's a Unix socket, requests are also sent over the socket.
 // If `INFRASPEC_IAM_ROLE` environment variable is set, it assumes IAM role specified in it.
 // Otherwise, uses default credentials.
 func NewAuthenticatedSession(region string) (*aws.Config, error) {
 	// If endpoint is localhost (embedded emulator), use dummy credentials
-	if endpoint := os.Getenv("AWS_ENDPOINT_URL"); isLocalhost(endpoint) {
+	endpoint := os.Getenv("AWS_ENDPOINT_URL")
+	if isLocalhost(endpoint) {
 		return NewAuthenticatedSessionWithCredentials(region, "test", "test")
 	}
+	if path, ok := UnixSocketPath(endpoint); ok {
+		cfg, err := NewAuthenticatedSessionWithCredentials(region, "test", "test")
+		if err != nil {
+			return nil, err
+		}
+		cfg.HTTPClient = UnixSocketHTTPClient(path)
+		cfg.BaseEndpoint = aws.String(UnixSocketBaseURL)
+		return cfg, nil
+	}
 
 	// Fall back to existing behavior
 	if assumeRoleArn, ok := os.LookupEnv(AuthAssumeRoleEnvVar); ok {
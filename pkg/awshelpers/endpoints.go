@@ -1,12 +1,22 @@
 package awshelpers
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"strings"
 )
 
+// unixSocketScheme prefixes endpoints that are Unix domain sockets, e.g. "unix:///tmp/infraspec.sock"
+const unixSocketScheme = "unix://"
+
+// UnixSocketBaseURL is the base URL of requests sent to a Unix socket endpoint. The connection
+// is always made to the socket, so the host only sets the Host header.
+const UnixSocketBaseURL = "http://localhost"
+
 // GetVirtualCloudEndpoint returns the endpoint URL to use for the given AWS service when
 // embedded emulator mode is enabled (detected via AWS_ENDPOINT_URL environment variable).
 // The function looks for a service-specific environment variable (e.g. AWS_ENDPOINT_URL_RDS)
@@ -28,6 +38,9 @@ func GetVirtualCloudEndpoint(service string) (string, bool) {
 		if service != "" {
 			return BuildServiceEndpoint(endpoint, service), true
 		}
+		if _, ok := UnixSocketPath(endpoint); ok {
+			return UnixSocketBaseURL, true
+		}
 		return endpoint, true
 	}
 
@@ -44,7 +57,14 @@ func GetVirtualCloudEndpoint(service string) (string, bool) {
 //
 // For localhost/127.0.0.1, nip.io is used to enable wildcard DNS resolution for virtual-hosted
 // style S3 addressing (e.g., bucket.s3.127.0.0.1.nip.io resolves to 127.0.0.1).
+//
+// For a Unix socket endpoint, the subdomain of localhost is used (e.g., "http://s3.localhost"),
+// since requests are sent to the socket without resolving the host.
 func BuildServiceEndpoint(baseEndpoint, subdomain string) string {
+	if _, ok := UnixSocketPath(baseEndpoint); ok {
+		return "http://" + subdomain + ".localhost"
+	}
+
 	parsedURL, err := url.Parse(baseEndpoint)
 	if err != nil {
 		// If parsing fails, return the base endpoint as-is
@@ -82,3 +102,22 @@ func BuildServiceEndpoint(baseEndpoint, subdomain string) string {
 
 	return parsedURL.String()
 }
+
+// UnixSocketPath returns the path of the socket a Unix socket endpoint like
+// "unix:///tmp/infraspec.sock" names, and false for any other endpoint
+func UnixSocketPath(endpoint string) (string, bool) {
+	path, ok := strings.CutPrefix(endpoint, unixSocketScheme)
+	return path, ok && path != ""
+}
+
+// UnixSocketHTTPClient returns an HTTP client that sends every request over the Unix socket at
+// path, whatever the host of the request's URL
+func UnixSocketHTTPClient(path string) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, "unix", path)
+	}
+	return &http.Client{Transport: transport}
+}
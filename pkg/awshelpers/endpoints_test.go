@@ -60,6 +60,20 @@ func TestGetVirtualCloudEndpoint(t *testing.T) {
 			expectedEndpoint: "https://ec2.example.com",
 			expectedOk:       true,
 		},
+		{
+			name:             "builds localhost subdomain for unix socket",
+			service:          "sqs",
+			awsEndpointURL:   "unix:///tmp/infraspec.sock",
+			expectedEndpoint: "http://sqs.localhost",
+			expectedOk:       true,
+		},
+		{
+			name:             "returns base URL for unix socket when service is empty",
+			service:          "",
+			awsEndpointURL:   "unix:///tmp/infraspec.sock",
+			expectedEndpoint: "http://localhost",
+			expectedOk:       true,
+		},
 	}
 
 	for _, tt := range tests {
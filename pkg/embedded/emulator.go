@@ -2,25 +2,37 @@ package embedded
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	emulator "github.com/robmorgan/infraspec/internal/emulator/core"
 	"github.com/robmorgan/infraspec/internal/emulator/graph"
+	"github.com/robmorgan/infraspec/internal/emulator/logging"
 	"github.com/robmorgan/infraspec/internal/emulator/metadata"
 	"github.com/robmorgan/infraspec/internal/emulator/server"
 	"github.com/robmorgan/infraspec/internal/emulator/services/applicationautoscaling"
+	"github.com/robmorgan/infraspec/internal/emulator/services/cloudwatch"
 	"github.com/robmorgan/infraspec/internal/emulator/services/dynamodb"
+	"github.com/robmorgan/infraspec/internal/emulator/services/dynamodbstreams"
 	"github.com/robmorgan/infraspec/internal/emulator/services/ec2"
+	"github.com/robmorgan/infraspec/internal/emulator/services/elbv2"
+	"github.com/robmorgan/infraspec/internal/emulator/services/eventbridge"
 	"github.com/robmorgan/infraspec/internal/emulator/services/iam"
 	"github.com/robmorgan/infraspec/internal/emulator/services/lambda"
 	"github.com/robmorgan/infraspec/internal/emulator/services/rds"
 	"github.com/robmorgan/infraspec/internal/emulator/services/s3"
 	"github.com/robmorgan/infraspec/internal/emulator/services/sqs"
+	"github.com/robmorgan/infraspec/internal/emulator/services/stepfunctions"
 	"github.com/robmorgan/infraspec/internal/emulator/services/sts"
+	"github.com/robmorgan/infraspec/pkg/awshelpers"
 )
 
 // Emulator represents an embedded AWS emulator instance.
@@ -32,11 +44,61 @@ type Emulator struct {
 	port     int
 	mu       sync.Mutex
 	running  bool
+
+	// listenAddress is the TCP host:port or "unix://" socket the emulator listens on, a
+	// dynamic port on 127.0.0.1 if empty
+	listenAddress string
+
+	// host is the host of the TCP endpoint
+	host string
+
+	// validateResponses enables the emulator's response self-check
+	validateResponses bool
+
+	// scope configures the default region and partition of requests
+	scope emulator.ScopeConfig
+
+	// sqsMaxInFlight overrides how many messages an SQS queue can have in flight
+	sqsMaxInFlight int
+
+	// s3LocationStyle is how S3 CreateBucket's Location header addresses the new bucket
+	s3LocationStyle string
+
+	// cors lists the origins, methods and headers browser clients can call the emulator with
+	cors CORSConfig
+
+	// seedResources are created when the emulator starts and after its state is reset
+	seedResources []SeedResource
+
+	// fixtures are canned responses returned instead of handling matching requests
+	fixtures []Fixture
+
+	// injectionRules fail or delay matching requests
+	injectionRules []InjectionRule
+
+	// baseline holds the state keys, within their partitions, of the resources the emulator
+	// creates itself, e.g. the default VPC and the seed resources
+	baseline map[string]bool
+
+	// partitioned holds the account/region partitioned services so their
+	// per-partition instances can be discarded when state is reset
+	partitioned []*emulator.PartitionedService
+
+	// resourceManager tracks the relationships of the default partition's EC2 and IAM
+	// resources, which are cleared with the state
+	resourceManager *graph.ResourceManager
 }
 
+// ReadyTimeout is how long Start waits for the emulator to report it's ready to handle requests
+var ReadyTimeout = 5 * time.Second
+
 // instance is the singleton embedded emulator instance
 var instance *Emulator
 
+// partitionKeyPattern matches the prefix of the state keys of a non-default account or
+// account and region partition, e.g. "partition:210987654321:eu-west-1:"
+var partitionKeyPattern = regexp.MustCompile(`^partition:\d{12}:(?:[a-z]{2}(?:-[a-z]+)+-\d+:)?`)
+
 // New creates a new embedded emulator instance.
 // The emulator will use a dynamically assigned port.
 func New() *Emulator {
@@ -45,6 +107,111 @@ func New() *Emulator {
 	}
 }
 
+// EnableResponseValidation makes the emulator check every response against the
+// generated response type for its action, logging a warning when the response can't
+// be unmarshaled. It must be called before Start.
+func (e *Emulator) EnableResponseValidation() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.validateResponses = true
+}
+
+// SetDefaultRegion sets the region used for requests that don't carry a SigV4 credential
+// scope. It must be called before Start.
+func (e *Emulator) SetDefaultRegion(region string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.scope.Region = region
+}
+
+// SetPartition overrides the partition and DNS suffix the emulator uses in ARNs and service
+// URLs, e.g. "aws-us-gov" and "amazonaws.com". By default both are derived from the region
+// of each request. It must be called before Start.
+func (e *Emulator) SetPartition(partition, dnsSuffix string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.scope.Partition = partition
+	e.scope.DNSSuffix = dnsSuffix
+}
+
+// SetSQSMaxInFlightMessages sets how many messages an SQS queue can have in flight before
+// ReceiveMessage and SendMessage fail with OverLimit. It defaults to the AWS quota of 120,000
+// and must be called before Start.
+func (e *Emulator) SetSQSMaxInFlightMessages(limit int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.sqsMaxInFlight = limit
+}
+
+// SetS3LocationStyle sets how the Location header of an S3 CreateBucket response addresses
+// the new bucket: "path" (the default) returns "/my-bucket", and "virtual-hosted" returns a URL
+// on the request's S3 host like "http://my-bucket.s3.localhost:3687/". It must be called
+// before Start.
+func (e *Emulator) SetS3LocationStyle(style string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.s3LocationStyle = style
+}
+
+// CORSConfig lists the origins, methods and headers browser clients, like the AWS SDK for
+// JavaScript in a local web app, can call the emulator with. An empty list allows all of them.
+type CORSConfig struct {
+	// AllowedOrigins are origins like "http://localhost:3000", or "*" for any origin
+	AllowedOrigins []string
+
+	// AllowedMethods are the HTTP methods preflight requests can ask for
+	AllowedMethods []string
+
+	// AllowedHeaders are the request headers preflight requests can ask for, or "*" for any
+	AllowedHeaders []string
+}
+
+// SetCORS sets the origins, methods and headers the emulator's CORS headers allow. By default
+// any origin can call the emulator with any headers. It must be called before Start.
+func (e *Emulator) SetCORS(config CORSConfig) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.cors = config
+}
+
+// serverCORSConfig returns the server's CORS configuration, the defaults with the lists
+// that are set replaced
+func (e *Emulator) serverCORSConfig() emulator.CORSConfig {
+	config := emulator.DefaultCORSConfig()
+	if len(e.cors.AllowedOrigins) > 0 {
+		config.AllowedOrigins = e.cors.AllowedOrigins
+	}
+	if len(e.cors.AllowedMethods) > 0 {
+		config.AllowedMethods = e.cors.AllowedMethods
+	}
+	if len(e.cors.AllowedHeaders) > 0 {
+		config.AllowedHeaders = e.cors.AllowedHeaders
+	}
+	return config
+}
+
+// SetListenAddress sets the address the emulator listens on: a TCP host:port like
+// "127.0.0.1:3687", or a Unix domain socket like "unix:///tmp/infraspec.sock". By default it
+// listens on a dynamic port on 127.0.0.1. It must be called before Start.
+func (e *Emulator) SetListenAddress(address string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.listenAddress = address
+}
+
+// SetLogLevels sets the level the emulator logs each service's messages at, from a
+// comma-separated list of service=level pairs like "s3=debug,ec2=warn". An entry without a
+// service, like "warn", sets the level of the others, which defaults to info. The levels apply to
+// every emulator in the process.
+func (e *Emulator) SetLogLevels(spec string) error {
+	levels, err := logging.ParseLevels(spec)
+	if err != nil {
+		return err
+	}
+	logging.SetLevels(levels)
+	return nil
+}
+
 // GetInstance returns the current running emulator instance, or nil if not running.
 func GetInstance() *Emulator {
 	return instance
@@ -60,6 +227,7 @@ func (e *Emulator) Start(ctx context.Context) error {
 	}
 
 	// Initialize core components
+	emulator.ConfigureScope(e.scope)
 	e.state = emulator.NewMemoryStateManager()
 	validator := emulator.NewSchemaValidator()
 	e.router = emulator.NewRouter()
@@ -72,6 +240,7 @@ func (e *Emulator) Start(ctx context.Context) error {
 		UseAWSSchema:          true,
 	}
 	resourceManager := graph.NewResourceManager(e.state, resourceManagerConfig)
+	e.resourceManager = resourceManager
 
 	// Register all service validations
 	emulator.RegisterAllServices(validator)
@@ -81,17 +250,75 @@ func (e *Emulator) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to initialize metadata service: %w", err)
 	}
 
-	// Register all services
+	// Register all services. Each service is partitioned by the account and region of the
+	// request so resources created for different accounts or regions don't collide. STS has
+	// no state and reports the caller's account from the request scope directly. S3 bucket
+	// names are global, so S3 shares one namespace and records each bucket's owning account.
+	newResourceManager := func(state emulator.StateManager) *graph.ResourceManager {
+		return graph.NewResourceManager(state, resourceManagerConfig)
+	}
+	newSQSService := func(state emulator.StateManager) *sqs.SQSService {
+		svc := sqs.NewSQSService(state, validator)
+		svc.SetMaxInFlightMessages(e.sqsMaxInFlight)
+		return svc
+	}
+	e.partitioned = []*emulator.PartitionedService{
+		emulator.NewPartitionedService(rds.NewRDSService(e.state, validator), e.state, func(state emulator.StateManager) emulator.Service {
+			return rds.NewRDSService(state, validator)
+		}, emulator.PartitionByAccountAndRegion),
+		emulator.NewPartitionedService(dynamodb.NewDynamoDBService(e.state, validator), e.state, func(state emulator.StateManager) emulator.Service {
+			return dynamodb.NewDynamoDBService(state, validator)
+		}, emulator.PartitionByAccountAndRegion),
+		// Streams reads the change logs DynamoDB writes, so it must use the same partitioning
+		emulator.NewPartitionedService(dynamodbstreams.NewDynamoDBStreamsService(e.state, validator), e.state, func(state emulator.StateManager) emulator.Service {
+			return dynamodbstreams.NewDynamoDBStreamsService(state, validator)
+		}, emulator.PartitionByAccountAndRegion),
+		emulator.NewPartitionedService(applicationautoscaling.NewApplicationAutoScalingService(e.state, validator), e.state, func(state emulator.StateManager) emulator.Service {
+			return applicationautoscaling.NewApplicationAutoScalingService(state, validator)
+		}, emulator.PartitionByAccountAndRegion),
+		emulator.NewPartitionedService(ec2.NewEC2ServiceWithGraph(e.state, validator, resourceManager), e.state, func(state emulator.StateManager) emulator.Service {
+			return ec2.NewEC2ServiceWithGraph(state, validator, newResourceManager(state))
+		}, emulator.PartitionByAccountAndRegion),
+		emulator.NewPartitionedService(iam.NewIAMServiceWithGraph(e.state, validator, resourceManager), e.state, func(state emulator.StateManager) emulator.Service {
+			return iam.NewIAMServiceWithGraph(state, validator, newResourceManager(state))
+		}, emulator.PartitionByAccount),
+		emulator.NewPartitionedService(newSQSService(e.state), e.state, func(state emulator.StateManager) emulator.Service {
+			return newSQSService(state)
+		}, emulator.PartitionByAccountAndRegion),
+		emulator.NewPartitionedService(lambda.NewLambdaService(e.state, validator), e.state, func(state emulator.StateManager) emulator.Service {
+			return lambda.NewLambdaService(state, validator)
+		}, emulator.PartitionByAccountAndRegion),
+		// EventBridge delivers to SQS and Lambda targets through their state, so it must use
+		// the same partitioning as those services
+		emulator.NewPartitionedService(eventbridge.NewEventBridgeService(e.state, validator), e.state, func(state emulator.StateManager) emulator.Service {
+			return eventbridge.NewEventBridgeService(state, validator)
+		}, emulator.PartitionByAccountAndRegion),
+		emulator.NewPartitionedService(stepfunctions.NewStepFunctionsService(e.state, validator), e.state, func(state emulator.StateManager) emulator.Service {
+			return stepfunctions.NewStepFunctionsService(state, validator)
+		}, emulator.PartitionByAccountAndRegion),
+		emulator.NewPartitionedService(cloudwatch.NewCloudWatchService(e.state, validator), e.state, func(state emulator.StateManager) emulator.Service {
+			return cloudwatch.NewCloudWatchService(state, validator)
+		}, emulator.PartitionByAccountAndRegion),
+		emulator.NewPartitionedService(elbv2.NewELBv2Service(e.state, validator), e.state, func(state emulator.StateManager) emulator.Service {
+			return elbv2.NewELBv2Service(state, validator)
+		}, emulator.PartitionByAccountAndRegion),
+	}
+
+	s3Service := s3.NewS3Service(e.state, validator)
+	switch style := s3.LocationStyle(e.s3LocationStyle); style {
+	case "":
+	case s3.LocationStylePath, s3.LocationStyleVirtualHosted:
+		s3Service.SetLocationStyle(style)
+	default:
+		return fmt.Errorf("invalid S3 location style %q, expected %q or %q", style, s3.LocationStylePath, s3.LocationStyleVirtualHosted)
+	}
 	services := []emulator.Service{
-		rds.NewRDSService(e.state, validator),
-		s3.NewS3Service(e.state, validator),
-		dynamodb.NewDynamoDBService(e.state, validator),
-		applicationautoscaling.NewApplicationAutoScalingService(e.state, validator),
 		sts.NewStsService(e.state, validator),
-		ec2.NewEC2ServiceWithGraph(e.state, validator, resourceManager),
-		iam.NewIAMServiceWithGraph(e.state, validator, resourceManager),
-		sqs.NewSQSService(e.state, validator),
-		lambda.NewLambdaService(e.state, validator),
+		s3Service,
+		s3.NewS3ControlService(s3Service),
+	}
+	for _, svc := range e.partitioned {
+		services = append(services, svc)
 	}
 
 	for _, svc := range services {
@@ -100,16 +327,50 @@ func (e *Emulator) Start(ctx context.Context) error {
 		}
 	}
 
-	// Create listener with dynamic port
-	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", e.port))
+	// Services added by a custom build with emulator.RegisterService
+	for _, custom := range emulator.CustomServices() {
+		var err error
+		if custom.Match != nil {
+			err = e.router.RegisterServiceWithMatcher(custom.Service, custom.Match)
+		} else {
+			err = e.router.RegisterService(custom.Service)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to register custom service %s: %w", custom.Service.ServiceName(), err)
+		}
+	}
+
+	if err := e.createSeedResources(ctx); err != nil {
+		return err
+	}
+	e.recordBaseline()
+
+	fixtures, err := e.serverFixtures()
+	if err != nil {
+		return err
+	}
+	injectionRules, err := e.serverInjectionRules()
+	if err != nil {
+		return err
+	}
+
+	listener, err := e.listen()
 	if err != nil {
 		return fmt.Errorf("failed to create listener: %w", err)
 	}
 	e.listener = listener
-	e.port = listener.Addr().(*net.TCPAddr).Port
+	if addr, ok := listener.Addr().(*net.TCPAddr); ok {
+		e.port = addr.Port
+	}
 
 	// Create server (no auth for embedded mode - nil keyStore)
 	e.server = server.NewServer(e.port, e.router, nil, e.state)
+	if e.validateResponses {
+		e.server.EnableResponseValidation()
+	}
+	e.server.SetFixtures(fixtures)
+	e.server.SetInjectionRules(injectionRules)
+	e.server.SetCORSConfig(e.serverCORSConfig())
 
 	// Start server in goroutine
 	errChan := make(chan error, 1)
@@ -127,11 +388,15 @@ func (e *Emulator) Start(ctx context.Context) error {
 		// Server likely started successfully
 	}
 
+	// Wait for server to be ready, so the first request of a scenario doesn't race it
+	if err := e.waitForReady(ctx); err != nil {
+		_ = e.server.Stop(context.Background())
+		return err
+	}
+
 	e.running = true
 	instance = e
-
-	// Wait for server to be ready
-	return e.waitForReady(ctx)
+	return nil
 }
 
 // Stop gracefully shuts down the emulator.
@@ -152,7 +417,8 @@ func (e *Emulator) Stop(ctx context.Context) error {
 	return nil
 }
 
-// ResetState clears all emulator state.
+// ResetState clears all emulator state and re-creates the resources the emulator starts
+// with, like the default VPC and the seed resources.
 // This can be used between test scenarios for isolation.
 func (e *Emulator) ResetState() {
 	e.mu.Lock()
@@ -160,19 +426,179 @@ func (e *Emulator) ResetState() {
 
 	if e.state != nil {
 		e.state.Clear()
+		e.resourceManager.Clear()
+		// Resetting the services re-creates their defaults, e.g. EC2's default VPC
+		for _, svc := range e.partitioned {
+			svc.Reset()
+		}
 		// Re-initialize metadata defaults
 		metadata.InitializeDefaults(e.state)
+		// Recreate the seed resources, which were created without error at Start
+		_ = e.createSeedResources(context.Background())
+		e.recordBaseline()
+	}
+}
+
+// StateSnapshot returns the emulator state whose keys start with prefix, keyed by state key.
+// Keys of non-default account and region partitions match on the key within the partition,
+// so a prefix like "s3:" matches the state of every partition.
+func (e *Emulator) StateSnapshot(prefix string) map[string]json.RawMessage {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.state == nil {
+		return nil
 	}
+	return e.state.Snapshot(func(key string) bool {
+		return strings.HasPrefix(KeyInPartition(key), prefix)
+	})
 }
 
-// Port returns the port the emulator is running on.
+// RemainingResources returns the state keys of the resources of the service, e.g. "sqs", that
+// were created since the emulator started or its state was last reset, sorted. An empty
+// service returns the resources of every service. The resources the emulator creates itself,
+// tags stored apart from their resources, and terminated EC2 instances, which stay visible
+// for a while as in AWS, aren't included.
+func (e *Emulator) RemainingResources(service string) []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.state == nil {
+		return nil
+	}
+
+	prefix := ""
+	if service != "" {
+		prefix = strings.ToLower(service) + ":"
+	}
+	snapshot := e.state.Snapshot(func(key string) bool {
+		inPartition := KeyInPartition(key)
+		return strings.HasPrefix(inPartition, prefix) && !e.baseline[inPartition] && !isTagsKey(inPartition)
+	})
+
+	keys := make([]string, 0, len(snapshot))
+	for key, value := range snapshot {
+		if strings.HasPrefix(KeyInPartition(key), "ec2:instances:") && isTerminatedInstance(value) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// recordBaseline records the state keys of the resources that exist before any scenario runs
+func (e *Emulator) recordBaseline() {
+	keys, _ := e.state.List("")
+	e.baseline = make(map[string]bool, len(keys))
+	for _, key := range keys {
+		e.baseline[KeyInPartition(key)] = true
+	}
+}
+
+// isTagsKey reports whether a state key holds tags, like "ec2:tags:vpc-123" or
+// "rds:db-instance-tags:db1", which some services don't delete with the resource
+func isTagsKey(key string) bool {
+	parts := strings.SplitN(key, ":", 3)
+	return len(parts) == 3 && (parts[1] == "tags" || strings.HasSuffix(parts[1], "-tags"))
+}
+
+func isTerminatedInstance(value json.RawMessage) bool {
+	var instance struct {
+		State *struct {
+			Name string
+		}
+	}
+	if err := json.Unmarshal(value, &instance); err != nil || instance.State == nil {
+		return false
+	}
+	return instance.State.Name == "terminated"
+}
+
+// KeyInPartition returns a state key without the prefix of its account and region partition,
+// e.g. "s3:my-bucket" for "partition:210987654321:eu-west-1:s3:my-bucket".
+func KeyInPartition(key string) string {
+	return partitionKeyPattern.ReplaceAllString(key, "")
+}
+
+// RequestCount returns the number of requests for the action the emulator has received
+// since it started or its request counts were last reset.
+func (e *Emulator) RequestCount(action string) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.server == nil {
+		return 0
+	}
+	return e.server.Requests().Count(action)
+}
+
+// ResetRequestCounts discards the emulator's request counts.
+// This is called before each test scenario so counts are per scenario.
+func (e *Emulator) ResetRequestCounts() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.server != nil {
+		e.server.Requests().Reset()
+	}
+}
+
+// listen creates the listener for the listen address. A socket file left behind by an
+// emulator that didn't stop cleanly is replaced.
+func (e *Emulator) listen() (net.Listener, error) {
+	if path, ok := awshelpers.UnixSocketPath(e.listenAddress); ok {
+		if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+			if err := os.Remove(path); err != nil {
+				return nil, err
+			}
+		}
+		return net.Listen("unix", path)
+	}
+
+	address := e.listenAddress
+	if address == "" {
+		address = fmt.Sprintf("127.0.0.1:%d", e.port)
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid listen address %q, expected host:port or unix:///path/to/socket", address)
+	}
+
+	// Clients can't connect to an unspecified address like 0.0.0.0, so they use the loopback
+	e.host = "127.0.0.1"
+	if ip := net.ParseIP(host); host != "" && (ip == nil || !ip.IsUnspecified()) {
+		e.host = host
+	}
+	return net.Listen("tcp", address)
+}
+
+// Port returns the port the emulator is running on, 0 if it's listening on a Unix socket.
 func (e *Emulator) Port() int {
 	return e.port
 }
 
-// Endpoint returns the base endpoint URL.
+// Endpoint returns the base endpoint URL, or the "unix://" endpoint of the socket the emulator
+// listens on.
 func (e *Emulator) Endpoint() string {
-	return fmt.Sprintf("http://127.0.0.1:%d", e.port)
+	if path, ok := awshelpers.UnixSocketPath(e.listenAddress); ok {
+		return "unix://" + path
+	}
+	host := e.host
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	return "http://" + net.JoinHostPort(host, fmt.Sprint(e.port))
+}
+
+// httpClient returns an HTTP client for the emulator's listener and the base URL of its requests
+func (e *Emulator) httpClient(timeout time.Duration) (*http.Client, string) {
+	if path, ok := awshelpers.UnixSocketPath(e.listenAddress); ok {
+		client := awshelpers.UnixSocketHTTPClient(path)
+		client.Timeout = timeout
+		return client, awshelpers.UnixSocketBaseURL
+	}
+	return &http.Client{Timeout: timeout}, e.Endpoint()
 }
 
 // IsRunning returns true if the emulator is currently running.
@@ -182,26 +608,34 @@ func (e *Emulator) IsRunning() bool {
 	return e.running
 }
 
+// waitForReady polls the emulator's readiness endpoint until it reports ready, failing if it
+// doesn't within ReadyTimeout
 func (e *Emulator) waitForReady(ctx context.Context) error {
-	healthURL := fmt.Sprintf("http://127.0.0.1:%d/_health", e.port)
-	client := &http.Client{Timeout: 1 * time.Second}
+	client, baseURL := e.httpClient(1 * time.Second)
+	readyURL := baseURL + "/_infraspec/ready"
+
+	ctx, cancel := context.WithTimeout(ctx, ReadyTimeout)
+	defer cancel()
 
 	ticker := time.NewTicker(10 * time.Millisecond)
 	defer ticker.Stop()
 
+	lastErr := fmt.Errorf("no response")
 	for {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return fmt.Errorf("emulator at %s did not become ready within %s: %w", e.Endpoint(), ReadyTimeout, lastErr)
 		case <-ticker.C:
-			resp, err := client.Get(healthURL)
-			if err == nil && resp.StatusCode == http.StatusOK {
-				resp.Body.Close()
-				return nil
+			resp, err := client.Get(readyURL)
+			if err != nil {
+				lastErr = err
+				continue
 			}
-			if resp != nil {
-				resp.Body.Close()
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
 			}
+			lastErr = fmt.Errorf("readiness check returned %s", resp.Status)
 		}
 	}
 }
@@ -0,0 +1,186 @@
+package embedded
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robmorgan/infraspec/pkg/awshelpers"
+)
+
+func TestRemainingResources(t *testing.T) {
+	emu := New()
+	emu.SetSeedResources([]SeedResource{
+		{Service: "sqs", Action: "CreateQueue", Params: map[string]interface{}{"QueueName": "jobs"}},
+	})
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+
+	// The default VPC and the seed resources aren't left over from scenarios
+	assert.Empty(t, emu.RemainingResources(""))
+
+	ctx := context.Background()
+	require.NoError(t, emu.createSeedResource(ctx, SeedResource{Service: "sqs", Action: "CreateQueue", Params: map[string]interface{}{"QueueName": "orders"}}))
+	require.NoError(t, emu.createSeedResource(ctx, SeedResource{Service: "ec2", Action: "CreateVpc", Params: map[string]interface{}{
+		"CidrBlock": "10.0.0.0/16",
+		"TagSpecification": []interface{}{map[string]interface{}{
+			"ResourceType": "vpc",
+			"Tag":          []interface{}{map[string]interface{}{"Key": "Name", "Value": "main"}},
+		}},
+	}}))
+
+	assert.Equal(t, []string{"sqs:messages:orders", "sqs:queue:orders"}, emu.RemainingResources("sqs"))
+	assert.Empty(t, emu.RemainingResources("dynamodb"))
+
+	// The VPC's main route table and default security group are created with it, and its
+	// tags aren't a resource of their own
+	remaining := emu.RemainingResources("ec2")
+	require.Len(t, remaining, 3)
+	for i, prefix := range []string{"ec2:route-tables:rtb-", "ec2:security-groups:sg-", "ec2:vpcs:vpc-"} {
+		assert.True(t, strings.HasPrefix(remaining[i], prefix), remaining[i])
+	}
+	assert.Len(t, emu.RemainingResources(""), 5)
+
+	emu.ResetState()
+	assert.Empty(t, emu.RemainingResources(""))
+}
+
+func TestResetState_RecreatesDefaults(t *testing.T) {
+	emu := New()
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+
+	defaults := emu.resourceManager.ResourceCount()
+	require.NoError(t, emu.createSeedResource(context.Background(), SeedResource{Service: "ec2", Action: "CreateVpc", Params: map[string]interface{}{
+		"CidrBlock": "10.0.0.0/16",
+	}}))
+	require.Greater(t, emu.resourceManager.ResourceCount(), defaults)
+
+	// The default VPC is re-created without the relationships of the deleted resources
+	emu.ResetState()
+	assert.Contains(t, emu.StateSnapshot("ec2:vpcs:"), "ec2:vpcs:vpc-default")
+	assert.Equal(t, defaults, emu.resourceManager.ResourceCount())
+	assert.Empty(t, emu.RemainingResources(""))
+}
+
+func TestListenUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "infraspec.sock")
+
+	// A socket left behind by an emulator that didn't stop cleanly is replaced
+	stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: socket, Net: "unix"})
+	require.NoError(t, err)
+	stale.SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	emu := New()
+	emu.SetListenAddress("unix://" + socket)
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+
+	assert.Equal(t, "unix://"+socket, emu.Endpoint())
+	assert.Zero(t, emu.Port())
+
+	for _, result := range emu.SelfTest(context.Background()) {
+		assert.NoError(t, result.Err, "%s %s", result.Service, result.Action)
+	}
+
+	// The assertion clients send their requests over the socket
+	t.Setenv("AWS_ENDPOINT_URL", emu.Endpoint())
+	cfg, err := awshelpers.NewAuthenticatedSession("us-east-1")
+	require.NoError(t, err)
+	endpoint, ok := awshelpers.GetVirtualCloudEndpoint("sqs")
+	require.True(t, ok)
+	client := sqs.NewFromConfig(*cfg, func(o *sqs.Options) {
+		o.BaseEndpoint = aws.String(endpoint)
+	})
+	_, err = client.CreateQueue(context.Background(), &sqs.CreateQueueInput{QueueName: aws.String("jobs")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sqs:messages:jobs", "sqs:queue:jobs"}, emu.RemainingResources("sqs"))
+
+	require.NoError(t, emu.Stop(context.Background()))
+	_, err = os.Stat(socket)
+	assert.True(t, os.IsNotExist(err), "expected the socket to be removed, got %v", err)
+}
+
+func TestListenTCPAddress(t *testing.T) {
+	emu := New()
+	emu.SetListenAddress("0.0.0.0:0")
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+
+	// Clients connect to an unspecified address over the loopback
+	assert.NotZero(t, emu.Port())
+	assert.True(t, strings.HasPrefix(emu.Endpoint(), "http://127.0.0.1:"), emu.Endpoint())
+
+	invalid := New()
+	invalid.SetListenAddress("localhost")
+	err := invalid.Start(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid listen address")
+}
+
+func TestWaitForReady(t *testing.T) {
+	emu := New()
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+
+	resp, err := http.Get(emu.Endpoint() + "/_infraspec/ready")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// An emulator that never comes up fails quickly with the address it was waiting for
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+
+	defer func(timeout time.Duration) { ReadyTimeout = timeout }(ReadyTimeout)
+	ReadyTimeout = 50 * time.Millisecond
+	unreachable := &Emulator{port: port}
+	err = unreachable.waitForReady(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), fmt.Sprintf("emulator at http://127.0.0.1:%d did not become ready within 50ms", port))
+}
+
+func TestCORS(t *testing.T) {
+	emu := New()
+	emu.SetCORS(CORSConfig{AllowedOrigins: []string{"http://localhost:3000"}})
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+
+	preflight := func(origin string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodOptions, emu.Endpoint()+"/my-bucket/key", nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", "PUT")
+		req.Header.Set("Access-Control-Request-Headers", "authorization, x-amz-date")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	// The methods and headers keep their permissive defaults
+	resp := preflight("http://localhost:3000")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "http://localhost:3000", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, resp.Header.Get("Access-Control-Allow-Methods"), "PUT")
+	assert.Equal(t, "authorization, x-amz-date", resp.Header.Get("Access-Control-Allow-Headers"))
+
+	resp = preflight("http://another.example")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+}
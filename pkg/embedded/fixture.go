@@ -0,0 +1,73 @@
+package embedded
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/robmorgan/infraspec/internal/emulator/server"
+)
+
+// Fixture is a canned response the emulator returns for requests of an action, without
+// running the service's logic, e.g. to reproduce an unusual response captured from AWS.
+type Fixture struct {
+	// Action is the action the fixture responds to, e.g. "DescribeTable"
+	Action string
+
+	// BodyPattern is an optional regular expression the request body must match
+	BodyPattern string
+
+	// PathPattern is an optional regular expression the request path, including any query
+	// string, must match, e.g. "^/my-bucket/" for S3 requests to a bucket
+	PathPattern string
+
+	// StatusCode defaults to 200
+	StatusCode int
+	Headers    map[string]string
+	Body       []byte
+}
+
+// SetFixtures sets the canned responses the emulator returns instead of handling the requests
+// they match. The first matching fixture is used and requests no fixture matches are handled
+// as usual. It must be called before Start.
+func (e *Emulator) SetFixtures(fixtures []Fixture) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.fixtures = fixtures
+}
+
+// serverFixtures compiles the body and path patterns of the fixtures for the server
+func (e *Emulator) serverFixtures() ([]server.Fixture, error) {
+	fixtures := make([]server.Fixture, 0, len(e.fixtures))
+	for i, fixture := range e.fixtures {
+		if fixture.Action == "" {
+			return nil, fmt.Errorf("fixture %d has no action", i+1)
+		}
+
+		bodyPattern, err := compileFixturePattern(fixture.BodyPattern)
+		if err != nil {
+			return nil, fmt.Errorf("fixture %d (%s) has an invalid body pattern: %w", i+1, fixture.Action, err)
+		}
+		pathPattern, err := compileFixturePattern(fixture.PathPattern)
+		if err != nil {
+			return nil, fmt.Errorf("fixture %d (%s) has an invalid path pattern: %w", i+1, fixture.Action, err)
+		}
+
+		fixtures = append(fixtures, server.Fixture{
+			Action:      fixture.Action,
+			BodyPattern: bodyPattern,
+			PathPattern: pathPattern,
+			StatusCode:  fixture.StatusCode,
+			Headers:     fixture.Headers,
+			Body:        fixture.Body,
+		})
+	}
+	return fixtures, nil
+}
+
+// compileFixturePattern compiles an optional fixture pattern, returning nil if it is empty
+func compileFixturePattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile(pattern)
+}
@@ -0,0 +1,109 @@
+package embedded
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixtures(t *testing.T) {
+	emu := New()
+	emu.SetFixtures([]Fixture{{
+		Action:      "DescribeTable",
+		BodyPattern: `"TableName":\s*"orders"`,
+		StatusCode:  400,
+		Headers:     map[string]string{"Content-Type": "application/x-amz-json-1.0"},
+		Body:        []byte(`{"__type":"com.amazonaws.dynamodb.v20120810#ResourceInUseException","message":"captured from AWS"}`),
+	}})
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+
+	describeTable := func(body string) (int, string, string) {
+		req, err := http.NewRequest(http.MethodPost, emu.Endpoint(), strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("X-Amz-Target", "DynamoDB_20120810.DescribeTable")
+		req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, resp.Header.Get("Content-Type"), string(data)
+	}
+
+	status, contentType, body := describeTable(`{"TableName": "orders"}`)
+	assert.Equal(t, 400, status)
+	assert.Equal(t, "application/x-amz-json-1.0", contentType)
+	assert.Contains(t, body, "captured from AWS")
+	assert.Equal(t, 1, emu.RequestCount("DescribeTable"))
+
+	// Requests the fixture doesn't match are handled by the service
+	status, _, body = describeTable(`{"TableName": "users"}`)
+	assert.Equal(t, 400, status)
+	assert.Contains(t, body, "ResourceNotFoundException")
+}
+
+func TestFixtures_PathPattern(t *testing.T) {
+	emu := New()
+	emu.SetFixtures([]Fixture{{
+		Action:      "PutObject",
+		PathPattern: `^/my-bucket/`,
+		StatusCode:  503,
+		Headers:     map[string]string{"Content-Type": "application/xml"},
+		Body:        []byte(`<Error><Code>SlowDown</Code><Message>Please reduce your request rate.</Message></Error>`),
+	}})
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+
+	request := func(method, path string) (int, string) {
+		req, err := http.NewRequest(method, emu.Endpoint()+path, strings.NewReader("hello"))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential=test/20240101/us-east-1/s3/aws4_request, SignedHeaders=host, Signature=test")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(data)
+	}
+
+	for _, bucket := range []string{"my-bucket", "other-bucket"} {
+		status, _ := request(http.MethodPut, "/"+bucket)
+		require.Equal(t, 200, status)
+	}
+
+	status, body := request(http.MethodPut, "/my-bucket/greeting.txt")
+	assert.Equal(t, 503, status)
+	assert.Contains(t, body, "SlowDown")
+
+	// PutObject requests for other buckets are handled by the service
+	status, _ = request(http.MethodPut, "/other-bucket/greeting.txt")
+	assert.Equal(t, 200, status)
+}
+
+func TestStart_FailsOnInvalidFixture(t *testing.T) {
+	emu := New()
+	emu.SetFixtures([]Fixture{{Action: "GetItem", BodyPattern: "("}})
+	err := emu.Start(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fixture 1 (GetItem) has an invalid body pattern")
+
+	emu = New()
+	emu.SetFixtures([]Fixture{{Action: "PutObject", PathPattern: "["}})
+	err = emu.Start(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fixture 1 (PutObject) has an invalid path pattern")
+
+	emu = New()
+	emu.SetFixtures([]Fixture{{Body: []byte("{}")}})
+	err = emu.Start(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fixture 1 has no action")
+}
@@ -0,0 +1,87 @@
+package embedded
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robmorgan/infraspec/internal/emulator/server"
+)
+
+// InjectionRule makes the emulator fail or delay requests of an action, e.g. to see how
+// infrastructure code copes with throttling or a slow service. A rule can inject latency, a
+// fault or both.
+type InjectionRule struct {
+	// Action is the action the rule applies to, e.g. "PutObject"
+	Action string
+
+	// BodyPattern is an optional regular expression the request body must match
+	BodyPattern string
+
+	// PathPattern is an optional regular expression the request path, including any query
+	// string, must match. S3 requests are matched in path style whether or not they address
+	// the bucket in the host, so "^/my-bucket/" matches every object request to my-bucket.
+	PathPattern string
+
+	// Latency delays the response to each matching request
+	Latency time.Duration
+
+	// StatusCode, if set, fails matching requests with an error of this HTTP status, in the
+	// service's protocol, instead of handling them
+	StatusCode int
+
+	// ErrorCode is the code of the injected error, e.g. "SlowDown". Defaults to
+	// "InjectedFault".
+	ErrorCode string
+
+	// Message is the message of the injected error
+	Message string
+}
+
+// SetInjectionRules sets the rules that fail or delay the requests they match. The first
+// matching rule is applied, before any fixture, and requests no rule matches are handled as
+// usual. It must be called before Start.
+func (e *Emulator) SetInjectionRules(rules []InjectionRule) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.injectionRules = rules
+}
+
+// serverInjectionRules checks the injection rules and compiles their body and path patterns
+// for the server
+func (e *Emulator) serverInjectionRules() ([]server.InjectionRule, error) {
+	rules := make([]server.InjectionRule, 0, len(e.injectionRules))
+	for i, rule := range e.injectionRules {
+		if rule.Action == "" {
+			return nil, fmt.Errorf("injection rule %d has no action", i+1)
+		}
+		if rule.Latency < 0 {
+			return nil, fmt.Errorf("injection rule %d (%s) has a negative latency", i+1, rule.Action)
+		}
+		if rule.StatusCode != 0 && (rule.StatusCode < 400 || rule.StatusCode > 599) {
+			return nil, fmt.Errorf("injection rule %d (%s) has status %d, which isn't an error status", i+1, rule.Action, rule.StatusCode)
+		}
+		if rule.Latency == 0 && rule.StatusCode == 0 {
+			return nil, fmt.Errorf("injection rule %d (%s) injects neither latency nor a fault", i+1, rule.Action)
+		}
+
+		bodyPattern, err := compileFixturePattern(rule.BodyPattern)
+		if err != nil {
+			return nil, fmt.Errorf("injection rule %d (%s) has an invalid body pattern: %w", i+1, rule.Action, err)
+		}
+		pathPattern, err := compileFixturePattern(rule.PathPattern)
+		if err != nil {
+			return nil, fmt.Errorf("injection rule %d (%s) has an invalid path pattern: %w", i+1, rule.Action, err)
+		}
+
+		rules = append(rules, server.InjectionRule{
+			Action:      rule.Action,
+			BodyPattern: bodyPattern,
+			PathPattern: pathPattern,
+			Latency:     rule.Latency,
+			StatusCode:  rule.StatusCode,
+			ErrorCode:   rule.ErrorCode,
+			Message:     rule.Message,
+		})
+	}
+	return rules, nil
+}
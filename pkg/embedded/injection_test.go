@@ -0,0 +1,170 @@
+package embedded
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInjectionRules_PathPattern(t *testing.T) {
+	emu := New()
+	emu.SetInjectionRules([]InjectionRule{{
+		Action:      "PutObject",
+		PathPattern: `^/my-bucket/`,
+		StatusCode:  503,
+		ErrorCode:   "SlowDown",
+		Message:     "Please reduce your request rate.",
+	}})
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+
+	request := func(method, host, path string) (int, string) {
+		req, err := http.NewRequest(method, emu.Endpoint()+path, strings.NewReader("hello"))
+		require.NoError(t, err)
+		if host != "" {
+			req.Host = host
+		}
+		req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential=test/20240101/us-east-1/s3/aws4_request, SignedHeaders=host, Signature=test")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(data)
+	}
+
+	// CreateBucket isn't matched by the rule's action
+	for _, bucket := range []string{"my-bucket", "other-bucket"} {
+		status, _ := request(http.MethodPut, "", "/"+bucket)
+		require.Equal(t, 200, status)
+	}
+
+	status, body := request(http.MethodPut, "", "/my-bucket/greeting.txt")
+	assert.Equal(t, 503, status)
+	assert.Contains(t, body, "<Code>SlowDown</Code>")
+	assert.Contains(t, body, "Please reduce your request rate.")
+
+	// A virtual-hosted request is matched as if the bucket were in its path
+	status, body = request(http.MethodPut, "my-bucket.s3.localhost", "/greeting.txt")
+	assert.Equal(t, 503, status)
+	assert.Contains(t, body, "<Code>SlowDown</Code>")
+
+	// PutObject requests for other buckets are handled by the service
+	status, _ = request(http.MethodPut, "", "/other-bucket/greeting.txt")
+	assert.Equal(t, 200, status)
+	status, _ = request(http.MethodPut, "other-bucket.s3.localhost", "/greeting.txt")
+	assert.Equal(t, 200, status)
+	status, _ = request(http.MethodPut, "", "/other-bucket/my-bucket/greeting.txt")
+	assert.Equal(t, 200, status)
+}
+
+func TestInjectionRules_BodyPattern(t *testing.T) {
+	emu := New()
+	emu.SetInjectionRules([]InjectionRule{
+		{
+			Action:      "CreateTable",
+			BodyPattern: `"TableName":\s*"orders"`,
+			StatusCode:  400,
+			ErrorCode:   "ThrottlingException",
+		},
+		{
+			Action:      "DescribeTable",
+			BodyPattern: `"TableName":\s*"users"`,
+			Latency:     300 * time.Millisecond,
+		},
+	})
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+
+	call := func(action, body string) (int, string, time.Duration) {
+		req, err := http.NewRequest(http.MethodPost, emu.Endpoint(), strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("X-Amz-Target", "DynamoDB_20120810."+action)
+		req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+
+		started := time.Now()
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(data), time.Since(started)
+	}
+	createTable := func(name string) (int, string) {
+		status, body, _ := call("CreateTable", `{"TableName": "`+name+`", "AttributeDefinitions": [{"AttributeName": "id", "AttributeType": "S"}], "KeySchema": [{"AttributeName": "id", "KeyType": "HASH"}], "BillingMode": "PAY_PER_REQUEST"}`)
+		return status, body
+	}
+
+	// The fault is returned in the service's protocol
+	status, body := createTable("orders")
+	assert.Equal(t, 400, status)
+	assert.Contains(t, body, `"__type":"ThrottlingException"`)
+	assert.Contains(t, body, "injected fault")
+	assert.Equal(t, 1, emu.RequestCount("CreateTable"))
+
+	// Requests whose body the rule doesn't match are handled by the service
+	status, _ = createTable("users")
+	assert.Equal(t, 200, status)
+
+	// A latency rule delays the response, which the service still handles
+	status, body, elapsed := call("DescribeTable", `{"TableName": "users"}`)
+	assert.Equal(t, 200, status)
+	assert.Contains(t, body, `"TableName":"users"`)
+	assert.GreaterOrEqual(t, elapsed, 300*time.Millisecond)
+
+	status, body, elapsed = call("DescribeTable", `{"TableName": "orders"}`)
+	assert.Equal(t, 400, status)
+	assert.Contains(t, body, "ResourceNotFoundException")
+	assert.Less(t, elapsed, 300*time.Millisecond)
+}
+
+func TestInjectionRules_ComeBeforeFixtures(t *testing.T) {
+	emu := New()
+	emu.SetInjectionRules([]InjectionRule{{Action: "ListTables", BodyPattern: `"Limit":\s*1\b`, StatusCode: 500}})
+	emu.SetFixtures([]Fixture{{Action: "ListTables", Body: []byte(`{"TableNames":["captured"]}`)}})
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+
+	listTables := func(body string) int {
+		req, err := http.NewRequest(http.MethodPost, emu.Endpoint(), strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("X-Amz-Target", "DynamoDB_20120810.ListTables")
+		req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, 500, listTables(`{"Limit": 1}`))
+	assert.Equal(t, 200, listTables(`{"Limit": 10}`))
+}
+
+func TestStart_FailsOnInvalidInjectionRule(t *testing.T) {
+	tests := []struct {
+		rule InjectionRule
+		want string
+	}{
+		{rule: InjectionRule{StatusCode: 500}, want: "injection rule 1 has no action"},
+		{rule: InjectionRule{Action: "GetItem"}, want: "injection rule 1 (GetItem) injects neither latency nor a fault"},
+		{rule: InjectionRule{Action: "GetItem", StatusCode: 200}, want: "injection rule 1 (GetItem) has status 200, which isn't an error status"},
+		{rule: InjectionRule{Action: "GetItem", Latency: -time.Second}, want: "injection rule 1 (GetItem) has a negative latency"},
+		{rule: InjectionRule{Action: "GetItem", StatusCode: 500, BodyPattern: "("}, want: "injection rule 1 (GetItem) has an invalid body pattern"},
+		{rule: InjectionRule{Action: "PutObject", StatusCode: 500, PathPattern: "["}, want: "injection rule 1 (PutObject) has an invalid path pattern"},
+	}
+	for _, tt := range tests {
+		emu := New()
+		emu.SetInjectionRules([]InjectionRule{tt.rule})
+		err := emu.Start(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), tt.want)
+	}
+}
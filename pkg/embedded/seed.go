@@ -0,0 +1,177 @@
+package embedded
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	emulator "github.com/robmorgan/infraspec/internal/emulator/core"
+)
+
+// SeedResource is a resource the emulator creates when it starts, by calling an action of
+// one of its services with the given parameters, e.g. the sqs service's CreateQueue action
+// with a QueueName.
+type SeedResource struct {
+	// Service is the service's endpoint name, as in AWS_ENDPOINT_URL_<SERVICE>, e.g. "dynamodb"
+	Service string
+	Action  string
+	Params  map[string]interface{}
+}
+
+// seedService is how a service that can create seed resources is registered and called
+type seedService struct {
+	name     string
+	protocol emulator.ProtocolType
+}
+
+// seedServices maps the endpoint names of the services that can create seed resources to
+// their registered names and protocols. Lambda is missing because its REST API routes
+// actions by path, which a seed resource's parameters don't describe.
+var seedServices = map[string]seedService{
+	"application-autoscaling": {"anyscalefrontendservice", emulator.ProtocolJSON},
+	"dynamodb":                {"dynamodb_20120810", emulator.ProtocolJSON},
+	"ec2":                     {"ec2", emulator.ProtocolQuery},
+	"elasticloadbalancing":    {"elasticloadbalancing", emulator.ProtocolQuery},
+	"events":                  {"events", emulator.ProtocolJSON},
+	"iam":                     {"iam", emulator.ProtocolQuery},
+	"monitoring":              {"monitoring", emulator.ProtocolQuery},
+	"rds":                     {"rds", emulator.ProtocolQuery},
+	"s3":                      {"s3", emulator.ProtocolRESTXML},
+	"sqs":                     {"sqs", emulator.ProtocolJSON},
+	"states":                  {"states", emulator.ProtocolJSON},
+}
+
+// SetSeedResources sets the resources the emulator creates when it starts and after its
+// state is reset, so they exist before any scenario runs. It must be called before Start.
+func (e *Emulator) SetSeedResources(resources []SeedResource) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.seedResources = resources
+}
+
+// createSeedResources calls the action of each seed resource in order, in the default
+// account and region
+func (e *Emulator) createSeedResources(ctx context.Context) error {
+	for i, resource := range e.seedResources {
+		if err := e.createSeedResource(ctx, resource); err != nil {
+			return fmt.Errorf("failed to create seed resource %d (%s %s): %w", i+1, resource.Service, resource.Action, err)
+		}
+	}
+	return nil
+}
+
+func (e *Emulator) createSeedResource(ctx context.Context, resource SeedResource) error {
+	seed, ok := seedServices[strings.ToLower(resource.Service)]
+	if !ok {
+		return fmt.Errorf("service %q can't create seed resources", resource.Service)
+	}
+	if resource.Action == "" {
+		return fmt.Errorf("action is required")
+	}
+
+	var service emulator.Service
+	for _, svc := range e.router.GetServices() {
+		if svc.ServiceName() == seed.name {
+			service = svc
+			break
+		}
+	}
+	if service == nil {
+		return fmt.Errorf("service %s is not registered", seed.name)
+	}
+
+	req, err := newSeedRequest(seed.protocol, resource)
+	if err != nil {
+		return err
+	}
+
+	resp, err := service.HandleRequest(emulator.WithRequestScope(ctx, emulator.ScopeFromRequest(req)), req)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, resp.Body)
+	}
+	return nil
+}
+
+// newSeedRequest builds the request for a seed resource's action. Query protocol parameters
+// are flattened, so nested keys are joined with dots and list items are numbered from 1,
+// e.g. {"Tags": {"member": [{"Key": "env"}]}} is sent as Tags.member.1.Key=env. S3 takes the
+// Bucket and Key parameters from the path.
+func newSeedRequest(protocol emulator.ProtocolType, resource SeedResource) (*emulator.AWSRequest, error) {
+	req := &emulator.AWSRequest{
+		Method:  "POST",
+		Path:    "/",
+		Headers: map[string]string{},
+		Action:  resource.Action,
+	}
+
+	switch protocol {
+	case emulator.ProtocolQuery:
+		values := url.Values{}
+		values.Set("Action", resource.Action)
+		flattenQueryParams(values, "", resource.Params)
+		req.Headers["Content-Type"] = "application/x-www-form-urlencoded"
+		req.Body = []byte(values.Encode())
+	case emulator.ProtocolJSON:
+		body, err := json.Marshal(resource.Params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode params: %w", err)
+		}
+		req.Headers["Content-Type"] = "application/x-amz-json-1.0"
+		req.Body = body
+	case emulator.ProtocolRESTXML:
+		params := make(map[string]interface{}, len(resource.Params))
+		for key, value := range resource.Params {
+			params[key] = value
+		}
+		bucket, _ := params["Bucket"].(string)
+		if bucket == "" {
+			return nil, fmt.Errorf("param Bucket is required")
+		}
+		req.Method = "PUT"
+		req.Path = "/" + bucket
+		if key, _ := params["Key"].(string); key != "" {
+			req.Path += "/" + key
+		}
+		delete(params, "Bucket")
+		delete(params, "Key")
+		req.Parameters = params
+	default:
+		return nil, fmt.Errorf("unsupported protocol %s", protocol)
+	}
+
+	return req, nil
+}
+
+func flattenQueryParams(values url.Values, prefix string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			flattenQueryParams(values, joinQueryKey(prefix, key), v[key])
+		}
+	case []interface{}:
+		for i, item := range v {
+			flattenQueryParams(values, joinQueryKey(prefix, fmt.Sprint(i+1)), item)
+		}
+	case nil:
+	default:
+		values.Set(prefix, fmt.Sprint(v))
+	}
+}
+
+func joinQueryKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
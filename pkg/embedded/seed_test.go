@@ -0,0 +1,46 @@
+package embedded
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStart_CreatesSeedResources(t *testing.T) {
+	emu := New()
+	emu.SetSeedResources([]SeedResource{
+		{Service: "s3", Action: "CreateBucket", Params: map[string]interface{}{"Bucket": "config"}},
+		{Service: "sqs", Action: "CreateQueue", Params: map[string]interface{}{"QueueName": "jobs", "Attributes": map[string]interface{}{"VisibilityTimeout": "45"}}},
+		{Service: "iam", Action: "CreateRole", Params: map[string]interface{}{
+			"RoleName":                 "deployer",
+			"AssumeRolePolicyDocument": `{"Version":"2012-10-17","Statement":[]}`,
+			"Tags":                     map[string]interface{}{"member": []interface{}{map[string]interface{}{"Key": "team", "Value": "platform"}}},
+		}},
+	})
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+
+	assert.Contains(t, emu.StateSnapshot("s3:config"), "s3:config")
+	assert.Contains(t, string(emu.StateSnapshot("sqs:queue:jobs")["sqs:queue:jobs"]), "45")
+	assert.Contains(t, string(emu.StateSnapshot("iam:role:deployer")["iam:role:deployer"]), "platform")
+
+	// Seed resources are recreated when the state is reset
+	emu.ResetState()
+	assert.Contains(t, emu.StateSnapshot("sqs:queue:jobs"), "sqs:queue:jobs")
+}
+
+func TestStart_FailsOnInvalidSeedResource(t *testing.T) {
+	emu := New()
+	emu.SetSeedResources([]SeedResource{{Service: "kms", Action: "CreateKey"}})
+	err := emu.Start(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `seed resource 1 (kms CreateKey): service "kms" can't create seed resources`)
+
+	emu = New()
+	emu.SetSeedResources([]SeedResource{{Service: "sqs", Action: "CreateQueue"}})
+	err = emu.Start(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 400")
+}
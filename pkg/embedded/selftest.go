@@ -0,0 +1,179 @@
+package embedded
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SelfTestResult is the outcome of probing one of the emulator's services
+type SelfTestResult struct {
+	// Service is the service's registered name, e.g. "dynamodb_20120810"
+	Service string
+
+	// Action is the action that was sent, empty if the service has no probe
+	Action string
+
+	// Err is why the probe failed, nil if it passed or the service has no probe
+	Err error
+}
+
+// Skipped reports whether the service has no probe, so it wasn't tested
+func (r SelfTestResult) Skipped() bool {
+	return r.Action == ""
+}
+
+// selfTestProbe is a minimal read-only request for a service, sent over HTTP
+type selfTestProbe struct {
+	// signingName is the service's name in the SigV4 credential scope, which routes the request
+	signingName string
+	action      string
+	method      string
+	path        string
+
+	// target and the body make a JSON protocol request, params a Query protocol request
+	target string
+	body   string
+	params url.Values
+
+	// xmlResponse is whether the response is XML rather than JSON
+	xmlResponse bool
+}
+
+// selfTestProbes maps the registered names of the built-in services to their probes.
+// DynamoDB Streams and S3 Control have no list action that works without an existing
+// resource, so they aren't probed.
+var selfTestProbes = map[string]selfTestProbe{
+	"anyscalefrontendservice": {signingName: "application-autoscaling", action: "DescribeScalableTargets", target: "AnyScaleFrontendService.DescribeScalableTargets", body: `{"ServiceNamespace":"dynamodb"}`},
+	"dynamodb_20120810":       {signingName: "dynamodb", action: "ListTables", target: "DynamoDB_20120810.ListTables", body: `{}`},
+	"ec2":                     {signingName: "ec2", action: "DescribeVpcs", params: url.Values{"Version": {"2016-11-15"}}, xmlResponse: true},
+	"elasticloadbalancing":    {signingName: "elasticloadbalancing", action: "DescribeLoadBalancers", params: url.Values{"Version": {"2015-12-01"}}, xmlResponse: true},
+	"events":                  {signingName: "events", action: "ListRules", target: "AWSEvents.ListRules", body: `{}`},
+	"iam":                     {signingName: "iam", action: "ListRoles", params: url.Values{"Version": {"2010-05-08"}}, xmlResponse: true},
+	"lambda":                  {signingName: "lambda", action: "ListFunctions", method: http.MethodGet, path: "/2015-03-31/functions"},
+	"monitoring":              {signingName: "monitoring", action: "ListMetrics", params: url.Values{"Version": {"2010-08-01"}}, xmlResponse: true},
+	"rds":                     {signingName: "rds", action: "DescribeDBInstances", params: url.Values{"Version": {"2014-10-31"}}, xmlResponse: true},
+	"s3":                      {signingName: "s3", action: "ListBuckets", method: http.MethodGet, path: "/", xmlResponse: true},
+	"sqs":                     {signingName: "sqs", action: "ListQueues", target: "AmazonSQS.ListQueues", body: `{}`},
+	"states":                  {signingName: "states", action: "ListStateMachines", target: "AWSStepFunctions.ListStateMachines", body: `{}`},
+	"sts":                     {signingName: "sts", action: "GetCallerIdentity", params: url.Values{"Version": {"2011-06-15"}}, xmlResponse: true},
+}
+
+// SelfTest sends a minimal read-only request, like ListBuckets or ListTables, to each of the
+// emulator's registered services over HTTP and checks that it succeeds with a well-formed
+// response. It's a smoke check that the services are wired up and serialize their responses.
+// Services without a probe, including custom services, are reported as skipped. Results are
+// sorted by service.
+func (e *Emulator) SelfTest(ctx context.Context) []SelfTestResult {
+	e.mu.Lock()
+	router := e.router
+	client, endpoint := e.httpClient(5 * time.Second)
+	e.mu.Unlock()
+
+	if router == nil {
+		return nil
+	}
+
+	var results []SelfTestResult
+	for _, svc := range router.GetServices() {
+		result := SelfTestResult{Service: svc.ServiceName()}
+		if probe, ok := selfTestProbes[result.Service]; ok {
+			result.Action = probe.action
+			result.Err = probe.run(ctx, client, endpoint)
+		}
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Service < results[j].Service
+	})
+	return results
+}
+
+// run sends the probe's request and checks the response
+func (p selfTestProbe) run(ctx context.Context, client *http.Client, endpoint string) error {
+	method := p.method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	var body io.Reader
+	contentType := ""
+	switch {
+	case p.target != "":
+		body = strings.NewReader(p.body)
+		contentType = "application/x-amz-json-1.0"
+	case p.params != nil:
+		values := url.Values{"Action": {p.action}}
+		for key, value := range p.params {
+			values[key] = value
+		}
+		body = strings.NewReader(values.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint+p.path, body)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if p.target != "" {
+		req.Header.Set("X-Amz-Target", p.target)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=test/%s/us-east-1/%s/aws4_request, SignedHeaders=host, Signature=selftest", time.Now().UTC().Format("20060102"), p.signingName))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, bytes.TrimSpace(data))
+	}
+
+	if p.xmlResponse {
+		return checkXML(data)
+	}
+	if !json.Valid(data) {
+		return fmt.Errorf("response isn't valid JSON: %s", data)
+	}
+	return nil
+}
+
+// checkXML returns an error if data isn't a well-formed XML document
+func checkXML(data []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	elements := 0
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("response isn't valid XML: %w", err)
+		}
+		if _, ok := token.(xml.StartElement); ok {
+			elements++
+		}
+	}
+	if elements == 0 {
+		return fmt.Errorf("response has no XML elements")
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package embedded
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelfTest(t *testing.T) {
+	emu := New()
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+
+	results := emu.SelfTest(context.Background())
+	require.NotEmpty(t, results)
+
+	probed := 0
+	for _, result := range results {
+		if result.Skipped() {
+			continue
+		}
+		probed++
+		assert.NoError(t, result.Err, "%s %s", result.Service, result.Action)
+	}
+	assert.Equal(t, len(selfTestProbes), probed)
+}
@@ -0,0 +1,64 @@
+// Package emulator lets custom infraspec builds add their own services to the embedded
+// emulator, alongside the emulated AWS services.
+//
+// A service implements Service: the emulator converts each HTTP request routed to it into an
+// AWSRequest, calls HandleRequest, and writes the returned AWSResponse as is. A service keeps
+// its own state; NewMemoryStateManager returns the store the built-in services use.
+//
+// Register services before the emulator starts, typically from an init function:
+//
+//	func init() {
+//		emulator.RegisterServiceWithMatcher(inventory.New(), func(req *http.Request) bool {
+//			return strings.HasPrefix(req.URL.Path, "/inventory/")
+//		})
+//	}
+package emulator
+
+import (
+	core "github.com/robmorgan/infraspec/internal/emulator/core"
+)
+
+type (
+	// Service handles the requests routed to it. ServiceName must be unique across the
+	// emulator, including the built-in services (e.g. "s3", "sqs", "monitoring").
+	Service = core.Service
+
+	// ActionProvider is an optional interface for services using the AWS Query protocol.
+	// Requests whose Action form parameter is one of SupportedActions are routed to the
+	// service.
+	ActionProvider = core.ActionProvider
+
+	// AWSRequest is a request routed to a service. Headers holds the first value of each
+	// header plus Host, Path includes the query string, and Action is taken from the
+	// X-Amz-Target header or the Action query or form parameter, if any.
+	AWSRequest = core.AWSRequest
+
+	// AWSResponse is written to the client with its status code, headers and body unchanged
+	AWSResponse = core.AWSResponse
+
+	// Matcher reports whether a request is for a particular service. A matcher that reads the
+	// request body must restore it for the service.
+	Matcher = core.Matcher
+
+	// StateManager is a key/value store for a service's resources
+	StateManager = core.StateManager
+)
+
+// RegisterService adds a service to emulators started afterwards. It is routed by name:
+// requests signed for the service name, sent to a host whose first label is the name (e.g.
+// inventory.localhost), or whose first path segment is the name (e.g. /inventory/items).
+func RegisterService(svc Service) {
+	core.RegisterCustomService(svc, nil)
+}
+
+// RegisterServiceWithMatcher adds a service to emulators started afterwards and routes every
+// request match accepts to it, ahead of the built-in services. Matchers are checked in
+// registration order.
+func RegisterServiceWithMatcher(svc Service, match Matcher) {
+	core.RegisterCustomService(svc, match)
+}
+
+// NewMemoryStateManager returns an empty in-memory StateManager
+func NewMemoryStateManager() StateManager {
+	return core.NewMemoryStateManager()
+}
@@ -0,0 +1,49 @@
+package emulator_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robmorgan/infraspec/pkg/embedded"
+	"github.com/robmorgan/infraspec/pkg/emulator"
+)
+
+// inventoryService answers every request with its action and path
+type inventoryService struct{}
+
+func (inventoryService) ServiceName() string {
+	return "inventory"
+}
+
+func (inventoryService) HandleRequest(ctx context.Context, req *emulator.AWSRequest) (*emulator.AWSResponse, error) {
+	return &emulator.AWSResponse{
+		StatusCode: 200,
+		Headers:    map[string]string{"Content-Type": "text/plain"},
+		Body:       []byte(req.Action + " " + req.Path),
+	}, nil
+}
+
+func TestRegisterServiceWithMatcher(t *testing.T) {
+	emulator.RegisterServiceWithMatcher(inventoryService{}, func(req *http.Request) bool {
+		return strings.HasPrefix(req.URL.Path, "/inventory/")
+	})
+
+	emu := embedded.New()
+	require.NoError(t, emu.Start(context.Background()))
+	defer emu.Stop(context.Background()) //nolint:errcheck
+
+	resp, err := http.Get(emu.Endpoint() + "/inventory/items?Action=ListItems")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "ListItems /inventory/items?Action=ListItems", string(body))
+}
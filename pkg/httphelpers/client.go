@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 type HttpRequestOptions struct {
@@ -23,6 +24,9 @@ type HttpRequestOptions struct {
 	RequestBody []byte
 	BasicAuth   *BasicAuth
 	BearerToken string
+
+	// CookieJar, if set, stores the cookies responses set and sends them on later requests
+	CookieJar http.CookieJar
 }
 
 type BasicAuth struct {
@@ -40,6 +44,9 @@ type HttpResponse struct {
 	StatusCode int
 	Headers    http.Header
 	Body       []byte
+
+	// Duration is how long the request took, from sending it to reading the whole response body
+	Duration time.Duration
 }
 
 // HttpClient handles HTTP requests and file uploads
@@ -159,8 +166,17 @@ func (h *HttpClient) Do(ctx context.Context, opts *HttpRequestOptions) (*HttpRes
 		req.Header.Set("Authorization", "Bearer "+opts.BearerToken)
 	}
 
+	// Send cookies from, and store cookies in, the request's jar
+	client := h.client
+	if opts.CookieJar != nil {
+		withJar := *h.client
+		withJar.Jar = opts.CookieJar
+		client = &withJar
+	}
+
 	// Send request
-	resp, err := h.client.Do(req)
+	start := time.Now()
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
@@ -177,5 +193,6 @@ func (h *HttpClient) Do(ctx context.Context, opts *HttpRequestOptions) (*HttpRes
 		StatusCode: resp.StatusCode,
 		Headers:    resp.Header,
 		Body:       responseBody,
+		Duration:   time.Since(start),
 	}, nil
 }
@@ -1,6 +1,7 @@
 package iacprovisioner
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"regexp"
@@ -90,9 +91,18 @@ func RunCommand(additionalOptions *Options, additionalArgs ...string) (string, e
 	cmd := generateCommand(options, args...)
 	description := fmt.Sprintf("%s %v", options.Binary, args)
 
+	ctx := options.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	return retry.DoWithRetryableErrors(description, options.RetryableTerraformErrors, options.MaxRetries, options.TimeBetweenRetries, func() (string, error) {
-		s, err := shell.RunCommandAndGetOutput(cmd)
+		s, err := shell.RunCommandAndGetOutputContext(ctx, cmd)
 		if err != nil {
+			// An interrupted command isn't retried, whatever its output
+			if ctx.Err() != nil {
+				return s, retry.FatalError{Underlying: fmt.Errorf("%s was interrupted: %w", description, ctx.Err())}
+			}
 			return s, err
 		}
 		if err := hasWarning(additionalOptions, s); err != nil {
@@ -0,0 +1,34 @@
+package iacprovisioner
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCommand_CanceledContextIsNotRetried(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	_, err := RunCommand(&Options{
+		Binary:                   "git",
+		WorkingDir:               t.TempDir(),
+		Context:                  ctx,
+		RetryableTerraformErrors: map[string]string{".*": "Every error is retryable."},
+		MaxRetries:               3,
+		TimeBetweenRetries:       time.Minute,
+	}, "version")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "was interrupted")
+	assert.Less(t, time.Since(start), time.Minute)
+}
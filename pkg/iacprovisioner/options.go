@@ -1,6 +1,7 @@
 package iacprovisioner
 
 import (
+	"context"
 	"time"
 
 	"github.com/jinzhu/copier"
@@ -41,6 +42,10 @@ type Options struct {
 	Binary     string // Name of the binary that will be used to run the IaC code.
 	WorkingDir string // The path to the folder where the IaC code is stored.
 
+	// Canceling the context interrupts the running IaC command, so it stops instead of being
+	// orphaned. If nil, commands run until they exit.
+	Context context.Context
+
 	// If set to true, Terraform configurations will be copied to a temporary directory before running.
 	// This is useful for running tests in parallel without file conflicts or to avoid polluting the
 	// original source directory with generated files. The temporary directory will be created with
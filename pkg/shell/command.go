@@ -2,12 +2,14 @@ package shell
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/robmorgan/infraspec/internal/config"
 )
@@ -20,6 +22,10 @@ type Command struct {
 	Env        map[string]string
 }
 
+// interruptGracePeriod is how long a canceled command has to exit after it is interrupted
+// before it is killed. Terraform uses it to stop its operation and write out its state.
+var interruptGracePeriod = 30 * time.Second
+
 // ErrWithCmdOutput is an error that includes the output of the command.
 type ErrWithCmdOutput struct {
 	Underlying error
@@ -51,7 +57,13 @@ func validateCommand(command Command) error {
 
 // RunCommandAndGetOutput runs the given command and returns the output as a string or an error if the command fails.
 func RunCommandAndGetOutput(command Command) (string, error) {
-	output, err := runCommand(command)
+	return RunCommandAndGetOutputContext(context.Background(), command)
+}
+
+// RunCommandAndGetOutputContext is RunCommandAndGetOutput, interrupting the command when ctx is
+// canceled. A command that hasn't exited within interruptGracePeriod of the interrupt is killed.
+func RunCommandAndGetOutputContext(ctx context.Context, command Command) (string, error) {
+	output, err := runCommand(ctx, command)
 	if err != nil {
 		return output.Stdout(), &ErrWithCmdOutput{err, output}
 	}
@@ -60,7 +72,7 @@ func RunCommandAndGetOutput(command Command) (string, error) {
 }
 
 // runCommand runs the given command and returns an error if the command fails.
-func runCommand(command Command) (*output, error) {
+func runCommand(ctx context.Context, command Command) (*output, error) {
 	config.Logging.Logger.Infof("Running command %s with args %s", command.Name, command.Args)
 
 	err := validateCommand(command)
@@ -68,7 +80,16 @@ func runCommand(command Command) (*output, error) {
 		return nil, err
 	}
 
-	cmd := exec.Command(command.Name, command.Args...) //nolint:gosec
+	cmd := exec.CommandContext(ctx, command.Name, command.Args...) //nolint:gosec
+	cmd.Cancel = func() error {
+		// Interrupt the command like Ctrl-C would, so it can exit cleanly, killing it on
+		// platforms without interrupts
+		if err := cmd.Process.Signal(os.Interrupt); err != nil {
+			return cmd.Process.Kill()
+		}
+		return nil
+	}
+	cmd.WaitDelay = interruptGracePeriod
 	cmd.Dir = command.WorkingDir
 	cmd.Stdin = os.Stdin
 	cmd.Env = formatEnvVars(command)
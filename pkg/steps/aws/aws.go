@@ -2,12 +2,20 @@ package aws
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/cucumber/godog"
+
+	"github.com/robmorgan/infraspec/internal/contexthelpers"
+	"github.com/robmorgan/infraspec/pkg/assertions"
+	"github.com/robmorgan/infraspec/pkg/assertions/aws"
+	"github.com/robmorgan/infraspec/pkg/embedded"
+	"github.com/robmorgan/infraspec/pkg/steps/registry"
 )
 
 // RegisterSteps registers all AWS-specific step definitions
-func RegisterSteps(sc *godog.ScenarioContext) {
+func RegisterSteps(sc registry.StepRegistrar) {
 	// DynamoDB steps
 	registerDynamoDBSteps(sc)
 
@@ -29,8 +37,25 @@ func RegisterSteps(sc *godog.ScenarioContext) {
 	// Lambda steps
 	registerLambdaSteps(sc)
 
+	// EventBridge steps
+	registerEventBridgeSteps(sc)
+
+	// Step Functions steps
+	registerStepFunctionsSteps(sc)
+
+	// CloudWatch steps
+	registerCloudWatchSteps(sc)
+
+	// Elastic Load Balancing steps
+	registerELBv2Steps(sc)
+
 	// Generic AWS steps
 	sc.Step(`^the AWS resource "([^"]*)" should exist$`, newAWSResourceExistsStep)
+	sc.Step(`^the resource with ARN "([^"]*)" should have (at least |exactly )?the tags$`, newResourceTagsStep)
+	sc.Step(`^the emulator should have received (\d+) "([^"]*)" requests?$`, newEmulatorReceivedRequestsStep)
+	sc.Step(`^the emulator should have no remaining "([^"]*)" resources$`, newEmulatorNoRemainingResourcesStep)
+	sc.Step(`^the emulator should have no remaining resources$`, newEmulatorNoRemainingResourcesOfAnyServiceStep)
+	sc.Step(`^I reset the emulator state$`, newResetEmulatorStateStep)
 }
 
 // Generic AWS Steps
@@ -38,3 +63,82 @@ func newAWSResourceExistsStep(ctx context.Context, resourceID string) error {
 	// TODO - implement
 	return nil
 }
+
+// newResourceTagsStep checks the tags of any supported resource, looked up by the service in its ARN
+func newResourceTagsStep(ctx context.Context, resourceArn, match string, table *godog.Table) error {
+	asserter, err := contexthelpers.GetAsserter(ctx, assertions.AWS)
+	if err != nil {
+		return err
+	}
+
+	tagsAssert, ok := asserter.(aws.ResourceTagsAsserter)
+	if !ok {
+		return fmt.Errorf("asserter does not implement ResourceTagsAsserter")
+	}
+	return tagsAssert.AssertResourceTags(resourceArn, tableToTags(table), tagMatchMode(match))
+}
+
+// newEmulatorReceivedRequestsStep checks how many requests for the action the embedded
+// emulator has received during the scenario.
+func newEmulatorReceivedRequestsStep(ctx context.Context, expected int, action string) error {
+	emu := embedded.GetInstance()
+	if emu == nil {
+		return fmt.Errorf("request counts are only available when running against the embedded emulator")
+	}
+
+	if actual := emu.RequestCount(action); actual != expected {
+		return fmt.Errorf("expected the emulator to have received %d %s requests, got %d", expected, action, actual)
+	}
+	return nil
+}
+
+// newEmulatorNoRemainingResourcesStep checks that the embedded emulator has no resources of
+// the service, e.g. "sqs", left over from the scenarios that ran
+func newEmulatorNoRemainingResourcesStep(ctx context.Context, service string) error {
+	emu := embedded.GetInstance()
+	if emu == nil {
+		return fmt.Errorf("remaining resources are only available when running against the embedded emulator")
+	}
+
+	if remaining := emu.RemainingResources(service); len(remaining) > 0 {
+		return fmt.Errorf("expected the emulator to have no remaining %s resources, got %d:\n  %s", service, len(remaining), strings.Join(remaining, "\n  "))
+	}
+	return nil
+}
+
+// newEmulatorNoRemainingResourcesOfAnyServiceStep checks that the embedded emulator has no
+// resources left over from the scenarios that ran
+func newEmulatorNoRemainingResourcesOfAnyServiceStep(ctx context.Context) error {
+	emu := embedded.GetInstance()
+	if emu == nil {
+		return fmt.Errorf("remaining resources are only available when running against the embedded emulator")
+	}
+
+	if remaining := emu.RemainingResources(""); len(remaining) > 0 {
+		return fmt.Errorf("expected the emulator to have no remaining resources, got %d:\n  %s", len(remaining), strings.Join(remaining, "\n  "))
+	}
+	return nil
+}
+
+// newResetEmulatorStateStep deletes every resource in the embedded emulator and re-creates the
+// ones it starts with, like the default VPC and the configured seed resources, so a later
+// phase of a scenario starts clean
+func newResetEmulatorStateStep(ctx context.Context) error {
+	emu := embedded.GetInstance()
+	if emu == nil {
+		return fmt.Errorf("the emulator state can only be reset when running against the embedded emulator")
+	}
+
+	emu.ResetState()
+	return nil
+}
+
+// storeVariable saves a captured value in the scenario store, so later steps can reference it as ${name}
+func storeVariable(ctx context.Context, name, value string) error {
+	store := contexthelpers.GetScenarioStore(ctx)
+	if store == nil {
+		return fmt.Errorf("no scenario store available to save %s", name)
+	}
+	store.Set(name, value)
+	return nil
+}
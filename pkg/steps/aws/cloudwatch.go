@@ -0,0 +1,37 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/robmorgan/infraspec/internal/contexthelpers"
+	"github.com/robmorgan/infraspec/pkg/assertions"
+	"github.com/robmorgan/infraspec/pkg/assertions/aws"
+	"github.com/robmorgan/infraspec/pkg/steps/registry"
+)
+
+// CloudWatch Step Definitions
+func registerCloudWatchSteps(sc registry.StepRegistrar) {
+	sc.Step(`^the CloudWatch metric "([^"]*)" in namespace "([^"]*)" should have (\d+) datapoints?$`, newMetricDatapointCountStep)
+}
+
+func newMetricDatapointCountStep(ctx context.Context, metricName, namespace string, count int) error {
+	cwAssert, err := getCloudWatchAsserter(ctx)
+	if err != nil {
+		return err
+	}
+	return cwAssert.AssertMetricDatapointCount(namespace, metricName, count)
+}
+
+func getCloudWatchAsserter(ctx context.Context) (aws.CloudWatchAsserter, error) {
+	asserter, err := contexthelpers.GetAsserter(ctx, assertions.AWS)
+	if err != nil {
+		return nil, err
+	}
+
+	cwAssert, ok := asserter.(aws.CloudWatchAsserter)
+	if !ok {
+		return nil, fmt.Errorf("asserter does not implement CloudWatchAsserter")
+	}
+	return cwAssert, nil
+}
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/cucumber/godog"
@@ -9,15 +10,20 @@ import (
 	"github.com/robmorgan/infraspec/internal/contexthelpers"
 	"github.com/robmorgan/infraspec/pkg/assertions"
 	"github.com/robmorgan/infraspec/pkg/assertions/aws"
+	"github.com/robmorgan/infraspec/pkg/steps/registry"
 )
 
 // DynamoDB Step Definitions
-func registerDynamoDBSteps(sc *godog.ScenarioContext) {
+func registerDynamoDBSteps(sc registry.StepRegistrar) {
 	sc.Step(`^the DynamoDB table "([^"]*)" should exist$`, newDynamoDBTableExistsStep)
-	sc.Step(`^the DynamoDB table "([^"]*)" should have tags$`, newDynamoDBTagsStep)
+	sc.Step(`^the DynamoDB table "([^"]*)" should have (at least |exactly )?(?:the )?tags$`, newDynamoDBTagsStep)
 	sc.Step(`^the DynamoDB table "([^"]*)" should have billing mode "([^"]*)"$`, newDynamoDBBillingModeStep)
 	sc.Step(`^the DynamoDB table "([^"]*)" should have read capacity (\d+)$`, newDynamoDBReadCapacityStep)
 	sc.Step(`^the DynamoDB table "([^"]*)" should have write capacity (\d+)$`, newDynamoDBWriteCapacityStep)
+	sc.Step(`^the following DynamoDB tables should exist:$`, newDynamoDBTablesExistStep)
+	sc.Step(`^the DynamoDB table "([^"]*)" should have a global secondary index "([^"]*)"(?: with hash key "([^"]*)")?$`, newDynamoDBGlobalSecondaryIndexStep)
+	sc.Step(`^the DynamoDB table "([^"]*)" stream should have (\d+) records?(?: of type "(INSERT|MODIFY|REMOVE)")?$`, newDynamoDBStreamRecordCountStep)
+	sc.Step(`^the DynamoDB table "([^"]*)" should have the item:$`, newDynamoDBItemStep)
 }
 
 func newDynamoDBTableExistsStep(ctx context.Context, tableName string) error {
@@ -29,7 +35,33 @@ func newDynamoDBTableExistsStep(ctx context.Context, tableName string) error {
 	return dynamoAssert.AssertTableExists(tableName)
 }
 
-func newDynamoDBTagsStep(ctx context.Context, tableName string, table *godog.Table) error {
+// newDynamoDBTablesExistStep checks every table in the table, with an optional "billing mode"
+// column, and reports all of the failures together
+func newDynamoDBTablesExistStep(ctx context.Context, table *godog.Table) error {
+	dynamoAssert, err := getDynamoDBAsserter(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := parseResourceTable(table, "name", "billing mode")
+	if err != nil {
+		return err
+	}
+
+	return assertEachResource("DynamoDB tables", rows, "name", func(row map[string]string) error {
+		tableName := row["name"]
+		if err := dynamoAssert.AssertTableExists(tableName); err != nil {
+			return err
+		}
+		return checkResourceColumns(row,
+			columnCheck{"billing mode", func(mode string) error {
+				return dynamoAssert.AssertBillingMode(tableName, mode)
+			}},
+		)
+	})
+}
+
+func newDynamoDBTagsStep(ctx context.Context, tableName, match string, table *godog.Table) error {
 	dynamoAssert, err := getDynamoDBAsserter(ctx)
 	if err != nil {
 		return err
@@ -41,7 +73,7 @@ func newDynamoDBTagsStep(ctx context.Context, tableName string, table *godog.Tab
 		tags[row.Cells[0].Value] = row.Cells[1].Value
 	}
 
-	return dynamoAssert.AssertTableTags(tableName, tags)
+	return dynamoAssert.AssertTableTags(tableName, tags, tagMatchMode(match))
 }
 
 func newDynamoDBBillingModeStep(ctx context.Context, tableName, expectedMode string) error {
@@ -72,6 +104,42 @@ func newDynamoDBWriteCapacityStep(ctx context.Context, tableName string, capacit
 	return dynamoAssert.AssertCapacity(tableName, -1, capacity)
 }
 
+func newDynamoDBStreamRecordCountStep(ctx context.Context, tableName string, count int, eventName string) error {
+	dynamoAssert, err := getDynamoDBAsserter(ctx)
+	if err != nil {
+		return err
+	}
+
+	// eventName is empty when the step doesn't filter by type
+	return dynamoAssert.AssertStreamRecordCount(tableName, count, eventName)
+}
+
+func newDynamoDBGlobalSecondaryIndexStep(ctx context.Context, tableName, indexName, hashKey string) error {
+	dynamoAssert, err := getDynamoDBAsserter(ctx)
+	if err != nil {
+		return err
+	}
+
+	// hashKey is empty when the step only checks that the index exists
+	return dynamoAssert.AssertGlobalSecondaryIndex(tableName, indexName, hashKey)
+}
+
+// newDynamoDBItemStep checks the item whose attributes are given as a JSON object, e.g.
+// {"id": "order-1", "total": 42}, rather than as DynamoDB attribute values
+func newDynamoDBItemStep(ctx context.Context, tableName string, doc *godog.DocString) error {
+	dynamoAssert, err := getDynamoDBAsserter(ctx)
+	if err != nil {
+		return err
+	}
+
+	var expected map[string]interface{}
+	if err := json.Unmarshal([]byte(doc.Content), &expected); err != nil {
+		return fmt.Errorf("the expected item must be a JSON object: %w", err)
+	}
+
+	return dynamoAssert.AssertItem(tableName, expected)
+}
+
 func getDynamoDBAsserter(ctx context.Context) (aws.DynamoDBAsserter, error) {
 	asserter, err := contexthelpers.GetAsserter(ctx, assertions.AWS)
 	if err != nil {
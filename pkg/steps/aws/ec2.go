@@ -11,10 +11,11 @@ import (
 	"github.com/robmorgan/infraspec/pkg/assertions"
 	"github.com/robmorgan/infraspec/pkg/assertions/aws"
 	"github.com/robmorgan/infraspec/pkg/iacprovisioner"
+	"github.com/robmorgan/infraspec/pkg/steps/registry"
 )
 
 // EC2 Step Definitions
-func registerEC2Steps(sc *godog.ScenarioContext) {
+func registerEC2Steps(sc registry.StepRegistrar) {
 	// Instance steps with direct IDs
 	sc.Step(`^the EC2 instance "([^"]*)" should exist$`, newEC2InstanceExistsStep)
 	sc.Step(`^the EC2 instance "([^"]*)" state should be "([^"]*)"$`, newEC2InstanceStateStep)
@@ -22,7 +23,9 @@ func registerEC2Steps(sc *godog.ScenarioContext) {
 	sc.Step(`^the EC2 instance "([^"]*)" AMI should be "([^"]*)"$`, newEC2InstanceAMIStep)
 	sc.Step(`^the EC2 instance "([^"]*)" should be in subnet "([^"]*)"$`, newEC2InstanceSubnetStep)
 	sc.Step(`^the EC2 instance "([^"]*)" should be in VPC "([^"]*)"$`, newEC2InstanceVPCStep)
-	sc.Step(`^the EC2 instance "([^"]*)" should have the tags$`, newEC2InstanceTagsStep)
+	sc.Step(`^the EC2 instance "([^"]*)" should have (at least |exactly )?the tags$`, newEC2InstanceTagsStep)
+	sc.Step(`^the following EC2 instances should exist:$`, newEC2InstancesExistStep)
+	sc.Step(`^there should be (\d+) EC2 instances? with tag "([^"]*)"="([^"]*)"$`, newEC2InstanceCountWithTagStep)
 
 	// Instance steps reading from Terraform output
 	sc.Step(`^the EC2 instance from output "([^"]*)" should exist$`, newEC2InstanceFromOutputExistsStep)
@@ -31,7 +34,7 @@ func registerEC2Steps(sc *godog.ScenarioContext) {
 	sc.Step(`^the EC2 instance from output "([^"]*)" AMI should be "([^"]*)"$`, newEC2InstanceFromOutputAMIStep)
 	sc.Step(`^the EC2 instance from output "([^"]*)" should be in subnet "([^"]*)"$`, newEC2InstanceFromOutputSubnetStep)
 	sc.Step(`^the EC2 instance from output "([^"]*)" should be in VPC "([^"]*)"$`, newEC2InstanceFromOutputVPCStep)
-	sc.Step(`^the EC2 instance from output "([^"]*)" should have the tags$`, newEC2InstanceFromOutputTagsStep)
+	sc.Step(`^the EC2 instance from output "([^"]*)" should have (at least |exactly )?the tags$`, newEC2InstanceFromOutputTagsStep)
 
 	// VPC steps with direct IDs
 	sc.Step(`^the VPC "([^"]*)" should exist$`, newVPCExistsStep)
@@ -39,13 +42,13 @@ func registerEC2Steps(sc *godog.ScenarioContext) {
 	sc.Step(`^the VPC "([^"]*)" CIDR block should be "([^"]*)"$`, newVPCCIDRStep)
 	sc.Step(`^the VPC "([^"]*)" should be the default VPC$`, newVPCIsDefaultStep)
 	sc.Step(`^the VPC "([^"]*)" should not be the default VPC$`, newVPCIsNotDefaultStep)
-	sc.Step(`^the VPC "([^"]*)" should have the tags$`, newVPCTagsStep)
+	sc.Step(`^the VPC "([^"]*)" should have (at least |exactly )?the tags$`, newVPCTagsStep)
 
 	// VPC steps reading from Terraform output
 	sc.Step(`^the VPC from output "([^"]*)" should exist$`, newVPCFromOutputExistsStep)
 	sc.Step(`^the VPC from output "([^"]*)" state should be "([^"]*)"$`, newVPCFromOutputStateStep)
 	sc.Step(`^the VPC from output "([^"]*)" CIDR block should be "([^"]*)"$`, newVPCFromOutputCIDRStep)
-	sc.Step(`^the VPC from output "([^"]*)" should have the tags$`, newVPCFromOutputTagsStep)
+	sc.Step(`^the VPC from output "([^"]*)" should have (at least |exactly )?the tags$`, newVPCFromOutputTagsStep)
 
 	// Subnet steps with direct IDs
 	sc.Step(`^the subnet "([^"]*)" should exist$`, newSubnetExistsStep)
@@ -53,7 +56,7 @@ func registerEC2Steps(sc *godog.ScenarioContext) {
 	sc.Step(`^the subnet "([^"]*)" CIDR block should be "([^"]*)"$`, newSubnetCIDRStep)
 	sc.Step(`^the subnet "([^"]*)" should be in VPC "([^"]*)"$`, newSubnetVPCStep)
 	sc.Step(`^the subnet "([^"]*)" availability zone should be "([^"]*)"$`, newSubnetAZStep)
-	sc.Step(`^the subnet "([^"]*)" should have the tags$`, newSubnetTagsStep)
+	sc.Step(`^the subnet "([^"]*)" should have (at least |exactly )?the tags$`, newSubnetTagsStep)
 
 	// Subnet steps reading from Terraform output
 	sc.Step(`^the subnet from output "([^"]*)" should exist$`, newSubnetFromOutputExistsStep)
@@ -61,48 +64,60 @@ func registerEC2Steps(sc *godog.ScenarioContext) {
 	sc.Step(`^the subnet from output "([^"]*)" CIDR block should be "([^"]*)"$`, newSubnetFromOutputCIDRStep)
 	sc.Step(`^the subnet from output "([^"]*)" should be in VPC "([^"]*)"$`, newSubnetFromOutputVPCStep)
 	sc.Step(`^the subnet from output "([^"]*)" availability zone should be "([^"]*)"$`, newSubnetFromOutputAZStep)
-	sc.Step(`^the subnet from output "([^"]*)" should have the tags$`, newSubnetFromOutputTagsStep)
+	sc.Step(`^the subnet from output "([^"]*)" should have (at least |exactly )?the tags$`, newSubnetFromOutputTagsStep)
 
 	// Security Group steps with direct IDs
 	sc.Step(`^the security group "([^"]*)" should exist$`, newSecurityGroupExistsStep)
 	sc.Step(`^the security group "([^"]*)" name should be "([^"]*)"$`, newSecurityGroupNameStep)
 	sc.Step(`^the security group "([^"]*)" should be in VPC "([^"]*)"$`, newSecurityGroupVPCStep)
 	sc.Step(`^the security group "([^"]*)" description should be "([^"]*)"$`, newSecurityGroupDescriptionStep)
-	sc.Step(`^the security group "([^"]*)" should have the tags$`, newSecurityGroupTagsStep)
+	sc.Step(`^the security group "([^"]*)" should have (at least |exactly )?the tags$`, newSecurityGroupTagsStep)
+	sc.Step(`^the security group "([^"]*)" should allow ingress on port (\d+) from "([^"]*)"$`, newSecurityGroupAllowsIngressStep)
+	sc.Step(`^the security group "([^"]*)" should not allow ingress from "([^"]*)" on port (\d+)$`, newSecurityGroupDeniesIngressStep)
 
 	// Security Group steps reading from Terraform output
 	sc.Step(`^the security group from output "([^"]*)" should exist$`, newSecurityGroupFromOutputExistsStep)
 	sc.Step(`^the security group from output "([^"]*)" name should be "([^"]*)"$`, newSecurityGroupFromOutputNameStep)
 	sc.Step(`^the security group from output "([^"]*)" should be in VPC "([^"]*)"$`, newSecurityGroupFromOutputVPCStep)
-	sc.Step(`^the security group from output "([^"]*)" should have the tags$`, newSecurityGroupFromOutputTagsStep)
+	sc.Step(`^the security group from output "([^"]*)" should have (at least |exactly )?the tags$`, newSecurityGroupFromOutputTagsStep)
+	sc.Step(`^the security group from output "([^"]*)" should allow ingress on port (\d+) from "([^"]*)"$`, newSecurityGroupFromOutputAllowsIngressStep)
+	sc.Step(`^the security group from output "([^"]*)" should not allow ingress from "([^"]*)" on port (\d+)$`, newSecurityGroupFromOutputDeniesIngressStep)
 
 	// Internet Gateway steps with direct IDs
 	sc.Step(`^the internet gateway "([^"]*)" should exist$`, newInternetGatewayExistsStep)
 	sc.Step(`^the internet gateway "([^"]*)" should be attached to VPC "([^"]*)"$`, newInternetGatewayAttachedStep)
-	sc.Step(`^the internet gateway "([^"]*)" should have the tags$`, newInternetGatewayTagsStep)
+	sc.Step(`^the internet gateway "([^"]*)" should have (at least |exactly )?the tags$`, newInternetGatewayTagsStep)
 
 	// Internet Gateway steps reading from Terraform output
 	sc.Step(`^the internet gateway from output "([^"]*)" should exist$`, newInternetGatewayFromOutputExistsStep)
 	sc.Step(`^the internet gateway from output "([^"]*)" should be attached to VPC "([^"]*)"$`, newInternetGatewayFromOutputAttachedStep)
-	sc.Step(`^the internet gateway from output "([^"]*)" should have the tags$`, newInternetGatewayFromOutputTagsStep)
+	sc.Step(`^the internet gateway from output "([^"]*)" should have (at least |exactly )?the tags$`, newInternetGatewayFromOutputTagsStep)
+
+	// VPC Endpoint steps
+	sc.Step(`^the VPC "([^"]*)" should have an endpoint for service "([^"]*)"$`, newVPCEndpointStep)
+	sc.Step(`^the VPC from output "([^"]*)" should have an endpoint for service "([^"]*)"$`, newVPCFromOutputEndpointStep)
 
 	// EBS Volume steps with direct IDs
 	sc.Step(`^the EBS volume "([^"]*)" should exist$`, newEBSVolumeExistsStep)
 	sc.Step(`^the EBS volume "([^"]*)" state should be "([^"]*)"$`, newEBSVolumeStateStep)
 	sc.Step(`^the EBS volume "([^"]*)" size should be (\d+) GB$`, newEBSVolumeSizeStep)
 	sc.Step(`^the EBS volume "([^"]*)" type should be "([^"]*)"$`, newEBSVolumeTypeStep)
-	sc.Step(`^the EBS volume "([^"]*)" should have the tags$`, newEBSVolumeTagsStep)
+	sc.Step(`^the EBS volume "([^"]*)" should have (at least |exactly )?the tags$`, newEBSVolumeTagsStep)
 
 	// EBS Volume steps reading from Terraform output
 	sc.Step(`^the EBS volume from output "([^"]*)" should exist$`, newEBSVolumeFromOutputExistsStep)
 	sc.Step(`^the EBS volume from output "([^"]*)" state should be "([^"]*)"$`, newEBSVolumeFromOutputStateStep)
 	sc.Step(`^the EBS volume from output "([^"]*)" size should be (\d+) GB$`, newEBSVolumeFromOutputSizeStep)
 	sc.Step(`^the EBS volume from output "([^"]*)" type should be "([^"]*)"$`, newEBSVolumeFromOutputTypeStep)
-	sc.Step(`^the EBS volume from output "([^"]*)" should have the tags$`, newEBSVolumeFromOutputTagsStep)
+	sc.Step(`^the EBS volume from output "([^"]*)" should have (at least |exactly )?the tags$`, newEBSVolumeFromOutputTagsStep)
 
 	// Key Pair steps
 	sc.Step(`^the key pair "([^"]*)" should exist$`, newKeyPairExistsStep)
 	sc.Step(`^the key pair from output "([^"]*)" should exist$`, newKeyPairFromOutputExistsStep)
+
+	// Capture steps storing an attribute in a scenario variable
+	sc.Step(`^I store the (EC2 instance|VPC|subnet|security group) "([^"]*)" ([A-Za-z ]+) as "([^"]*)"$`, newStoreEC2AttributeStep)
+	sc.Step(`^I store the (EC2 instance|VPC|subnet|security group) from output "([^"]*)" ([A-Za-z ]+) as "([^"]*)"$`, newStoreEC2AttributeFromOutputStep)
 }
 
 // ==================== Instance Steps ====================
@@ -191,7 +206,7 @@ func newEC2InstanceVPCStep(ctx context.Context, instanceID, vpcID string) error
 	return asserter.AssertEC2InstanceVPC(instanceID, vpcID, region)
 }
 
-func newEC2InstanceTagsStep(ctx context.Context, instanceID string, table *godog.Table) error {
+func newEC2InstanceTagsStep(ctx context.Context, instanceID, match string, table *godog.Table) error {
 	asserter, err := getEC2Asserter(ctx)
 	if err != nil {
 		return err
@@ -204,7 +219,55 @@ func newEC2InstanceTagsStep(ctx context.Context, instanceID string, table *godog
 		return fmt.Errorf("no AWS region available")
 	}
 
-	return asserter.AssertEC2InstanceTags(instanceID, tags, region)
+	return asserter.AssertEC2InstanceTags(instanceID, tags, tagMatchMode(match), region)
+}
+
+func newEC2InstanceCountWithTagStep(ctx context.Context, count int, tagKey, tagValue string) error {
+	asserter, err := getEC2Asserter(ctx)
+	if err != nil {
+		return err
+	}
+
+	region := contexthelpers.GetAwsRegion(ctx)
+	if region == "" {
+		return fmt.Errorf("no AWS region available")
+	}
+
+	return asserter.AssertEC2InstanceCountWithTag(tagKey, tagValue, count, region)
+}
+
+// newEC2InstancesExistStep checks every instance in the table, with optional "state" and
+// "type" columns, and reports all of the failures together
+func newEC2InstancesExistStep(ctx context.Context, table *godog.Table) error {
+	asserter, err := getEC2Asserter(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := parseResourceTable(table, "id", "state", "type")
+	if err != nil {
+		return err
+	}
+
+	region := contexthelpers.GetAwsRegion(ctx)
+	if region == "" {
+		return fmt.Errorf("no AWS region available")
+	}
+
+	return assertEachResource("EC2 instances", rows, "id", func(row map[string]string) error {
+		instanceID := row["id"]
+		if err := asserter.AssertEC2InstanceExists(instanceID, region); err != nil {
+			return err
+		}
+		return checkResourceColumns(row,
+			columnCheck{"state", func(state string) error {
+				return asserter.AssertEC2InstanceState(instanceID, state, region)
+			}},
+			columnCheck{"type", func(instanceType string) error {
+				return asserter.AssertEC2InstanceType(instanceID, instanceType, region)
+			}},
+		)
+	})
 }
 
 // Instance steps from Terraform output
@@ -257,12 +320,12 @@ func newEC2InstanceFromOutputVPCStep(ctx context.Context, outputName, vpcID stri
 	return newEC2InstanceVPCStep(ctx, instanceID, vpcID)
 }
 
-func newEC2InstanceFromOutputTagsStep(ctx context.Context, outputName string, table *godog.Table) error {
+func newEC2InstanceFromOutputTagsStep(ctx context.Context, outputName, match string, table *godog.Table) error {
 	instanceID, err := getResourceIDFromOutput(ctx, outputName)
 	if err != nil {
 		return err
 	}
-	return newEC2InstanceTagsStep(ctx, instanceID, table)
+	return newEC2InstanceTagsStep(ctx, instanceID, match, table)
 }
 
 // ==================== VPC Steps ====================
@@ -337,7 +400,7 @@ func newVPCIsNotDefaultStep(ctx context.Context, vpcID string) error {
 	return asserter.AssertVPCIsDefault(vpcID, false, region)
 }
 
-func newVPCTagsStep(ctx context.Context, vpcID string, table *godog.Table) error {
+func newVPCTagsStep(ctx context.Context, vpcID, match string, table *godog.Table) error {
 	asserter, err := getEC2Asserter(ctx)
 	if err != nil {
 		return err
@@ -350,7 +413,7 @@ func newVPCTagsStep(ctx context.Context, vpcID string, table *godog.Table) error
 		return fmt.Errorf("no AWS region available")
 	}
 
-	return asserter.AssertVPCTags(vpcID, tags, region)
+	return asserter.AssertVPCTags(vpcID, tags, tagMatchMode(match), region)
 }
 
 // VPC steps from Terraform output
@@ -379,12 +442,12 @@ func newVPCFromOutputCIDRStep(ctx context.Context, outputName, cidrBlock string)
 	return newVPCCIDRStep(ctx, vpcID, cidrBlock)
 }
 
-func newVPCFromOutputTagsStep(ctx context.Context, outputName string, table *godog.Table) error {
+func newVPCFromOutputTagsStep(ctx context.Context, outputName, match string, table *godog.Table) error {
 	vpcID, err := getResourceIDFromOutput(ctx, outputName)
 	if err != nil {
 		return err
 	}
-	return newVPCTagsStep(ctx, vpcID, table)
+	return newVPCTagsStep(ctx, vpcID, match, table)
 }
 
 // ==================== Subnet Steps ====================
@@ -459,7 +522,7 @@ func newSubnetAZStep(ctx context.Context, subnetID, az string) error {
 	return asserter.AssertSubnetAvailabilityZone(subnetID, az, region)
 }
 
-func newSubnetTagsStep(ctx context.Context, subnetID string, table *godog.Table) error {
+func newSubnetTagsStep(ctx context.Context, subnetID, match string, table *godog.Table) error {
 	asserter, err := getEC2Asserter(ctx)
 	if err != nil {
 		return err
@@ -472,7 +535,7 @@ func newSubnetTagsStep(ctx context.Context, subnetID string, table *godog.Table)
 		return fmt.Errorf("no AWS region available")
 	}
 
-	return asserter.AssertSubnetTags(subnetID, tags, region)
+	return asserter.AssertSubnetTags(subnetID, tags, tagMatchMode(match), region)
 }
 
 // Subnet steps from Terraform output
@@ -517,12 +580,12 @@ func newSubnetFromOutputAZStep(ctx context.Context, outputName, az string) error
 	return newSubnetAZStep(ctx, subnetID, az)
 }
 
-func newSubnetFromOutputTagsStep(ctx context.Context, outputName string, table *godog.Table) error {
+func newSubnetFromOutputTagsStep(ctx context.Context, outputName, match string, table *godog.Table) error {
 	subnetID, err := getResourceIDFromOutput(ctx, outputName)
 	if err != nil {
 		return err
 	}
-	return newSubnetTagsStep(ctx, subnetID, table)
+	return newSubnetTagsStep(ctx, subnetID, match, table)
 }
 
 // ==================== Security Group Steps ====================
@@ -583,7 +646,7 @@ func newSecurityGroupDescriptionStep(ctx context.Context, groupID, description s
 	return asserter.AssertSecurityGroupDescription(groupID, description, region)
 }
 
-func newSecurityGroupTagsStep(ctx context.Context, groupID string, table *godog.Table) error {
+func newSecurityGroupTagsStep(ctx context.Context, groupID, match string, table *godog.Table) error {
 	asserter, err := getEC2Asserter(ctx)
 	if err != nil {
 		return err
@@ -596,7 +659,7 @@ func newSecurityGroupTagsStep(ctx context.Context, groupID string, table *godog.
 		return fmt.Errorf("no AWS region available")
 	}
 
-	return asserter.AssertSecurityGroupTags(groupID, tags, region)
+	return asserter.AssertSecurityGroupTags(groupID, tags, tagMatchMode(match), region)
 }
 
 // Security Group steps from Terraform output
@@ -625,12 +688,56 @@ func newSecurityGroupFromOutputVPCStep(ctx context.Context, outputName, vpcID st
 	return newSecurityGroupVPCStep(ctx, groupID, vpcID)
 }
 
-func newSecurityGroupFromOutputTagsStep(ctx context.Context, outputName string, table *godog.Table) error {
+func newSecurityGroupFromOutputTagsStep(ctx context.Context, outputName, match string, table *godog.Table) error {
+	groupID, err := getResourceIDFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newSecurityGroupTagsStep(ctx, groupID, match, table)
+}
+
+func newSecurityGroupAllowsIngressStep(ctx context.Context, groupID string, port int, cidr string) error {
+	asserter, err := getEC2Asserter(ctx)
+	if err != nil {
+		return err
+	}
+
+	region := contexthelpers.GetAwsRegion(ctx)
+	if region == "" {
+		return fmt.Errorf("no AWS region available")
+	}
+
+	return asserter.AssertSecurityGroupAllowsIngress(groupID, port, cidr, region)
+}
+
+func newSecurityGroupFromOutputAllowsIngressStep(ctx context.Context, outputName string, port int, cidr string) error {
 	groupID, err := getResourceIDFromOutput(ctx, outputName)
 	if err != nil {
 		return err
 	}
-	return newSecurityGroupTagsStep(ctx, groupID, table)
+	return newSecurityGroupAllowsIngressStep(ctx, groupID, port, cidr)
+}
+
+func newSecurityGroupDeniesIngressStep(ctx context.Context, groupID, cidr string, port int) error {
+	asserter, err := getEC2Asserter(ctx)
+	if err != nil {
+		return err
+	}
+
+	region := contexthelpers.GetAwsRegion(ctx)
+	if region == "" {
+		return fmt.Errorf("no AWS region available")
+	}
+
+	return asserter.AssertSecurityGroupDeniesIngress(groupID, port, cidr, region)
+}
+
+func newSecurityGroupFromOutputDeniesIngressStep(ctx context.Context, outputName, cidr string, port int) error {
+	groupID, err := getResourceIDFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newSecurityGroupDeniesIngressStep(ctx, groupID, cidr, port)
 }
 
 // ==================== Internet Gateway Steps ====================
@@ -663,7 +770,7 @@ func newInternetGatewayAttachedStep(ctx context.Context, igwID, vpcID string) er
 	return asserter.AssertInternetGatewayAttachedToVPC(igwID, vpcID, region)
 }
 
-func newInternetGatewayTagsStep(ctx context.Context, igwID string, table *godog.Table) error {
+func newInternetGatewayTagsStep(ctx context.Context, igwID, match string, table *godog.Table) error {
 	asserter, err := getEC2Asserter(ctx)
 	if err != nil {
 		return err
@@ -676,7 +783,7 @@ func newInternetGatewayTagsStep(ctx context.Context, igwID string, table *godog.
 		return fmt.Errorf("no AWS region available")
 	}
 
-	return asserter.AssertInternetGatewayTags(igwID, tags, region)
+	return asserter.AssertInternetGatewayTags(igwID, tags, tagMatchMode(match), region)
 }
 
 // Internet Gateway steps from Terraform output
@@ -697,12 +804,36 @@ func newInternetGatewayFromOutputAttachedStep(ctx context.Context, outputName, v
 	return newInternetGatewayAttachedStep(ctx, igwID, vpcID)
 }
 
-func newInternetGatewayFromOutputTagsStep(ctx context.Context, outputName string, table *godog.Table) error {
+func newInternetGatewayFromOutputTagsStep(ctx context.Context, outputName, match string, table *godog.Table) error {
 	igwID, err := getResourceIDFromOutput(ctx, outputName)
 	if err != nil {
 		return err
 	}
-	return newInternetGatewayTagsStep(ctx, igwID, table)
+	return newInternetGatewayTagsStep(ctx, igwID, match, table)
+}
+
+// ==================== VPC Endpoint Steps ====================
+
+func newVPCEndpointStep(ctx context.Context, vpcID, serviceName string) error {
+	asserter, err := getEC2Asserter(ctx)
+	if err != nil {
+		return err
+	}
+
+	region := contexthelpers.GetAwsRegion(ctx)
+	if region == "" {
+		return fmt.Errorf("no AWS region available")
+	}
+
+	return asserter.AssertVPCHasEndpoint(vpcID, serviceName, region)
+}
+
+func newVPCFromOutputEndpointStep(ctx context.Context, outputName, serviceName string) error {
+	vpcID, err := getResourceIDFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newVPCEndpointStep(ctx, vpcID, serviceName)
 }
 
 // ==================== EBS Volume Steps ====================
@@ -763,7 +894,7 @@ func newEBSVolumeTypeStep(ctx context.Context, volumeID, volumeType string) erro
 	return asserter.AssertEBSVolumeType(volumeID, volumeType, region)
 }
 
-func newEBSVolumeTagsStep(ctx context.Context, volumeID string, table *godog.Table) error {
+func newEBSVolumeTagsStep(ctx context.Context, volumeID, match string, table *godog.Table) error {
 	asserter, err := getEC2Asserter(ctx)
 	if err != nil {
 		return err
@@ -776,7 +907,7 @@ func newEBSVolumeTagsStep(ctx context.Context, volumeID string, table *godog.Tab
 		return fmt.Errorf("no AWS region available")
 	}
 
-	return asserter.AssertEBSVolumeTags(volumeID, tags, region)
+	return asserter.AssertEBSVolumeTags(volumeID, tags, tagMatchMode(match), region)
 }
 
 // EBS Volume steps from Terraform output
@@ -813,12 +944,12 @@ func newEBSVolumeFromOutputTypeStep(ctx context.Context, outputName, volumeType
 	return newEBSVolumeTypeStep(ctx, volumeID, volumeType)
 }
 
-func newEBSVolumeFromOutputTagsStep(ctx context.Context, outputName string, table *godog.Table) error {
+func newEBSVolumeFromOutputTagsStep(ctx context.Context, outputName, match string, table *godog.Table) error {
 	volumeID, err := getResourceIDFromOutput(ctx, outputName)
 	if err != nil {
 		return err
 	}
-	return newEBSVolumeTagsStep(ctx, volumeID, table)
+	return newEBSVolumeTagsStep(ctx, volumeID, match, table)
 }
 
 // ==================== Key Pair Steps ====================
@@ -860,6 +991,45 @@ func getEC2Asserter(ctx context.Context) (aws.EC2Asserter, error) {
 	return ec2Assert, nil
 }
 
+// ==================== Capture Steps ====================
+
+func newStoreEC2AttributeStep(ctx context.Context, resourceType, resourceID, attribute, variable string) error {
+	asserter, err := getEC2Asserter(ctx)
+	if err != nil {
+		return err
+	}
+
+	region := contexthelpers.GetAwsRegion(ctx)
+	if region == "" {
+		return fmt.Errorf("no AWS region available")
+	}
+
+	var value string
+	switch resourceType {
+	case "EC2 instance":
+		value, err = asserter.GetEC2InstanceAttribute(resourceID, attribute, region)
+	case "VPC":
+		value, err = asserter.GetVPCAttribute(resourceID, attribute, region)
+	case "subnet":
+		value, err = asserter.GetSubnetAttribute(resourceID, attribute, region)
+	case "security group":
+		value, err = asserter.GetSecurityGroupAttribute(resourceID, attribute, region)
+	}
+	if err != nil {
+		return err
+	}
+
+	return storeVariable(ctx, variable, value)
+}
+
+func newStoreEC2AttributeFromOutputStep(ctx context.Context, resourceType, outputName, attribute, variable string) error {
+	resourceID, err := getResourceIDFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newStoreEC2AttributeStep(ctx, resourceType, resourceID, attribute, variable)
+}
+
 func getResourceIDFromOutput(ctx context.Context, outputName string) (string, error) {
 	options := contexthelpers.GetIacProvisionerOptions(ctx)
 	resourceID, err := iacprovisioner.Output(options, outputName)
@@ -877,5 +1047,14 @@ func tableToTags(table *godog.Table) map[string]string {
 	return tags
 }
 
+// tagMatchMode converts the optional "at least " or "exactly " qualifier captured by the tag
+// steps into a match mode. Without a qualifier, extra tags on the resource are ignored.
+func tagMatchMode(qualifier string) aws.TagMatchMode {
+	if qualifier == "exactly " {
+		return aws.TagMatchExact
+	}
+	return aws.TagMatchSubset
+}
+
 // strconv is used for potential boolean parsing in future steps
 var _ = strconv.ParseBool
@@ -0,0 +1,46 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/robmorgan/infraspec/internal/contexthelpers"
+	"github.com/robmorgan/infraspec/pkg/assertions"
+	"github.com/robmorgan/infraspec/pkg/assertions/aws"
+	"github.com/robmorgan/infraspec/pkg/steps/registry"
+)
+
+// Elastic Load Balancing Step Definitions
+func registerELBv2Steps(sc registry.StepRegistrar) {
+	sc.Step(`^the load balancer "([^"]*)" should have a listener on port (\d+)$`, newLoadBalancerListenerPortStep)
+	sc.Step(`^the target group "([^"]*)" should have (\d+) healthy targets?$`, newTargetGroupHealthyTargetsStep)
+}
+
+func newLoadBalancerListenerPortStep(ctx context.Context, name string, port int32) error {
+	elbAssert, err := getELBv2Asserter(ctx)
+	if err != nil {
+		return err
+	}
+	return elbAssert.AssertLoadBalancerListenerPort(name, port)
+}
+
+func newTargetGroupHealthyTargetsStep(ctx context.Context, name string, count int) error {
+	elbAssert, err := getELBv2Asserter(ctx)
+	if err != nil {
+		return err
+	}
+	return elbAssert.AssertTargetGroupHealthyTargets(name, count)
+}
+
+func getELBv2Asserter(ctx context.Context) (aws.ELBv2Asserter, error) {
+	asserter, err := contexthelpers.GetAsserter(ctx, assertions.AWS)
+	if err != nil {
+		return nil, err
+	}
+
+	elbAssert, ok := asserter.(aws.ELBv2Asserter)
+	if !ok {
+		return nil, fmt.Errorf("asserter does not implement ELBv2Asserter")
+	}
+	return elbAssert, nil
+}
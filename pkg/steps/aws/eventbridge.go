@@ -0,0 +1,39 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/robmorgan/infraspec/internal/contexthelpers"
+	"github.com/robmorgan/infraspec/pkg/assertions"
+	"github.com/robmorgan/infraspec/pkg/assertions/aws"
+	"github.com/robmorgan/infraspec/pkg/steps/registry"
+)
+
+// EventBridge Step Definitions
+func registerEventBridgeSteps(sc registry.StepRegistrar) {
+	sc.Step(`^the event bus (?:"([^"]*)" )?should route to queue "([^"]*)"$`, newEventBusRoutesToQueueStep)
+}
+
+func newEventBusRoutesToQueueStep(ctx context.Context, busName, queueName string) error {
+	eventsAssert, err := getEventBridgeAsserter(ctx)
+	if err != nil {
+		return err
+	}
+
+	// busName is empty for the default event bus
+	return eventsAssert.AssertEventBusRoutesToQueue(busName, queueName)
+}
+
+func getEventBridgeAsserter(ctx context.Context) (aws.EventBridgeAsserter, error) {
+	asserter, err := contexthelpers.GetAsserter(ctx, assertions.AWS)
+	if err != nil {
+		return nil, err
+	}
+
+	eventsAssert, ok := asserter.(aws.EventBridgeAsserter)
+	if !ok {
+		return nil, fmt.Errorf("asserter does not implement EventBridgeAsserter")
+	}
+	return eventsAssert, nil
+}
@@ -11,10 +11,11 @@ import (
 	"github.com/robmorgan/infraspec/pkg/assertions"
 	"github.com/robmorgan/infraspec/pkg/assertions/aws"
 	"github.com/robmorgan/infraspec/pkg/iacprovisioner"
+	"github.com/robmorgan/infraspec/pkg/steps/registry"
 )
 
 // registerIAMSteps registers all IAM-related Gherkin step definitions
-func registerIAMSteps(sc *godog.ScenarioContext) {
+func registerIAMSteps(sc registry.StepRegistrar) {
 	// Permission check
 	sc.Step(`^I have the necessary IAM permissions to describe IAM roles$`, newVerifyIAMDescribeRolesStep)
 
@@ -22,29 +23,35 @@ func registerIAMSteps(sc *godog.ScenarioContext) {
 	sc.Step(`^the IAM role "([^"]*)" should exist$`, newIAMRoleExistsStep)
 	sc.Step(`^the IAM role "([^"]*)" path should be "([^"]*)"$`, newIAMRolePathStep)
 	sc.Step(`^the IAM role "([^"]*)" max session duration should be (\d+)$`, newIAMRoleMaxSessionDurationStep)
-	sc.Step(`^the IAM role "([^"]*)" should have the tags$`, newIAMRoleTagsStep)
+	sc.Step(`^the IAM role "([^"]*)" should have (at least |exactly )?the tags$`, newIAMRoleTagsStep)
 
 	// Role assertions - from Terraform output
 	sc.Step(`^the IAM role from output "([^"]*)" should exist$`, newIAMRoleFromOutputExistsStep)
 	sc.Step(`^the IAM role from output "([^"]*)" path should be "([^"]*)"$`, newIAMRoleFromOutputPathStep)
 	sc.Step(`^the IAM role from output "([^"]*)" max session duration should be (\d+)$`, newIAMRoleFromOutputMaxSessionDurationStep)
-	sc.Step(`^the IAM role from output "([^"]*)" should have the tags$`, newIAMRoleFromOutputTagsStep)
+	sc.Step(`^the IAM role from output "([^"]*)" should have (at least |exactly )?the tags$`, newIAMRoleFromOutputTagsStep)
 
 	// Policy assertions - direct
 	sc.Step(`^the IAM policy "([^"]*)" should exist$`, newIAMPolicyExistsStep)
 	sc.Step(`^the IAM policy "([^"]*)" should be attached to role "([^"]*)"$`, newIAMPolicyAttachedToRoleStep)
+	sc.Step(`^the IAM policy "([^"]*)" should allow action "([^"]*)" on resource "([^"]*)"$`, newIAMPolicyAllowsActionStep)
+	sc.Step(`^the IAM role "([^"]*)" inline policy "([^"]*)" should allow action "([^"]*)" on resource "([^"]*)"$`, newIAMRoleInlinePolicyAllowsActionStep)
 
 	// Policy assertions - from Terraform output
 	sc.Step(`^the IAM policy from output "([^"]*)" should exist$`, newIAMPolicyFromOutputExistsStep)
 	sc.Step(`^the IAM policy from output "([^"]*)" should be attached to role from output "([^"]*)"$`, newIAMPolicyAttachedToRoleFromOutputStep)
+	sc.Step(`^the IAM policy from output "([^"]*)" should allow action "([^"]*)" on resource "([^"]*)"$`, newIAMPolicyFromOutputAllowsActionStep)
 
 	// Instance profile assertions - direct
 	sc.Step(`^the IAM instance profile "([^"]*)" should exist$`, newIAMInstanceProfileExistsStep)
-	sc.Step(`^the IAM instance profile "([^"]*)" should have role "([^"]*)"$`, newIAMInstanceProfileHasRoleStep)
+	sc.Step(`^the IAM instance profile "([^"]*)" should (?:have|contain) role "([^"]*)"$`, newIAMInstanceProfileHasRoleStep)
 
 	// Instance profile assertions - from Terraform output
 	sc.Step(`^the IAM instance profile from output "([^"]*)" should exist$`, newIAMInstanceProfileFromOutputExistsStep)
-	sc.Step(`^the IAM instance profile from output "([^"]*)" should have role from output "([^"]*)"$`, newIAMInstanceProfileHasRoleFromOutputStep)
+	sc.Step(`^the IAM instance profile from output "([^"]*)" should (?:have|contain) role from output "([^"]*)"$`, newIAMInstanceProfileHasRoleFromOutputStep)
+
+	// Account steps
+	sc.Step(`^the IAM account alias should be "([^"]*)"$`, newIAMAccountAliasStep)
 }
 
 // Permission check step
@@ -86,7 +93,7 @@ func newIAMRoleMaxSessionDurationStep(ctx context.Context, roleName string, dura
 	return iamAssert.AssertRoleMaxSessionDuration(roleName, int32(duration))
 }
 
-func newIAMRoleTagsStep(ctx context.Context, roleName string, table *godog.Table) error {
+func newIAMRoleTagsStep(ctx context.Context, roleName, match string, table *godog.Table) error {
 	expectedTags, err := parseTagsTable(table)
 	if err != nil {
 		return err
@@ -96,7 +103,7 @@ func newIAMRoleTagsStep(ctx context.Context, roleName string, table *godog.Table
 	if err != nil {
 		return err
 	}
-	return iamAssert.AssertRoleTags(roleName, expectedTags)
+	return iamAssert.AssertRoleTags(roleName, expectedTags, tagMatchMode(match))
 }
 
 // Role steps - from Terraform output
@@ -124,12 +131,12 @@ func newIAMRoleFromOutputMaxSessionDurationStep(ctx context.Context, outputName
 	return newIAMRoleMaxSessionDurationStep(ctx, roleName, durationStr)
 }
 
-func newIAMRoleFromOutputTagsStep(ctx context.Context, outputName string, table *godog.Table) error {
+func newIAMRoleFromOutputTagsStep(ctx context.Context, outputName, match string, table *godog.Table) error {
 	roleName, err := getRoleNameFromOutput(ctx, outputName)
 	if err != nil {
 		return err
 	}
-	return newIAMRoleTagsStep(ctx, roleName, table)
+	return newIAMRoleTagsStep(ctx, roleName, match, table)
 }
 
 // Policy steps - direct
@@ -149,6 +156,22 @@ func newIAMPolicyAttachedToRoleStep(ctx context.Context, policyArn, roleName str
 	return iamAssert.AssertPolicyAttachedToRole(roleName, policyArn)
 }
 
+func newIAMPolicyAllowsActionStep(ctx context.Context, policyArn, action, resource string) error {
+	iamAssert, err := getIAMAsserter(ctx)
+	if err != nil {
+		return err
+	}
+	return iamAssert.AssertPolicyAllowsAction(policyArn, action, resource)
+}
+
+func newIAMRoleInlinePolicyAllowsActionStep(ctx context.Context, roleName, policyName, action, resource string) error {
+	iamAssert, err := getIAMAsserter(ctx)
+	if err != nil {
+		return err
+	}
+	return iamAssert.AssertRoleInlinePolicyAllowsAction(roleName, policyName, action, resource)
+}
+
 // Policy steps - from Terraform output
 func newIAMPolicyFromOutputExistsStep(ctx context.Context, outputName string) error {
 	policyArn, err := getPolicyArnFromOutput(ctx, outputName)
@@ -158,6 +181,14 @@ func newIAMPolicyFromOutputExistsStep(ctx context.Context, outputName string) er
 	return newIAMPolicyExistsStep(ctx, policyArn)
 }
 
+func newIAMPolicyFromOutputAllowsActionStep(ctx context.Context, outputName, action, resource string) error {
+	policyArn, err := getPolicyArnFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newIAMPolicyAllowsActionStep(ctx, policyArn, action, resource)
+}
+
 func newIAMPolicyAttachedToRoleFromOutputStep(ctx context.Context, policyOutputName, roleOutputName string) error {
 	policyArn, err := getPolicyArnFromOutput(ctx, policyOutputName)
 	if err != nil {
@@ -208,6 +239,15 @@ func newIAMInstanceProfileHasRoleFromOutputStep(ctx context.Context, profileOutp
 	return newIAMInstanceProfileHasRoleStep(ctx, instanceProfileName, roleName)
 }
 
+// Account steps
+func newIAMAccountAliasStep(ctx context.Context, expectedAlias string) error {
+	iamAssert, err := getIAMAsserter(ctx)
+	if err != nil {
+		return err
+	}
+	return iamAssert.AssertAccountAlias(expectedAlias)
+}
+
 // Helper functions
 
 func getIAMAsserter(ctx context.Context) (aws.IAMAsserter, error) {
@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/cucumber/godog"
 
@@ -10,10 +11,11 @@ import (
 	"github.com/robmorgan/infraspec/pkg/assertions"
 	"github.com/robmorgan/infraspec/pkg/assertions/aws"
 	"github.com/robmorgan/infraspec/pkg/iacprovisioner"
+	"github.com/robmorgan/infraspec/pkg/steps/registry"
 )
 
 // Lambda Step Definitions
-func registerLambdaSteps(sc *godog.ScenarioContext) {
+func registerLambdaSteps(sc registry.StepRegistrar) {
 	// Basic existence - direct name
 	sc.Step(`^the Lambda function "([^"]*)" should exist$`, newLambdaFunctionExistsStep)
 	sc.Step(`^the Lambda function "([^"]*)" should not exist$`, newLambdaFunctionNotExistsStep)
@@ -28,6 +30,7 @@ func registerLambdaSteps(sc *godog.ScenarioContext) {
 	sc.Step(`^the Lambda function "([^"]*)" timeout should be (\d+) seconds$`, newLambdaFunctionTimeoutStep)
 	sc.Step(`^the Lambda function "([^"]*)" memory should be (\d+) MB$`, newLambdaFunctionMemoryStep)
 	sc.Step(`^the Lambda function "([^"]*)" should have environment variable "([^"]*)" with value "([^"]*)"$`, newLambdaFunctionEnvVarStep)
+	sc.Step(`^the Lambda function "([^"]*)" environment variable "([^"]*)" should be "([^"]*)"$`, newLambdaFunctionEnvVarStep)
 
 	// Configuration - from output
 	sc.Step(`^the Lambda function from output "([^"]*)" runtime should be "([^"]*)"$`, newLambdaFunctionFromOutputRuntimeStep)
@@ -35,6 +38,7 @@ func registerLambdaSteps(sc *godog.ScenarioContext) {
 	sc.Step(`^the Lambda function from output "([^"]*)" timeout should be (\d+) seconds$`, newLambdaFunctionFromOutputTimeoutStep)
 	sc.Step(`^the Lambda function from output "([^"]*)" memory should be (\d+) MB$`, newLambdaFunctionFromOutputMemoryStep)
 	sc.Step(`^the Lambda function from output "([^"]*)" should have environment variable "([^"]*)" with value "([^"]*)"$`, newLambdaFunctionFromOutputEnvVarStep)
+	sc.Step(`^the Lambda function from output "([^"]*)" environment variable "([^"]*)" should be "([^"]*)"$`, newLambdaFunctionFromOutputEnvVarStep)
 
 	// Versions & Aliases - direct name
 	sc.Step(`^the Lambda function "([^"]*)" version "([^"]*)" should exist$`, newLambdaFunctionVersionExistsStep)
@@ -59,11 +63,21 @@ func registerLambdaSteps(sc *godog.ScenarioContext) {
 	// Layers - from output
 	sc.Step(`^the Lambda function from output "([^"]*)" should have layer "([^"]*)"$`, newLambdaFunctionFromOutputLayerStep)
 
+	// Invocation
+	sc.Step(`^I invoke the Lambda function "([^"]*)" with payload "([^"]*)"$`, newInvokeLambdaFunctionStep)
+	sc.Step(`^I invoke the Lambda function "([^"]*)" with payload:$`, newInvokeLambdaFunctionWithDocStringStep)
+	sc.Step(`^I invoke the Lambda function from output "([^"]*)" with payload "([^"]*)"$`, newInvokeLambdaFunctionFromOutputStep)
+	sc.Step(`^the Lambda response should contain "([^"]*)"$`, newLambdaResponseContainsStep)
+
 	// Event Source Mappings
 	sc.Step(`^the event source mapping "([^"]*)" should exist$`, newEventSourceMappingExistsStep)
 	sc.Step(`^the event source mapping from output "([^"]*)" should exist$`, newEventSourceMappingFromOutputExistsStep)
 }
 
+// lambdaResponseVariable is the scenario variable the invoke steps store the response payload
+// in, so later steps can check it or reference it as ${lambda_response}
+const lambdaResponseVariable = "lambda_response"
+
 // Helper function to get Lambda asserter
 func getLambdaAsserter(ctx context.Context) (aws.LambdaAsserter, error) {
 	asserter, err := contexthelpers.GetAsserter(ctx, assertions.AWS)
@@ -316,3 +330,48 @@ func newEventSourceMappingFromOutputExistsStep(ctx context.Context, outputName s
 	}
 	return newEventSourceMappingExistsStep(ctx, uuid)
 }
+
+// Invocation steps
+
+func newInvokeLambdaFunctionStep(ctx context.Context, functionName, payload string) error {
+	lambdaAssert, err := getLambdaAsserter(ctx)
+	if err != nil {
+		return err
+	}
+
+	response, err := lambdaAssert.InvokeFunction(functionName, payload)
+	if err != nil {
+		return err
+	}
+	return storeVariable(ctx, lambdaResponseVariable, response)
+}
+
+// newInvokeLambdaFunctionWithDocStringStep invokes the function with a payload given as a doc
+// string, for JSON payloads, whose quotes can't be written in the step text
+func newInvokeLambdaFunctionWithDocStringStep(ctx context.Context, functionName string, doc *godog.DocString) error {
+	return newInvokeLambdaFunctionStep(ctx, functionName, doc.Content)
+}
+
+func newInvokeLambdaFunctionFromOutputStep(ctx context.Context, outputName, payload string) error {
+	functionName, err := getFunctionNameFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newInvokeLambdaFunctionStep(ctx, functionName, payload)
+}
+
+func newLambdaResponseContainsStep(ctx context.Context, expected string) error {
+	store := contexthelpers.GetScenarioStore(ctx)
+	if store == nil {
+		return fmt.Errorf("no scenario store available to read the Lambda response from")
+	}
+	response, ok := store.Get(lambdaResponseVariable)
+	if !ok {
+		return fmt.Errorf("no Lambda function has been invoked in this scenario")
+	}
+
+	if !strings.Contains(response, expected) {
+		return fmt.Errorf("expected the Lambda response to contain %q, but got %s", expected, response)
+	}
+	return nil
+}
@@ -11,10 +11,11 @@ import (
 	"github.com/robmorgan/infraspec/pkg/assertions"
 	"github.com/robmorgan/infraspec/pkg/assertions/aws"
 	"github.com/robmorgan/infraspec/pkg/iacprovisioner"
+	"github.com/robmorgan/infraspec/pkg/steps/registry"
 )
 
 // RDS Step Definitions
-func registerRDSSteps(sc *godog.ScenarioContext) {
+func registerRDSSteps(sc registry.StepRegistrar) {
 	sc.Step(`^I have access to AWS RDS service$`, newVerifyAWSRDSAccessStep)
 	sc.Step(`^I have the necessary IAM permissions to describe RDS instances$`, newVerifyAWSRDSDescribeInstancesStep)
 	sc.Step(`^I describe the RDS instance$`, newRDSDescribeInstanceStep)
@@ -26,7 +27,7 @@ func registerRDSSteps(sc *godog.ScenarioContext) {
 	sc.Step(`^the RDS instance "([^"]*)" MultiAZ should be "(true|false)"$`, newRDSInstanceMultiAZStep)
 	sc.Step(`^the RDS instance "([^"]*)" encryption should be "(true|false)"$`, newRDSInstanceEncryptionStep)
 	sc.Step(`^the RDS instance "([^"]*)" should not be publicly accessible$`, newRDSInstanceNotPubliclyAccessibleStep)
-	sc.Step(`^the RDS instance "([^"]*)" should have the tags$`, newRDSInstanceTagsStep)
+	sc.Step(`^the RDS instance "([^"]*)" should have (at least |exactly )?the tags$`, newRDSInstanceTagsStep)
 
 	// Steps that read DB instance identifier from Terraform output
 	sc.Step(`^the RDS instance from output "([^"]*)" should exist$`, newRDSInstanceFromOutputExistsStep)
@@ -37,7 +38,7 @@ func registerRDSSteps(sc *godog.ScenarioContext) {
 	sc.Step(`^the RDS instance from output "([^"]*)" MultiAZ should be "(true|false)"$`, newRDSInstanceFromOutputMultiAZStep)
 	sc.Step(`^the RDS instance from output "([^"]*)" encryption should be "(true|false)"$`, newRDSInstanceFromOutputEncryptionStep)
 	sc.Step(`^the RDS instance from output "([^"]*)" should not be publicly accessible$`, newRDSInstanceFromOutputNotPubliclyAccessibleStep)
-	sc.Step(`^the RDS instance from output "([^"]*)" should have the tags$`, newRDSInstanceFromOutputTagsStep)
+	sc.Step(`^the RDS instance from output "([^"]*)" should have (at least |exactly )?the tags$`, newRDSInstanceFromOutputTagsStep)
 }
 
 func newVerifyAWSRDSAccessStep(ctx context.Context) error {
@@ -183,7 +184,7 @@ func newRDSInstanceNotPubliclyAccessibleStep(ctx context.Context, dbInstance str
 	return rdsAssert.AssertDBInstancePubliclyAccessible(dbInstance, false, region)
 }
 
-func newRDSInstanceTagsStep(ctx context.Context, dbInstanceID string, table *godog.Table) error {
+func newRDSInstanceTagsStep(ctx context.Context, dbInstanceID, match string, table *godog.Table) error {
 	rdsAssert, err := getRdsAsserter(ctx)
 	if err != nil {
 		return err
@@ -200,7 +201,7 @@ func newRDSInstanceTagsStep(ctx context.Context, dbInstanceID string, table *god
 		return fmt.Errorf("no AWS region available")
 	}
 
-	return rdsAssert.AssertDBInstanceTags(dbInstanceID, tags, region)
+	return rdsAssert.AssertDBInstanceTags(dbInstanceID, tags, tagMatchMode(match), region)
 }
 
 func getRdsAsserter(ctx context.Context) (aws.RDSAsserter, error) {
@@ -282,12 +283,12 @@ func newRDSInstanceFromOutputNotPubliclyAccessibleStep(ctx context.Context, outp
 	return newRDSInstanceNotPubliclyAccessibleStep(ctx, dbInstanceID)
 }
 
-func newRDSInstanceFromOutputTagsStep(ctx context.Context, outputName string, table *godog.Table) error {
+func newRDSInstanceFromOutputTagsStep(ctx context.Context, outputName, match string, table *godog.Table) error {
 	dbInstanceID, err := getDBInstanceIDFromOutput(ctx, outputName)
 	if err != nil {
 		return err
 	}
-	return newRDSInstanceTagsStep(ctx, dbInstanceID, table)
+	return newRDSInstanceTagsStep(ctx, dbInstanceID, match, table)
 }
 
 // Helper function to get DB instance identifier from Terraform output
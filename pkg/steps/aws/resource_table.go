@@ -0,0 +1,88 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cucumber/godog"
+)
+
+// parseResourceTable reads a data table listing one resource per row. The header row names
+// the columns, matched case-insensitively. keyColumn identifies each resource and is required;
+// the other allowed columns are optional, and empty cells are not checked.
+func parseResourceTable(table *godog.Table, keyColumn string, optionalColumns ...string) ([]map[string]string, error) {
+	if table == nil || len(table.Rows) < 2 {
+		return nil, fmt.Errorf("resource table must have a header and at least one data row")
+	}
+
+	allowed := map[string]bool{keyColumn: true}
+	for _, column := range optionalColumns {
+		allowed[column] = true
+	}
+
+	header := make([]string, len(table.Rows[0].Cells))
+	hasKey := false
+	for i, cell := range table.Rows[0].Cells {
+		column := strings.ToLower(strings.TrimSpace(cell.Value))
+		if !allowed[column] {
+			return nil, fmt.Errorf("unknown column %q, expected %s", cell.Value, strings.Join(append([]string{keyColumn}, optionalColumns...), ", "))
+		}
+		header[i] = column
+		hasKey = hasKey || column == keyColumn
+	}
+	if !hasKey {
+		return nil, fmt.Errorf("resource table must have a %q column", keyColumn)
+	}
+
+	rows := make([]map[string]string, 0, len(table.Rows)-1)
+	for _, row := range table.Rows[1:] {
+		values := make(map[string]string, len(header))
+		for i, cell := range row.Cells {
+			if i < len(header) {
+				values[header[i]] = strings.TrimSpace(cell.Value)
+			}
+		}
+		if values[keyColumn] == "" {
+			return nil, fmt.Errorf("every row must have a %s", keyColumn)
+		}
+		rows = append(rows, values)
+	}
+	return rows, nil
+}
+
+// assertEachResource runs check against every row and reports all of the failures together,
+// so a single step shows every resource that doesn't match rather than stopping at the first.
+func assertEachResource(kind string, rows []map[string]string, keyColumn string, check func(row map[string]string) error) error {
+	var failures []error
+	for _, row := range rows {
+		if err := check(row); err != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", row[keyColumn], err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%d of %d %s failed:\n%w", len(failures), len(rows), kind, errors.Join(failures...))
+	}
+	return nil
+}
+
+// columnCheck asserts the value of one optional column of a resource table
+type columnCheck struct {
+	column string
+	check  func(value string) error
+}
+
+// checkResourceColumns runs the checks for the columns that have a value in the row, and
+// returns all of the failures together
+func checkResourceColumns(row map[string]string, checks ...columnCheck) error {
+	var failures []error
+	for _, c := range checks {
+		if value := row[c.column]; value != "" {
+			if err := c.check(value); err != nil {
+				failures = append(failures, err)
+			}
+		}
+	}
+	return errors.Join(failures...)
+}
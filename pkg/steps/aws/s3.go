@@ -3,6 +3,8 @@ package aws
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"strconv"
 
 	"github.com/cucumber/godog"
 
@@ -10,23 +12,49 @@ import (
 	"github.com/robmorgan/infraspec/pkg/assertions"
 	"github.com/robmorgan/infraspec/pkg/assertions/aws"
 	"github.com/robmorgan/infraspec/pkg/iacprovisioner"
+	"github.com/robmorgan/infraspec/pkg/steps/registry"
 )
 
 // S3 Step Definitions
-func registerS3Steps(sc *godog.ScenarioContext) {
+func registerS3Steps(sc registry.StepRegistrar) {
 	sc.Step(`^I have the necessary IAM permissions to describe S3 buckets$`, newVerifyAWSS3DescribeBucketsStep)
 	sc.Step(`^the S3 bucket "([^"]*)" should exist$`, newS3BucketExistsStep)
 	sc.Step(`^the S3 bucket "([^"]*)" should have a versioning configuration$`, newS3BucketVersioningStep)
 	sc.Step(`^the S3 bucket "([^"]*)" should have a public access block$`, newS3BucketPublicAccessBlockStep)
+	sc.Step(`^the S3 bucket "([^"]*)" should block all public access$`, newS3BucketBlocksAllPublicAccessStep)
+	sc.Step(`^the S3 bucket "([^"]*)" should restrict public buckets$`, newS3BucketRestrictsPublicBucketsStep)
 	sc.Step(`^the S3 bucket "([^"]*)" should have a server access logging configuration$`, newS3BucketServerAccessLoggingStep)
+	sc.Step(`^the S3 bucket "([^"]*)" logging should target bucket "([^"]*)" with prefix "([^"]*)"$`, newS3BucketLoggingTargetStep)
+	sc.Step(`^the S3 bucket "([^"]*)" should not have logging enabled$`, newS3BucketLoggingDisabledStep)
 	sc.Step(`^the S3 bucket "([^"]*)" should have an encryption configuration$`, newS3BucketEncryptionStep)
+	sc.Step(`^the S3 bucket "([^"]*)" policy should allow "([^"]*)"(?: for principal "([^"]*)")?$`, newS3BucketPolicyAllowsStep)
+	sc.Step(`^the S3 bucket "([^"]*)" policy should deny public access$`, newS3BucketPolicyDeniesPublicAccessStep)
+	sc.Step(`^the following S3 buckets should exist:$`, newS3BucketsExistStep)
+	sc.Step(`^the S3 bucket "([^"]*)" object "([^"]*)" should match the file "([^"]*)"( ignoring whitespace)?$`, newS3ObjectMatchesFileStep)
+	sc.Step(`^the S3 bucket "([^"]*)" object "([^"]*)" storage class should be "([^"]*)"$`, newS3ObjectStorageClassStep)
+	sc.Step(`^the S3 bucket "([^"]*)" should have a lifecycle rule transitioning to "([^"]*)" after (\d+) days$`, newS3BucketLifecycleTransitionStep)
+	sc.Step(`^the S3 bucket "([^"]*)" should have the same objects as bucket "([^"]*)"( with the same ETags)?$`, newS3BucketsHaveSameObjectsStep)
 
 	// Steps that read bucket name from Terraform output
 	sc.Step(`^the S3 bucket from output "([^"]*)" should exist$`, newS3BucketFromOutputExistsStep)
 	sc.Step(`^the S3 bucket from output "([^"]*)" should have a versioning configuration$`, newS3BucketFromOutputVersioningStep)
 	sc.Step(`^the S3 bucket from output "([^"]*)" should have a public access block$`, newS3BucketFromOutputPublicAccessBlockStep)
+	sc.Step(`^the S3 bucket from output "([^"]*)" should block all public access$`, newS3BucketFromOutputBlocksAllPublicAccessStep)
+	sc.Step(`^the S3 bucket from output "([^"]*)" should restrict public buckets$`, newS3BucketFromOutputRestrictsPublicBucketsStep)
 	sc.Step(`^the S3 bucket from output "([^"]*)" should have a server access logging configuration$`, newS3BucketFromOutputServerAccessLoggingStep)
+	sc.Step(`^the S3 bucket from output "([^"]*)" logging should target bucket "([^"]*)" with prefix "([^"]*)"$`, newS3BucketFromOutputLoggingTargetStep)
+	sc.Step(`^the S3 bucket from output "([^"]*)" should not have logging enabled$`, newS3BucketFromOutputLoggingDisabledStep)
 	sc.Step(`^the S3 bucket from output "([^"]*)" should have an encryption configuration$`, newS3BucketFromOutputEncryptionStep)
+	sc.Step(`^the S3 bucket from output "([^"]*)" policy should allow "([^"]*)"(?: for principal "([^"]*)")?$`, newS3BucketFromOutputPolicyAllowsStep)
+	sc.Step(`^the S3 bucket from output "([^"]*)" policy should deny public access$`, newS3BucketFromOutputPolicyDeniesPublicAccessStep)
+	sc.Step(`^the S3 bucket from output "([^"]*)" object "([^"]*)" should match the file "([^"]*)"( ignoring whitespace)?$`, newS3ObjectFromOutputMatchesFileStep)
+	sc.Step(`^the S3 bucket from output "([^"]*)" object "([^"]*)" storage class should be "([^"]*)"$`, newS3ObjectFromOutputStorageClassStep)
+	sc.Step(`^the S3 bucket from output "([^"]*)" should have a lifecycle rule transitioning to "([^"]*)" after (\d+) days$`, newS3BucketFromOutputLifecycleTransitionStep)
+	sc.Step(`^the S3 bucket from output "([^"]*)" should have the same objects as bucket from output "([^"]*)"( with the same ETags)?$`, newS3BucketsFromOutputHaveSameObjectsStep)
+
+	// Capture steps storing an attribute in a scenario variable
+	sc.Step(`^I store the S3 bucket "([^"]*)" (region|versioning status) as "([^"]*)"$`, newStoreS3BucketAttributeStep)
+	sc.Step(`^I store the S3 bucket from output "([^"]*)" (region|versioning status) as "([^"]*)"$`, newStoreS3BucketFromOutputAttributeStep)
 }
 
 func newVerifyAWSS3DescribeBucketsStep(ctx context.Context) error {
@@ -61,6 +89,22 @@ func newS3BucketPublicAccessBlockStep(ctx context.Context, bucketName string) er
 	return s3Assert.AssertBucketPublicAccessBlock(bucketName)
 }
 
+func newS3BucketBlocksAllPublicAccessStep(ctx context.Context, bucketName string) error {
+	s3Assert, err := getS3Asserter(ctx)
+	if err != nil {
+		return err
+	}
+	return s3Assert.AssertBucketBlocksAllPublicAccess(bucketName)
+}
+
+func newS3BucketRestrictsPublicBucketsStep(ctx context.Context, bucketName string) error {
+	s3Assert, err := getS3Asserter(ctx)
+	if err != nil {
+		return err
+	}
+	return s3Assert.AssertBucketRestrictsPublicBuckets(bucketName)
+}
+
 func newS3BucketServerAccessLoggingStep(ctx context.Context, bucketName string) error {
 	s3Assert, err := getS3Asserter(ctx)
 	if err != nil {
@@ -69,6 +113,22 @@ func newS3BucketServerAccessLoggingStep(ctx context.Context, bucketName string)
 	return s3Assert.AssertBucketServerAccessLogging(bucketName)
 }
 
+func newS3BucketLoggingTargetStep(ctx context.Context, bucketName, targetBucket, targetPrefix string) error {
+	s3Assert, err := getS3Asserter(ctx)
+	if err != nil {
+		return err
+	}
+	return s3Assert.AssertBucketLoggingTarget(bucketName, targetBucket, targetPrefix)
+}
+
+func newS3BucketLoggingDisabledStep(ctx context.Context, bucketName string) error {
+	s3Assert, err := getS3Asserter(ctx)
+	if err != nil {
+		return err
+	}
+	return s3Assert.AssertBucketLoggingDisabled(bucketName)
+}
+
 func newS3BucketEncryptionStep(ctx context.Context, bucketName string) error {
 	s3Assert, err := getS3Asserter(ctx)
 	if err != nil {
@@ -77,6 +137,95 @@ func newS3BucketEncryptionStep(ctx context.Context, bucketName string) error {
 	return s3Assert.AssertBucketEncryption(bucketName)
 }
 
+func newS3BucketPolicyAllowsStep(ctx context.Context, bucketName, action, principal string) error {
+	s3Assert, err := getS3Asserter(ctx)
+	if err != nil {
+		return err
+	}
+	return s3Assert.AssertBucketPolicyAllows(bucketName, action, principal)
+}
+
+func newS3BucketPolicyDeniesPublicAccessStep(ctx context.Context, bucketName string) error {
+	s3Assert, err := getS3Asserter(ctx)
+	if err != nil {
+		return err
+	}
+	return s3Assert.AssertBucketPolicyDeniesPublicAccess(bucketName)
+}
+
+// newS3ObjectMatchesFileStep compares the object with a file, resolved relative to the feature file
+func newS3ObjectMatchesFileStep(ctx context.Context, bucketName, key, filePath, ignoringWhitespace string) error {
+	s3Assert, err := getS3Asserter(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !filepath.IsAbs(filePath) {
+		filePath = filepath.Join(filepath.Dir(contexthelpers.GetUri(ctx)), filePath)
+	}
+	return s3Assert.AssertObjectMatchesFile(bucketName, key, filePath, ignoringWhitespace != "")
+}
+
+func newS3ObjectStorageClassStep(ctx context.Context, bucketName, key, storageClass string) error {
+	s3Assert, err := getS3Asserter(ctx)
+	if err != nil {
+		return err
+	}
+	return s3Assert.AssertObjectStorageClass(bucketName, key, storageClass)
+}
+
+func newS3BucketLifecycleTransitionStep(ctx context.Context, bucketName, storageClass string, days int32) error {
+	s3Assert, err := getS3Asserter(ctx)
+	if err != nil {
+		return err
+	}
+	return s3Assert.AssertBucketLifecycleTransition(bucketName, storageClass, days)
+}
+
+func newS3BucketsHaveSameObjectsStep(ctx context.Context, bucketName, otherBucketName, withSameETags string) error {
+	s3Assert, err := getS3Asserter(ctx)
+	if err != nil {
+		return err
+	}
+	return s3Assert.AssertBucketsHaveSameObjects(bucketName, otherBucketName, withSameETags != "")
+}
+
+// newS3BucketsExistStep checks every bucket in the table, with optional "region" and
+// "encryption" columns, and reports all of the failures together
+func newS3BucketsExistStep(ctx context.Context, table *godog.Table) error {
+	s3Assert, err := getS3Asserter(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := parseResourceTable(table, "name", "region", "encryption")
+	if err != nil {
+		return err
+	}
+
+	return assertEachResource("S3 buckets", rows, "name", func(row map[string]string) error {
+		bucketName := row["name"]
+		if err := s3Assert.AssertBucketExists(bucketName); err != nil {
+			return err
+		}
+		return checkResourceColumns(row,
+			columnCheck{"region", func(region string) error {
+				return s3Assert.AssertBucketRegion(bucketName, region)
+			}},
+			columnCheck{"encryption", func(value string) error {
+				encrypted, err := strconv.ParseBool(value)
+				if err != nil {
+					return fmt.Errorf("encryption must be true or false, got %q", value)
+				}
+				if !encrypted {
+					return nil
+				}
+				return s3Assert.AssertBucketEncryption(bucketName)
+			}},
+		)
+	})
+}
+
 func getS3Asserter(ctx context.Context) (aws.S3Asserter, error) {
 	asserter, err := contexthelpers.GetAsserter(ctx, assertions.AWS)
 	if err != nil {
@@ -116,6 +265,22 @@ func newS3BucketFromOutputPublicAccessBlockStep(ctx context.Context, outputName
 	return newS3BucketPublicAccessBlockStep(ctx, bucketName)
 }
 
+func newS3BucketFromOutputBlocksAllPublicAccessStep(ctx context.Context, outputName string) error {
+	bucketName, err := getBucketNameFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newS3BucketBlocksAllPublicAccessStep(ctx, bucketName)
+}
+
+func newS3BucketFromOutputRestrictsPublicBucketsStep(ctx context.Context, outputName string) error {
+	bucketName, err := getBucketNameFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newS3BucketRestrictsPublicBucketsStep(ctx, bucketName)
+}
+
 func newS3BucketFromOutputServerAccessLoggingStep(ctx context.Context, outputName string) error {
 	bucketName, err := getBucketNameFromOutput(ctx, outputName)
 	if err != nil {
@@ -124,6 +289,22 @@ func newS3BucketFromOutputServerAccessLoggingStep(ctx context.Context, outputNam
 	return newS3BucketServerAccessLoggingStep(ctx, bucketName)
 }
 
+func newS3BucketFromOutputLoggingTargetStep(ctx context.Context, outputName, targetBucket, targetPrefix string) error {
+	bucketName, err := getBucketNameFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newS3BucketLoggingTargetStep(ctx, bucketName, targetBucket, targetPrefix)
+}
+
+func newS3BucketFromOutputLoggingDisabledStep(ctx context.Context, outputName string) error {
+	bucketName, err := getBucketNameFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newS3BucketLoggingDisabledStep(ctx, bucketName)
+}
+
 func newS3BucketFromOutputEncryptionStep(ctx context.Context, outputName string) error {
 	bucketName, err := getBucketNameFromOutput(ctx, outputName)
 	if err != nil {
@@ -132,6 +313,79 @@ func newS3BucketFromOutputEncryptionStep(ctx context.Context, outputName string)
 	return newS3BucketEncryptionStep(ctx, bucketName)
 }
 
+func newS3BucketFromOutputPolicyAllowsStep(ctx context.Context, outputName, action, principal string) error {
+	bucketName, err := getBucketNameFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newS3BucketPolicyAllowsStep(ctx, bucketName, action, principal)
+}
+
+func newS3BucketFromOutputPolicyDeniesPublicAccessStep(ctx context.Context, outputName string) error {
+	bucketName, err := getBucketNameFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newS3BucketPolicyDeniesPublicAccessStep(ctx, bucketName)
+}
+
+func newS3ObjectFromOutputMatchesFileStep(ctx context.Context, outputName, key, filePath, ignoringWhitespace string) error {
+	bucketName, err := getBucketNameFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newS3ObjectMatchesFileStep(ctx, bucketName, key, filePath, ignoringWhitespace)
+}
+
+func newS3ObjectFromOutputStorageClassStep(ctx context.Context, outputName, key, storageClass string) error {
+	bucketName, err := getBucketNameFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newS3ObjectStorageClassStep(ctx, bucketName, key, storageClass)
+}
+
+func newS3BucketFromOutputLifecycleTransitionStep(ctx context.Context, outputName, storageClass string, days int32) error {
+	bucketName, err := getBucketNameFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newS3BucketLifecycleTransitionStep(ctx, bucketName, storageClass, days)
+}
+
+func newS3BucketsFromOutputHaveSameObjectsStep(ctx context.Context, outputName, otherOutputName, withSameETags string) error {
+	bucketName, err := getBucketNameFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	otherBucketName, err := getBucketNameFromOutput(ctx, otherOutputName)
+	if err != nil {
+		return err
+	}
+	return newS3BucketsHaveSameObjectsStep(ctx, bucketName, otherBucketName, withSameETags)
+}
+
+func newStoreS3BucketAttributeStep(ctx context.Context, bucketName, attribute, variable string) error {
+	s3Assert, err := getS3Asserter(ctx)
+	if err != nil {
+		return err
+	}
+
+	value, err := s3Assert.GetBucketAttribute(bucketName, attribute)
+	if err != nil {
+		return err
+	}
+	return storeVariable(ctx, variable, value)
+}
+
+func newStoreS3BucketFromOutputAttributeStep(ctx context.Context, outputName, attribute, variable string) error {
+	bucketName, err := getBucketNameFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newStoreS3BucketAttributeStep(ctx, bucketName, attribute, variable)
+}
+
 // Helper function to get bucket name from Terraform output
 func getBucketNameFromOutput(ctx context.Context, outputName string) (string, error) {
 	options := contexthelpers.GetIacProvisionerOptions(ctx)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/cucumber/godog"
 
@@ -11,11 +12,16 @@ import (
 	"github.com/robmorgan/infraspec/pkg/assertions"
 	"github.com/robmorgan/infraspec/pkg/assertions/aws"
 	"github.com/robmorgan/infraspec/pkg/iacprovisioner"
+	"github.com/robmorgan/infraspec/pkg/steps/registry"
 )
 
 // SQS Step Definitions
-func registerSQSSteps(sc *godog.ScenarioContext) {
+func registerSQSSteps(sc registry.StepRegistrar) {
 	sc.Step(`^I have the necessary IAM permissions to describe SQS queues$`, newVerifyAWSSQSDescribeQueuesStep)
+	sc.Step(`^I purge the SQS queue "([^"]*)"$`, newPurgeSQSQueueStep)
+	sc.Step(`^I purge the SQS queue from output "([^"]*)"$`, newPurgeSQSQueueFromOutputStep)
+	sc.Step(`^I receive a message from the SQS queue "([^"]*)" within (\d+) seconds? and store it as "([^"]*)"$`, newReceiveSQSMessageStep)
+	sc.Step(`^I receive a message from the SQS queue from output "([^"]*)" within (\d+) seconds? and store it as "([^"]*)"$`, newReceiveSQSMessageFromOutputStep)
 	sc.Step(`^the SQS queue "([^"]*)" should exist$`, newSQSQueueExistsStep)
 	sc.Step(`^the SQS queue "([^"]*)" should have visibility timeout (\d+)$`, newSQSQueueVisibilityTimeoutStep)
 	sc.Step(`^the SQS queue "([^"]*)" should have delay seconds (\d+)$`, newSQSQueueDelaySecondsStep)
@@ -24,9 +30,11 @@ func registerSQSSteps(sc *godog.ScenarioContext) {
 	sc.Step(`^the SQS queue "([^"]*)" should have receive message wait time (\d+)$`, newSQSQueueReceiveMessageWaitTimeStep)
 	sc.Step(`^the SQS queue "([^"]*)" should be a FIFO queue$`, newSQSQueueIsFifoStep)
 	sc.Step(`^the SQS queue "([^"]*)" should have a dead letter queue$`, newSQSQueueHasDeadLetterQueueStep)
-	sc.Step(`^the SQS queue "([^"]*)" should have tags$`, newSQSQueueTagsStep)
+	sc.Step(`^the SQS queue "([^"]*)" should have (at least |exactly )?(?:the )?tags$`, newSQSQueueTagsStep)
 	sc.Step(`^the SQS queue "([^"]*)" should be encrypted$`, newSQSQueueEncryptedStep)
 	sc.Step(`^the SQS queue "([^"]*)" should not be encrypted$`, newSQSQueueNotEncryptedStep)
+	sc.Step(`^the SQS queue "([^"]*)" should have (\d+) messages?$`, newSQSQueueMessageCountStep)
+	sc.Step(`^the SQS queue "([^"]*)" should have (\d+) messages? in flight$`, newSQSQueueMessagesInFlightStep)
 
 	// Steps that read queue name from Terraform output
 	sc.Step(`^the SQS queue from output "([^"]*)" should exist$`, newSQSQueueFromOutputExistsStep)
@@ -37,9 +45,11 @@ func registerSQSSteps(sc *godog.ScenarioContext) {
 	sc.Step(`^the SQS queue from output "([^"]*)" should have receive message wait time (\d+)$`, newSQSQueueFromOutputReceiveMessageWaitTimeStep)
 	sc.Step(`^the SQS queue from output "([^"]*)" should be a FIFO queue$`, newSQSQueueFromOutputIsFifoStep)
 	sc.Step(`^the SQS queue from output "([^"]*)" should have a dead letter queue$`, newSQSQueueFromOutputHasDeadLetterQueueStep)
-	sc.Step(`^the SQS queue from output "([^"]*)" should have tags$`, newSQSQueueFromOutputTagsStep)
+	sc.Step(`^the SQS queue from output "([^"]*)" should have (at least |exactly )?(?:the )?tags$`, newSQSQueueFromOutputTagsStep)
 	sc.Step(`^the SQS queue from output "([^"]*)" should be encrypted$`, newSQSQueueFromOutputEncryptedStep)
 	sc.Step(`^the SQS queue from output "([^"]*)" should not be encrypted$`, newSQSQueueFromOutputNotEncryptedStep)
+	sc.Step(`^the SQS queue from output "([^"]*)" should have (\d+) messages?$`, newSQSQueueFromOutputMessageCountStep)
+	sc.Step(`^the SQS queue from output "([^"]*)" should have (\d+) messages? in flight$`, newSQSQueueFromOutputMessagesInFlightStep)
 }
 
 func newVerifyAWSSQSDescribeQueuesStep(ctx context.Context) error {
@@ -50,6 +60,43 @@ func newVerifyAWSSQSDescribeQueuesStep(ctx context.Context) error {
 	return sqsAssert.AssertSQSDescribeQueues()
 }
 
+func newPurgeSQSQueueStep(ctx context.Context, queueName string) error {
+	sqsAssert, err := getSQSAsserter(ctx)
+	if err != nil {
+		return err
+	}
+	return sqsAssert.PurgeQueue(queueName)
+}
+
+func newPurgeSQSQueueFromOutputStep(ctx context.Context, outputName string) error {
+	queueName, err := getQueueNameFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newPurgeSQSQueueStep(ctx, queueName)
+}
+
+func newReceiveSQSMessageStep(ctx context.Context, queueName string, seconds int, name string) error {
+	sqsAssert, err := getSQSAsserter(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, err := sqsAssert.ReceiveMessage(queueName, time.Duration(seconds)*time.Second)
+	if err != nil {
+		return err
+	}
+	return storeVariable(ctx, name, body)
+}
+
+func newReceiveSQSMessageFromOutputStep(ctx context.Context, outputName string, seconds int, name string) error {
+	queueName, err := getQueueNameFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newReceiveSQSMessageStep(ctx, queueName, seconds, name)
+}
+
 func newSQSQueueExistsStep(ctx context.Context, queueName string) error {
 	sqsAssert, err := getSQSAsserter(ctx)
 	if err != nil {
@@ -114,7 +161,7 @@ func newSQSQueueHasDeadLetterQueueStep(ctx context.Context, queueName string) er
 	return sqsAssert.AssertQueueHasDeadLetterQueue(queueName)
 }
 
-func newSQSQueueTagsStep(ctx context.Context, queueName string, table *godog.Table) error {
+func newSQSQueueTagsStep(ctx context.Context, queueName, match string, table *godog.Table) error {
 	sqsAssert, err := getSQSAsserter(ctx)
 	if err != nil {
 		return err
@@ -126,7 +173,7 @@ func newSQSQueueTagsStep(ctx context.Context, queueName string, table *godog.Tab
 		tags[row.Cells[0].Value] = row.Cells[1].Value
 	}
 
-	return sqsAssert.AssertQueueTags(queueName, tags)
+	return sqsAssert.AssertQueueTags(queueName, tags, tagMatchMode(match))
 }
 
 func newSQSQueueEncryptedStep(ctx context.Context, queueName string) error {
@@ -176,6 +223,38 @@ func newSQSQueueFromOutputVisibilityTimeoutStep(ctx context.Context, outputName
 	return newSQSQueueVisibilityTimeoutStep(ctx, queueName, timeout)
 }
 
+func newSQSQueueMessageCountStep(ctx context.Context, queueName string, count int) error {
+	sqsAssert, err := getSQSAsserter(ctx)
+	if err != nil {
+		return err
+	}
+	return sqsAssert.AssertQueueMessageCount(queueName, count)
+}
+
+func newSQSQueueMessagesInFlightStep(ctx context.Context, queueName string, count int) error {
+	sqsAssert, err := getSQSAsserter(ctx)
+	if err != nil {
+		return err
+	}
+	return sqsAssert.AssertQueueMessagesInFlight(queueName, count)
+}
+
+func newSQSQueueFromOutputMessageCountStep(ctx context.Context, outputName string, count int) error {
+	queueName, err := getQueueNameFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newSQSQueueMessageCountStep(ctx, queueName, count)
+}
+
+func newSQSQueueFromOutputMessagesInFlightStep(ctx context.Context, outputName string, count int) error {
+	queueName, err := getQueueNameFromOutput(ctx, outputName)
+	if err != nil {
+		return err
+	}
+	return newSQSQueueMessagesInFlightStep(ctx, queueName, count)
+}
+
 func newSQSQueueFromOutputDelaySecondsStep(ctx context.Context, outputName string, delay int) error {
 	queueName, err := getQueueNameFromOutput(ctx, outputName)
 	if err != nil {
@@ -224,12 +303,12 @@ func newSQSQueueFromOutputHasDeadLetterQueueStep(ctx context.Context, outputName
 	return newSQSQueueHasDeadLetterQueueStep(ctx, queueName)
 }
 
-func newSQSQueueFromOutputTagsStep(ctx context.Context, outputName string, table *godog.Table) error {
+func newSQSQueueFromOutputTagsStep(ctx context.Context, outputName, match string, table *godog.Table) error {
 	queueName, err := getQueueNameFromOutput(ctx, outputName)
 	if err != nil {
 		return err
 	}
-	return newSQSQueueTagsStep(ctx, queueName, table)
+	return newSQSQueueTagsStep(ctx, queueName, match, table)
 }
 
 func newSQSQueueFromOutputEncryptedStep(ctx context.Context, outputName string) error {
@@ -0,0 +1,46 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/robmorgan/infraspec/internal/contexthelpers"
+	"github.com/robmorgan/infraspec/pkg/assertions"
+	"github.com/robmorgan/infraspec/pkg/assertions/aws"
+	"github.com/robmorgan/infraspec/pkg/steps/registry"
+)
+
+// Step Functions Step Definitions
+func registerStepFunctionsSteps(sc registry.StepRegistrar) {
+	sc.Step(`^the Step Functions state machine "([^"]*)" should exist$`, newStateMachineExistsStep)
+	sc.Step(`^the Step Functions state machine "([^"]*)" should have the role "([^"]*)"$`, newStateMachineRoleStep)
+}
+
+func newStateMachineExistsStep(ctx context.Context, name string) error {
+	sfnAssert, err := getStepFunctionsAsserter(ctx)
+	if err != nil {
+		return err
+	}
+	return sfnAssert.AssertStateMachineExists(name)
+}
+
+func newStateMachineRoleStep(ctx context.Context, name, roleArn string) error {
+	sfnAssert, err := getStepFunctionsAsserter(ctx)
+	if err != nil {
+		return err
+	}
+	return sfnAssert.AssertStateMachineRole(name, roleArn)
+}
+
+func getStepFunctionsAsserter(ctx context.Context) (aws.StepFunctionsAsserter, error) {
+	asserter, err := contexthelpers.GetAsserter(ctx, assertions.AWS)
+	if err != nil {
+		return nil, err
+	}
+
+	sfnAssert, ok := asserter.(aws.StepFunctionsAsserter)
+	if !ok {
+		return nil, fmt.Errorf("asserter does not implement StepFunctionsAsserter")
+	}
+	return sfnAssert, nil
+}
@@ -3,8 +3,10 @@ package http
 import (
 	"context"
 	"fmt"
+	"net/http/cookiejar"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/cucumber/godog"
 
@@ -12,15 +14,16 @@ import (
 	"github.com/robmorgan/infraspec/pkg/assertions"
 	httpassert "github.com/robmorgan/infraspec/pkg/assertions/http"
 	"github.com/robmorgan/infraspec/pkg/httphelpers"
+	"github.com/robmorgan/infraspec/pkg/steps/registry"
 )
 
 // RegisterSteps registers all HTTP step definitions
-func RegisterSteps(sc *godog.ScenarioContext) {
+func RegisterSteps(sc registry.StepRegistrar) {
 	registerHTTPSteps(sc)
 }
 
 // HTTP Step Definitions
-func registerHTTPSteps(sc *godog.ScenarioContext) {
+func registerHTTPSteps(sc registry.StepRegistrar) {
 	// Setup steps
 	sc.Step(`^I have a HTTP endpoint at "([^"]*)"$`, newHTTPEndpointStep)
 	sc.Step(`^I set the headers to$`, newSetHeadersStep)
@@ -30,6 +33,7 @@ func registerHTTPSteps(sc *godog.ScenarioContext) {
 	sc.Step(`^I set the request body to "([^"]*)"$`, newSetRequestBodyStep)
 	sc.Step(`^I set basic auth credentials with username "([^"]*)" and password "([^"]*)"$`, newSetBasicAuthCredentialsStep)
 	sc.Step(`^I am authenticated with a valid bearer token$`, newSetBearerTokenFromEnvStep)
+	sc.Step(`^I enable HTTP cookies$`, newEnableCookiesStep)
 
 	// Basic HTTP requests
 	sc.Step(`^I send a ([A-Z]+) request$`, newHTTPRequestStep)
@@ -47,6 +51,13 @@ func registerHTTPSteps(sc *godog.ScenarioContext) {
 
 	// Header assertions
 	sc.Step(`^the HTTP response header "([^"]*)" should be "([^"]*)"$`, newHTTPResponseHeaderStep)
+
+	// Cookie assertions
+	sc.Step(`^the HTTP response should set the cookie "([^"]*)"$`, newHTTPResponseCookieStep)
+	sc.Step(`^the HTTP response should set the cookie "([^"]*)" to "([^"]*)"$`, newHTTPResponseCookieValueStep)
+
+	// Latency assertions
+	sc.Step(`^the HTTP response should be received within (\d+) ms$`, newHTTPResponseTimeStep)
 }
 
 // Basic HTTP request step (uses endpoint from scenario state)
@@ -129,6 +140,45 @@ func newHTTPResponseHeaderStep(ctx context.Context, headerName, expectedValue st
 	return httpAssert.AssertResponseHeader(resp, headerName, expectedValue)
 }
 
+// Response cookie assertion for the last request
+func newHTTPResponseCookieStep(ctx context.Context, cookieName string) error {
+	httpAssert, err := getHTTPAsserter(ctx)
+	if err != nil {
+		return err
+	}
+	resp := contexthelpers.GetHttpResponse(ctx)
+	if resp == nil {
+		return fmt.Errorf("no HTTP response found in context")
+	}
+	return httpAssert.AssertResponseCookie(resp, cookieName)
+}
+
+// Response cookie value assertion for the last request
+func newHTTPResponseCookieValueStep(ctx context.Context, cookieName, expectedValue string) error {
+	httpAssert, err := getHTTPAsserter(ctx)
+	if err != nil {
+		return err
+	}
+	resp := contexthelpers.GetHttpResponse(ctx)
+	if resp == nil {
+		return fmt.Errorf("no HTTP response found in context")
+	}
+	return httpAssert.AssertResponseCookieValue(resp, cookieName, expectedValue)
+}
+
+// Response time assertion for the last request
+func newHTTPResponseTimeStep(ctx context.Context, milliseconds int) error {
+	httpAssert, err := getHTTPAsserter(ctx)
+	if err != nil {
+		return err
+	}
+	resp := contexthelpers.GetHttpResponse(ctx)
+	if resp == nil {
+		return fmt.Errorf("no HTTP response found in context")
+	}
+	return httpAssert.AssertResponseTime(resp, time.Duration(milliseconds)*time.Millisecond)
+}
+
 // Setup step functions
 func newHTTPEndpointStep(ctx context.Context, url string) (context.Context, error) {
 	opts := contexthelpers.GetHttpRequestOptions(ctx)
@@ -202,6 +252,21 @@ func newSetBasicAuthCredentialsStep(ctx context.Context, username, password stri
 	return context.WithValue(ctx, contexthelpers.HttpRequestOptionsCtxKey{}, opts), nil
 }
 
+// newEnableCookiesStep gives the scenario a cookie jar, so the cookies a response sets are sent
+// on the scenario's later requests
+func newEnableCookiesStep(ctx context.Context) (context.Context, error) {
+	opts := contexthelpers.GetHttpRequestOptions(ctx)
+	if opts == nil {
+		opts = &httphelpers.HttpRequestOptions{}
+	}
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return ctx, fmt.Errorf("failed to create cookie jar: %w", err)
+	}
+	opts.CookieJar = jar
+	return context.WithValue(ctx, contexthelpers.HttpRequestOptionsCtxKey{}, opts), nil
+}
+
 func newSetBearerTokenFromEnvStep(ctx context.Context) (context.Context, error) {
 	return NewSetBearerTokenFromEnvStep(ctx)
 }
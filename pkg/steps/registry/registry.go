@@ -0,0 +1,7 @@
+package registry
+
+// StepRegistrar registers step definitions. It is satisfied by *godog.ScenarioContext and lets
+// the runner wrap step handlers before they reach godog.
+type StepRegistrar interface {
+	Step(expr, stepFunc interface{})
+}
@@ -1,15 +1,14 @@
 package steps
 
 import (
-	"github.com/cucumber/godog"
-
 	"github.com/robmorgan/infraspec/pkg/steps/aws"
 	"github.com/robmorgan/infraspec/pkg/steps/http"
+	"github.com/robmorgan/infraspec/pkg/steps/registry"
 	"github.com/robmorgan/infraspec/pkg/steps/terraform"
 )
 
 // RegisterSteps registers all step definitions.
-func RegisterSteps(sc *godog.ScenarioContext) {
+func RegisterSteps(sc registry.StepRegistrar) {
 	// Register Terraform steps
 	terraform.RegisterSteps(sc)
 
@@ -16,10 +16,11 @@ import (
 	"github.com/robmorgan/infraspec/internal/contexthelpers"
 	"github.com/robmorgan/infraspec/pkg/awshelpers"
 	"github.com/robmorgan/infraspec/pkg/iacprovisioner"
+	"github.com/robmorgan/infraspec/pkg/steps/registry"
 )
 
 // RegisterSteps registers all Terraform-specific step definitions
-func RegisterSteps(sc *godog.ScenarioContext) {
+func RegisterSteps(sc registry.StepRegistrar) {
 	sc.Step(`^I run [Tt]erraform apply$`, newTerraformApplyStep)
 	sc.Step(`^the Terraform module at "([^"]*)"$`, newTerraformConfigStep)
 	sc.Step(`^I have a Terraform configuration in "([^"]*)"$`, newTerraformConfigStep)
@@ -32,6 +33,7 @@ func RegisterSteps(sc *godog.ScenarioContext) {
 	sc.Step(`^the "([^"]*)" output is "([^"]*)"$`, newTerraformOutputEqualsStep)
 	sc.Step(`^the output "([^"]*)" should equal "([^"]*)"$`, newTerraformOutputEqualsStep)
 	sc.Step(`^the output "([^"]*)" should contain "([^"]*)"$`, newTerraformOutputContainsStep)
+	sc.Step(`^the Terraform output "([^"]*)" should equal the output "([^"]*)"$`, newTerraformOutputsEqualStep)
 }
 
 func newTerraformConfigStep(ctx context.Context, path string) (context.Context, error) {
@@ -74,6 +76,7 @@ func newTerraformConfigStep(ctx context.Context, path string) (context.Context,
 
 func newTerraformApplyStep(ctx context.Context) (context.Context, error) {
 	options := contexthelpers.GetIacProvisionerOptions(ctx)
+	options.Context = ctx
 	out, err := iacprovisioner.InitAndApply(options)
 	if err != nil {
 		// Include both the error message and output for better debugging
@@ -87,6 +90,9 @@ func newTerraformApplyStep(ctx context.Context) (context.Context, error) {
 
 func NewTerraformDestroyStep(ctx context.Context) (context.Context, error) {
 	options := contexthelpers.GetIacProvisionerOptions(ctx)
+	// Destroy even when the run was interrupted, so an interrupted apply doesn't leave
+	// resources behind
+	options.Context = context.WithoutCancel(ctx)
 	out, err := iacprovisioner.Destroy(options)
 	if err != nil {
 		return ctx, fmt.Errorf("there was an error running terraform destroy: %s", out)
@@ -190,6 +196,25 @@ func newTerraformOutputContainsStep(ctx context.Context, outputName, expectedVal
 	return nil
 }
 
+func newTerraformOutputsEqualStep(ctx context.Context, outputName, otherOutputName string) error {
+	options := contexthelpers.GetIacProvisionerOptions(ctx)
+	actualValue, err := iacprovisioner.Output(options, outputName)
+	if err != nil {
+		return fmt.Errorf("failed to get output %s, got %s: %w", outputName, actualValue, err)
+	}
+
+	otherValue, err := iacprovisioner.Output(options, otherOutputName)
+	if err != nil {
+		return fmt.Errorf("failed to get output %s, got %s: %w", otherOutputName, otherValue, err)
+	}
+
+	// compare normalized values so trailing newlines or padding don't cause false mismatches
+	if strings.TrimSpace(actualValue) != strings.TrimSpace(otherValue) {
+		return fmt.Errorf("expected output %s to equal output %s, got %s and %s", outputName, otherOutputName, actualValue, otherValue)
+	}
+	return nil
+}
+
 // configureVirtualCloudEndpoints sets AWS endpoint environment variables when the embedded
 // emulator is enabled (detected via AWS_ENDPOINT_URL environment variable).
 // This configures Terraform/OpenTofu to use the embedded emulator instead of real AWS.
@@ -207,6 +232,11 @@ func configureVirtualCloudEndpoints(options *iacprovisioner.Options, workingDir
 		return nil
 	}
 
+	// The AWS provider can only connect to endpoints over TCP
+	if path, ok := awshelpers.UnixSocketPath(endpoint); ok {
+		return fmt.Errorf("the embedded emulator is listening on the Unix socket %s, which Terraform/OpenTofu's AWS provider can't connect to; listen on a TCP address like 127.0.0.1:3687 to run Terraform", path)
+	}
+
 	config.Logging.Logger.Infof("Configuring embedded emulator endpoints for Terraform/OpenTofu")
 
 	// Map of AWS SDK service identifiers to subdomain names
@@ -20,6 +20,10 @@ var (
 	gentypesOperations    string
 	gentypesTypeSuffix    string
 	gentypesIncludeInputs bool
+	gentypesGenerateTests bool
+	gentypesWrappers      bool
+	gentypesModelDir      string
+	gentypesManifest      string
 )
 
 // modelsCacheInstance is the global models cache instance
@@ -40,19 +44,36 @@ matching real AWS API responses, rather than PascalCase from Go SDK types.
 By default, only response (output) types are generated. Use --include-inputs
 to also generate request (input) types for type-safe request parsing.
 
+Use --generate-tests to also write a <output>_generated_test.go file with a
+round-trip marshal/unmarshal test for every generated type.
+
+Use --response-wrappers with query protocol services to also generate an
+<Operation>Response envelope type holding the result and ResponseMetadata.
+
+Use --services-from-model-dir to generate every service model found in a
+directory in one run. The service and package names come from each model's
+service shape, and types are written to <services-path>/<package>/smithy_types.go.
+A JSON --manifest can override the output path, package and type suffix per
+model file:
+
+  {"services": [{"model": "ec2-2016-11-15.json", "output": "./ec2/types.go", "suffix": "XML"}]}
+
 Examples:
   cloudmirror gentypes --service=ec2
   cloudmirror gentypes --service=rds --output=./internal/emulator/services/rds/smithy_types.go
   cloudmirror gentypes --service=iam --dry-run
   cloudmirror gentypes --service=ec2 --operations=DescribeInstances,DescribeVpcs
-  cloudmirror gentypes --service=iam --include-inputs`,
+  cloudmirror gentypes --service=iam --include-inputs
+  cloudmirror gentypes --service=sqs --generate-tests
+  cloudmirror gentypes --service=iam --response-wrappers
+  cloudmirror gentypes --services-from-model-dir=./models --manifest=./models/manifest.json`,
 	Run: runGentypes,
 }
 
 func init() {
 	rootCmd.AddCommand(gentypesCmd)
 
-	gentypesCmd.Flags().StringVar(&gentypesService, "service", "", "AWS service name to generate types for [required]")
+	gentypesCmd.Flags().StringVar(&gentypesService, "service", "", "AWS service name to generate types for [required unless --services-from-model-dir is set]")
 	gentypesCmd.Flags().StringVar(&gentypesOutput, "output", "", "Output file path (default: ./internal/emulator/services/<service>/smithy_types.go)")
 	gentypesCmd.Flags().StringVar(&gentypesModelsPath, "models-path", "", "Path to api-models-aws repo (auto-downloaded if not specified)")
 	gentypesCmd.Flags().StringVar(&gentypesProtocol, "protocol", "", "Override protocol detection (ec2, query, rest-xml, json)")
@@ -60,11 +81,21 @@ func init() {
 	gentypesCmd.Flags().StringVar(&gentypesOperations, "operations", "", "Comma-separated list of operations to generate types for (default: all)")
 	gentypesCmd.Flags().StringVar(&gentypesTypeSuffix, "suffix", "", "Suffix to add to generated type names (e.g., 'XML' -> VpcXML)")
 	gentypesCmd.Flags().BoolVar(&gentypesIncludeInputs, "include-inputs", false, "Also generate input types for request parsing")
+	gentypesCmd.Flags().BoolVar(&gentypesGenerateTests, "generate-tests", false, "Also generate a round-trip serialization test for the generated types")
+	gentypesCmd.Flags().BoolVar(&gentypesWrappers, "response-wrappers", false, "Also generate <Operation>Response envelope types with ResponseMetadata (query protocol)")
+	gentypesCmd.Flags().StringVar(&gentypesModelDir, "services-from-model-dir", "", "Generate types for every Smithy model in this directory")
+	gentypesCmd.Flags().StringVar(&gentypesManifest, "manifest", "", "JSON manifest mapping model files to output paths and type suffixes (with --services-from-model-dir)")
 
-	gentypesCmd.MarkFlagRequired("service")
+	gentypesCmd.MarkFlagsMutuallyExclusive("service", "services-from-model-dir")
+	gentypesCmd.MarkFlagsOneRequired("service", "services-from-model-dir")
 }
 
 func runGentypes(cmd *cobra.Command, args []string) {
+	if gentypesModelDir != "" {
+		runGentypesFromModelDir()
+		return
+	}
+
 	// Get models path
 	modelsPath := gentypesModelsPath
 	if modelsPath == "" {
@@ -120,6 +151,9 @@ func runGentypes(cmd *cobra.Command, args []string) {
 		IncludeInputs: gentypesIncludeInputs,
 		Operations:    operations,
 		TypeSuffix:    gentypesTypeSuffix,
+		GenerateTests: gentypesGenerateTests,
+
+		GenerateResponseWrappers: gentypesWrappers,
 	}
 
 	// Create and run generator
@@ -132,6 +166,14 @@ func runGentypes(cmd *cobra.Command, args []string) {
 			os.Exit(1)
 		}
 		fmt.Println(code)
+		if gentypesGenerateTests {
+			testCode, err := generator.GenerateTest()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error generating tests: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println(testCode)
+		}
 		if !quiet {
 			fmt.Fprintf(os.Stderr, "\nDry run complete. Would write to: %s\n", outputPath)
 		}
@@ -146,6 +188,75 @@ func runGentypes(cmd *cobra.Command, args []string) {
 
 	if !quiet {
 		fmt.Fprintf(os.Stderr, "Generated types written to: %s\n", outputPath)
+		if gentypesGenerateTests {
+			fmt.Fprintf(os.Stderr, "Generated tests written to: %s\n", typegen.TestOutputPath(outputPath))
+		}
+	}
+}
+
+// runGentypesFromModelDir generates types for every service model in --services-from-model-dir
+func runGentypesFromModelDir() {
+	var manifest *typegen.Manifest
+	if gentypesManifest != "" {
+		var err error
+		manifest, err = typegen.LoadManifest(gentypesManifest)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	var operations []string
+	if gentypesOperations != "" {
+		operations = strings.Split(gentypesOperations, ",")
+		for i := range operations {
+			operations[i] = strings.TrimSpace(operations[i])
+		}
+	}
+
+	base := typegen.Config{
+		Protocol:      gentypesProtocol,
+		ResponseOnly:  !gentypesIncludeInputs,
+		IncludeInputs: gentypesIncludeInputs,
+		Operations:    operations,
+		TypeSuffix:    gentypesTypeSuffix,
+		GenerateTests: gentypesGenerateTests,
+
+		GenerateResponseWrappers: gentypesWrappers,
+	}
+
+	configs, err := typegen.PlanServicesFromModelDir(gentypesModelDir, servicesPath, manifest, base)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(configs) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no service models found in %s\n", gentypesModelDir)
+		os.Exit(1)
+	}
+
+	failed := 0
+	for _, config := range configs {
+		if gentypesDryRun {
+			if !quiet {
+				fmt.Fprintf(os.Stderr, "%s: %s -> %s\n", config.ServiceName, config.ModelPath, config.OutputPath)
+			}
+			continue
+		}
+
+		if err := typegen.NewGenerator(config).GenerateToFile(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating %s: %v\n", config.ServiceName, err)
+			failed++
+			continue
+		}
+		if !quiet {
+			fmt.Fprintf(os.Stderr, "Generated %s types written to: %s\n", config.ServiceName, config.OutputPath)
+		}
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "Error: %d of %d services failed to generate\n", failed, len(configs))
+		os.Exit(1)
 	}
 }
 
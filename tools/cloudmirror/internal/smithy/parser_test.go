@@ -401,6 +401,25 @@ func TestGetXMLElementName(t *testing.T) {
 	}
 }
 
+func TestGetXMLAttributeName(t *testing.T) {
+	tests := []struct {
+		name     string
+		attr     string
+		expected string
+	}{
+		{name: "unprefixed", attr: "encoding", expected: "encoding"},
+		{name: "xsi prefix", attr: "xsi:type", expected: "http://www.w3.org/2001/XMLSchema-instance type"},
+		{name: "xml prefix", attr: "xml:lang", expected: "http://www.w3.org/XML/1998/namespace lang"},
+		{name: "unknown prefix is kept", attr: "foo:bar", expected: "foo:bar"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, GetXMLAttributeName(tc.attr))
+		})
+	}
+}
+
 func TestIsRequired(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -213,6 +213,11 @@ func (r *Resolver) resolveMember(memberName string, member *Member) (*ResolvedFi
 		}
 	}
 
+	// Attributes (@xmlAttribute) are serialized on the enclosing element, not as child elements
+	if field.IsAttribute {
+		field.XMLTag = GetXMLAttributeName(field.XMLName) + ",attr"
+	}
+
 	// Add omitempty for optional fields
 	if !field.IsRequired && !field.IsAttribute {
 		if !strings.Contains(field.XMLTag, ",") {
@@ -253,7 +258,8 @@ func (r *Resolver) resolveTargetType(targetName string, targetShape *Shape, xmlN
 
 		// Build XML tag for list
 		if xmlTraits.IsFlattened {
-			// Flattened lists don't have wrapper
+			// Flattened lists (@xmlFlattened) don't have a wrapper: each item is an element named
+			// after the structure member, and the list member's xmlName is ignored
 			return goType, xmlName, dependencies
 		}
 
@@ -1,6 +1,8 @@
 // Package smithy provides types and parsing functionality for AWS Smithy 2.0 JSON AST models.
 package smithy
 
+import "strings"
+
 // Model represents the top-level Smithy 2.0 JSON AST format from api-models-aws
 type Model struct {
 	Smithy   string                 `json:"smithy"`
@@ -255,6 +257,26 @@ func GetXMLElementName(memberName string, traits map[string]interface{}, protoco
 	return memberName
 }
 
+// wellKnownXMLNamespaces maps the reserved prefixes used in AWS models to their namespace URIs
+var wellKnownXMLNamespaces = map[string]string{
+	"xml": "http://www.w3.org/XML/1998/namespace",
+	"xsi": "http://www.w3.org/2001/XMLSchema-instance",
+}
+
+// GetXMLAttributeName returns the encoding/xml tag name for an attribute. encoding/xml matches
+// attributes by namespace URI rather than prefix, so a well-known prefix (e.g. "xsi:type") is
+// rewritten to the "<uri> <local>" form.
+func GetXMLAttributeName(name string) string {
+	prefix, local, ok := strings.Cut(name, ":")
+	if !ok {
+		return name
+	}
+	if uri, ok := wellKnownXMLNamespaces[prefix]; ok {
+		return uri + " " + local
+	}
+	return name
+}
+
 // IsRequired checks if a member is required
 func IsRequired(traits map[string]interface{}) bool {
 	_, ok := traits[TraitRequired]
@@ -0,0 +1,148 @@
+package typegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/robmorgan/infraspec/tools/cloudmirror/internal/smithy"
+)
+
+// Manifest overrides the generation settings for individual model files during bulk generation
+type Manifest struct {
+	Services []ManifestEntry `json:"services"`
+}
+
+// ManifestEntry maps a model file to its generation settings. Empty fields fall back to the
+// values inferred from the model's service shape.
+type ManifestEntry struct {
+	Model       string `json:"model"`             // Model file, relative to the model directory (or its base name)
+	Output      string `json:"output,omitempty"`  // Output file path
+	PackageName string `json:"package,omitempty"` // Go package name
+	TypeSuffix  string `json:"suffix,omitempty"`  // Suffix to add to type names
+}
+
+// LoadManifest reads a JSON bulk generation manifest
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	for i, entry := range manifest.Services {
+		if entry.Model == "" {
+			return nil, fmt.Errorf("manifest entry %d has no model", i)
+		}
+	}
+	return &manifest, nil
+}
+
+// lookup returns the entry for a model file, matched by path relative to the model directory
+// or by base name
+func (m *Manifest) lookup(relPath string) (ManifestEntry, bool) {
+	if m == nil {
+		return ManifestEntry{}, false
+	}
+	relPath = filepath.ToSlash(relPath)
+	for _, entry := range m.Services {
+		model := filepath.ToSlash(entry.Model)
+		if model == relPath || model == filepath.Base(relPath) {
+			return entry, true
+		}
+	}
+	return ManifestEntry{}, false
+}
+
+// PlanServicesFromModelDir scans modelDir (recursively) for Smithy JSON models and returns a
+// generator config for each one that defines a service. The service and package names are
+// inferred from the service shape's sdkId (e.g. "Application Auto Scaling" ->
+// "applicationautoscaling") and output goes to <servicesPath>/<package>/smithy_types.go
+// unless the manifest says otherwise. base supplies the remaining settings.
+func PlanServicesFromModelDir(modelDir, servicesPath string, manifest *Manifest, base Config) ([]*Config, error) {
+	var modelPaths []string
+	err := filepath.WalkDir(modelDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.EqualFold(filepath.Ext(path), ".json") {
+			modelPaths = append(modelPaths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan model directory: %w", err)
+	}
+	sort.Strings(modelPaths)
+
+	var configs []*Config
+	outputs := make(map[string]string)
+	for _, modelPath := range modelPaths {
+		parser := smithy.NewParser()
+		if _, err := parser.ParseFile(modelPath); err != nil {
+			return nil, fmt.Errorf("%s: %w", modelPath, err)
+		}
+		info, err := parser.GetServiceInfo()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", modelPath, err)
+		}
+		if info.Name == "" {
+			continue // Not a service model (e.g. a shared shapes file or a manifest)
+		}
+
+		serviceName := ServiceNameFromSDKID(info.FullName)
+		if serviceName == "" {
+			serviceName = strings.ToLower(info.Name)
+		}
+
+		config := base
+		config.ServiceName = serviceName
+		config.PackageName = serviceName
+		config.ModelPath = modelPath
+
+		relPath, err := filepath.Rel(modelDir, modelPath)
+		if err != nil {
+			relPath = filepath.Base(modelPath)
+		}
+		if entry, ok := manifest.lookup(relPath); ok {
+			if entry.PackageName != "" {
+				config.PackageName = entry.PackageName
+			}
+			if entry.TypeSuffix != "" {
+				config.TypeSuffix = entry.TypeSuffix
+			}
+			config.OutputPath = entry.Output
+		}
+		if config.OutputPath == "" {
+			config.OutputPath = filepath.Join(servicesPath, config.PackageName, "smithy_types.go")
+		}
+
+		if other, ok := outputs[config.OutputPath]; ok {
+			return nil, fmt.Errorf("%s and %s would both be written to %s", other, modelPath, config.OutputPath)
+		}
+		outputs[config.OutputPath] = modelPath
+
+		configs = append(configs, &config)
+	}
+
+	return configs, nil
+}
+
+// ServiceNameFromSDKID converts a Smithy sdkId into the Go package name the emulator uses
+// for the service, e.g. "Application Auto Scaling" -> "applicationautoscaling"
+func ServiceNameFromSDKID(sdkID string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(sdkID) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
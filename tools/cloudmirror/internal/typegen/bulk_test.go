@@ -0,0 +1,91 @@
+package typegen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeModelDir(t *testing.T) string {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "ec2"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ec2", "ec2-2016-11-15.json"), []byte(generatorTestModel), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lambda.json"), []byte(strings.Replace(generatorTestModelRESTJSON, `"sdkId": "Test"`, `"sdkId": "Lambda"`, 1)), 0644))
+	// Files without a service shape are skipped
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "shapes.json"), []byte(`{"smithy": "2.0", "shapes": {}}`), 0644))
+	return dir
+}
+
+func TestPlanServicesFromModelDir(t *testing.T) {
+	dir := writeModelDir(t)
+
+	configs, err := PlanServicesFromModelDir(dir, "services", nil, Config{GenerateTests: true})
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+
+	assert.Equal(t, "test", configs[0].ServiceName)
+	assert.Equal(t, filepath.Join(dir, "ec2", "ec2-2016-11-15.json"), configs[0].ModelPath)
+	assert.Equal(t, filepath.Join("services", "test", "smithy_types.go"), configs[0].OutputPath)
+	assert.True(t, configs[0].GenerateTests, "base settings apply to every service")
+	assert.Equal(t, "lambda", configs[1].ServiceName)
+	assert.Equal(t, filepath.Join("services", "lambda", "smithy_types.go"), configs[1].OutputPath)
+
+	// Two models can't be written to the same file
+	manifest := &Manifest{Services: []ManifestEntry{{Model: "lambda.json", PackageName: "test"}}}
+	_, err = PlanServicesFromModelDir(dir, "services", manifest, Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "would both be written to")
+}
+
+func TestPlanServicesFromModelDir_Manifest(t *testing.T) {
+	dir := writeModelDir(t)
+	manifestPath := filepath.Join(t.TempDir(), "manifest.json")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`{
+		"services": [
+			{"model": "ec2/ec2-2016-11-15.json", "output": "out/ec2/types.go", "suffix": "XML"},
+			{"model": "lambda.json", "package": "awslambda"}
+		]
+	}`), 0644))
+
+	manifest, err := LoadManifest(manifestPath)
+	require.NoError(t, err)
+
+	configs, err := PlanServicesFromModelDir(dir, "services", manifest, Config{TypeSuffix: "Default"})
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+
+	assert.Equal(t, "out/ec2/types.go", configs[0].OutputPath)
+	assert.Equal(t, "XML", configs[0].TypeSuffix)
+	assert.Equal(t, "awslambda", configs[1].PackageName)
+	assert.Equal(t, filepath.Join("services", "awslambda", "smithy_types.go"), configs[1].OutputPath)
+	assert.Equal(t, "Default", configs[1].TypeSuffix)
+
+	// Generated configs are ready to use
+	code, err := NewGenerator(configs[0]).Generate()
+	require.NoError(t, err)
+	assert.Contains(t, code, "type DescribeVpcsResultXML struct")
+}
+
+func TestLoadManifest_RequiresModel(t *testing.T) {
+	manifestPath := filepath.Join(t.TempDir(), "manifest.json")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`{"services": [{"output": "x.go"}]}`), 0644))
+
+	_, err := LoadManifest(manifestPath)
+	require.Error(t, err)
+}
+
+func TestServiceNameFromSDKID(t *testing.T) {
+	tests := map[string]string{
+		"Application Auto Scaling":  "applicationautoscaling",
+		"DynamoDB":                  "dynamodb",
+		"EC2":                       "ec2",
+		"Elastic Load Balancing v2": "elasticloadbalancingv2",
+	}
+	for sdkID, want := range tests {
+		assert.Equal(t, want, ServiceNameFromSDKID(sdkID), sdkID)
+	}
+}
@@ -24,6 +24,7 @@ type Generator struct {
 	parser   *smithy.Parser
 	resolver *smithy.Resolver
 	config   *Config
+	data     *TemplateData // Template data from the last Generate call, reused by GenerateTest
 }
 
 // Config holds the generator configuration
@@ -37,6 +38,10 @@ type Config struct {
 	IncludeInputs bool     // Also generate input types for request parsing
 	Operations    []string // Specific operations to generate (empty = all)
 	TypeSuffix    string   // Suffix to add to type names
+	GenerateTests bool     // Also write a round-trip serialization test next to the output file
+	// GenerateResponseWrappers also emits an {Operation}Response envelope for each query
+	// protocol response type, so the full document can be marshaled without BuildQueryResponse
+	GenerateResponseWrappers bool
 }
 
 // NewGenerator creates a new type generator
@@ -84,7 +89,17 @@ func (g *Generator) Generate() (string, error) {
 	return code, nil
 }
 
-// GenerateToFile generates types and writes to the output file
+// GenerateTest generates a table-driven test that marshals a zero-valued and a populated
+// instance of each type produced by the last Generate call and checks it unmarshals back.
+func (g *Generator) GenerateTest() (string, error) {
+	if g.data == nil {
+		return "", fmt.Errorf("Generate must be called before GenerateTest")
+	}
+	return g.renderTemplate("smithy_types_test.go.tmpl", g.data)
+}
+
+// GenerateToFile generates types and writes to the output file. When GenerateTests is set,
+// the round-trip test is written alongside it (see TestOutputPath).
 func (g *Generator) GenerateToFile() error {
 	code, err := g.Generate()
 	if err != nil {
@@ -102,9 +117,25 @@ func (g *Generator) GenerateToFile() error {
 		return fmt.Errorf("failed to write output file: %w", err)
 	}
 
+	if g.config.GenerateTests {
+		testCode, err := g.GenerateTest()
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(TestOutputPath(g.config.OutputPath), []byte(testCode), 0644); err != nil {
+			return fmt.Errorf("failed to write test file: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// TestOutputPath returns the path of the generated round-trip test for a types file,
+// e.g. smithy_types.go -> smithy_types_generated_test.go.
+func TestOutputPath(outputPath string) string {
+	return strings.TrimSuffix(outputPath, ".go") + "_generated_test.go"
+}
+
 // collectTypesToGenerate determines which types need to be generated
 func (g *Generator) collectTypesToGenerate() ([]string, error) {
 	operations := g.parser.GetOperations()
@@ -195,7 +226,24 @@ type TemplateData struct {
 	UseHTTPLocationTags bool // True for rest-json/rest-xml protocols (add header/query/uri/payload tags)
 	HasUnixTimestamp    bool // True if UnixTimestamp type is needed (JSON protocols with timestamps)
 	Types               []GoType
-	Enums               []GoEnum // Enum type aliases to generate
+	Enums               []GoEnum            // Enum type aliases to generate
+	ResponseOperations  []ResponseOperation // Operations mapped to their generated response types
+	ResponseWrappers    []ResponseWrapper   // {Operation}Response envelopes (query protocol, opt-in)
+	ResponseMetadata    string              // Name of the ResponseMetadata type used by ResponseWrappers
+}
+
+// ResponseWrapper describes the <{Operation}Response> envelope around a query protocol result
+type ResponseWrapper struct {
+	Name          string // Go type name, e.g. "CreateUserResponseEnvelope"
+	ElementName   string // XML root element, e.g. "CreateUserResponse"
+	ResultType    string // Generated result type, e.g. "CreateUserResult"
+	ResultElement string // XML element of the result, e.g. "CreateUserResult"
+}
+
+// ResponseOperation maps an operation to the generated type of its output shape
+type ResponseOperation struct {
+	Operation string
+	TypeName  string
 }
 
 // GoEnum represents an enum type alias
@@ -331,6 +379,14 @@ func (g *Generator) generateCode(model *smithy.Model, typeNames []string) (strin
 					// so the generated type needs {Operation}Result as its XMLName
 					goType.ResponseElementName = opName + "Result"
 					data.HasXMLImport = true
+					if g.config.GenerateResponseWrappers {
+						data.ResponseWrappers = append(data.ResponseWrappers, ResponseWrapper{
+							Name:          opName + "ResponseEnvelope" + g.config.TypeSuffix,
+							ElementName:   opName + "Response",
+							ResultType:    goType.Name,
+							ResultElement: goType.ResponseElementName,
+						})
+					}
 				}
 			}
 		}
@@ -447,6 +503,32 @@ func (g *Generator) generateCode(model *smithy.Model, typeNames []string) (strin
 		data.Types = append(data.Types, goType)
 	}
 
+	// Map every operation to its generated response type. Output shapes can be shared by
+	// several operations, so this is built from the operations rather than outputToOperation.
+	// Outputs with an httpPayload member (e.g. S3 GetObject, Lambda Invoke) return a raw body
+	// rather than a serialized document, so they can't be validated and are left out.
+	generatedResponses := make(map[string]string)
+	for _, goType := range data.Types {
+		if goType.IsResponse && !hasPayloadField(goType) {
+			generatedResponses[strings.TrimSuffix(goType.Name, g.config.TypeSuffix)] = goType.Name
+		}
+	}
+	for opName, op := range g.parser.GetOperations() {
+		if typeName, ok := generatedResponses[op.OutputShape]; ok {
+			data.ResponseOperations = append(data.ResponseOperations, ResponseOperation{Operation: opName, TypeName: typeName})
+		}
+	}
+	sort.Slice(data.ResponseOperations, func(i, j int) bool {
+		return data.ResponseOperations[i].Operation < data.ResponseOperations[j].Operation
+	})
+
+	if len(data.ResponseWrappers) > 0 {
+		data.ResponseMetadata = "ResponseMetadata" + g.config.TypeSuffix
+		sort.Slice(data.ResponseWrappers, func(i, j int) bool {
+			return data.ResponseWrappers[i].Name < data.ResponseWrappers[j].Name
+		})
+	}
+
 	// Convert enum set to sorted slice
 	for enumName := range enumSet {
 		data.Enums = append(data.Enums, GoEnum{Name: enumName})
@@ -463,10 +545,16 @@ func (g *Generator) generateCode(model *smithy.Model, typeNames []string) (strin
 		return data.Types[i].Name < data.Types[j].Name
 	})
 
-	// Execute template
-	tmpl, err := template.New("smithy_types.go.tmpl").Funcs(template.FuncMap{
+	g.data = &data
+
+	return g.renderTemplate("smithy_types.go.tmpl", &data)
+}
+
+// renderTemplate executes the named template with data and formats the result
+func (g *Generator) renderTemplate(name string, data *TemplateData) (string, error) {
+	tmpl, err := template.New(name).Funcs(template.FuncMap{
 		"toLower": strings.ToLower,
-	}).ParseFS(templateFS, "templates/smithy_types.go.tmpl")
+	}).ParseFS(templateFS, "templates/"+name)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse template: %w", err)
 	}
@@ -561,6 +649,16 @@ func shouldUsePointer(goType string, isEnum bool) bool {
 	return true
 }
 
+// hasPayloadField reports whether the type binds a member to the HTTP body
+func hasPayloadField(goType GoType) bool {
+	for _, field := range goType.Fields {
+		if field.IsPayload {
+			return true
+		}
+	}
+	return false
+}
+
 // cleanDocumentation cleans up documentation strings
 func cleanDocumentation(doc string) string {
 	if doc == "" {
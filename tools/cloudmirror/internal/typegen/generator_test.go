@@ -225,6 +225,69 @@ func TestGenerator_GenerateToFile(t *testing.T) {
 	assert.Contains(t, string(content), "type Vpc struct")
 }
 
+func TestGenerator_GenerateToFileWithTests(t *testing.T) {
+	modelPath := createTestModelFile(t)
+	outputPath := filepath.Join(t.TempDir(), "output", "smithy_types.go")
+
+	config := &Config{
+		ServiceName:   "test",
+		PackageName:   "test",
+		ModelPath:     modelPath,
+		OutputPath:    outputPath,
+		ResponseOnly:  true,
+		GenerateTests: true,
+	}
+
+	generator := NewGenerator(config)
+	err := generator.GenerateToFile()
+	require.NoError(t, err)
+
+	testPath := filepath.Join(filepath.Dir(outputPath), "smithy_types_generated_test.go")
+	assert.Equal(t, testPath, TestOutputPath(outputPath))
+
+	content, err := os.ReadFile(testPath)
+	require.NoError(t, err)
+	code := string(content)
+
+	assert.Contains(t, code, "package test")
+	assert.Contains(t, code, "func TestSmithyTypesRoundTrip(t *testing.T)")
+	assert.Contains(t, code, `"encoding/xml"`)
+	assert.NotContains(t, code, `"encoding/json"`)
+
+	// Every generated type has a round-trip case
+	assert.Contains(t, code, `{"DescribeVpcsResult", func() interface{} { return &DescribeVpcsResult{} }}`)
+	assert.Contains(t, code, `{"Vpc", func() interface{} { return &Vpc{} }}`)
+	assert.Contains(t, code, `{"Tag", func() interface{} { return &Tag{} }}`)
+}
+
+func TestGenerator_GenerateTest_JSONProtocol(t *testing.T) {
+	modelPath := createTestModelFileWithHTTP(t)
+
+	config := &Config{
+		ServiceName:   "test",
+		PackageName:   "test",
+		ModelPath:     modelPath,
+		IncludeInputs: true,
+	}
+
+	generator := NewGenerator(config)
+	_, err := generator.Generate()
+	require.NoError(t, err)
+
+	code, err := generator.GenerateTest()
+	require.NoError(t, err)
+
+	assert.Contains(t, code, `"encoding/json"`)
+	assert.NotContains(t, code, `"encoding/xml"`)
+	assert.Contains(t, code, `const smithyTagKey = "json"`)
+}
+
+func TestGenerator_GenerateTest_RequiresGenerate(t *testing.T) {
+	generator := NewGenerator(&Config{PackageName: "test"})
+	_, err := generator.GenerateTest()
+	assert.Error(t, err)
+}
+
 func TestCleanDocumentation(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1147,6 +1210,100 @@ func TestGenerator_HTTPLocationTags_RESTJSON(t *testing.T) {
 	assert.Contains(t, code, `json:"-"`, "payload fields should have json:\"-\"")
 }
 
+func TestGenerator_ResponseTypes(t *testing.T) {
+	config := &Config{
+		ServiceName: "test",
+		PackageName: "test",
+		ModelPath:   createTestModelFile(t),
+	}
+	code, err := NewGenerator(config).Generate()
+	require.NoError(t, err)
+
+	// Operations are keyed by name and map to the type generated for their output shape
+	assert.Contains(t, code, "var smithyResponseTypes = map[string]func() interface{}{")
+	assert.Contains(t, code, `"DescribeVpcs": func() interface{} { return &DescribeVpcsResult{} },`)
+
+	// Outputs bound to the HTTP body can't be validated, so they're left out
+	config = &Config{
+		ServiceName: "test",
+		PackageName: "test",
+		Protocol:    "rest-json",
+		ModelPath:   createTestModelFileWithHTTP(t),
+	}
+	code, err = NewGenerator(config).Generate()
+	require.NoError(t, err)
+	assert.Contains(t, code, "type InvokeResponse struct")
+	assert.NotContains(t, code, "smithyResponseTypes")
+}
+
+func TestGenerator_ResponseWrappers(t *testing.T) {
+	config := &Config{
+		ServiceName:              "test",
+		PackageName:              "test",
+		Protocol:                 "query",
+		ModelPath:                createTestModelFileWithValidation(t),
+		GenerateResponseWrappers: true,
+	}
+	code, err := NewGenerator(config).Generate()
+	require.NoError(t, err)
+
+	assert.Contains(t, code, "type ResponseMetadata struct {")
+	assert.Contains(t, code, "RequestId *string `xml:\"RequestId,omitempty\"`")
+	assert.Contains(t, code, "type CreateUserResponseEnvelope struct {")
+	assert.Regexp(t, `XMLName\s+xml\.Name\s+`+"`"+`xml:"CreateUserResponse"`, code)
+	assert.Regexp(t, `Result\s+\*CreateUserResult\s+`+"`"+`xml:"CreateUserResult"`, code)
+	assert.Regexp(t, `ResponseMetadata\s+ResponseMetadata\s+`+"`"+`xml:"ResponseMetadata"`, code)
+
+	// Wrappers are opt-in
+	config.GenerateResponseWrappers = false
+	code, err = NewGenerator(config).Generate()
+	require.NoError(t, err)
+	assert.NotContains(t, code, "ResponseEnvelope")
+	assert.NotContains(t, code, "type ResponseMetadata struct")
+}
+
+// Test that a generated response envelope nests the result and metadata like a query protocol response
+func TestGeneratedTypes_ResponseWrapperSerialization(t *testing.T) {
+	// Simulate the generated types
+	type CreateUserResult struct {
+		XMLName  xml.Name `xml:"CreateUserResult"`
+		UserName *string  `xml:"UserName,omitempty"`
+	}
+
+	type ResponseMetadata struct {
+		RequestId *string `xml:"RequestId,omitempty"`
+	}
+
+	type CreateUserResponseEnvelope struct {
+		XMLName          xml.Name          `xml:"CreateUserResponse"`
+		Xmlns            string            `xml:"xmlns,attr,omitempty"`
+		Result           *CreateUserResult `xml:"CreateUserResult"`
+		ResponseMetadata ResponseMetadata  `xml:"ResponseMetadata"`
+	}
+
+	userName := "alice"
+	requestID := "req-123"
+	envelope := CreateUserResponseEnvelope{
+		Xmlns:            "https://iam.amazonaws.com/doc/2010-05-08/",
+		Result:           &CreateUserResult{UserName: &userName},
+		ResponseMetadata: ResponseMetadata{RequestId: &requestID},
+	}
+
+	data, err := xml.Marshal(envelope)
+	require.NoError(t, err)
+	assert.Equal(t, `<CreateUserResponse xmlns="https://iam.amazonaws.com/doc/2010-05-08/">`+
+		`<CreateUserResult><UserName>alice</UserName></CreateUserResult>`+
+		`<ResponseMetadata><RequestId>req-123</RequestId></ResponseMetadata>`+
+		`</CreateUserResponse>`, string(data))
+
+	var decoded CreateUserResponseEnvelope
+	require.NoError(t, xml.Unmarshal(data, &decoded))
+	require.NotNil(t, decoded.Result)
+	assert.Equal(t, "alice", *decoded.Result.UserName)
+	require.NotNil(t, decoded.ResponseMetadata.RequestId)
+	assert.Equal(t, "req-123", *decoded.ResponseMetadata.RequestId)
+}
+
 func TestGenerator_NoHTTPTags_QueryProtocol(t *testing.T) {
 	// Query protocol should NOT have HTTP location tags
 	modelPath := createTestModelFile(t) // Uses ec2Query protocol
@@ -1168,3 +1325,206 @@ func TestGenerator_NoHTTPTags_QueryProtocol(t *testing.T) {
 	assert.NotContains(t, code, `query:"`, "Query protocol should not have query tags")
 	assert.NotContains(t, code, `payload:"`, "Query protocol should not have payload tags")
 }
+
+// Test model with XML serialization traits (rest-xml protocol)
+const generatorTestModelWithXMLTraits = `{
+	"smithy": "2.0",
+	"shapes": {
+		"com.amazonaws.test#TestService": {
+			"type": "service",
+			"traits": {
+				"aws.api#service": { "sdkId": "Test" },
+				"aws.protocols#restXml": {}
+			}
+		},
+		"com.amazonaws.test#ListObjects": {
+			"type": "operation",
+			"input": { "target": "com.amazonaws.test#ListObjectsRequest" },
+			"output": { "target": "com.amazonaws.test#ListObjectsOutput" }
+		},
+		"com.amazonaws.test#ListObjectsRequest": {
+			"type": "structure",
+			"members": {}
+		},
+		"com.amazonaws.test#ListObjectsOutput": {
+			"type": "structure",
+			"members": {
+				"Contents": {
+					"target": "com.amazonaws.test#ObjectList",
+					"traits": { "smithy.api#xmlFlattened": {} }
+				},
+				"Items": {
+					"target": "com.amazonaws.test#ItemList",
+					"traits": {
+						"smithy.api#xmlName": "item",
+						"smithy.api#xmlFlattened": {}
+					}
+				},
+				"Tags": {
+					"target": "com.amazonaws.test#TagList",
+					"traits": { "smithy.api#xmlName": "tagSet" }
+				},
+				"Owner": {
+					"target": "com.amazonaws.test#Grantee"
+				}
+			},
+			"traits": { "smithy.api#output": {} }
+		},
+		"com.amazonaws.test#Grantee": {
+			"type": "structure",
+			"members": {
+				"ID": { "target": "smithy.api#String" },
+				"Type": {
+					"target": "smithy.api#String",
+					"traits": {
+						"smithy.api#xmlAttribute": {},
+						"smithy.api#xmlName": "xsi:type"
+					}
+				},
+				"Encoding": {
+					"target": "smithy.api#String",
+					"traits": {
+						"smithy.api#xmlAttribute": {},
+						"smithy.api#xmlName": "encoding"
+					}
+				}
+			}
+		},
+		"com.amazonaws.test#Object": {
+			"type": "structure",
+			"members": {
+				"Key": { "target": "smithy.api#String" }
+			}
+		},
+		"com.amazonaws.test#Tag": {
+			"type": "structure",
+			"members": {
+				"Key": { "target": "smithy.api#String" },
+				"Value": { "target": "smithy.api#String" }
+			}
+		},
+		"com.amazonaws.test#ObjectList": {
+			"type": "list",
+			"member": { "target": "com.amazonaws.test#Object" }
+		},
+		"com.amazonaws.test#ItemList": {
+			"type": "list",
+			"member": {
+				"target": "smithy.api#String",
+				"traits": { "smithy.api#xmlName": "member" }
+			}
+		},
+		"com.amazonaws.test#TagList": {
+			"type": "list",
+			"member": {
+				"target": "com.amazonaws.test#Tag",
+				"traits": { "smithy.api#xmlName": "item" }
+			}
+		}
+	}
+}`
+
+func createTestModelFileWithXMLTraits(t *testing.T) string {
+	tmpDir := t.TempDir()
+	modelPath := filepath.Join(tmpDir, "test-model-xml-traits.json")
+	err := os.WriteFile(modelPath, []byte(generatorTestModelWithXMLTraits), 0644)
+	require.NoError(t, err)
+	return modelPath
+}
+
+func TestGenerator_FlattenedLists(t *testing.T) {
+	modelPath := createTestModelFileWithXMLTraits(t)
+
+	config := &Config{
+		ServiceName:  "test",
+		PackageName:  "test",
+		ModelPath:    modelPath,
+		ResponseOnly: true,
+	}
+
+	generator := NewGenerator(config)
+	code, err := generator.Generate()
+	require.NoError(t, err)
+
+	// Flattened lists repeat the member element directly, without a wrapper
+	assert.Contains(t, code, `xml:"Contents,omitempty"`)
+	assert.Contains(t, code, `xml:"item,omitempty"`, "flattened list should use the member's xmlName")
+	assert.NotContains(t, code, `xml:"item>member`, "flattened list should ignore the list member's xmlName")
+	assert.NotContains(t, code, `xml:"Contents>`)
+
+	// Lists without the trait keep their wrapper element
+	assert.Contains(t, code, `xml:"tagSet>item,omitempty"`)
+}
+
+// Test that flattened list tags deserialize repeated elements without a wrapper
+func TestGeneratedTypes_FlattenedListDeserialization(t *testing.T) {
+	type Object struct {
+		Key string `xml:"Key"`
+	}
+
+	type ListObjectsOutput struct {
+		Contents []Object `xml:"Contents,omitempty"`
+		Items    []string `xml:"item,omitempty"`
+	}
+
+	awsXML := `<ListObjectsOutput>
+		<Contents><Key>a.txt</Key></Contents>
+		<Contents><Key>b.txt</Key></Contents>
+		<item>one</item>
+		<item>two</item>
+	</ListObjectsOutput>`
+
+	var output ListObjectsOutput
+	err := xml.Unmarshal([]byte(awsXML), &output)
+	require.NoError(t, err)
+
+	require.Len(t, output.Contents, 2)
+	assert.Equal(t, "b.txt", output.Contents[1].Key)
+	assert.Equal(t, []string{"one", "two"}, output.Items)
+}
+
+func TestGenerator_XMLAttributes(t *testing.T) {
+	modelPath := createTestModelFileWithXMLTraits(t)
+
+	config := &Config{
+		ServiceName:  "test",
+		PackageName:  "test",
+		ModelPath:    modelPath,
+		ResponseOnly: true,
+	}
+
+	generator := NewGenerator(config)
+	code, err := generator.Generate()
+	require.NoError(t, err)
+
+	// @xmlAttribute members become attributes on the enclosing element
+	assert.Contains(t, code, `xml:"encoding,attr"`)
+	assert.Contains(t, code, `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr"`, "xsi prefix should resolve to its namespace")
+	assert.NotContains(t, code, `xml:"xsi:type"`)
+
+	// Other members remain elements
+	assert.Contains(t, code, `xml:"ID,omitempty"`)
+}
+
+// Test that attribute tags deserialize AWS-style attributes, including namespaced ones
+func TestGeneratedTypes_XMLAttributeDeserialization(t *testing.T) {
+	type Grantee struct {
+		ID       *string `xml:"ID,omitempty"`
+		Type     string  `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr"`
+		Encoding *string `xml:"encoding,attr"`
+	}
+
+	awsXML := `<Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CanonicalUser" encoding="url">
+		<ID>owner-id</ID>
+	</Grantee>`
+
+	var grantee Grantee
+	err := xml.Unmarshal([]byte(awsXML), &grantee)
+	require.NoError(t, err)
+
+	assert.Equal(t, "CanonicalUser", grantee.Type)
+	require.NotNil(t, grantee.Encoding)
+	assert.Equal(t, "url", *grantee.Encoding)
+	require.NotNil(t, grantee.ID)
+	assert.Equal(t, "owner-id", *grantee.ID)
+}